@@ -0,0 +1,68 @@
+#!/bin/bash
+# SQLite Driver Benchmark Comparison Script
+#
+# Compares the pure-Go modernc.org/sqlite driver (default build) against
+# mattn/go-sqlite3 (sqlite_cgo build tag) on the heavy match_repo and
+# card_performance_repo analytics queries.
+#
+# Prerequisites:
+#   - Go 1.25 or later
+#   - A C toolchain for the cgo build
+#   - benchstat: go install golang.org/x/perf/cmd/benchstat@latest
+#
+# Usage:
+#   ./run_sqlite_driver_comparison.sh [count]
+#
+# Arguments:
+#   count: Number of benchmark runs (default: 5)
+
+set -e
+
+COUNT=${1:-5}
+RESULTS_DIR="benchmark_results"
+TIMESTAMP=$(date +%Y%m%d_%H%M%S)
+
+# Colors for output
+GREEN='\033[0;32m'
+BLUE='\033[0;34m'
+YELLOW='\033[1;33m'
+NC='\033[0m' # No Color
+
+echo -e "${BLUE}=== SQLite Driver Benchmark Comparison ===${NC}"
+echo "Go version: $(go version)"
+echo "Benchmark runs: $COUNT"
+echo ""
+
+# Create results directory
+mkdir -p "$RESULTS_DIR"
+
+# Check for benchstat
+if ! command -v benchstat &> /dev/null; then
+    echo -e "${YELLOW}Installing benchstat...${NC}"
+    go install golang.org/x/perf/cmd/benchstat@latest
+fi
+
+# Run with the pure-Go driver
+echo -e "${GREEN}Running modernc.org/sqlite (CGO_ENABLED=0)...${NC}"
+CGO_ENABLED=0 go test -run='^$' -bench="BenchmarkSQLite" -benchmem -count="$COUNT" ./benchmarks/... > "$RESULTS_DIR/sqlite_modernc_$TIMESTAMP.txt" 2>&1
+echo "Results saved to: $RESULTS_DIR/sqlite_modernc_$TIMESTAMP.txt"
+echo ""
+
+# Run with the cgo driver
+echo -e "${GREEN}Running mattn/go-sqlite3 (-tags sqlite_cgo)...${NC}"
+CGO_ENABLED=1 go test -tags sqlite_cgo -run='^$' -bench="BenchmarkSQLite" -benchmem -count="$COUNT" ./benchmarks/... > "$RESULTS_DIR/sqlite_mattn_$TIMESTAMP.txt" 2>&1
+echo "Results saved to: $RESULTS_DIR/sqlite_mattn_$TIMESTAMP.txt"
+echo ""
+
+# Compare results
+echo -e "${BLUE}=== Comparison (modernc vs mattn) ===${NC}"
+echo ""
+benchstat "$RESULTS_DIR/sqlite_modernc_$TIMESTAMP.txt" "$RESULTS_DIR/sqlite_mattn_$TIMESTAMP.txt"
+benchstat "$RESULTS_DIR/sqlite_modernc_$TIMESTAMP.txt" "$RESULTS_DIR/sqlite_mattn_$TIMESTAMP.txt" > "$RESULTS_DIR/sqlite_comparison_$TIMESTAMP.txt"
+
+echo ""
+echo -e "${GREEN}Done!${NC}"
+echo ""
+echo "Benchstat compares modernc (baseline) vs mattn (new):"
+echo "  - Negative delta: mattn is faster than modernc"
+echo "  - Positive delta: mattn is slower than modernc"
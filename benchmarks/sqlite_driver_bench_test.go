@@ -0,0 +1,223 @@
+package benchmarks
+
+// SQLite driver benchmarks for the heavy analytics queries in match_repo and
+// card_performance_repo.
+//
+// The driver is selected at build time (see internal/storage/sqlitedriver), so
+// the comparison runs the same benchmarks twice:
+//
+//	CGO_ENABLED=0 go test -bench=BenchmarkSQLite -benchmem -count=5 ./benchmarks/... > modernc.txt
+//	go test -tags sqlite_cgo -bench=BenchmarkSQLite -benchmem -count=5 ./benchmarks/... > mattn.txt
+//	benchstat modernc.txt mattn.txt
+//
+// Or use ./benchmarks/run_sqlite_driver_comparison.sh.
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
+)
+
+const (
+	benchMatches       = 500
+	benchGamesPerMatch = 2
+	benchPlaysPerGame  = 40
+	benchDeckID        = "bench-deck"
+)
+
+var (
+	benchDBOnce sync.Once
+	benchDB     *storage.DB
+	benchDBDir  string
+	benchDBErr  error
+)
+
+// TestMain removes the shared benchmark database after all benchmarks finish.
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if benchDB != nil {
+		_ = benchDB.Close()
+	}
+	if benchDBDir != "" {
+		_ = os.RemoveAll(benchDBDir)
+	}
+	os.Exit(code)
+}
+
+// openBenchDB creates and seeds a migrated on-disk database shared by all
+// driver benchmarks in the process.
+func openBenchDB(b *testing.B) *storage.DB {
+	b.Helper()
+
+	benchDBOnce.Do(func() {
+		benchDBDir, benchDBErr = os.MkdirTemp("", "mtga-sqlite-bench-")
+		if benchDBErr != nil {
+			return
+		}
+		cfg := storage.DefaultConfig(filepath.Join(benchDBDir, "bench.db"))
+		cfg.AutoMigrate = true
+		benchDB, benchDBErr = storage.Open(cfg)
+		if benchDBErr != nil {
+			return
+		}
+		benchDBErr = seedBenchDB(context.Background(), benchDB)
+	})
+
+	if benchDBErr != nil {
+		b.Fatalf("failed to prepare benchmark database (%s): %v", sqlitedriver.Implementation, benchDBErr)
+	}
+	return benchDB
+}
+
+func seedBenchDB(ctx context.Context, db *storage.DB) error {
+	svc := storage.NewService(db)
+	conn := db.Conn()
+	matches := repository.NewMatchRepository(conn)
+	plays := repository.NewGamePlayRepository(conn)
+
+	deckID := benchDeckID
+	start := time.Now().Add(-90 * 24 * time.Hour)
+
+	deck := &models.Deck{
+		ID:         deckID,
+		AccountID:  svc.CurrentAccountID(),
+		Name:       "Benchmark Deck",
+		Format:     "Standard",
+		Source:     "constructed",
+		CreatedAt:  start,
+		ModifiedAt: start,
+	}
+	if err := repository.NewDeckRepository(conn).Create(ctx, deck); err != nil {
+		return err
+	}
+
+	for i := 0; i < benchMatches; i++ {
+		result := "loss"
+		if i%5 < 3 {
+			result = "win"
+		}
+		match := &models.Match{
+			ID:        fmt.Sprintf("bench-match-%d", i),
+			AccountID: svc.CurrentAccountID(),
+			EventID:   "Ladder",
+			EventName: "Ladder",
+			Timestamp: start.Add(time.Duration(i) * time.Hour),
+			DeckID:    &deckID,
+			Format:    "Ladder",
+			Result:    result,
+		}
+		if result == "win" {
+			match.PlayerWins = 2
+		} else {
+			match.OpponentWins = 2
+		}
+		if err := matches.Create(ctx, match); err != nil {
+			return err
+		}
+
+		for g := 1; g <= benchGamesPerMatch; g++ {
+			game := &models.Game{MatchID: match.ID, GameNumber: g, Result: result}
+			if err := matches.CreateGame(ctx, game); err != nil {
+				return err
+			}
+
+			batch := make([]*models.GamePlay, 0, benchPlaysPerGame)
+			for p := 0; p < benchPlaysPerGame; p++ {
+				cardID := 90000 + (i+p)%60
+				name := fmt.Sprintf("Bench Card %d", cardID)
+				action, zoneTo := "play_card", "battlefield"
+				if p%3 == 0 {
+					action, zoneTo = "draw", "hand"
+				}
+				batch = append(batch, &models.GamePlay{
+					GameID:         game.ID,
+					MatchID:        match.ID,
+					TurnNumber:     p/4 + 1,
+					Phase:          "Main1",
+					PlayerType:     "player",
+					ActionType:     action,
+					CardID:         &cardID,
+					CardName:       &name,
+					ZoneTo:         &zoneTo,
+					Timestamp:      match.Timestamp.Add(time.Duration(p) * time.Second),
+					SequenceNumber: p,
+				})
+			}
+			if err := plays.CreatePlays(ctx, batch); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func BenchmarkSQLiteMatchGetStats(b *testing.B) {
+	repo := repository.NewMatchRepository(openBenchDB(b).Conn())
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetStats(ctx, models.StatsFilter{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSQLiteMatchGetStatsByDeck(b *testing.B) {
+	repo := repository.NewMatchRepository(openBenchDB(b).Conn())
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetStatsByDeck(ctx, models.StatsFilter{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSQLiteMatchGetPerformanceMetrics(b *testing.B) {
+	repo := repository.NewMatchRepository(openBenchDB(b).Conn())
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetPerformanceMetrics(ctx, models.StatsFilter{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSQLiteCardPerformance(b *testing.B) {
+	repo := repository.NewCardPerformanceRepository(openBenchDB(b).Conn())
+	ctx := context.Background()
+	filter := models.CardPerformanceFilter{DeckID: benchDeckID}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetCardPerformance(ctx, filter); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSQLiteDeckPerformanceAnalysis(b *testing.B) {
+	repo := repository.NewCardPerformanceRepository(openBenchDB(b).Conn())
+	ctx := context.Background()
+	filter := models.CardPerformanceFilter{DeckID: benchDeckID}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetDeckPerformanceAnalysis(ctx, filter); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -110,6 +110,13 @@ func main() {
 	// Create context
 	ctx := context.Background()
 
+	// Practice drafts live in memory, so ones left unfinished by the last run are orphaned
+	if deleted, err := storageService.DraftRepo().DeleteUnfinishedSimulations(ctx); err != nil {
+		log.Printf("Warning: Failed to clean up unfinished practice drafts: %v", err)
+	} else if deleted > 0 {
+		log.Printf("Removed %d unfinished practice drafts", deleted)
+	}
+
 	// Initialize card services
 	scryfallClient := scryfall.NewClient()
 
@@ -140,8 +147,8 @@ func main() {
 		log.Fatalf("Failed to initialize card service: %v", err)
 	}
 
-	// Initialize DeckImportParser
-	deckImportParser := deckimport.NewParser(cardService)
+	// Initialize DeckImportParser, resolving card names against SetCardRepo
+	deckImportParser := deckimport.NewParser(storageService.SetCardRepo())
 
 	// Initialize DeckExporter with a CardProvider
 	deckExporter := deckexport.NewExporter(cardService)
@@ -154,6 +161,23 @@ func main() {
 	// Initialize meta service
 	metaService := meta.NewService(nil)
 
+	// Initialize shared services
+	services := &gui.Services{
+		Context:              ctx,
+		Storage:              storageService,
+		DaemonPort:           *daemonPort,
+		DraftMetrics:         metrics.NewDraftMetrics(),
+		MetaService:          metaService,
+		SetFetcher:           setFetcher,
+		RatingsFetcher:       ratingsFetcher,
+		CardService:          cardService,
+		DatasetService:       datasetService,
+		DeckImportParser:     deckImportParser,
+		DeckURLImporter:      deckimport.NewURLImporter(deckImportParser),
+		DeckExporter:         deckExporter,
+		RecommendationEngine: recommendationEngine,
+	}
+
 	// Create and start daemon if enabled
 	var daemonService *daemon.Service
 	if *enableDaemon {
@@ -165,27 +189,13 @@ func main() {
 		daemonConfig.DBPath = finalDBPath
 
 		daemonService = daemon.New(daemonConfig, storageService)
+		daemonService.SetDraftRatings(services.DraftRatings())
+		daemonService.SetOpponentInferrer(services.OpponentInferrer())
 		if err := daemonService.Start(); err != nil {
 			log.Fatalf("Failed to start daemon: %v", err)
 		}
 		fmt.Printf("Daemon started on port %d\n", *daemonPort)
-	}
-
-	// Initialize shared services
-	services := &gui.Services{
-		Context:              ctx,
-		Storage:              storageService,
-		DaemonPort:           *daemonPort,
-		DraftMetrics:         metrics.NewDraftMetrics(),
-		MetaService:          metaService,
-		SetFetcher:           setFetcher,
-		RatingsFetcher:       ratingsFetcher,
-		CardService:          cardService,
-		DatasetService:       datasetService,
-		DeckImportParser:     deckImportParser,
-		DeckExporter:         deckExporter,
-		RecommendationEngine: recommendationEngine,
-		DaemonService:        daemonService,
+		services.DaemonService = daemonService
 	}
 
 	// Create facades
@@ -138,6 +138,12 @@ func main() {
 		return
 	}
 
+	// Check if this is a doctor command
+	if len(os.Args) > 1 && os.Args[1] == "doctor" {
+		runDoctorCommand()
+		return
+	}
+
 	// Check if this is a service command
 	if len(os.Args) > 1 && os.Args[1] == "service" {
 		runServiceCommand()
@@ -171,6 +177,7 @@ func printUsage() {
 	fmt.Println("  service    - Manage daemon as system service (install/start/stop/status/uninstall)")
 	fmt.Println("  migrate    - Run database migrations")
 	fmt.Println("  backup     - Create database backup")
+	fmt.Println("  doctor     - Check database integrity and repair safe issues")
 	fmt.Println("  replay     - Replay historical log files for testing")
 	fmt.Println()
 	fmt.Println("Examples:")
@@ -179,6 +186,7 @@ func printUsage() {
 	fmt.Println("  mtga-companion service start")
 	fmt.Println("  mtga-companion migrate up")
 	fmt.Println("  mtga-companion backup create")
+	fmt.Println("  mtga-companion doctor --fix")
 	fmt.Println()
 	fmt.Println("For more information, see: https://github.com/RdHamilton/MTGA-Companion")
 	fmt.Println()
@@ -754,6 +762,112 @@ func printBackupUsage() {
 	fmt.Println()
 }
 
+// runDoctorCommand checks the database for integrity problems and optionally repairs them.
+// Exits with status 1 if any error or warning findings remain.
+func runDoctorCommand() {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	dbPath := fs.String("db-path", "", "Database path (default: ~/.mtga-companion/mtga.db)")
+	fix := fs.Bool("fix", false, "Apply safe automatic repairs")
+	format := fs.String("format", "table", "Output format: 'table' or 'json'")
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing doctor flags: %v\n", err)
+		os.Exit(1)
+	}
+	if *format != "table" && *format != "json" {
+		log.Fatalf("Invalid format: %s (must be 'table' or 'json')", *format)
+	}
+
+	finalDBPath := *dbPath
+	if finalDBPath == "" {
+		finalDBPath = getDBPath()
+	}
+	if _, err := os.Stat(finalDBPath); os.IsNotExist(err) {
+		log.Fatalf("Database file does not exist: %s", finalDBPath)
+	}
+
+	db, err := storage.Open(storage.DefaultConfig(finalDBPath))
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Printf("Error closing database: %v", err)
+		}
+	}()
+
+	opts := storage.DefaultDoctorOptions()
+	opts.Fix = *fix
+
+	report, err := storage.NewService(db).RunDoctor(context.Background(), opts)
+	if err != nil {
+		log.Fatalf("Error running doctor: %v", err)
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Error encoding JSON: %v", err)
+		}
+	} else {
+		printDoctorReport(finalDBPath, report)
+	}
+
+	if !report.Healthy() {
+		os.Exit(1)
+	}
+}
+
+// printDoctorReport prints a doctor report as a human-readable checklist.
+func printDoctorReport(dbPath string, report *storage.DoctorReport) {
+	fmt.Println("MTGA Companion - Database Doctor")
+	fmt.Println("================================")
+	fmt.Printf("Database: %s\n\n", dbPath)
+
+	fixable := 0
+	for _, f := range report.Findings {
+		if f.OK() {
+			fmt.Printf("✓ %s\n", f.Description)
+			continue
+		}
+
+		marker := "!"
+		if f.Severity == storage.DoctorSeverityError {
+			marker = "✗"
+		}
+		fmt.Printf("%s %s: %d [%s]\n", marker, f.Description, f.Count, f.Severity)
+		for _, sample := range f.Samples {
+			fmt.Printf("    - %s\n", sample)
+		}
+		if f.Count > len(f.Samples) && len(f.Samples) > 0 {
+			fmt.Printf("    ... and %d more\n", f.Count-len(f.Samples))
+		}
+
+		switch {
+		case f.FixError != "":
+			fmt.Printf("    Fix failed: %s\n", f.FixError)
+		case f.Fixed > 0:
+			fmt.Printf("    Fixed %d\n", f.Fixed)
+		case f.Fixable:
+			fixable++
+		case f.Hint != "":
+			fmt.Printf("    Hint: %s\n", f.Hint)
+		}
+	}
+
+	fmt.Println()
+	if report.FixSkipped != "" {
+		fmt.Printf("Fixes not applied: %s\n", report.FixSkipped)
+	}
+	if fixable > 0 && !report.FixRequested {
+		fmt.Printf("%d issue(s) can be repaired automatically. Run 'mtga-companion doctor --fix' (back up first with 'mtga-companion backup create').\n", fixable)
+	}
+	if report.Healthy() {
+		fmt.Println("Database is healthy.")
+	}
+}
+
 // runBackupCommandInteractive handles backup commands from the interactive console.
 func runDaemonCommand() {
 	fs := flag.NewFlagSet("daemon", flag.ExitOnError)
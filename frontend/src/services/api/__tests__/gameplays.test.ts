@@ -7,7 +7,7 @@ vi.mock('../../apiClient', () => ({
   post: vi.fn(),
 }));
 
-import { get } from '../../apiClient';
+import { get, post } from '../../apiClient';
 
 describe('gameplays API', () => {
   beforeEach(() => {
@@ -79,6 +79,10 @@ describe('gameplays API', () => {
         land_drops: 8,
         total_turns: 12,
         opponent_cards_seen: 15,
+        damage_dealt: 20,
+        damage_taken: 14,
+        life_gained: 3,
+        opponent_life_gained: 0,
       };
       vi.mocked(get).mockResolvedValue(mockSummary);
 
@@ -89,6 +93,70 @@ describe('gameplays API', () => {
     });
   });
 
+  describe('getMatchLifeChanges', () => {
+    it('should call get with correct path', async () => {
+      const mockChanges: gameplays.LifeChange[] = [
+        {
+          id: 1,
+          match_id: 'match-123',
+          game_number: 1,
+          turn_number: 4,
+          phase: 'Combat',
+          step: 'CombatDamage',
+          affected_player: 'opponent',
+          amount: -3,
+          life_after: 17,
+          change_type: 'combat',
+          source_card_id: 12345,
+          source_controller: 'player',
+          annotation_id: 50,
+          timestamp: '2024-01-15T10:00:00Z',
+        },
+      ];
+      vi.mocked(get).mockResolvedValue(mockChanges);
+
+      const result = await gameplays.getMatchLifeChanges('match-123');
+
+      expect(get).toHaveBeenCalledWith('/matches/match-123/life-changes');
+      expect(result).toEqual(mockChanges);
+    });
+  });
+
+  describe('getDeckDamageByTurn', () => {
+    it('should call get with correct path', async () => {
+      vi.mocked(get).mockResolvedValue([]);
+
+      await gameplays.getDeckDamageByTurn('deck-1');
+
+      expect(get).toHaveBeenCalledWith('/gameplays/decks/deck-1/damage-by-turn');
+    });
+  });
+
+  describe('getMatchMulliganAdvice', () => {
+    it('should call get with correct path', async () => {
+      vi.mocked(get).mockResolvedValue([]);
+
+      await gameplays.getMatchMulliganAdvice('match-123');
+
+      expect(get).toHaveBeenCalledWith('/matches/match-123/mulligan-advice');
+    });
+  });
+
+  describe('getDeckMulliganAdvice', () => {
+    it('should call post with correct path and body', async () => {
+      const request: gameplays.MulliganAdviceRequest = {
+        card_ids: [1, 2, 3, 4, 5, 6, 7],
+        mulligan_count: 0,
+        on_play: true,
+      };
+      vi.mocked(post).mockResolvedValue({});
+
+      await gameplays.getDeckMulliganAdvice('deck-1', request);
+
+      expect(post).toHaveBeenCalledWith('/decks/deck-1/mulligan-advice', request);
+    });
+  });
+
   describe('getMatchOpponentCards', () => {
     it('should call get with correct path', async () => {
       const mockCards: gameplays.OpponentCard[] = [
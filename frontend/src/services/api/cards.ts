@@ -141,6 +141,41 @@ export async function refreshSetRatings(
   await post(`/cards/ratings/${setCode}/refresh`, { format });
 }
 
+/**
+ * Early-set rating model trained on previous sets' final ratings.
+ */
+export interface RatingPriorModel {
+  format: string;
+  trainedAt: string;
+  sets: string[];
+  ratedSets: string[];
+  samples: number;
+  baseline: number;
+  rmse: number;
+  bias: number;
+  weights: number[];
+}
+
+/**
+ * Get the early-set rating model for a draft format.
+ */
+export async function getRatingPriorModel(
+  format: string = 'PremierDraft'
+): Promise<RatingPriorModel> {
+  const params = new URLSearchParams({ format });
+  return get<RatingPriorModel>(`/cards/rating-priors?${params.toString()}`);
+}
+
+/**
+ * Retrain the early-set rating model for a draft format.
+ */
+export async function trainRatingPriors(
+  format: string = 'PremierDraft'
+): Promise<RatingPriorModel> {
+  const params = new URLSearchParams({ format });
+  return post<RatingPriorModel>(`/cards/rating-priors/train?${params.toString()}`);
+}
+
 // ============================================================================
 // ChannelFireball (CFB) Ratings
 // ============================================================================
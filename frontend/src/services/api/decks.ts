@@ -38,10 +38,12 @@ export interface UpdateDeckRequest {
 }
 
 /**
- * Request to import a deck.
+ * Request to import a deck from a deck list, or from a public deck page url
+ * when content is empty.
  */
 export interface ImportDeckApiRequest {
   content: string;
+  url?: string;
   name: string;
   format: string;
 }
@@ -117,6 +119,70 @@ export async function getDeckStats(deckId: string): Promise<DeckStatistics> {
   return get<DeckStatistics>(`/decks/${deckId}/stats`);
 }
 
+/**
+ * Simulated games of a deck against an empty board.
+ */
+export interface GoldfishResult {
+  iterations: number;
+  onDraw: boolean;
+  killTurns: Record<number, number>;
+  averageKillTurn: number;
+  medianKillTurn: number;
+  noKillRate: number;
+  mulliganRate: number;
+  manaScrewRate: number;
+  manaFloodRate: number;
+  averageFirstSpellTurn: number;
+  curveEfficiency: number;
+}
+
+/**
+ * A deck version's simulated speed.
+ */
+export interface PermutationGoldfish extends GoldfishResult {
+  permutationId: number;
+  versionNumber: number;
+  versionName?: string;
+  cardCount: number;
+}
+
+/**
+ * Two deck versions goldfished with the same shuffles.
+ */
+export interface PermutationGoldfishComparison {
+  from: PermutationGoldfish;
+  to: PermutationGoldfish;
+  killTurnDelta: number;
+  fasterId: number;
+}
+
+/**
+ * Goldfish a deck to estimate its speed.
+ */
+export async function getDeckGoldfish(deckId: string, iterations?: number): Promise<GoldfishResult> {
+  const query = iterations ? `?iterations=${iterations}` : '';
+  return get<GoldfishResult>(`/decks/${deckId}/goldfish${query}`);
+}
+
+/**
+ * Compare two versions of a deck by goldfishing both.
+ */
+export async function compareDeckPermutations(
+  deckId: string,
+  fromPermutationId: number,
+  toPermutationId: number,
+  iterations?: number
+): Promise<PermutationGoldfishComparison> {
+  const params = new URLSearchParams({
+    from: fromPermutationId.toString(),
+    to: toPermutationId.toString(),
+  });
+  if (iterations) {
+    params.set('iterations', iterations.toString());
+  }
+  return get<PermutationGoldfishComparison>(`/decks/${deckId}/goldfish/compare?${params.toString()}`);
+}
+
 /**
  * Get deck performance/matches.
  */
@@ -149,7 +215,7 @@ export async function exportDeck(
 }
 
 /**
- * Import a deck from text.
+ * Import a deck from text or a deck page URL.
  */
 export async function importDeck(request: ImportDeckApiRequest): Promise<ImportDeckResponse> {
   return post<ImportDeckResponse>('/decks/import', request);
@@ -706,6 +772,67 @@ export async function getCardPerformance(
   );
 }
 
+/**
+ * Win rate over the games matching one 17Lands-style condition.
+ */
+export interface WinRateMetric {
+  games: number;
+  wins: number;
+  win_rate: number;
+  adjusted: number;
+  ci_low: number;
+  ci_high: number;
+}
+
+/**
+ * In-game metrics for one card: games in hand, opening hand, drawn and not seen.
+ */
+export interface CardGameMetrics {
+  card_id: number;
+  card_name: string;
+  gih: WinRateMetric;
+  oh: WinRateMetric;
+  gd: WinRateMetric;
+  gns: WinRateMetric;
+  iwd: number;
+  avg_turn_played: number;
+  turn_played_dist: Record<number, number> | null;
+}
+
+export interface DeckCardGameMetrics {
+  deck_id: string;
+  permutation_id?: number;
+  total_games: number;
+  games_won: number;
+  cards: CardGameMetrics[];
+}
+
+/**
+ * Get GIH/OH/GD/GNS card metrics for a deck.
+ * @param deckId - The deck ID
+ * @param options - Optional deck version and land filter
+ */
+export async function getCardGameMetrics(
+  deckId: string,
+  options?: {
+    permutationId?: number;
+    includeLands?: boolean;
+  }
+): Promise<DeckCardGameMetrics> {
+  const params = new URLSearchParams();
+  if (options?.permutationId !== undefined) {
+    params.set('permutation_id', options.permutationId.toString());
+  }
+  if (options?.includeLands) {
+    params.set('include_lands', 'true');
+  }
+
+  const query = params.toString();
+  return get<DeckCardGameMetrics>(
+    `/decks/${deckId}/card-metrics${query ? `?${query}` : ''}`
+  );
+}
+
 /**
  * Get card add recommendations based on performance data.
  * @param deckId - The deck ID
@@ -3,7 +3,7 @@
  * Replaces Wails draft-related function bindings.
  */
 
-import { get, post } from '../apiClient';
+import { get, post, put, del } from '../apiClient';
 import { models, gui, grading, metrics, insights, pickquality, prediction } from '@/types/models';
 
 // Re-export types for convenience
@@ -50,6 +50,93 @@ export interface WinProbabilityRequest {
   session_id: string;
 }
 
+/**
+ * Openness reading for one color ("W") or color pair ("WU").
+ */
+export interface ColorSignal {
+  colors: string;
+  score: number;
+  openness: number; // 0-100, 50 = no signal
+  late_cards: number;
+  wheeled: number;
+  cut: number;
+}
+
+/**
+ * An above-average card seen later than 17Lands drafters usually see it.
+ */
+export interface LateCard {
+  card_id: string;
+  card_name: string;
+  colors: string;
+  pack_number: number;
+  pick_in_pack: number;
+  expected_pick: number;
+  gihwr: number;
+  wheeled: boolean;
+  evidence: number;
+}
+
+/**
+ * Color signals read from the packs seen in a draft.
+ * Also pushed as the payload of `draft:signals` events.
+ */
+export interface DraftSignals {
+  session_id: string;
+  set_code: string;
+  packs_analyzed: number;
+  colors: ColorSignal[];
+  pairs: ColorSignal[];
+  open_colors: string[];
+  late_cards: LateCard[];
+  history: { pack_number: number; pick_in_pack: number; openness: Record<string, number> }[];
+}
+
+/**
+ * Predicted vs observed match win rate for one prediction bucket.
+ */
+export interface ReliabilityBin {
+  lower: number;
+  upper: number;
+  matches: number;
+  mean_predicted: number;
+  observed: number;
+}
+
+/**
+ * The learned draft win rate model and its out-of-fold calibration.
+ */
+export interface LearnedModel {
+  version: string;
+  features: string[];
+  mean: number[];
+  scale: number[];
+  weights: number[];
+  bias: number;
+  lambda: number;
+  samples: number;
+  matches: number;
+  sources: Record<string, number>;
+  trained_at: string;
+  calibration?: {
+    folds: number;
+    brier_score: number;
+    baseline_brier: number;
+    log_loss: number;
+    accuracy: number;
+    bins: ReliabilityBin[];
+  };
+}
+
+/**
+ * Request for training the learned win rate model.
+ */
+export interface TrainPredictorRequest {
+  game_data_csv?: string;
+  set_code?: string;
+  draft_format?: string;
+}
+
 /**
  * Get draft sessions with optional filters.
  */
@@ -167,6 +254,27 @@ export async function getDraftDeckMetrics(sessionId: string): Promise<models.Dec
   return get<models.DeckMetrics>(`/drafts/${sessionId}/deck-metrics`);
 }
 
+/**
+ * Get color signals for a draft session. Null when 17Lands ratings are unavailable.
+ */
+export async function getDraftSignals(sessionId: string): Promise<DraftSignals | null> {
+  return get<DraftSignals | null>(`/drafts/${sessionId}/signals`);
+}
+
+/**
+ * Get the active learned win rate model. Null until one has been trained.
+ */
+export async function getPredictorModel(): Promise<LearnedModel | null> {
+  return get<LearnedModel | null>('/drafts/predictor');
+}
+
+/**
+ * Train the learned win rate model on completed drafts and their match results.
+ */
+export async function trainPredictor(request: TrainPredictorRequest = {}): Promise<LearnedModel> {
+  return post<LearnedModel>('/drafts/predictor/train', request);
+}
+
 /**
  * Get draft performance metrics.
  */
@@ -368,3 +476,347 @@ export async function getExportableDrafts(limit?: number): Promise<DraftSession[
   const params = limit ? `?limit=${limit}` : '';
   return get<DraftSession[]>(`/drafts/exportable${params}`);
 }
+
+/**
+ * Booster layout for a simulated draft.
+ */
+export interface PackSlot {
+  count: number;
+  rarities: Record<string, number>;
+}
+
+export interface PackConfig {
+  slots: PackSlot[];
+  print_runs?: Record<string, number>;
+}
+
+/**
+ * Request for starting a practice draft against bots.
+ */
+export interface StartSimulatedDraftRequest {
+  set_code: string;
+  draft_format?: string;
+  seed?: number;
+  pack_config?: PackConfig;
+}
+
+/**
+ * A card in a simulated draft.
+ */
+export interface SimulatedCard {
+  card_id: string;
+  name: string;
+  rarity: string;
+  colors: string;
+  gihwr?: number;
+}
+
+/**
+ * A bot drafter. Picks are revealed once the draft is complete.
+ */
+export interface SimulatedBot {
+  seat: number;
+  colors: string;
+  picks?: SimulatedCard[];
+}
+
+/**
+ * The user's view of a simulated draft. session_id is a regular draft session.
+ */
+export interface SimulatedDraftState {
+  session_id: string;
+  set_code: string;
+  draft_format: string;
+  seed: number;
+  pack_number: number;
+  pick_number: number;
+  pack_size: number;
+  total_picks: number;
+  picks_made: number;
+  pass_direction: 'left' | 'right';
+  complete: boolean;
+  pack: SimulatedCard[];
+  pool: SimulatedCard[];
+  bots: SimulatedBot[];
+}
+
+/**
+ * Start a practice draft against seven bots.
+ */
+export async function startSimulatedDraft(request: StartSimulatedDraftRequest): Promise<SimulatedDraftState> {
+  return post<SimulatedDraftState>('/drafts/sim', request);
+}
+
+/**
+ * Get the current state of a practice draft.
+ */
+export async function getSimulatedDraft(sessionId: string): Promise<SimulatedDraftState> {
+  return get<SimulatedDraftState>(`/drafts/sim/${sessionId}`);
+}
+
+/**
+ * Pick a card in a practice draft and receive the next pack.
+ */
+export async function pickSimulatedDraft(sessionId: string, cardId: string): Promise<SimulatedDraftState> {
+  return post<SimulatedDraftState>(`/drafts/sim/${sessionId}/pick`, { card_id: cardId });
+}
+
+/**
+ * One pick compared with the evaluator's choice.
+ */
+export interface PostMortemPick {
+  pack_number: number;
+  pick_number: number;
+  card_id: string;
+  card_name: string;
+  score: number;
+  rank: number;
+  pack_size: number;
+  best_card_id?: string;
+  best_card_name?: string;
+  best_score: number;
+  agreed: boolean;
+  grade?: string;
+  pool_colors: string;
+}
+
+/**
+ * The pick from which the pool's colors never changed again.
+ */
+export interface PostMortemPivot {
+  pick_index: number;
+  pack_number: number;
+  pick_number: number;
+  card_name: string;
+  colors: string;
+  previous_colors?: string;
+}
+
+export interface PostMortemDeckCard {
+  card_id: number;
+  name: string;
+  quantity: number;
+  gihwr?: number;
+}
+
+export interface PostMortemDeck {
+  name: string;
+  colors: string;
+  spells: number;
+  avg_gihwr: number;
+  cards: PostMortemDeckCard[];
+}
+
+/**
+ * The deck that was played against the best build from the pool.
+ */
+export interface PostMortemDeckComparison {
+  built?: PostMortemDeck;
+  optimal?: PostMortemDeck;
+  overlap: number;
+  only_built: string[];
+  only_optimal: string[];
+}
+
+export interface PostMortemMatch {
+  match_id: string;
+  result: string;
+  player_wins: number;
+  opponent_wins: number;
+  timestamp: string;
+}
+
+export interface PostMortemCardPerformance {
+  card_id: number;
+  name: string;
+  casts: number;
+  matches_cast: number;
+  wins_when_cast: number;
+  win_rate_when_cast: number;
+  avg_turn: number;
+  in_deck: boolean;
+}
+
+/**
+ * Full post-mortem of a draft.
+ */
+export interface DraftPostMortem {
+  session_id: string;
+  set_code: string;
+  event_name: string;
+  draft_format: string;
+  status: string;
+  start_time: string;
+  generated_at: string;
+  grade?: DraftGrade;
+  prediction?: {
+    win_rate: number;
+    win_rate_min: number;
+    win_rate_max: number;
+    predicted_at?: string;
+  };
+  picks: PostMortemPick[];
+  agreement: number;
+  pivot?: PostMortemPivot;
+  deck?: PostMortemDeckComparison;
+  record: {
+    wins: number;
+    losses: number;
+    matches: PostMortemMatch[];
+  };
+  card_performance: PostMortemCardPerformance[];
+}
+
+/**
+ * A post-mortem rendered as Markdown or HTML.
+ */
+export interface DraftPostMortemDocument {
+  format: string;
+  file_name: string;
+  content: string;
+}
+
+/**
+ * Get the post-mortem report for a draft session.
+ */
+export async function getDraftPostMortem(sessionId: string): Promise<DraftPostMortem> {
+  return get<DraftPostMortem>(`/drafts/${sessionId}/postmortem`);
+}
+
+/**
+ * Render the post-mortem report for a draft session as Markdown or HTML.
+ */
+export async function renderDraftPostMortem(
+  sessionId: string,
+  format: 'markdown' | 'html'
+): Promise<DraftPostMortemDocument> {
+  return get<DraftPostMortemDocument>(`/drafts/${sessionId}/postmortem?format=${format}`);
+}
+
+/**
+ * A spell in an optimized build.
+ */
+export interface OptimalBuildCard {
+  card_id: string;
+  name: string;
+  mana_cost: string;
+  cmc: number;
+  colors: string;
+  gihwr: number;
+  rated: boolean;
+  creature: boolean;
+  splash: boolean;
+  castability: number;
+}
+
+/**
+ * A 40-card deck built from a draft pool.
+ */
+export interface OptimalBuild {
+  rank: number;
+  name: string;
+  colors: string;
+  splash?: string;
+  score: number;
+  avg_gihwr: number;
+  castability: number;
+  land_count: number;
+  lands: Record<string, number>;
+  spells: OptimalBuildCard[];
+  curve: number[];
+  creatures: number;
+  explanation: string[];
+}
+
+export interface OptimalBuildsResult {
+  session_id: string;
+  set_code: string;
+  draft_format: string;
+  pool_size: number;
+  builds: OptimalBuild[];
+}
+
+/**
+ * Get the best decks the optimizer finds in a draft pool.
+ */
+export async function getOptimalBuilds(sessionId: string, top?: number): Promise<OptimalBuildsResult> {
+  const params = top ? `?top=${top}` : '';
+  return get<OptimalBuildsResult>(`/drafts/${sessionId}/optimal-builds${params}`);
+}
+
+/**
+ * A rating source a blend can weight.
+ */
+export type RatingSource = 'gihwr' | 'cfb' | 'personal';
+
+export interface RatingComponent {
+  source: RatingSource;
+  weight: number;
+}
+
+/**
+ * A weighted combination of rating sources for a set and format.
+ * An empty setCode or format matches any.
+ */
+export interface RatingBlend {
+  setCode: string;
+  format: string;
+  components: RatingComponent[];
+  version: number;
+  updatedAt: string;
+}
+
+export interface RatingBlendConfig {
+  version: number;
+  blends: RatingBlend[];
+  history: RatingBlend[];
+}
+
+export interface BlendedCardScore {
+  arenaId: number;
+  name: string;
+  rating: number;
+  gihwr: number;
+  sources: Partial<Record<RatingSource, number>>;
+  zScores: Partial<Record<RatingSource, number>>;
+}
+
+export interface BlendedRatings {
+  setCode: string;
+  format: string;
+  blend: RatingBlend | null;
+  configVersion: number;
+  cards: BlendedCardScore[];
+}
+
+/**
+ * Get the user's rating blends and the blends they replaced.
+ */
+export async function getRatingBlends(): Promise<RatingBlendConfig> {
+  return get<RatingBlendConfig>('/drafts/rating-blends');
+}
+
+/**
+ * Save a rating blend, replacing the previous version for its set and format.
+ */
+export async function saveRatingBlend(
+  blend: Pick<RatingBlend, 'setCode' | 'format' | 'components'>
+): Promise<RatingBlend> {
+  return put<RatingBlend>('/drafts/rating-blends', blend);
+}
+
+/**
+ * Delete the rating blend for a set and format.
+ */
+export async function deleteRatingBlend(setCode = '', format = ''): Promise<void> {
+  const params = new URLSearchParams({ set: setCode, format });
+  return del<void>(`/drafts/rating-blends?${params}`);
+}
+
+/**
+ * Get each card's blended rating with the sources behind it.
+ */
+export async function getBlendedRatings(setCode: string, format = 'PremierDraft'): Promise<BlendedRatings> {
+  const params = new URLSearchParams({ set: setCode, format });
+  return get<BlendedRatings>(`/drafts/rating-blends/ratings?${params}`);
+}
@@ -4,7 +4,7 @@
  * attacks, blocks, and turn-by-turn game state snapshots.
  */
 
-import { get } from '../apiClient';
+import { get, post } from '../apiClient';
 
 /**
  * Represents a single game play action.
@@ -46,6 +46,186 @@ export interface GameStateSnapshot {
   timestamp: string;
 }
 
+/**
+ * Represents a game object in a replay frame: a card or token in any zone.
+ */
+export interface ReplayObject {
+  instance_id: number;
+  card_id: number;
+  controller: 'player' | 'opponent';
+  owner: 'player' | 'opponent';
+  zone: string;
+  card_types?: string[];
+  power?: number;
+  toughness?: number;
+  is_tapped?: boolean;
+  is_attacking?: boolean;
+  is_blocking?: boolean;
+  summoning_sick?: boolean;
+  counters?: Record<string, number>;
+}
+
+/**
+ * Represents the complete game state at the end of a phase or step.
+ */
+export interface ReplayFrame {
+  match_id: string;
+  game_number: number;
+  frame_index: number;
+  turn_number: number;
+  phase: string;
+  step?: string;
+  active_player: string;
+  player_life?: number;
+  opponent_life?: number;
+  objects: ReplayObject[];
+  timestamp: string;
+}
+
+/**
+ * Represents one game of a match replay.
+ */
+export interface ReplayGame {
+  game_number: number;
+  frames: ReplayFrame[];
+}
+
+/**
+ * Represents one turn's duration and each player's decision time in it.
+ */
+export interface TurnTiming {
+  match_id: string;
+  game_number: number;
+  turn_number: number;
+  active_player: 'player' | 'opponent';
+  started_at: string;
+  ended_at: string;
+  duration_ms: number;
+  player_decision_ms: number;
+  opponent_decision_ms: number;
+  player_decisions: number;
+  opponent_decisions: number;
+}
+
+/**
+ * Represents how long a player held a decision or priority.
+ */
+export interface DecisionTiming {
+  id: number;
+  match_id: string;
+  game_number: number;
+  turn_number: number;
+  player_type: 'player' | 'opponent';
+  phase?: string;
+  step?: string;
+  started_at: string;
+  duration_ms: number;
+}
+
+/**
+ * Represents a rope warning or timeout for one player.
+ */
+export interface TimerEvent {
+  id: number;
+  match_id: string;
+  game_number: number;
+  turn_number: number;
+  player_type: 'player' | 'opponent';
+  event_type: 'rope' | 'timeout';
+  elapsed_ms: number;
+  timestamp: string;
+}
+
+/**
+ * Represents the decision time each player used in one game.
+ */
+export interface GameClockUsage {
+  game_number: number;
+  turns: number;
+  player_ms: number;
+  opponent_ms: number;
+  player_longest_decision_ms: number;
+  opponent_longest_decision_ms: number;
+}
+
+/**
+ * Represents the turn, decision and clock data for a match.
+ */
+export interface MatchTiming {
+  match_id: string;
+  turns: TurnTiming[];
+  decisions: DecisionTiming[];
+  timer_events: TimerEvent[];
+  games: GameClockUsage[];
+  player_total_ms: number;
+  opponent_total_ms: number;
+  player_ropes: number;
+  opponent_ropes: number;
+  player_timeouts: number;
+  opponent_timeouts: number;
+}
+
+/**
+ * Represents average turn times with one deck.
+ */
+export interface DeckTimingStats {
+  deck_id: string;
+  deck_name: string;
+  matches: number;
+  win_rate: number;
+  avg_turn_seconds: number;
+  avg_match_clock_seconds: number;
+  longest_turn_seconds: number;
+  ropes: number;
+  timeouts: number;
+  avg_opponent_turn_seconds: number;
+}
+
+/**
+ * Represents average turn times against one opponent archetype.
+ */
+export interface MatchupTimingStats {
+  opponent_archetype: string;
+  matches: number;
+  win_rate: number;
+  avg_turn_seconds: number;
+  avg_opponent_turn_seconds: number;
+  avg_match_clock_seconds: number;
+  ropes: number;
+}
+
+/**
+ * Represents how turn time relates to losing.
+ */
+export interface TurnTimeOutcome {
+  matches: number;
+  wins: number;
+  losses: number;
+  avg_turn_seconds_in_wins: number;
+  avg_turn_seconds_in_losses: number;
+  correlation: number;
+  long_turn_seconds: number;
+  matches_with_long_turns: number;
+  loss_rate_with_long_turns: number;
+  loss_rate_otherwise: number;
+}
+
+/**
+ * Represents an unusually long opponent decision on the final turn of a lost game.
+ */
+export interface SlowRollSuspect {
+  match_id: string;
+  game_number: number;
+  turn_number: number;
+  opponent_name?: string;
+  phase?: string;
+  step?: string;
+  decision_ms: number;
+  median_decision_ms: number;
+  ratio: number;
+  timestamp: string;
+}
+
 /**
  * Represents a card observed from the opponent.
  */
@@ -86,6 +266,112 @@ export interface GamePlaySummary {
   land_drops: number;
   total_turns: number;
   opponent_cards_seen: number;
+  damage_dealt: number;
+  damage_taken: number;
+  life_gained: number;
+  opponent_life_gained: number;
+  damage_by_source?: DamageSource[];
+  killed_by?: LethalSummary[];
+}
+
+/**
+ * Represents one change to a player's life total and its source.
+ */
+export interface LifeChange {
+  id: number;
+  match_id: string;
+  game_number: number;
+  turn_number: number;
+  phase?: string;
+  step?: string;
+  affected_player: 'player' | 'opponent';
+  amount: number;
+  life_after?: number;
+  change_type: 'combat' | 'burn' | 'drain' | 'lifelink' | 'life_gain' | 'other';
+  source_card_id?: number;
+  source_controller?: 'player' | 'opponent';
+  annotation_id: number;
+  timestamp: string;
+}
+
+/**
+ * Represents the life one card took or gave during a match.
+ */
+export interface DamageSource {
+  card_id?: number;
+  controller?: 'player' | 'opponent';
+  target: 'player' | 'opponent';
+  damage: number;
+  life_gained: number;
+  hits: number;
+  combat_damage: number;
+}
+
+/**
+ * Represents the damage taken on the turn we died in one game.
+ */
+export interface LethalSummary {
+  game_number: number;
+  turn_number: number;
+  damage: number;
+  sources: DamageSource[];
+  final_blow: LifeChange;
+}
+
+/**
+ * Represents average damage on one turn number across a deck's games.
+ */
+export interface TurnDamageStats {
+  turn_number: number;
+  games: number;
+  avg_damage_dealt: number;
+  avg_damage_taken: number;
+  avg_life_gained: number;
+}
+
+/**
+ * Describes an opening hand for mulligan advice.
+ */
+export interface HandFeatures {
+  lands: number;
+  castableByTurn3: number;
+  colorCoverage: number;
+  onPlay: boolean;
+}
+
+/**
+ * Represents an estimated win probability after keeping or mulliganing.
+ */
+export interface OutcomeEstimate {
+  winProbability: number;
+  prior: number;
+  games: number;
+  wins: number;
+}
+
+/**
+ * Represents keep or mulligan advice for an opening hand.
+ */
+export interface MulliganAdvice {
+  deckId: string;
+  matchId?: string;
+  gameNumber?: number;
+  mulliganCount: number;
+  cardIds: number[];
+  features: HandFeatures;
+  keep: OutcomeEstimate;
+  mulligan: OutcomeEstimate;
+  recommendation: 'keep' | 'mulligan';
+  decision?: 'keep' | 'mulligan';
+}
+
+/**
+ * Request body for advice on a hand drawn with a deck.
+ */
+export interface MulliganAdviceRequest {
+  card_ids: number[];
+  mulligan_count: number;
+  on_play: boolean;
 }
 
 /**
@@ -130,6 +416,84 @@ export async function getMatchSnapshots(
   return get<GameStateSnapshot[]>(url);
 }
 
+/**
+ * Get turn-by-turn game states for replaying a match.
+ * Optionally limit to one game number.
+ */
+export async function getMatchReplay(matchId: string, gameNumber?: number): Promise<ReplayGame[]> {
+  const url = gameNumber
+    ? `/matches/${encodeURIComponent(matchId)}/replay?game=${gameNumber}`
+    : `/matches/${encodeURIComponent(matchId)}/replay`;
+  return get<ReplayGame[]>(url);
+}
+
+/**
+ * Get every life total change of a match with its source.
+ */
+export async function getMatchLifeChanges(matchId: string): Promise<LifeChange[]> {
+  return get<LifeChange[]>(`/matches/${encodeURIComponent(matchId)}/life-changes`);
+}
+
+/**
+ * Get average damage dealt and taken on each turn of a deck's games.
+ */
+export async function getDeckDamageByTurn(deckId: string): Promise<TurnDamageStats[]> {
+  return get<TurnDamageStats[]>(`/gameplays/decks/${encodeURIComponent(deckId)}/damage-by-turn`);
+}
+
+/**
+ * Get keep or mulligan advice for every opening hand captured in a match.
+ */
+export async function getMatchMulliganAdvice(matchId: string): Promise<MulliganAdvice[]> {
+  return get<MulliganAdvice[]>(`/matches/${encodeURIComponent(matchId)}/mulligan-advice`);
+}
+
+/**
+ * Get keep or mulligan advice for a hand drawn with a deck.
+ */
+export async function getDeckMulliganAdvice(
+  deckId: string,
+  request: MulliganAdviceRequest
+): Promise<MulliganAdvice> {
+  return post<MulliganAdvice>(`/decks/${encodeURIComponent(deckId)}/mulligan-advice`, request);
+}
+
+/**
+ * Get turn durations, decision times and clock usage for a match.
+ */
+export async function getMatchTiming(matchId: string): Promise<MatchTiming> {
+  return get<MatchTiming>(`/matches/${encodeURIComponent(matchId)}/timing`);
+}
+
+/**
+ * Get average turn times per deck.
+ */
+export async function getDeckTimingStats(): Promise<DeckTimingStats[]> {
+  return get<DeckTimingStats[]>('/analytics/timing/decks');
+}
+
+/**
+ * Get average turn times per opponent archetype.
+ */
+export async function getMatchupTimingStats(): Promise<MatchupTimingStats[]> {
+  return get<MatchupTimingStats[]>('/analytics/timing/matchups');
+}
+
+/**
+ * Get how turn time relates to match results.
+ */
+export async function getTurnTimeOutcome(): Promise<TurnTimeOutcome> {
+  return get<TurnTimeOutcome>('/analytics/timing/outcomes');
+}
+
+/**
+ * Get unusually long opponent decisions on the final turn of lost games.
+ */
+export async function getSlowRollSuspects(limit?: number): Promise<SlowRollSuspect[]> {
+  const url = limit ? `/analytics/timing/slow-rolls?limit=${limit}` : '/analytics/timing/slow-rolls';
+  return get<SlowRollSuspect[]>(url);
+}
+
 /**
  * Get plays for a specific game within a match.
  */
@@ -79,6 +79,19 @@ export type {
   OpponentCard,
   PlayTimelineEntry,
   GamePlaySummary,
+  MatchTiming,
+  DeckTimingStats,
+  MatchupTimingStats,
+  TurnTimeOutcome,
+  SlowRollSuspect,
+  LifeChange,
+  DamageSource,
+  LethalSummary,
+  TurnDamageStats,
+  HandFeatures,
+  OutcomeEstimate,
+  MulliganAdvice,
+  MulliganAdviceRequest,
 } from './gameplays';
 
 export type {
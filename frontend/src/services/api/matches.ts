@@ -118,13 +118,22 @@ export async function getPerformanceByHour(
   return post<PerformanceMetrics>('/matches/performance-by-hour', filter);
 }
 
+/**
+ * Matchup matrix cell: deck statistics with win rate uncertainty.
+ */
+export interface MatchupStats extends Statistics {
+  win_rate_ci: Interval;
+  warning?: string;
+  significance: SignificanceComparison;
+}
+
 /**
  * Get matchup matrix (win rates against different decks).
  */
 export async function getMatchupMatrix(
   filter: StatsFilterRequest = {}
-): Promise<Record<string, Statistics>> {
-  return post<Record<string, Statistics>>('/matches/matchup-matrix', filter);
+): Promise<Record<string, MatchupStats>> {
+  return post<Record<string, MatchupStats>>('/matches/matchup-matrix', filter);
 }
 
 /**
@@ -219,6 +228,38 @@ export function statsFilterToRequest(filter: StatsFilter): StatsFilterRequest {
 // Comparison Types
 // ==================
 
+/**
+ * Interval is a confidence interval on a win rate (0-1).
+ */
+export interface Interval {
+  low: number;
+  high: number;
+}
+
+/**
+ * WinRateProportion is a win rate with Wilson and Jeffreys intervals.
+ */
+export interface WinRateProportion {
+  successes: number;
+  trials: number;
+  rate: number;
+  wilson: Interval;
+  jeffreys: Interval;
+  warning?: string;
+}
+
+/**
+ * SignificanceComparison tests whether two win rates genuinely differ.
+ */
+export interface SignificanceComparison {
+  difference: number;
+  z_score: number;
+  p_value: number;
+  probability_better: number;
+  significant: boolean;
+  warning?: string;
+}
+
 /**
  * ComparisonGroup represents a labeled group of matches for comparison.
  */
@@ -227,6 +268,7 @@ export interface ComparisonGroup {
   Filter: StatsFilter;
   Statistics: Statistics | null;
   MatchCount: number;
+  WinRate: WinRateProportion;
 }
 
 /**
@@ -237,6 +279,7 @@ export interface ComparisonResult {
   BestGroup: ComparisonGroup | null;
   WorstGroup: ComparisonGroup | null;
   WinRateDiff: number;
+  Significance: SignificanceComparison;
   TotalMatches: number;
   ComparisonDate: string;
 }
@@ -252,6 +295,7 @@ export interface ComparisonDiff {
   MatchCountDiff: number;
   GamesPlayedDiff: number;
   Trend: string;
+  Significance: SignificanceComparison;
 }
 
 /**
@@ -117,6 +117,102 @@ export interface ArchetypeExpectedCard {
   createdAt: string;
 }
 
+export interface ArchetypeLikelihood {
+  archetype: string;
+  probability: number;
+  prior: number;
+  cardsMatched: number;
+}
+
+export interface CardThreat {
+  cardId: number;
+  cardName: string;
+  threat: 'sweeper' | 'counterspell' | 'instant_removal';
+  manaValue: number;
+  inDeck: number; // Chance a copy is left in library or hand
+  inHand: number; // Chance a copy is in hand right now
+  castable: boolean; // Enough untapped lands to cast it
+}
+
+/**
+ * Live read of the opponent's deck from cards revealed so far.
+ * Also pushed as the `inference` payload of `match:opponent_inference` events.
+ */
+export interface OpponentInference {
+  matchId: string;
+  turn: number;
+  format?: string;
+  cardsObserved: number;
+  opponentHandSize: number;
+  untappedLands: number;
+  unknownCards: number;
+  archetypes: ArchetypeLikelihood[];
+  threats: CardThreat[];
+}
+
+export interface ScoutedDeck {
+  archetype: string;
+  colorIdentity: string;
+  format: string;
+  matches: number;
+  wins: number; // Our wins against this deck
+  lastSeen: string;
+}
+
+export interface ScoutedCard {
+  cardId: number;
+  cardName: string;
+  matches: number;
+  timesSeen: number;
+}
+
+export interface OpponentPlayPatterns {
+  concedes: number;
+  concedeRate: number; // Share of our wins that were concessions
+  avgConcedeTurn?: number;
+  avgTurnSeconds?: number;
+  turnsTimed: number;
+  timeouts: number;
+}
+
+export interface ScoutedMatchSummary {
+  matchId: string;
+  timestamp: string;
+  format: string;
+  result: string;
+  resultReason?: string;
+  archetype?: string;
+}
+
+/**
+ * Our history against one opponent across matches.
+ * Also pushed as the `report` payload of `match:scouting` events.
+ */
+export interface OpponentScoutingReport {
+  opponentId: string;
+  opponentName: string;
+  matchesPlayed: number;
+  matchesWon: number;
+  matchesLost: number;
+  gamesWon: number;
+  gamesLost: number;
+  winRate: number;
+  firstSeen?: string;
+  lastSeen?: string;
+  decks: ScoutedDeck[];
+  cardsSeen: ScoutedCard[];
+  playPatterns: OpponentPlayPatterns;
+  recentMatches: ScoutedMatchSummary[];
+}
+
+export interface ScoutedOpponent {
+  opponentId: string;
+  opponentName: string;
+  matchesPlayed: number;
+  matchesWon: number;
+  lastSeen: string;
+}
+
 // API Functions
 
 /**
@@ -126,6 +222,13 @@ export async function getOpponentAnalysis(matchId: string): Promise<OpponentAnal
   return get<OpponentAnalysis>(`/matches/${matchId}/opponent-analysis`);
 }
 
+/**
+ * Get the live archetype and held-card inference for a match
+ */
+export async function getOpponentInference(matchId: string): Promise<OpponentInference> {
+  return get<OpponentInference>(`/matches/${matchId}/opponent-inference`);
+}
+
 /**
  * List reconstructed opponent deck profiles
  */
@@ -150,6 +253,34 @@ export async function listOpponentDecks(params?: {
   return get<{ profiles: OpponentDeckProfile[]; total: number }>(url);
 }
 
+/**
+ * List opponents we have played repeatedly
+ */
+export async function listScoutedOpponents(params?: {
+  minMatches?: number;
+  limit?: number;
+}): Promise<{ opponents: ScoutedOpponent[] | null; total: number }> {
+  const searchParams = new URLSearchParams();
+  if (params?.minMatches !== undefined) {
+    searchParams.set('min_matches', params.minMatches.toString());
+  }
+  if (params?.limit !== undefined) {
+    searchParams.set('limit', params.limit.toString());
+  }
+
+  const query = searchParams.toString();
+  return get<{ opponents: ScoutedOpponent[] | null; total: number }>(
+    `/opponents/scouting${query ? `?${query}` : ''}`
+  );
+}
+
+/**
+ * Get our history against one opponent
+ */
+export async function getScoutingReport(opponentId: string): Promise<OpponentScoutingReport> {
+  return get<OpponentScoutingReport>(`/opponents/scouting/${encodeURIComponent(opponentId)}`);
+}
+
 /**
  * Get matchup statistics
  */
@@ -5,6 +5,7 @@
 
 import { get, post } from '../apiClient';
 import { gui, models } from '@/types/models';
+import type { StatsFilterRequest } from './matches';
 
 // Re-export types for convenience
 export type ConnectionStatus = gui.ConnectionStatus;
@@ -70,6 +71,31 @@ export interface HealthStatus {
   metrics: HealthMetrics;
 }
 
+/**
+ * A single database doctor check result.
+ */
+export interface DoctorFinding {
+  check: string;
+  severity: 'error' | 'warning' | 'info';
+  description: string;
+  count: number;
+  samples?: string[];
+  fixable: boolean;
+  fixed: number;
+  fix_error?: string;
+  hint?: string;
+}
+
+/**
+ * Database doctor report.
+ */
+export interface DoctorReport {
+  checked_at: string;
+  fix_requested: boolean;
+  fix_skipped?: string;
+  findings: DoctorFinding[];
+}
+
 /**
  * Get the current connection status.
  */
@@ -126,6 +152,20 @@ export async function setDatabasePath(path: string): Promise<{ status: string }>
   return post<{ status: string }>('/system/database/path', { path });
 }
 
+/**
+ * Check the database for integrity problems without changing anything.
+ */
+export async function getDoctorReport(): Promise<DoctorReport> {
+  return get<DoctorReport>('/system/doctor');
+}
+
+/**
+ * Check the database and apply the safe repairs.
+ */
+export async function runDoctorFix(): Promise<DoctorReport> {
+  return post<DoctorReport>('/system/doctor', { fix: true });
+}
+
 /**
  * Get current account.
  */
@@ -180,6 +220,57 @@ export async function exportMLTrainingData(limit: number): Promise<gui.MLTrainin
   return get<gui.MLTrainingDataExport>(`/feedback/ml-training?limit=${limit}`);
 }
 
+/**
+ * A column in an anonymized export table.
+ */
+export interface AnonymizedColumn {
+  name: string;
+  type: string;
+  nullable: boolean;
+  description: string;
+}
+
+/**
+ * A table file in an anonymized export bundle.
+ */
+export interface AnonymizedTable {
+  name: string;
+  file: string;
+  rows: number;
+  sha256: string;
+  columns: AnonymizedColumn[];
+}
+
+/**
+ * Manifest written alongside an anonymized export bundle.
+ */
+export interface AnonymizedManifest {
+  schema_version: number;
+  exported_from: string;
+  exported_day: string;
+  format: 'csv' | 'jsonl';
+  time_bucket_seconds: number;
+  anonymization: string[];
+  tables: AnonymizedTable[];
+}
+
+/**
+ * Export matches, games, plays and drafts as an anonymized bundle for sharing.
+ */
+export async function exportAnonymizedBundle(
+  outputDir: string,
+  format: 'csv' | 'jsonl' = 'csv',
+  filter?: StatsFilterRequest,
+  overwrite = false
+): Promise<AnonymizedManifest> {
+  return post<AnonymizedManifest>('/export/anonymized', {
+    output_dir: outputDir,
+    format,
+    filter,
+    overwrite,
+  });
+}
+
 /**
  * Get feedback dashboard metrics.
  */
@@ -1,3 +1,53 @@
+export namespace archetype {
+	
+	export class ArchetypeMatch {
+	    archetype: string;
+	    source: string;
+	    similarity: number;
+	    probability: number;
+	    matchedCards: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ArchetypeMatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.archetype = source["archetype"];
+	        this.source = source["source"];
+	        this.similarity = source["similarity"];
+	        this.probability = source["probability"];
+	        this.matchedCards = source["matchedCards"];
+	    }
+	}
+
+}
+
+export namespace deckimport {
+	
+	export class UnresolvedCard {
+	    line?: number;
+	    name: string;
+	    quantity: number;
+	    board: string;
+	    suggestions?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new UnresolvedCard(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.line = source["line"];
+	        this.name = source["name"];
+	        this.quantity = source["quantity"];
+	        this.board = source["board"];
+	        this.suggestions = source["suggestions"];
+	    }
+	}
+
+}
+
 export namespace grading {
 	
 	export class DraftGrade {
@@ -175,6 +225,7 @@ export namespace gui {
 	    indicators: ArchetypeIndicatorInfo[];
 	    totalCards: number;
 	    analysis?: DeckArchetypeAnalysis;
+	    archetypeMatches?: archetype.ArchetypeMatch[];
 	
 	    static createFrom(source: any = {}) {
 	        return new ArchetypeClassificationResult(source);
@@ -193,6 +244,7 @@ export namespace gui {
 	        this.indicators = this.convertValues(source["indicators"], ArchetypeIndicatorInfo);
 	        this.totalCards = source["totalCards"];
 	        this.analysis = this.convertValues(source["analysis"], DeckArchetypeAnalysis);
+	        this.archetypeMatches = this.convertValues(source["archetypeMatches"], archetype.ArchetypeMatch);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -264,6 +316,10 @@ export namespace gui {
 	    "# in_hand_drawn": number;
 	    "# games_played"?: number;
 	    "# decks"?: number;
+	    predicted?: boolean;
+	    prior_gihwr?: number;
+	    observed_gihwr?: number;
+	    prior_weight?: number;
 	    tier: string;
 	    colors: string[];
 	
@@ -296,6 +352,10 @@ export namespace gui {
 	        this["# in_hand_drawn"] = source["# in_hand_drawn"];
 	        this["# games_played"] = source["# games_played"];
 	        this["# decks"] = source["# decks"];
+	        this.predicted = source["predicted"];
+	        this.prior_gihwr = source["prior_gihwr"];
+	        this.observed_gihwr = source["observed_gihwr"];
+	        this.prior_weight = source["prior_weight"];
 	        this.tier = source["tier"];
 	        this.colors = source["colors"];
 	    }
@@ -1065,6 +1125,7 @@ export namespace gui {
 	    lands: LandStats;
 	    creatures: CreatureStats;
 	    legality: FormatLegality;
+	    manaBase?: manabase.Report;
 	
 	    static createFrom(source: any = {}) {
 	        return new DeckStatistics(source);
@@ -1083,6 +1144,7 @@ export namespace gui {
 	        this.lands = this.convertValues(source["lands"], LandStats);
 	        this.creatures = this.convertValues(source["creatures"], CreatureStats);
 	        this.legality = this.convertValues(source["legality"], FormatLegality);
+	        this.manaBase = this.convertValues(source["manaBase"], manabase.Report);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1112,6 +1174,8 @@ export namespace gui {
 	    topCards: string[];
 	    synergies: string[];
 	    playableCount: number;
+	    castability: number;
+	    underSupported?: string[];
 	
 	    static createFrom(source: any = {}) {
 	        return new DeckSuggestionAnalysisResponse(source);
@@ -1127,6 +1191,8 @@ export namespace gui {
 	        this.topCards = source["topCards"];
 	        this.synergies = source["synergies"];
 	        this.playableCount = source["playableCount"];
+	        this.castability = source["castability"];
+	        this.underSupported = source["underSupported"];
 	    }
 	}
 	export class DeckUpdatedEvent {
@@ -1320,6 +1386,7 @@ export namespace gui {
 	    importText: string;
 	    source: string;
 	    draftEventID?: string;
+	    url?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new ImportDeckRequest(source);
@@ -1332,6 +1399,7 @@ export namespace gui {
 	        this.importText = source["importText"];
 	        this.source = source["source"];
 	        this.draftEventID = source["draftEventID"];
+	        this.url = source["url"];
 	    }
 	}
 	export class ImportDeckResponse {
@@ -1341,6 +1409,7 @@ export namespace gui {
 	    warnings?: string[];
 	    cardsImported: number;
 	    cardsSkipped: number;
+	    unresolved?: deckimport.UnresolvedCard[];
 	
 	    static createFrom(source: any = {}) {
 	        return new ImportDeckResponse(source);
@@ -1354,7 +1423,26 @@ export namespace gui {
 	        this.warnings = source["warnings"];
 	        this.cardsImported = source["cardsImported"];
 	        this.cardsSkipped = source["cardsSkipped"];
+	        this.unresolved = this.convertValues(source["unresolved"], deckimport.UnresolvedCard);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class ImportLogFileResult {
 	    fileName: string;
@@ -2375,6 +2463,113 @@ export namespace insights {
 	
 	
 
+}
+
+export namespace manabase {
+	
+	export class Castability {
+	    name: string;
+	    quantity: number;
+	    manaCost: string;
+	    cmc: number;
+	    pips: Record<string, number>;
+	    onPlay: number;
+	    onDraw: number;
+	    consistency: number;
+	    requiredSources: Record<string, number>;
+	    supported: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Castability(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.quantity = source["quantity"];
+	        this.manaCost = source["manaCost"];
+	        this.cmc = source["cmc"];
+	        this.pips = source["pips"];
+	        this.onPlay = source["onPlay"];
+	        this.onDraw = source["onDraw"];
+	        this.consistency = source["consistency"];
+	        this.requiredSources = source["requiredSources"];
+	        this.supported = source["supported"];
+	    }
+	}
+	export class HandStats {
+	    simulations: number;
+	    landCounts: number[];
+	    averageLands: number;
+	    keepableRate: number;
+	    allColorsRate: number;
+	    landDrops: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new HandStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.simulations = source["simulations"];
+	        this.landCounts = source["landCounts"];
+	        this.averageLands = source["averageLands"];
+	        this.keepableRate = source["keepableRate"];
+	        this.allColorsRate = source["allColorsRate"];
+	        this.landDrops = source["landDrops"];
+	    }
+	}
+	export class Report {
+	    deckSize: number;
+	    landCount: number;
+	    recommendedLands: number;
+	    averageCMC: number;
+	    sources: Record<string, number>;
+	    recommendedSources: Record<string, number>;
+	    entersTapped: number;
+	    target: number;
+	    cards: Castability[];
+	    underSupported: string[];
+	    openingHands?: HandStats;
+	
+	    static createFrom(source: any = {}) {
+	        return new Report(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deckSize = source["deckSize"];
+	        this.landCount = source["landCount"];
+	        this.recommendedLands = source["recommendedLands"];
+	        this.averageCMC = source["averageCMC"];
+	        this.sources = source["sources"];
+	        this.recommendedSources = source["recommendedSources"];
+	        this.entersTapped = source["entersTapped"];
+	        this.target = source["target"];
+	        this.cards = this.convertValues(source["cards"], Castability);
+	        this.underSupported = source["underSupported"];
+	        this.openingHands = this.convertValues(source["openingHands"], HandStats);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
 }
 
 export namespace metrics {
@@ -2774,6 +2969,9 @@ export namespace models {
 	    PackBestGIHWR?: number;
 	    PickedCardGIHWR?: number;
 	    AlternativesJSON?: string;
+	    PickScore?: number;
+	    PackBestScore?: number;
+	    ScoreBreakdownJSON?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new DraftPickSession(source);
@@ -2792,6 +2990,9 @@ export namespace models {
 	        this.PackBestGIHWR = source["PackBestGIHWR"];
 	        this.PickedCardGIHWR = source["PickedCardGIHWR"];
 	        this.AlternativesJSON = source["AlternativesJSON"];
+	        this.PickScore = source["PickScore"];
+	        this.PackBestScore = source["PackBestScore"];
+	        this.ScoreBreakdownJSON = source["ScoreBreakdownJSON"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -2821,6 +3022,7 @@ export namespace models {
 	    EndTime?: time.Time;
 	    Status: string;
 	    TotalPicks: number;
+	    IsSimulated: boolean;
 	    OverallGrade?: string;
 	    OverallScore?: number;
 	    PickQualityScore?: number;
@@ -2849,6 +3051,7 @@ export namespace models {
 	        this.EndTime = this.convertValues(source["EndTime"], time.Time);
 	        this.Status = source["Status"];
 	        this.TotalPicks = source["TotalPicks"];
+	        this.IsSimulated = source["IsSimulated"];
 	        this.OverallGrade = source["OverallGrade"];
 	        this.OverallScore = source["OverallScore"];
 	        this.PickQualityScore = source["PickQualityScore"];
@@ -3417,11 +3620,33 @@ export namespace models {
 
 export namespace pickquality {
 	
+	export class ScoreFactors {
+	    base_gihwr: number;
+	    color_fit: number;
+	    archetype: number;
+	    role_need: number;
+	    position: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScoreFactors(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.base_gihwr = source["base_gihwr"];
+	        this.color_fit = source["color_fit"];
+	        this.archetype = source["archetype"];
+	        this.role_need = source["role_need"];
+	        this.position = source["position"];
+	    }
+	}
 	export class Alternative {
 	    card_id: string;
 	    card_name: string;
 	    gihwr: number;
 	    rank: number;
+	    score?: number;
+	    factors?: ScoreFactors;
 	
 	    static createFrom(source: any = {}) {
 	        return new Alternative(source);
@@ -3433,7 +3658,27 @@ export namespace pickquality {
 	        this.card_name = source["card_name"];
 	        this.gihwr = source["gihwr"];
 	        this.rank = source["rank"];
+	        this.score = source["score"];
+	        this.factors = this.convertValues(source["factors"], ScoreFactors);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class PickQuality {
 	    grade: string;
@@ -3441,6 +3686,9 @@ export namespace pickquality {
 	    pack_best_gihwr: number;
 	    picked_card_gihwr: number;
 	    alternatives: Alternative[];
+	    picked_score?: number;
+	    pack_best_score?: number;
+	    picked_factors?: ScoreFactors;
 	
 	    static createFrom(source: any = {}) {
 	        return new PickQuality(source);
@@ -3453,6 +3701,9 @@ export namespace pickquality {
 	        this.pack_best_gihwr = source["pack_best_gihwr"];
 	        this.picked_card_gihwr = source["picked_card_gihwr"];
 	        this.alternatives = this.convertValues(source["alternatives"], Alternative);
+	        this.picked_score = source["picked_score"];
+	        this.pack_best_score = source["pack_best_score"];
+	        this.picked_factors = this.convertValues(source["picked_factors"], ScoreFactors);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -3540,6 +3791,76 @@ export namespace prediction {
 		    return a;
 		}
 	}
+	export class DeckFeatures {
+	    avg_gihwr: number;
+	    top_gihwr: number;
+	    avg_iwd: number;
+	    curve_score: number;
+	    two_drops: number;
+	    removal: number;
+	    creatures: number;
+	    bombs: number;
+	    color_pair_win_rate: number;
+	    colors: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeckFeatures(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.avg_gihwr = source["avg_gihwr"];
+	        this.top_gihwr = source["top_gihwr"];
+	        this.avg_iwd = source["avg_iwd"];
+	        this.curve_score = source["curve_score"];
+	        this.two_drops = source["two_drops"];
+	        this.removal = source["removal"];
+	        this.creatures = source["creatures"];
+	        this.bombs = source["bombs"];
+	        this.color_pair_win_rate = source["color_pair_win_rate"];
+	        this.colors = source["colors"];
+	    }
+	}
+	export class LearnedPrediction {
+	    model_version: string;
+	    samples: number;
+	    win_rate: number;
+	    expected_wins: number;
+	    features: DeckFeatures;
+	    contributions: Record<string, number>;
+	
+	    static createFrom(source: any = {}) {
+	        return new LearnedPrediction(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.model_version = source["model_version"];
+	        this.samples = source["samples"];
+	        this.win_rate = source["win_rate"];
+	        this.expected_wins = source["expected_wins"];
+	        this.features = this.convertValues(source["features"], DeckFeatures);
+	        this.contributions = source["contributions"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class PredictionFactors {
 	    deck_average_gihwr: number;
 	    color_adjustment: number;
@@ -3556,6 +3877,7 @@ export namespace prediction {
 	    high_performers: string[];
 	    low_performers: string[];
 	    confidence_level: string;
+	    learned?: LearnedPrediction;
 	
 	    static createFrom(source: any = {}) {
 	        return new PredictionFactors(source);
@@ -3578,6 +3900,7 @@ export namespace prediction {
 	        this.high_performers = source["high_performers"];
 	        this.low_performers = source["low_performers"];
 	        this.confidence_level = source["confidence_level"];
+	        this.learned = this.convertValues(source["learned"], LearnedPrediction);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -336,6 +336,43 @@ func (h *CardHandler) GetDatasetSource(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetRatingPriorModel returns the early-set rating model for a draft format.
+func (h *CardHandler) GetRatingPriorModel(w http.ResponseWriter, r *http.Request) {
+	draftFormat := r.URL.Query().Get("format")
+	if draftFormat == "" {
+		draftFormat = "PremierDraft"
+	}
+
+	model, err := h.facade.GetRatingPriorModel(r.Context(), draftFormat)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	if model == nil {
+		response.NotFound(w, errors.New("no rating prior model has been trained"))
+		return
+	}
+
+	response.Success(w, model)
+}
+
+// TrainRatingPriors retrains the early-set rating model for a draft format.
+func (h *CardHandler) TrainRatingPriors(w http.ResponseWriter, r *http.Request) {
+	draftFormat := r.URL.Query().Get("format")
+	if draftFormat == "" {
+		draftFormat = "PremierDraft"
+	}
+
+	model, err := h.facade.TrainRatingPriors(r.Context(), draftFormat)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, model)
+}
+
 // GetCardRatingByArenaID returns the rating for a specific card.
 func (h *CardHandler) GetCardRatingByArenaID(w http.ResponseWriter, r *http.Request) {
 	setCode := chi.URLParam(r, "setCode")
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/api/response"
 	"github.com/ramonehamilton/MTGA-Companion/internal/gui"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
 )
 
@@ -181,6 +182,64 @@ func (h *DeckHandler) GetDeckStats(w http.ResponseWriter, r *http.Request) {
 	response.Success(w, stats)
 }
 
+// GetDeckGoldfish plays a deck against an empty board to estimate its speed.
+// GET /decks/{deckID}/goldfish?iterations=1000
+func (h *DeckHandler) GetDeckGoldfish(w http.ResponseWriter, r *http.Request) {
+	deckID := chi.URLParam(r, "deckID")
+	if deckID == "" {
+		response.BadRequest(w, errors.New("deck ID is required"))
+		return
+	}
+
+	result, err := h.facade.GoldfishDeck(r.Context(), deckID, goldfishIterations(r))
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, result)
+}
+
+// CompareDeckPermutations goldfishes two versions of a deck with the same shuffles.
+// GET /decks/{deckID}/goldfish/compare?from=1&to=2&iterations=1000
+func (h *DeckHandler) CompareDeckPermutations(w http.ResponseWriter, r *http.Request) {
+	deckID := chi.URLParam(r, "deckID")
+	if deckID == "" {
+		response.BadRequest(w, errors.New("deck ID is required"))
+		return
+	}
+
+	fromID, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil {
+		response.BadRequest(w, errors.New("from permutation ID is required"))
+		return
+	}
+	toID, err := strconv.Atoi(r.URL.Query().Get("to"))
+	if err != nil {
+		response.BadRequest(w, errors.New("to permutation ID is required"))
+		return
+	}
+
+	result, err := h.facade.CompareDeckPermutations(r.Context(), deckID, fromID, toID, goldfishIterations(r))
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, result)
+}
+
+// goldfishIterations reads the number of simulated games, capped to keep requests fast.
+func goldfishIterations(r *http.Request) int {
+	iterations := 0
+	if iterStr := r.URL.Query().Get("iterations"); iterStr != "" {
+		if parsed, err := strconv.Atoi(iterStr); err == nil && parsed > 0 {
+			iterations = min(parsed, 10000)
+		}
+	}
+	return iterations
+}
+
 // GetDeckMatches returns performance for a deck.
 func (h *DeckHandler) GetDeckMatches(w http.ResponseWriter, r *http.Request) {
 	deckID := chi.URLParam(r, "deckID")
@@ -268,11 +327,12 @@ func (h *DeckHandler) ExportDeck(w http.ResponseWriter, r *http.Request) {
 // ImportDeckRequest represents a request to import a deck.
 type ImportDeckRequest struct {
 	Content string `json:"content"`
+	URL     string `json:"url"` // Public deck page, used when Content is empty
 	Name    string `json:"name"`
 	Format  string `json:"format"`
 }
 
-// ImportDeck imports a deck from text.
+// ImportDeck imports a deck from text or a public deck page URL.
 func (h *DeckHandler) ImportDeck(w http.ResponseWriter, r *http.Request) {
 	var req ImportDeckRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -280,13 +340,14 @@ func (h *DeckHandler) ImportDeck(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.Content == "" {
-		response.BadRequest(w, errors.New("deck content is required"))
+	if req.Content == "" && req.URL == "" {
+		response.BadRequest(w, errors.New("deck content or URL is required"))
 		return
 	}
 
 	importReq := &gui.ImportDeckRequest{
 		ImportText: req.Content,
+		URL:        req.URL,
 		Name:       req.Name,
 		Format:     req.Format,
 		Source:     "imported",
@@ -988,6 +1049,37 @@ func (h *DeckHandler) GetCardPerformance(w http.ResponseWriter, r *http.Request)
 	response.Success(w, result)
 }
 
+// GetCardGameMetrics returns GIH/OH/GD/GNS win rates for the cards in a deck.
+// GET /decks/{deckID}/card-metrics
+func (h *DeckHandler) GetCardGameMetrics(w http.ResponseWriter, r *http.Request) {
+	deckID := chi.URLParam(r, "deckID")
+	if deckID == "" {
+		response.BadRequest(w, errors.New("deck ID is required"))
+		return
+	}
+
+	filter := models.CardGameMetricsFilter{
+		DeckID:       deckID,
+		IncludeLands: r.URL.Query().Get("include_lands") == "true",
+	}
+	if permStr := r.URL.Query().Get("permutation_id"); permStr != "" {
+		permID, err := strconv.Atoi(permStr)
+		if err != nil {
+			response.BadRequest(w, errors.New("invalid permutation_id"))
+			return
+		}
+		filter.PermutationID = &permID
+	}
+
+	result, err := h.facade.GetCardGameMetrics(r.Context(), filter)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, result)
+}
+
 // GetPerformanceRecommendationsRequest represents the request body for getting performance-based recommendations.
 type GetPerformanceRecommendationsRequest struct {
 	MaxResults   int    `json:"max_results,omitempty"`
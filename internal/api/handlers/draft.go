@@ -10,7 +10,12 @@ import (
 	"github.com/go-chi/chi/v5"
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/api/response"
+	"github.com/ramonehamilton/MTGA-Companion/internal/export"
 	"github.com/ramonehamilton/MTGA-Companion/internal/gui"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/optimizer"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/postmortem"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/ratings"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/simulator"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 )
 
@@ -219,6 +224,166 @@ func (h *DraftHandler) GetDraftDeckMetrics(w http.ResponseWriter, r *http.Reques
 	response.Success(w, metrics)
 }
 
+// GetDraftSignals returns color openness signals read from the packs seen so far.
+func (h *DraftHandler) GetDraftSignals(w http.ResponseWriter, r *http.Request) {
+	sessionID := chi.URLParam(r, "sessionID")
+	if sessionID == "" {
+		response.BadRequest(w, errors.New("session ID is required"))
+		return
+	}
+
+	result, err := h.facade.GetDraftSignals(r.Context(), sessionID)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, result)
+}
+
+// TrainPredictorRequest represents a request to train the learned win rate model.
+type TrainPredictorRequest struct {
+	GameDataCSV string `json:"game_data_csv,omitempty"` // Optional 17Lands game_data_public CSV path
+	SetCode     string `json:"set_code,omitempty"`      // Set of the game data
+	DraftFormat string `json:"draft_format,omitempty"`  // Format of the game data (default PremierDraft)
+}
+
+// TrainPredictor trains the learned win rate model on completed drafts.
+func (h *DraftHandler) TrainPredictor(w http.ResponseWriter, r *http.Request) {
+	var req TrainPredictorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, errors.New("invalid request body"))
+		return
+	}
+	if req.GameDataCSV != "" && req.SetCode == "" {
+		response.BadRequest(w, errors.New("set_code is required with game_data_csv"))
+		return
+	}
+	if req.DraftFormat == "" {
+		req.DraftFormat = "PremierDraft"
+	}
+
+	model, err := h.facade.TrainDraftPredictor(r.Context(), req.GameDataCSV, req.SetCode, req.DraftFormat)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, model)
+}
+
+// GetPredictorModel returns the active learned win rate model.
+func (h *DraftHandler) GetPredictorModel(w http.ResponseWriter, r *http.Request) {
+	model, err := h.facade.GetDraftPredictorModel(r.Context())
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, model)
+}
+
+// StartSimulatedDraftRequest represents a request to start a practice draft against bots.
+type StartSimulatedDraftRequest struct {
+	SetCode     string                `json:"set_code"`
+	DraftFormat string                `json:"draft_format,omitempty"` // Ratings format for the bots (default PremierDraft)
+	Seed        int64                 `json:"seed,omitempty"`         // Replays the same packs and bot picks
+	PackConfig  *simulator.PackConfig `json:"pack_config,omitempty"`  // Overrides the default 14-card booster layout
+}
+
+// SimulatedPickRequest represents the user's pick in a practice draft.
+type SimulatedPickRequest struct {
+	CardID string `json:"card_id"`
+}
+
+// StartSimulatedDraft starts a practice draft and returns the first pack.
+func (h *DraftHandler) StartSimulatedDraft(w http.ResponseWriter, r *http.Request) {
+	var req StartSimulatedDraftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, errors.New("invalid request body"))
+		return
+	}
+	if req.SetCode == "" {
+		response.BadRequest(w, errors.New("set_code is required"))
+		return
+	}
+	if req.PackConfig != nil {
+		if err := req.PackConfig.Validate(); err != nil {
+			response.BadRequest(w, err)
+			return
+		}
+	}
+
+	state, err := h.facade.StartSimulatedDraft(r.Context(), simulator.Options{
+		SetCode:     req.SetCode,
+		DraftFormat: req.DraftFormat,
+		Seed:        req.Seed,
+		PackConfig:  req.PackConfig,
+	})
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, state)
+}
+
+// GetSimulatedDraft returns the current state of a practice draft.
+func (h *DraftHandler) GetSimulatedDraft(w http.ResponseWriter, r *http.Request) {
+	simID := chi.URLParam(r, "simID")
+	if simID == "" {
+		response.BadRequest(w, errors.New("simulated draft ID is required"))
+		return
+	}
+
+	state, err := h.facade.GetSimulatedDraft(r.Context(), simID)
+	if err != nil {
+		writeSimulatorError(w, err)
+		return
+	}
+
+	response.Success(w, state)
+}
+
+// PickSimulatedDraft makes the user's pick in a practice draft.
+func (h *DraftHandler) PickSimulatedDraft(w http.ResponseWriter, r *http.Request) {
+	simID := chi.URLParam(r, "simID")
+	if simID == "" {
+		response.BadRequest(w, errors.New("simulated draft ID is required"))
+		return
+	}
+
+	var req SimulatedPickRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, errors.New("invalid request body"))
+		return
+	}
+	if req.CardID == "" {
+		response.BadRequest(w, errors.New("card_id is required"))
+		return
+	}
+
+	state, err := h.facade.PickSimulatedDraft(r.Context(), simID, req.CardID)
+	if err != nil {
+		writeSimulatorError(w, err)
+		return
+	}
+
+	response.Success(w, state)
+}
+
+// writeSimulatorError maps simulator errors to status codes.
+func writeSimulatorError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, simulator.ErrDraftNotFound):
+		response.NotFound(w, err)
+	case errors.Is(err, simulator.ErrCardNotInPack), errors.Is(err, simulator.ErrDraftComplete):
+		response.BadRequest(w, err)
+	default:
+		response.InternalError(w, err)
+	}
+}
+
 // DraftStatsRequest represents a request for draft statistics.
 type DraftStatsRequest struct {
 	SetCode   *string `json:"set_code,omitempty"`
@@ -556,6 +721,136 @@ func (h *DraftHandler) ExportTo17Lands(w http.ResponseWriter, r *http.Request) {
 	response.Success(w, exportData)
 }
 
+// GetDraftPostMortem returns the post-mortem report for a draft session.
+// With ?format=markdown or ?format=html the rendered document is returned instead.
+func (h *DraftHandler) GetDraftPostMortem(w http.ResponseWriter, r *http.Request) {
+	sessionID := chi.URLParam(r, "sessionID")
+	if sessionID == "" {
+		response.BadRequest(w, errors.New("session ID is required"))
+		return
+	}
+
+	var (
+		result interface{}
+		err    error
+	)
+	switch format := export.Format(r.URL.Query().Get("format")); format {
+	case "", export.FormatJSON:
+		result, err = h.facade.GetDraftPostMortem(r.Context(), sessionID)
+	case export.FormatMarkdown, export.FormatHTML:
+		result, err = h.facade.RenderDraftPostMortem(r.Context(), sessionID, format)
+	default:
+		response.BadRequest(w, errors.New("format must be json, markdown or html"))
+		return
+	}
+	if err != nil {
+		if errors.Is(err, postmortem.ErrSessionNotFound) {
+			response.NotFound(w, err)
+			return
+		}
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, result)
+}
+
+// GetOptimalBuilds returns the best decks the optimizer finds in a draft's pool.
+func (h *DraftHandler) GetOptimalBuilds(w http.ResponseWriter, r *http.Request) {
+	sessionID := chi.URLParam(r, "sessionID")
+	if sessionID == "" {
+		response.BadRequest(w, errors.New("session ID is required"))
+		return
+	}
+
+	top := 3
+	if topStr := r.URL.Query().Get("top"); topStr != "" {
+		if t, err := strconv.Atoi(topStr); err == nil && t > 0 {
+			top = t
+		}
+	}
+
+	result, err := h.facade.GetOptimalBuilds(r.Context(), sessionID, top)
+	if err != nil {
+		if errors.Is(err, optimizer.ErrSessionNotFound) {
+			response.NotFound(w, err)
+			return
+		}
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, result)
+}
+
+// GetRatingBlends returns the user's rating blends.
+func (h *DraftHandler) GetRatingBlends(w http.ResponseWriter, r *http.Request) {
+	config, err := h.facade.GetRatingBlends(r.Context())
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, config)
+}
+
+// SaveRatingBlend saves a rating blend for a set and format.
+func (h *DraftHandler) SaveRatingBlend(w http.ResponseWriter, r *http.Request) {
+	var blend ratings.Blend
+	if err := json.NewDecoder(r.Body).Decode(&blend); err != nil {
+		response.BadRequest(w, errors.New("invalid request body"))
+		return
+	}
+	if err := blend.Validate(); err != nil {
+		response.BadRequest(w, err)
+		return
+	}
+
+	saved, err := h.facade.SaveRatingBlend(r.Context(), blend)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, saved)
+}
+
+// DeleteRatingBlend removes the rating blend for a set and format. Empty
+// set and format name the global blend.
+func (h *DraftHandler) DeleteRatingBlend(w http.ResponseWriter, r *http.Request) {
+	setCode := r.URL.Query().Get("set")
+	draftFormat := r.URL.Query().Get("format")
+
+	if err := h.facade.DeleteRatingBlend(r.Context(), setCode, draftFormat); err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, map[string]string{"status": "success"})
+}
+
+// GetBlendedRatings explains each card's blended rating for a set and format.
+func (h *DraftHandler) GetBlendedRatings(w http.ResponseWriter, r *http.Request) {
+	setCode := r.URL.Query().Get("set")
+	draftFormat := r.URL.Query().Get("format")
+
+	if setCode == "" {
+		response.BadRequest(w, errors.New("set query parameter is required"))
+		return
+	}
+	if draftFormat == "" {
+		draftFormat = "PremierDraft"
+	}
+
+	breakdown, err := h.facade.GetBlendedRatings(r.Context(), setCode, draftFormat)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, breakdown)
+}
+
 // GetExportableDrafts returns draft sessions that can be exported.
 func (h *DraftHandler) GetExportableDrafts(w http.ResponseWriter, r *http.Request) {
 	limitStr := r.URL.Query().Get("limit")
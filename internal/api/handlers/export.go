@@ -8,6 +8,7 @@ import (
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/api/response"
 	"github.com/ramonehamilton/MTGA-Companion/internal/gui"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 )
 
 // ExportHandler handles export-related API requests.
@@ -112,6 +113,50 @@ func (h *ExportHandler) ExportDeck(w http.ResponseWriter, r *http.Request) {
 	response.Success(w, exportedDeck)
 }
 
+// ExportAnonymizedRequest represents a request to export an anonymized bundle.
+type ExportAnonymizedRequest struct {
+	OutputDir string              `json:"output_dir"`
+	Format    string              `json:"format"` // "csv" or "jsonl"
+	Filter    *StatsFilterRequest `json:"filter,omitempty"`
+	Overwrite bool                `json:"overwrite,omitempty"`
+}
+
+// ExportAnonymized writes an anonymized matches/games/plays/drafts bundle to disk
+// and returns its manifest.
+func (h *ExportHandler) ExportAnonymized(w http.ResponseWriter, r *http.Request) {
+	var req ExportAnonymizedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, errors.New("invalid request body"))
+		return
+	}
+
+	if req.OutputDir == "" {
+		response.BadRequest(w, errors.New("output_dir is required"))
+		return
+	}
+	if req.Format == "" {
+		req.Format = "csv"
+	}
+	if req.Format != "csv" && req.Format != "jsonl" {
+		response.BadRequest(w, errors.New("format must be csv or jsonl"))
+		return
+	}
+
+	var filter *models.StatsFilter
+	if req.Filter != nil {
+		f := req.Filter.ToStatsFilter()
+		filter = &f
+	}
+
+	manifest, err := h.facade.ExportAnonymizedBundle(r.Context(), req.OutputDir, req.Format, filter, req.Overwrite)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, manifest)
+}
+
 // GetExportFormats returns available export formats.
 func (h *ExportHandler) GetExportFormats(w http.ResponseWriter, _ *http.Request) {
 	formats := []map[string]string{
@@ -120,6 +165,7 @@ func (h *ExportHandler) GetExportFormats(w http.ResponseWriter, _ *http.Request)
 		{"id": "mtga", "name": "MTGA", "description": "MTG Arena format"},
 		{"id": "arena", "name": "Arena", "description": "Arena export format"},
 		{"id": "text", "name": "Text", "description": "Plain text format"},
+		{"id": "jsonl", "name": "JSONL", "description": "JSON Lines, one record per line (anonymized bundles)"},
 	}
 
 	response.Success(w, formats)
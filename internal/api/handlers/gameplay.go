@@ -185,6 +185,61 @@ func (h *GamePlayHandler) GetMatchSnapshots(w http.ResponseWriter, r *http.Reque
 	response.Success(w, snapshots)
 }
 
+// ReplayGameResponse is one game of a match replay: the complete game state
+// at the end of every phase and step.
+type ReplayGameResponse struct {
+	GameNumber int                   `json:"game_number"`
+	Frames     []*models.ReplayFrame `json:"frames"`
+}
+
+// GetMatchReplay returns turn-by-turn game states for replaying a match.
+// Optionally filter by game number.
+func (h *GamePlayHandler) GetMatchReplay(w http.ResponseWriter, r *http.Request) {
+	if !h.checkStorage(w) {
+		return
+	}
+
+	matchID := chi.URLParam(r, "matchID")
+	if matchID == "" {
+		response.BadRequest(w, errors.New("match ID is required"))
+		return
+	}
+
+	var gameNumber int
+	if gameStr := r.URL.Query().Get("game"); gameStr != "" {
+		var err error
+		gameNumber, err = strconv.Atoi(gameStr)
+		if err != nil || gameNumber < 1 {
+			response.BadRequest(w, errors.New("invalid game number"))
+			return
+		}
+	}
+
+	frames, err := h.storage.GamePlayRepo().GetReplayFrames(r.Context(), matchID)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	games := []ReplayGameResponse{}
+	for _, frame := range frames {
+		if gameNumber > 0 && frame.GameNumber != gameNumber {
+			continue
+		}
+		if len(games) == 0 || games[len(games)-1].GameNumber != frame.GameNumber {
+			games = append(games, ReplayGameResponse{GameNumber: frame.GameNumber})
+		}
+		games[len(games)-1].Frames = append(games[len(games)-1].Frames, frame)
+	}
+
+	if gameNumber > 0 && len(games) == 0 {
+		response.NotFound(w, errors.New("no replay recorded for this game"))
+		return
+	}
+
+	response.Success(w, games)
+}
+
 // GetMatchPlaySummary returns a summary of plays for a match.
 func (h *GamePlayHandler) GetMatchPlaySummary(w http.ResponseWriter, r *http.Request) {
 	if !h.checkStorage(w) {
@@ -249,3 +304,144 @@ func (h *GamePlayHandler) GetPlaysByGame(w http.ResponseWriter, r *http.Request)
 
 	response.Success(w, plays)
 }
+
+// GetMatchTiming returns turn durations, decision times and clock usage for a match.
+func (h *GamePlayHandler) GetMatchTiming(w http.ResponseWriter, r *http.Request) {
+	if !h.checkStorage(w) {
+		return
+	}
+
+	matchID := chi.URLParam(r, "matchID")
+	if matchID == "" {
+		response.BadRequest(w, errors.New("match ID is required"))
+		return
+	}
+
+	timing, err := h.storage.NewTimingRepo().GetMatchTiming(r.Context(), matchID)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	if timing == nil {
+		response.NotFound(w, errors.New("no timing recorded for this match"))
+		return
+	}
+
+	response.Success(w, timing)
+}
+
+// GetDeckTimingStats returns average turn times per deck.
+func (h *GamePlayHandler) GetDeckTimingStats(w http.ResponseWriter, r *http.Request) {
+	if !h.checkStorage(w) {
+		return
+	}
+
+	stats, err := h.storage.NewTimingRepo().GetDeckTimingStats(r.Context(), h.storage.CurrentAccountID())
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, stats)
+}
+
+// GetMatchupTimingStats returns average turn times per opponent archetype.
+func (h *GamePlayHandler) GetMatchupTimingStats(w http.ResponseWriter, r *http.Request) {
+	if !h.checkStorage(w) {
+		return
+	}
+
+	stats, err := h.storage.NewTimingRepo().GetMatchupTimingStats(r.Context(), h.storage.CurrentAccountID())
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, stats)
+}
+
+// GetTurnTimeOutcome returns how turn time relates to losing.
+func (h *GamePlayHandler) GetTurnTimeOutcome(w http.ResponseWriter, r *http.Request) {
+	if !h.checkStorage(w) {
+		return
+	}
+
+	outcome, err := h.storage.NewTimingRepo().GetTurnTimeOutcome(r.Context(), h.storage.CurrentAccountID())
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, outcome)
+}
+
+// GetSlowRollSuspects returns unusually long opponent decisions on the final
+// turn of games we lost.
+func (h *GamePlayHandler) GetSlowRollSuspects(w http.ResponseWriter, r *http.Request) {
+	if !h.checkStorage(w) {
+		return
+	}
+
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	suspects, err := h.storage.NewTimingRepo().GetSlowRollSuspects(r.Context(), h.storage.CurrentAccountID(), limit)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, suspects)
+}
+
+// GetMatchLifeChanges returns every life total change of a match with its source.
+func (h *GamePlayHandler) GetMatchLifeChanges(w http.ResponseWriter, r *http.Request) {
+	if !h.checkStorage(w) {
+		return
+	}
+
+	matchID := chi.URLParam(r, "matchID")
+	if matchID == "" {
+		response.BadRequest(w, errors.New("match ID is required"))
+		return
+	}
+
+	changes, err := h.storage.GamePlayRepo().GetLifeChangesByMatch(r.Context(), matchID)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	if changes == nil {
+		changes = []*models.LifeChange{}
+	}
+
+	response.Success(w, changes)
+}
+
+// GetDeckDamageByTurn returns the average damage dealt and taken on each turn
+// of the games played with a deck.
+func (h *GamePlayHandler) GetDeckDamageByTurn(w http.ResponseWriter, r *http.Request) {
+	if !h.checkStorage(w) {
+		return
+	}
+
+	deckID := chi.URLParam(r, "deckID")
+	if deckID == "" {
+		response.BadRequest(w, errors.New("deck ID is required"))
+		return
+	}
+
+	stats, err := h.storage.GamePlayRepo().GetDamageByTurn(r.Context(), deckID)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, stats)
+}
@@ -303,13 +303,13 @@ func (h *MatchHandler) GetMatchupMatrix(w http.ResponseWriter, r *http.Request)
 	}
 
 	filter := req.ToStatsFilter()
-	stats, err := h.facade.GetStatsByDeck(r.Context(), filter)
+	matrix, err := h.facade.GetMatchupMatrix(r.Context(), filter)
 	if err != nil {
 		response.InternalError(w, err)
 		return
 	}
 
-	response.Success(w, stats)
+	response.Success(w, matrix)
 }
 
 // GetRankProgression returns rank progression data for a specific format.
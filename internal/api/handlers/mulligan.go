@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/api/response"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/analysis"
+)
+
+// MulliganHandler handles keep or mulligan advice API requests.
+type MulliganHandler struct {
+	advisor   *analysis.MulliganAdvisor
+	accountID func() int
+}
+
+// NewMulliganHandler creates a new mulligan handler.
+func NewMulliganHandler(advisor *analysis.MulliganAdvisor, accountIDFunc func() int) *MulliganHandler {
+	return &MulliganHandler{
+		advisor:   advisor,
+		accountID: accountIDFunc,
+	}
+}
+
+// GetMatchMulliganAdvice returns keep or mulligan advice for every opening
+// hand captured in a match.
+// GET /matches/{matchID}/mulligan-advice
+func (h *MulliganHandler) GetMatchMulliganAdvice(w http.ResponseWriter, r *http.Request) {
+	matchID := chi.URLParam(r, "matchID")
+	if matchID == "" {
+		response.BadRequest(w, errors.New("match ID is required"))
+		return
+	}
+
+	advice, err := h.advisor.AdviseMatch(r.Context(), h.accountID(), matchID)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+	if advice == nil {
+		advice = []*analysis.MulliganAdvice{}
+	}
+
+	response.Success(w, advice)
+}
+
+// MulliganAdviceRequest is an opening hand to advise on.
+type MulliganAdviceRequest struct {
+	CardIDs       []int `json:"card_ids"`
+	MulliganCount int   `json:"mulligan_count"`
+	OnPlay        bool  `json:"on_play"`
+}
+
+// GetDeckMulliganAdvice returns keep or mulligan advice for a hand drawn with a deck.
+// POST /decks/{deckID}/mulligan-advice
+func (h *MulliganHandler) GetDeckMulliganAdvice(w http.ResponseWriter, r *http.Request) {
+	deckID := chi.URLParam(r, "deckID")
+	if deckID == "" {
+		response.BadRequest(w, errors.New("deck ID is required"))
+		return
+	}
+
+	var req MulliganAdviceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, errors.New("invalid request body"))
+		return
+	}
+	if len(req.CardIDs) == 0 {
+		response.BadRequest(w, errors.New("card_ids is required"))
+		return
+	}
+	if req.MulliganCount < 0 || req.MulliganCount >= len(req.CardIDs) {
+		response.BadRequest(w, errors.New("mulligan_count must leave at least one card"))
+		return
+	}
+
+	advice, err := h.advisor.Advise(r.Context(), deckID, req.CardIDs, req.MulliganCount, req.OnPlay)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, advice)
+}
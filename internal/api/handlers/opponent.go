@@ -18,14 +18,16 @@ const MaxOpponentLimit = 500
 // OpponentHandler handles opponent analysis API requests.
 type OpponentHandler struct {
 	analyzer     *analysis.OpponentAnalyzer
+	inferrer     *analysis.OpponentInferrer
 	opponentRepo repository.OpponentRepository
 	accountID    func() int
 }
 
 // NewOpponentHandler creates a new opponent handler.
-func NewOpponentHandler(analyzer *analysis.OpponentAnalyzer, opponentRepo repository.OpponentRepository, accountIDFunc func() int) *OpponentHandler {
+func NewOpponentHandler(analyzer *analysis.OpponentAnalyzer, inferrer *analysis.OpponentInferrer, opponentRepo repository.OpponentRepository, accountIDFunc func() int) *OpponentHandler {
 	return &OpponentHandler{
 		analyzer:     analyzer,
+		inferrer:     inferrer,
 		opponentRepo: opponentRepo,
 		accountID:    accountIDFunc,
 	}
@@ -49,6 +51,28 @@ func (h *OpponentHandler) GetOpponentAnalysis(w http.ResponseWriter, r *http.Req
 	response.JSON(w, http.StatusOK, opponentAnalysis)
 }
 
+// GetOpponentInference retrieves the live archetype and held-card read for a match.
+// GET /matches/{matchID}/opponent-inference
+func (h *OpponentHandler) GetOpponentInference(w http.ResponseWriter, r *http.Request) {
+	matchID := chi.URLParam(r, "matchID")
+	if matchID == "" {
+		response.Error(w, http.StatusBadRequest, errors.New("match ID required"))
+		return
+	}
+
+	inference, err := h.inferrer.Infer(r.Context(), matchID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+	if inference == nil {
+		response.Error(w, http.StatusNotFound, errors.New("no archetype expected cards to infer from"))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, inference)
+}
+
 // ListOpponentDecks lists reconstructed opponent decks.
 // GET /opponents/decks
 func (h *OpponentHandler) ListOpponentDecks(w http.ResponseWriter, r *http.Request) {
@@ -91,6 +115,56 @@ func (h *OpponentHandler) ListOpponentDecks(w http.ResponseWriter, r *http.Reque
 	})
 }
 
+// ListScoutedOpponents lists opponents we have played, most recently seen first.
+// GET /opponents/scouting
+func (h *OpponentHandler) ListScoutedOpponents(w http.ResponseWriter, r *http.Request) {
+	minMatches := 2 // Repeat opponents are the ones worth scouting
+	if minStr := r.URL.Query().Get("min_matches"); minStr != "" {
+		if parsed, err := strconv.Atoi(minStr); err == nil && parsed > 0 {
+			minMatches = parsed
+		}
+	}
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			limit = min(parsed, MaxOpponentLimit)
+		}
+	}
+
+	opponents, err := h.opponentRepo.ListScoutedOpponents(r.Context(), h.accountID(), minMatches, limit)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"opponents": opponents,
+		"total":     len(opponents),
+	})
+}
+
+// GetScoutingReport retrieves our history against one opponent.
+// GET /opponents/scouting/{opponentID}
+func (h *OpponentHandler) GetScoutingReport(w http.ResponseWriter, r *http.Request) {
+	opponentID := chi.URLParam(r, "opponentID")
+	if opponentID == "" {
+		response.Error(w, http.StatusBadRequest, errors.New("opponent ID required"))
+		return
+	}
+
+	report, err := h.opponentRepo.GetScoutingReport(r.Context(), h.accountID(), opponentID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+	if report == nil {
+		response.Error(w, http.StatusNotFound, errors.New("no matches against this opponent"))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, report)
+}
+
 // GetMatchupStats retrieves matchup statistics.
 // GET /analytics/matchups
 func (h *OpponentHandler) GetMatchupStats(w http.ResponseWriter, r *http.Request) {
@@ -213,3 +213,28 @@ func (h *SystemHandler) GetReplayProgress(w http.ResponseWriter, r *http.Request
 
 	response.Success(w, progress)
 }
+
+// RunDoctorRequest represents a request to run the database doctor.
+type RunDoctorRequest struct {
+	Fix bool `json:"fix,omitempty"`
+}
+
+// RunDoctor checks the database for integrity problems.
+// GET only reports; POST with {"fix": true} also applies the safe repairs.
+func (h *SystemHandler) RunDoctor(w http.ResponseWriter, r *http.Request) {
+	var req RunDoctorRequest
+	if r.Method == http.MethodPost && r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			response.BadRequest(w, errors.New("invalid request body"))
+			return
+		}
+	}
+
+	report, err := h.facade.RunDoctor(r.Context(), req.Fix)
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+
+	response.Success(w, report)
+}
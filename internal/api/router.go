@@ -53,6 +53,7 @@ func (s *Server) setupRoutes() {
 		gamePlayHandler := handlers.NewGamePlayHandler(gamePlayStorage)
 		r.Route("/gameplays", func(r chi.Router) {
 			r.Get("/game/{gameID}", gamePlayHandler.GetPlaysByGame) // Get plays for a specific game
+			r.Get("/decks/{deckID}/damage-by-turn", gamePlayHandler.GetDeckDamageByTurn)
 		})
 		// Also add game play endpoints under matches for convenience
 		r.Get("/matches/{matchID}/plays", gamePlayHandler.GetMatchPlays)
@@ -60,6 +61,15 @@ func (s *Server) setupRoutes() {
 		r.Get("/matches/{matchID}/plays/summary", gamePlayHandler.GetMatchPlaySummary)
 		r.Get("/matches/{matchID}/opponent-cards", gamePlayHandler.GetMatchOpponentCards)
 		r.Get("/matches/{matchID}/snapshots", gamePlayHandler.GetMatchSnapshots)
+		r.Get("/matches/{matchID}/replay", gamePlayHandler.GetMatchReplay)
+		r.Get("/matches/{matchID}/life-changes", gamePlayHandler.GetMatchLifeChanges)
+		r.Get("/matches/{matchID}/timing", gamePlayHandler.GetMatchTiming)
+		r.Route("/analytics/timing", func(r chi.Router) {
+			r.Get("/decks", gamePlayHandler.GetDeckTimingStats)
+			r.Get("/matchups", gamePlayHandler.GetMatchupTimingStats)
+			r.Get("/outcomes", gamePlayHandler.GetTurnTimeOutcome)
+			r.Get("/slow-rolls", gamePlayHandler.GetSlowRollSuspects)
+		})
 
 		// Draft routes
 		draftHandler := handlers.NewDraftHandler(s.draftFacade)
@@ -74,7 +84,16 @@ func (s *Server) setupRoutes() {
 			r.Post("/insights", draftHandler.GetDraftInsights)
 			r.Post("/archetype-cards", draftHandler.GetArchetypeCards)
 			r.Post("/win-probability", draftHandler.PredictWinProbability)
+			r.Get("/predictor", draftHandler.GetPredictorModel)
+			r.Post("/predictor/train", draftHandler.TrainPredictor)
+			r.Post("/sim", draftHandler.StartSimulatedDraft)
+			r.Get("/sim/{simID}", draftHandler.GetSimulatedDraft)
+			r.Post("/sim/{simID}/pick", draftHandler.PickSimulatedDraft)
 			r.Post("/recalculate-set-grades", draftHandler.RecalculateSetGrades)
+			r.Get("/rating-blends", draftHandler.GetRatingBlends)
+			r.Put("/rating-blends", draftHandler.SaveRatingBlend)
+			r.Delete("/rating-blends", draftHandler.DeleteRatingBlend)
+			r.Get("/rating-blends/ratings", draftHandler.GetBlendedRatings)
 			r.Get("/{sessionID}", draftHandler.GetDraftSession)
 			r.Get("/{sessionID}/picks", draftHandler.GetDraftPicks)
 			r.Get("/{sessionID}/packs", draftHandler.GetDraftPacks)
@@ -84,7 +103,10 @@ func (s *Server) setupRoutes() {
 			r.Get("/{sessionID}/colors", draftHandler.GetDraftColors)
 			r.Get("/{sessionID}/current-pack", draftHandler.GetCurrentPack)
 			r.Get("/{sessionID}/deck-metrics", draftHandler.GetDraftDeckMetrics)
+			r.Get("/{sessionID}/signals", draftHandler.GetDraftSignals)
 			r.Get("/{sessionID}/export/17lands", draftHandler.ExportTo17Lands)
+			r.Get("/{sessionID}/postmortem", draftHandler.GetDraftPostMortem)
+			r.Get("/{sessionID}/optimal-builds", draftHandler.GetOptimalBuilds)
 			r.Post("/{sessionID}/missing-cards", draftHandler.GetMissingCards)
 			r.Post("/{sessionID}/analyze-picks", draftHandler.AnalyzePickQuality)
 			r.Post("/{sessionID}/calculate-grade", draftHandler.CalculateGrade)
@@ -117,6 +139,8 @@ func (s *Server) setupRoutes() {
 			r.Put("/{deckID}", deckHandler.UpdateDeck)
 			r.Delete("/{deckID}", deckHandler.DeleteDeck)
 			r.Get("/{deckID}/stats", deckHandler.GetDeckStats)
+			r.Get("/{deckID}/goldfish", deckHandler.GetDeckGoldfish)
+			r.Get("/{deckID}/goldfish/compare", deckHandler.CompareDeckPermutations)
 			r.Get("/{deckID}/matches", deckHandler.GetDeckMatches)
 			r.Get("/{deckID}/curve", deckHandler.GetDeckCurve)
 			r.Get("/{deckID}/colors", deckHandler.GetDeckColors)
@@ -131,6 +155,7 @@ func (s *Server) setupRoutes() {
 
 			// Card performance analysis routes (Issue #771)
 			r.Get("/{deckID}/card-performance", deckHandler.GetCardPerformance)
+			r.Get("/{deckID}/card-metrics", deckHandler.GetCardGameMetrics)
 			r.Get("/{deckID}/recommendations/add", deckHandler.GetPerformanceAddRecommendations)
 			r.Get("/{deckID}/recommendations/remove", deckHandler.GetPerformanceRemoveRecommendations)
 			r.Get("/{deckID}/recommendations/swap", deckHandler.GetPerformanceSwapRecommendations)
@@ -160,6 +185,8 @@ func (s *Server) setupRoutes() {
 			r.Get("/ratings/{setCode}/card/{arenaID}", cardHandler.GetCardRatingByArenaID)
 			r.Post("/ratings/{setCode}/fetch", cardHandler.FetchSetRatings)
 			r.Post("/ratings/{setCode}/refresh", cardHandler.RefreshSetRatings)
+			r.Get("/rating-priors", cardHandler.GetRatingPriorModel)
+			r.Post("/rating-priors/train", cardHandler.TrainRatingPriors)
 
 			// ChannelFireball ratings routes
 			cfbHandler := handlers.NewCFBHandler(s.cardFacade)
@@ -210,6 +237,8 @@ func (s *Server) setupRoutes() {
 			r.Get("/account", systemHandler.GetCurrentAccount)
 			r.Get("/database/path", systemHandler.GetDatabasePath)
 			r.Post("/database/path", systemHandler.SetDatabasePath)
+			r.Get("/doctor", systemHandler.RunDoctor)
+			r.Post("/doctor", systemHandler.RunDoctor)
 			// Daemon routes
 			r.Get("/daemon/status", systemHandler.GetDaemonStatus)
 			r.Post("/daemon/connect", systemHandler.ConnectDaemon)
@@ -242,6 +271,7 @@ func (s *Server) setupRoutes() {
 			r.Post("/drafts", exportHandler.ExportDrafts)
 			r.Post("/collection", exportHandler.ExportCollection)
 			r.Post("/deck", exportHandler.ExportDeck)
+			r.Post("/anonymized", exportHandler.ExportAnonymized)
 			r.Get("/formats", exportHandler.GetExportFormats)
 			r.Post("/import/matches", exportHandler.ImportMatches)
 			r.Post("/import/log", exportHandler.ImportLogFile)
@@ -299,6 +329,10 @@ func (s *Server) setupRoutes() {
 			playRepo := s.services.Storage.NewGamePlayRepo()
 			matchRepo := s.services.Storage.NewMatchRepo()
 			playAnalyzer := analysis.NewPlayAnalyzer(playRepo, matchRepo)
+			if s.deckFacade != nil {
+				playAnalyzer.SetGoldfisher(s.deckFacade)
+			}
+			playAnalyzer.SetCardLookup(s.services.Storage.NewSetCardRepo())
 			suggGenerator := analysis.NewSuggestionGenerator(playAnalyzer, suggRepo)
 			notesHandler := handlers.NewNotesHandler(notesRepo, suggRepo, suggGenerator)
 
@@ -360,15 +394,29 @@ func (s *Server) setupRoutes() {
 			opponentRepo := s.services.Storage.NewOpponentRepo()
 			perfRepo := s.services.Storage.DeckPerformanceRepo()
 			classifier := archetype.NewClassifier(s.services.CardService, deckRepo, perfRepo)
+			referenceSources := []archetype.ReferenceSource{archetype.NewMTGZoneReferences(s.services.Storage.NewMTGZoneRepo())}
+			if s.services.MetaService != nil {
+				referenceSources = append(referenceSources, archetype.NewGoldfishReferences(s.services.MetaService))
+			}
+			classifier.SetSimilarity(archetype.NewSimilarityClassifier(referenceSources...))
 			opponentAnalyzer := analysis.NewOpponentAnalyzer(playRepo, opponentRepo, matchRepo, s.services.CardService, classifier)
-			opponentHandler := handlers.NewOpponentHandler(opponentAnalyzer, opponentRepo, func() int { return s.services.Storage.CurrentAccountID() })
+			opponentHandler := handlers.NewOpponentHandler(opponentAnalyzer, s.services.OpponentInferrer(), opponentRepo, func() int { return s.services.Storage.CurrentAccountID() })
 
 			// Match opponent analysis
 			r.Get("/matches/{matchID}/opponent-analysis", opponentHandler.GetOpponentAnalysis)
+			r.Get("/matches/{matchID}/opponent-inference", opponentHandler.GetOpponentInference)
+
+			// Keep or mulligan advice
+			mulliganAdvisor := analysis.NewMulliganAdvisor(playRepo, matchRepo, deckRepo, s.services.Storage.NewSetCardRepo())
+			mulliganHandler := handlers.NewMulliganHandler(mulliganAdvisor, func() int { return s.services.Storage.CurrentAccountID() })
+			r.Get("/matches/{matchID}/mulligan-advice", mulliganHandler.GetMatchMulliganAdvice)
+			r.Post("/decks/{deckID}/mulligan-advice", mulliganHandler.GetDeckMulliganAdvice)
 
 			// Opponent routes
 			r.Route("/opponents", func(r chi.Router) {
 				r.Get("/decks", opponentHandler.ListOpponentDecks)
+				r.Get("/scouting", opponentHandler.ListScoutedOpponents)
+				r.Get("/scouting/{opponentID}", opponentHandler.GetScoutingReport)
 			})
 
 			// Analytics routes for matchups
@@ -3,6 +3,7 @@ package archetype
 import (
 	"context"
 	"fmt"
+	"log"
 	"sort"
 	"strings"
 
@@ -43,6 +44,7 @@ type ClassificationResult struct {
 	ArchetypeIndicators []ArchetypeIndicator // Cards that indicate the archetype
 	TotalCards          int
 	Analysis            *DeckAnalysis
+	ArchetypeMatches    []ArchetypeMatch // Reference lists ranked by similarity, when compared
 }
 
 // ArchetypeIndicator represents a card that indicates a specific archetype.
@@ -82,8 +84,13 @@ type Classifier struct {
 	cardService *cards.Service
 	deckRepo    repository.DeckRepository
 	perfRepo    repository.DeckPerformanceRepository
+	similarity  *SimilarityClassifier // Optional reference decklist matching
 }
 
+// minReferenceConfidence is the similarity confidence needed for a reference
+// archetype to replace the color and style heuristics.
+const minReferenceConfidence = 0.35
+
 // NewClassifier creates a new archetype classifier.
 func NewClassifier(cardService *cards.Service, deckRepo repository.DeckRepository, perfRepo repository.DeckPerformanceRepository) *Classifier {
 	return &Classifier{
@@ -93,6 +100,12 @@ func NewClassifier(cardService *cards.Service, deckRepo repository.DeckRepositor
 	}
 }
 
+// SetSimilarity enables matching against reference decklists. When a
+// reference archetype matches confidently it becomes the primary archetype.
+func (c *Classifier) SetSimilarity(similarity *SimilarityClassifier) {
+	c.similarity = similarity
+}
+
 // ClassifyDeck classifies a deck based on its cards.
 func (c *Classifier) ClassifyDeck(ctx context.Context, deckID string) (*ClassificationResult, error) {
 	// Get deck cards
@@ -115,19 +128,57 @@ func (c *Classifier) ClassifyDeck(ctx context.Context, deckID string) (*Classifi
 		}
 	}
 
-	return c.ClassifyCards(cardIDs, cardQuantities)
+	result, cardMetadata, err := c.classifyCards(cardIDs, cardQuantities)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.similarity != nil {
+		deck, err := c.deckRepo.GetByID(ctx, deckID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deck: %w", err)
+		}
+		if deck != nil {
+			c.applyReferenceMatches(ctx, result, cardMetadata, cardQuantities, deck.Format, false)
+		}
+	}
+
+	return result, nil
+}
+
+// ClassifyObserved classifies the cards seen of an unknown deck, such as an
+// opponent's revealed cards. Each card counts once since copies are unknown.
+func (c *Classifier) ClassifyObserved(ctx context.Context, cardIDs []int, format string) (*ClassificationResult, error) {
+	quantities := make(map[int]int)
+	for _, id := range cardIDs {
+		quantities[id] = 1
+	}
+
+	result, cardMetadata, err := c.classifyCards(cardIDs, quantities)
+	if err != nil {
+		return nil, err
+	}
+	c.applyReferenceMatches(ctx, result, cardMetadata, quantities, format, true)
+	return result, nil
 }
 
 // ClassifyCards classifies a set of cards with their quantities.
 func (c *Classifier) ClassifyCards(cardIDs []int, quantities map[int]int) (*ClassificationResult, error) {
+	result, _, err := c.classifyCards(cardIDs, quantities)
+	return result, err
+}
+
+// classifyCards runs the heuristic classification and returns the card
+// metadata it loaded.
+func (c *Classifier) classifyCards(cardIDs []int, quantities map[int]int) (*ClassificationResult, map[int]*cards.Card, error) {
 	if len(cardIDs) == 0 {
-		return nil, fmt.Errorf("no cards to classify")
+		return nil, nil, fmt.Errorf("no cards to classify")
 	}
 
 	// Get card metadata
 	cardMetadata, err := c.cardService.GetCards(cardIDs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get card metadata: %w", err)
+		return nil, nil, fmt.Errorf("failed to get card metadata: %w", err)
 	}
 
 	// Perform deck analysis
@@ -150,7 +201,42 @@ func (c *Classifier) ClassifyCards(cardIDs []int, quantities map[int]int) (*Clas
 	// Classify into archetype based on analysis
 	c.classifyArchetype(result, cardMetadata, quantities)
 
-	return result, nil
+	return result, cardMetadata, nil
+}
+
+// applyReferenceMatches ranks reference archetypes for the cards and adopts
+// the best one when it is confident enough. Failures leave the heuristic
+// classification in place.
+func (c *Classifier) applyReferenceMatches(ctx context.Context, result *ClassificationResult, cardMetadata map[int]*cards.Card, quantities map[int]int, format string, partial bool) {
+	if c.similarity == nil || format == "" {
+		return
+	}
+
+	cardCounts := make(map[string]int, len(cardMetadata))
+	for id, card := range cardMetadata {
+		if card != nil && card.Name != "" {
+			cardCounts[card.Name] += quantities[id]
+		}
+	}
+
+	similarity, err := c.similarity.Classify(ctx, format, cardCounts, partial)
+	if err != nil {
+		log.Printf("Warning: Reference archetype matching failed: %v", err)
+		return
+	}
+	result.ArchetypeMatches = similarity.Matches
+
+	top := similarity.Top()
+	if top == nil || similarity.Confidence < minReferenceConfidence {
+		return
+	}
+	result.PrimaryArchetype = top.Archetype
+	result.Confidence = similarity.Confidence
+	result.SecondaryArchetype = nil
+	if len(similarity.Matches) > 1 && similarity.Matches[1].Probability >= 0.2 {
+		secondary := similarity.Matches[1].Archetype
+		result.SecondaryArchetype = &secondary
+	}
 }
 
 // ClassifyDraftPool classifies a draft pool based on picked cards.
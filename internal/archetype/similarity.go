@@ -0,0 +1,456 @@
+package archetype
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/meta"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+const (
+	// referenceCacheTTL is how long a format's reference library is reused.
+	referenceCacheTTL = 30 * time.Minute
+
+	// maxArchetypeMatches caps the ranked distribution returned by Classify.
+	maxArchetypeMatches = 10
+
+	// distributionTemperature controls how sharply similarity differences turn
+	// into probability; lower values favor the best match more.
+	distributionTemperature = 0.1
+
+	// fullEvidenceCards is how many observed cards a partial list needs before
+	// its confidence is no longer discounted.
+	fullEvidenceCards = 5
+
+	// goldfishReferenceDecks is how many of a format's top metagame decks are
+	// used as references; each list costs two rate-limited requests.
+	goldfishReferenceDecks = 10
+)
+
+// basicLands are ignored when comparing lists; every deck of a color plays them.
+var basicLands = map[string]bool{
+	"plains": true, "island": true, "swamp": true, "mountain": true, "forest": true, "wastes": true,
+	"snow-covered plains": true, "snow-covered island": true, "snow-covered swamp": true,
+	"snow-covered mountain": true, "snow-covered forest": true,
+}
+
+// ReferenceDeck is a known decklist for an archetype.
+type ReferenceDeck struct {
+	Archetype string
+	Format    string
+	Source    string         // "mtgzone" or "goldfish"
+	Cards     map[string]int // Normalized card name -> copies
+}
+
+// ReferenceSource supplies reference decklists for a format. A source that
+// loads only some of its lists returns them along with the error.
+type ReferenceSource interface {
+	ReferenceDecks(ctx context.Context, format string) ([]*ReferenceDeck, error)
+}
+
+// ArchetypeMatch is one archetype in a similarity ranking.
+type ArchetypeMatch struct {
+	Archetype    string   `json:"archetype"`
+	Source       string   `json:"source"`
+	Similarity   float64  `json:"similarity"`  // Weighted Jaccard over TF-IDF card weights, 0.0-1.0
+	Probability  float64  `json:"probability"` // Share of the ranked distribution
+	MatchedCards []string `json:"matchedCards"`
+}
+
+// SimilarityResult is a ranked distribution over reference archetypes.
+type SimilarityResult struct {
+	Matches       []ArchetypeMatch `json:"matches"`       // Most likely first
+	Confidence    float64          `json:"confidence"`    // 0.0-1.0 for the top match
+	CardsCompared int              `json:"cardsCompared"` // Non-basic cards in the query
+	References    int              `json:"references"`    // Reference lists compared against
+}
+
+// Top returns the most likely match, or nil if nothing matched.
+func (r *SimilarityResult) Top() *ArchetypeMatch {
+	if r == nil || len(r.Matches) == 0 {
+		return nil
+	}
+	return &r.Matches[0]
+}
+
+// SimilarityClassifier matches card lists against reference decklists.
+type SimilarityClassifier struct {
+	sources []ReferenceSource
+
+	mu      sync.Mutex
+	cache   map[string]*referenceLibrary
+	loading map[string]*libraryLoad // Loads in progress by format
+}
+
+// libraryLoad is a reference library being loaded, shared by every caller
+// that asks for the format meanwhile.
+type libraryLoad struct {
+	done    chan struct{}
+	library *referenceLibrary
+	err     error
+}
+
+// referenceLibrary is the reference lists of one format with their card weights.
+type referenceLibrary struct {
+	decks    []*ReferenceDeck
+	idf      map[string]float64
+	missing  float64 // IDF of a card no reference plays
+	loadedAt time.Time
+}
+
+// NewSimilarityClassifier creates a classifier over the given reference sources.
+func NewSimilarityClassifier(sources ...ReferenceSource) *SimilarityClassifier {
+	return &SimilarityClassifier{
+		sources: sources,
+		cache:   make(map[string]*referenceLibrary),
+		loading: make(map[string]*libraryLoad),
+	}
+}
+
+// Classify ranks reference archetypes by similarity to a card list.
+//
+// When partial is true the list is what has been observed of an unknown deck,
+// such as an opponent's revealed cards; only observed cards are compared and
+// copies are ignored, so a handful of cards can still match a full reference.
+// Otherwise the list is a complete deck and cards missing from either side
+// count against the match.
+func (s *SimilarityClassifier) Classify(ctx context.Context, format string, cardCounts map[string]int, partial bool) (*SimilarityResult, error) {
+	library, err := s.library(ctx, format)
+	if err != nil {
+		return nil, err
+	}
+
+	query := make(map[string]int, len(cardCounts))
+	for name, copies := range cardCounts {
+		name = NormalizeCardName(name)
+		if name == "" || basicLands[name] {
+			continue
+		}
+		query[name] += copies
+	}
+
+	result := &SimilarityResult{
+		Matches:       []ArchetypeMatch{},
+		CardsCompared: len(query),
+		References:    len(library.decks),
+	}
+	if len(query) == 0 || len(library.decks) == 0 {
+		return result, nil
+	}
+
+	// Keep the best-matching list of each archetype
+	best := make(map[string]ArchetypeMatch)
+	for _, deck := range library.decks {
+		match := library.compare(query, deck, partial)
+		if match.Similarity <= 0 {
+			continue
+		}
+		key := strings.ToLower(deck.Archetype)
+		if existing, ok := best[key]; !ok || match.Similarity > existing.Similarity {
+			best[key] = match
+		}
+	}
+	if len(best) == 0 {
+		return result, nil
+	}
+
+	for _, match := range best {
+		result.Matches = append(result.Matches, match)
+	}
+	sort.Slice(result.Matches, func(i, j int) bool {
+		if result.Matches[i].Similarity != result.Matches[j].Similarity {
+			return result.Matches[i].Similarity > result.Matches[j].Similarity
+		}
+		return result.Matches[i].Archetype < result.Matches[j].Archetype
+	})
+
+	// Softmax over similarity gives the distribution
+	top := result.Matches[0].Similarity
+	total := 0.0
+	for i := range result.Matches {
+		result.Matches[i].Probability = math.Exp((result.Matches[i].Similarity - top) / distributionTemperature)
+		total += result.Matches[i].Probability
+	}
+	for i := range result.Matches {
+		result.Matches[i].Probability /= total
+	}
+	if len(result.Matches) > maxArchetypeMatches {
+		result.Matches = result.Matches[:maxArchetypeMatches]
+	}
+
+	// A confident call needs a close match that stands out from the rest,
+	// and for partial lists enough cards to go on
+	result.Confidence = result.Matches[0].Similarity * result.Matches[0].Probability
+	if partial && len(query) < fullEvidenceCards {
+		result.Confidence *= float64(len(query)) / fullEvidenceCards
+	}
+
+	return result, nil
+}
+
+// compare scores one reference list against a query with weighted Jaccard
+// similarity, weighting each card by its inverse document frequency.
+func (l *referenceLibrary) compare(query map[string]int, deck *ReferenceDeck, partial bool) ArchetypeMatch {
+	match := ArchetypeMatch{
+		Archetype:    deck.Archetype,
+		Source:       deck.Source,
+		MatchedCards: []string{},
+	}
+
+	var intersection, union float64
+	for name, copies := range query {
+		idf := l.weight(name)
+		refCopies := deck.Cards[name]
+		if refCopies > 0 {
+			match.MatchedCards = append(match.MatchedCards, name)
+		}
+
+		if partial {
+			// Presence only: we cannot know how many copies an unseen list plays
+			if refCopies > 0 {
+				intersection += idf
+			}
+			union += idf
+			continue
+		}
+		q, r := idf*copyWeight(copies), idf*copyWeight(refCopies)
+		intersection += math.Min(q, r)
+		union += math.Max(q, r)
+	}
+	if !partial {
+		for name, copies := range deck.Cards {
+			if _, ok := query[name]; !ok && !basicLands[name] {
+				union += l.weight(name) * copyWeight(copies)
+			}
+		}
+	}
+
+	if union > 0 {
+		match.Similarity = intersection / union
+	}
+	sort.Strings(match.MatchedCards)
+	return match
+}
+
+// weight returns a card's inverse document frequency in the library.
+func (l *referenceLibrary) weight(name string) float64 {
+	if idf, ok := l.idf[name]; ok {
+		return idf
+	}
+	return l.missing
+}
+
+// copyWeight scales a card's weight by copies, treating four as a full playset.
+func copyWeight(copies int) float64 {
+	if copies <= 0 {
+		return 0
+	}
+	return math.Min(float64(copies), 4) / 4
+}
+
+// library returns the cached reference library for a format, loading it from
+// every source when stale. Sources are read without holding the lock, and a
+// library missing lists from a failing source is used but not cached, so the
+// next call tries again.
+func (s *SimilarityClassifier) library(ctx context.Context, format string) (*referenceLibrary, error) {
+	key := strings.ToLower(format)
+
+	s.mu.Lock()
+	if cached, ok := s.cache[key]; ok && time.Since(cached.loadedAt) < referenceCacheTTL {
+		s.mu.Unlock()
+		return cached, nil
+	}
+	if load, ok := s.loading[key]; ok {
+		s.mu.Unlock()
+		select {
+		case <-load.done:
+			return load.library, load.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	load := &libraryLoad{done: make(chan struct{})}
+	s.loading[key] = load
+	s.mu.Unlock()
+
+	library, complete, err := s.loadLibrary(ctx, key)
+	load.library, load.err = library, err
+
+	s.mu.Lock()
+	delete(s.loading, key)
+	if complete {
+		s.cache[key] = library
+	}
+	s.mu.Unlock()
+	close(load.done)
+
+	return library, err
+}
+
+// loadLibrary reads every source's lists for a format. A failing source is
+// logged and skipped; complete reports whether every source loaded.
+func (s *SimilarityClassifier) loadLibrary(ctx context.Context, format string) (library *referenceLibrary, complete bool, err error) {
+	var decks []*ReferenceDeck
+	var lastErr error
+	for _, source := range s.sources {
+		sourceDecks, err := source.ReferenceDecks(ctx, format)
+		if err != nil {
+			log.Printf("Warning: Failed to load reference decks: %v", err)
+			lastErr = err
+		}
+		for _, deck := range sourceDecks {
+			if len(deck.Cards) > 0 {
+				decks = append(decks, deck)
+			}
+		}
+	}
+	if len(decks) == 0 && lastErr != nil {
+		return nil, false, fmt.Errorf("failed to load reference decks: %w", lastErr)
+	}
+	return newReferenceLibrary(decks), lastErr == nil, nil
+}
+
+// newReferenceLibrary computes smoothed IDF weights for the cards in decks.
+func newReferenceLibrary(decks []*ReferenceDeck) *referenceLibrary {
+	documentFrequency := make(map[string]int)
+	for _, deck := range decks {
+		for name := range deck.Cards {
+			documentFrequency[name]++
+		}
+	}
+
+	n := float64(len(decks))
+	library := &referenceLibrary{
+		decks:    decks,
+		idf:      make(map[string]float64, len(documentFrequency)),
+		missing:  math.Log(n+1) + 1,
+		loadedAt: time.Now(),
+	}
+	for name, df := range documentFrequency {
+		library.idf[name] = math.Log((n+1)/float64(df+1)) + 1
+	}
+	return library
+}
+
+// NormalizeCardName lowercases a card name and keeps only the front face of
+// split and double-faced cards, so names from different sources line up.
+func NormalizeCardName(name string) string {
+	if i := strings.Index(name, "//"); i >= 0 {
+		name = name[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// MTGZoneReferences reads archetype card lists stored from MTGZone articles.
+type MTGZoneReferences struct {
+	repo repository.MTGZoneRepository
+}
+
+// NewMTGZoneReferences creates a reference source backed by MTGZone archetypes.
+func NewMTGZoneReferences(repo repository.MTGZoneRepository) *MTGZoneReferences {
+	return &MTGZoneReferences{repo: repo}
+}
+
+// ReferenceDecks returns the core and flex cards of each archetype in a format.
+func (m *MTGZoneReferences) ReferenceDecks(ctx context.Context, format string) ([]*ReferenceDeck, error) {
+	archetypes, err := m.repo.GetArchetypesByFormat(ctx, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MTGZone archetypes: %w", err)
+	}
+
+	decks := make([]*ReferenceDeck, 0, len(archetypes))
+	for _, a := range archetypes {
+		archetypeCards, err := m.repo.GetArchetypeCards(ctx, a.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cards for archetype %s: %w", a.Name, err)
+		}
+
+		deck := &ReferenceDeck{
+			Archetype: a.Name,
+			Format:    a.Format,
+			Source:    "mtgzone",
+			Cards:     make(map[string]int),
+		}
+		for _, card := range archetypeCards {
+			if card.Role == models.CardRoleSideboard {
+				continue
+			}
+			copies := card.Copies
+			if copies <= 0 {
+				copies = 1
+			}
+			deck.Cards[NormalizeCardName(card.CardName)] += copies
+		}
+		decks = append(decks, deck)
+	}
+	return decks, nil
+}
+
+// TopDeckSource provides the current top decks of a format and their lists,
+// such as meta.GoldfishClient or meta.Service.
+type TopDeckSource interface {
+	GetTopDecks(ctx context.Context, format string, limit int) ([]*meta.MetaDeck, error)
+	GetDeckList(ctx context.Context, archetypeURL string) (mainboard, sideboard []meta.DeckCard, err error)
+}
+
+// GoldfishReferences uses the lists of a format's top metagame decks.
+type GoldfishReferences struct {
+	source TopDeckSource
+}
+
+// NewGoldfishReferences creates a reference source backed by metagame decks.
+func NewGoldfishReferences(source TopDeckSource) *GoldfishReferences {
+	return &GoldfishReferences{source: source}
+}
+
+// ReferenceDecks returns the mainboards of a format's top decks, fetching
+// each list from its archetype page when the deck does not carry one. Decks
+// without a list are skipped; lists that fail to load are reported in the
+// error after the rest are returned.
+func (g *GoldfishReferences) ReferenceDecks(ctx context.Context, format string) ([]*ReferenceDeck, error) {
+	metaDecks, err := g.source.GetTopDecks(ctx, format, goldfishReferenceDecks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get meta decks: %w", err)
+	}
+
+	var decks []*ReferenceDeck
+	var lastErr error
+	for _, metaDeck := range metaDecks {
+		name := metaDeck.Name
+		if name == "" {
+			name = metaDeck.ArchetypeName
+		}
+
+		mainboard := metaDeck.MainboardCards
+		if len(mainboard) == 0 && metaDeck.URL != "" {
+			mainboard, _, err = g.source.GetDeckList(ctx, metaDeck.URL)
+			if err != nil {
+				lastErr = fmt.Errorf("failed to get deck list for %s: %w", name, err)
+				continue
+			}
+		}
+		if len(mainboard) == 0 {
+			continue
+		}
+
+		deck := &ReferenceDeck{
+			Archetype: name,
+			Format:    metaDeck.Format,
+			Source:    "goldfish",
+			Cards:     make(map[string]int, len(mainboard)),
+		}
+		for _, card := range mainboard {
+			deck.Cards[NormalizeCardName(card.Name)] += card.Quantity
+		}
+		decks = append(decks, deck)
+	}
+	return decks, lastErr
+}
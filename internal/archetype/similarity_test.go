@@ -0,0 +1,256 @@
+package archetype
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/meta"
+)
+
+type staticReferences []*ReferenceDeck
+
+func (s staticReferences) ReferenceDecks(ctx context.Context, format string) ([]*ReferenceDeck, error) {
+	return s, nil
+}
+
+type failingReferences struct{}
+
+func (failingReferences) ReferenceDecks(ctx context.Context, format string) ([]*ReferenceDeck, error) {
+	return nil, errors.New("source unavailable")
+}
+
+type staticTopDecks struct {
+	decks []*meta.MetaDeck
+	lists map[string][]meta.DeckCard // Mainboards by archetype page URL
+}
+
+func (s staticTopDecks) GetTopDecks(ctx context.Context, format string, limit int) ([]*meta.MetaDeck, error) {
+	return s.decks, nil
+}
+
+func (s staticTopDecks) GetDeckList(ctx context.Context, archetypeURL string) ([]meta.DeckCard, []meta.DeckCard, error) {
+	list, ok := s.lists[archetypeURL]
+	if !ok {
+		return nil, nil, errors.New("deck list unavailable")
+	}
+	return list, nil, nil
+}
+
+// flakyReferences fails its first load, then supplies its lists.
+type flakyReferences struct {
+	decks staticReferences
+	loads int
+}
+
+func (f *flakyReferences) ReferenceDecks(ctx context.Context, format string) ([]*ReferenceDeck, error) {
+	f.loads++
+	if f.loads == 1 {
+		return nil, errors.New("source unavailable")
+	}
+	return f.decks, nil
+}
+
+func testReferences() staticReferences {
+	return staticReferences{
+		{
+			Archetype: "Mono-Red Aggro",
+			Source:    "mtgzone",
+			Cards: map[string]int{
+				"monastery swiftspear": 4, "play with fire": 4, "kumano faces kakkazan": 4,
+				"lightning strike": 4, "mountain": 20,
+			},
+		},
+		{
+			Archetype: "Izzet Spells",
+			Source:    "mtgzone",
+			Cards: map[string]int{
+				"lightning strike": 4, "consider": 4, "stormchaser's talent": 4,
+				"monastery swiftspear": 2, "island": 8, "mountain": 8,
+			},
+		},
+		{
+			Archetype: "Azorius Control",
+			Source:    "mtgzone",
+			Cards: map[string]int{
+				"consider": 4, "sunfall": 3, "memory deluge": 2, "no more lies": 4,
+			},
+		},
+	}
+}
+
+func TestSimilarityClassifier_PartialList(t *testing.T) {
+	classifier := NewSimilarityClassifier(testReferences())
+
+	result, err := classifier.Classify(context.Background(), "Standard", map[string]int{
+		"Play with Fire":        1,
+		"Kumano Faces Kakkazan": 1,
+		"Lightning Strike":      1,
+		"Mountain":              1,
+	}, true)
+	if err != nil {
+		t.Fatalf("Classify returned error: %v", err)
+	}
+
+	top := result.Top()
+	if top == nil || top.Archetype != "Mono-Red Aggro" {
+		t.Fatalf("expected Mono-Red Aggro first, got %+v", result.Matches)
+	}
+	if top.Similarity != 1 {
+		t.Errorf("every observed card is on the list, similarity = %v", top.Similarity)
+	}
+	if result.CardsCompared != 3 {
+		t.Errorf("basic lands should be ignored, compared %d cards", result.CardsCompared)
+	}
+	if len(result.Matches) != 2 {
+		t.Errorf("Azorius Control shares no cards and should be dropped, got %+v", result.Matches)
+	}
+
+	total := 0.0
+	for _, m := range result.Matches {
+		total += m.Probability
+	}
+	if total < 0.999 || total > 1.001 {
+		t.Errorf("probabilities sum to %v", total)
+	}
+
+	// Three cards are below fullEvidenceCards, so confidence is discounted
+	if want := top.Probability * 3 / fullEvidenceCards; result.Confidence-want > 1e-9 || want-result.Confidence > 1e-9 {
+		t.Errorf("confidence = %v, want %v", result.Confidence, want)
+	}
+}
+
+func TestSimilarityClassifier_SharedCardsWeighLess(t *testing.T) {
+	classifier := NewSimilarityClassifier(testReferences())
+
+	// Lightning Strike is in two lists; Stormchaser's Talent is only in Izzet
+	result, err := classifier.Classify(context.Background(), "standard", map[string]int{
+		"Lightning Strike":     1,
+		"Stormchaser's Talent": 1,
+	}, true)
+	if err != nil {
+		t.Fatalf("Classify returned error: %v", err)
+	}
+	if top := result.Top(); top == nil || top.Archetype != "Izzet Spells" {
+		t.Fatalf("expected Izzet Spells first, got %+v", result.Matches)
+	}
+	if result.Matches[1].Similarity >= 0.5 {
+		t.Errorf("a shared card should count for less than a distinctive one, got %v", result.Matches[1].Similarity)
+	}
+}
+
+func TestSimilarityClassifier_CompleteDeck(t *testing.T) {
+	classifier := NewSimilarityClassifier(testReferences())
+
+	result, err := classifier.Classify(context.Background(), "standard", map[string]int{
+		"Monastery Swiftspear":  4,
+		"Play with Fire":        4,
+		"Kumano Faces Kakkazan": 4,
+		"Lightning Strike":      2,
+		"Mountain":              20,
+	}, false)
+	if err != nil {
+		t.Fatalf("Classify returned error: %v", err)
+	}
+
+	top := result.Top()
+	if top == nil || top.Archetype != "Mono-Red Aggro" {
+		t.Fatalf("expected Mono-Red Aggro first, got %+v", result.Matches)
+	}
+	if top.Similarity >= 1 || top.Similarity < 0.8 {
+		t.Errorf("fewer copies of one card should lower similarity slightly, got %v", top.Similarity)
+	}
+	if result.Confidence < minReferenceConfidence {
+		t.Errorf("confidence %v should pass the classifier threshold", result.Confidence)
+	}
+}
+
+func TestSimilarityClassifier_NoReferences(t *testing.T) {
+	classifier := NewSimilarityClassifier(staticReferences{})
+
+	result, err := classifier.Classify(context.Background(), "standard", map[string]int{"Consider": 1}, true)
+	if err != nil {
+		t.Fatalf("Classify returned error: %v", err)
+	}
+	if result.Top() != nil || result.Confidence != 0 {
+		t.Errorf("expected no matches, got %+v", result)
+	}
+}
+
+func TestSimilarityClassifier_FailingSource(t *testing.T) {
+	// A failing source is skipped when another supplies lists
+	classifier := NewSimilarityClassifier(failingReferences{}, testReferences())
+	if _, err := classifier.Classify(context.Background(), "standard", map[string]int{"Consider": 1}, true); err != nil {
+		t.Errorf("expected the working source to be used, got %v", err)
+	}
+
+	classifier = NewSimilarityClassifier(failingReferences{})
+	if _, err := classifier.Classify(context.Background(), "standard", map[string]int{"Consider": 1}, true); err == nil {
+		t.Error("expected an error when every source fails")
+	}
+}
+
+func TestGoldfishReferences(t *testing.T) {
+	source := NewGoldfishReferences(staticTopDecks{
+		decks: []*meta.MetaDeck{
+			{Name: "Standard Mono-Red", ArchetypeName: "mono-red", Format: "standard", MainboardCards: []meta.DeckCard{
+				{Name: "Monastery Swiftspear", Quantity: 4},
+				{Name: "Fable of the Mirror-Breaker // Reflection of Kiki-Jiki", Quantity: 2},
+			}},
+			{Name: "Domain Ramp", Format: "standard"},
+			{Name: "Dimir Midrange", Format: "standard", URL: "https://www.mtggoldfish.com/archetype/dimir"},
+			{Name: "Esper Legends", Format: "standard", URL: "https://www.mtggoldfish.com/archetype/esper"},
+		},
+		lists: map[string][]meta.DeckCard{
+			"https://www.mtggoldfish.com/archetype/dimir": {{Name: "Sheoldred, the Apocalypse", Quantity: 4}},
+		},
+	})
+
+	decks, err := source.ReferenceDecks(context.Background(), "standard")
+	if err == nil {
+		t.Error("expected the list that failed to load to be reported")
+	}
+	if len(decks) != 2 {
+		t.Fatalf("decks without a list should be skipped, got %d", len(decks))
+	}
+	if decks[0].Archetype != "Standard Mono-Red" || decks[0].Source != "goldfish" {
+		t.Errorf("unexpected deck %+v", decks[0])
+	}
+	if decks[0].Cards["fable of the mirror-breaker"] != 2 {
+		t.Errorf("double-faced card should be keyed by its front face, got %+v", decks[0].Cards)
+	}
+	if decks[1].Archetype != "Dimir Midrange" || decks[1].Cards["sheoldred, the apocalypse"] != 4 {
+		t.Errorf("list should be fetched from the archetype page, got %+v", decks[1])
+	}
+}
+
+func TestSimilarityClassifier_RetriesIncompleteLibrary(t *testing.T) {
+	flaky := &flakyReferences{decks: testReferences()}
+	classifier := NewSimilarityClassifier(flaky, testReferences())
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := classifier.Classify(ctx, "standard", map[string]int{"Consider": 1}, true); err != nil {
+			t.Fatalf("Classify: %v", err)
+		}
+	}
+	// The first library missed the flaky source's lists, so it was not
+	// cached; the second was complete and served the third call
+	if flaky.loads != 2 {
+		t.Errorf("expected 2 loads of the flaky source, got %d", flaky.loads)
+	}
+}
+
+func TestNormalizeCardName(t *testing.T) {
+	tests := map[string]string{
+		"Lightning Strike":               "lightning strike",
+		"  Consider ":                    "consider",
+		"Fire // Ice":                    "fire",
+		"Brazen Borrower // Petty Theft": "brazen borrower",
+	}
+	for input, want := range tests {
+		if got := NormalizeCardName(input); got != want {
+			t.Errorf("NormalizeCardName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/logprocessor"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/logreader"
 )
 
@@ -268,7 +269,7 @@ func (r *ReplayEngine) streamEntries() {
 		if filterType == "draft" && r.isDraftEntry(entry) {
 			// Process this draft event immediately
 			log.Printf("Processing draft event immediately (entry %d, isPick=%v)", i, isDraftPick)
-			r.service.processEntries([]*logreader.LogEntry{entry})
+			r.service.processEntries(logprocessor.SourceReplay, []*logreader.LogEntry{entry})
 
 			// Emit draft:updated to refresh UI after processing
 			r.service.broadcastEvent(Event{
@@ -284,7 +285,7 @@ func (r *ReplayEngine) streamEntries() {
 			// Process batch when full or at end
 			if len(batch) >= batchSize || i == len(r.entries)-1 {
 				log.Printf("Processing batch of %d entries (batchFull=%v, isLast=%v)", len(batch), len(batch) >= batchSize, i == len(r.entries)-1)
-				r.service.processEntries(batch)
+				r.service.processEntries(logprocessor.SourceReplay, batch)
 				batch = batch[:0] // Clear batch
 			}
 		}
@@ -12,9 +12,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/analysis"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/ratings"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/signals"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/logprocessor"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/logreader"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
 )
 
 // Version is the daemon version
@@ -32,12 +37,19 @@ type Service struct {
 	config       *Config
 	storage      *storage.Service
 	logProcessor *logprocessor.Service
+	writeQueue   *logprocessor.WriteQueue // serializes logProcessor writes from all sources
 	poller       *logreader.Poller
 	wsServer     *WebSocketServer
 	ctx          context.Context
 	cancel       context.CancelFunc
 	startTime    time.Time
 
+	// Draft ratings with priors and the user's blends, as the app reads them
+	draftRatings repository.DraftRatingsRepository
+
+	// Opponent inferrer behind live opponent inference events
+	opponentInferrer *analysis.OpponentInferrer
+
 	// Replay engine for testing
 	replayEngine *ReplayEngine
 
@@ -69,6 +81,19 @@ func New(config *Config, storage *storage.Service) *Service {
 		cancel:       cancel,
 	}
 
+	if storage != nil {
+		s.draftRatings, _ = ratings.NewStorageRepository(storage)
+		s.opponentInferrer = analysis.NewOpponentInferrer(
+			storage.GamePlayRepo(),
+			storage.NewOpponentRepo(),
+			storage.MatchRepo(),
+			storage.SetCardRepo(),
+		)
+	}
+
+	// All log sources write through one queue so they never contend for the SQLite write lock
+	s.writeQueue = logprocessor.NewWriteQueue(s.logProcessor, logprocessor.DefaultWriteQueueConfig())
+
 	// Initialize replay engine
 	s.replayEngine = NewReplayEngine(s)
 
@@ -190,6 +215,11 @@ func (s *Service) Stop(ctx context.Context) error {
 			s.poller.Stop()
 		}
 
+		// Flush queued log writes
+		if s.writeQueue != nil {
+			s.writeQueue.Close()
+		}
+
 		// Stop WebSocket server
 		if s.wsServer != nil {
 			if err := s.wsServer.Stop(); err != nil {
@@ -226,6 +256,20 @@ func (s *Service) RegisterEventForwarder(forwarder EventForwarder) {
 	log.Printf("Registered event forwarder (total: %d)", len(s.forwarders))
 }
 
+// SetDraftRatings replaces the draft ratings the daemon reads, so a host
+// process that already has them shares one blend configuration. Call it
+// before Start.
+func (s *Service) SetDraftRatings(repo repository.DraftRatingsRepository) {
+	s.draftRatings = repo
+}
+
+// SetOpponentInferrer replaces the inferrer behind match:opponent_inference
+// events, so a host process with a metagame source reads opponents the same
+// way as its API. Call it before Start.
+func (s *Service) SetOpponentInferrer(inferrer *analysis.OpponentInferrer) {
+	s.opponentInferrer = inferrer
+}
+
 // broadcastEvent broadcasts an event to both the daemon's WebSocket clients
 // and any registered event forwarders (e.g., the API server).
 func (s *Service) broadcastEvent(event Event) {
@@ -282,17 +326,17 @@ func (s *Service) processUpdates(updates <-chan *logreader.LogEntry, errChan <-c
 		case <-ticker.C:
 			// Process buffered entries
 			if len(entryBuffer) > 0 {
-				s.processEntries(entryBuffer)
+				s.processEntries(logprocessor.SourcePoller, entryBuffer)
 				entryBuffer = nil // Clear buffer
 			}
 		}
 	}
 }
 
-// processEntries processes a batch of log entries.
-func (s *Service) processEntries(entries []*logreader.LogEntry) {
+// processEntries processes a batch of log entries read by source.
+func (s *Service) processEntries(source logprocessor.Source, entries []*logreader.LogEntry) {
 	log.Printf("Processing %d log entries...", len(entries))
-	result, err := s.logProcessor.ProcessLogEntries(s.ctx, entries)
+	result, err := s.writeQueue.Submit(s.ctx, source, entries)
 	if err != nil {
 		log.Printf("Error processing log entries: %v", err)
 
@@ -371,6 +415,118 @@ func (s *Service) processEntries(entries []*logreader.LogEntry) {
 				"picks": result.DraftPicksStored,
 			},
 		})
+		s.broadcastDraftSignals()
+	}
+
+	if result.GamePlayMatchID != "" && (result.GameSnapshotsStored > 0 || result.OpponentCardsStored > 0) {
+		s.broadcastOpponentInference(result.GamePlayMatchID)
+	}
+
+	for _, start := range result.MatchStarts {
+		s.broadcastScouting(start)
+	}
+
+	for _, hand := range result.PendingOpeningHands {
+		s.broadcastMulliganAdvice(hand)
+	}
+}
+
+// broadcastMulliganAdvice estimates whether an opening hand waiting on our
+// decision is better kept or mulliganed and broadcasts it as a
+// match:mulligan_advice event. Nothing is sent when the hand's deck is unknown.
+func (s *Service) broadcastMulliganAdvice(hand *models.OpeningHand) {
+	advisor := analysis.NewMulliganAdvisor(
+		s.storage.GamePlayRepo(),
+		s.storage.MatchRepo(),
+		s.storage.DeckRepo(),
+		s.storage.SetCardRepo(),
+	)
+	advice, err := advisor.AdviseOpeningHand(s.ctx, s.storage.CurrentAccountID(), hand)
+	if err != nil {
+		log.Printf("Warning: Failed to advise on opening hand for match %s: %v", hand.MatchID, err)
+		return
+	}
+	if advice == nil {
+		return
+	}
+	s.broadcastEvent(Event{
+		Type: "match:mulligan_advice",
+		Data: map[string]interface{}{
+			"match_id":       hand.MatchID,
+			"game_number":    hand.GameNumber,
+			"mulligan_count": hand.MulliganCount,
+			"advice":         advice,
+		},
+	})
+}
+
+// broadcastScouting looks up our history against a new match's opponent and
+// broadcasts it as a match:scouting event. The report is null on a first meeting.
+func (s *Service) broadcastScouting(start *logreader.MatchStart) {
+	report, err := s.storage.NewOpponentRepo().GetScoutingReport(s.ctx, s.storage.CurrentAccountID(), start.OpponentID)
+	if err != nil {
+		log.Printf("Warning: Failed to scout opponent %s for match %s: %v", start.OpponentName, start.MatchID, err)
+		return
+	}
+	s.broadcastEvent(Event{
+		Type: "match:scouting",
+		Data: map[string]interface{}{
+			"match_id":      start.MatchID,
+			"event_id":      start.EventID,
+			"opponent_id":   start.OpponentID,
+			"opponent_name": start.OpponentName,
+			"report":        report,
+		},
+	})
+}
+
+// broadcastOpponentInference re-reads the opponent's likely archetype and held
+// interaction for a match in progress and broadcasts it as a
+// match:opponent_inference event.
+func (s *Service) broadcastOpponentInference(matchID string) {
+	result, err := s.opponentInferrer.Infer(s.ctx, matchID)
+	if err != nil {
+		log.Printf("Warning: Failed to infer opponent deck for match %s: %v", matchID, err)
+		return
+	}
+	if result == nil {
+		return // No archetype expected cards to compare against
+	}
+	s.broadcastEvent(Event{
+		Type: "match:opponent_inference",
+		Data: map[string]interface{}{
+			"match_id":  matchID,
+			"inference": result,
+		},
+	})
+}
+
+// broadcastDraftSignals re-reads color signals for every active draft and
+// broadcasts them as draft:signals events.
+func (s *Service) broadcastDraftSignals() {
+	sessions, err := s.storage.DraftRepo().GetActiveSessions(s.ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to get active drafts for signals: %v", err)
+		return
+	}
+
+	analyzer := signals.NewAnalyzer(s.storage.DraftRepo(), s.draftRatings)
+	for _, session := range sessions {
+		result, err := analyzer.AnalyzeSession(s.ctx, session.ID)
+		if err != nil {
+			log.Printf("Warning: Failed to read signals for draft %s: %v", session.ID, err)
+			continue
+		}
+		if result == nil {
+			continue // No 17Lands ratings for this set yet
+		}
+		s.broadcastEvent(Event{
+			Type: "draft:signals",
+			Data: map[string]interface{}{
+				"session_id": session.ID,
+				"signals":    result,
+			},
+		})
 	}
 }
 
@@ -684,7 +840,7 @@ func (s *Service) ReplayHistoricalLogs(clearData bool) error {
 
 		log.Printf("Processing chunk %d/%d (%d entries)...", chunkIdx+1, totalChunks, len(chunk))
 
-		result, err := s.logProcessor.ProcessLogEntries(s.ctx, chunk)
+		result, err := s.writeQueue.Submit(s.ctx, logprocessor.SourceReplay, chunk)
 		if err != nil {
 			s.broadcastEvent(Event{
 				Type: "replay:error",
@@ -799,7 +955,7 @@ func (s *Service) StartupRecovery() error {
 		}
 
 		// Process entries through business logic
-		result, err := s.logProcessor.ProcessLogEntries(s.ctx, entries)
+		result, err := s.writeQueue.Submit(s.ctx, logprocessor.SourceRecovery, entries)
 		if err != nil {
 			log.Printf("Warning: Failed to process entries from %s: %v", logFile.Name, err)
 			continue
@@ -907,7 +1063,7 @@ func (s *Service) checkForNewLogFiles() error {
 			continue
 		}
 
-		result, err := s.logProcessor.ProcessLogEntries(s.ctx, entries)
+		result, err := s.writeQueue.Submit(s.ctx, logprocessor.SourceLogMonitor, entries)
 		if err != nil {
 			log.Printf("Warning: Failed to process %s: %v", logFile.Name, err)
 			continue
@@ -0,0 +1,511 @@
+package export
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+)
+
+// AnonymizedSchemaVersion is bumped whenever a column is added, removed or changes meaning.
+const AnonymizedSchemaVersion = 1
+
+// AnonymizedManifestFile is the name of the manifest written alongside the table files.
+const AnonymizedManifestFile = "manifest.json"
+
+// anonymizedDraftLimit caps how many completed drafts are read for a bundle.
+const anonymizedDraftLimit = 10000
+
+// AnonymizedOptions configures an anonymized bundle export.
+type AnonymizedOptions struct {
+	// Format is FormatCSV (one CSV file per table) or FormatJSONL (one JSONL file per table).
+	Format Format
+
+	// OutputDir is the directory the bundle is written to. It is created if missing.
+	OutputDir string
+
+	// Filter selects which matches are exported. Drafts are filtered by the
+	// same date range. AccountID is honoured but never written out.
+	Filter models.StatsFilter
+
+	// TimeBucket is the granularity timestamps are truncated to. Default: 24h.
+	TimeBucket time.Duration
+
+	// Salt keys the hashes used for pseudonymous IDs. When nil a random salt is
+	// generated. The salt is never written to the bundle, so keys cannot be
+	// linked back to the original IDs or across exports.
+	Salt []byte
+
+	// Overwrite allows replacing an existing bundle in OutputDir.
+	Overwrite bool
+}
+
+// AnonymizedMatchRow is one match in an anonymized bundle.
+type AnonymizedMatchRow struct {
+	MatchKey        string  `csv:"match_key" json:"match_key" desc:"Salted hash of the match ID"`
+	Day             string  `csv:"day" json:"day" desc:"Match start, truncated to the export's time bucket (RFC 3339, UTC)"`
+	EventID         string  `csv:"event_id" json:"event_id" desc:"MTGA event identifier, e.g. PremierDraft_DSK_20240917"`
+	EventName       string  `csv:"event_name" json:"event_name" desc:"MTGA event name"`
+	Format          string  `csv:"format" json:"format" desc:"Queue type (Ladder, Play, ...)"`
+	Result          string  `csv:"result" json:"result" desc:"win or loss"`
+	ResultReason    *string `csv:"result_reason" json:"result_reason,omitempty" desc:"How the match ended (normal, concede, timeout, ...)"`
+	PlayerWins      int     `csv:"player_wins" json:"player_wins" desc:"Games won by the exporting player"`
+	OpponentWins    int     `csv:"opponent_wins" json:"opponent_wins" desc:"Games won by the opponent"`
+	DurationSeconds *int    `csv:"duration_seconds" json:"duration_seconds,omitempty" desc:"Match length in seconds"`
+	RankBefore      *string `csv:"rank_before" json:"rank_before,omitempty" desc:"Rank before the match"`
+	RankAfter       *string `csv:"rank_after" json:"rank_after,omitempty" desc:"Rank after the match"`
+	DeckKey         string  `csv:"deck_key" json:"deck_key,omitempty" desc:"Salted hash of the deck ID; empty if unlinked"`
+	DeckFormat      *string `csv:"deck_format" json:"deck_format,omitempty" desc:"Deck format (Standard, Historic, ...)"`
+	OpponentKey     string  `csv:"opponent_key" json:"opponent_key,omitempty" desc:"Salted hash of the opponent's ID, or name when no ID was logged"`
+}
+
+// AnonymizedGameRow is one game within a match.
+type AnonymizedGameRow struct {
+	MatchKey        string  `csv:"match_key" json:"match_key" desc:"References matches.match_key"`
+	GameNumber      int     `csv:"game_number" json:"game_number" desc:"1-based game number within the match"`
+	Result          string  `csv:"result" json:"result" desc:"win or loss"`
+	ResultReason    *string `csv:"result_reason" json:"result_reason,omitempty" desc:"How the game ended"`
+	DurationSeconds *int    `csv:"duration_seconds" json:"duration_seconds,omitempty" desc:"Game length in seconds"`
+}
+
+// AnonymizedPlayRow is one recorded action within a game.
+type AnonymizedPlayRow struct {
+	MatchKey       string  `csv:"match_key" json:"match_key" desc:"References matches.match_key"`
+	GameNumber     int     `csv:"game_number" json:"game_number" desc:"References games.game_number within the match"`
+	SequenceNumber int     `csv:"sequence_number" json:"sequence_number" desc:"Order of the action within the game"`
+	TurnNumber     int     `csv:"turn_number" json:"turn_number" desc:"Game turn"`
+	Phase          string  `csv:"phase" json:"phase" desc:"Turn phase (Main1, Combat, ...)"`
+	Step           string  `csv:"step" json:"step,omitempty" desc:"Turn step (DeclareAttackers, ...)"`
+	PlayerType     string  `csv:"player_type" json:"player_type" desc:"player or opponent"`
+	ActionType     string  `csv:"action_type" json:"action_type" desc:"play_card, attack, block, land_drop or mulligan"`
+	CardID         *int    `csv:"card_id" json:"card_id,omitempty" desc:"Arena card ID"`
+	ZoneFrom       *string `csv:"zone_from" json:"zone_from,omitempty" desc:"Source zone"`
+	ZoneTo         *string `csv:"zone_to" json:"zone_to,omitempty" desc:"Destination zone"`
+}
+
+// AnonymizedDraftRow is one draft session.
+type AnonymizedDraftRow struct {
+	DraftKey         string   `csv:"draft_key" json:"draft_key" desc:"Salted hash of the draft session ID"`
+	Day              string   `csv:"day" json:"day" desc:"Draft start, truncated to the export's time bucket (RFC 3339, UTC)"`
+	EventName        string   `csv:"event_name" json:"event_name" desc:"MTGA event name"`
+	SetCode          string   `csv:"set_code" json:"set_code" desc:"Set drafted"`
+	DraftType        string   `csv:"draft_type" json:"draft_type" desc:"quick_draft, premier_draft, ..."`
+	Status           string   `csv:"status" json:"status" desc:"in_progress, completed or abandoned"`
+	TotalPicks       int      `csv:"total_picks" json:"total_picks" desc:"Expected number of picks in the draft"`
+	OverallGrade     *string  `csv:"overall_grade" json:"overall_grade,omitempty" desc:"Draft grade (A+ to F)"`
+	OverallScore     *int     `csv:"overall_score" json:"overall_score,omitempty" desc:"Draft score (0-100)"`
+	PredictedWinRate *float64 `csv:"predicted_win_rate" json:"predicted_win_rate,omitempty" desc:"Predicted deck win rate (0.0-1.0)"`
+}
+
+// AnonymizedPickRow is one pick within a draft.
+type AnonymizedPickRow struct {
+	DraftKey         string  `csv:"draft_key" json:"draft_key" desc:"References drafts.draft_key"`
+	PackNumber       int     `csv:"pack_number" json:"pack_number" desc:"0-based pack number"`
+	PickNumber       int     `csv:"pick_number" json:"pick_number" desc:"Pick number within the pack"`
+	CardID           string  `csv:"card_id" json:"card_id" desc:"Arena card ID picked"`
+	PackCardIDs      string  `csv:"pack_card_ids" json:"pack_card_ids,omitempty" desc:"Space-separated Arena card IDs in the pack when the pick was made"`
+	PickQualityGrade *string `csv:"pick_quality_grade" json:"pick_quality_grade,omitempty" desc:"Pick grade (A+ to F)"`
+	PickQualityRank  *int    `csv:"pick_quality_rank" json:"pick_quality_rank,omitempty" desc:"Rank of the picked card in the pack (1 = best)"`
+}
+
+// AnonymizedManifest describes an anonymized bundle: what it contains, how it
+// was anonymized and the schema of every table.
+type AnonymizedManifest struct {
+	SchemaVersion     int                `json:"schema_version"`
+	ExportedFrom      string             `json:"exported_from"`
+	ExportedDay       string             `json:"exported_day"`
+	Format            Format             `json:"format"`
+	TimeBucketSeconds int64              `json:"time_bucket_seconds"`
+	Anonymization     []string           `json:"anonymization"`
+	Tables            []*AnonymizedTable `json:"tables"`
+}
+
+// AnonymizedTable describes one file in an anonymized bundle.
+type AnonymizedTable struct {
+	Name    string              `json:"name"`
+	File    string              `json:"file"`
+	Rows    int                 `json:"rows"`
+	SHA256  string              `json:"sha256"`
+	Columns []*AnonymizedColumn `json:"columns"`
+}
+
+// AnonymizedColumn documents one column of an anonymized table.
+type AnonymizedColumn struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Nullable    bool   `json:"nullable"`
+	Description string `json:"description"`
+}
+
+// anonymizer turns identifying values into salted keys and coarse timestamps.
+type anonymizer struct {
+	salt   []byte
+	bucket time.Duration
+}
+
+// key returns a stable pseudonym for value within namespace, or "" for an empty value.
+// Namespacing keeps, say, a deck and a match with the same ID from sharing a key.
+func (a *anonymizer) key(namespace, value string) string {
+	if value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, a.salt)
+	mac.Write([]byte(namespace))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// day truncates t to the time bucket in UTC.
+func (a *anonymizer) day(t time.Time) string {
+	return t.UTC().Truncate(a.bucket).Format(time.RFC3339)
+}
+
+// ExportAnonymized writes matches, games, plays, drafts and picks to opts.OutputDir
+// as an anonymized bundle with a manifest, and returns the manifest.
+//
+// Opponent names and IDs, match, deck and draft IDs are replaced with salted
+// hashes; the account, notes and free-text fields are dropped; and timestamps are
+// truncated to opts.TimeBucket. Card IDs, events and results are kept as-is.
+func ExportAnonymized(ctx context.Context, service *storage.Service, opts AnonymizedOptions) (*AnonymizedManifest, error) {
+	if opts.OutputDir == "" {
+		return nil, fmt.Errorf("output directory is required")
+	}
+	if opts.Format == "" {
+		opts.Format = FormatCSV
+	}
+	if opts.Format != FormatCSV && opts.Format != FormatJSONL {
+		return nil, fmt.Errorf("unsupported anonymized export format: %s (must be csv or jsonl)", opts.Format)
+	}
+	if opts.TimeBucket <= 0 {
+		opts.TimeBucket = 24 * time.Hour
+	}
+
+	salt := opts.Salt
+	if len(salt) == 0 {
+		salt = make([]byte, 32)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, fmt.Errorf("failed to generate salt: %w", err)
+		}
+	}
+	anon := &anonymizer{salt: salt, bucket: opts.TimeBucket}
+
+	manifestPath := filepath.Join(opts.OutputDir, AnonymizedManifestFile)
+	if _, err := os.Stat(manifestPath); err == nil && !opts.Overwrite {
+		return nil, fmt.Errorf("bundle already exists: %s (use overwrite option to replace)", opts.OutputDir)
+	}
+	if err := os.MkdirAll(opts.OutputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	matches, games, plays, err := collectAnonymizedMatches(ctx, service, anon, opts.Filter)
+	if err != nil {
+		return nil, err
+	}
+	drafts, picks, err := collectAnonymizedDrafts(ctx, service, anon, opts.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &AnonymizedManifest{
+		SchemaVersion:     AnonymizedSchemaVersion,
+		ExportedFrom:      "MTGA-Companion",
+		ExportedDay:       anon.day(time.Now()),
+		Format:            opts.Format,
+		TimeBucketSeconds: int64(opts.TimeBucket.Seconds()),
+		Anonymization: []string{
+			"match, deck, draft and opponent identifiers are HMAC-SHA256 hashes keyed by a salt that is not included",
+			"keys are consistent within this bundle and cannot be joined with other exports unless they were made with the same salt",
+			"account, opponent names, notes and deck names are not exported",
+			"timestamps are truncated to time_bucket_seconds; per-action timestamps are dropped in favour of sequence numbers",
+		},
+	}
+
+	tables := []struct {
+		name string
+		rows interface{}
+	}{
+		{"matches", matches},
+		{"games", games},
+		{"plays", plays},
+		{"drafts", drafts},
+		{"picks", picks},
+	}
+	for _, table := range tables {
+		info, err := writeAnonymizedTable(opts.OutputDir, table.name, opts.Format, table.rows)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Tables = append(manifest.Tables, info)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(manifestPath, data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// collectAnonymizedMatches loads matches with their games and plays and anonymizes them.
+func collectAnonymizedMatches(ctx context.Context, service *storage.Service, anon *anonymizer, filter models.StatsFilter) ([]*AnonymizedMatchRow, []*AnonymizedGameRow, []*AnonymizedPlayRow, error) {
+	matches, err := service.GetMatches(ctx, filter)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+
+	gamePlays := service.NewReadOnlyGamePlayRepo()
+	matchRows := make([]*AnonymizedMatchRow, 0, len(matches))
+	var gameRows []*AnonymizedGameRow
+	var playRows []*AnonymizedPlayRow
+
+	for _, m := range matches {
+		matchKey := anon.key("match", m.ID)
+
+		row := &AnonymizedMatchRow{
+			MatchKey:        matchKey,
+			Day:             anon.day(m.Timestamp),
+			EventID:         m.EventID,
+			EventName:       m.EventName,
+			Format:          m.Format,
+			Result:          m.Result,
+			ResultReason:    m.ResultReason,
+			PlayerWins:      m.PlayerWins,
+			OpponentWins:    m.OpponentWins,
+			DurationSeconds: m.DurationSeconds,
+			RankBefore:      m.RankBefore,
+			RankAfter:       m.RankAfter,
+			DeckFormat:      m.DeckFormat,
+		}
+		if m.DeckID != nil {
+			row.DeckKey = anon.key("deck", *m.DeckID)
+		}
+		// Prefer the stable opponent ID; fall back to the display name
+		switch {
+		case m.OpponentID != nil && *m.OpponentID != "":
+			row.OpponentKey = anon.key("opponent", *m.OpponentID)
+		case m.OpponentName != nil && *m.OpponentName != "":
+			row.OpponentKey = anon.key("opponent-name", *m.OpponentName)
+		}
+		matchRows = append(matchRows, row)
+
+		games, err := service.GetGamesForMatch(ctx, m.ID)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to get games for match: %w", err)
+		}
+		gameNumbers := make(map[int]int, len(games))
+		for _, g := range games {
+			gameNumbers[g.ID] = g.GameNumber
+			gameRows = append(gameRows, &AnonymizedGameRow{
+				MatchKey:        matchKey,
+				GameNumber:      g.GameNumber,
+				Result:          g.Result,
+				ResultReason:    g.ResultReason,
+				DurationSeconds: g.DurationSeconds,
+			})
+		}
+
+		plays, err := gamePlays.GetPlaysByMatch(ctx, m.ID)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to get plays for match: %w", err)
+		}
+		for _, p := range plays {
+			playRows = append(playRows, &AnonymizedPlayRow{
+				MatchKey:       matchKey,
+				GameNumber:     gameNumbers[p.GameID],
+				SequenceNumber: p.SequenceNumber,
+				TurnNumber:     p.TurnNumber,
+				Phase:          p.Phase,
+				Step:           p.Step,
+				PlayerType:     p.PlayerType,
+				ActionType:     p.ActionType,
+				CardID:         p.CardID,
+				ZoneFrom:       p.ZoneFrom,
+				ZoneTo:         p.ZoneTo,
+			})
+		}
+	}
+
+	return matchRows, gameRows, playRows, nil
+}
+
+// collectAnonymizedDrafts loads draft sessions in the filter's date range with
+// their picks and pack contents and anonymizes them.
+func collectAnonymizedDrafts(ctx context.Context, service *storage.Service, anon *anonymizer, filter models.StatsFilter) ([]*AnonymizedDraftRow, []*AnonymizedPickRow, error) {
+	draftRepo := service.NewReadOnlyDraftRepo()
+
+	active, err := draftRepo.GetActiveSessions(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get active drafts: %w", err)
+	}
+	completed, err := draftRepo.GetCompletedSessions(ctx, anonymizedDraftLimit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get completed drafts: %w", err)
+	}
+
+	var draftRows []*AnonymizedDraftRow
+	var pickRows []*AnonymizedPickRow
+
+	for _, s := range append(active, completed...) {
+		if filter.StartDate != nil && s.StartTime.Before(*filter.StartDate) {
+			continue
+		}
+		if filter.EndDate != nil && s.StartTime.After(*filter.EndDate) {
+			continue
+		}
+
+		draftKey := anon.key("draft", s.ID)
+		draftRows = append(draftRows, &AnonymizedDraftRow{
+			DraftKey:         draftKey,
+			Day:              anon.day(s.StartTime),
+			EventName:        s.EventName,
+			SetCode:          s.SetCode,
+			DraftType:        s.DraftType,
+			Status:           s.Status,
+			TotalPicks:       s.TotalPicks,
+			OverallGrade:     s.OverallGrade,
+			OverallScore:     s.OverallScore,
+			PredictedWinRate: s.PredictedWinRate,
+		})
+
+		picks, err := draftRepo.GetPicksBySession(ctx, s.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get picks for draft: %w", err)
+		}
+		packs, err := draftRepo.GetPacksBySession(ctx, s.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get packs for draft: %w", err)
+		}
+		packContents := make(map[[2]int]string, len(packs))
+		for _, p := range packs {
+			packContents[[2]int{p.PackNumber, p.PickNumber}] = strings.Join(p.CardIDs, " ")
+		}
+
+		for _, p := range picks {
+			pickRows = append(pickRows, &AnonymizedPickRow{
+				DraftKey:         draftKey,
+				PackNumber:       p.PackNumber,
+				PickNumber:       p.PickNumber,
+				CardID:           p.CardID,
+				PackCardIDs:      packContents[[2]int{p.PackNumber, p.PickNumber}],
+				PickQualityGrade: p.PickQualityGrade,
+				PickQualityRank:  p.PickQualityRank,
+			})
+		}
+	}
+
+	return draftRows, pickRows, nil
+}
+
+// writeAnonymizedTable writes rows (a slice of pointers to row structs) to
+// dir/<name>.<format> and describes the result for the manifest.
+func writeAnonymizedTable(dir, name string, format Format, rows interface{}) (info *AnonymizedTable, err error) {
+	v := reflect.ValueOf(rows)
+	rowType := v.Type().Elem().Elem()
+
+	info = &AnonymizedTable{
+		Name:    name,
+		File:    fmt.Sprintf("%s.%s", name, format),
+		Rows:    v.Len(),
+		Columns: anonymizedColumns(rowType),
+	}
+
+	file, err := os.Create(filepath.Join(dir, info.File))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", info.File, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	sum := sha256.New()
+	w := io.MultiWriter(file, sum)
+
+	if format == FormatJSONL {
+		err = writeJSONLRows(w, v)
+	} else {
+		err = writeCSVRows(w, rowType, v)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", info.File, err)
+	}
+
+	info.SHA256 = hex.EncodeToString(sum.Sum(nil))
+	return info, nil
+}
+
+// writeCSVRows writes a header for rowType followed by every row, even when there are none.
+func writeCSVRows(w io.Writer, rowType reflect.Type, rows reflect.Value) error {
+	exporter := &Exporter{}
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(exporter.getCSVHeaders(rowType)); err != nil {
+		return err
+	}
+	for i := 0; i < rows.Len(); i++ {
+		if err := writer.Write(exporter.structToCSVRow(rows.Index(i).Elem())); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeJSONLRows writes each row as a single line of JSON.
+func writeJSONLRows(w io.Writer, rows reflect.Value) error {
+	encoder := json.NewEncoder(w)
+	for i := 0; i < rows.Len(); i++ {
+		if err := encoder.Encode(rows.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// anonymizedColumns documents rowType's columns from its csv, json and desc tags.
+func anonymizedColumns(rowType reflect.Type) []*AnonymizedColumn {
+	columns := make([]*AnonymizedColumn, 0, rowType.NumField())
+	for i := 0; i < rowType.NumField(); i++ {
+		field := rowType.Field(i)
+		fieldType := field.Type
+		nullable := fieldType.Kind() == reflect.Ptr
+		if nullable {
+			fieldType = fieldType.Elem()
+		}
+
+		columnType := "string"
+		switch fieldType.Kind() {
+		case reflect.Int, reflect.Int64:
+			columnType = "integer"
+		case reflect.Float64:
+			columnType = "number"
+		}
+
+		columns = append(columns, &AnonymizedColumn{
+			Name:        field.Tag.Get("csv"),
+			Type:        columnType,
+			Nullable:    nullable || strings.Contains(field.Tag.Get("json"), "omitempty"),
+			Description: field.Tag.Get("desc"),
+		})
+	}
+	return columns
+}
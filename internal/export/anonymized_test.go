@@ -0,0 +1,289 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+)
+
+// setupAnonymizedTestService creates a migrated storage service holding one
+// match (with a game and a play) and one draft (with a pick and its pack).
+func setupAnonymizedTestService(t *testing.T) *storage.Service {
+	t.Helper()
+
+	config := storage.DefaultConfig(filepath.Join(t.TempDir(), "test.db"))
+	config.AutoMigrate = true
+	db, err := storage.Open(config)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	service := storage.NewService(db)
+	t.Cleanup(func() { _ = service.Close() })
+
+	ctx := context.Background()
+	played := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	opponentName := "SecretPlayer#12345"
+	opponentID := "OPPONENT-USER-ID"
+	deckID := "deck-abc"
+	reason := "normal"
+
+	match := &models.Match{
+		ID:           "match-guid-1",
+		AccountID:    1,
+		EventID:      "Ladder",
+		EventName:    "Ladder",
+		Timestamp:    played,
+		PlayerWins:   2,
+		OpponentWins: 1,
+		PlayerTeamID: 1,
+		DeckID:       &deckID,
+		Format:       "Ladder",
+		Result:       "win",
+		ResultReason: &reason,
+		OpponentName: &opponentName,
+		OpponentID:   &opponentID,
+	}
+	game := &models.Game{MatchID: match.ID, GameNumber: 1, Result: "win", CreatedAt: played}
+	if err := service.StoreMatch(ctx, match, []*models.Game{game}); err != nil {
+		t.Fatalf("Failed to store match: %v", err)
+	}
+
+	cardID := 90001
+	play := &models.GamePlay{
+		GameID:         game.ID,
+		MatchID:        match.ID,
+		TurnNumber:     1,
+		Phase:          "Main1",
+		PlayerType:     "player",
+		ActionType:     "land_drop",
+		CardID:         &cardID,
+		Timestamp:      played.Add(time.Minute),
+		SequenceNumber: 1,
+	}
+	if err := service.GamePlayRepo().CreatePlay(ctx, play); err != nil {
+		t.Fatalf("Failed to store play: %v", err)
+	}
+
+	draftRepo := service.DraftRepo()
+	session := &models.DraftSession{
+		ID:         "draft-session-1",
+		EventName:  "QuickDraft_TST_20250314",
+		SetCode:    "TST",
+		DraftType:  "quick_draft",
+		StartTime:  played,
+		Status:     "completed",
+		TotalPicks: 42,
+	}
+	if err := draftRepo.CreateSession(ctx, session); err != nil {
+		t.Fatalf("Failed to store draft session: %v", err)
+	}
+	if err := draftRepo.SavePack(ctx, &models.DraftPackSession{
+		SessionID: session.ID, PackNumber: 0, PickNumber: 1, CardIDs: []string{"1", "2", "3"}, Timestamp: played,
+	}); err != nil {
+		t.Fatalf("Failed to store pack: %v", err)
+	}
+	if err := draftRepo.SavePick(ctx, &models.DraftPickSession{
+		SessionID: session.ID, PackNumber: 0, PickNumber: 1, CardID: "2", Timestamp: played,
+	}); err != nil {
+		t.Fatalf("Failed to store pick: %v", err)
+	}
+
+	return service
+}
+
+// readBundle concatenates every file in dir for leak checks.
+func readBundle(t *testing.T, dir string) string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read bundle: %v", err)
+	}
+	var all strings.Builder
+	for _, e := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", e.Name(), err)
+		}
+		all.Write(data)
+	}
+	return all.String()
+}
+
+func TestExportAnonymized_CSV(t *testing.T) {
+	service := setupAnonymizedTestService(t)
+	dir := filepath.Join(t.TempDir(), "bundle")
+
+	manifest, err := ExportAnonymized(context.Background(), service, AnonymizedOptions{
+		Format:    FormatCSV,
+		OutputDir: dir,
+	})
+	if err != nil {
+		t.Fatalf("ExportAnonymized failed: %v", err)
+	}
+
+	wantRows := map[string]int{"matches": 1, "games": 1, "plays": 1, "drafts": 1, "picks": 1}
+	if len(manifest.Tables) != len(wantRows) {
+		t.Fatalf("manifest has %d tables, want %d", len(manifest.Tables), len(wantRows))
+	}
+	for _, table := range manifest.Tables {
+		if table.Rows != wantRows[table.Name] {
+			t.Errorf("%s: %d rows, want %d", table.Name, table.Rows, wantRows[table.Name])
+		}
+		if len(table.SHA256) != 64 {
+			t.Errorf("%s: missing checksum", table.Name)
+		}
+		for _, col := range table.Columns {
+			if col.Name == "" || col.Description == "" {
+				t.Errorf("%s: undocumented column %+v", table.Name, col)
+			}
+		}
+	}
+
+	// Nothing identifying may appear anywhere in the bundle
+	bundle := readBundle(t, dir)
+	for _, secret := range []string{"match-guid-1", "SecretPlayer", "OPPONENT-USER-ID", "deck-abc", "draft-session-1", "15:09"} {
+		if strings.Contains(bundle, secret) {
+			t.Errorf("bundle leaks %q", secret)
+		}
+	}
+
+	f, err := os.Open(filepath.Join(dir, "matches.csv"))
+	if err != nil {
+		t.Fatalf("Failed to open matches.csv: %v", err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse matches.csv: %v", err)
+	}
+	header, row := records[0], records[1]
+	get := func(col string) string {
+		for i, name := range header {
+			if name == col {
+				return row[i]
+			}
+		}
+		t.Fatalf("matches.csv has no %s column", col)
+		return ""
+	}
+	if got := get("day"); got != "2025-03-14T00:00:00Z" {
+		t.Errorf("day = %q, want timestamp truncated to the day", got)
+	}
+	if get("opponent_key") == "" || get("deck_key") == "" || get("match_key") == "" {
+		t.Error("expected hashed keys for match, deck and opponent")
+	}
+	if get("result") != "win" || get("player_wins") != "2" {
+		t.Errorf("match outcome not preserved: %v", row)
+	}
+}
+
+func TestExportAnonymized_JSONLKeysAreConsistentWithinBundle(t *testing.T) {
+	service := setupAnonymizedTestService(t)
+	dir := t.TempDir()
+
+	if _, err := ExportAnonymized(context.Background(), service, AnonymizedOptions{
+		Format:    FormatJSONL,
+		OutputDir: dir,
+	}); err != nil {
+		t.Fatalf("ExportAnonymized failed: %v", err)
+	}
+
+	readFirst := func(name string, v interface{}) {
+		t.Helper()
+		f, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", name, err)
+		}
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		if !scanner.Scan() {
+			t.Fatalf("%s is empty", name)
+		}
+		if err := json.Unmarshal(scanner.Bytes(), v); err != nil {
+			t.Fatalf("Failed to decode %s: %v", name, err)
+		}
+	}
+
+	var match AnonymizedMatchRow
+	var game AnonymizedGameRow
+	var play AnonymizedPlayRow
+	var draft AnonymizedDraftRow
+	var pick AnonymizedPickRow
+	readFirst("matches.jsonl", &match)
+	readFirst("games.jsonl", &game)
+	readFirst("plays.jsonl", &play)
+	readFirst("drafts.jsonl", &draft)
+	readFirst("picks.jsonl", &pick)
+
+	if game.MatchKey != match.MatchKey || play.MatchKey != match.MatchKey {
+		t.Error("games and plays should reference the match by the same key")
+	}
+	if play.GameNumber != 1 {
+		t.Errorf("play game_number = %d, want 1", play.GameNumber)
+	}
+	if pick.DraftKey != draft.DraftKey {
+		t.Error("picks should reference the draft by the same key")
+	}
+	if pick.PackCardIDs != "1 2 3" {
+		t.Errorf("pack_card_ids = %q, want %q", pick.PackCardIDs, "1 2 3")
+	}
+}
+
+func TestExportAnonymized_SaltChangesKeys(t *testing.T) {
+	service := setupAnonymizedTestService(t)
+	ctx := context.Background()
+
+	keyFor := func(salt string) string {
+		dir := t.TempDir()
+		if _, err := ExportAnonymized(ctx, service, AnonymizedOptions{
+			Format:    FormatJSONL,
+			OutputDir: dir,
+			Salt:      []byte(salt),
+		}); err != nil {
+			t.Fatalf("ExportAnonymized failed: %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "matches.jsonl"))
+		if err != nil {
+			t.Fatalf("Failed to read matches.jsonl: %v", err)
+		}
+		var row AnonymizedMatchRow
+		if err := json.Unmarshal(data, &row); err != nil {
+			t.Fatalf("Failed to decode match: %v", err)
+		}
+		return row.OpponentKey
+	}
+
+	if keyFor("salt-a") == keyFor("salt-b") {
+		t.Error("different salts should produce different opponent keys")
+	}
+	if keyFor("salt-a") != keyFor("salt-a") {
+		t.Error("the same salt should produce the same opponent key")
+	}
+}
+
+func TestExportAnonymized_RefusesToOverwrite(t *testing.T) {
+	service := setupAnonymizedTestService(t)
+	dir := t.TempDir()
+	opts := AnonymizedOptions{Format: FormatCSV, OutputDir: dir}
+
+	if _, err := ExportAnonymized(context.Background(), service, opts); err != nil {
+		t.Fatalf("first export failed: %v", err)
+	}
+	if _, err := ExportAnonymized(context.Background(), service, opts); err == nil {
+		t.Error("expected an error when a bundle already exists")
+	}
+
+	opts.Overwrite = true
+	if _, err := ExportAnonymized(context.Background(), service, opts); err != nil {
+		t.Errorf("overwrite export failed: %v", err)
+	}
+}
@@ -31,6 +31,7 @@ type CardTrendExport struct {
 
 // ExportCardPerformanceTrend exports a card's performance trend over time.
 func ExportCardPerformanceTrend(ctx context.Context, service *storage.Service, arenaID int, expansion string, days int, opts Options) error {
+	setCards := service.NewReadOnlySetCardRepo()
 	// Get card trend data
 	trend, err := service.GetCardWinRateTrend(ctx, arenaID, expansion, days)
 	if err != nil {
@@ -58,7 +59,7 @@ func ExportCardPerformanceTrend(ctx context.Context, service *storage.Service, a
 	// Get card name if available
 	cardName := trend.CardName
 	if cardName == "" {
-		card, err := service.SetCardRepo().GetCardByArenaID(ctx, fmt.Sprintf("%d", arenaID))
+		card, err := setCards.GetCardByArenaID(ctx, fmt.Sprintf("%d", arenaID))
 		if err == nil && card != nil {
 			cardName = card.Name
 		}
@@ -96,6 +97,7 @@ type MultipleCardTrendsExport struct {
 
 // ExportMultipleCardTrends exports trends for multiple cards for comparison.
 func ExportMultipleCardTrends(ctx context.Context, service *storage.Service, arenaIDs []int, expansion string, days int, opts Options) error {
+	setCards := service.NewReadOnlySetCardRepo()
 	trends := make([]*CardTrendExport, 0, len(arenaIDs))
 
 	for _, arenaID := range arenaIDs {
@@ -127,7 +129,7 @@ func ExportMultipleCardTrends(ctx context.Context, service *storage.Service, are
 		// Get card name if available
 		cardName := trend.CardName
 		if cardName == "" {
-			card, err := service.SetCardRepo().GetCardByArenaID(ctx, fmt.Sprintf("%d", arenaID))
+			card, err := setCards.GetCardByArenaID(ctx, fmt.Sprintf("%d", arenaID))
 			if err == nil && card != nil {
 				cardName = card.Name
 			}
@@ -0,0 +1,223 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/postmortem"
+)
+
+// ExportDraftPostMortem renders a draft post-mortem report as Markdown, HTML or JSON.
+func ExportDraftPostMortem(w io.Writer, report *postmortem.Report, format Format) error {
+	if report == nil {
+		return fmt.Errorf("post-mortem report is required")
+	}
+
+	switch format {
+	case FormatMarkdown:
+		return exportPostMortemMarkdown(w, report)
+	case FormatHTML:
+		return postMortemHTML.Execute(w, report)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported post-mortem format: %s", format)
+	}
+}
+
+// exportPostMortemMarkdown writes the report as Markdown.
+func exportPostMortemMarkdown(w io.Writer, report *postmortem.Report) error {
+	var buf bytes.Buffer
+
+	buf.WriteString(fmt.Sprintf("# %s Draft Post-Mortem\n\n", strings.ToUpper(report.SetCode)))
+	buf.WriteString(fmt.Sprintf("**Event:** %s\n\n", report.EventName))
+	buf.WriteString(fmt.Sprintf("**Drafted:** %s\n\n", report.StartTime.Format("2006-01-02 15:04")))
+	buf.WriteString(fmt.Sprintf("**Record:** %d-%d\n\n", report.Record.Wins, report.Record.Losses))
+
+	// Summary
+	buf.WriteString("## Summary\n\n")
+	if report.Grade != nil {
+		buf.WriteString(fmt.Sprintf("- **Grade:** %s (%d/100)\n", report.Grade.OverallGrade, report.Grade.OverallScore))
+	}
+	if report.Prediction != nil {
+		buf.WriteString(fmt.Sprintf("- **Predicted Win Rate:** %.1f%% (%.1f%%-%.1f%%)\n",
+			report.Prediction.WinRate*100, report.Prediction.WinRateMin*100, report.Prediction.WinRateMax*100))
+	}
+	buf.WriteString(fmt.Sprintf("- **Evaluator Agreement:** %.0f%%\n", report.Agreement*100))
+	if report.Pivot != nil {
+		buf.WriteString(fmt.Sprintf("- **Colors Decided:** P%dp%d (%s) locked in %s\n",
+			report.Pivot.PackNumber+1, report.Pivot.PickNumber, report.Pivot.CardName, report.Pivot.Colors))
+	}
+	buf.WriteString("\n")
+	if report.Grade != nil && len(report.Grade.Suggestions) > 0 {
+		for _, suggestion := range report.Grade.Suggestions {
+			buf.WriteString(fmt.Sprintf("> %s\n", suggestion))
+		}
+		buf.WriteString("\n")
+	}
+
+	// Picks
+	if len(report.Picks) > 0 {
+		buf.WriteString("## Picks\n\n")
+		buf.WriteString("| Pick | Taken | Score | Evaluator Pick | Best Score | Rank | Pool Colors |\n")
+		buf.WriteString("|------|-------|-------|----------------|------------|------|-------------|\n")
+		for _, pick := range report.Picks {
+			best, rank := "-", "-"
+			if pick.Rank > 0 {
+				best = pick.BestCardName
+				if pick.Agreed {
+					best = "✓"
+				}
+				rank = fmt.Sprintf("%d/%d", pick.Rank, pick.PackSize)
+			}
+			buf.WriteString(fmt.Sprintf("| P%dp%d | %s | %.1f | %s | %.1f | %s | %s |\n",
+				pick.PackNumber+1, pick.PickNumber, pick.CardName, pick.Score, best, pick.BestScore, rank, pick.PoolColors))
+		}
+		buf.WriteString("\n")
+	}
+
+	// Deck
+	if report.Deck != nil {
+		buf.WriteString("## Deck vs. Optimal Build\n\n")
+		writeDeckSummaryMarkdown(&buf, "Built", report.Deck.Built)
+		writeDeckSummaryMarkdown(&buf, "Optimal", report.Deck.Optimal)
+		if report.Deck.Built != nil && report.Deck.Optimal != nil {
+			buf.WriteString(fmt.Sprintf("**Shared Spells:** %d\n\n", report.Deck.Overlap))
+			if len(report.Deck.OnlyBuilt) > 0 {
+				buf.WriteString(fmt.Sprintf("- **Played over the optimal build:** %s\n", strings.Join(report.Deck.OnlyBuilt, ", ")))
+			}
+			if len(report.Deck.OnlyOptimal) > 0 {
+				buf.WriteString(fmt.Sprintf("- **Left in the sideboard:** %s\n", strings.Join(report.Deck.OnlyOptimal, ", ")))
+			}
+			buf.WriteString("\n")
+		}
+	}
+
+	// Card performance
+	if len(report.CardPerformance) > 0 {
+		buf.WriteString("## Card Performance\n\n")
+		buf.WriteString("| Card | Casts | Matches | Win Rate | Avg Turn | In Deck |\n")
+		buf.WriteString("|------|-------|---------|----------|----------|---------|\n")
+		for _, card := range report.CardPerformance {
+			inDeck := ""
+			if card.InDeck {
+				inDeck = "✓"
+			}
+			buf.WriteString(fmt.Sprintf("| %s | %d | %d | %.0f%% | %.1f | %s |\n",
+				card.Name, card.Casts, card.MatchesCast, card.WinRateWhenCast*100, card.AvgTurn, inDeck))
+		}
+		buf.WriteString("\n")
+	}
+
+	buf.WriteString(fmt.Sprintf("*Generated %s*\n", report.GeneratedAt.Format("2006-01-02 15:04:05")))
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writeDeckSummaryMarkdown(buf *bytes.Buffer, label string, deck *postmortem.DeckSummary) {
+	if deck == nil {
+		return
+	}
+	buf.WriteString(fmt.Sprintf("### %s: %s (%s)\n\n", label, deck.Name, deck.Colors))
+	buf.WriteString(fmt.Sprintf("%d spells, average GIHWR %.1f%%\n\n", deck.Spells, deck.AvgGIHWR))
+	for _, card := range deck.Cards {
+		buf.WriteString(fmt.Sprintf("- %dx %s\n", card.Quantity, card.Name))
+	}
+	buf.WriteString("\n")
+}
+
+var postMortemHTML = template.Must(template.New("postmortem").Funcs(template.FuncMap{
+	"inc":     func(i int) int { return i + 1 },
+	"percent": func(v float64) string { return fmt.Sprintf("%.1f%%", v*100) },
+	"join":    strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.SetCode}} Draft Post-Mortem</title>
+<style>
+body { font-family: sans-serif; max-width: 960px; margin: 2em auto; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+th { background: #f0f0f0; }
+tr.agreed td { color: #2a7a2a; }
+</style>
+</head>
+<body>
+<h1>{{.SetCode}} Draft Post-Mortem</h1>
+<p><strong>Event:</strong> {{.EventName}} &middot; <strong>Drafted:</strong> {{.StartTime.Format "2006-01-02 15:04"}} &middot; <strong>Record:</strong> {{.Record.Wins}}-{{.Record.Losses}}</p>
+
+<h2>Summary</h2>
+<ul>
+{{- with .Grade}}
+<li><strong>Grade:</strong> {{.OverallGrade}} ({{.OverallScore}}/100)</li>
+{{- end}}
+{{- with .Prediction}}
+<li><strong>Predicted Win Rate:</strong> {{percent .WinRate}} ({{percent .WinRateMin}}-{{percent .WinRateMax}})</li>
+{{- end}}
+<li><strong>Evaluator Agreement:</strong> {{percent .Agreement}}</li>
+{{- with .Pivot}}
+<li><strong>Colors Decided:</strong> P{{inc .PackNumber}}p{{.PickNumber}} ({{.CardName}}) locked in {{.Colors}}</li>
+{{- end}}
+</ul>
+{{- with .Grade}}{{range .Suggestions}}
+<blockquote>{{.}}</blockquote>
+{{- end}}{{end}}
+
+{{- if .Picks}}
+<h2>Picks</h2>
+<table>
+<tr><th>Pick</th><th>Taken</th><th>Score</th><th>Evaluator Pick</th><th>Best Score</th><th>Rank</th><th>Pool Colors</th></tr>
+{{- range .Picks}}
+<tr{{if .Agreed}} class="agreed"{{end}}><td>P{{inc .PackNumber}}p{{.PickNumber}}</td><td>{{.CardName}}</td><td>{{printf "%.1f" .Score}}</td><td>{{if .Rank}}{{if .Agreed}}&#10003;{{else}}{{.BestCardName}}{{end}}{{else}}-{{end}}</td><td>{{printf "%.1f" .BestScore}}</td><td>{{if .Rank}}{{.Rank}}/{{.PackSize}}{{else}}-{{end}}</td><td>{{.PoolColors}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+
+{{- with .Deck}}
+<h2>Deck vs. Optimal Build</h2>
+{{- with .Built}}
+<h3>Built: {{.Name}} ({{.Colors}})</h3>
+<p>{{.Spells}} spells, average GIHWR {{printf "%.1f" .AvgGIHWR}}%</p>
+<ul>{{range .Cards}}<li>{{.Quantity}}x {{.Name}}</li>{{end}}</ul>
+{{- end}}
+{{- with .Optimal}}
+<h3>Optimal: {{.Name}} ({{.Colors}})</h3>
+<p>{{.Spells}} spells, average GIHWR {{printf "%.1f" .AvgGIHWR}}%</p>
+<ul>{{range .Cards}}<li>{{.Quantity}}x {{.Name}}</li>{{end}}</ul>
+{{- end}}
+{{- if and .Built .Optimal}}
+<p><strong>Shared Spells:</strong> {{.Overlap}}</p>
+{{- if .OnlyBuilt}}
+<p><strong>Played over the optimal build:</strong> {{join .OnlyBuilt ", "}}</p>
+{{- end}}
+{{- if .OnlyOptimal}}
+<p><strong>Left in the sideboard:</strong> {{join .OnlyOptimal ", "}}</p>
+{{- end}}
+{{- end}}
+{{- end}}
+
+{{- if .CardPerformance}}
+<h2>Card Performance</h2>
+<table>
+<tr><th>Card</th><th>Casts</th><th>Matches</th><th>Win Rate</th><th>Avg Turn</th><th>In Deck</th></tr>
+{{- range .CardPerformance}}
+<tr><td>{{.Name}}</td><td>{{.Casts}}</td><td>{{.MatchesCast}}</td><td>{{percent .WinRateWhenCast}}</td><td>{{printf "%.1f" .AvgTurn}}</td><td>{{if .InDeck}}&#10003;{{end}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+
+<p><em>Generated {{.GeneratedAt.Format "2006-01-02 15:04:05"}}</em></p>
+</body>
+</html>
+`))
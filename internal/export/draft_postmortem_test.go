@@ -0,0 +1,95 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/grading"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/postmortem"
+)
+
+func testPostMortem() *postmortem.Report {
+	return &postmortem.Report{
+		SessionID: "draft-1",
+		SetCode:   "TLA",
+		EventName: "PremierDraft_TLA",
+		StartTime: time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC),
+		Grade:     &grading.DraftGrade{OverallGrade: "B+", OverallScore: 78},
+		Picks: []postmortem.PickReview{
+			{PackNumber: 0, PickNumber: 1, CardName: "Fire <Bolt>", Score: 60, Rank: 1, PackSize: 14, BestCardName: "Fire <Bolt>", BestScore: 60, Agreed: true, PoolColors: "R"},
+			{PackNumber: 0, PickNumber: 2, CardName: "Grizzly Bears", Score: 52, Rank: 3, PackSize: 13, BestCardName: "Shock", BestScore: 58, PoolColors: "RG"},
+		},
+		Agreement: 0.5,
+		Pivot:     &postmortem.Pivot{PickIndex: 2, PackNumber: 0, PickNumber: 2, CardName: "Grizzly Bears", Colors: "RG", PreviousColors: "R"},
+		Deck: &postmortem.DeckComparison{
+			Built:       &postmortem.DeckSummary{Name: "Gruul", Colors: "RG", Spells: 1, Cards: []postmortem.DeckCard{{CardID: 1, Name: "Grizzly Bears", Quantity: 1}}},
+			Optimal:     &postmortem.DeckSummary{Name: "Gruul", Colors: "RG", Spells: 1, Cards: []postmortem.DeckCard{{CardID: 2, Name: "Shock", Quantity: 1}}},
+			OnlyBuilt:   []string{"Grizzly Bears"},
+			OnlyOptimal: []string{"Shock"},
+		},
+		Record: postmortem.Record{Wins: 3, Losses: 3},
+		CardPerformance: []postmortem.CardPerformance{
+			{CardID: 1, Name: "Grizzly Bears", Casts: 4, MatchesCast: 3, WinsWhenCast: 2, WinRateWhenCast: 2.0 / 3, AvgTurn: 2.5, InDeck: true},
+		},
+	}
+}
+
+func TestExportDraftPostMortem_Markdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportDraftPostMortem(&buf, testPostMortem(), FormatMarkdown); err != nil {
+		t.Fatalf("ExportDraftPostMortem: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"# TLA Draft Post-Mortem",
+		"**Record:** 3-3",
+		"- **Grade:** B+ (78/100)",
+		"- **Colors Decided:** P1p2 (Grizzly Bears) locked in RG",
+		"| P1p2 | Grizzly Bears | 52.0 | Shock | 58.0 | 3/13 | RG |",
+		"- **Left in the sideboard:** Shock",
+		"| Grizzly Bears | 4 | 3 | 67% | 2.5 | ✓ |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Markdown missing %q", want)
+		}
+	}
+}
+
+func TestExportDraftPostMortem_HTMLEscapes(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportDraftPostMortem(&buf, testPostMortem(), FormatHTML); err != nil {
+		t.Fatalf("ExportDraftPostMortem: %v", err)
+	}
+	out := buf.String()
+
+	if strings.Contains(out, "<Bolt>") || !strings.Contains(out, "Fire &lt;Bolt&gt;") {
+		t.Error("card names should be HTML escaped")
+	}
+	if !strings.Contains(out, "<h2>Card Performance</h2>") || !strings.Contains(out, "66.7%") {
+		t.Error("HTML missing card performance section")
+	}
+}
+
+func TestExportDraftPostMortem_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportDraftPostMortem(&buf, testPostMortem(), FormatJSON); err != nil {
+		t.Fatalf("ExportDraftPostMortem: %v", err)
+	}
+	var decoded postmortem.Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded.SessionID != "draft-1" || len(decoded.Picks) != 2 {
+		t.Errorf("decoded report = %+v", decoded)
+	}
+}
+
+func TestExportDraftPostMortem_UnsupportedFormat(t *testing.T) {
+	if err := ExportDraftPostMortem(&bytes.Buffer{}, testPostMortem(), FormatCSV); err == nil {
+		t.Error("expected error for CSV")
+	}
+}
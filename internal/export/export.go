@@ -23,6 +23,10 @@ const (
 	FormatMarkdown Format = "markdown"
 	// FormatArena represents MTG Arena deck format.
 	FormatArena Format = "arena"
+	// FormatJSONL represents newline-delimited JSON, one record per line.
+	FormatJSONL Format = "jsonl"
+	// FormatHTML represents a standalone HTML report.
+	FormatHTML Format = "html"
 )
 
 // Options holds configuration for export operations.
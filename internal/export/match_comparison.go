@@ -19,6 +19,9 @@ type ComparisonExportRow struct {
 	GamesWon     int     `csv:"Games Won" json:"games_won"`
 	GamesLost    int     `csv:"Games Lost" json:"games_lost"`
 	GameWinRate  float64 `csv:"Game Win Rate" json:"game_win_rate"`
+	WinRateLow   float64 `csv:"Win Rate CI Low" json:"win_rate_ci_low"` // 95% Wilson interval
+	WinRateHigh  float64 `csv:"Win Rate CI High" json:"win_rate_ci_high"`
+	Warning      string  `csv:"Warning" json:"warning,omitempty"`
 }
 
 // ComparisonSummary provides overall comparison insights.
@@ -30,6 +33,12 @@ type ComparisonSummary struct {
 	WinRateDiff    float64 `json:"win_rate_difference"`
 	TotalMatches   int     `json:"total_matches"`
 	GroupsCompared int     `json:"groups_compared"`
+
+	// Whether the best and worst groups genuinely differ
+	PValue            float64 `json:"p_value"`
+	ProbabilityBetter float64 `json:"probability_better"`
+	Significant       bool    `json:"significant"`
+	Warning           string  `json:"warning,omitempty"`
 }
 
 // ComparisonExport combines comparison data with summary.
@@ -54,6 +63,9 @@ func ExportMatchComparison(ctx context.Context, service *storage.Service, result
 			GamesWon:     group.Statistics.GamesWon,
 			GamesLost:    group.Statistics.GamesLost,
 			GameWinRate:  group.Statistics.GameWinRate * 100,
+			WinRateLow:   group.WinRate.Wilson.Low * 100,
+			WinRateHigh:  group.WinRate.Wilson.High * 100,
+			Warning:      group.WinRate.Warning,
 		})
 	}
 
@@ -62,6 +74,11 @@ func ExportMatchComparison(ctx context.Context, service *storage.Service, result
 		TotalMatches:   result.TotalMatches,
 		GroupsCompared: len(result.Groups),
 		WinRateDiff:    result.WinRateDiff * 100,
+
+		PValue:            result.Significance.PValue,
+		ProbabilityBetter: result.Significance.ProbabilityBetter,
+		Significant:       result.Significance.Significant,
+		Warning:           result.Significance.Warning,
 	}
 
 	if result.BestGroup != nil {
@@ -41,6 +41,7 @@ type MetaSnapshotExport struct {
 
 // ExportMetaSnapshot exports top cards for a set as a meta snapshot.
 func ExportMetaSnapshot(ctx context.Context, service *storage.Service, setCode, format, colors string, topN int, opts Options) error {
+	setCards := service.NewReadOnlySetCardRepo()
 	// Get all card ratings for the set
 	ratings, err := service.GetCardRatingsForSet(ctx, setCode, format, colors)
 	if err != nil {
@@ -65,7 +66,7 @@ func ExportMetaSnapshot(ctx context.Context, service *storage.Service, setCode,
 	rows := make([]*MetaSnapshotRow, 0, len(ratings))
 	for i, rating := range ratings {
 		// Get card metadata
-		card, err := service.SetCardRepo().GetCardByArenaID(ctx, strconv.Itoa(rating.ArenaID))
+		card, err := setCards.GetCardByArenaID(ctx, strconv.Itoa(rating.ArenaID))
 		if err != nil || card == nil {
 			// Skip cards we don't have metadata for
 			continue
@@ -114,6 +115,7 @@ func ExportMetaSnapshot(ctx context.Context, service *storage.Service, setCode,
 
 // ExportMetaSnapshotByRarity exports meta snapshot grouped by rarity.
 func ExportMetaSnapshotByRarity(ctx context.Context, service *storage.Service, setCode, format, colors string, topNPerRarity int, opts Options) error {
+	setCards := service.NewReadOnlySetCardRepo()
 	// Get all card ratings for the set
 	ratings, err := service.GetCardRatingsForSet(ctx, setCode, format, colors)
 	if err != nil {
@@ -127,7 +129,7 @@ func ExportMetaSnapshotByRarity(ctx context.Context, service *storage.Service, s
 	// Group by rarity
 	byRarity := make(map[string][]*storage.DraftCardRating)
 	for _, rating := range ratings {
-		card, err := service.SetCardRepo().GetCardByArenaID(ctx, strconv.Itoa(rating.ArenaID))
+		card, err := setCards.GetCardByArenaID(ctx, strconv.Itoa(rating.ArenaID))
 		if err != nil || card == nil {
 			continue
 		}
@@ -156,7 +158,7 @@ func ExportMetaSnapshotByRarity(ctx context.Context, service *storage.Service, s
 
 		// Convert to export rows
 		for i, rating := range rarityRatings {
-			card, err := service.SetCardRepo().GetCardByArenaID(ctx, strconv.Itoa(rating.ArenaID))
+			card, err := setCards.GetCardByArenaID(ctx, strconv.Itoa(rating.ArenaID))
 			if err != nil || card == nil {
 				continue
 			}
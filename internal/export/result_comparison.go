@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 )
@@ -19,6 +20,9 @@ type ResultComparisonRow struct {
 	AllTimePercentage float64 `csv:"all_time_percentage" json:"all_time_percentage"`
 	PercentageChange  float64 `csv:"percentage_change" json:"percentage_change"`
 	Trend             string  `csv:"trend" json:"trend"`
+	PValue            float64 `csv:"p_value" json:"p_value"`
+	Significant       bool    `csv:"significant" json:"significant"`
+	Warning           string  `csv:"warning" json:"warning,omitempty"`
 }
 
 // ResultComparisonJSON represents a comparison in JSON format.
@@ -49,6 +53,9 @@ type ResultComparisonDetail struct {
 	AllTimePercentage float64 `json:"all_time_percentage"`
 	PercentageChange  float64 `json:"percentage_change"`
 	Trend             string  `json:"trend"`
+	PValue            float64 `json:"p_value"`
+	Significant       bool    `json:"significant"`
+	Warning           string  `json:"warning,omitempty"`
 }
 
 // ExportResultComparison exports a comparison of recent vs. all-time result breakdowns.
@@ -120,6 +127,8 @@ func exportResultComparisonCSV(recentWins, recentLosses, allTimeWins, allTimeLos
 			trend = "decreasing"
 		}
 
+		significance := compareResultShare(recentCount, allTimeCount, recentTotal, allTimeTotal)
+
 		rows = append(rows, ResultComparisonRow{
 			ResultReason:      reason,
 			ResultType:        resultType,
@@ -129,6 +138,9 @@ func exportResultComparisonCSV(recentWins, recentLosses, allTimeWins, allTimeLos
 			AllTimePercentage: allTimePct,
 			PercentageChange:  change,
 			Trend:             trend,
+			PValue:            significance.PValue,
+			Significant:       significance.Significant,
+			Warning:           significance.Warning,
 		})
 	}
 
@@ -196,6 +208,8 @@ func exportResultComparisonJSON(recentWins, recentLosses, allTimeWins, allTimeLo
 			trend = "decreasing"
 		}
 
+		significance := compareResultShare(recentCount, allTimeCount, recentTotal, allTimeTotal)
+
 		comparison.Comparison = append(comparison.Comparison, ResultComparisonDetail{
 			ResultReason:      reason,
 			ResultType:        resultType,
@@ -205,6 +219,9 @@ func exportResultComparisonJSON(recentWins, recentLosses, allTimeWins, allTimeLo
 			AllTimePercentage: allTimePct,
 			PercentageChange:  change,
 			Trend:             trend,
+			PValue:            significance.PValue,
+			Significant:       significance.Significant,
+			Warning:           significance.Warning,
 		})
 	}
 
@@ -221,3 +238,12 @@ func exportResultComparisonJSON(recentWins, recentLosses, allTimeWins, allTimeLo
 	exporter := NewExporter(opts)
 	return exporter.Export(comparison)
 }
+
+// compareResultShare tests whether a result reason's share of recent matches
+// differs from its share of the matches before the recent window. Recent
+// matches are part of all-time, so they are removed to keep the samples independent.
+func compareResultShare(recentCount, allTimeCount, recentTotal, allTimeTotal int) stats.Comparison {
+	earlierCount := max(allTimeCount-recentCount, 0)
+	earlierTotal := max(allTimeTotal-recentTotal, 0)
+	return stats.Compare(recentCount, recentTotal, earlierCount, earlierTotal)
+}
@@ -22,6 +22,9 @@ type SeasonStatsExportRow struct {
 	GamesLost    int     `csv:"games_lost" json:"games_lost"`
 	WinRate      float64 `csv:"win_rate" json:"win_rate"`
 	GameWinRate  float64 `csv:"game_win_rate" json:"game_win_rate"`
+	WinRateLow   float64 `csv:"win_rate_ci_low" json:"win_rate_ci_low"`
+	WinRateHigh  float64 `csv:"win_rate_ci_high" json:"win_rate_ci_high"`
+	Warning      string  `csv:"warning" json:"warning,omitempty"`
 }
 
 // SeasonComparisonExportRow represents two-season comparison for CSV export.
@@ -37,6 +40,10 @@ type SeasonComparisonExportRow struct {
 	MatchCountChange     int     `csv:"match_count_change" json:"match_count_change"`
 	MatchCountChangePerc float64 `csv:"match_count_change_perc" json:"match_count_change_perc"`
 	Trend                string  `csv:"trend" json:"trend"`
+	PValue               float64 `csv:"p_value" json:"p_value"`
+	ProbabilityBetter    float64 `csv:"probability_better" json:"probability_better"` // Season 2 beats season 1
+	Significant          bool    `csv:"significant" json:"significant"`
+	Warning              string  `csv:"warning" json:"warning,omitempty"`
 }
 
 // MultiSeasonComparisonExportRow represents multi-season summary for CSV export.
@@ -45,6 +52,7 @@ type MultiSeasonComparisonExportRow struct {
 	WorstSeason  string `csv:"worst_season" json:"worst_season"`
 	MostActive   string `csv:"most_active" json:"most_active"`
 	OverallTrend string `csv:"overall_trend" json:"overall_trend"`
+	Significant  bool   `csv:"significant" json:"significant"` // Last season differs from the first
 }
 
 // ExportSeasonStats exports statistics for a single season.
@@ -71,6 +79,9 @@ func ExportSeasonStats(ctx context.Context, service *storage.Service, seasonName
 		GamesLost:    stats.GamesLost,
 		WinRate:      stats.WinRate,
 		GameWinRate:  stats.GameWinRate,
+		WinRateLow:   stats.WinRateCI.Low,
+		WinRateHigh:  stats.WinRateCI.High,
+		Warning:      stats.Warning,
 	}
 
 	switch opts.Format {
@@ -118,6 +129,10 @@ func ExportSeasonComparison(ctx context.Context, service *storage.Service,
 		MatchCountChange:     comparison.MatchCountChange,
 		MatchCountChangePerc: comparison.MatchCountChangePerc,
 		Trend:                comparison.Trend,
+		PValue:               comparison.Significance.PValue,
+		ProbabilityBetter:    comparison.Significance.ProbabilityBetter,
+		Significant:          comparison.Significance.Significant,
+		Warning:              comparison.Significance.Warning,
 	}
 
 	switch opts.Format {
@@ -158,6 +173,9 @@ func ExportMultiSeasonComparison(ctx context.Context, service *storage.Service,
 			MostActive:   comparison.MostActive,
 			OverallTrend: comparison.OverallTrend,
 		}
+		if comparison.Significance != nil {
+			summaryRow.Significant = comparison.Significance.Significant
+		}
 
 		exporter := NewExporter(opts)
 		return exporter.Export([]MultiSeasonComparisonExportRow{summaryRow})
@@ -6,15 +6,14 @@ import (
 	"testing"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
-
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 func setupFeedbackTestDB(t *testing.T) *sql.DB {
 	t.Helper()
 
-	db, err := sql.Open("sqlite3", ":memory:")
+	db, err := sql.Open(sqlitedriver.Name, ":memory:")
 	if err != nil {
 		t.Fatalf("failed to open test database: %v", err)
 	}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/priors"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 )
@@ -242,7 +243,7 @@ func (c *CardFacade) GetCardRatings(ctx context.Context, setCode string, draftFo
 	}
 
 	// Get ratings from repository
-	ratings, _, err := c.services.Storage.DraftRatingsRepo().GetCardRatings(ctx, setCode, draftFormat)
+	ratings, _, err := c.services.DraftRatings().GetCardRatings(ctx, setCode, draftFormat)
 	if err != nil {
 		return nil, &AppError{Message: fmt.Sprintf("Failed to get card ratings: %v", err)}
 	}
@@ -278,13 +279,14 @@ func (c *CardFacade) GetCardRatings(ctx context.Context, setCode string, draftFo
 	return result, nil
 }
 
-// GetCardRatingByArenaID returns the 17Lands rating for a specific card.
+// GetCardRatingByArenaID returns the 17Lands rating for a specific card, with
+// the user's rating blend applied.
 func (c *CardFacade) GetCardRatingByArenaID(ctx context.Context, setCode string, draftFormat string, arenaID string) (*CardRatingWithTier, error) {
 	if c.services.Storage == nil {
 		return nil, &AppError{Message: "Database not initialized"}
 	}
 
-	rating, err := c.services.Storage.DraftRatingsRepo().GetCardRatingByArenaID(ctx, setCode, draftFormat, arenaID)
+	rating, err := c.services.DraftRatings().GetCardRatingByArenaID(ctx, setCode, draftFormat, arenaID)
 	if err != nil {
 		return nil, &AppError{Message: fmt.Sprintf("Failed to get card rating: %v", err)}
 	}
@@ -299,6 +301,34 @@ func (c *CardFacade) GetCardRatingByArenaID(ctx context.Context, setCode string,
 	}, nil
 }
 
+// GetRatingPriorModel returns the early-set rating model for a draft format,
+// or nil when none has been trained.
+func (c *CardFacade) GetRatingPriorModel(ctx context.Context, draftFormat string) (*priors.Model, error) {
+	if c.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	model, err := priors.NewStore(c.services.Storage.SettingsRepo()).Load(ctx, draftFormat)
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to get rating prior model: %v", err), Err: err}
+	}
+	return model, nil
+}
+
+// TrainRatingPriors retrains the early-set rating model for a draft format
+// on every set with enough 17Lands data.
+func (c *CardFacade) TrainRatingPriors(ctx context.Context, draftFormat string) (*priors.Model, error) {
+	if c.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	model, err := c.services.RatingPriors().Train(ctx, draftFormat)
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to train rating priors: %v", err), Err: err}
+	}
+	return model, nil
+}
+
 // GetColorRatings returns 17Lands color combination ratings for a set and draft format.
 func (c *CardFacade) GetColorRatings(ctx context.Context, setCode string, draftFormat string) ([]seventeenlands.ColorRating, error) {
 	if c.services.Storage == nil {
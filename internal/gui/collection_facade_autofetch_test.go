@@ -51,6 +51,10 @@ func (m *mockCardFetcher) FetchCardByName(_ context.Context, _, _, _ string) (*m
 	return nil, nil // Not used in GetCollection tests
 }
 
+func (m *mockCardFetcher) GetCachedSet(_ context.Context, _ string) ([]*models.SetCard, error) {
+	return nil, nil // Not used in GetCollection tests
+}
+
 func (m *mockCardFetcher) FetchAndCacheSet(_ context.Context, _ string) (int, error) {
 	return 0, nil // Not used in GetCollection tests
 }
@@ -2,18 +2,25 @@ package gui
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/archetype"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/deckexport"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/deckimport"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/goldfish"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/manabase"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/recommendations"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
@@ -23,6 +30,14 @@ import (
 // DeckFacade handles all deck builder operations.
 type DeckFacade struct {
 	services *Services
+
+	// Reference decklists are shared so their per-format cache outlives a call
+	similarityOnce sync.Once
+	similarity     *archetype.SimilarityClassifier
+
+	// Mana base reports by deck contents, since the hand simulation is slow
+	manaBaseMu    sync.Mutex
+	manaBaseCache map[string]*manabase.Report
 }
 
 // NewDeckFacade creates a new DeckFacade with the given services.
@@ -681,13 +696,14 @@ func (d *DeckFacade) GetDeckByDraftEvent(ctx context.Context, draftEventID strin
 	}, nil
 }
 
-// ImportDeckRequest represents a request to import a deck from text.
+// ImportDeckRequest represents a request to import a deck from text or a deck page URL.
 type ImportDeckRequest struct {
 	Name         string  `json:"name"`
 	Format       string  `json:"format"`
 	ImportText   string  `json:"importText"`
-	Source       string  `json:"source"`       // "constructed" or "imported"
-	DraftEventID *string `json:"draftEventID"` // Required if source is "draft"
+	URL          string  `json:"url,omitempty"` // Public deck page, used when ImportText is empty
+	Source       string  `json:"source"`        // "constructed" or "imported"
+	DraftEventID *string `json:"draftEventID"`  // Required if source is "draft"
 }
 
 // ImportDeckResponse contains the result of a deck import operation.
@@ -698,9 +714,13 @@ type ImportDeckResponse struct {
 	Warnings      []string `json:"warnings,omitempty"`
 	CardsImported int      `json:"cardsImported"`
 	CardsSkipped  int      `json:"cardsSkipped"`
+
+	Unresolved []*deckimport.UnresolvedCard `json:"unresolved,omitempty"` // Cards not found, with suggested names
 }
 
-// ImportDeck imports a deck from text (Arena format or plain text).
+// ImportDeck imports a deck from text (Arena, plain text, MTGO .dek,
+// Cockatrice .cod, Forge .dck, or Moxfield/Archidekt JSON) or from a public
+// deck page URL.
 func (d *DeckFacade) ImportDeck(ctx context.Context, req *ImportDeckRequest) (*ImportDeckResponse, error) {
 	if d.services.Storage == nil {
 		return nil, &AppError{Message: "Database not initialized"}
@@ -730,8 +750,8 @@ func (d *DeckFacade) ImportDeck(ctx context.Context, req *ImportDeckRequest) (*I
 		return response, nil
 	}
 
-	if req.ImportText == "" {
-		response.Errors = append(response.Errors, "Import text is required")
+	if req.ImportText == "" && req.URL == "" {
+		response.Errors = append(response.Errors, "Import text or URL is required")
 		return response, nil
 	}
 
@@ -751,7 +771,16 @@ func (d *DeckFacade) ImportDeck(ctx context.Context, req *ImportDeckRequest) (*I
 		return nil, &AppError{Message: "Deck import parser not initialized"}
 	}
 
-	parseResult, err := parser.Parse(req.ImportText)
+	var parseResult *deckimport.ParseResult
+	var err error
+	if req.ImportText == "" {
+		if d.services.DeckURLImporter == nil {
+			return nil, &AppError{Message: "Deck URL importer not initialized"}
+		}
+		parseResult, err = d.services.DeckURLImporter.Import(ctx, req.URL)
+	} else {
+		parseResult, err = parser.Parse(req.ImportText)
+	}
 	if err != nil {
 		response.Errors = append(response.Errors, fmt.Sprintf("Failed to parse import: %v", err))
 		return response, nil
@@ -761,6 +790,7 @@ func (d *DeckFacade) ImportDeck(ctx context.Context, req *ImportDeckRequest) (*I
 	response.Errors = append(response.Errors, parseResult.Deck.Errors...)
 	response.Warnings = append(response.Warnings, parseResult.Deck.Warnings...)
 	response.Warnings = append(response.Warnings, parseResult.Warnings...)
+	response.Unresolved = parseResult.Unresolved
 
 	if !parseResult.Deck.ParsedOK {
 		return response, nil
@@ -1299,11 +1329,7 @@ func (d *DeckFacade) convertToDeckListItems(ctx context.Context, decks []*models
 	// Create archetype classifier for deck classification
 	var classifier *archetype.Classifier
 	if d.services.CardService != nil && d.services.Storage != nil {
-		classifier = archetype.NewClassifier(
-			d.services.CardService,
-			d.services.Storage.DeckRepo(),
-			d.services.Storage.DeckPerformanceRepo(),
-		)
+		classifier = d.newArchetypeClassifier()
 	}
 
 	for _, deck := range decks {
@@ -1400,8 +1426,17 @@ type DeckStatistics struct {
 
 	// Format legality
 	Legality FormatLegality `json:"legality"`
+
+	// Castability of each spell from the deck's mana sources
+	ManaBase *manabase.Report `json:"manaBase,omitempty"`
 }
 
+// manaBaseSimulations is the number of opening hands simulated for deck statistics.
+const manaBaseSimulations = 10000
+
+// maxCachedManaBases caps the mana base reports kept between calls.
+const maxCachedManaBases = 64
+
 // ColorStats represents color distribution in the deck.
 type ColorStats struct {
 	White      int `json:"white"`
@@ -1525,6 +1560,167 @@ func (d *DeckFacade) GetDeckStatistics(ctx context.Context, deckID string) (*Dec
 	return stats, nil
 }
 
+// basicLandNamesByID maps basic land Arena IDs to their names, for when metadata is unavailable.
+var basicLandNamesByID = map[int]string{
+	81716: "Plains",
+	81717: "Island",
+	81718: "Swamp",
+	81719: "Mountain",
+	81720: "Forest",
+}
+
+// GoldfishDeck plays a deck's mainboard against an empty board to estimate its speed.
+func (d *DeckFacade) GoldfishDeck(ctx context.Context, deckID string, iterations int) (*goldfish.Result, error) {
+	if d.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	var deckCards []*models.DeckCard
+	err := storage.RetryOnBusy(func() error {
+		var err error
+		deckCards, err = d.services.Storage.DeckRepo().GetCards(ctx, deckID)
+		return err
+	})
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to get deck cards: %v", err)}
+	}
+	if len(deckCards) == 0 {
+		return nil, &AppError{Message: "Deck not found"}
+	}
+
+	quantities := make(map[int]int)
+	for _, card := range deckCards {
+		if card.Board == "main" {
+			quantities[card.CardID] += card.Quantity
+		}
+	}
+	return goldfish.Simulate(d.goldfishCards(ctx, quantities), goldfish.Options{Iterations: iterations, Seed: 1}), nil
+}
+
+// PermutationGoldfish is a deck version's simulated speed.
+type PermutationGoldfish struct {
+	PermutationID int     `json:"permutationId"`
+	VersionNumber int     `json:"versionNumber"`
+	VersionName   *string `json:"versionName,omitempty"`
+	CardCount     int     `json:"cardCount"`
+	*goldfish.Result
+}
+
+// PermutationGoldfishComparison compares two deck versions played with the same shuffles.
+type PermutationGoldfishComparison struct {
+	From          *PermutationGoldfish `json:"from"`
+	To            *PermutationGoldfish `json:"to"`
+	KillTurnDelta float64              `json:"killTurnDelta"` // Negative when the newer version kills faster
+	FasterID      int                  `json:"fasterId"`      // Permutation that kills faster, 0 for a tie
+}
+
+// CompareDeckPermutations goldfishes two versions of a deck so they can be
+// compared before queuing.
+func (d *DeckFacade) CompareDeckPermutations(ctx context.Context, deckID string, fromID, toID, iterations int) (*PermutationGoldfishComparison, error) {
+	if d.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	permRepo := d.services.Storage.NewDeckPermutationRepo()
+	load := func(id int) (*models.DeckPermutation, []goldfish.Card, error) {
+		var perm *models.DeckPermutation
+		err := storage.RetryOnBusy(func() error {
+			var err error
+			perm, err = permRepo.GetByID(ctx, id)
+			return err
+		})
+		if err != nil {
+			return nil, nil, &AppError{Message: fmt.Sprintf("Failed to get deck permutation: %v", err)}
+		}
+		if perm == nil || perm.DeckID != deckID {
+			return nil, nil, &AppError{Message: fmt.Sprintf("Deck permutation %d not found", id)}
+		}
+		var permCards []models.DeckPermutationCard
+		if err := json.Unmarshal([]byte(perm.Cards), &permCards); err != nil {
+			return nil, nil, &AppError{Message: fmt.Sprintf("Failed to parse deck permutation %d", id), Err: err}
+		}
+		quantities := make(map[int]int)
+		for _, card := range permCards {
+			if card.Board == "main" {
+				quantities[card.CardID] += card.Quantity
+			}
+		}
+		return perm, d.goldfishCards(ctx, quantities), nil
+	}
+
+	fromPerm, fromCards, err := load(fromID)
+	if err != nil {
+		return nil, err
+	}
+	toPerm, toCards, err := load(toID)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := goldfish.Compare(fromCards, toCards, goldfish.Options{Iterations: iterations, Seed: 1})
+	result := &PermutationGoldfishComparison{
+		From:          newPermutationGoldfish(fromPerm, fromCards, comparison.A),
+		To:            newPermutationGoldfish(toPerm, toCards, comparison.B),
+		KillTurnDelta: comparison.KillTurnDelta,
+	}
+	switch comparison.Faster {
+	case "a":
+		result.FasterID = fromPerm.ID
+	case "b":
+		result.FasterID = toPerm.ID
+	}
+	return result, nil
+}
+
+func newPermutationGoldfish(perm *models.DeckPermutation, deck []goldfish.Card, result *goldfish.Result) *PermutationGoldfish {
+	count := 0
+	for _, card := range deck {
+		count += card.Quantity
+	}
+	return &PermutationGoldfish{
+		PermutationID: perm.ID,
+		VersionNumber: perm.VersionNumber,
+		VersionName:   perm.VersionName,
+		CardCount:     count,
+		Result:        result,
+	}
+}
+
+// goldfishCards looks up metadata for a mainboard, skipping cards that cannot be found.
+func (d *DeckFacade) goldfishCards(ctx context.Context, quantities map[int]int) []goldfish.Card {
+	deck := make([]goldfish.Card, 0, len(quantities))
+	for cardID, quantity := range quantities {
+		if name, ok := basicLandNamesByID[cardID]; ok {
+			deck = append(deck, goldfish.Card{Name: name, Quantity: quantity, TypeLine: "Basic Land — " + name})
+			continue
+		}
+
+		var card *cards.Card
+		setCard, err := d.services.Storage.SetCardRepo().GetCardByArenaID(ctx, fmt.Sprintf("%d", cardID))
+		if err == nil && setCard != nil {
+			card = convertSetCardToCard(setCard)
+		} else if d.services.CardService != nil {
+			card, err = d.services.CardService.GetCard(cardID)
+			if err != nil {
+				card = nil
+			}
+		}
+		if card == nil {
+			log.Printf("Warning: Failed to get card metadata for card ID %d", cardID)
+			continue
+		}
+		deck = append(deck, goldfish.FromCard(card, quantity))
+	}
+	// Map order is random; sort so the same deck always shuffles the same way
+	sort.Slice(deck, func(i, j int) bool {
+		if deck[i].Name != deck[j].Name {
+			return deck[i].Name < deck[j].Name
+		}
+		return deck[i].Quantity < deck[j].Quantity
+	})
+	return deck
+}
+
 // calculateDeckStats performs the core statistical calculations.
 func (d *DeckFacade) calculateDeckStats(ctx context.Context, deckCards []*models.DeckCard, stats *DeckStatistics) *DeckStatistics {
 	totalCMC := 0.0
@@ -1537,20 +1733,14 @@ func (d *DeckFacade) calculateDeckStats(ctx context.Context, deckCards []*models
 		"Plains": true, "Island": true, "Swamp": true, "Mountain": true, "Forest": true, "Wastes": true,
 	}
 
-	// Basic land IDs (for when metadata is unavailable)
-	basicLandIDs := map[int]string{
-		81716: "Plains",
-		81717: "Island",
-		81718: "Swamp",
-		81719: "Mountain",
-		81720: "Forest",
-	}
+	var manaCards []manabase.Card
 
 	for _, deckCard := range deckCards {
 		quantity := deckCard.Quantity
 
 		// Check if this is a basic land by ID (handle even without metadata)
-		if _, isBasicLand := basicLandIDs[deckCard.CardID]; isBasicLand {
+		if name, isBasicLand := basicLandNamesByID[deckCard.CardID]; isBasicLand {
+			manaCards = append(manaCards, manabase.Card{Name: name, Quantity: quantity, TypeLine: "Basic Land — " + name})
 			stats.TotalCards += quantity
 			stats.Lands.Total += quantity
 			stats.Lands.Basic += quantity
@@ -1577,6 +1767,15 @@ func (d *DeckFacade) calculateDeckStats(ctx context.Context, deckCards []*models
 
 		stats.TotalCards += quantity
 
+		manaCard := manabase.Card{Name: card.Name, Quantity: quantity, CMC: int(card.CMC), TypeLine: card.TypeLine}
+		if card.ManaCost != nil {
+			manaCard.ManaCost = *card.ManaCost
+		}
+		if card.OracleText != nil {
+			manaCard.OracleText = *card.OracleText
+		}
+		manaCards = append(manaCards, manaCard)
+
 		// Mana curve
 		cmc := int(card.CMC)
 		stats.ManaCurve[cmc] += quantity
@@ -1636,9 +1835,36 @@ func (d *DeckFacade) calculateDeckStats(ctx context.Context, deckCards []*models
 		stats.Creatures.AverageToughness = float64(totalCreatureToughness) / float64(creatureCountForAvg)
 	}
 
+	// Mana base castability
+	if len(manaCards) > 0 {
+		stats.ManaBase = d.analyzeManaBase(manaCards)
+	}
+
 	return stats
 }
 
+// analyzeManaBase analyzes a mana base, reusing the report for the same cards
+// so repeated statistics requests for an unchanged deck skip the simulation.
+func (d *DeckFacade) analyzeManaBase(manaCards []manabase.Card) *manabase.Report {
+	key := fmt.Sprint(manaCards)
+	d.manaBaseMu.Lock()
+	report, ok := d.manaBaseCache[key]
+	d.manaBaseMu.Unlock()
+	if ok {
+		return report
+	}
+
+	report = manabase.Analyze(manaCards, manabase.Options{Simulations: manaBaseSimulations, Seed: 1})
+
+	d.manaBaseMu.Lock()
+	defer d.manaBaseMu.Unlock()
+	if d.manaBaseCache == nil || len(d.manaBaseCache) >= maxCachedManaBases {
+		d.manaBaseCache = make(map[string]*manabase.Report)
+	}
+	d.manaBaseCache[key] = report
+	return report
+}
+
 // analyzeCardColors updates color statistics.
 func (d *DeckFacade) analyzeCardColors(card *cards.Card, quantity int, colors *ColorStats) {
 	if len(card.Colors) == 0 {
@@ -2044,17 +2270,18 @@ func convertSetCardToCard(setCard *models.SetCard) *cards.Card {
 
 // ArchetypeClassificationResult represents the result of classifying a deck archetype.
 type ArchetypeClassificationResult struct {
-	PrimaryArchetype   string                   `json:"primaryArchetype"`
-	SecondaryArchetype *string                  `json:"secondaryArchetype,omitempty"`
-	Confidence         float64                  `json:"confidence"`        // 0.0-1.0
-	ConfidencePercent  int                      `json:"confidencePercent"` // 0-100
-	ColorIdentity      string                   `json:"colorIdentity"`
-	DominantColors     []string                 `json:"dominantColors"`
-	ColorPair          *ColorPairInfo           `json:"colorPair,omitempty"`
-	SignatureCards     []int                    `json:"signatureCards"`
-	Indicators         []ArchetypeIndicatorInfo `json:"indicators"`
-	TotalCards         int                      `json:"totalCards"`
-	Analysis           *DeckArchetypeAnalysis   `json:"analysis"`
+	PrimaryArchetype   string                     `json:"primaryArchetype"`
+	SecondaryArchetype *string                    `json:"secondaryArchetype,omitempty"`
+	Confidence         float64                    `json:"confidence"`        // 0.0-1.0
+	ConfidencePercent  int                        `json:"confidencePercent"` // 0-100
+	ColorIdentity      string                     `json:"colorIdentity"`
+	DominantColors     []string                   `json:"dominantColors"`
+	ColorPair          *ColorPairInfo             `json:"colorPair,omitempty"`
+	SignatureCards     []int                      `json:"signatureCards"`
+	Indicators         []ArchetypeIndicatorInfo   `json:"indicators"`
+	TotalCards         int                        `json:"totalCards"`
+	Analysis           *DeckArchetypeAnalysis     `json:"analysis"`
+	ArchetypeMatches   []archetype.ArchetypeMatch `json:"archetypeMatches,omitempty"` // Closest reference lists
 }
 
 // ColorPairInfo represents a detected color pair.
@@ -2088,6 +2315,27 @@ type DeckArchetypeAnalysis struct {
 	RareCounts        map[string]int `json:"rareCounts"`
 }
 
+// newArchetypeClassifier creates a classifier that also matches decks against
+// MTGZone and metagame reference lists.
+func (d *DeckFacade) newArchetypeClassifier() *archetype.Classifier {
+	classifier := archetype.NewClassifier(
+		d.services.CardService,
+		d.services.Storage.DeckRepo(),
+		d.services.Storage.DeckPerformanceRepo(),
+	)
+
+	d.similarityOnce.Do(func() {
+		sources := []archetype.ReferenceSource{archetype.NewMTGZoneReferences(d.services.Storage.NewMTGZoneRepo())}
+		if d.services.MetaService != nil {
+			sources = append(sources, archetype.NewGoldfishReferences(d.services.MetaService))
+		}
+		d.similarity = archetype.NewSimilarityClassifier(sources...)
+	})
+	classifier.SetSimilarity(d.similarity)
+
+	return classifier
+}
+
 // ClassifyDeckArchetype classifies a deck into its archetype.
 func (d *DeckFacade) ClassifyDeckArchetype(ctx context.Context, deckID string) (*ArchetypeClassificationResult, error) {
 	if d.services.Storage == nil {
@@ -2099,11 +2347,7 @@ func (d *DeckFacade) ClassifyDeckArchetype(ctx context.Context, deckID string) (
 	}
 
 	// Create classifier
-	classifier := archetype.NewClassifier(
-		d.services.CardService,
-		d.services.Storage.DeckRepo(),
-		d.services.Storage.DeckPerformanceRepo(),
-	)
+	classifier := d.newArchetypeClassifier()
 
 	// Classify the deck
 	result, err := classifier.ClassifyDeck(ctx, deckID)
@@ -2121,6 +2365,7 @@ func (d *DeckFacade) ClassifyDeckArchetype(ctx context.Context, deckID string) (
 		DominantColors:     result.DominantColors,
 		SignatureCards:     result.SignatureCards,
 		TotalCards:         result.TotalCards,
+		ArchetypeMatches:   result.ArchetypeMatches,
 	}
 
 	// Convert color pair
@@ -2191,11 +2436,7 @@ func (d *DeckFacade) ClassifyDraftPoolArchetype(ctx context.Context, draftEventI
 	}
 
 	// Create classifier
-	classifier := archetype.NewClassifier(
-		d.services.CardService,
-		d.services.Storage.DeckRepo(),
-		d.services.Storage.DeckPerformanceRepo(),
-	)
+	classifier := d.newArchetypeClassifier()
 
 	// Classify the draft pool
 	result, err := classifier.ClassifyDraftPool(cardIDs)
@@ -2312,6 +2553,8 @@ type DeckSuggestionAnalysisResponse struct {
 	TopCards          []string       `json:"topCards"`
 	Synergies         []string       `json:"synergies"`
 	PlayableCount     int            `json:"playableCount"`
+	Castability       float64        `json:"castability"`
+	UnderSupported    []string       `json:"underSupported,omitempty"`
 }
 
 // SuggestDecks generates all viable deck suggestions for a draft pool.
@@ -2449,6 +2692,8 @@ func convertSuggestedDeck(s *recommendations.SuggestedDeck) *SuggestedDeckRespon
 			TopCards:          s.Analysis.TopCards,
 			Synergies:         s.Analysis.Synergies,
 			PlayableCount:     s.Analysis.PlayableCount,
+			Castability:       s.Analysis.Castability,
+			UnderSupported:    s.Analysis.UnderSupported,
 		}
 	}
 
@@ -3416,6 +3661,30 @@ func convertRecommendation(rec *models.CardRecommendation) *CardRecommendationRe
 	}
 }
 
+// GetCardGameMetrics returns GIH/OH/GD/GNS win rates for every card seen in a deck's games,
+// optionally limited to the games played with one deck version.
+func (d *DeckFacade) GetCardGameMetrics(ctx context.Context, filter models.CardGameMetricsFilter) (*models.DeckCardGameMetrics, error) {
+	if filter.DeckID == "" {
+		return nil, &AppError{Message: "deck_id is required"}
+	}
+
+	if d.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	repo := d.services.Storage.CardPerformanceAnalysisRepo()
+	if repo == nil {
+		return nil, &AppError{Message: "Card performance repository not available"}
+	}
+
+	metrics, err := repo.GetCardGameMetrics(ctx, filter, seventeenlands.DefaultBayesianConfig())
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to get card game metrics: %v", err)}
+	}
+
+	return metrics, nil
+}
+
 // GetUnderperformingCards returns cards that hurt deck performance.
 func (d *DeckFacade) GetUnderperformingCards(ctx context.Context, deckID string, threshold float64) ([]*CardPerformanceResponse, error) {
 	if deckID == "" {
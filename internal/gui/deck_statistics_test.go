@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/manabase"
 )
 
 func TestParsePowerToughness(t *testing.T) {
@@ -356,3 +357,22 @@ func TestCalculateLandRecommendations_Status(t *testing.T) {
 // Note: TestCheckFormatLegality is tested indirectly through GetDeckStatistics integration tests
 // Direct unit testing of checkFormatLegality requires extensive mocking of card service
 // which is better covered by integration tests with a real test database
+
+func TestAnalyzeManaBase_ReusesReportForSameCards(t *testing.T) {
+	facade := &DeckFacade{}
+	deck := []manabase.Card{
+		{Name: "Plains", Quantity: 17, TypeLine: "Basic Land — Plains"},
+		{Name: "Savannah Lions", Quantity: 23, ManaCost: "{W}", CMC: 1, TypeLine: "Creature — Cat"},
+	}
+
+	first := facade.analyzeManaBase(deck)
+	if again := facade.analyzeManaBase(deck); again != first {
+		t.Error("unchanged deck should reuse the cached mana base report")
+	}
+
+	changed := append([]manabase.Card(nil), deck...)
+	changed[0].Quantity = 16
+	if report := facade.analyzeManaBase(changed); report == first || report.LandCount != 16 {
+		t.Errorf("changed deck should be analyzed again, got %d lands", report.LandCount)
+	}
+}
@@ -1,10 +1,12 @@
 package gui
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"log"
+	"os"
 	"sync"
 	"time"
 
@@ -13,8 +15,14 @@ import (
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/grading"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/insights"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/optimizer"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/pickquality"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/postmortem"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/prediction"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/ratings"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/signals"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/simulator"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/recommendations"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 )
@@ -30,6 +38,10 @@ type DraftFacade struct {
 	// Track in-flight card fetches
 	cardFetchMu     sync.Mutex
 	inFlightFetches map[string]bool
+
+	// Practice drafts against bots, created on first use
+	simulatorOnce sync.Once
+	simulator     *simulator.Simulator
 }
 
 // NewDraftFacade creates a new DraftFacade with the given services.
@@ -258,6 +270,27 @@ func (d *DraftFacade) GetDraftDeckMetrics(ctx context.Context, sessionID string)
 	return metrics, nil
 }
 
+// GetDraftSignals reads which colors look open from the packs seen so far.
+// Returns nil if 17Lands ratings are not available for the set.
+func (d *DraftFacade) GetDraftSignals(ctx context.Context, sessionID string) (*signals.Signals, error) {
+	if d.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	analyzer := signals.NewAnalyzer(d.services.Storage.DraftRepo(), d.services.Storage.DraftRatingsRepo())
+	var result *signals.Signals
+	err := storage.RetryOnBusy(func() error {
+		var err error
+		result, err = analyzer.AnalyzeSession(ctx, sessionID)
+		return err
+	})
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to read draft signals: %v", err)}
+	}
+
+	return result, nil
+}
+
 // GetDraftPerformanceMetrics returns performance metrics for draft operations.
 func (d *DraftFacade) GetDraftPerformanceMetrics(ctx context.Context) *metrics.DraftStats {
 	if d.services.DraftMetrics == nil {
@@ -417,14 +450,21 @@ func (d *DraftFacade) AnalyzeSessionPickQuality(ctx context.Context, sessionID s
 		return &AppError{Message: fmt.Sprintf("Failed to get picks: %v", err)}
 	}
 
-	// Create pick quality analyzer
-	analyzer := pickquality.NewAnalyzer(
-		d.services.Storage.DraftRatingsRepo(),
+	// Create context-aware pick analyzer (scores each pack against the pool drafted so far)
+	analyzer, err := pickquality.NewAnalyzer(
+		d.services.DraftRatings(),
 		d.services.Storage.SetCardRepo(),
-	)
+	).NewContextAnalyzer(ctx, session.SetCode, session.EventName)
+	if err != nil {
+		return &AppError{Message: fmt.Sprintf("Failed to load ratings: %v", err)}
+	}
 
-	// Analyze each pick
+	// Analyze each pick in order, growing the pool as we go
+	pool := make([]string, 0, len(picks))
 	for _, pick := range picks {
+		pickContext := pickquality.PickContext{Pool: pool, TotalPicks: session.TotalPicks}
+		pool = append(pool, pick.CardID)
+
 		// Get the pack for this pick
 		pack, err := d.services.Storage.DraftRepo().GetPack(ctx, sessionID, pick.PackNumber, pick.PickNumber)
 		if err != nil || pack == nil {
@@ -433,7 +473,7 @@ func (d *DraftFacade) AnalyzeSessionPickQuality(ctx context.Context, sessionID s
 		}
 
 		// Ensure we have card image data (FetchCardByName checks cache first)
-		pickedRating, err := d.services.Storage.DraftRatingsRepo().GetCardRatingByArenaID(ctx, session.SetCode, session.EventName, pick.CardID)
+		pickedRating, err := d.services.DraftRatings().GetCardRatingByArenaID(ctx, session.SetCode, session.EventName, pick.CardID)
 		if err == nil && pickedRating != nil && pickedRating.Name != "" {
 			card, err := d.services.SetFetcher.FetchCardByName(ctx, session.SetCode, pickedRating.Name, pick.CardID)
 			if err != nil {
@@ -444,7 +484,7 @@ func (d *DraftFacade) AnalyzeSessionPickQuality(ctx context.Context, sessionID s
 		}
 
 		// Analyze pick quality
-		quality, err := analyzer.AnalyzePick(ctx, session.SetCode, session.EventName, pack.CardIDs, pick.CardID)
+		quality, err := analyzer.AnalyzePick(ctx, pickContext, pack.CardIDs, pick.CardID)
 		if err != nil {
 			log.Printf("Warning: Could not analyze pick %d: %v", pick.ID, err)
 			continue
@@ -470,6 +510,17 @@ func (d *DraftFacade) AnalyzeSessionPickQuality(ctx context.Context, sessionID s
 		if err != nil {
 			log.Printf("Warning: Could not update pick quality for pick %d: %v", pick.ID, err)
 		}
+
+		// Store the context score breakdown alongside the alternatives
+		breakdownJSON, err := pickquality.SerializeFactors(quality.PickedFactors)
+		if err != nil {
+			log.Printf("Warning: Could not serialize score breakdown for pick %d: %v", pick.ID, err)
+			continue
+		}
+		err = d.services.Storage.DraftRepo().UpdatePickScore(ctx, pick.ID, quality.PickedScore, quality.PackBestScore, breakdownJSON)
+		if err != nil {
+			log.Printf("Warning: Could not update pick score for pick %d: %v", pick.ID, err)
+		}
 	}
 
 	// Automatically recalculate draft grade after pick quality analysis
@@ -528,12 +579,28 @@ func (d *DraftFacade) GetPickAlternatives(ctx context.Context, sessionID string,
 		return nil, &AppError{Message: "Pack not found"}
 	}
 
-	analyzer := pickquality.NewAnalyzer(
-		d.services.Storage.DraftRatingsRepo(),
+	// Score against the pool drafted before this pick
+	picks, err := d.services.Storage.DraftRepo().GetPicksBySession(ctx, sessionID)
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to get picks: %v", err)}
+	}
+	pool := []string{}
+	for _, p := range picks {
+		if p.PackNumber < packNum || (p.PackNumber == packNum && p.PickNumber < pickNum) {
+			pool = append(pool, p.CardID)
+		}
+	}
+
+	analyzer, err := pickquality.NewAnalyzer(
+		d.services.DraftRatings(),
 		d.services.Storage.SetCardRepo(),
-	)
+	).NewContextAnalyzer(ctx, session.SetCode, session.EventName)
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to load ratings: %v", err)}
+	}
 
-	quality, err := analyzer.AnalyzePick(ctx, session.SetCode, session.EventName, pack.CardIDs, pick.CardID)
+	pickContext := pickquality.PickContext{Pool: pool, TotalPicks: session.TotalPicks}
+	quality, err := analyzer.AnalyzePick(ctx, pickContext, pack.CardIDs, pick.CardID)
 	if err != nil {
 		return nil, &AppError{Message: fmt.Sprintf("Failed to analyze pick: %v", err)}
 	}
@@ -551,7 +618,7 @@ func (d *DraftFacade) CalculateDraftGrade(ctx context.Context, sessionID string)
 	// Create grade calculator
 	calculator := grading.NewCalculator(
 		d.services.Storage.DraftRepo(),
-		d.services.Storage.DraftRatingsRepo(),
+		d.services.DraftRatings(),
 		d.services.Storage.SetCardRepo(),
 	)
 
@@ -638,6 +705,14 @@ func (d *DraftFacade) PredictDraftWinRate(ctx context.Context, sessionID string)
 		d.services.Storage.SetCardRepo(),
 	)
 
+	// Use the learned model too, if one has been trained
+	model, err := prediction.LoadLearnedModel(ctx, d.services.Storage.NewMLSuggestionRepo())
+	if err != nil {
+		log.Printf("Warning: Failed to load learned win rate model: %v", err)
+	} else if model != nil {
+		predictionService.SetLearnedModel(model)
+	}
+
 	// Calculate prediction
 	pred, err := predictionService.PredictSessionWinRate(ctx, sessionID)
 	if err != nil {
@@ -669,6 +744,252 @@ func (d *DraftFacade) GetDraftWinRatePrediction(ctx context.Context, sessionID s
 	return pred, nil
 }
 
+// TrainDraftPredictor fits the learned win rate model on completed drafts and
+// their match results, optionally adding a 17Lands game data CSV for setCode
+// and draftFormat, and stores it as the active model.
+func (d *DraftFacade) TrainDraftPredictor(ctx context.Context, gameDataCSV, setCode, draftFormat string) (*prediction.LearnedModel, error) {
+	if d.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	var extra []prediction.TrainingSample
+	if gameDataCSV != "" {
+		cards, pairWinRates, err := prediction.GameDataCards(ctx, d.services.Storage.DraftRatingsRepo(), d.services.Storage.SetCardRepo(), setCode, draftFormat)
+		if err != nil {
+			return nil, &AppError{Message: fmt.Sprintf("Failed to load cards for game data: %v", err)}
+		}
+		f, err := os.Open(gameDataCSV)
+		if err != nil {
+			return nil, &AppError{Message: fmt.Sprintf("Failed to open game data: %v", err)}
+		}
+		defer func() { _ = f.Close() }()
+		extra, err = prediction.ParseGameDataCSV(f, cards, pairWinRates)
+		if err != nil {
+			return nil, &AppError{Message: fmt.Sprintf("Failed to parse game data: %v", err)}
+		}
+		log.Printf("[TrainDraftPredictor] Loaded %d decks from %s", len(extra), gameDataCSV)
+	}
+
+	trainer := prediction.NewTrainer(
+		d.services.Storage.DraftRepo(),
+		d.services.Storage.MatchRepo(),
+		d.services.Storage.DraftRatingsRepo(),
+		d.services.Storage.SetCardRepo(),
+	)
+	model, err := trainer.Train(ctx, d.services.Storage.NewMLSuggestionRepo(), extra, prediction.DefaultFitOptions())
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to train win rate model: %v", err)}
+	}
+
+	log.Printf("[TrainDraftPredictor] Trained on %d decks (%d matches), Brier %.3f vs baseline %.3f",
+		model.Samples, model.Matches, model.Calibration.BrierScore, model.Calibration.BaselineBrier)
+	return model, nil
+}
+
+// GetDraftPredictorModel returns the active learned win rate model, or nil if none has been trained.
+func (d *DraftFacade) GetDraftPredictorModel(ctx context.Context) (*prediction.LearnedModel, error) {
+	if d.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	model, err := prediction.LoadLearnedModel(ctx, d.services.Storage.NewMLSuggestionRepo())
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to load win rate model: %v", err)}
+	}
+	return model, nil
+}
+
+// draftSimulator returns the shared practice draft simulator.
+func (d *DraftFacade) draftSimulator() *simulator.Simulator {
+	d.simulatorOnce.Do(func() {
+		d.simulator = simulator.NewSimulator(
+			d.services.SetFetcher,
+			d.services.Storage.DraftRepo(),
+			d.services.Storage.DraftRatingsRepo(),
+		)
+	})
+	return d.simulator
+}
+
+// StartSimulatedDraft opens a practice draft against seven bots. The draft is
+// stored as a draft session flagged as simulated, so stats, exports and
+// training leave it out.
+func (d *DraftFacade) StartSimulatedDraft(ctx context.Context, opts simulator.Options) (*simulator.State, error) {
+	if d.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+	if d.services.SetFetcher == nil {
+		return nil, &AppError{Message: "Card fetcher not initialized"}
+	}
+
+	state, err := d.draftSimulator().Start(ctx, opts)
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to start simulated draft: %v", err), Err: err}
+	}
+	return state, nil
+}
+
+// GetSimulatedDraft returns the current state of a practice draft.
+func (d *DraftFacade) GetSimulatedDraft(ctx context.Context, sessionID string) (*simulator.State, error) {
+	if d.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	state, err := d.draftSimulator().State(sessionID)
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to get simulated draft: %v", err), Err: err}
+	}
+	return state, nil
+}
+
+// PickSimulatedDraft makes the user's pick in a practice draft and returns the next pack.
+func (d *DraftFacade) PickSimulatedDraft(ctx context.Context, sessionID, cardID string) (*simulator.State, error) {
+	if d.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	state, err := d.draftSimulator().Pick(ctx, sessionID, cardID)
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to make pick: %v", err), Err: err}
+	}
+	return state, nil
+}
+
+// GetDraftPostMortem builds the post-mortem report for a draft session: pick
+// reviews, the color pivot, the deck against the optimal build, and how the
+// cards performed in the event.
+func (d *DraftFacade) GetDraftPostMortem(ctx context.Context, sessionID string) (*postmortem.Report, error) {
+	if d.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	var builder postmortem.DeckBuilder
+	if engine, ok := d.services.RecommendationEngine.(*recommendations.RuleBasedEngine); ok && d.services.CardService != nil {
+		builder = recommendations.NewDeckSuggester(
+			engine,
+			d.services.CardService,
+			d.services.Storage.SetCardRepo(),
+			d.services.Storage.DraftRatingsRepo(),
+		)
+	}
+
+	generator := postmortem.NewGenerator(
+		d.services.Storage.DraftRepo(),
+		d.services.Storage.MatchRepo(),
+		d.services.Storage.DeckRepo(),
+		d.services.Storage.GamePlayRepo(),
+		d.services.DraftRatings(),
+		d.services.Storage.SetCardRepo(),
+		builder,
+	)
+	report, err := generator.Generate(ctx, sessionID)
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to generate post-mortem: %v", err), Err: err}
+	}
+	return report, nil
+}
+
+// GetOptimalBuilds searches a draft session's pool for its best decks,
+// including land counts, basic splits and splashes.
+func (d *DraftFacade) GetOptimalBuilds(ctx context.Context, sessionID string, top int) (*optimizer.Result, error) {
+	if d.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	result, err := optimizer.NewOptimizer(
+		d.services.Storage.DraftRepo(),
+		d.services.Storage.SetCardRepo(),
+		d.services.Storage.DraftRatingsRepo(),
+	).OptimalBuilds(ctx, sessionID, top)
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to build decks: %v", err), Err: err}
+	}
+	return result, nil
+}
+
+// DraftPostMortemDocument is a post-mortem rendered for download.
+type DraftPostMortemDocument struct {
+	Format   string `json:"format"`
+	FileName string `json:"file_name"`
+	Content  string `json:"content"`
+}
+
+// RenderDraftPostMortem renders a draft's post-mortem as Markdown or HTML.
+func (d *DraftFacade) RenderDraftPostMortem(ctx context.Context, sessionID string, format export.Format) (*DraftPostMortemDocument, error) {
+	report, err := d.GetDraftPostMortem(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := export.ExportDraftPostMortem(&buf, report, format); err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to render post-mortem: %v", err), Err: err}
+	}
+
+	extension := string(format)
+	if format == export.FormatMarkdown {
+		extension = "md"
+	}
+	return &DraftPostMortemDocument{
+		Format:   string(format),
+		FileName: fmt.Sprintf("postmortem_%s_%s.%s", report.SetCode, report.StartTime.Format("20060102_150405"), extension),
+		Content:  buf.String(),
+	}, nil
+}
+
+// GetRatingBlends returns the user's rating blends and the blends they replaced.
+func (d *DraftFacade) GetRatingBlends(ctx context.Context) (*ratings.Config, error) {
+	if d.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	config, err := d.services.DraftRatings().Store().Load(ctx)
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to get rating blends: %v", err), Err: err}
+	}
+	return config, nil
+}
+
+// SaveRatingBlend saves a rating blend for a set and format, replacing the
+// previous version. Pick quality, grades, tier lists and the overlay use it
+// from the next lookup.
+func (d *DraftFacade) SaveRatingBlend(ctx context.Context, blend ratings.Blend) (*ratings.Blend, error) {
+	if d.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	saved, err := d.services.DraftRatings().Store().SaveBlend(ctx, blend)
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to save rating blend: %v", err), Err: err}
+	}
+	return saved, nil
+}
+
+// DeleteRatingBlend removes the rating blend for a set and format.
+func (d *DraftFacade) DeleteRatingBlend(ctx context.Context, setCode, draftFormat string) error {
+	if d.services.Storage == nil {
+		return &AppError{Message: "Database not initialized"}
+	}
+
+	if err := d.services.DraftRatings().Store().DeleteBlend(ctx, setCode, draftFormat); err != nil {
+		return &AppError{Message: fmt.Sprintf("Failed to delete rating blend: %v", err), Err: err}
+	}
+	return nil
+}
+
+// GetBlendedRatings explains each card's blended rating for a set and format.
+func (d *DraftFacade) GetBlendedRatings(ctx context.Context, setCode, draftFormat string) (*ratings.Breakdown, error) {
+	if d.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	breakdown, err := d.services.DraftRatings().Explain(ctx, setCode, draftFormat)
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to get blended ratings: %v", err), Err: err}
+	}
+	return breakdown, nil
+}
+
 // SetCardRefresher is a function type that refreshes set cards from external sources.
 type SetCardRefresher func(ctx context.Context, setCode string) (count int, err error)
 
@@ -878,7 +1199,7 @@ func (d *DraftFacade) backfillPickQualityData(ctx context.Context, sessionID, se
 		}
 
 		// Get rating for picked card
-		pickedRating, err := d.services.Storage.DraftRatingsRepo().GetCardRatingByArenaID(ctx, setCode, draftFormat, pick.CardID)
+		pickedRating, err := d.services.DraftRatings().GetCardRatingByArenaID(ctx, setCode, draftFormat, pick.CardID)
 
 		var grade string
 		if err != nil || pickedRating == nil {
@@ -908,7 +1229,7 @@ func (d *DraftFacade) backfillPickQualityData(ctx context.Context, sessionID, se
 		packRatings := make(map[string]float64)
 		bestGIHWR := 0.0
 		for _, cardID := range pack.CardIDs {
-			rating, err := d.services.Storage.DraftRatingsRepo().GetCardRatingByArenaID(ctx, setCode, draftFormat, cardID)
+			rating, err := d.services.DraftRatings().GetCardRatingByArenaID(ctx, setCode, draftFormat, cardID)
 			if err == nil && rating != nil {
 				packRatings[cardID] = rating.GIHWR
 				if rating.GIHWR > bestGIHWR {
@@ -1363,7 +1684,7 @@ func (d *DraftFacade) analyzePoolColors(ctx context.Context, setCode, eventName
 
 	for _, pick := range picks {
 		// Get card info from ratings
-		rating, err := d.services.Storage.DraftRatingsRepo().GetCardRatingByArenaID(ctx, setCode, eventName, pick.CardID)
+		rating, err := d.services.DraftRatings().GetCardRatingByArenaID(ctx, setCode, eventName, pick.CardID)
 		if err != nil || rating == nil {
 			continue
 		}
@@ -1394,7 +1715,7 @@ func (d *DraftFacade) analyzePoolColors(ctx context.Context, setCode, eventName
 // getCardWithRating builds a PackCardWithRating from card ID.
 func (d *DraftFacade) getCardWithRating(ctx context.Context, setCode, eventName, cardID string, poolColors []string, poolSize int) *PackCardWithRating {
 	// Get card rating from 17Lands data
-	rating, err := d.services.Storage.DraftRatingsRepo().GetCardRatingByArenaID(ctx, setCode, eventName, cardID)
+	rating, err := d.services.DraftRatings().GetCardRatingByArenaID(ctx, setCode, eventName, cardID)
 	if err != nil || rating == nil {
 		log.Printf("Warning: No rating found for card %s", cardID)
 		return nil
@@ -394,6 +394,35 @@ func (e *ExportFacade) GetDraftsExportData(ctx context.Context, limit int) ([]*m
 	return append(activeSessions, completedSessions...), nil
 }
 
+// ExportAnonymizedBundle writes an anonymized bundle of matches, games, plays
+// and drafts to outputDir for pooling with other players' data.
+// format is "csv" or "jsonl"; a nil filter exports everything.
+func (e *ExportFacade) ExportAnonymizedBundle(ctx context.Context, outputDir, format string, filter *models.StatsFilter, overwrite bool) (*export.AnonymizedManifest, error) {
+	if e.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+	if outputDir == "" {
+		return nil, &AppError{Message: "Output directory is required"}
+	}
+
+	opts := export.AnonymizedOptions{
+		Format:    export.Format(format),
+		OutputDir: outputDir,
+		Overwrite: overwrite,
+	}
+	if filter != nil {
+		opts.Filter = *filter
+	}
+
+	manifest, err := export.ExportAnonymized(ctx, e.services.Storage, opts)
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to export anonymized bundle: %v", err)}
+	}
+
+	log.Printf("Successfully exported anonymized bundle to %s", outputDir)
+	return manifest, nil
+}
+
 // CollectionExportEntry represents a card in the collection for export.
 type CollectionExportEntry struct {
 	CardID   int `json:"cardId"`
@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 )
@@ -45,6 +46,42 @@ func (m *MatchFacade) GetStats(ctx context.Context, filter models.StatsFilter) (
 	return m.services.Storage.GetStats(ctx, filter)
 }
 
+// MatchupStats is one cell of the matchup matrix: a deck's statistics plus
+// how far its win rate can be trusted.
+type MatchupStats struct {
+	*models.Statistics
+	WinRateCI    stats.Interval   `json:"win_rate_ci"`
+	Warning      string           `json:"warning,omitempty"`
+	Significance stats.Comparison `json:"significance"` // This deck vs all other decks combined
+}
+
+// GetMatchupMatrix returns per-deck statistics with confidence intervals and a
+// test of whether each deck performs differently from the rest.
+func (m *MatchFacade) GetMatchupMatrix(ctx context.Context, filter models.StatsFilter) (map[string]*MatchupStats, error) {
+	byDeck, err := m.GetStatsByDeck(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalWon, totalMatches int
+	for _, deckStats := range byDeck {
+		totalWon += deckStats.MatchesWon
+		totalMatches += deckStats.TotalMatches
+	}
+
+	matrix := make(map[string]*MatchupStats, len(byDeck))
+	for deck, deckStats := range byDeck {
+		matrix[deck] = &MatchupStats{
+			Statistics: deckStats,
+			WinRateCI:  stats.WilsonInterval(deckStats.MatchesWon, deckStats.TotalMatches, stats.DefaultConfidence),
+			Warning:    stats.SampleWarning(deckStats.TotalMatches),
+			Significance: stats.Compare(deckStats.MatchesWon, deckStats.TotalMatches,
+				totalWon-deckStats.MatchesWon, totalMatches-deckStats.TotalMatches),
+		}
+	}
+	return matrix, nil
+}
+
 // GetTrendAnalysis returns trend analysis for the specified time period.
 func (m *MatchFacade) GetTrendAnalysis(ctx context.Context, startDate, endDate time.Time, periodType string, formats []string) (*storage.TrendAnalysis, error) {
 	if m.services.Storage == nil {
@@ -2,16 +2,20 @@ package gui
 
 import (
 	"context"
+	"sync"
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/daemon"
 	"github.com/ramonehamilton/MTGA-Companion/internal/ipc"
 	"github.com/ramonehamilton/MTGA-Companion/internal/meta"
 	"github.com/ramonehamilton/MTGA-Companion/internal/metrics"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/analysis"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/datasets"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/setcache"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/deckexport"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/deckimport"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/priors"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/ratings"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/logreader"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/recommendations"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage"
@@ -26,6 +30,7 @@ type CardFetcher interface {
 	FetchAndCacheSet(ctx context.Context, mtgaSetCode string) (int, error)
 	RefreshSet(ctx context.Context, setCode string) (int, error)
 	GetCardByArenaID(ctx context.Context, arenaID string) (*models.SetCard, error)
+	GetCachedSet(ctx context.Context, setCode string) ([]*models.SetCard, error)
 }
 
 // Services contains all shared services needed by facades.
@@ -43,6 +48,7 @@ type Services struct {
 	RatingsFetcher   *setcache.RatingsFetcher
 	DatasetService   *datasets.Service
 	DeckImportParser *deckimport.Parser
+	DeckURLImporter  *deckimport.URLImporter
 
 	// Deck operations
 	DeckExporter         *deckexport.Exporter
@@ -66,6 +72,71 @@ type Services struct {
 
 	// Daemon service (when running integrated)
 	DaemonService *daemon.Service
+
+	// Draft ratings with early-set priors and the user's rating blends applied,
+	// built by DraftRatings
+	draftRatingsMu      sync.Mutex
+	draftRatings        *ratings.Repository
+	ratingPriors        *priors.Repository
+	draftRatingsStorage *storage.Service
+
+	// Opponent inferrer shared by the REST API and the daemon, built by
+	// OpponentInferrer
+	opponentInferrerMu      sync.Mutex
+	opponentInferrer        *analysis.OpponentInferrer
+	opponentInferrerStorage *storage.Service
+}
+
+// DraftRatings returns the draft ratings repository with predicted ratings
+// filled in for sparse sets and the user's rating blends applied. Pick
+// quality, grading, tier lists and the overlay read ratings through it so
+// they all agree. Storage must be initialized.
+func (s *Services) DraftRatings() *ratings.Repository {
+	s.draftRatingsMu.Lock()
+	defer s.draftRatingsMu.Unlock()
+	s.buildDraftRatings()
+	return s.draftRatings
+}
+
+// RatingPriors returns the early-set prior layer under DraftRatings.
+// Storage must be initialized.
+func (s *Services) RatingPriors() *priors.Repository {
+	s.draftRatingsMu.Lock()
+	defer s.draftRatingsMu.Unlock()
+	s.buildDraftRatings()
+	return s.ratingPriors
+}
+
+func (s *Services) buildDraftRatings() {
+	if s.draftRatings != nil && s.draftRatingsStorage == s.Storage {
+		return
+	}
+	s.draftRatings, s.ratingPriors = ratings.NewStorageRepository(s.Storage)
+	s.draftRatingsStorage = s.Storage
+}
+
+// OpponentInferrer returns the opponent inferrer, with metagame shares from
+// MetaService in its archetype priors when MetaService is set. The REST API
+// and the daemon's live match events both read opponents through it so they
+// agree. Storage must be initialized.
+func (s *Services) OpponentInferrer() *analysis.OpponentInferrer {
+	s.opponentInferrerMu.Lock()
+	defer s.opponentInferrerMu.Unlock()
+	if s.opponentInferrer != nil && s.opponentInferrerStorage == s.Storage {
+		return s.opponentInferrer
+	}
+
+	s.opponentInferrer = analysis.NewOpponentInferrer(
+		s.Storage.GamePlayRepo(),
+		s.Storage.NewOpponentRepo(),
+		s.Storage.MatchRepo(),
+		s.Storage.NewSetCardRepo(),
+	)
+	if s.MetaService != nil {
+		s.opponentInferrer.SetMetaSource(s.MetaService)
+	}
+	s.opponentInferrerStorage = s.Storage
+	return s.opponentInferrer
 }
 
 // AppError represents an application error with a user-friendly message.
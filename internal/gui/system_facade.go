@@ -71,6 +71,13 @@ func (s *SystemFacade) Initialize(ctx context.Context, dbPath string) error {
 	}
 	s.services.Storage = storage.NewService(db)
 
+	// Practice drafts live in memory, so ones left unfinished by the last run are orphaned
+	if deleted, err := s.services.Storage.DraftRepo().DeleteUnfinishedSimulations(ctx); err != nil {
+		log.Printf("Warning: Failed to clean up unfinished practice drafts: %v", err)
+	} else if deleted > 0 {
+		log.Printf("Removed %d unfinished practice drafts", deleted)
+	}
+
 	// Initialize card services
 	scryfallClient := scryfall.NewClient()
 
@@ -104,8 +111,9 @@ func (s *SystemFacade) Initialize(ctx context.Context, dbPath string) error {
 	}
 	s.services.CardService = cardService
 
-	// Initialize DeckImportParser (depends on CardService)
-	s.services.DeckImportParser = deckimport.NewParser(cardService)
+	// Initialize DeckImportParser, resolving card names against SetCardRepo
+	s.services.DeckImportParser = deckimport.NewParser(s.services.Storage.SetCardRepo())
+	s.services.DeckURLImporter = deckimport.NewURLImporter(s.services.DeckImportParser)
 
 	// Initialize DeckExporter with a CardProvider that checks SetCardRepo first
 	// This ensures draft cards are found in the local database before trying Scryfall
@@ -382,6 +390,42 @@ func (s *SystemFacade) setupEventHandlers(ctx context.Context) {
 		})
 	})
 
+	// Handle draft:signals events from daemon
+	s.services.IPCClient.On("draft:signals", func(data map[string]interface{}) {
+		s.eventDispatcher.Dispatch(events.Event{
+			Type:    "draft:signals",
+			Data:    data,
+			Context: ctx,
+		})
+	})
+
+	// Handle match:opponent_inference events from daemon
+	s.services.IPCClient.On("match:opponent_inference", func(data map[string]interface{}) {
+		s.eventDispatcher.Dispatch(events.Event{
+			Type:    "match:opponent_inference",
+			Data:    data,
+			Context: ctx,
+		})
+	})
+
+	// Handle match:scouting events from daemon
+	s.services.IPCClient.On("match:scouting", func(data map[string]interface{}) {
+		s.eventDispatcher.Dispatch(events.Event{
+			Type:    "match:scouting",
+			Data:    data,
+			Context: ctx,
+		})
+	})
+
+	// Handle match:mulligan_advice events from daemon
+	s.services.IPCClient.On("match:mulligan_advice", func(data map[string]interface{}) {
+		s.eventDispatcher.Dispatch(events.Event{
+			Type:    "match:mulligan_advice",
+			Data:    data,
+			Context: ctx,
+		})
+	})
+
 	// Handle collection:updated events from daemon
 	s.services.IPCClient.On("collection:updated", func(data map[string]interface{}) {
 		log.Printf("Received collection:updated event from daemon: %v", data)
@@ -1006,6 +1050,24 @@ func (s *SystemFacade) GetHealth(ctx context.Context) (*HealthStatus, error) {
 	}, nil
 }
 
+// RunDoctor checks the database for integrity problems and inconsistent rows.
+// When fix is true, the safe repairs are applied and reported alongside the findings.
+func (s *SystemFacade) RunDoctor(ctx context.Context, fix bool) (*storage.DoctorReport, error) {
+	if s.services.Storage == nil {
+		return nil, &AppError{Message: "Database not initialized"}
+	}
+
+	opts := storage.DefaultDoctorOptions()
+	opts.Fix = fix
+
+	report, err := s.services.Storage.RunDoctor(ctx, opts)
+	if err != nil {
+		return nil, &AppError{Message: fmt.Sprintf("Failed to run database doctor: %v", err)}
+	}
+
+	return report, nil
+}
+
 // localFirstCardProvider implements deckexport.CardProvider by checking
 // SetCardRepo first (local database) before falling back to CardService (Scryfall).
 // This ensures draft cards are found locally without expensive API calls.
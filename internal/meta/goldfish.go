@@ -83,8 +83,9 @@ type FormatMeta struct {
 
 // MetaCache caches meta data.
 type MetaCache struct {
-	data map[string]*CacheEntry
-	mu   sync.RWMutex
+	data  map[string]*CacheEntry
+	lists map[string]*DeckListEntry // By archetype page URL
+	mu    sync.RWMutex
 }
 
 // CacheEntry represents a cached meta entry.
@@ -93,6 +94,13 @@ type CacheEntry struct {
 	ExpiresAt time.Time
 }
 
+// DeckListEntry represents a cached archetype deck list.
+type DeckListEntry struct {
+	Mainboard []DeckCard
+	Sideboard []DeckCard
+	ExpiresAt time.Time
+}
+
 // NewGoldfishClient creates a new MTGGoldfish client.
 func NewGoldfishClient(config *GoldfishConfig) *GoldfishClient {
 	if config == nil {
@@ -107,7 +115,8 @@ func NewGoldfishClient(config *GoldfishConfig) *GoldfishClient {
 		cacheTTL:    config.CacheTTL,
 		rateLimiter: time.NewTicker(time.Duration(config.RateLimitMs) * time.Millisecond),
 		cache: &MetaCache{
-			data: make(map[string]*CacheEntry),
+			data:  make(map[string]*CacheEntry),
+			lists: make(map[string]*DeckListEntry),
 		},
 	}
 }
@@ -196,11 +205,24 @@ func (c *GoldfishClient) fetchMeta(ctx context.Context, format string) (*FormatM
 		return nil, fmt.Errorf("unsupported format: %s", format)
 	}
 
-	url := c.baseURL + urlPath
+	body, err := c.fetchPage(ctx, c.baseURL+urlPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the HTML response
+	meta := c.parseMetaPage(body, format)
+	meta.Source = "mtggoldfish"
+	meta.LastUpdated = time.Now()
 
+	return meta, nil
+}
+
+// fetchPage downloads a page from MTGGoldfish.
+func (c *GoldfishClient) fetchPage(ctx context.Context, url string) (string, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("User-Agent", "MTGA-Companion/1.4.0")
@@ -208,25 +230,107 @@ func (c *GoldfishClient) fetchMeta(ctx context.Context, format string) (*FormatM
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return "", fmt.Errorf("request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
+		return "", fmt.Errorf("unexpected status: %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	return string(body), nil
+}
+
+// GetDeckList returns the mainboard and sideboard of the deck shown on an
+// archetype page, read from the page's text download. Lists are cached like
+// the meta.
+func (c *GoldfishClient) GetDeckList(ctx context.Context, archetypeURL string) ([]DeckCard, []DeckCard, error) {
+	if cached := c.getDeckListFromCache(archetypeURL); cached != nil {
+		return cached.Mainboard, cached.Sideboard, nil
 	}
 
-	// Parse the HTML response
-	meta := c.parseMetaPage(string(body), format)
-	meta.Source = "mtggoldfish"
-	meta.LastUpdated = time.Now()
+	c.waitForRateLimit()
+	page, err := c.fetchPage(ctx, archetypeURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch archetype page: %w", err)
+	}
+	download := deckDownloadPattern.FindStringSubmatch(page)
+	if download == nil {
+		return nil, nil, fmt.Errorf("no deck download on archetype page: %s", archetypeURL)
+	}
 
-	return meta, nil
+	c.waitForRateLimit()
+	text, err := c.fetchPage(ctx, c.baseURL+"/deck/download/"+download[1])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download deck: %w", err)
+	}
+	mainboard, sideboard := parseDeckList(text)
+	if len(mainboard) == 0 {
+		return nil, nil, fmt.Errorf("empty deck list: %s", archetypeURL)
+	}
+
+	c.cache.mu.Lock()
+	c.cache.lists[archetypeURL] = &DeckListEntry{
+		Mainboard: mainboard,
+		Sideboard: sideboard,
+		ExpiresAt: time.Now().Add(c.cacheTTL),
+	}
+	c.cache.mu.Unlock()
+
+	return mainboard, sideboard, nil
+}
+
+// getDeckListFromCache retrieves a deck list from cache if not expired.
+func (c *GoldfishClient) getDeckListFromCache(archetypeURL string) *DeckListEntry {
+	c.cache.mu.RLock()
+	defer c.cache.mu.RUnlock()
+
+	entry, exists := c.cache.lists[archetypeURL]
+	if !exists || time.Now().After(entry.ExpiresAt) {
+		return nil
+	}
+	return entry
+}
+
+// deckDownloadPattern matches the text download link on an archetype page.
+var deckDownloadPattern = regexp.MustCompile(`/deck/download/(\d+)`)
+
+// archetypeLinkPattern matches a link to an archetype page. Group 1 is the
+// path without its fragment and group 2 the link text.
+var archetypeLinkPattern = regexp.MustCompile(`<a[^>]*href=['"](/archetype/[^'"#]+)[^'"]*['"][^>]*>([^<]+)</a>`)
+
+// parseDeckList parses a text deck list of "4 Card Name" lines. The
+// sideboard follows a blank line or a "Sideboard" header.
+func parseDeckList(text string) (mainboard, sideboard []DeckCard) {
+	inSideboard := false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			inSideboard = inSideboard || len(mainboard) > 0
+			continue
+		}
+		if strings.EqualFold(line, "sideboard") {
+			inSideboard = true
+			continue
+		}
+
+		quantity, name, ok := strings.Cut(line, " ")
+		copies, err := strconv.Atoi(quantity)
+		if !ok || err != nil || copies <= 0 {
+			continue
+		}
+		card := DeckCard{Name: strings.TrimSpace(name), Quantity: copies}
+		if inSideboard {
+			sideboard = append(sideboard, card)
+		} else {
+			mainboard = append(mainboard, card)
+		}
+	}
+	return mainboard, sideboard
 }
 
 // parseMetaPage parses the MTGGoldfish meta page HTML.
@@ -270,6 +374,15 @@ func (c *GoldfishClient) parseMetaPage(html, format string) *FormatMeta {
 		matches = tablePattern.FindAllStringSubmatch(html, -1)
 	}
 
+	// Archetype pages by link text, for fetching deck lists later
+	links := make(map[string]string)
+	for _, link := range archetypeLinkPattern.FindAllStringSubmatch(html, -1) {
+		name := strings.TrimSpace(link[2])
+		if _, ok := links[name]; !ok {
+			links[name] = c.baseURL + link[1]
+		}
+	}
+
 	tier := 1
 	tierThresholds := []float64{5.0, 2.0, 0.5} // Tier 1 > 5%, Tier 2 > 2%, Tier 3 > 0.5%
 
@@ -319,6 +432,7 @@ func (c *GoldfishClient) parseMetaPage(html, format string) *FormatMeta {
 			Tier:          tier,
 			MetaShare:     share,
 			Colors:        colors,
+			URL:           links[name],
 			LastUpdated:   time.Now(),
 		}
 
@@ -444,6 +558,7 @@ func (c *GoldfishClient) ClearCache() {
 	defer c.cache.mu.Unlock()
 
 	c.cache.data = make(map[string]*CacheEntry)
+	c.cache.lists = make(map[string]*DeckListEntry)
 }
 
 // RefreshMeta forces a refresh of meta data for a format.
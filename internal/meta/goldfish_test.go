@@ -123,6 +123,72 @@ func TestGoldfishClient_GetMeta(t *testing.T) {
 	}
 }
 
+func TestGoldfishClient_GetDeckList(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/metagame/standard/full":
+			_, _ = w.Write([]byte(`
+			<div class='archetype-tile' id='1'>
+			<div class='archetype-tile-title'>
+				<a href="/archetype/standard-mono-red#paper">Mono Red Aggro</a>
+			</div>
+			<div class='archetype-tile-statistic metagame-percentage'>
+				<div class='archetype-tile-statistic-value'>15.5%</div>
+			</div>
+			</div>`))
+		case "/archetype/standard-mono-red":
+			_, _ = w.Write([]byte(`<a class="btn" href="/deck/download/6543210">Download</a>`))
+		case "/deck/download/6543210":
+			_, _ = w.Write([]byte("4 Monastery Swiftspear\r\n20 Mountain\r\n\r\n2 Duress\r\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewGoldfishClient(&GoldfishConfig{
+		BaseURL:        server.URL,
+		CacheTTL:       1 * time.Hour,
+		RequestTimeout: 5 * time.Second,
+		RateLimitMs:    10,
+	})
+
+	ctx := context.Background()
+	decks, err := client.GetTopDecks(ctx, "standard", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(decks) != 1 || decks[0].URL != server.URL+"/archetype/standard-mono-red" {
+		t.Fatalf("expected the archetype page URL on the deck, got %+v", decks)
+	}
+
+	mainboard, sideboard, err := client.GetDeckList(ctx, decks[0].URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mainboard) != 2 || mainboard[0].Name != "Monastery Swiftspear" || mainboard[0].Quantity != 4 {
+		t.Errorf("unexpected mainboard %+v", mainboard)
+	}
+	if len(sideboard) != 1 || sideboard[0].Name != "Duress" || sideboard[0].Quantity != 2 {
+		t.Errorf("unexpected sideboard %+v", sideboard)
+	}
+
+	// The list is cached
+	before := requests
+	if _, _, err := client.GetDeckList(ctx, decks[0].URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != before {
+		t.Errorf("expected a cached deck list, made %d more requests", requests-before)
+	}
+
+	if _, _, err := client.GetDeckList(ctx, server.URL+"/archetype/missing"); err == nil {
+		t.Error("expected an error for a missing archetype page")
+	}
+}
+
 func TestGoldfishClient_GetMeta_UnsupportedFormat(t *testing.T) {
 	config := &GoldfishConfig{
 		BaseURL:        "https://test.url",
@@ -279,6 +279,17 @@ func (s *Service) GetTopArchetypes(ctx context.Context, format string, limit int
 	return meta.Archetypes[:limit], nil
 }
 
+// GetTopDecks returns the top N MTGGoldfish decks for a format.
+func (s *Service) GetTopDecks(ctx context.Context, format string, limit int) ([]*MetaDeck, error) {
+	return s.goldfishClient.GetTopDecks(ctx, format, limit)
+}
+
+// GetDeckList returns the mainboard and sideboard of an MTGGoldfish archetype,
+// given the archetype page URL of one of its top decks.
+func (s *Service) GetDeckList(ctx context.Context, archetypeURL string) ([]DeckCard, []DeckCard, error) {
+	return s.goldfishClient.GetDeckList(ctx, archetypeURL)
+}
+
 // GetArchetypeByName finds an archetype by name.
 func (s *Service) GetArchetypeByName(ctx context.Context, format, name string) (*AggregatedArchetype, error) {
 	meta, err := s.GetAggregatedMeta(ctx, format)
@@ -0,0 +1,465 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+// lethalAdjacentLife is the life total at which taking more damage is
+// treated as close to losing.
+const lethalAdjacentLife = 5
+
+// Misplay is a likely mistake found by a MisplayRule.
+type Misplay struct {
+	Rule        string
+	Severity    string // low, medium, high (the suggestion priorities)
+	MatchID     string
+	Turn        int
+	Description string
+	CardID      *int
+	CardName    *string
+	Evidence    map[string]interface{}
+}
+
+// MisplayRule is a heuristic evaluated over one game's plays and snapshots.
+type MisplayRule interface {
+	// Name identifies the rule in misplays and suggestion evidence.
+	Name() string
+	// Title is the heading of the suggestion made when the rule keeps firing.
+	Title() string
+	// Advice explains how to avoid the misplay.
+	Advice() string
+	// Check returns the misplays the rule finds in a game.
+	Check(game *MisplayGame) []Misplay
+}
+
+// CardLookup resolves Arena card IDs for rules that need card text or mana value.
+type CardLookup interface {
+	GetCardByArenaID(ctx context.Context, arenaID string) (*models.SetCard, error)
+}
+
+// DefaultMisplayRules returns the rules the analyzer runs unless replaced.
+func DefaultMisplayRules() []MisplayRule {
+	return []MisplayRule{
+		&UnusedManaRule{},
+		&MissedLandDropRule{},
+		&BadAttackRule{},
+		&HeldRemovalRule{},
+		&PrecombatThreatRule{},
+	}
+}
+
+// MisplayGame is one game prepared for the rules: plays grouped by turn,
+// snapshots and decoded boards by turn, and the cards they reference.
+type MisplayGame struct {
+	MatchID     string
+	MaxTurn     int
+	PlaysByTurn map[int][]*models.GamePlay
+	Snapshots   map[int]*models.GameStateSnapshot
+	Boards      map[int]*repository.BoardState
+	Cards       map[int]*models.SetCard
+}
+
+// NewMisplayGame groups a game's plays and snapshots for the rules. Cards
+// may be nil, in which case rules that need card data find nothing.
+func NewMisplayGame(matchID string, plays []*models.GamePlay, snapshots []*models.GameStateSnapshot, cards map[int]*models.SetCard) *MisplayGame {
+	game := &MisplayGame{
+		MatchID:     matchID,
+		PlaysByTurn: make(map[int][]*models.GamePlay),
+		Snapshots:   make(map[int]*models.GameStateSnapshot),
+		Boards:      make(map[int]*repository.BoardState),
+		Cards:       cards,
+	}
+	for _, play := range plays {
+		game.PlaysByTurn[play.TurnNumber] = append(game.PlaysByTurn[play.TurnNumber], play)
+		if play.TurnNumber > game.MaxTurn {
+			game.MaxTurn = play.TurnNumber
+		}
+	}
+	for _, turnPlays := range game.PlaysByTurn {
+		sort.SliceStable(turnPlays, func(i, j int) bool {
+			return turnPlays[i].SequenceNumber < turnPlays[j].SequenceNumber
+		})
+	}
+	for _, snapshot := range snapshots {
+		game.Snapshots[snapshot.TurnNumber] = snapshot
+		if snapshot.TurnNumber > game.MaxTurn {
+			game.MaxTurn = snapshot.TurnNumber
+		}
+		if board, err := repository.ParseBoardState(snapshot.BoardStateJSON); err == nil && board != nil {
+			game.Boards[snapshot.TurnNumber] = board
+		}
+	}
+	return game
+}
+
+// Card returns the card for an Arena ID, or nil when it is unknown.
+func (g *MisplayGame) Card(cardID int) *models.SetCard {
+	if g.Cards == nil {
+		return nil
+	}
+	return g.Cards[cardID]
+}
+
+// PlayerTurn reports whether the player was the active player on a turn.
+func (g *MisplayGame) PlayerTurn(turn int) bool {
+	if snapshot := g.Snapshots[turn]; snapshot != nil && snapshot.ActivePlayer != "" {
+		return snapshot.ActivePlayer == models.PlayerTypePlayer
+	}
+	// Without a snapshot, only the active player drops lands and attacks
+	for _, play := range g.PlaysByTurn[turn] {
+		if play.ActionType == models.ActionTypeLandDrop || play.ActionType == models.ActionTypeAttack {
+			return play.PlayerType == models.PlayerTypePlayer
+		}
+	}
+	return false
+}
+
+// BoardBefore returns the latest board recorded before a turn.
+func (g *MisplayGame) BoardBefore(turn int) *repository.BoardState {
+	for t := turn - 1; t >= 0; t-- {
+		if board := g.Boards[t]; board != nil {
+			return board
+		}
+	}
+	return nil
+}
+
+// PlayerPlays returns the player's plays of an action type on a turn.
+func (g *MisplayGame) PlayerPlays(turn int, actionType string) []*models.GamePlay {
+	var plays []*models.GamePlay
+	for _, play := range g.PlaysByTurn[turn] {
+		if play.PlayerType == models.PlayerTypePlayer && play.ActionType == actionType {
+			plays = append(plays, play)
+		}
+	}
+	return plays
+}
+
+// CheckMisplays runs rules over a game and returns everything they find.
+func CheckMisplays(game *MisplayGame, rules []MisplayRule) []Misplay {
+	var misplays []Misplay
+	for _, rule := range rules {
+		for _, misplay := range rule.Check(game) {
+			misplay.Rule = rule.Name()
+			misplay.MatchID = game.MatchID
+			misplays = append(misplays, misplay)
+		}
+	}
+	sort.SliceStable(misplays, func(i, j int) bool {
+		return misplays[i].Turn < misplays[j].Turn
+	})
+	return misplays
+}
+
+// UnusedManaRule flags the player's turns that ended with untapped lands and
+// a sorcery-speed card in hand that those lands could have paid for.
+type UnusedManaRule struct{}
+
+func (r *UnusedManaRule) Name() string  { return "unused_mana" }
+func (r *UnusedManaRule) Title() string { return "Unused Mana" }
+func (r *UnusedManaRule) Advice() string {
+	return "Turns ended with mana open and a castable card in hand. Spend your mana every turn unless you are holding up an instant."
+}
+
+func (r *UnusedManaRule) Check(game *MisplayGame) []Misplay {
+	var misplays []Misplay
+	for turn := 1; turn <= game.MaxTurn; turn++ {
+		board := game.Boards[turn]
+		if board == nil || !game.PlayerTurn(turn) {
+			continue
+		}
+		untapped := countLands(board.PlayerPermanents, true)
+		if untapped == 0 {
+			continue
+		}
+
+		var castable *models.SetCard
+		for _, obj := range board.PlayerHand {
+			card := game.Card(obj.CardID)
+			if card == nil || isLandCard(card) || isInstantSpeed(card) || card.CMC == 0 || card.CMC > untapped {
+				continue
+			}
+			if castable == nil || card.CMC > castable.CMC {
+				castable = card
+			}
+		}
+		if castable == nil {
+			continue
+		}
+		misplays = append(misplays, cardMisplay(castable, Misplay{
+			Severity:    models.SuggestionPriorityMedium,
+			Turn:        turn,
+			Description: fmt.Sprintf("Turn %d ended with %d untapped lands and %s (%d mana) still in hand", turn, untapped, castable.Name, castable.CMC),
+			Evidence:    map[string]interface{}{"untappedLands": untapped, "cardCmc": castable.CMC},
+		}))
+	}
+	return misplays
+}
+
+// MissedLandDropRule flags the player's turns without a land drop while a
+// land was in hand.
+type MissedLandDropRule struct{}
+
+func (r *MissedLandDropRule) Name() string  { return "missed_land_drop" }
+func (r *MissedLandDropRule) Title() string { return "Lands Left in Hand" }
+func (r *MissedLandDropRule) Advice() string {
+	return "Turns passed without a land drop while a land was in hand. Play a land every turn unless you have a specific reason to hold it."
+}
+
+func (r *MissedLandDropRule) Check(game *MisplayGame) []Misplay {
+	var misplays []Misplay
+	for turn := 1; turn <= game.MaxTurn; turn++ {
+		board := game.Boards[turn]
+		if board == nil || !game.PlayerTurn(turn) || len(game.PlayerPlays(turn, models.ActionTypeLandDrop)) > 0 {
+			continue
+		}
+		for _, obj := range board.PlayerHand {
+			if !obj.HasType("Land") && !isLandCard(game.Card(obj.CardID)) {
+				continue
+			}
+			misplay := Misplay{
+				Severity:    models.SuggestionPriorityHigh,
+				Turn:        turn,
+				Description: fmt.Sprintf("No land played on turn %d with a land in hand", turn),
+				Evidence:    map[string]interface{}{"landsInPlay": countLands(board.PlayerPermanents, false)},
+			}
+			if card := game.Card(obj.CardID); card != nil {
+				misplay = cardMisplay(card, misplay)
+			}
+			misplays = append(misplays, misplay)
+			break
+		}
+	}
+	return misplays
+}
+
+// BadAttackRule flags attacks into an untapped creature that could block,
+// kill the attacker and survive.
+type BadAttackRule struct{}
+
+func (r *BadAttackRule) Name() string  { return "bad_attack" }
+func (r *BadAttackRule) Title() string { return "Unfavorable Attacks" }
+func (r *BadAttackRule) Advice() string {
+	return "Creatures attacked into blockers that kill them and survive. Check the opponent's untapped creatures before attacking."
+}
+
+func (r *BadAttackRule) Check(game *MisplayGame) []Misplay {
+	var misplays []Misplay
+	for turn := 1; turn <= game.MaxTurn; turn++ {
+		attacks := game.PlayerPlays(turn, models.ActionTypeAttack)
+		if len(attacks) == 0 {
+			continue
+		}
+		// Blockers untap on the opponent's turn, so the board before this turn shows who can block
+		board := game.BoardBefore(turn)
+		if board == nil {
+			continue
+		}
+		for _, attack := range attacks {
+			if attack.CardID == nil {
+				continue
+			}
+			attacker := findPermanent(board.PlayerPermanents, *attack.CardID)
+			if attacker == nil || hasEvasion(game.Card(*attack.CardID)) {
+				continue
+			}
+			for _, blocker := range board.OpponentPermanents {
+				if blocker.IsTapped || !blocker.HasType("Creature") {
+					continue
+				}
+				if blocker.Power < attacker.Toughness || blocker.Toughness <= attacker.Power {
+					continue
+				}
+				misplays = append(misplays, playMisplay(attack, Misplay{
+					Severity:    models.SuggestionPriorityMedium,
+					Turn:        turn,
+					Description: fmt.Sprintf("Attacked with a %d/%d into an untapped %d/%d blocker", attacker.Power, attacker.Toughness, blocker.Power, blocker.Toughness),
+					Evidence: map[string]interface{}{
+						"attacker":      fmt.Sprintf("%d/%d", attacker.Power, attacker.Toughness),
+						"blocker":       fmt.Sprintf("%d/%d", blocker.Power, blocker.Toughness),
+						"blockerCardId": blocker.CardID,
+					},
+				}))
+				break
+			}
+		}
+	}
+	return misplays
+}
+
+// HeldRemovalRule flags the opponent's turns that left the player at low
+// life while removal the player could afford sat in hand unused.
+type HeldRemovalRule struct{}
+
+func (r *HeldRemovalRule) Name() string  { return "held_removal" }
+func (r *HeldRemovalRule) Title() string { return "Removal Held Too Long" }
+func (r *HeldRemovalRule) Advice() string {
+	return "You took damage down to a low life total while holding affordable removal. Use removal on threats before they put you in lethal range."
+}
+
+func (r *HeldRemovalRule) Check(game *MisplayGame) []Misplay {
+	var misplays []Misplay
+	for turn := 2; turn <= game.MaxTurn; turn++ {
+		if game.PlayerTurn(turn) {
+			continue
+		}
+		before, after := game.Snapshots[turn-1], game.Snapshots[turn]
+		if before == nil || after == nil || before.PlayerLife == nil || after.PlayerLife == nil {
+			continue
+		}
+		lifeLost := *before.PlayerLife - *after.PlayerLife
+		if lifeLost <= 0 || *after.PlayerLife > lethalAdjacentLife {
+			continue
+		}
+		boardBefore, boardAfter := game.Boards[turn-1], game.Boards[turn]
+		if boardBefore == nil || boardAfter == nil {
+			continue
+		}
+		lands := countLands(boardBefore.PlayerPermanents, false)
+		for _, obj := range boardBefore.PlayerHand {
+			card := game.Card(obj.CardID)
+			if card == nil || !cards.IsRemovalText(card.Text) || card.CMC > lands || !inHand(boardAfter, obj.InstanceID) {
+				continue
+			}
+			misplays = append(misplays, cardMisplay(card, Misplay{
+				Severity:    models.SuggestionPriorityHigh,
+				Turn:        turn,
+				Description: fmt.Sprintf("Fell to %d life on turn %d while holding %s", *after.PlayerLife, turn, card.Name),
+				Evidence:    map[string]interface{}{"lifeLost": lifeLost, "lifeAfter": *after.PlayerLife, "landsInPlay": lands},
+			}))
+			break
+		}
+	}
+	return misplays
+}
+
+// PrecombatThreatRule flags creatures cast before attacking that had no
+// reason to come down first: no haste and no enters-the-battlefield effect.
+type PrecombatThreatRule struct{}
+
+func (r *PrecombatThreatRule) Name() string  { return "precombat_threat" }
+func (r *PrecombatThreatRule) Title() string { return "Creatures Cast Before Combat" }
+func (r *PrecombatThreatRule) Advice() string {
+	return "Creatures without haste or combat-relevant effects were cast before attacking. Cast them after combat to keep the opponent guessing about tricks."
+}
+
+func (r *PrecombatThreatRule) Check(game *MisplayGame) []Misplay {
+	var misplays []Misplay
+	for turn := 1; turn <= game.MaxTurn; turn++ {
+		attacks := game.PlayerPlays(turn, models.ActionTypeAttack)
+		if len(attacks) == 0 {
+			continue
+		}
+		firstAttack := attacks[0].SequenceNumber
+		for _, play := range game.PlayerPlays(turn, models.ActionTypePlayCard) {
+			if play.CardID == nil || play.SequenceNumber > firstAttack || play.Phase != models.PhaseMain1 {
+				continue
+			}
+			card := game.Card(*play.CardID)
+			if card == nil || !hasType(card, "Creature") || affectsCombat(card) {
+				continue
+			}
+			misplays = append(misplays, playMisplay(play, Misplay{
+				Severity:    models.SuggestionPriorityLow,
+				Turn:        turn,
+				Description: fmt.Sprintf("Cast %s before combat on turn %d", card.Name, turn),
+				Evidence:    map[string]interface{}{"attackers": len(attacks)},
+			}))
+		}
+	}
+	return misplays
+}
+
+// countLands counts the lands among permanents, only untapped ones if asked.
+func countLands(permanents []repository.PermanentState, untappedOnly bool) int {
+	n := 0
+	for _, p := range permanents {
+		if p.HasType("Land") && (!untappedOnly || !p.IsTapped) {
+			n++
+		}
+	}
+	return n
+}
+
+func findPermanent(permanents []repository.PermanentState, cardID int) *repository.PermanentState {
+	for i, p := range permanents {
+		if p.CardID == cardID {
+			return &permanents[i]
+		}
+	}
+	return nil
+}
+
+func inHand(board *repository.BoardState, instanceID int) bool {
+	for _, obj := range board.PlayerHand {
+		if obj.InstanceID == instanceID {
+			return true
+		}
+	}
+	return false
+}
+
+func hasType(card *models.SetCard, cardType string) bool {
+	for _, t := range card.Types {
+		if strings.EqualFold(t, cardType) {
+			return true
+		}
+	}
+	return false
+}
+
+func isLandCard(card *models.SetCard) bool {
+	return card != nil && hasType(card, "Land")
+}
+
+func isInstantSpeed(card *models.SetCard) bool {
+	return hasType(card, "Instant") || strings.Contains(strings.ToLower(card.Text), "flash")
+}
+
+// hasEvasion reports whether blockers on the ground may not be able to block the card.
+func hasEvasion(card *models.SetCard) bool {
+	if card == nil {
+		return false
+	}
+	text := strings.ToLower(card.Text)
+	for _, keyword := range []string{"flying", "menace", "trample", "can't be blocked", "skulk", "shadow"} {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// affectsCombat reports whether casting the card before combat can matter.
+func affectsCombat(card *models.SetCard) bool {
+	text := strings.ToLower(card.Text)
+	for _, pattern := range []string{"haste", "enters", "creatures you control get", "other creatures", "whenever a creature you control attacks"} {
+		if strings.Contains(text, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+func cardMisplay(card *models.SetCard, misplay Misplay) Misplay {
+	if id, err := strconv.Atoi(card.ArenaID); err == nil {
+		misplay.CardID = &id
+	}
+	name := card.Name
+	misplay.CardName = &name
+	return misplay
+}
+
+func playMisplay(play *models.GamePlay, misplay Misplay) Misplay {
+	misplay.CardID = play.CardID
+	misplay.CardName = play.CardName
+	return misplay
+}
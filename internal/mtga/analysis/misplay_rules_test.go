@@ -0,0 +1,245 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+var testCards = map[int]*models.SetCard{
+	1: {ArenaID: "1", Name: "Forest", Types: []string{"Basic", "Land", "Forest"}},
+	2: {ArenaID: "2", Name: "Grizzly Bears", CMC: 2, Types: []string{"Creature", "Bear"}},
+	3: {ArenaID: "3", Name: "Murder", CMC: 3, Types: []string{"Instant"}, Text: "Destroy target creature."},
+	4: {ArenaID: "4", Name: "Hill Giant", CMC: 4, Types: []string{"Creature", "Giant"}},
+	5: {ArenaID: "5", Name: "Raging Goblin", CMC: 1, Types: []string{"Creature", "Goblin"}, Text: "Haste"},
+}
+
+func land(tapped bool) repository.PermanentState {
+	return repository.PermanentState{CardID: 1, CardTypes: []string{"Land"}, IsTapped: tapped}
+}
+
+func creature(cardID, power, toughness int) repository.PermanentState {
+	return repository.PermanentState{CardID: cardID, CardTypes: []string{"Creature"}, Power: power, Toughness: toughness}
+}
+
+func handCard(instanceID, cardID int) repository.PermanentState {
+	return repository.PermanentState{InstanceID: instanceID, CardID: cardID}
+}
+
+func snapshot(turn int, active string, life int, board repository.BoardState) *models.GameStateSnapshot {
+	data, _ := json.Marshal(board)
+	boardJSON := string(data)
+	return &models.GameStateSnapshot{TurnNumber: turn, ActivePlayer: active, PlayerLife: &life, BoardStateJSON: &boardJSON}
+}
+
+func play(turn, seq int, action, phase string, cardID int) *models.GamePlay {
+	return &models.GamePlay{TurnNumber: turn, SequenceNumber: seq, PlayerType: models.PlayerTypePlayer, ActionType: action, Phase: phase, CardID: &cardID}
+}
+
+func rulesFired(misplays []Misplay) map[string]int {
+	fired := make(map[string]int)
+	for _, m := range misplays {
+		fired[m.Rule]++
+	}
+	return fired
+}
+
+func TestUnusedManaAndMissedLandDrop(t *testing.T) {
+	snapshots := []*models.GameStateSnapshot{
+		// Two lands open, a land and a castable two-drop left in hand
+		snapshot(3, models.PlayerTypePlayer, 20, repository.BoardState{
+			PlayerPermanents: []repository.PermanentState{land(false), land(false)},
+			PlayerHand:       []repository.PermanentState{handCard(10, 1), handCard(11, 2), handCard(12, 3)},
+		}),
+		// Mana spent and land played: nothing to flag
+		snapshot(5, models.PlayerTypePlayer, 20, repository.BoardState{
+			PlayerPermanents: []repository.PermanentState{land(true), land(true), land(true)},
+			PlayerHand:       []repository.PermanentState{handCard(11, 2)},
+		}),
+	}
+	plays := []*models.GamePlay{play(5, 1, models.ActionTypeLandDrop, models.PhaseMain1, 1)}
+
+	misplays := CheckMisplays(NewMisplayGame("m1", plays, snapshots, testCards), DefaultMisplayRules())
+	fired := rulesFired(misplays)
+	if fired["unused_mana"] != 1 || fired["missed_land_drop"] != 1 || len(misplays) != 2 {
+		t.Fatalf("got %+v", misplays)
+	}
+	for _, m := range misplays {
+		if m.Turn != 3 || m.MatchID != "m1" {
+			t.Errorf("misplay %+v should be on turn 3 of m1", m)
+		}
+		if m.Rule == "unused_mana" && (m.CardName == nil || *m.CardName != "Grizzly Bears") {
+			t.Errorf("unused mana should name the castable creature, not the instant: %+v", m)
+		}
+	}
+
+	// Without card data the mana rule cannot judge castability
+	misplays = CheckMisplays(NewMisplayGame("m1", plays, snapshots, nil), DefaultMisplayRules())
+	if fired := rulesFired(misplays); fired["unused_mana"] != 0 {
+		t.Errorf("unused mana fired without card data: %+v", misplays)
+	}
+}
+
+func TestBadAttack(t *testing.T) {
+	snapshots := []*models.GameStateSnapshot{
+		snapshot(2, models.PlayerTypeOpponent, 20, repository.BoardState{
+			PlayerPermanents:   []repository.PermanentState{creature(2, 2, 2), creature(5, 1, 1)},
+			OpponentPermanents: []repository.PermanentState{creature(4, 3, 3)},
+		}),
+	}
+	plays := []*models.GamePlay{
+		play(3, 1, models.ActionTypeAttack, models.PhaseCombat, 2),
+		play(3, 2, models.ActionTypeAttack, models.PhaseCombat, 5),
+	}
+
+	misplays := (&BadAttackRule{}).Check(NewMisplayGame("m1", plays, snapshots, testCards))
+	if len(misplays) != 2 || misplays[0].Turn != 3 || misplays[0].Evidence["blocker"] != "3/3" {
+		t.Fatalf("got %+v", misplays)
+	}
+
+	// A tapped blocker cannot block
+	tapped := creature(4, 3, 3)
+	tapped.IsTapped = true
+	snapshots[0] = snapshot(2, models.PlayerTypeOpponent, 20, repository.BoardState{
+		PlayerPermanents:   []repository.PermanentState{creature(2, 2, 2)},
+		OpponentPermanents: []repository.PermanentState{tapped},
+	})
+	if misplays := (&BadAttackRule{}).Check(NewMisplayGame("m1", plays, snapshots, testCards)); len(misplays) != 0 {
+		t.Errorf("attack past a tapped creature flagged: %+v", misplays)
+	}
+}
+
+func TestHeldRemoval(t *testing.T) {
+	lands := []repository.PermanentState{land(false), land(false), land(false)}
+	snapshots := []*models.GameStateSnapshot{
+		snapshot(5, models.PlayerTypePlayer, 9, repository.BoardState{PlayerPermanents: lands, PlayerHand: []repository.PermanentState{handCard(20, 3)}}),
+		snapshot(6, models.PlayerTypeOpponent, 4, repository.BoardState{PlayerPermanents: lands, PlayerHand: []repository.PermanentState{handCard(20, 3)}}),
+	}
+
+	misplays := (&HeldRemovalRule{}).Check(NewMisplayGame("m1", nil, snapshots, testCards))
+	if len(misplays) != 1 || misplays[0].Turn != 6 || misplays[0].Evidence["lifeLost"] != 5 {
+		t.Fatalf("got %+v", misplays)
+	}
+
+	// Casting the removal clears the flag
+	snapshots[1] = snapshot(6, models.PlayerTypeOpponent, 4, repository.BoardState{PlayerPermanents: lands})
+	if misplays := (&HeldRemovalRule{}).Check(NewMisplayGame("m1", nil, snapshots, testCards)); len(misplays) != 0 {
+		t.Errorf("used removal flagged: %+v", misplays)
+	}
+}
+
+func TestPrecombatThreat(t *testing.T) {
+	plays := []*models.GamePlay{
+		play(4, 1, models.ActionTypePlayCard, models.PhaseMain1, 4), // Hill Giant before attacking
+		play(4, 2, models.ActionTypePlayCard, models.PhaseMain1, 5), // Haste justifies it
+		play(4, 3, models.ActionTypeAttack, models.PhaseCombat, 2),
+		play(4, 4, models.ActionTypePlayCard, models.PhaseMain2, 2),
+	}
+
+	misplays := (&PrecombatThreatRule{}).Check(NewMisplayGame("m1", plays, nil, testCards))
+	if len(misplays) != 1 || *misplays[0].CardID != 4 {
+		t.Fatalf("got %+v", misplays)
+	}
+}
+
+type fakeSuggestionRepo struct {
+	repository.SuggestionRepository
+	created []*models.ImprovementSuggestion
+}
+
+func (f *fakeSuggestionRepo) DeleteActiveSuggestionsByDeck(ctx context.Context, deckID string) error {
+	return nil
+}
+
+func (f *fakeSuggestionRepo) CreateSuggestion(ctx context.Context, sugg *models.ImprovementSuggestion) error {
+	f.created = append(f.created, sugg)
+	return nil
+}
+
+type fixedRule struct{ misplays []Misplay }
+
+func (r *fixedRule) Name() string                      { return "fixed" }
+func (r *fixedRule) Title() string                     { return "Fixed Rule" }
+func (r *fixedRule) Advice() string                    { return "Do better." }
+func (r *fixedRule) Check(game *MisplayGame) []Misplay { return r.misplays }
+
+func TestGenerateSuggestions_RecurringMisplays(t *testing.T) {
+	matches := make([]*models.Match, 5)
+	for i := range matches {
+		matches[i] = &models.Match{ID: string(rune('a' + i)), Result: "loss"}
+	}
+	playRepo := &fakeGamePlays{plays: []*models.GamePlay{play(1, 1, models.ActionTypeLandDrop, models.PhaseMain1, 1)}}
+	analyzer := NewPlayAnalyzer(playRepo, &fakeMatchRepo{matches: matches})
+	cardID := 2
+	analyzer.SetMisplayRules(&fixedRule{misplays: []Misplay{
+		{Severity: models.SuggestionPriorityLow, Turn: 3, CardID: &cardID},
+		{Severity: models.SuggestionPriorityHigh, Turn: 4},
+	}})
+
+	suggRepo := &fakeSuggestionRepo{}
+	suggestions, err := NewSuggestionGenerator(analyzer, suggRepo).GenerateSuggestions(context.Background(), "deck-1", 5)
+	if err != nil {
+		t.Fatalf("GenerateSuggestions: %v", err)
+	}
+
+	var found *models.ImprovementSuggestion
+	for _, s := range suggestions {
+		if s.Title == "Fixed Rule" {
+			found = s
+		}
+	}
+	if found == nil {
+		t.Fatalf("expected a suggestion for the recurring misplay, got %d suggestions", len(suggestions))
+	}
+	if found.Priority != models.SuggestionPriorityHigh || found.SuggestionType != models.SuggestionTypeSequencing {
+		t.Errorf("suggestion = %+v, want high priority sequencing", found)
+	}
+	if !strings.Contains(found.Description, "10 times across 5 of 5 games") {
+		t.Errorf("description %q", found.Description)
+	}
+	if found.CardReferences == nil || *found.CardReferences != "[2]" || found.Evidence == nil || !strings.Contains(*found.Evidence, `"occurrences":10`) {
+		t.Errorf("references %v, evidence %v", found.CardReferences, found.Evidence)
+	}
+
+	// A misplay seen in one game is not a habit
+	analyzer.SetMisplayRules(&onceRule{})
+	suggestions, err = NewSuggestionGenerator(analyzer, suggRepo).GenerateSuggestions(context.Background(), "deck-1", 5)
+	if err != nil {
+		t.Fatalf("GenerateSuggestions: %v", err)
+	}
+	for _, s := range suggestions {
+		if s.Title == "Once Rule" {
+			t.Errorf("one-off misplay became a suggestion: %+v", s)
+		}
+	}
+}
+
+// onceRule fires only in match "a".
+type onceRule struct{}
+
+func (r *onceRule) Name() string   { return "once" }
+func (r *onceRule) Title() string  { return "Once Rule" }
+func (r *onceRule) Advice() string { return "Rare." }
+func (r *onceRule) Check(game *MisplayGame) []Misplay {
+	if game.MatchID != "a" {
+		return nil
+	}
+	return []Misplay{{Severity: models.SuggestionPriorityHigh, Turn: 1}}
+}
+
+type fakeGamePlays struct {
+	repository.GamePlayRepository
+	plays []*models.GamePlay
+}
+
+func (f *fakeGamePlays) GetPlaysByMatch(ctx context.Context, matchID string) ([]*models.GamePlay, error) {
+	return f.plays, nil
+}
+
+func (f *fakeGamePlays) GetSnapshotsByMatch(ctx context.Context, matchID string) ([]*models.GameStateSnapshot, error) {
+	return nil, nil
+}
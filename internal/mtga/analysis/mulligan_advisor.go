@@ -0,0 +1,480 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/goldfish"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/manabase"
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+const (
+	// mulliganPriorWeight is how many of our games the goldfish prior counts
+	// for when it is blended with the results of similar hands.
+	mulliganPriorWeight = 10
+	// mulliganSimulations is the number of goldfish games behind each prior.
+	mulliganSimulations = 500
+	// castableByTurn is the turn spells must be castable by to count as early plays.
+	castableByTurn = 3
+	// maxCoverageGap is how far apart color coverage can be for hands to be
+	// similar: one color of a three-color deck.
+	maxCoverageGap = 0.34
+	// Priors are kept away from certainty so a few games can still move them.
+	minMulliganPrior = 0.05
+	maxMulliganPrior = 0.95
+	openingHandSize  = 7
+)
+
+// HandFeatures describes the cards drawn for an opening hand.
+type HandFeatures struct {
+	Lands           int     `json:"lands"`
+	CastableByTurn3 int     `json:"castableByTurn3"` // Spells of mana value 3 or less the hand's lands can pay for
+	ColorCoverage   float64 `json:"colorCoverage"`   // Share of the deck's spell colors the hand's lands make
+	OnPlay          bool    `json:"onPlay"`
+}
+
+// OutcomeEstimate is the chance to win a game after one mulligan decision.
+type OutcomeEstimate struct {
+	WinProbability float64 `json:"winProbability"`
+	Prior          float64 `json:"prior"` // Goldfish estimate before our games are counted
+	Games          int     `json:"games"` // Our games after the same decision with a similar hand
+	Wins           int     `json:"wins"`
+}
+
+// MulliganAdvice compares keeping an opening hand against mulliganing it.
+type MulliganAdvice struct {
+	DeckID         string          `json:"deckId"`
+	MatchID        string          `json:"matchId,omitempty"`
+	GameNumber     int             `json:"gameNumber,omitempty"`
+	MulliganCount  int             `json:"mulliganCount"`
+	CardIDs        []int           `json:"cardIds"`
+	Features       HandFeatures    `json:"features"`
+	Keep           OutcomeEstimate `json:"keep"`
+	Mulligan       OutcomeEstimate `json:"mulligan"`
+	Recommendation string          `json:"recommendation"`     // "keep" or "mulligan"
+	Decision       string          `json:"decision,omitempty"` // What was chosen, for captured hands
+}
+
+// MulliganAdvisor estimates whether an opening hand wins more often kept or
+// mulliganed. It starts from a goldfish simulation of the deck and moves
+// toward the results of our own games with similar hands as they add up.
+type MulliganAdvisor struct {
+	gamePlayRepo repository.GamePlayRepository
+	matchRepo    repository.MatchRepository
+	deckRepo     repository.DeckRepository
+	cardLookup   CardLookup
+}
+
+// NewMulliganAdvisor creates a new mulligan advisor.
+func NewMulliganAdvisor(
+	gamePlayRepo repository.GamePlayRepository,
+	matchRepo repository.MatchRepository,
+	deckRepo repository.DeckRepository,
+	cardLookup CardLookup,
+) *MulliganAdvisor {
+	return &MulliganAdvisor{
+		gamePlayRepo: gamePlayRepo,
+		matchRepo:    matchRepo,
+		deckRepo:     deckRepo,
+		cardLookup:   cardLookup,
+	}
+}
+
+// deckCards is a deck's mainboard with card metadata.
+type deckCards struct {
+	quantities map[int]int
+	cards      map[int]*models.SetCard
+	colors     []string // Colors the deck's spells need
+}
+
+// Advise estimates the win probability of keeping and of mulliganing a hand
+// drawn with a deck.
+func (a *MulliganAdvisor) Advise(ctx context.Context, deckID string, cardIDs []int, mulliganCount int, onPlay bool) (*MulliganAdvice, error) {
+	deck, err := a.loadDeck(ctx, deckID)
+	if err != nil {
+		return nil, err
+	}
+	if len(deck.quantities) == 0 {
+		return nil, fmt.Errorf("deck %s has no mainboard cards", deckID)
+	}
+	history, err := a.gamePlayRepo.GetOpeningHandsByDeck(ctx, deckID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get opening hands: %w", err)
+	}
+	return a.advise(ctx, deck, history, &models.OpeningHand{
+		DeckID:        deckID,
+		MulliganCount: mulliganCount,
+		CardIDs:       cardIDs,
+		OnPlay:        &onPlay,
+	})
+}
+
+// AdviseMatch advises on every opening hand captured in a match. Returns nil
+// if the match has no hands or its deck cannot be found.
+func (a *MulliganAdvisor) AdviseMatch(ctx context.Context, accountID int, matchID string) ([]*MulliganAdvice, error) {
+	hands, err := a.gamePlayRepo.GetOpeningHandsByMatch(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get opening hands: %w", err)
+	}
+
+	var advice []*MulliganAdvice
+	for _, hand := range hands {
+		handAdvice, err := a.AdviseOpeningHand(ctx, accountID, hand)
+		if err != nil {
+			return nil, err
+		}
+		if handAdvice != nil {
+			advice = append(advice, handAdvice)
+		}
+	}
+	return advice, nil
+}
+
+// AdviseOpeningHand advises on a hand captured from the GRE stream. The deck
+// is the stored match's; a match still in progress is not stored yet, so its
+// deck is taken to be the most recently played one holding every card drawn.
+// Hands whose starting player is unknown are treated as on the play. Returns
+// nil if no deck can be found.
+func (a *MulliganAdvisor) AdviseOpeningHand(ctx context.Context, accountID int, hand *models.OpeningHand) (*MulliganAdvice, error) {
+	deckID := hand.DeckID
+	if deckID == "" {
+		match, err := a.matchRepo.GetByID(ctx, hand.MatchID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get match: %w", err)
+		}
+		if match != nil && match.DeckID != nil {
+			deckID = *match.DeckID
+		}
+	}
+	if deckID == "" {
+		var err error
+		if deckID, err = a.findDeck(ctx, accountID, hand.CardIDs); err != nil {
+			return nil, err
+		}
+		if deckID == "" {
+			return nil, nil
+		}
+	}
+
+	deck, err := a.loadDeck(ctx, deckID)
+	if err != nil {
+		return nil, err
+	}
+	if len(deck.quantities) == 0 {
+		return nil, nil
+	}
+	history, err := a.gamePlayRepo.GetOpeningHandsByDeck(ctx, deckID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get opening hands: %w", err)
+	}
+
+	subject := *hand
+	subject.DeckID = deckID
+	advice, err := a.advise(ctx, deck, history, &subject)
+	if err != nil {
+		return nil, err
+	}
+	advice.MatchID = hand.MatchID
+	advice.GameNumber = hand.GameNumber
+	advice.Decision = hand.Decision
+	return advice, nil
+}
+
+// findDeck returns the most recently played deck whose mainboard holds every
+// card drawn, or "" if none does.
+func (a *MulliganAdvisor) findDeck(ctx context.Context, accountID int, cardIDs []int) (string, error) {
+	if len(cardIDs) == 0 {
+		return "", nil
+	}
+	decks, err := a.deckRepo.List(ctx, accountID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list decks: %w", err)
+	}
+	sort.SliceStable(decks, func(i, j int) bool {
+		if decks[i].LastPlayed == nil || decks[j].LastPlayed == nil {
+			return decks[i].LastPlayed != nil
+		}
+		return decks[i].LastPlayed.After(*decks[j].LastPlayed)
+	})
+
+	drawn := make(map[int]int)
+	for _, id := range cardIDs {
+		drawn[id]++
+	}
+	for _, deck := range decks {
+		cards, err := a.deckRepo.GetCards(ctx, deck.ID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get deck cards: %w", err)
+		}
+		mainboard := make(map[int]int)
+		for _, card := range cards {
+			if card.Board == "main" {
+				mainboard[card.CardID] += card.Quantity
+			}
+		}
+		holds := true
+		for id, n := range drawn {
+			if mainboard[id] < n {
+				holds = false
+				break
+			}
+		}
+		if holds {
+			return deck.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// loadDeck reads a deck's mainboard and the metadata of its cards.
+func (a *MulliganAdvisor) loadDeck(ctx context.Context, deckID string) (*deckCards, error) {
+	cards, err := a.deckRepo.GetCards(ctx, deckID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deck cards: %w", err)
+	}
+
+	deck := &deckCards{quantities: make(map[int]int), cards: make(map[int]*models.SetCard)}
+	for _, card := range cards {
+		if card.Board == "main" {
+			deck.quantities[card.CardID] += card.Quantity
+		}
+	}
+	colors := make(map[string]bool)
+	for cardID := range deck.quantities {
+		card, err := a.lookup(ctx, deck, cardID)
+		if err != nil {
+			return nil, err
+		}
+		if card == nil || isLandCard(card) {
+			continue
+		}
+		for color := range manabase.ParseManaCost(card.ManaCost, card.CMC).Pips {
+			colors[color] = true
+		}
+	}
+	for _, color := range manabase.Colors {
+		if colors[color] {
+			deck.colors = append(deck.colors, color)
+		}
+	}
+	return deck, nil
+}
+
+// lookup returns a card's metadata, caching it on the deck.
+func (a *MulliganAdvisor) lookup(ctx context.Context, deck *deckCards, cardID int) (*models.SetCard, error) {
+	if card, ok := deck.cards[cardID]; ok {
+		return card, nil
+	}
+	card, err := a.cardLookup.GetCardByArenaID(ctx, strconv.Itoa(cardID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card %d: %w", cardID, err)
+	}
+	deck.cards[cardID] = card
+	return card, nil
+}
+
+// advise blends the goldfish prior for each decision with our results after
+// the same decision. Keeping counts the kept hands similar to this one;
+// mulliganing counts every hand sent back at the same mulligan count and
+// play or draw, since what follows does not depend on the hand given up.
+// Hands from the same game are left out so a finished game is not scored on
+// its own result.
+func (a *MulliganAdvisor) advise(ctx context.Context, deck *deckCards, history []*models.OpeningHand, hand *models.OpeningHand) (*MulliganAdvice, error) {
+	// A hand whose play or draw is not known yet is advised as on the play
+	onPlay := hand.OnPlay == nil || *hand.OnPlay
+	features, err := a.handFeatures(ctx, deck, hand.CardIDs, onPlay)
+	if err != nil {
+		return nil, err
+	}
+
+	advice := &MulliganAdvice{
+		DeckID:        hand.DeckID,
+		MulliganCount: hand.MulliganCount,
+		CardIDs:       hand.CardIDs,
+		Features:      features,
+	}
+
+	// The deck's win rate in our games is what an average hand is worth
+	var games, wins int
+	for _, past := range history {
+		if past.Decision == models.MulliganDecisionKeep && past.Result != "" {
+			games++
+			if past.Result == "win" {
+				wins++
+			}
+		}
+	}
+	baseline := (float64(wins) + 0.5*mulliganPriorWeight) / (float64(games) + mulliganPriorWeight)
+	advice.Keep.Prior, advice.Mulligan.Prior = goldfishPriors(deck, hand, onPlay, baseline)
+
+	for _, past := range history {
+		if past.Result == "" || past.OnPlay == nil || *past.OnPlay != onPlay || past.MulliganCount != hand.MulliganCount {
+			continue
+		}
+		if past.MatchID == hand.MatchID && past.GameNumber == hand.GameNumber {
+			continue
+		}
+		estimate := &advice.Mulligan
+		if past.Decision == models.MulliganDecisionKeep {
+			pastFeatures, err := a.handFeatures(ctx, deck, past.CardIDs, onPlay)
+			if err != nil {
+				return nil, err
+			}
+			if !similarHands(features, pastFeatures) {
+				continue
+			}
+			estimate = &advice.Keep
+		} else if past.Decision != models.MulliganDecisionMulligan {
+			continue
+		}
+		estimate.Games++
+		if past.Result == "win" {
+			estimate.Wins++
+		}
+	}
+	for _, estimate := range []*OutcomeEstimate{&advice.Keep, &advice.Mulligan} {
+		estimate.WinProbability = stats.Round((float64(estimate.Wins)+estimate.Prior*mulliganPriorWeight)/
+			(float64(estimate.Games)+mulliganPriorWeight), 3)
+	}
+
+	advice.Recommendation = models.MulliganDecisionKeep
+	if hand.MulliganCount+1 < openingHandSize && advice.Mulligan.WinProbability > advice.Keep.WinProbability {
+		advice.Recommendation = models.MulliganDecisionMulligan
+	}
+	return advice, nil
+}
+
+// goldfishPriors scales the deck's baseline win rate by how often the hand,
+// and a fresh hand one card smaller, kill an empty board by the deck's usual
+// kill turn compared with a random hand. A deck that never kills in the
+// simulation gives both decisions the baseline, and a mulligan that would
+// leave no cards gets the lowest prior.
+func goldfishPriors(deck *deckCards, hand *models.OpeningHand, onPlay bool, baseline float64) (float64, float64) {
+	var cards []goldfish.Card
+	for cardID, quantity := range deck.quantities {
+		if card := deck.cards[cardID]; card != nil {
+			cards = append(cards, goldfishCard(card, quantity))
+		}
+	}
+	// Map order is random; sort so the same deck always shuffles the same way
+	sort.Slice(cards, func(i, j int) bool { return cards[i].Name < cards[j].Name })
+
+	var names []string
+	for _, cardID := range hand.CardIDs {
+		if card := deck.cards[cardID]; card != nil {
+			names = append(names, card.Name)
+		}
+	}
+
+	opts := goldfish.Options{Iterations: mulliganSimulations, OnDraw: !onPlay, Seed: 1}
+	reference := goldfish.Simulate(cards, opts)
+	killTurn := reference.MedianKillTurn
+	if killTurn == 0 {
+		killTurn = goldfish.DefaultMaxTurns
+	}
+	average := reference.KilledBy(killTurn)
+
+	// Another mulligan would leave no cards, so there is nothing to compare
+	lastHand := hand.MulliganCount+1 >= openingHandSize
+	if average == 0 {
+		if lastHand {
+			return stats.Round(baseline, 3), minMulliganPrior
+		}
+		return stats.Round(baseline, 3), stats.Round(baseline, 3)
+	}
+
+	prior := func(r *goldfish.Result) float64 {
+		p := baseline * r.KilledBy(killTurn) / average
+		return stats.Round(math.Max(minMulliganPrior, math.Min(maxMulliganPrior, p)), 3)
+	}
+	keepOpts := opts
+	keepOpts.Mulligans = hand.MulliganCount
+	keep := prior(goldfish.SimulateHand(cards, names, keepOpts))
+	if lastHand {
+		return keep, minMulliganPrior
+	}
+	mulliganOpts := opts
+	mulliganOpts.Mulligans = hand.MulliganCount + 1
+	return keep, prior(goldfish.Simulate(cards, mulliganOpts))
+}
+
+// handFeatures counts the lands, early castable spells and colors of the cards drawn.
+func (a *MulliganAdvisor) handFeatures(ctx context.Context, deck *deckCards, cardIDs []int, onPlay bool) (HandFeatures, error) {
+	features := HandFeatures{OnPlay: onPlay}
+	sources := make(map[string]int)
+	var spells []*models.SetCard
+	for _, cardID := range cardIDs {
+		card, err := a.lookup(ctx, deck, cardID)
+		if err != nil {
+			return features, err
+		}
+		if card == nil {
+			continue
+		}
+		if !isLandCard(card) {
+			spells = append(spells, card)
+			continue
+		}
+		features.Lands++
+		for _, color := range manabase.ParseLand(card.Name, strings.Join(card.Types, " "), card.Text).Colors {
+			sources[color]++
+		}
+	}
+
+	for _, spell := range spells {
+		if spell.CMC > castableByTurn || spell.CMC > features.Lands {
+			continue
+		}
+		castable := true
+		for color, pips := range manabase.ParseManaCost(spell.ManaCost, spell.CMC).Pips {
+			if sources[color] < pips {
+				castable = false
+				break
+			}
+		}
+		if castable {
+			features.CastableByTurn3++
+		}
+	}
+
+	features.ColorCoverage = 1
+	if len(deck.colors) > 0 {
+		covered := 0
+		for _, color := range deck.colors {
+			if sources[color] > 0 {
+				covered++
+			}
+		}
+		features.ColorCoverage = stats.Round(float64(covered)/float64(len(deck.colors)), 3)
+	}
+	return features, nil
+}
+
+// similarHands reports whether two hands have the same land count, nearly
+// the same early plays and nearly the same colors.
+func similarHands(a, b HandFeatures) bool {
+	castableGap := a.CastableByTurn3 - b.CastableByTurn3
+	return a.Lands == b.Lands && castableGap >= -1 && castableGap <= 1 &&
+		math.Abs(a.ColorCoverage-b.ColorCoverage) < maxCoverageGap
+}
+
+// goldfishCard converts card metadata into a goldfish deck entry.
+func goldfishCard(card *models.SetCard, quantity int) goldfish.Card {
+	c := goldfish.Card{
+		Name:       card.Name,
+		Quantity:   quantity,
+		ManaCost:   card.ManaCost,
+		CMC:        card.CMC,
+		TypeLine:   strings.Join(card.Types, " "),
+		OracleText: card.Text,
+	}
+	c.Power, _ = strconv.Atoi(card.Power) // "*" counts as 0
+	return c
+}
@@ -0,0 +1,180 @@
+package analysis
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+type mulliganPlays struct {
+	repository.GamePlayRepository
+	hands []*models.OpeningHand
+}
+
+func (f *mulliganPlays) GetOpeningHandsByDeck(ctx context.Context, deckID string) ([]*models.OpeningHand, error) {
+	return f.hands, nil
+}
+
+func (f *mulliganPlays) GetOpeningHandsByMatch(ctx context.Context, matchID string) ([]*models.OpeningHand, error) {
+	var hands []*models.OpeningHand
+	for _, hand := range f.hands {
+		if hand.MatchID == matchID {
+			hands = append(hands, hand)
+		}
+	}
+	return hands, nil
+}
+
+type mulliganDecks struct {
+	repository.DeckRepository
+	cards []*models.DeckCard
+}
+
+func (f *mulliganDecks) List(ctx context.Context, accountID int) ([]*models.Deck, error) {
+	lastPlayed := time.Now()
+	return []*models.Deck{{ID: "old-deck"}, {ID: "deck-1", LastPlayed: &lastPlayed}}, nil
+}
+
+func (f *mulliganDecks) GetCards(ctx context.Context, deckID string) ([]*models.DeckCard, error) {
+	if deckID != "deck-1" {
+		return nil, nil
+	}
+	return f.cards, nil
+}
+
+func redDeckAdvisor(history []*models.OpeningHand) *MulliganAdvisor {
+	cards := cardTable{
+		1: {Name: "Mountain", Types: []string{"Basic", "Land", "Mountain"}},
+		2: {Name: "Goblin Guide", ManaCost: "{R}", CMC: 1, Types: []string{"Creature"}, Text: "Haste", Power: "2"},
+		3: {Name: "Bear", ManaCost: "{1}{R}", CMC: 2, Types: []string{"Creature"}, Power: "2"},
+		4: {Name: "Lightning Bolt", ManaCost: "{R}", CMC: 1, Types: []string{"Instant"}, Text: "Lightning Bolt deals 3 damage to any target."},
+		5: {Name: "Dragon", ManaCost: "{4}{G}{G}", CMC: 6, Types: []string{"Creature"}, Power: "5"},
+	}
+	decks := &mulliganDecks{cards: []*models.DeckCard{
+		{CardID: 1, Quantity: 20, Board: "main"},
+		{CardID: 2, Quantity: 12, Board: "main"},
+		{CardID: 3, Quantity: 12, Board: "main"},
+		{CardID: 4, Quantity: 16, Board: "main"},
+		{CardID: 5, Quantity: 2, Board: "sideboard"},
+	}}
+	return NewMulliganAdvisor(&mulliganPlays{hands: history}, &inferenceMatches{}, decks, cards)
+}
+
+func TestMulliganAdvisor_Advise(t *testing.T) {
+	advisor := redDeckAdvisor(nil)
+	ctx := context.Background()
+
+	good, err := advisor.Advise(ctx, "deck-1", []int{1, 1, 2, 2, 3, 4, 4}, 0, true)
+	if err != nil {
+		t.Fatalf("Advise: %v", err)
+	}
+	if good.Features.Lands != 2 || good.Features.CastableByTurn3 != 5 || good.Features.ColorCoverage != 1 {
+		t.Errorf("unexpected features %+v", good.Features)
+	}
+	if good.Recommendation != models.MulliganDecisionKeep || good.Keep.WinProbability <= good.Mulligan.WinProbability {
+		t.Errorf("two-land hand should be kept: %+v", good)
+	}
+
+	landless, err := advisor.Advise(ctx, "deck-1", []int{2, 2, 3, 3, 4, 4, 4}, 0, true)
+	if err != nil {
+		t.Fatalf("Advise: %v", err)
+	}
+	if landless.Features.Lands != 0 || landless.Features.CastableByTurn3 != 0 || landless.Features.ColorCoverage != 0 {
+		t.Errorf("unexpected features %+v", landless.Features)
+	}
+	if landless.Recommendation != models.MulliganDecisionMulligan {
+		t.Errorf("landless hand should be mulliganed: %+v", landless)
+	}
+	if landless.Keep.Games != 0 || landless.Keep.WinProbability != landless.Keep.Prior {
+		t.Errorf("without history the estimate should be the prior: %+v", landless.Keep)
+	}
+}
+
+func TestMulliganAdvisor_History(t *testing.T) {
+	onPlay := true
+	var history []*models.OpeningHand
+	// We keep landless hands and win anyway, and lose after mulligans
+	for i := 0; i < 40; i++ {
+		matchID := "match-" + strconv.Itoa(i)
+		history = append(history,
+			&models.OpeningHand{MatchID: matchID, GameNumber: 1, MulliganCount: 0, CardIDs: []int{2, 2, 3, 3, 4, 4, 4}, OnPlay: &onPlay, Decision: models.MulliganDecisionKeep, Result: "win"},
+			&models.OpeningHand{MatchID: matchID, GameNumber: 2, MulliganCount: 0, CardIDs: []int{1, 1, 1, 1, 1, 1, 1}, OnPlay: &onPlay, Decision: models.MulliganDecisionMulligan, Result: "loss"},
+			&models.OpeningHand{MatchID: matchID, GameNumber: 2, MulliganCount: 1, CardIDs: []int{1, 1, 2, 2, 3, 4, 4}, OnPlay: &onPlay, Decision: models.MulliganDecisionKeep, Result: "loss"},
+		)
+	}
+	// A hand from the game being advised on must not count
+	history = append(history, &models.OpeningHand{MatchID: "live", GameNumber: 1, CardIDs: []int{2, 2, 3, 3, 4, 4, 4}, OnPlay: &onPlay, Decision: models.MulliganDecisionKeep, Result: "loss"})
+
+	advisor := redDeckAdvisor(history)
+	advice, err := advisor.AdviseOpeningHand(context.Background(), 1, &models.OpeningHand{MatchID: "live", GameNumber: 1, CardIDs: []int{2, 2, 3, 3, 4, 4, 4}})
+	if err != nil {
+		t.Fatalf("AdviseOpeningHand: %v", err)
+	}
+	if advice == nil {
+		t.Fatal("expected the deck to be found from the cards drawn")
+	}
+	if advice.DeckID != "deck-1" || advice.MatchID != "live" {
+		t.Errorf("unexpected deck or match: %s, %s", advice.DeckID, advice.MatchID)
+	}
+	if advice.Keep.Games != 40 || advice.Keep.Wins != 40 || advice.Mulligan.Games != 40 || advice.Mulligan.Wins != 0 {
+		t.Errorf("unexpected history counts: keep %+v, mulligan %+v", advice.Keep, advice.Mulligan)
+	}
+	if advice.Recommendation != models.MulliganDecisionKeep {
+		t.Errorf("our results should outweigh the prior: %+v", advice)
+	}
+}
+
+func TestMulliganAdvisor_UnknownStartingPlayer(t *testing.T) {
+	advisor := redDeckAdvisor(nil)
+	ctx := context.Background()
+	cards := []int{1, 1, 2, 2, 3, 4, 4}
+
+	unknown, err := advisor.AdviseOpeningHand(ctx, 1, &models.OpeningHand{MatchID: "live", CardIDs: cards})
+	if err != nil || unknown == nil {
+		t.Fatalf("AdviseOpeningHand = %v, %v", unknown, err)
+	}
+	onPlay, err := advisor.Advise(ctx, "deck-1", cards, 0, true)
+	if err != nil {
+		t.Fatalf("Advise: %v", err)
+	}
+
+	// The features and the simulated priors both take the unknown hand to be on the play
+	if !unknown.Features.OnPlay {
+		t.Error("a hand with an unknown starting player should be advised as on the play")
+	}
+	if unknown.Keep.Prior != onPlay.Keep.Prior || unknown.Mulligan.Prior != onPlay.Mulligan.Prior {
+		t.Errorf("priors = %v/%v, want the on-the-play %v/%v",
+			unknown.Keep.Prior, unknown.Mulligan.Prior, onPlay.Keep.Prior, onPlay.Mulligan.Prior)
+	}
+}
+
+func TestMulliganAdvisor_NoCardsLeftAfterMulligan(t *testing.T) {
+	advisor := redDeckAdvisor(nil)
+	for _, mulligans := range []int{6, 8} {
+		advice, err := advisor.Advise(context.Background(), "deck-1", []int{2}, mulligans, true)
+		if err != nil {
+			t.Fatalf("Advise after %d mulligans: %v", mulligans, err)
+		}
+		if advice.Recommendation != models.MulliganDecisionKeep {
+			t.Errorf("after %d mulligans the hand must be kept: %+v", mulligans, advice)
+		}
+		if advice.Mulligan.Prior != minMulliganPrior {
+			t.Errorf("after %d mulligans the mulligan prior = %v, want %v", mulligans, advice.Mulligan.Prior, minMulliganPrior)
+		}
+	}
+}
+
+func TestMulliganAdvisor_UnknownDeck(t *testing.T) {
+	advisor := redDeckAdvisor(nil)
+	advice, err := advisor.AdviseOpeningHand(context.Background(), 1, &models.OpeningHand{MatchID: "live", CardIDs: []int{5}})
+	if err != nil {
+		t.Fatalf("AdviseOpeningHand: %v", err)
+	}
+	if advice != nil {
+		t.Errorf("a hand from no known deck should get no advice, got %+v", advice)
+	}
+}
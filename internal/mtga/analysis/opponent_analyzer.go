@@ -67,7 +67,6 @@ func (a *OpponentAnalyzer) AnalyzeOpponent(ctx context.Context, matchID string)
 
 	// Convert observed cards to classification format
 	cardIDs := make([]int, 0, len(observedCards))
-	quantities := make(map[int]int)
 	cardIDSet := make(map[int]bool)
 
 	for _, card := range observedCards {
@@ -75,15 +74,6 @@ func (a *OpponentAnalyzer) AnalyzeOpponent(ctx context.Context, matchID string)
 			cardIDs = append(cardIDs, card.CardID)
 			cardIDSet[card.CardID] = true
 		}
-		// Each observed card counts as 1 for classification
-		// (we can't know how many copies they have)
-		quantities[card.CardID] = 1
-	}
-
-	// Classify the observed cards
-	classification, err := a.classifier.ClassifyCards(cardIDs, quantities)
-	if err != nil {
-		return nil, fmt.Errorf("failed to classify opponent cards: %w", err)
 	}
 
 	// Get match info for format
@@ -97,6 +87,17 @@ func (a *OpponentAnalyzer) AnalyzeOpponent(ctx context.Context, matchID string)
 		format = &match.Format
 	}
 
+	// Classify the observed cards against reference lists for the format.
+	// Each observed card counts once since we can't know how many copies they have.
+	formatName := ""
+	if format != nil {
+		formatName = *format
+	}
+	classification, err := a.classifier.ClassifyObserved(ctx, cardIDs, formatName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to classify opponent cards: %w", err)
+	}
+
 	// Build or update opponent deck profile
 	profile := a.buildProfile(matchID, classification, len(observedCards), cardIDs, format)
 	if existingProfile != nil {
@@ -239,12 +240,12 @@ func (a *OpponentAnalyzer) convertObservedCards(observed []*models.OpponentCardO
 
 // categorizeCard determines the category of a card.
 func (a *OpponentAnalyzer) categorizeCard(cardID int) string {
-	cards, err := a.cardService.GetCards([]int{cardID})
-	if err != nil || cards[cardID] == nil {
+	found, err := a.cardService.GetCards([]int{cardID})
+	if err != nil || found[cardID] == nil {
 		return ""
 	}
 
-	card := cards[cardID]
+	card := found[cardID]
 	oracleText := ""
 	if card.OracleText != nil {
 		oracleText = strings.ToLower(*card.OracleText)
@@ -252,9 +253,7 @@ func (a *OpponentAnalyzer) categorizeCard(cardID int) string {
 	typeLine := strings.ToLower(card.TypeLine)
 
 	// Check for removal
-	if strings.Contains(oracleText, "destroy target") ||
-		strings.Contains(oracleText, "exile target") ||
-		strings.Contains(oracleText, "deals") && strings.Contains(oracleText, "damage to") {
+	if cards.IsRemovalText(oracleText) {
 		return models.CardCategoryRemoval
 	}
 
@@ -348,6 +347,14 @@ func (a *OpponentAnalyzer) generateInsights(classification *archetype.Classifica
 			Description: fmt.Sprintf("Opponent is %s playing %s", confidence, classification.PrimaryArchetype),
 			Priority:    models.InsightPriorityHigh,
 		})
+
+		if classification.SecondaryArchetype != nil {
+			insights = append(insights, models.StrategicInsight{
+				Type:        "archetype",
+				Description: fmt.Sprintf("Revealed cards also fit %s", *classification.SecondaryArchetype),
+				Priority:    models.InsightPriorityMedium,
+			})
+		}
 	}
 
 	// Insights about deck style
@@ -60,6 +60,14 @@ func (m *mockOpponentRepo) GetExpectedCards(ctx context.Context, archetype, form
 	return args.Get(0).([]*models.ArchetypeExpectedCard), args.Error(1)
 }
 
+func (m *mockOpponentRepo) ListExpectedCards(ctx context.Context, format *string) ([]*models.ArchetypeExpectedCard, error) {
+	args := m.Called(ctx, format)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ArchetypeExpectedCard), args.Error(1)
+}
+
 func (m *mockOpponentRepo) RecordMatchup(ctx context.Context, stat *models.MatchupStatistic) error {
 	args := m.Called(ctx, stat)
 	return args.Error(0)
@@ -89,6 +97,22 @@ func (m *mockOpponentRepo) GetOpponentHistorySummary(ctx context.Context, accoun
 	return args.Get(0).(*models.OpponentHistorySummary), args.Error(1)
 }
 
+func (m *mockOpponentRepo) GetScoutingReport(ctx context.Context, accountID int, opponentID string) (*models.OpponentScoutingReport, error) {
+	args := m.Called(ctx, accountID, opponentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OpponentScoutingReport), args.Error(1)
+}
+
+func (m *mockOpponentRepo) ListScoutedOpponents(ctx context.Context, accountID int, minMatches, limit int) ([]*models.ScoutedOpponent, error) {
+	args := m.Called(ctx, accountID, minMatches, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ScoutedOpponent), args.Error(1)
+}
+
 func (m *mockOpponentRepo) DeleteProfile(ctx context.Context, matchID string) error {
 	args := m.Called(ctx, matchID)
 	return args.Error(0)
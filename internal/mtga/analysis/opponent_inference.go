@@ -0,0 +1,418 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/meta"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+// Threat categories tracked during live inference.
+const (
+	ThreatSweeper        = "sweeper"
+	ThreatCounterspell   = "counterspell"
+	ThreatInstantRemoval = "instant_removal"
+)
+
+const (
+	// offListRate is the chance an archetype plays a card missing from its expected list.
+	offListRate = 0.05
+	// minThreatProbability hides cards that are very unlikely to be in hand.
+	minThreatProbability = 0.01
+	maxThreats           = 10
+)
+
+// MetaShareSource provides metagame shares for archetypes in a format.
+type MetaShareSource interface {
+	GetTopArchetypes(ctx context.Context, format string, limit int) ([]*meta.AggregatedArchetype, error)
+}
+
+// ArchetypeLikelihood is the chance the opponent is playing an archetype.
+type ArchetypeLikelihood struct {
+	Archetype    string  `json:"archetype"`
+	Probability  float64 `json:"probability"`
+	Prior        float64 `json:"prior"`
+	CardsMatched int     `json:"cardsMatched"` // Revealed cards on the archetype's expected list
+}
+
+// CardThreat is a card the opponent may be holding that punishes a line of play.
+type CardThreat struct {
+	CardID    int     `json:"cardId"`
+	CardName  string  `json:"cardName"`
+	Threat    string  `json:"threat"`
+	ManaValue int     `json:"manaValue"`
+	InDeck    float64 `json:"inDeck"`   // Chance a copy is left in library or hand
+	InHand    float64 `json:"inHand"`   // Chance a copy is in hand right now
+	Castable  bool    `json:"castable"` // The opponent has enough untapped lands to cast it
+}
+
+// OpponentInference is a live read of the opponent's deck from the cards revealed so far.
+type OpponentInference struct {
+	MatchID          string                `json:"matchId"`
+	Turn             int                   `json:"turn"`
+	Format           string                `json:"format,omitempty"`
+	CardsObserved    int                   `json:"cardsObserved"`
+	OpponentHandSize int                   `json:"opponentHandSize"`
+	UntappedLands    int                   `json:"untappedLands"`
+	UnknownCards     int                   `json:"unknownCards"` // Cards in hand and library not yet revealed
+	Archetypes       []ArchetypeLikelihood `json:"archetypes"`
+	Threats          []CardThreat          `json:"threats"`
+}
+
+// OpponentInferrer updates a probability distribution over opponent archetypes
+// as cards are revealed during a match.
+type OpponentInferrer struct {
+	gamePlayRepo repository.GamePlayRepository
+	opponentRepo repository.OpponentRepository
+	matchRepo    repository.MatchRepository
+	cardLookup   CardLookup
+	metaSource   MetaShareSource
+}
+
+// NewOpponentInferrer creates a new opponent inferrer.
+func NewOpponentInferrer(
+	gamePlayRepo repository.GamePlayRepository,
+	opponentRepo repository.OpponentRepository,
+	matchRepo repository.MatchRepository,
+	cardLookup CardLookup,
+) *OpponentInferrer {
+	return &OpponentInferrer{
+		gamePlayRepo: gamePlayRepo,
+		opponentRepo: opponentRepo,
+		matchRepo:    matchRepo,
+		cardLookup:   cardLookup,
+	}
+}
+
+// SetMetaSource adds metagame shares to the archetype priors.
+func (i *OpponentInferrer) SetMetaSource(source MetaShareSource) {
+	i.metaSource = source
+}
+
+// archetypeList is the expected cards of one archetype keyed by card ID.
+type archetypeList struct {
+	name  string
+	cards map[int]*models.ArchetypeExpectedCard
+}
+
+// Infer reads the opponent's likely archetype and held interaction for a match.
+// Returns nil if no archetype has expected cards to compare against.
+func (i *OpponentInferrer) Infer(ctx context.Context, matchID string) (*OpponentInference, error) {
+	observed, err := i.gamePlayRepo.GetOpponentCardsByMatch(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get opponent cards: %w", err)
+	}
+
+	// Live matches are stored when they end, so the format may not be known yet
+	var format *string
+	match, err := i.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match: %w", err)
+	}
+	if match != nil && match.Format != "" {
+		format = &match.Format
+	}
+
+	expected, err := i.opponentRepo.ListExpectedCards(ctx, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expected cards: %w", err)
+	}
+	archetypes := groupExpectedCards(expected)
+	if len(archetypes) == 0 {
+		return nil, nil
+	}
+
+	result := &OpponentInference{
+		MatchID:       matchID,
+		CardsObserved: len(observed),
+	}
+	if format != nil {
+		result.Format = *format
+	}
+
+	snapshots, err := i.gamePlayRepo.GetSnapshotsByMatch(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshots: %w", err)
+	}
+	if len(snapshots) > 0 {
+		latest := snapshots[len(snapshots)-1]
+		result.Turn = latest.TurnNumber
+		if latest.OpponentCardsInHand != nil {
+			result.OpponentHandSize = *latest.OpponentCardsInHand
+		}
+		if board, err := repository.ParseBoardState(latest.BoardStateJSON); err == nil && board != nil {
+			result.UntappedLands = countLands(board.OpponentPermanents, true)
+		} else if latest.OpponentLandsInPlay != nil {
+			result.UntappedLands = *latest.OpponentLandsInPlay
+		}
+	}
+	result.UnknownCards = max(deckSizeForFormat(format)-len(observed), result.OpponentHandSize)
+
+	cards := make(map[int]*models.SetCard)
+	seen := make(map[int]bool)
+	for _, card := range observed {
+		seen[card.CardID] = true
+	}
+
+	priors := i.archetypePriors(ctx, archetypes, format)
+	result.Archetypes = scoreArchetypes(archetypes, priors, seen, func(cardID int) *models.SetCard {
+		return i.lookupCard(ctx, cards, cardID)
+	})
+
+	result.Threats = estimateThreats(archetypes, result, seen, func(cardID int) *models.SetCard {
+		return i.lookupCard(ctx, cards, cardID)
+	})
+
+	return result, nil
+}
+
+// lookupCard returns card data, caching misses as nil.
+func (i *OpponentInferrer) lookupCard(ctx context.Context, cards map[int]*models.SetCard, cardID int) *models.SetCard {
+	if card, ok := cards[cardID]; ok {
+		return card
+	}
+	var card *models.SetCard
+	if i.cardLookup != nil {
+		card, _ = i.cardLookup.GetCardByArenaID(ctx, strconv.Itoa(cardID))
+	}
+	cards[cardID] = card
+	return card
+}
+
+// archetypePriors mixes a uniform baseline with how often each archetype has
+// been faced before and, when available, its metagame share.
+func (i *OpponentInferrer) archetypePriors(ctx context.Context, archetypes []*archetypeList, format *string) map[string]float64 {
+	components := []map[string]float64{make(map[string]float64)}
+	for _, a := range archetypes {
+		components[0][a.name] = 1
+	}
+
+	if profiles, err := i.opponentRepo.ListProfiles(ctx, &repository.OpponentProfileFilter{Format: format}); err == nil {
+		faced := make(map[string]float64)
+		for _, profile := range profiles {
+			if profile.DetectedArchetype != nil {
+				faced[*profile.DetectedArchetype]++
+			}
+		}
+		components = append(components, faced)
+	}
+
+	if i.metaSource != nil && format != nil {
+		if metaArchetypes, err := i.metaSource.GetTopArchetypes(ctx, *format, 0); err == nil {
+			shares := make(map[string]float64)
+			for _, a := range archetypes {
+				for _, m := range metaArchetypes {
+					if strings.EqualFold(m.Name, a.name) || strings.EqualFold(m.NormalizedName, a.name) {
+						shares[a.name] = m.MetaShare
+						break
+					}
+				}
+			}
+			components = append(components, shares)
+		}
+	}
+
+	priors := make(map[string]float64)
+	used := 0
+	for _, component := range components {
+		var total float64
+		for _, a := range archetypes {
+			total += component[a.name]
+		}
+		if total == 0 {
+			continue // No data for these archetypes
+		}
+		for _, a := range archetypes {
+			priors[a.name] += component[a.name] / total
+		}
+		used++
+	}
+	for name := range priors {
+		priors[name] /= float64(used)
+	}
+	return priors
+}
+
+// scoreArchetypes applies Bayes' rule over the revealed nonland cards. A card
+// on an archetype's list is as likely as its inclusion rate; any other card
+// counts as an off-list include.
+func scoreArchetypes(archetypes []*archetypeList, priors map[string]float64, seen map[int]bool, card func(int) *models.SetCard) []ArchetypeLikelihood {
+	var evidence []int
+	for cardID := range seen {
+		if !isLandCard(card(cardID)) {
+			evidence = append(evidence, cardID)
+		}
+	}
+
+	logPosterior := make([]float64, len(archetypes))
+	likelihoods := make([]ArchetypeLikelihood, len(archetypes))
+	best := math.Inf(-1)
+	for n, a := range archetypes {
+		likelihoods[n] = ArchetypeLikelihood{Archetype: a.name, Prior: priors[a.name]}
+		logPosterior[n] = math.Log(priors[a.name])
+		for _, cardID := range evidence {
+			rate := offListRate
+			if exp := a.cards[cardID]; exp != nil {
+				rate = math.Max(exp.InclusionRate, offListRate)
+				likelihoods[n].CardsMatched++
+			}
+			logPosterior[n] += math.Log(rate)
+		}
+		best = math.Max(best, logPosterior[n])
+	}
+
+	var total float64
+	for n := range likelihoods {
+		likelihoods[n].Probability = math.Exp(logPosterior[n] - best)
+		total += likelihoods[n].Probability
+	}
+	for n := range likelihoods {
+		likelihoods[n].Probability /= total
+	}
+
+	sort.SliceStable(likelihoods, func(a, b int) bool {
+		return likelihoods[a].Probability > likelihoods[b].Probability
+	})
+	return likelihoods
+}
+
+// estimateThreats weighs each archetype's sweepers, counterspells and
+// instant-speed removal by the archetype's probability. Hand odds are
+// hypergeometric: the opponent's hand is a random draw from their unrevealed cards.
+func estimateThreats(archetypes []*archetypeList, inference *OpponentInference, seen map[int]bool, card func(int) *models.SetCard) []CardThreat {
+	probability := make(map[string]float64)
+	for _, a := range inference.Archetypes {
+		probability[a.Archetype] = a.Probability
+	}
+
+	threats := make(map[int]*CardThreat)
+	for _, a := range archetypes {
+		weight := probability[a.name]
+		for cardID, exp := range a.cards {
+			data := card(cardID)
+			threat := threatCategory(data)
+			if threat == "" {
+				continue
+			}
+
+			copies := max(int(math.Round(exp.AvgCopies)), 1)
+			included := exp.InclusionRate
+			if seen[cardID] {
+				included = 1
+				copies--
+			}
+			if copies <= 0 {
+				continue
+			}
+
+			t := threats[cardID]
+			if t == nil {
+				t = &CardThreat{
+					CardID:    cardID,
+					CardName:  exp.CardName,
+					Threat:    threat,
+					ManaValue: data.CMC,
+					Castable:  data.CMC <= inference.UntappedLands,
+				}
+				threats[cardID] = t
+			}
+			t.InDeck += weight * included
+			t.InHand += weight * included * atLeastOneInHand(inference.UnknownCards, copies, inference.OpponentHandSize)
+		}
+	}
+
+	result := make([]CardThreat, 0, len(threats))
+	for _, t := range threats {
+		if t.InDeck >= minThreatProbability {
+			result = append(result, *t)
+		}
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].InHand != result[b].InHand {
+			return result[a].InHand > result[b].InHand
+		}
+		return result[a].CardID < result[b].CardID
+	})
+	if len(result) > maxThreats {
+		result = result[:maxThreats]
+	}
+	return result
+}
+
+// threatCategory classifies cards that punish overextending or tapping out.
+func threatCategory(card *models.SetCard) string {
+	if card == nil {
+		return ""
+	}
+	text := strings.ToLower(card.Text)
+	for _, pattern := range []string{"destroy all", "exile all", "damage to each creature", "all creatures get -", "return all creatures"} {
+		if strings.Contains(text, pattern) {
+			return ThreatSweeper
+		}
+	}
+	if !isInstantSpeed(card) {
+		return ""
+	}
+	if strings.Contains(text, "counter target") {
+		return ThreatCounterspell
+	}
+	if cards.IsRemovalText(card.Text) {
+		return ThreatInstantRemoval
+	}
+	return ""
+}
+
+// atLeastOneInHand is the chance a hand of handSize cards drawn from unknown
+// cards holds at least one of copies.
+func atLeastOneInHand(unknown, copies, handSize int) float64 {
+	if handSize <= 0 || copies <= 0 || unknown <= 0 {
+		return 0
+	}
+	if copies > unknown-handSize {
+		return 1
+	}
+	none := 1.0
+	for n := 0; n < handSize; n++ {
+		none *= float64(unknown-copies-n) / float64(unknown-n)
+	}
+	return 1 - none
+}
+
+// groupExpectedCards splits expected cards by archetype, keeping the first
+// format seen when an archetype appears in several.
+func groupExpectedCards(expected []*models.ArchetypeExpectedCard) []*archetypeList {
+	var archetypes []*archetypeList
+	byName := make(map[string]*archetypeList)
+	formats := make(map[string]string)
+	for _, card := range expected {
+		a := byName[card.ArchetypeName]
+		if a == nil {
+			a = &archetypeList{name: card.ArchetypeName, cards: make(map[int]*models.ArchetypeExpectedCard)}
+			byName[card.ArchetypeName] = a
+			formats[card.ArchetypeName] = card.Format
+			archetypes = append(archetypes, a)
+		}
+		if card.Format == formats[card.ArchetypeName] {
+			a.cards[card.CardID] = card
+		}
+	}
+	return archetypes
+}
+
+// deckSizeForFormat returns the usual deck size for a format.
+func deckSizeForFormat(format *string) int {
+	if format != nil {
+		f := strings.ToLower(*format)
+		if strings.Contains(f, "draft") || strings.Contains(f, "sealed") {
+			return 40
+		}
+	}
+	return 60
+}
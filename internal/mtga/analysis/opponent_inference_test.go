@@ -0,0 +1,157 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+type inferencePlays struct {
+	repository.GamePlayRepository
+	observed  []*models.OpponentCardObserved
+	snapshots []*models.GameStateSnapshot
+}
+
+func (f *inferencePlays) GetOpponentCardsByMatch(ctx context.Context, matchID string) ([]*models.OpponentCardObserved, error) {
+	return f.observed, nil
+}
+
+func (f *inferencePlays) GetSnapshotsByMatch(ctx context.Context, matchID string) ([]*models.GameStateSnapshot, error) {
+	return f.snapshots, nil
+}
+
+type inferenceOpponents struct {
+	repository.OpponentRepository
+	expected []*models.ArchetypeExpectedCard
+	profiles []*models.OpponentDeckProfile
+}
+
+func (f *inferenceOpponents) ListExpectedCards(ctx context.Context, format *string) ([]*models.ArchetypeExpectedCard, error) {
+	return f.expected, nil
+}
+
+func (f *inferenceOpponents) ListProfiles(ctx context.Context, filter *repository.OpponentProfileFilter) ([]*models.OpponentDeckProfile, error) {
+	return f.profiles, nil
+}
+
+type inferenceMatches struct {
+	repository.MatchRepository
+}
+
+func (f *inferenceMatches) GetByID(ctx context.Context, id string) (*models.Match, error) {
+	return nil, nil // Still in progress
+}
+
+type cardTable map[int]*models.SetCard
+
+func (c cardTable) GetCardByArenaID(ctx context.Context, arenaID string) (*models.SetCard, error) {
+	id, _ := strconv.Atoi(arenaID)
+	return c[id], nil
+}
+
+func expectedCard(archetype string, cardID int, name string, inclusion, copies float64) *models.ArchetypeExpectedCard {
+	return &models.ArchetypeExpectedCard{ArchetypeName: archetype, Format: "Ladder", CardID: cardID, CardName: name, InclusionRate: inclusion, AvgCopies: copies}
+}
+
+func TestOpponentInferrer_Infer(t *testing.T) {
+	cards := cardTable{
+		1: {Name: "Island", Types: []string{"Basic", "Land", "Island"}},
+		2: {Name: "Depopulate", CMC: 4, Types: []string{"Sorcery"}, Text: "Destroy all creatures."},
+		3: {Name: "No More Lies", CMC: 2, Types: []string{"Instant"}, Text: "Counter target spell unless its controller pays {3}."},
+		4: {Name: "Memory Deluge", CMC: 4, Types: []string{"Instant"}, Text: "Look at the top X cards of your library."},
+		5: {Name: "Play with Fire", CMC: 1, Types: []string{"Instant"}, Text: "Play with Fire deals 2 damage to any target."},
+		6: {Name: "Monastery Swiftspear", CMC: 1, Types: []string{"Creature"}, Text: "Haste"},
+	}
+	opponents := &inferenceOpponents{
+		expected: []*models.ArchetypeExpectedCard{
+			expectedCard("Azorius Control", 2, "Depopulate", 0.9, 2),
+			expectedCard("Azorius Control", 3, "No More Lies", 0.8, 4),
+			expectedCard("Azorius Control", 4, "Memory Deluge", 0.9, 3),
+			expectedCard("Mono-Red Aggro", 5, "Play with Fire", 0.9, 4),
+			expectedCard("Mono-Red Aggro", 6, "Monastery Swiftspear", 1.0, 4),
+		},
+	}
+	// Mono-Red has been faced more often, so it starts ahead
+	red := "Mono-Red Aggro"
+	for range 3 {
+		opponents.profiles = append(opponents.profiles, &models.OpponentDeckProfile{DetectedArchetype: &red})
+	}
+
+	board, _ := json.Marshal(repository.BoardState{OpponentPermanents: []repository.PermanentState{land(false), land(false), land(true)}})
+	boardJSON := string(board)
+	hand := 5
+	plays := &inferencePlays{
+		observed: []*models.OpponentCardObserved{{CardID: 1}, {CardID: 4}},
+		snapshots: []*models.GameStateSnapshot{
+			{TurnNumber: 4, OpponentCardsInHand: &hand, BoardStateJSON: &boardJSON},
+		},
+	}
+
+	inferrer := NewOpponentInferrer(plays, opponents, &inferenceMatches{}, cards)
+	result, err := inferrer.Infer(context.Background(), "match-1")
+	if err != nil {
+		t.Fatalf("Infer: %v", err)
+	}
+
+	if result.Turn != 4 || result.OpponentHandSize != 5 || result.UntappedLands != 2 || result.UnknownCards != 58 {
+		t.Errorf("game state = turn %d, hand %d, untapped %d, unknown %d", result.Turn, result.OpponentHandSize, result.UntappedLands, result.UnknownCards)
+	}
+
+	// Priors: uniform (0.5/0.5) averaged with history (0/1). The Island is ignored,
+	// Memory Deluge is 0.9 for Azorius and off-list (0.05) for Mono-Red.
+	top := result.Archetypes[0]
+	if top.Archetype != "Azorius Control" || math.Abs(top.Prior-0.25) > 1e-9 || top.CardsMatched != 1 {
+		t.Fatalf("top archetype = %+v", top)
+	}
+	want := 0.25 * 0.9 / (0.25*0.9 + 0.75*0.05)
+	if math.Abs(top.Probability-want) > 1e-9 {
+		t.Errorf("Azorius probability = %v, want %v", top.Probability, want)
+	}
+
+	threats := make(map[int]CardThreat)
+	for _, threat := range result.Threats {
+		threats[threat.CardID] = threat
+	}
+	if len(threats) != 3 {
+		t.Fatalf("got threats %+v", result.Threats)
+	}
+	if counter := threats[3]; counter.Threat != ThreatCounterspell || !counter.Castable {
+		t.Errorf("counterspell = %+v", counter)
+	}
+	if sweeper := threats[2]; sweeper.Threat != ThreatSweeper || sweeper.Castable {
+		t.Errorf("sweeper = %+v", sweeper)
+	}
+	wantInHand := want * 0.8 * atLeastOneInHand(58, 4, 5)
+	if math.Abs(threats[3].InHand-wantInHand) > 1e-9 || math.Abs(threats[3].InDeck-want*0.8) > 1e-9 {
+		t.Errorf("counterspell odds = %+v, want in hand %v", threats[3], wantInHand)
+	}
+	if removal := threats[5]; removal.Threat != ThreatInstantRemoval || removal.InHand >= threats[3].InHand {
+		t.Errorf("unlikely archetype's removal should rank below the counterspell: %+v", removal)
+	}
+	if result.Threats[0].CardID != 3 {
+		t.Errorf("most likely held threat = %+v", result.Threats[0])
+	}
+
+	// Without expected cards there is nothing to infer
+	inferrer = NewOpponentInferrer(plays, &inferenceOpponents{}, &inferenceMatches{}, cards)
+	if result, err := inferrer.Infer(context.Background(), "match-1"); err != nil || result != nil {
+		t.Errorf("expected no inference, got %+v, %v", result, err)
+	}
+}
+
+func TestAtLeastOneInHand(t *testing.T) {
+	if p := atLeastOneInHand(40, 1, 7); math.Abs(p-7.0/40) > 1e-9 {
+		t.Errorf("one copy in 40 with 7 in hand = %v", p)
+	}
+	if p := atLeastOneInHand(10, 4, 7); p != 1 {
+		t.Errorf("four copies in ten with seven in hand = %v, want 1", p)
+	}
+	if p := atLeastOneInHand(40, 4, 0); p != 0 {
+		t.Errorf("empty hand = %v", p)
+	}
+}
@@ -2,7 +2,10 @@ package analysis
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/goldfish"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
 )
@@ -20,16 +23,19 @@ type AnalysisResult struct {
 	CurveAnalysis    CurveAnalysis
 	ManaAnalysis     ManaAnalysis
 	SequencingIssues []SequencingIssue
+	Misplays         []Misplay
+	GamesWithPlays   int // Games with recorded plays for the misplay rules to check
 }
 
 // CurveAnalysis examines mana curve performance.
 type CurveAnalysis struct {
-	AvgFirstPlay     float64     // Average turn of first non-land spell
-	EmptyTurns       float64     // Average turns with no plays
-	FloodedGames     int         // Games with 7+ lands and few spells in hand
-	ScrewedGames     int         // Games stuck on 2-3 lands by turn 5
-	CMCDistribution  map[int]int // CMC -> count of plays at that CMC
-	CurveSuggestions []string    // Generated curve improvement suggestions
+	AvgFirstPlay     float64          // Average turn of first non-land spell
+	EmptyTurns       float64          // Average turns with no plays
+	FloodedGames     int              // Games with 7+ lands and few spells in hand
+	ScrewedGames     int              // Games stuck on 2-3 lands by turn 5
+	CMCDistribution  map[int]int      // CMC -> count of plays at that CMC
+	CurveSuggestions []string         // Generated curve improvement suggestions
+	Goldfish         *goldfish.Result // Simulated games against an empty board, when available
 }
 
 // ManaAnalysis examines mana base performance.
@@ -50,10 +56,21 @@ type SequencingIssue struct {
 	CardName    *string
 }
 
+// DeckGoldfisher plays a deck against an empty board to estimate its speed.
+type DeckGoldfisher interface {
+	GoldfishDeck(ctx context.Context, deckID string, iterations int) (*goldfish.Result, error)
+}
+
+// goldfishIterations is the number of simulated games behind curve suggestions.
+const goldfishIterations = 1000
+
 // PlayAnalyzer analyzes game_plays data to find patterns and generate improvement suggestions.
 type PlayAnalyzer struct {
-	playRepo  repository.GamePlayRepository
-	matchRepo repository.MatchRepository
+	playRepo   repository.GamePlayRepository
+	matchRepo  repository.MatchRepository
+	goldfisher DeckGoldfisher
+	cardLookup CardLookup
+	rules      []MisplayRule
 }
 
 // NewPlayAnalyzer creates a new play analyzer.
@@ -61,9 +78,30 @@ func NewPlayAnalyzer(playRepo repository.GamePlayRepository, matchRepo repositor
 	return &PlayAnalyzer{
 		playRepo:  playRepo,
 		matchRepo: matchRepo,
+		rules:     DefaultMisplayRules(),
 	}
 }
 
+// SetGoldfisher sets the simulator used to compare real games against the deck's goldfish speed.
+func (a *PlayAnalyzer) SetGoldfisher(goldfisher DeckGoldfisher) {
+	a.goldfisher = goldfisher
+}
+
+// SetCardLookup sets the card source misplay rules use for mana values and card text.
+func (a *PlayAnalyzer) SetCardLookup(cardLookup CardLookup) {
+	a.cardLookup = cardLookup
+}
+
+// SetMisplayRules replaces the misplay rules run over each game.
+func (a *PlayAnalyzer) SetMisplayRules(rules ...MisplayRule) {
+	a.rules = rules
+}
+
+// MisplayRules returns the misplay rules run over each game.
+func (a *PlayAnalyzer) MisplayRules() []MisplayRule {
+	return a.rules
+}
+
 // AnalyzeDeck analyzes play patterns for a deck across multiple matches.
 // minGames specifies the minimum number of games required for meaningful analysis.
 func (a *PlayAnalyzer) AnalyzeDeck(ctx context.Context, deckID string, minGames int) (*AnalysisResult, error) {
@@ -87,6 +125,7 @@ func (a *PlayAnalyzer) AnalyzeDeck(ctx context.Context, deckID string, minGames
 		DeckID:           deckID,
 		TotalGames:       len(matches),
 		SequencingIssues: []SequencingIssue{},
+		Misplays:         []Misplay{},
 		CurveAnalysis: CurveAnalysis{
 			CMCDistribution: make(map[int]int),
 		},
@@ -97,6 +136,7 @@ func (a *PlayAnalyzer) AnalyzeDeck(ctx context.Context, deckID string, minGames
 	var gamesWithMissedLandDrop int
 	var firstPlayTurns []int
 	var matchesWithPlayData int
+	cards := make(map[int]*models.SetCard)
 
 	for _, match := range matches {
 		// Count wins/losses
@@ -132,6 +172,13 @@ func (a *PlayAnalyzer) AnalyzeDeck(ctx context.Context, deckID string, minGames
 			result.SequencingIssues = append(result.SequencingIssues, issue)
 		}
 
+		// Run the misplay rules over games with recorded plays
+		if len(plays) > 0 {
+			game := NewMisplayGame(match.ID, plays, snapshots, nil)
+			game.Cards = a.lookupCards(ctx, cards, game)
+			result.Misplays = append(result.Misplays, CheckMisplays(game, a.rules)...)
+		}
+
 		// Aggregate results
 		totalTurns += matchAnalysis.maxTurn
 
@@ -163,6 +210,8 @@ func (a *PlayAnalyzer) AnalyzeDeck(ctx context.Context, deckID string, minGames
 		result.ManaAnalysis.AvgLandDrops += float64(matchAnalysis.landDrops)
 	}
 
+	result.GamesWithPlays = matchesWithPlayData
+
 	// Calculate averages
 	if result.TotalGames > 0 {
 		result.WinRate = float64(result.Wins) / float64(result.TotalGames) * 100
@@ -181,6 +230,13 @@ func (a *PlayAnalyzer) AnalyzeDeck(ctx context.Context, deckID string, minGames
 		result.CurveAnalysis.AvgFirstPlay = float64(sum) / float64(len(firstPlayTurns))
 	}
 
+	// Simulate the deck for a baseline free of opponents and misplays
+	if a.goldfisher != nil {
+		if sim, err := a.goldfisher.GoldfishDeck(ctx, deckID, goldfishIterations); err == nil {
+			result.CurveAnalysis.Goldfish = sim
+		}
+	}
+
 	// Generate curve suggestions based on analysis
 	result.CurveAnalysis.CurveSuggestions = a.generateCurveSuggestions(result)
 
@@ -296,6 +352,22 @@ func (a *PlayAnalyzer) generateCurveSuggestions(result *AnalysisResult) []string
 		suggestions = append(suggestions, "Deck curve appears top-heavy; early game presence is limited")
 	}
 
+	// Goldfish games show what the list itself does
+	if sim := result.CurveAnalysis.Goldfish; sim != nil {
+		if sim.CurveEfficiency > 0 && sim.CurveEfficiency < 0.6 {
+			suggestions = append(suggestions, fmt.Sprintf("Simulated games spend only %.0f%% of their mana in the first six turns; fill the gaps in the curve", sim.CurveEfficiency*100))
+		}
+		if sim.ManaScrewRate > 0.15 {
+			suggestions = append(suggestions, fmt.Sprintf("Simulated games are stuck below 3 lands on turn 5 %.0f%% of the time; consider another land", sim.ManaScrewRate*100))
+		}
+		if sim.ManaFloodRate > 0.25 {
+			suggestions = append(suggestions, fmt.Sprintf("Simulated games have 7 lands on turn 7 %.0f%% of the time; consider cutting a land", sim.ManaFloodRate*100))
+		}
+		if sim.AverageFirstSpellTurn > 0 && result.CurveAnalysis.AvgFirstPlay > sim.AverageFirstSpellTurn+1 {
+			suggestions = append(suggestions, fmt.Sprintf("Your first play comes on turn %.1f, but the deck can make one on turn %.1f; check mulligans and early sequencing", result.CurveAnalysis.AvgFirstPlay, sim.AverageFirstSpellTurn))
+		}
+	}
+
 	return suggestions
 }
 
@@ -337,6 +409,10 @@ func (a *PlayAnalyzer) AnalyzeMatch(ctx context.Context, matchID string) (*Match
 	// Analyze plays for sequencing issues
 	result.SequencingIssues = a.detectSequencingIssues(plays, snapshots)
 
+	game := NewMisplayGame(matchID, plays, snapshots, nil)
+	game.Cards = a.lookupCards(ctx, make(map[int]*models.SetCard), game)
+	result.Misplays = CheckMisplays(game, a.rules)
+
 	return result, nil
 }
 
@@ -350,6 +426,7 @@ type MatchAnalysis struct {
 	Attacks          int
 	Blocks           int
 	SequencingIssues []SequencingIssue
+	Misplays         []Misplay
 }
 
 // detectSequencingIssues identifies potential play order mistakes.
@@ -395,3 +472,38 @@ func (a *PlayAnalyzer) detectSequencingIssues(plays []*models.GamePlay, snapshot
 
 	return issues
 }
+
+// lookupCards adds the cards a game's plays and boards reference to cards,
+// which is shared across games to avoid repeat lookups. It returns nil when
+// no card lookup is set.
+func (a *PlayAnalyzer) lookupCards(ctx context.Context, cards map[int]*models.SetCard, game *MisplayGame) map[int]*models.SetCard {
+	if a.cardLookup == nil {
+		return nil
+	}
+
+	lookup := func(cardID int) {
+		if _, seen := cards[cardID]; seen || cardID <= 0 {
+			return
+		}
+		card, err := a.cardLookup.GetCardByArenaID(ctx, strconv.Itoa(cardID))
+		if err != nil {
+			card = nil
+		}
+		cards[cardID] = card
+	}
+	for _, turnPlays := range game.PlaysByTurn {
+		for _, play := range turnPlays {
+			if play.CardID != nil {
+				lookup(*play.CardID)
+			}
+		}
+	}
+	for _, board := range game.Boards {
+		for _, permanents := range [][]repository.PermanentState{board.PlayerPermanents, board.OpponentPermanents, board.PlayerHand} {
+			for _, p := range permanents {
+				lookup(p.CardID)
+			}
+		}
+	}
+	return cards
+}
@@ -0,0 +1,76 @@
+package analysis
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/goldfish"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+type fakeMatchRepo struct {
+	repository.MatchRepository
+	matches []*models.Match
+}
+
+func (f *fakeMatchRepo) GetMatches(ctx context.Context, filter models.StatsFilter) ([]*models.Match, error) {
+	return f.matches, nil
+}
+
+type fakePlayRepo struct {
+	repository.GamePlayRepository
+}
+
+func (f *fakePlayRepo) GetPlaysByMatch(ctx context.Context, matchID string) ([]*models.GamePlay, error) {
+	return nil, nil
+}
+
+func (f *fakePlayRepo) GetSnapshotsByMatch(ctx context.Context, matchID string) ([]*models.GameStateSnapshot, error) {
+	return nil, nil
+}
+
+type fakeGoldfisher struct {
+	result *goldfish.Result
+	deckID string
+}
+
+func (f *fakeGoldfisher) GoldfishDeck(ctx context.Context, deckID string, iterations int) (*goldfish.Result, error) {
+	f.deckID = deckID
+	return f.result, nil
+}
+
+func TestAnalyzeDeck_GoldfishCurveSuggestions(t *testing.T) {
+	matches := make([]*models.Match, 5)
+	for i := range matches {
+		matches[i] = &models.Match{ID: string(rune('a' + i)), Result: "win"}
+	}
+	analyzer := NewPlayAnalyzer(&fakePlayRepo{}, &fakeMatchRepo{matches: matches})
+
+	result, err := analyzer.AnalyzeDeck(context.Background(), "deck-1", 5)
+	if err != nil {
+		t.Fatalf("AnalyzeDeck: %v", err)
+	}
+	if result.CurveAnalysis.Goldfish != nil || len(result.CurveAnalysis.CurveSuggestions) != 0 {
+		t.Errorf("without a goldfisher expected no simulation or suggestions, got %v", result.CurveAnalysis.CurveSuggestions)
+	}
+
+	goldfisher := &fakeGoldfisher{result: &goldfish.Result{Iterations: 1000, ManaScrewRate: 0.2, CurveEfficiency: 0.5, AverageFirstSpellTurn: 2}}
+	analyzer.SetGoldfisher(goldfisher)
+	result, err = analyzer.AnalyzeDeck(context.Background(), "deck-1", 5)
+	if err != nil {
+		t.Fatalf("AnalyzeDeck: %v", err)
+	}
+	if goldfisher.deckID != "deck-1" || result.CurveAnalysis.Goldfish != goldfisher.result {
+		t.Fatal("expected the deck to be goldfished")
+	}
+
+	suggestions := strings.Join(result.CurveAnalysis.CurveSuggestions, "\n")
+	if !strings.Contains(suggestions, "another land") || !strings.Contains(suggestions, "gaps in the curve") {
+		t.Errorf("expected land and curve suggestions, got %q", suggestions)
+	}
+	if strings.Contains(suggestions, "cutting a land") {
+		t.Errorf("unexpected flood suggestion: %q", suggestions)
+	}
+}
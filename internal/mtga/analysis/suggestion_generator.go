@@ -65,6 +65,10 @@ func (g *SuggestionGenerator) GenerateSuggestions(ctx context.Context, deckID st
 	sequencingSuggestions := g.generateSequencingSuggestions(deckID, analysis)
 	suggestions = append(suggestions, sequencingSuggestions...)
 
+	// Generate suggestions from recurring misplays
+	misplaySuggestions := g.generateMisplaySuggestions(deckID, analysis)
+	suggestions = append(suggestions, misplaySuggestions...)
+
 	// Store suggestions in database
 	for _, sugg := range suggestions {
 		if err := g.suggRepo.CreateSuggestion(ctx, sugg); err != nil {
@@ -220,6 +224,100 @@ func (g *SuggestionGenerator) generateSequencingSuggestions(deckID string, analy
 	return suggestions
 }
 
+// minMisplayGames is the number of games a misplay rule must fire in before
+// it becomes a suggestion, so one-off situations are not reported as habits.
+const minMisplayGames = 2
+
+// maxMisplayExamples caps the misplays quoted in a suggestion's evidence.
+const maxMisplayExamples = 5
+
+// generateMisplaySuggestions creates a suggestion for each misplay rule that
+// fired across several games.
+func (g *SuggestionGenerator) generateMisplaySuggestions(deckID string, analysis *AnalysisResult) []*models.ImprovementSuggestion {
+	var suggestions []*models.ImprovementSuggestion
+
+	byRule := make(map[string][]Misplay)
+	for _, misplay := range analysis.Misplays {
+		byRule[misplay.Rule] = append(byRule[misplay.Rule], misplay)
+	}
+
+	// Rules are walked in the analyzer's order so suggestions come out stable
+	for _, rule := range g.analyzer.MisplayRules() {
+		misplays := byRule[rule.Name()]
+		games := make(map[string]bool)
+		for _, misplay := range misplays {
+			games[misplay.MatchID] = true
+		}
+		if len(games) < minMisplayGames {
+			continue
+		}
+
+		priority := models.SuggestionPriorityLow
+		var cardIDs []int
+		seenCards := make(map[int]bool)
+		for _, misplay := range misplays {
+			priority = higherPriority(priority, misplay.Severity)
+			if misplay.CardID != nil && !seenCards[*misplay.CardID] {
+				seenCards[*misplay.CardID] = true
+				cardIDs = append(cardIDs, *misplay.CardID)
+			}
+		}
+
+		examples := misplays
+		if len(examples) > maxMisplayExamples {
+			examples = examples[:maxMisplayExamples]
+		}
+		exampleEvidence := make([]map[string]interface{}, len(examples))
+		for i, misplay := range examples {
+			exampleEvidence[i] = map[string]interface{}{
+				"matchId":     misplay.MatchID,
+				"turn":        misplay.Turn,
+				"description": misplay.Description,
+				"details":     misplay.Evidence,
+			}
+		}
+		evidence := map[string]interface{}{
+			"rule":        rule.Name(),
+			"occurrences": len(misplays),
+			"games":       len(games),
+			"totalGames":  analysis.GamesWithPlays,
+			"examples":    exampleEvidence,
+		}
+		evidenceJSON, _ := json.Marshal(evidence)
+		evidenceStr := string(evidenceJSON)
+
+		sugg := &models.ImprovementSuggestion{
+			DeckID:         deckID,
+			SuggestionType: models.SuggestionTypeSequencing,
+			Priority:       priority,
+			Title:          rule.Title(),
+			Description:    fmt.Sprintf("%s Seen %d times across %d of %d games.", rule.Advice(), len(misplays), len(games), analysis.GamesWithPlays),
+			Evidence:       &evidenceStr,
+		}
+		if len(cardIDs) > 0 {
+			cardsJSON, _ := json.Marshal(cardIDs)
+			cardsStr := string(cardsJSON)
+			sugg.CardReferences = &cardsStr
+		}
+		suggestions = append(suggestions, sugg)
+	}
+
+	return suggestions
+}
+
+// higherPriority returns the more urgent of two suggestion priorities.
+func higherPriority(a, b string) string {
+	rank := map[string]int{
+		models.SuggestionPriorityLow:    0,
+		models.SuggestionPriorityMedium: 1,
+		models.SuggestionPriorityHigh:   2,
+	}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
 // GetDeckSuggestions retrieves existing suggestions for a deck without regenerating.
 func (g *SuggestionGenerator) GetDeckSuggestions(ctx context.Context, deckID string, activeOnly bool) ([]*models.ImprovementSuggestion, error) {
 	if activeOnly {
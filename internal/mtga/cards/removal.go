@@ -0,0 +1,32 @@
+package cards
+
+import "strings"
+
+// removalPatterns are oracle text fragments of spells that kill, exile or
+// shrink an opposing creature, or make the opponent sacrifice one.
+var removalPatterns = []string{
+	"destroy target",
+	"exile target",
+	"damage to target creature",
+	"damage to any target",
+	"target creature gets -",
+	"-x/-x",
+	"target creature an opponent controls",
+	"fights target",
+	"opponent sacrifices a creature",
+	"destroy all creatures",
+	"exile all creatures",
+}
+
+// IsRemovalText reports whether oracle text describes a spell that deals with
+// an opposing creature. The draft, deck building and game analysis code all
+// classify removal through it so a card counts the same everywhere.
+func IsRemovalText(text string) bool {
+	text = strings.ToLower(text)
+	for _, pattern := range removalPatterns {
+		if strings.Contains(text, pattern) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package cards
+
+import "testing"
+
+func TestIsRemovalText(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"Destroy target creature.", true},
+		{"Exile target nonland permanent.", true},
+		{"Lightning Strike deals 3 damage to any target.", true},
+		{"Target creature gets -3/-3 until end of turn.", true},
+		{"Target creature you control fights target creature you don't control.", true},
+		{"Each opponent sacrifices a creature.", true},
+		{"Destroy all creatures. They can't be regenerated.", true},
+		{"Draw three cards.", false},
+		{"Whenever this creature deals combat damage to a player, draw a card.", false},
+		{"As an additional cost to cast this spell, sacrifice a creature.", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRemovalText(tt.text); got != tt.want {
+			t.Errorf("IsRemovalText(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
@@ -5,13 +5,13 @@ import (
 	"testing"
 	"time"
 
-	_ "modernc.org/sqlite"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 func setupTestDB(t *testing.T) *sql.DB {
 	t.Helper()
 
-	db, err := sql.Open("sqlite", ":memory:")
+	db, err := sql.Open(sqlitedriver.Name, ":memory:")
 	if err != nil {
 		t.Fatalf("Failed to create test database: %v", err)
 	}
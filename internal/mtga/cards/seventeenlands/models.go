@@ -44,6 +44,12 @@ type CardRating struct {
 	// Deck metrics
 	GamesPlayed int `json:"# games_played,omitempty"` // Games played with this card
 	NumberDecks int `json:"# decks,omitempty"`        // Number of decks with this card
+
+	// Early-set estimates, set when 17Lands data is still sparse (not from 17Lands)
+	Predicted     bool    `json:"predicted,omitempty"`      // GIHWR includes a model prediction
+	PriorGIHWR    float64 `json:"prior_gihwr,omitempty"`    // Model prediction
+	ObservedGIHWR float64 `json:"observed_gihwr,omitempty"` // 17Lands GIHWR before the prediction was blended in
+	PriorWeight   float64 `json:"prior_weight,omitempty"`   // Share of GIHWR from the prediction; 1 when there is no live data
 }
 
 // ColorRating represents color combination performance statistics from 17Lands.
@@ -0,0 +1,165 @@
+// Package castability answers whether lands can pay for a spell and how likely
+// a deck is to draw those lands. The mana base calculator, the draft optimizer
+// and the goldfish simulator share it.
+package castability
+
+import "strings"
+
+// Colors is the canonical WUBRG order. Color masks set bit i for Colors[i].
+var Colors = []string{"W", "U", "B", "R", "G"}
+
+// openingHand is the number of cards seen before the first draw.
+const openingHand = 7
+
+// Group is a set of identical mana sources.
+type Group struct {
+	Mask  int // Colors the sources make
+	Count int
+}
+
+// Bit returns a color's bit index, or -1 if it is not a color.
+func Bit(color string) int {
+	for bit, c := range Colors {
+		if c == color {
+			return bit
+		}
+	}
+	return -1
+}
+
+// Mask returns the color mask of colors, ignoring anything that is not a color.
+func Mask(colors []string) int {
+	mask := 0
+	for _, color := range colors {
+		if bit := Bit(color); bit >= 0 {
+			mask |= 1 << bit
+		}
+	}
+	return mask
+}
+
+// IsLand reports whether a type line is a land. Land creatures count as spells.
+func IsLand(typeLine string) bool {
+	lower := strings.ToLower(typeLine)
+	return strings.Contains(lower, "land") && !strings.Contains(lower, "creature")
+}
+
+// Payable reports whether lands, one mask per land, can pay total mana
+// including the colored pips. It checks Hall's condition: for every set of
+// required colors, the lands able to make any of them must cover their pips.
+func Payable(lands []int, total int, pips [5]int) bool {
+	if total > len(lands) {
+		return false
+	}
+	return covers(pips, func(subset int) int {
+		available := 0
+		for _, mask := range lands {
+			if mask&subset != 0 {
+				available++
+			}
+		}
+		return available
+	})
+}
+
+// covers checks Hall's condition, with available counting the lands that
+// make any color in a subset.
+func covers(pips [5]int, available func(subset int) int) bool {
+	for subset := 1; subset < 1<<len(pips); subset++ {
+		need := 0
+		for bit, n := range pips {
+			if subset&(1<<bit) != 0 {
+				need += n
+			}
+		}
+		if need > 0 && available(subset) < need {
+			return false
+		}
+	}
+	return true
+}
+
+// Probability is the chance of having lands that pay a spell's cost by the
+// given turn. It sums over every way of drawing lands from each group and
+// keeps the draws with cmc lands that are Payable.
+func Probability(lands []Group, deckSize, turn, cmc int, pips map[string]int, onDraw bool) float64 {
+	seen := cardsSeen(turn, deckSize, onDraw)
+	totalLands := 0
+	for _, l := range lands {
+		totalLands += l.Count
+	}
+	var need [5]int
+	for bit, color := range Colors {
+		need[bit] = pips[color]
+	}
+
+	drawn := make([]int, len(lands))
+	payable := func() bool {
+		return covers(need, func(subset int) int {
+			available := 0
+			for i, l := range lands {
+				if l.Mask&subset != 0 {
+					available += drawn[i]
+				}
+			}
+			return available
+		})
+	}
+
+	var ways float64
+	var walk func(i, total int, product float64)
+	walk = func(i, total int, product float64) {
+		if i == len(lands) {
+			if total < cmc || !payable() {
+				return
+			}
+			ways += product * Binomial(deckSize-totalLands, seen-total)
+			return
+		}
+		for k := 0; k <= lands[i].Count && total+k <= seen; k++ {
+			drawn[i] = k
+			walk(i+1, total+k, product*Binomial(lands[i].Count, k))
+		}
+		drawn[i] = 0
+	}
+	walk(0, 0, 1)
+	return ways / Binomial(deckSize, seen)
+}
+
+// LandProbability is the chance of having at least n of a deck's lands by
+// the given turn.
+func LandProbability(lands, deckSize, turn, n int, onDraw bool) float64 {
+	if n < 1 {
+		return 1
+	}
+	seen := cardsSeen(turn, deckSize, onDraw)
+	var p float64
+	for k := n; k <= lands && k <= seen; k++ {
+		p += Binomial(lands, k) * Binomial(deckSize-lands, seen-k)
+	}
+	return p / Binomial(deckSize, seen)
+}
+
+// cardsSeen is the cards drawn by a turn, capped at the deck size.
+func cardsSeen(turn, deckSize int, onDraw bool) int {
+	seen := openingHand + max(turn, 1) - 1
+	if onDraw {
+		seen++
+	}
+	return min(seen, deckSize)
+}
+
+// Binomial returns n choose k as a float64.
+func Binomial(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	if k > n-k {
+		k = n - k
+	}
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
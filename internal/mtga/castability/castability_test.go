@@ -0,0 +1,51 @@
+package castability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPayable_DualLands(t *testing.T) {
+	wu := 0b11
+	if !Payable([]int{wu, wu}, 2, [5]int{1, 1}) {
+		t.Error("two duals should pay {W}{U}")
+	}
+	if Payable([]int{wu, wu}, 3, [5]int{2, 1}) {
+		t.Error("two lands cannot pay three mana")
+	}
+	if Payable([]int{wu, 0, 0}, 3, [5]int{1, 1}) {
+		t.Error("one dual and two colorless lands cannot pay {W}{U}")
+	}
+}
+
+func TestProbability_HallCondition(t *testing.T) {
+	// Two dual lands cannot pay {W}{U} and a third pip at once: drawing only
+	// the two duals never casts a WWU spell even though each color is present.
+	lands := []Group{{Mask: 0b11, Count: 2}}
+	if got := Probability(lands, 10, 4, 3, map[string]int{"W": 2, "U": 1}, false); got != 0 {
+		t.Errorf("castability with two duals = %v, want 0", got)
+	}
+	if got := Probability(lands, 10, 4, 2, map[string]int{"W": 1, "U": 1}, false); got != 1 {
+		t.Errorf("castability of WU with both duals always drawn = %v, want 1", got)
+	}
+}
+
+func TestProbability_MonoColorIsLandDrops(t *testing.T) {
+	lands := []Group{{Mask: Mask([]string{"W"}), Count: 17}}
+	got := Probability(lands, 40, 3, 3, map[string]int{"W": 2}, false)
+	if want := LandProbability(17, 40, 3, 3, false); math.Abs(got-want) > 1e-9 {
+		t.Errorf("mono-color castability = %v, want the land drop chance %v", got, want)
+	}
+	if onDraw := LandProbability(17, 40, 3, 3, true); onDraw <= got {
+		t.Errorf("on the draw %v should beat on the play %v", onDraw, got)
+	}
+}
+
+func TestMask(t *testing.T) {
+	if got := Mask([]string{"G", "W", "C"}); got != 0b10001 {
+		t.Errorf("Mask(G, W, C) = %b, want 10001", got)
+	}
+	if !IsLand("Basic Land — Forest") || IsLand("Land Creature — Forest Dryad") {
+		t.Error("IsLand should accept lands and reject land creatures")
+	}
+}
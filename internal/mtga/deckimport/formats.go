@@ -0,0 +1,270 @@
+package deckimport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// structuredParser picks the parser for a structured deck file from its
+// markup, returning nil for text formats.
+func (p *Parser) structuredParser(input string) (func(string) (*ParseResult, error), string) {
+	switch {
+	case strings.HasPrefix(input, "<"):
+		switch xmlRoot(input) {
+		case "Deck":
+			return p.ParseMTGODek, "MTGO"
+		case "cockatrice_deck":
+			return p.ParseCockatrice, "Cockatrice"
+		}
+	case strings.HasPrefix(input, "{"):
+		return p.ParseJSON, "JSON"
+	case isForgeDeck(input):
+		return p.ParseForge, "Forge"
+	}
+	return nil, ""
+}
+
+// xmlRoot returns the name of the first element of an XML document.
+func xmlRoot(input string) string {
+	decoder := xml.NewDecoder(strings.NewReader(input))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return ""
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local
+		}
+	}
+}
+
+// mtgoDeck is an MTGO .dek file.
+type mtgoDeck struct {
+	XMLName xml.Name `xml:"Deck"`
+	Cards   []struct {
+		CatID     string `xml:"CatID,attr"`
+		Quantity  int    `xml:"Quantity,attr"`
+		Sideboard bool   `xml:"Sideboard,attr"`
+		Name      string `xml:"Name,attr"`
+	} `xml:"Cards"`
+}
+
+// ParseMTGODek parses an MTGO .dek deck file.
+// Format example:
+//
+//	<?xml version="1.0" encoding="utf-8"?>
+//	<Deck xmlns:xsd="http://www.w3.org/2001/XMLSchema">
+//	  <NetDeckID>0</NetDeckID>
+//	  <PreconstructedDeckID>0</PreconstructedDeckID>
+//	  <Cards CatID="78894" Quantity="4" Sideboard="false" Name="Lightning Bolt" />
+//	  <Cards CatID="80006" Quantity="2" Sideboard="true" Name="Duress" />
+//	</Deck>
+//
+// CatID is an MTGO catalog ID, so cards are resolved by name.
+func (p *Parser) ParseMTGODek(input string) (*ParseResult, error) {
+	var deck mtgoDeck
+	if err := xml.Unmarshal([]byte(input), &deck); err != nil {
+		return nil, fmt.Errorf("failed to parse MTGO deck: %w", err)
+	}
+
+	result := newParseResult()
+	for i, card := range deck.Cards {
+		name := strings.TrimSpace(card.Name)
+		if name == "" || card.Quantity <= 0 {
+			result.Deck.Warnings = append(result.Deck.Warnings,
+				fmt.Sprintf("Card %d: Missing name or quantity", i+1))
+			continue
+		}
+
+		board := "main"
+		if card.Sideboard {
+			board = "sideboard"
+		}
+		p.addCard(result, &ParsedCard{
+			Quantity: card.Quantity,
+			Name:     name,
+			Board:    board,
+		}, 0)
+	}
+
+	finishParse(result)
+	return result, nil
+}
+
+// cockatriceDeck is a Cockatrice .cod file.
+type cockatriceDeck struct {
+	XMLName xml.Name `xml:"cockatrice_deck"`
+	Name    string   `xml:"deckname"`
+	Zones   []struct {
+		Name  string `xml:"name,attr"`
+		Cards []struct {
+			Number  int    `xml:"number,attr"`
+			Name    string `xml:"name,attr"`
+			SetCode string `xml:"setShortName,attr"`
+		} `xml:"card"`
+	} `xml:"zone"`
+}
+
+// ParseCockatrice parses a Cockatrice .cod deck file.
+// Format example:
+//
+//	<?xml version="1.0" encoding="UTF-8"?>
+//	<cockatrice_deck version="1">
+//	    <deckname>Mono Red</deckname>
+//	    <zone name="main">
+//	        <card number="4" name="Lightning Bolt"/>
+//	    </zone>
+//	    <zone name="side">
+//	        <card number="2" name="Duress"/>
+//	    </zone>
+//	</cockatrice_deck>
+//
+// The tokens zone is ignored.
+func (p *Parser) ParseCockatrice(input string) (*ParseResult, error) {
+	var deck cockatriceDeck
+	if err := xml.Unmarshal([]byte(input), &deck); err != nil {
+		return nil, fmt.Errorf("failed to parse Cockatrice deck: %w", err)
+	}
+
+	result := newParseResult()
+	result.Deck.Name = strings.TrimSpace(deck.Name)
+
+	for _, zone := range deck.Zones {
+		var board string
+		switch zone.Name {
+		case "main":
+			board = "main"
+		case "side":
+			board = "sideboard"
+		case "tokens":
+			continue
+		default:
+			result.Deck.Warnings = append(result.Deck.Warnings,
+				fmt.Sprintf("Skipping unknown zone '%s'", zone.Name))
+			continue
+		}
+
+		for _, card := range zone.Cards {
+			name := strings.TrimSpace(card.Name)
+			if name == "" || card.Number <= 0 {
+				result.Deck.Warnings = append(result.Deck.Warnings,
+					fmt.Sprintf("Zone %s: Missing card name or number", zone.Name))
+				continue
+			}
+			p.addCard(result, &ParsedCard{
+				Quantity: card.Number,
+				Name:     name,
+				SetCode:  strings.ToUpper(card.SetCode),
+				Board:    board,
+			}, 0)
+		}
+	}
+
+	finishParse(result)
+	return result, nil
+}
+
+// forgeSectionRegex matches a Forge section header such as "[Main]".
+var forgeSectionRegex = regexp.MustCompile(`^\[([A-Za-z]+)\]$`)
+
+// forgeCardRegex matches a Forge card line: "4 Lightning Bolt|M21|1".
+// Group 1: quantity, Group 2: card name, Group 3: set code (optional)
+var forgeCardRegex = regexp.MustCompile(`^(\d+)\s+([^|]+?)(?:\|([^|]*))?(?:\|.*)?$`)
+
+// isForgeDeck reports whether the input has Forge section headers.
+func isForgeDeck(input string) bool {
+	for _, line := range strings.Split(input, "\n") {
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "[metadata]", "[main]":
+			return true
+		}
+	}
+	return false
+}
+
+// ParseForge parses a Forge .dck deck file.
+// Format example:
+//
+//	[metadata]
+//	Name=Mono Red
+//	[Main]
+//	4 Lightning Bolt|M21
+//	20 Mountain|M21|1
+//	[Sideboard]
+//	2 Duress|M21
+//
+// Commanders are added to the mainboard; other sections are skipped.
+func (p *Parser) ParseForge(input string) (*ParseResult, error) {
+	result := newParseResult()
+	lines := strings.Split(input, "\n")
+	section := ""
+
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if matches := forgeSectionRegex.FindStringSubmatch(line); matches != nil {
+			section = strings.ToLower(matches[1])
+			switch section {
+			case "metadata", "main", "sideboard", "commander":
+			default:
+				result.Deck.Warnings = append(result.Deck.Warnings,
+					fmt.Sprintf("Line %d: Skipping section '%s'", i+1, line))
+			}
+			continue
+		}
+
+		var board string
+		switch section {
+		case "metadata":
+			if key, value, ok := strings.Cut(line, "="); ok && strings.EqualFold(strings.TrimSpace(key), "name") {
+				result.Deck.Name = strings.TrimSpace(value)
+			}
+			continue
+		case "main", "commander":
+			board = "main"
+		case "sideboard":
+			board = "sideboard"
+		default:
+			continue
+		}
+
+		matches := forgeCardRegex.FindStringSubmatch(line)
+		if matches == nil {
+			result.Deck.Warnings = append(result.Deck.Warnings,
+				fmt.Sprintf("Line %d: Could not parse '%s'", i+1, line))
+			continue
+		}
+
+		quantity, err := strconv.Atoi(matches[1])
+		if err != nil {
+			result.Deck.Errors = append(result.Deck.Errors,
+				fmt.Sprintf("Line %d: Invalid quantity '%s'", i+1, matches[1]))
+			result.Deck.ParsedOK = false
+			continue
+		}
+
+		p.addCard(result, &ParsedCard{
+			Quantity: quantity,
+			Name:     strings.TrimSpace(matches[2]),
+			SetCode:  strings.ToUpper(strings.TrimSpace(matches[3])),
+			Board:    board,
+		}, i+1)
+	}
+
+	finishParse(result)
+	return result, nil
+}
+
+// finishParse marks a result failed if no cards were found.
+func finishParse(result *ParseResult) {
+	if len(result.Deck.Mainboard) == 0 && len(result.Deck.Sideboard) == 0 {
+		result.Deck.ParsedOK = false
+		result.Deck.Errors = append(result.Deck.Errors, "No cards found in import")
+	}
+}
@@ -0,0 +1,208 @@
+package deckimport
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+)
+
+// fakeCardLookup matches names by case-insensitive substring, like SearchCards.
+type fakeCardLookup struct {
+	cards []*models.SetCard
+}
+
+func (f *fakeCardLookup) SearchCards(ctx context.Context, query string, setCodes []string, limit int) ([]*models.SetCard, error) {
+	var matches []*models.SetCard
+	for _, card := range f.cards {
+		if strings.Contains(strings.ToLower(card.Name), strings.ToLower(query)) {
+			matches = append(matches, card)
+		}
+	}
+	return matches, nil
+}
+
+func testLookup() *fakeCardLookup {
+	return &fakeCardLookup{cards: []*models.SetCard{
+		{ArenaID: "1001", SetCode: "M21", Name: "Lightning Bolt"},
+		{ArenaID: "2001", SetCode: "STA", Name: "Lightning Bolt"},
+		{ArenaID: "1002", SetCode: "M21", Name: "Lightning Helix"},
+		{ArenaID: "1003", SetCode: "M21", Name: "Duress"},
+		{ArenaID: "1004", SetCode: "M21", Name: "Mountain"},
+		{ArenaID: "1005", SetCode: "STX", Name: "Fire // Ice"},
+		{ArenaID: "", SetCode: "LEA", Name: "Black Lotus"},
+	}}
+}
+
+const testMTGODek = `<?xml version="1.0" encoding="utf-8"?>
+<Deck xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <NetDeckID>0</NetDeckID>
+  <PreconstructedDeckID>0</PreconstructedDeckID>
+  <Cards CatID="78894" Quantity="4" Sideboard="false" Name="Lightning Bolt" Annotation="0" />
+  <Cards CatID="80102" Quantity="20" Sideboard="false" Name="Mountain" Annotation="0" />
+  <Cards CatID="80006" Quantity="2" Sideboard="true" Name="Duress" Annotation="0" />
+</Deck>`
+
+const testCockatrice = `<?xml version="1.0" encoding="UTF-8"?>
+<cockatrice_deck version="1">
+    <deckname>Mono Red</deckname>
+    <comments></comments>
+    <zone name="main">
+        <card number="4" name="Lightning Bolt" setShortName="sta"/>
+        <card number="20" name="Mountain"/>
+    </zone>
+    <zone name="side">
+        <card number="2" name="Duress"/>
+    </zone>
+    <zone name="tokens">
+        <card number="1" name="Goblin"/>
+    </zone>
+</cockatrice_deck>`
+
+const testForge = `[metadata]
+Name=Mono Red
+[Main]
+4 Lightning Bolt|M21
+20 Mountain|M21|1
+[Sideboard]
+2 Duress|M21
+[Planes]
+1 Some Plane`
+
+func TestParse_StructuredFormats(t *testing.T) {
+	parser := NewParser(testLookup())
+
+	tests := []struct {
+		name          string
+		input         string
+		wantName      string
+		wantMainboard int
+		wantSideboard int
+	}{
+		{name: "MTGO dek", input: testMTGODek, wantMainboard: 2, wantSideboard: 1},
+		{name: "Cockatrice cod", input: testCockatrice, wantName: "Mono Red", wantMainboard: 2, wantSideboard: 1},
+		{name: "Forge dck", input: testForge, wantName: "Mono Red", wantMainboard: 2, wantSideboard: 1},
+		{name: "Moxfield JSON", input: testMoxfieldJSON, wantName: "Mono Red", wantMainboard: 2, wantSideboard: 1},
+		{name: "Archidekt JSON", input: testArchidektJSON, wantName: "Mono Red", wantMainboard: 2, wantSideboard: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parser.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if result.Deck.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", result.Deck.Name, tt.wantName)
+			}
+			if len(result.Deck.Mainboard) != tt.wantMainboard {
+				t.Errorf("mainboard cards = %d, want %d", len(result.Deck.Mainboard), tt.wantMainboard)
+			}
+			if len(result.Deck.Sideboard) != tt.wantSideboard {
+				t.Errorf("sideboard cards = %d, want %d", len(result.Deck.Sideboard), tt.wantSideboard)
+			}
+			if result.CardIDs["Lightning Bolt"] == 0 || result.CardIDs["Mountain"] != 1004 || result.CardIDs["Duress"] != 1003 {
+				t.Errorf("unexpected card IDs %v", result.CardIDs)
+			}
+			if len(result.Unresolved) != 0 {
+				t.Errorf("unexpected unresolved cards %+v", result.Unresolved)
+			}
+		})
+	}
+}
+
+func TestParse_StructuredFormatErrors(t *testing.T) {
+	parser := NewParser(nil)
+
+	inputs := map[string]string{
+		"malformed MTGO":      `<Deck><Cards Quantity="four" Name="Lightning Bolt" /></Deck>`,
+		"empty Cockatrice":    `<cockatrice_deck version="1"><zone name="main"></zone></cockatrice_deck>`,
+		"unknown JSON":        `{"deck": []}`,
+		"empty Forge":         "[metadata]\nName=Nothing\n[Main]",
+		"truncated Archidekt": `{"name": "Mono Red", "cards": [`,
+	}
+
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parser.Parse(input); err == nil {
+				t.Error("Parse() should fail")
+			}
+		})
+	}
+}
+
+func TestParseForge_Sections(t *testing.T) {
+	parser := NewParser(nil)
+
+	result, err := parser.ParseForge(testForge)
+	if err != nil {
+		t.Fatalf("ParseForge() error = %v", err)
+	}
+
+	bolt := result.Deck.Mainboard[0]
+	if bolt.Name != "Lightning Bolt" || bolt.Quantity != 4 || bolt.SetCode != "M21" {
+		t.Errorf("unexpected card %+v", bolt)
+	}
+	if len(result.Deck.Warnings) != 1 || !strings.Contains(result.Deck.Warnings[0], "[Planes]") {
+		t.Errorf("expected a warning for the skipped section, got %v", result.Deck.Warnings)
+	}
+}
+
+func TestResolveCardID(t *testing.T) {
+	parser := NewParser(testLookup())
+
+	tests := []struct {
+		name        string
+		cardName    string
+		setCode     string
+		wantID      int
+		wantMatched string
+	}{
+		{name: "exact", cardName: "Lightning Bolt", wantID: 1001, wantMatched: "Lightning Bolt"},
+		{name: "case insensitive", cardName: "lightning bolt", wantID: 1001, wantMatched: "Lightning Bolt"},
+		{name: "preferred printing", cardName: "Lightning Bolt", setCode: "sta", wantID: 2001, wantMatched: "Lightning Bolt"},
+		{name: "split card", cardName: "Fire/Ice", wantID: 1005, wantMatched: "Fire // Ice"},
+		{name: "front face", cardName: "Fire", wantID: 1005, wantMatched: "Fire // Ice"},
+		{name: "misspelled", cardName: "Lightening Bolt", wantID: 1001, wantMatched: "Lightning Bolt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, matched, err := parser.resolveCardID(tt.cardName, tt.setCode)
+			if err != nil {
+				t.Fatalf("resolveCardID() error = %v", err)
+			}
+			if id != tt.wantID || matched != tt.wantMatched {
+				t.Errorf("resolveCardID() = %d %q, want %d %q", id, matched, tt.wantID, tt.wantMatched)
+			}
+		})
+	}
+}
+
+func TestParse_UnresolvedSuggestions(t *testing.T) {
+	parser := NewParser(testLookup())
+
+	result, err := parser.Parse("4 Lightning Bolt\n2 Lightnin Helx\n1 Black Lotus")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(result.Unresolved) != 2 {
+		t.Fatalf("got %d unresolved cards, want 2: %+v", len(result.Unresolved), result.Unresolved)
+	}
+	helix := result.Unresolved[0]
+	if helix.Name != "Lightnin Helx" || helix.Line != 2 || helix.Quantity != 2 || helix.Board != "main" {
+		t.Errorf("unexpected unresolved card %+v", helix)
+	}
+	if len(helix.Suggestions) == 0 || helix.Suggestions[0] != "Lightning Helix" {
+		t.Errorf("expected Lightning Helix to be suggested, got %v", helix.Suggestions)
+	}
+	// Cards with no Arena printing are never matched
+	if lotus := result.Unresolved[1]; lotus.Name != "Black Lotus" || len(lotus.Suggestions) != 0 {
+		t.Errorf("unexpected unresolved card %+v", lotus)
+	}
+	if _, ok := result.CardIDs["Black Lotus"]; ok {
+		t.Error("Black Lotus should not resolve")
+	}
+}
@@ -0,0 +1,191 @@
+package deckimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// moxfieldCard is one card entry of a Moxfield board.
+type moxfieldCard struct {
+	Quantity int `json:"quantity"`
+	Card     struct {
+		Name string `json:"name"`
+		Set  string `json:"set"`
+	} `json:"card"`
+}
+
+// moxfieldDeck is a deck from the Moxfield API. Version 2 lists boards as
+// top-level maps; version 3 nests them under boards.
+type moxfieldDeck struct {
+	Name       string                  `json:"name"`
+	Format     string                  `json:"format"`
+	Mainboard  map[string]moxfieldCard `json:"mainboard"`
+	Sideboard  map[string]moxfieldCard `json:"sideboard"`
+	Commanders map[string]moxfieldCard `json:"commanders"`
+	Companions map[string]moxfieldCard `json:"companions"`
+	Boards     map[string]struct {
+		Cards map[string]moxfieldCard `json:"cards"`
+	} `json:"boards"`
+}
+
+// moxfieldBoards maps Moxfield boards to ours. Boards not listed, such as
+// the maybeboard, are not imported.
+var moxfieldBoards = map[string]string{
+	"mainboard":  "main",
+	"commanders": "main",
+	"sideboard":  "sideboard",
+	"companions": "sideboard",
+}
+
+// archidektDeck is a deck from the Archidekt API.
+type archidektDeck struct {
+	Name  string `json:"name"`
+	Cards []struct {
+		Quantity   int      `json:"quantity"`
+		Categories []string `json:"categories"`
+		Card       struct {
+			OracleCard struct {
+				Name string `json:"name"`
+			} `json:"oracleCard"`
+			Edition struct {
+				EditionCode string `json:"editioncode"`
+			} `json:"edition"`
+		} `json:"card"`
+	} `json:"cards"`
+}
+
+// ParseJSON parses a deck exported as JSON by Moxfield or Archidekt.
+func (p *Parser) ParseJSON(input string) (*ParseResult, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(input), &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse deck JSON: %w", err)
+	}
+
+	switch {
+	case fields["boards"] != nil || fields["mainboard"] != nil:
+		return p.ParseMoxfieldJSON(input)
+	case fields["cards"] != nil:
+		return p.ParseArchidektJSON(input)
+	}
+	return nil, fmt.Errorf("unrecognized deck JSON")
+}
+
+// ParseMoxfieldJSON parses a deck from the Moxfield API.
+// Format example (version 3):
+//
+//	{"name": "Mono Red", "format": "standard", "boards": {
+//	  "mainboard": {"cards": {"abc": {"quantity": 4, "card": {"name": "Lightning Bolt", "set": "m21"}}}},
+//	  "sideboard": {"cards": {"def": {"quantity": 2, "card": {"name": "Duress", "set": "m21"}}}}}}
+func (p *Parser) ParseMoxfieldJSON(input string) (*ParseResult, error) {
+	var deck moxfieldDeck
+	if err := json.Unmarshal([]byte(input), &deck); err != nil {
+		return nil, fmt.Errorf("failed to parse Moxfield deck: %w", err)
+	}
+
+	boards := map[string]map[string]moxfieldCard{
+		"mainboard":  deck.Mainboard,
+		"sideboard":  deck.Sideboard,
+		"commanders": deck.Commanders,
+		"companions": deck.Companions,
+	}
+	for name, board := range deck.Boards {
+		boards[name] = board.Cards
+	}
+
+	result := newParseResult()
+	result.Deck.Name = deck.Name
+	result.Deck.Format = deck.Format
+
+	for _, boardName := range []string{"commanders", "mainboard", "companions", "sideboard"} {
+		entries := boards[boardName]
+		// Maps are unordered; keep the import stable by card name
+		keys := make([]string, 0, len(entries))
+		for key := range entries {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return moxfieldName(keys[i], entries[keys[i]]) < moxfieldName(keys[j], entries[keys[j]])
+		})
+
+		for _, key := range keys {
+			entry := entries[key]
+			name := moxfieldName(key, entry)
+			if name == "" || entry.Quantity <= 0 {
+				result.Deck.Warnings = append(result.Deck.Warnings,
+					fmt.Sprintf("Board %s: Missing card name or quantity", boardName))
+				continue
+			}
+			p.addCard(result, &ParsedCard{
+				Quantity: entry.Quantity,
+				Name:     name,
+				SetCode:  strings.ToUpper(entry.Card.Set),
+				Board:    moxfieldBoards[boardName],
+			}, 0)
+		}
+	}
+
+	finishParse(result)
+	return result, nil
+}
+
+// moxfieldName returns a card's name; version 2 boards are keyed by name.
+func moxfieldName(key string, entry moxfieldCard) string {
+	if entry.Card.Name != "" {
+		return entry.Card.Name
+	}
+	return key
+}
+
+// ParseArchidektJSON parses a deck from the Archidekt API.
+// Format example:
+//
+//	{"name": "Mono Red", "cards": [
+//	  {"quantity": 4, "categories": ["Instant"], "card": {"oracleCard": {"name": "Lightning Bolt"}, "edition": {"editioncode": "m21"}}},
+//	  {"quantity": 2, "categories": ["Sideboard"], "card": {"oracleCard": {"name": "Duress"}, "edition": {"editioncode": "m21"}}}]}
+//
+// Cards in the Sideboard category go to the sideboard and cards in the
+// Maybeboard are skipped; every other category is mainboard.
+func (p *Parser) ParseArchidektJSON(input string) (*ParseResult, error) {
+	var deck archidektDeck
+	if err := json.Unmarshal([]byte(input), &deck); err != nil {
+		return nil, fmt.Errorf("failed to parse Archidekt deck: %w", err)
+	}
+
+	result := newParseResult()
+	result.Deck.Name = deck.Name
+
+	for i, entry := range deck.Cards {
+		name := strings.TrimSpace(entry.Card.OracleCard.Name)
+		if name == "" || entry.Quantity <= 0 {
+			result.Deck.Warnings = append(result.Deck.Warnings,
+				fmt.Sprintf("Card %d: Missing name or quantity", i+1))
+			continue
+		}
+
+		board := "main"
+		skip := false
+		for _, category := range entry.Categories {
+			switch strings.ToLower(category) {
+			case "sideboard":
+				board = "sideboard"
+			case "maybeboard":
+				skip = true
+			}
+		}
+		if skip {
+			continue
+		}
+
+		p.addCard(result, &ParsedCard{
+			Quantity: entry.Quantity,
+			Name:     name,
+			SetCode:  strings.ToUpper(entry.Card.Edition.EditionCode),
+			Board:    board,
+		}, 0)
+	}
+
+	finishParse(result)
+	return result, nil
+}
@@ -1,12 +1,13 @@
 package deckimport
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 
-	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 )
 
 // ParsedCard represents a single card in a deck import.
@@ -28,34 +29,81 @@ type ParsedDeck struct {
 	Warnings  []string
 }
 
+// CardLookup searches the card database by name.
+// repository.SetCardRepository satisfies it.
+type CardLookup interface {
+	SearchCards(ctx context.Context, query string, setCodes []string, limit int) ([]*models.SetCard, error)
+}
+
 // Parser handles deck import parsing from various formats.
 type Parser struct {
-	cardService *cards.Service
+	cardLookup CardLookup
 }
 
 // NewParser creates a new deck import parser.
-func NewParser(cardService *cards.Service) *Parser {
+// Card names are not resolved to IDs when cardLookup is nil.
+func NewParser(cardLookup CardLookup) *Parser {
 	return &Parser{
-		cardService: cardService,
+		cardLookup: cardLookup,
 	}
 }
 
+// UnresolvedCard is an imported card whose name matched no card in the database.
+type UnresolvedCard struct {
+	Line        int      `json:"line,omitempty"` // Line of the import text, when the format is line based
+	Name        string   `json:"name"`
+	Quantity    int      `json:"quantity"`
+	Board       string   `json:"board"`
+	Suggestions []string `json:"suggestions,omitempty"` // Closest card names, best first
+}
+
 // ParseResult contains the result of parsing a deck import.
 type ParseResult struct {
-	Deck     *ParsedDeck
-	CardIDs  map[string]int // Map of card name to card ID for validation
-	Errors   []error
-	Warnings []string
+	Deck       *ParsedDeck
+	CardIDs    map[string]int // Map of card name to card ID for validation
+	Unresolved []*UnresolvedCard
+	Errors     []error
+	Warnings   []string
+}
+
+// newParseResult creates an empty result for a parser to fill in.
+func newParseResult() *ParseResult {
+	return &ParseResult{
+		Deck: &ParsedDeck{
+			Mainboard: make([]*ParsedCard, 0),
+			Sideboard: make([]*ParsedCard, 0),
+			ParsedOK:  true,
+			Errors:    make([]string, 0),
+			Warnings:  make([]string, 0),
+		},
+		CardIDs:    make(map[string]int),
+		Unresolved: make([]*UnresolvedCard, 0),
+		Errors:     make([]error, 0),
+		Warnings:   make([]string, 0),
+	}
 }
 
 // Parse attempts to parse deck import text from multiple formats.
-// It tries Arena format first, then falls back to plain text.
+// Structured formats (MTGO .dek, Cockatrice .cod, Forge .dck, Moxfield and
+// Archidekt JSON) are recognized by their markup; anything else is tried as
+// Arena format, then plain text.
 func (p *Parser) Parse(input string) (*ParseResult, error) {
 	input = strings.TrimSpace(input)
 	if input == "" {
 		return nil, fmt.Errorf("empty import string")
 	}
 
+	if parse, format := p.structuredParser(input); parse != nil {
+		result, err := parse(input)
+		if err != nil {
+			return nil, err
+		}
+		if !result.Deck.ParsedOK {
+			return nil, fmt.Errorf("unable to parse %s deck: %s", format, strings.Join(result.Deck.Errors, "; "))
+		}
+		return result, nil
+	}
+
 	// Try Arena format first
 	if result, err := p.ParseArenaFormat(input); err == nil && result.Deck.ParsedOK {
 		return result, nil
@@ -80,18 +128,7 @@ func (p *Parser) Parse(input string) (*ParseResult, error) {
 //
 // The empty line separates mainboard from sideboard.
 func (p *Parser) ParseArenaFormat(input string) (*ParseResult, error) {
-	result := &ParseResult{
-		Deck: &ParsedDeck{
-			Mainboard: make([]*ParsedCard, 0),
-			Sideboard: make([]*ParsedCard, 0),
-			ParsedOK:  true,
-			Errors:    make([]string, 0),
-			Warnings:  make([]string, 0),
-		},
-		CardIDs:  make(map[string]int),
-		Errors:   make([]error, 0),
-		Warnings: make([]string, 0),
-	}
+	result := newParseResult()
 
 	lines := strings.Split(input, "\n")
 	board := "main"
@@ -140,28 +177,12 @@ func (p *Parser) ParseArenaFormat(input string) (*ParseResult, error) {
 			setCode = matches[3]
 		}
 
-		parsedCard := &ParsedCard{
+		p.addCard(result, &ParsedCard{
 			Quantity: quantity,
 			Name:     cardName,
 			SetCode:  setCode,
 			Board:    board,
-		}
-
-		if board == "main" {
-			result.Deck.Mainboard = append(result.Deck.Mainboard, parsedCard)
-		} else {
-			result.Deck.Sideboard = append(result.Deck.Sideboard, parsedCard)
-		}
-
-		// Try to resolve card name to ID
-		if p.cardService != nil {
-			if cardID, err := p.resolveCardID(cardName, setCode); err == nil {
-				result.CardIDs[cardName] = cardID
-			} else {
-				result.Warnings = append(result.Warnings,
-					fmt.Sprintf("Card '%s' not found in database", cardName))
-			}
-		}
+		}, i+1)
 	}
 
 	if len(result.Deck.Mainboard) == 0 && len(result.Deck.Sideboard) == 0 {
@@ -178,18 +199,7 @@ func (p *Parser) ParseArenaFormat(input string) (*ParseResult, error) {
 //   - "4x Lightning Bolt"
 //   - "Lightning Bolt x4"
 func (p *Parser) ParsePlainText(input string) (*ParseResult, error) {
-	result := &ParseResult{
-		Deck: &ParsedDeck{
-			Mainboard: make([]*ParsedCard, 0),
-			Sideboard: make([]*ParsedCard, 0),
-			ParsedOK:  true,
-			Errors:    make([]string, 0),
-			Warnings:  make([]string, 0),
-		},
-		CardIDs:  make(map[string]int),
-		Errors:   make([]error, 0),
-		Warnings: make([]string, 0),
-	}
+	result := newParseResult()
 
 	lines := strings.Split(input, "\n")
 	board := "main"
@@ -246,27 +256,11 @@ func (p *Parser) ParsePlainText(input string) (*ParseResult, error) {
 			continue
 		}
 
-		parsedCard := &ParsedCard{
+		p.addCard(result, &ParsedCard{
 			Quantity: quantity,
 			Name:     cardName,
 			Board:    board,
-		}
-
-		if board == "main" {
-			result.Deck.Mainboard = append(result.Deck.Mainboard, parsedCard)
-		} else {
-			result.Deck.Sideboard = append(result.Deck.Sideboard, parsedCard)
-		}
-
-		// Try to resolve card name to ID
-		if p.cardService != nil {
-			if cardID, err := p.resolveCardID(cardName, ""); err == nil {
-				result.CardIDs[cardName] = cardID
-			} else {
-				result.Warnings = append(result.Warnings,
-					fmt.Sprintf("Card '%s' not found in database", cardName))
-			}
-		}
+		}, i+1)
 	}
 
 	if len(result.Deck.Mainboard) == 0 && len(result.Deck.Sideboard) == 0 {
@@ -277,15 +271,49 @@ func (p *Parser) ParsePlainText(input string) (*ParseResult, error) {
 	return result, nil
 }
 
-// resolveCardID attempts to find the card ID for a given card name.
-func (p *Parser) resolveCardID(cardName, setCode string) (int, error) {
-	if p.cardService == nil {
-		return 0, fmt.Errorf("card service not available")
+// addCard adds a parsed card to its board and resolves its name to a card ID.
+// line is the card's line in the import text, or 0 for formats without lines.
+func (p *Parser) addCard(result *ParseResult, card *ParsedCard, line int) {
+	if card.Board == "main" {
+		result.Deck.Mainboard = append(result.Deck.Mainboard, card)
+	} else {
+		result.Deck.Sideboard = append(result.Deck.Sideboard, card)
+	}
+
+	if p.cardLookup == nil {
+		return
+	}
+	if _, ok := result.CardIDs[card.Name]; ok {
+		return
+	}
+
+	cardID, matchedName, err := p.resolveCardID(card.Name, card.SetCode)
+	if err == nil {
+		result.CardIDs[card.Name] = cardID
+		if !strings.EqualFold(matchedName, card.Name) {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("Card '%s' matched to '%s'", card.Name, matchedName))
+		}
+		return
 	}
 
-	// TODO: Implement card lookup by name
-	// For now, return an error - this will be implemented when we integrate with card service
-	return 0, fmt.Errorf("card lookup not yet implemented")
+	unresolved := &UnresolvedCard{
+		Line:     line,
+		Name:     card.Name,
+		Quantity: card.Quantity,
+		Board:    card.Board,
+	}
+	warning := fmt.Sprintf("Card '%s' not found in database", card.Name)
+	if notFound, ok := err.(*cardNotFoundError); ok {
+		unresolved.Suggestions = notFound.suggestions
+		if len(notFound.suggestions) > 0 {
+			warning += fmt.Sprintf(" (did you mean %s?)", strings.Join(notFound.suggestions, ", "))
+		}
+	} else {
+		warning = fmt.Sprintf("Failed to look up card '%s': %v", card.Name, err)
+	}
+	result.Unresolved = append(result.Unresolved, unresolved)
+	result.Warnings = append(result.Warnings, warning)
 }
 
 // ValidateDraftImport validates that all cards in the parsed deck exist in the draft pool.
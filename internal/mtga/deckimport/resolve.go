@@ -0,0 +1,161 @@
+package deckimport
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/fuzzy"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+)
+
+const (
+	// lookupLimit caps the cards fetched per name search.
+	lookupLimit = 100
+
+	// fuzzyMatchScore is the fuzzy score at which a misspelled name is taken
+	// as the card it resembles; one typo in a ten letter name scores 90.
+	fuzzyMatchScore = 90
+
+	// suggestionScore is the lowest fuzzy score offered as a suggestion.
+	suggestionScore = 60
+
+	// maxSuggestions caps the suggestions reported for an unresolved name.
+	maxSuggestions = 3
+
+	// maxFuzzyWords caps the name searches made to gather fuzzy candidates.
+	maxFuzzyWords = 3
+)
+
+// cardNotFoundError reports a card name with no match, with the closest names.
+type cardNotFoundError struct {
+	name        string
+	suggestions []string
+}
+
+func (e *cardNotFoundError) Error() string {
+	return fmt.Sprintf("card '%s' not found", e.name)
+}
+
+// resolveCardID attempts to find the card ID for a given card name.
+// An exact name match wins, preferring the printing from setCode; the front
+// face of a double-faced or split card also matches. Otherwise the name is
+// compared to similarly named cards with fuzzy.Search and the closest is used
+// if it is near enough. It returns the ID and the name of the card matched;
+// when nothing matches the error is a *cardNotFoundError with suggestions.
+func (p *Parser) resolveCardID(cardName, setCode string) (int, string, error) {
+	if p.cardLookup == nil {
+		return 0, "", fmt.Errorf("card lookup not available")
+	}
+	ctx := context.Background()
+	wanted := normalizeCardName(cardName)
+
+	candidates, err := p.cardLookup.SearchCards(ctx, frontFace(cardName), nil, lookupLimit)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to search cards: %w", err)
+	}
+	if card := bestPrinting(candidates, setCode, func(name string) bool {
+		normalized := normalizeCardName(name)
+		return normalized == wanted || normalizeCardName(frontFace(name)) == wanted
+	}); card != nil {
+		id, _ := strconv.Atoi(card.ArenaID)
+		return id, card.Name, nil
+	}
+
+	// Gather cards sharing a word with the name, since a misspelled name
+	// will not be found by a substring search for the whole name
+	words := strings.Fields(frontFace(cardName))
+	searched := 0
+	for _, word := range words {
+		if searched == maxFuzzyWords {
+			break
+		}
+		if len(word) < 4 {
+			continue
+		}
+		searched++
+		more, err := p.cardLookup.SearchCards(ctx, word, nil, lookupLimit)
+		if err != nil {
+			return 0, "", fmt.Errorf("failed to search cards: %w", err)
+		}
+		candidates = append(candidates, more...)
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, card := range candidates {
+		if arenaID(card) == 0 || seen[card.Name] {
+			continue
+		}
+		seen[card.Name] = true
+		names = append(names, card.Name)
+	}
+
+	matches := fuzzy.Search(cardName, names, fuzzy.SearchOptions{
+		MaxResults: maxSuggestions,
+		MinScore:   suggestionScore,
+	})
+	if len(matches) > 0 && matches[0].Score >= fuzzyMatchScore {
+		matched := matches[0].Item.(string)
+		card := bestPrinting(candidates, setCode, func(name string) bool { return name == matched })
+		return arenaID(card), matched, nil
+	}
+
+	notFound := &cardNotFoundError{name: cardName}
+	for _, match := range matches {
+		notFound.suggestions = append(notFound.suggestions, match.Item.(string))
+	}
+	return 0, "", notFound
+}
+
+// bestPrinting returns the card with an Arena ID whose name matches,
+// preferring the printing from setCode.
+func bestPrinting(candidates []*models.SetCard, setCode string, matches func(name string) bool) *models.SetCard {
+	var best *models.SetCard
+	for _, card := range candidates {
+		if arenaID(card) == 0 || !matches(card.Name) {
+			continue
+		}
+		if setCode != "" && strings.EqualFold(card.SetCode, setCode) {
+			return card
+		}
+		if best == nil {
+			best = card
+		}
+	}
+	return best
+}
+
+// arenaID returns the card's Arena ID, or 0 if it is not on Arena.
+func arenaID(card *models.SetCard) int {
+	if card == nil {
+		return 0
+	}
+	id, err := strconv.Atoi(card.ArenaID)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// frontFace returns the first face of a split or double-faced card name,
+// written either "Fire // Ice" or "Fire/Ice".
+func frontFace(name string) string {
+	if i := strings.Index(name, "/"); i >= 0 {
+		name = name[:i]
+	}
+	return strings.TrimSpace(name)
+}
+
+// normalizeCardName lowercases a name and writes face separators one way.
+func normalizeCardName(name string) string {
+	faces := strings.Split(name, "/")
+	normalized := make([]string, 0, len(faces))
+	for _, face := range faces {
+		if face = strings.TrimSpace(face); face != "" {
+			normalized = append(normalized, strings.ToLower(face))
+		}
+	}
+	return strings.Join(normalized, " // ")
+}
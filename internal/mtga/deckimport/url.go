@@ -0,0 +1,190 @@
+package deckimport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxDeckDownloadSize caps how much of a deck download is read.
+const maxDeckDownloadSize = 5 << 20
+
+// DeckSource maps public deck pages on one site to a download the parser
+// understands.
+type DeckSource interface {
+	// Name identifies the site in errors.
+	Name() string
+
+	// DownloadURL returns where to fetch the deck shown at page, or false
+	// if the page is not a deck from this site.
+	DownloadURL(page *url.URL) (string, bool)
+}
+
+// siteSource is a deck site whose deck pages carry the deck ID in the path.
+type siteSource struct {
+	name     string
+	hosts    []string
+	path     *regexp.Regexp // Group 1 is the deck ID
+	download string         // Download URL with %s for the deck ID
+}
+
+func (s *siteSource) Name() string {
+	return s.name
+}
+
+func (s *siteSource) DownloadURL(page *url.URL) (string, bool) {
+	host := strings.ToLower(page.Hostname())
+	for _, h := range s.hosts {
+		if host != h {
+			continue
+		}
+		if matches := s.path.FindStringSubmatch(page.Path); matches != nil {
+			return fmt.Sprintf(s.download, matches[1]), true
+		}
+	}
+	return "", false
+}
+
+// MoxfieldSource imports moxfield.com deck pages through the Moxfield API at apiBase.
+func MoxfieldSource(apiBase string) DeckSource {
+	return &siteSource{
+		name:     "Moxfield",
+		hosts:    []string{"moxfield.com", "www.moxfield.com"},
+		path:     regexp.MustCompile(`^/decks/([A-Za-z0-9_-]+)/?$`),
+		download: strings.TrimSuffix(apiBase, "/") + "/v3/decks/all/%s",
+	}
+}
+
+// ArchidektSource imports archidekt.com deck pages through the Archidekt API at apiBase.
+func ArchidektSource(apiBase string) DeckSource {
+	return &siteSource{
+		name:     "Archidekt",
+		hosts:    []string{"archidekt.com", "www.archidekt.com"},
+		path:     regexp.MustCompile(`^/decks/(\d+)(?:/.*)?$`),
+		download: strings.TrimSuffix(apiBase, "/") + "/api/decks/%s/",
+	}
+}
+
+// MTGGoldfishSource imports mtggoldfish.com deck pages from the text
+// download at base.
+func MTGGoldfishSource(base string) DeckSource {
+	return &siteSource{
+		name:     "MTGGoldfish",
+		hosts:    []string{"mtggoldfish.com", "www.mtggoldfish.com"},
+		path:     regexp.MustCompile(`^/deck/(\d+)/?$`),
+		download: strings.TrimSuffix(base, "/") + "/deck/download/%s",
+	}
+}
+
+// fileSource imports deck files linked directly, such as a .dek on a forum.
+type fileSource struct{}
+
+// deckFileExtensions are the file types Parse understands.
+var deckFileExtensions = map[string]bool{
+	".txt": true, ".dek": true, ".cod": true, ".dck": true, ".json": true,
+}
+
+func (fileSource) Name() string {
+	return "deck file"
+}
+
+func (fileSource) DownloadURL(page *url.URL) (string, bool) {
+	if !deckFileExtensions[strings.ToLower(path.Ext(page.Path))] {
+		return "", false
+	}
+	return page.String(), true
+}
+
+// DefaultSources returns the deck sites imported from by default.
+func DefaultSources() []DeckSource {
+	return []DeckSource{
+		MoxfieldSource("https://api2.moxfield.com"),
+		ArchidektSource("https://archidekt.com"),
+		MTGGoldfishSource("https://www.mtggoldfish.com"),
+		fileSource{},
+	}
+}
+
+// URLImporter imports decks from public deck pages.
+type URLImporter struct {
+	parser     *Parser
+	httpClient *http.Client
+	sources    []DeckSource
+}
+
+// NewURLImporter creates a URL importer that parses downloads with parser.
+// It uses DefaultSources when no sources are given.
+func NewURLImporter(parser *Parser, sources ...DeckSource) *URLImporter {
+	if len(sources) == 0 {
+		sources = DefaultSources()
+	}
+	return &URLImporter{
+		parser: parser,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		sources: sources,
+	}
+}
+
+// Import fetches the deck shown at a public deck page and parses it.
+func (i *URLImporter) Import(ctx context.Context, rawURL string) (*ParseResult, error) {
+	page, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (page.Scheme != "http" && page.Scheme != "https") || page.Host == "" {
+		return nil, fmt.Errorf("invalid deck URL: %s", rawURL)
+	}
+
+	for _, source := range i.sources {
+		download, ok := source.DownloadURL(page)
+		if !ok {
+			continue
+		}
+
+		content, err := i.fetch(ctx, download)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch deck from %s: %w", source.Name(), err)
+		}
+
+		result, err := i.parser.Parse(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse deck from %s: %w", source.Name(), err)
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("unsupported deck site: %s", page.Host)
+}
+
+// fetch downloads a deck.
+func (i *URLImporter) fetch(ctx context.Context, download string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, download, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "MTGA-Companion/1.0")
+
+	resp, err := i.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download deck: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("deck not found or not public")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDeckDownloadSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read deck: %w", err)
+	}
+	return string(body), nil
+}
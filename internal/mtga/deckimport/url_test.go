@@ -0,0 +1,122 @@
+package deckimport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Responses recorded from the deck sites, trimmed to the fields we read.
+const testMoxfieldJSON = `{
+  "id": "abc123",
+  "name": "Mono Red",
+  "format": "standard",
+  "boards": {
+    "mainboard": {"count": 24, "cards": {
+      "x1": {"quantity": 4, "boardType": "mainboard", "card": {"name": "Lightning Bolt", "set": "sta", "cn": "42"}},
+      "x2": {"quantity": 20, "boardType": "mainboard", "card": {"name": "Mountain", "set": "m21", "cn": "275"}}
+    }},
+    "sideboard": {"count": 2, "cards": {
+      "x3": {"quantity": 2, "boardType": "sideboard", "card": {"name": "Duress", "set": "m21", "cn": "95"}}
+    }},
+    "maybeboard": {"count": 1, "cards": {
+      "x4": {"quantity": 1, "boardType": "maybeboard", "card": {"name": "Lightning Helix", "set": "m21"}}
+    }}
+  }
+}`
+
+const testArchidektJSON = `{
+  "id": 123456,
+  "name": "Mono Red",
+  "deckFormat": 1,
+  "cards": [
+    {"id": 1, "quantity": 4, "categories": ["Instant"], "card": {"oracleCard": {"name": "Lightning Bolt"}, "edition": {"editioncode": "m21"}}},
+    {"id": 2, "quantity": 20, "categories": ["Land"], "card": {"oracleCard": {"name": "Mountain"}, "edition": {"editioncode": "m21"}}},
+    {"id": 3, "quantity": 2, "categories": ["Sideboard"], "card": {"oracleCard": {"name": "Duress"}, "edition": {"editioncode": "m21"}}},
+    {"id": 4, "quantity": 1, "categories": ["Maybeboard"], "card": {"oracleCard": {"name": "Lightning Helix"}, "edition": {"editioncode": "m21"}}}
+  ]
+}`
+
+const testMTGGoldfishText = "4 Lightning Bolt\r\n20 Mountain\r\n\r\n2 Duress\r\n"
+
+func deckSiteServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	responses := map[string]string{
+		"/v3/decks/all/abc123":   testMoxfieldJSON,
+		"/api/decks/123456/":     testArchidektJSON,
+		"/deck/download/6543210": testMTGGoldfishText,
+		"/files/mono-red.dek":    testMTGODek,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") == "" {
+			t.Errorf("request to %s has no User-Agent", r.URL.Path)
+		}
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestURLImporter_Import(t *testing.T) {
+	server := deckSiteServer(t)
+	importer := NewURLImporter(NewParser(testLookup()),
+		MoxfieldSource(server.URL),
+		ArchidektSource(server.URL),
+		MTGGoldfishSource(server.URL),
+		fileSource{},
+	)
+
+	pages := map[string]string{
+		"Moxfield":    "https://www.moxfield.com/decks/abc123",
+		"Archidekt":   "https://archidekt.com/decks/123456/mono_red",
+		"MTGGoldfish": "https://www.mtggoldfish.com/deck/6543210",
+		"deck file":   server.URL + "/files/mono-red.dek",
+	}
+
+	for name, page := range pages {
+		t.Run(name, func(t *testing.T) {
+			result, err := importer.Import(context.Background(), page)
+			if err != nil {
+				t.Fatalf("Import() error = %v", err)
+			}
+			if len(result.Deck.Mainboard) != 2 || len(result.Deck.Sideboard) != 1 {
+				t.Errorf("got %d mainboard and %d sideboard cards, want 2 and 1",
+					len(result.Deck.Mainboard), len(result.Deck.Sideboard))
+			}
+			if result.CardIDs["Lightning Bolt"] == 0 || result.CardIDs["Duress"] != 1003 {
+				t.Errorf("unexpected card IDs %v", result.CardIDs)
+			}
+		})
+	}
+}
+
+func TestURLImporter_Errors(t *testing.T) {
+	server := deckSiteServer(t)
+	importer := NewURLImporter(NewParser(nil), MoxfieldSource(server.URL), fileSource{})
+
+	tests := map[string]struct {
+		url     string
+		wantErr string
+	}{
+		"not a URL":        {url: "moxfield abc123", wantErr: "invalid deck URL"},
+		"unsupported site": {url: "https://example.com/decks/1", wantErr: "unsupported deck site"},
+		"private deck":     {url: "https://moxfield.com/decks/private1", wantErr: "not public"},
+		"missing file":     {url: server.URL + "/files/missing.txt", wantErr: "failed to fetch deck from deck file"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := importer.Import(context.Background(), tt.url)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Import() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,11 +2,13 @@ package analytics
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
 )
 
 // mockMatchRepository is a mock implementation for testing.
@@ -94,6 +96,10 @@ func (m *mockMatchRepository) MarkMatchesAsProcessedForML(ctx context.Context, m
 	return nil
 }
 
+func (m *mockMatchRepository) WithTx(tx *sql.Tx) repository.MatchRepository {
+	return m
+}
+
 func TestArchetypePerformanceAnalyzer_AnalyzeArchetypePerformance(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
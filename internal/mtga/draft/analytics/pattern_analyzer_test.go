@@ -72,6 +72,10 @@ func (m *mockDraftRepository) UpdatePickQuality(ctx context.Context, pickID int,
 	return nil
 }
 
+func (m *mockDraftRepository) UpdatePickScore(ctx context.Context, pickID int, pickScore, packBestScore float64, breakdownJSON string) error {
+	return nil
+}
+
 func (m *mockDraftRepository) SavePack(ctx context.Context, pack *models.DraftPackSession) error {
 	return nil
 }
@@ -96,6 +100,10 @@ func (m *mockDraftRepository) ClearAllSessions(ctx context.Context) (sessionsDel
 	return 0, 0, 0, nil
 }
 
+func (m *mockDraftRepository) DeleteUnfinishedSimulations(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
 func (m *mockDraftRepository) GetSessionCount(ctx context.Context) (int, error) {
 	return len(m.sessions), nil
 }
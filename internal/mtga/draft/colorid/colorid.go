@@ -0,0 +1,21 @@
+// Package colorid normalizes the color strings used by 17Lands ratings and
+// draft decks so packages compare color combinations the same way.
+package colorid
+
+import "strings"
+
+// WUBRG lists the five colors in their conventional order.
+var WUBRG = []string{"W", "U", "B", "R", "G"}
+
+// Key normalizes a color string such as "gw" or "RG" to WUBRG order, dropping
+// anything that is not a color. Colorless cards give "".
+func Key(s string) string {
+	var key strings.Builder
+	upper := strings.ToUpper(s)
+	for _, c := range WUBRG {
+		if strings.Contains(upper, c) {
+			key.WriteString(c)
+		}
+	}
+	return key.String()
+}
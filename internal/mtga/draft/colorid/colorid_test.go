@@ -0,0 +1,21 @@
+package colorid
+
+import "testing"
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"C", ""},
+		{"gw", "WG"},
+		{"RG", "RG"},
+		{"GRUBW", "WUBRG"},
+		{"R/G", "RG"},
+	}
+	for _, tt := range tests {
+		if got := Key(tt.in); got != tt.want {
+			t.Errorf("Key(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
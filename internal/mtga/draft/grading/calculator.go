@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/pickquality"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
 )
@@ -88,12 +89,28 @@ func (c *Calculator) CalculateGrade(ctx context.Context, sessionID string) (*Dra
 }
 
 // calculatePickQualityScore calculates the pick quality component (0-40).
-// Based on average GIHWR and pick quality grades.
+// Based on how close each pick's context-aware score came to the best card in
+// its pack, falling back to average GIHWR for picks analyzed before context
+// scores existed.
 func (c *Calculator) calculatePickQualityScore(picks []*models.DraftPickSession) float64 {
 	if len(picks) == 0 {
 		return 0
 	}
 
+	// Average points given up versus the best-scoring card in each pack
+	// 0 points = 40, each point given up costs 4
+	gapSum := 0.0
+	gapCount := 0
+	for _, pick := range picks {
+		if pick.PickScore != nil && pick.PackBestScore != nil {
+			gapSum += math.Max(*pick.PackBestScore-*pick.PickScore, 0)
+			gapCount++
+		}
+	}
+	if gapCount > 0 {
+		return math.Max(40-4*gapSum/float64(gapCount), 0)
+	}
+
 	// Count picks with quality grades
 	qualitySum := 0.0
 	qualityCount := 0
@@ -144,16 +161,38 @@ func (c *Calculator) calculatePickQualityScore(picks []*models.DraftPickSession)
 }
 
 // calculateColorDisciplineScore calculates the color discipline component (0-20).
-// Based on staying in strong colors.
+// Based on how much of the context-aware color fit penalty the picks took
+// once the pool had settled into its colors, plus a bonus for drafting into
+// color pairs that beat the format average.
 func (c *Calculator) calculateColorDisciplineScore(ctx context.Context, session *models.DraftSession, picks []*models.DraftPickSession) float64 {
-	// Simple implementation: reward for having color data
-	// In a full implementation, this would:
-	// - Analyze color pair win rates from 17Lands
-	// - Check if player stayed in top-tier colors
-	// - Penalize for switching colors late
-
-	// For now, give a default score
-	return 15.0 // Default to B grade
+	var colorFitSum, archetypeSum float64
+	scored := 0
+	for _, pick := range picks {
+		if pick.ScoreBreakdownJSON == nil {
+			continue
+		}
+		factors, err := pickquality.DeserializeFactors(*pick.ScoreBreakdownJSON)
+		if err != nil {
+			continue
+		}
+		colorFitSum += factors.ColorFit
+		archetypeSum += factors.Archetype
+		scored++
+	}
+
+	if scored == 0 {
+		return 15.0 // Default to B grade without context scores
+	}
+
+	// Color fit is a penalty of up to 6 points per pick; an average of -3
+	// (half the pool off-color after committing) costs the full 16 points
+	avgPenalty := -colorFitSum / float64(scored)
+	score := 16 * (1 - math.Min(avgPenalty/3, 1))
+
+	// Up to 4 points for drafting toward strong archetypes
+	score += math.Max(math.Min(archetypeSum/float64(scored), 4), 0)
+
+	return math.Min(score, 20)
 }
 
 // calculateDeckCompositionScore calculates the deck composition component (0-25).
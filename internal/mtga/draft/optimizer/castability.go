@@ -0,0 +1,114 @@
+package optimizer
+
+import (
+	"strings"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/castability"
+)
+
+// deckSize is the size of a limited deck.
+const deckSize = 40
+
+// cost is a card's mana requirement.
+type cost struct {
+	CMC    int
+	Pips   map[string]int // Colored pips by color
+	Hybrid [][]string     // Hybrid symbols, each payable by any of its colors
+}
+
+// parseManaCost reads a Scryfall-style mana cost such as "{2}{W}{W}" or
+// "{1}{W/U}". Phyrexian symbols are ignored since they can be paid with life.
+func parseManaCost(manaCost string) cost {
+	c := cost{Pips: make(map[string]int)}
+	for _, part := range strings.Split(manaCost, "{") {
+		symbol := strings.TrimSuffix(strings.TrimSpace(part), "}")
+		if symbol == "" {
+			continue
+		}
+		switch {
+		case strings.Contains(symbol, "/P"):
+			continue
+		case strings.Contains(symbol, "/"):
+			var colors []string
+			for _, s := range strings.Split(symbol, "/") {
+				if isColor(s) {
+					colors = append(colors, s)
+				}
+			}
+			if len(colors) > 0 {
+				c.Hybrid = append(c.Hybrid, colors)
+			}
+		case isColor(symbol):
+			c.Pips[symbol]++
+		}
+	}
+	return c
+}
+
+func isColor(s string) bool {
+	return len(s) == 1 && strings.Contains("WUBRG", s)
+}
+
+// requirement resolves hybrid symbols against the deck's colors, preferring
+// the colors listed first, and returns colored pips by color. ok is false when
+// the card cannot be cast from these colors.
+func (c cost) requirement(colors []string) (map[string]int, bool) {
+	pips := make(map[string]int, len(c.Pips))
+	for color, n := range c.Pips {
+		if !containsColor(colors, color) {
+			return nil, false
+		}
+		pips[color] = n
+	}
+	for _, options := range c.Hybrid {
+		paid := false
+		for _, color := range colors {
+			if containsColor(options, color) {
+				pips[color]++
+				paid = true
+				break
+			}
+		}
+		if !paid {
+			return nil, false
+		}
+	}
+	return pips, true
+}
+
+func containsColor(colors []string, color string) bool {
+	for _, c := range colors {
+		if c == color {
+			return true
+		}
+	}
+	return false
+}
+
+// castProbability is the chance of having the lands to cast a spell on curve,
+// on the play, in a 40-card deck with the given basic lands.
+func castProbability(lands map[string]int, cmc int, pips map[string]int) float64 {
+	return castProbabilityBy(lands, cmc, cmc, pips)
+}
+
+// castProbabilityBy is the chance of having the lands to cast a spell by the
+// given turn, on the play, with basics of each color as one land group.
+func castProbabilityBy(lands map[string]int, cmc, turn int, pips map[string]int) float64 {
+	groups := make([]castability.Group, 0, len(lands))
+	for color, n := range lands {
+		if n > 0 {
+			groups = append(groups, castability.Group{Mask: castability.Mask([]string{color}), Count: n})
+		}
+	}
+	return castability.Probability(groups, deckSize, turn, cmc, pips, false)
+}
+
+// landDropProbability is the chance of having at least n lands by turn n on the play.
+func landDropProbability(lands, n int) float64 {
+	return landsByTurn(lands, n, n)
+}
+
+// landsByTurn is the chance of having at least n lands by the given turn on the play.
+func landsByTurn(lands, n, turn int) float64 {
+	return castability.LandProbability(lands, deckSize, turn, n, false)
+}
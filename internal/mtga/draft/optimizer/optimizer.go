@@ -0,0 +1,632 @@
+package optimizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+// Build search tuning. Card values are in GIHWR percentage points.
+const (
+	defaultBuilds     = 3
+	maxBuilds         = 10
+	minLands          = 16
+	maxLands          = 18
+	landDropTarget    = 0.75 // Average chance to hit land drops on curve a land count must reach
+	minSplashLands    = 2
+	maxSplashLands    = 4
+	maxSplashCards    = 3
+	maxSplashPips     = 1    // Splash cards may need at most this many pips of the splash color
+	splashDelay       = 2    // Turns after curve a splash card is expected to be cast
+	valueFloor        = 45.0 // What a card stuck in hand is worth
+	unratedGIHWR      = 50.0 // Stand-in for cards without a 17Lands rating
+	curveOverPenalty  = 1.5  // Per card over a curve slot's target
+	minCreatures      = 14
+	creaturePenalty   = 1.0 // Per creature short of minCreatures
+	castabilityWarned = 0.6 // On-curve castability below which a card is called out
+)
+
+// curveTargets are the ideal spell counts by mana value for 23 spells. The
+// last slot holds everything at six or more.
+var curveTargets = [7]int{0, 1, 6, 5, 4, 3, 2}
+
+var wubrg = []string{"W", "U", "B", "R", "G"}
+
+var colorNames = map[string]string{"W": "White", "U": "Blue", "B": "Black", "R": "Red", "G": "Green"}
+
+var pairNames = map[string]string{
+	"WU": "Azorius", "UB": "Dimir", "BR": "Rakdos", "RG": "Gruul", "WG": "Selesnya",
+	"WB": "Orzhov", "UR": "Izzet", "BG": "Golgari", "WR": "Boros", "UG": "Simic",
+}
+
+var basicNames = map[string]string{"W": "Plains", "U": "Island", "B": "Swamp", "R": "Mountain", "G": "Forest"}
+
+// ErrSessionNotFound is returned when the draft session does not exist.
+var ErrSessionNotFound = errors.New("draft session not found")
+
+// BuildCard is a spell in a build.
+type BuildCard struct {
+	CardID      string  `json:"card_id"`
+	Name        string  `json:"name"`
+	ManaCost    string  `json:"mana_cost"`
+	CMC         int     `json:"cmc"`
+	Colors      string  `json:"colors"`
+	GIHWR       float64 `json:"gihwr"`
+	Rated       bool    `json:"rated"`
+	Creature    bool    `json:"creature"`
+	Splash      bool    `json:"splash"`
+	Castability float64 `json:"castability"` // Chance to cast on curve, on the play
+}
+
+// Build is one 40-card deck from the pool.
+type Build struct {
+	Rank        int            `json:"rank"`
+	Name        string         `json:"name"`
+	Colors      string         `json:"colors"`
+	Splash      string         `json:"splash,omitempty"`
+	Score       float64        `json:"score"`       // Castability-weighted GIHWR less curve penalties
+	AvgGIHWR    float64        `json:"avg_gihwr"`   // Mean GIHWR of the spells
+	Castability float64        `json:"castability"` // Mean on-curve castability of the spells
+	LandCount   int            `json:"land_count"`
+	Lands       map[string]int `json:"lands"` // Basic lands by color
+	Spells      []BuildCard    `json:"spells"`
+	Curve       [7]int         `json:"curve"` // Spells by mana value, 6+ in the last slot
+	Creatures   int            `json:"creatures"`
+	Explanation []string       `json:"explanation"`
+}
+
+// Result is the optimizer's best builds for a draft pool.
+type Result struct {
+	SessionID   string  `json:"session_id"`
+	SetCode     string  `json:"set_code"`
+	DraftFormat string  `json:"draft_format"`
+	PoolSize    int     `json:"pool_size"`
+	Builds      []Build `json:"builds"`
+}
+
+// poolCard is a nonland card from the pool.
+type poolCard struct {
+	BuildCard
+	cost cost
+}
+
+// Optimizer searches a draft pool for its best limited decks.
+type Optimizer struct {
+	draftRepo   repository.DraftRepository
+	setCardRepo repository.SetCardRepository
+	ratingsRepo repository.DraftRatingsRepository
+}
+
+// NewOptimizer creates a new deck optimizer.
+func NewOptimizer(draftRepo repository.DraftRepository, setCardRepo repository.SetCardRepository, ratingsRepo repository.DraftRatingsRepository) *Optimizer {
+	return &Optimizer{
+		draftRepo:   draftRepo,
+		setCardRepo: setCardRepo,
+		ratingsRepo: ratingsRepo,
+	}
+}
+
+// OptimalBuilds returns the top builds for a draft session's pool. It tries
+// every two-color pair with and without a splash, picks spells and basics for
+// each, and ranks them by castability-weighted GIHWR. top defaults to 3.
+func (o *Optimizer) OptimalBuilds(ctx context.Context, sessionID string, top int) (*Result, error) {
+	session, err := o.draftRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	picks, err := o.draftRepo.GetPicksBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get picks: %w", err)
+	}
+
+	ratings := make(map[string]float64)
+	cardRatings, _, err := o.ratingsRepo.GetCardRatings(ctx, session.SetCode, session.EventName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card ratings: %w", err)
+	}
+	for _, r := range cardRatings {
+		if r.MTGAID != 0 && r.GIHWR > 0 {
+			ratings[strconv.Itoa(r.MTGAID)] = r.GIHWR
+		}
+	}
+
+	pool := make([]*poolCard, 0, len(picks))
+	for _, pick := range picks {
+		card, err := o.setCardRepo.GetCardByArenaID(ctx, pick.CardID)
+		if err != nil || card == nil || isLand(card) {
+			continue
+		}
+		pool = append(pool, newPoolCard(card, ratings))
+	}
+
+	return &Result{
+		SessionID:   session.ID,
+		SetCode:     session.SetCode,
+		DraftFormat: session.EventName,
+		PoolSize:    len(picks),
+		Builds:      optimize(pool, top),
+	}, nil
+}
+
+func newPoolCard(card *models.SetCard, ratings map[string]float64) *poolCard {
+	c := &poolCard{
+		BuildCard: BuildCard{
+			CardID:   card.ArenaID,
+			Name:     card.Name,
+			ManaCost: card.ManaCost,
+			CMC:      card.CMC,
+			Creature: containsString(card.Types, "Creature"),
+			GIHWR:    unratedGIHWR,
+		},
+		cost: parseManaCost(card.ManaCost),
+	}
+	if gihwr, ok := ratings[card.ArenaID]; ok {
+		c.GIHWR = gihwr
+		c.Rated = true
+	}
+	// Without a mana cost, fall back to the card's colors
+	if card.ManaCost == "" {
+		for _, color := range card.Colors {
+			if isColor(color) {
+				c.cost.Pips[color]++
+			}
+		}
+	}
+	return c
+}
+
+// candidate is a build being assembled for one color configuration.
+type candidate struct {
+	main   []string
+	splash string
+	cards  []*poolCard
+	pips   map[*poolCard]map[string]int // Resolved colored pips per eligible card
+}
+
+// optimize builds the best deck for every pair and pair-plus-splash and
+// returns the top builds.
+func optimize(pool []*poolCard, top int) []Build {
+	if top <= 0 {
+		top = defaultBuilds
+	}
+	if top > maxBuilds {
+		top = maxBuilds
+	}
+
+	var builds []Build
+	for i := 0; i < len(wubrg); i++ {
+		for j := i + 1; j < len(wubrg); j++ {
+			main := []string{wubrg[i], wubrg[j]}
+			if build, ok := buildDeck(newCandidate(pool, main, "")); ok {
+				builds = append(builds, build)
+			}
+			for _, splash := range wubrg {
+				if containsColor(main, splash) {
+					continue
+				}
+				// A splash that ends up unused is the same deck as the pair
+				if build, ok := buildDeck(newCandidate(pool, main, splash)); ok && build.Splash != "" {
+					builds = append(builds, build)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(builds, func(i, j int) bool { return builds[i].Score > builds[j].Score })
+	if len(builds) > top {
+		builds = builds[:top]
+	}
+	for i := range builds {
+		builds[i].Rank = i + 1
+	}
+	return builds
+}
+
+// newCandidate collects the pool cards castable in a configuration. Splash
+// cards may need only a single pip of the splash color.
+func newCandidate(pool []*poolCard, main []string, splash string) *candidate {
+	colors := append([]string(nil), main...)
+	if splash != "" {
+		colors = append(colors, splash)
+	}
+	c := &candidate{main: main, splash: splash, pips: make(map[*poolCard]map[string]int)}
+	for _, card := range pool {
+		pips, ok := card.cost.requirement(colors)
+		if !ok || (splash != "" && pips[splash] > maxSplashPips) {
+			continue
+		}
+		c.cards = append(c.cards, card)
+		c.pips[card] = pips
+	}
+	return c
+}
+
+// isSplash reports whether a card needs the splash color.
+func (c *candidate) isSplash(card *poolCard) bool {
+	return c.splash != "" && c.pips[card][c.splash] > 0
+}
+
+// buildDeck chooses spells, land count and basics for a candidate. Spell
+// choice and the land split depend on each other, so they are refined in two
+// passes starting from a split by pip count.
+func buildDeck(c *candidate) (Build, bool) {
+	lands := c.initialLands(17)
+	var spells []*poolCard
+	landCount := 17
+	for pass := 0; pass < 2; pass++ {
+		// Land count follows the curve of the spells it would support
+		landCount = maxLands
+		for n := minLands; n <= maxLands; n++ {
+			chosen := c.selectSpells(deckSize-n, c.scaleLands(lands, n))
+			if chosen == nil {
+				continue
+			}
+			if averageLandDrop(chosen, n) >= landDropTarget {
+				landCount = n
+				break
+			}
+		}
+		spells = c.selectSpells(deckSize-landCount, c.scaleLands(lands, landCount))
+		if spells == nil {
+			return Build{}, false
+		}
+		lands = c.splitLands(spells, landCount)
+	}
+	return c.describe(spells, lands, landCount), true
+}
+
+// initialLands splits basics in proportion to the eligible cards' pips.
+func (c *candidate) initialLands(total int) map[string]int {
+	weights := make(map[string]float64)
+	for _, card := range c.cards {
+		for color, n := range c.pips[card] {
+			weights[color] += float64(n)
+		}
+	}
+	for _, color := range c.main {
+		weights[color] += 1 // Keep both main colors represented
+	}
+	lands := make(map[string]int)
+	if c.splash != "" {
+		lands[c.splash] = 3
+		total -= 3
+		delete(weights, c.splash)
+	}
+	sum := weights[c.main[0]] + weights[c.main[1]]
+	first := int(math.Round(float64(total) * weights[c.main[0]] / sum))
+	lands[c.main[0]] = clamp(first, 1, total-1)
+	lands[c.main[1]] = total - lands[c.main[0]]
+	return lands
+}
+
+// scaleLands adjusts a split to a new land count by adding to or taking from
+// the larger main color.
+func (c *candidate) scaleLands(lands map[string]int, total int) map[string]int {
+	scaled := make(map[string]int, len(lands))
+	current := 0
+	for color, n := range lands {
+		scaled[color] = n
+		current += n
+	}
+	largest := c.main[0]
+	if scaled[c.main[1]] > scaled[largest] {
+		largest = c.main[1]
+	}
+	scaled[largest] += total - current
+	return scaled
+}
+
+// castTurn is the turn a card is expected to be cast. Splash cards are
+// held until the splash color shows up.
+func (c *candidate) castTurn(card *poolCard) int {
+	if c.isSplash(card) {
+		return card.CMC + splashDelay
+	}
+	return card.CMC
+}
+
+// value is a card's castability-weighted GIHWR. GIHWR is measured in decks
+// with ordinary two-color mana, so a card is only discounted by how much
+// harder it is to cast here than with an even split of sources.
+func (c *candidate) value(card *poolCard, lands map[string]int) float64 {
+	total := 0
+	for _, n := range lands {
+		total += n
+	}
+	turn := c.castTurn(card)
+	reference := castProbabilityBy(referenceLands(total, c.pips[card]), card.CMC, turn, c.pips[card])
+	if reference == 0 {
+		return valueFloor
+	}
+	colorFit := castProbabilityBy(lands, card.CMC, turn, c.pips[card]) / reference
+	return valueFloor + (card.GIHWR-valueFloor)*math.Min(1, colorFit)
+}
+
+// referenceLands splits lands evenly between a card's colors and another
+// color, the mana a typical two-color deck casts it with.
+func referenceLands(total int, pips map[string]int) map[string]int {
+	colors := pipColors(pips)
+	shares := max(2, len(colors))
+	lands := map[string]int{"C": total}
+	for _, color := range colors {
+		lands[string(color)] = total / shares
+		lands["C"] -= total / shares
+	}
+	return lands
+}
+
+// selectSpells greedily picks the best spells by value, nudged toward the
+// curve targets. It returns nil when the configuration lacks enough playables.
+func (c *candidate) selectSpells(count int, lands map[string]int) []*poolCard {
+	if len(c.cards) < count {
+		return nil
+	}
+	values := make(map[*poolCard]float64, len(c.cards))
+	for _, card := range c.cards {
+		values[card] = c.value(card, lands)
+	}
+
+	var curve [7]int
+	chosen := make([]*poolCard, 0, count)
+	used := make(map[int]bool, count)
+	splashes := 0
+	for len(chosen) < count {
+		best, bestValue := -1, math.Inf(-1)
+		for i, card := range c.cards {
+			if used[i] || (c.isSplash(card) && splashes >= maxSplashCards) {
+				continue
+			}
+			v := values[card]
+			if curve[curveSlot(card.CMC)] >= curveTarget(curveSlot(card.CMC), count) {
+				v -= curveOverPenalty
+			}
+			if v > bestValue {
+				best, bestValue = i, v
+			}
+		}
+		if best == -1 {
+			return nil
+		}
+		card := c.cards[best]
+		used[best] = true
+		curve[curveSlot(card.CMC)]++
+		if c.isSplash(card) {
+			splashes++
+		}
+		chosen = append(chosen, card)
+	}
+	return chosen
+}
+
+// splitLands finds the basic land split that maximizes the spells' total
+// castability, weighted by how much each spell is worth.
+func (c *candidate) splitLands(spells []*poolCard, total int) map[string]int {
+	splashOptions := []int{0}
+	if c.splash != "" && c.splashCount(spells) > 0 {
+		splashOptions = nil
+		for n := minSplashLands; n <= maxSplashLands; n++ {
+			splashOptions = append(splashOptions, n)
+		}
+	}
+
+	var best map[string]int
+	bestScore := math.Inf(-1)
+	for _, splashLands := range splashOptions {
+		for first := 1; first < total-splashLands; first++ {
+			lands := map[string]int{c.main[0]: first, c.main[1]: total - splashLands - first}
+			if splashLands > 0 {
+				lands[c.splash] = splashLands
+			}
+			score := 0.0
+			for _, card := range spells {
+				weight := math.Max(1, card.GIHWR-valueFloor)
+				score += weight * castProbabilityBy(lands, card.CMC, c.castTurn(card), c.pips[card])
+			}
+			if score > bestScore {
+				best, bestScore = lands, score
+			}
+		}
+	}
+	return best
+}
+
+func (c *candidate) splashCount(spells []*poolCard) int {
+	n := 0
+	for _, card := range spells {
+		if c.isSplash(card) {
+			n++
+		}
+	}
+	return n
+}
+
+// describe scores a finished build and explains it.
+func (c *candidate) describe(spells []*poolCard, lands map[string]int, landCount int) Build {
+	sort.SliceStable(spells, func(i, j int) bool {
+		if spells[i].CMC != spells[j].CMC {
+			return spells[i].CMC < spells[j].CMC
+		}
+		return spells[i].GIHWR > spells[j].GIHWR
+	})
+
+	colors := strings.Join(c.main, "")
+	build := Build{
+		Name:      pairNames[colors],
+		Colors:    colors,
+		LandCount: landCount,
+		Lands:     lands,
+		Spells:    make([]BuildCard, 0, len(spells)),
+	}
+	if c.splashCount(spells) > 0 {
+		build.Splash = c.splash
+		build.Name += " splash " + colorNames[c.splash]
+	}
+
+	var valueSum, gihwrSum, castSum float64
+	var splashNames []string
+	var hardest *BuildCard
+	for _, card := range spells {
+		bc := card.BuildCard
+		bc.Colors = pipColors(c.pips[card])
+		bc.Splash = c.isSplash(card)
+		bc.Castability = stats.Round(castProbability(lands, card.CMC, c.pips[card]), 3)
+		build.Spells = append(build.Spells, bc)
+
+		valueSum += c.value(card, lands)
+		gihwrSum += card.GIHWR
+		castSum += bc.Castability
+		build.Curve[curveSlot(card.CMC)]++
+		if card.Creature {
+			build.Creatures++
+		}
+		if bc.Splash {
+			splashNames = append(splashNames, card.Name)
+		}
+		if hardest == nil || bc.Castability < hardest.Castability {
+			hardest = &build.Spells[len(build.Spells)-1]
+		}
+	}
+
+	penalty := 0.0
+	for slot, n := range build.Curve {
+		if over := n - curveTarget(slot, len(spells)); over > 0 {
+			penalty += float64(over) * curveOverPenalty
+		}
+	}
+	if short := minCreatures - build.Creatures; short > 0 {
+		penalty += float64(short) * creaturePenalty
+	}
+	n := float64(len(spells))
+	build.Score = stats.Round((valueSum-penalty)/n, 1)
+	build.AvgGIHWR = stats.Round(gihwrSum/n, 1)
+	build.Castability = stats.Round(castSum/n, 3)
+
+	// Explanation
+	best := append([]BuildCard(nil), build.Spells...)
+	sort.SliceStable(best, func(i, j int) bool { return best[i].GIHWR > best[j].GIHWR })
+	var top []string
+	for _, card := range best[:min(3, len(best))] {
+		top = append(top, fmt.Sprintf("%s (%.1f%%)", card.Name, card.GIHWR))
+	}
+	build.Explanation = append(build.Explanation, "Best cards: "+strings.Join(top, ", "))
+	if build.Splash != "" {
+		build.Explanation = append(build.Explanation, fmt.Sprintf("Splashes %s off %d %s",
+			strings.Join(splashNames, ", "), lands[build.Splash], basicNames[build.Splash]))
+	}
+	build.Explanation = append(build.Explanation, fmt.Sprintf("%d lands (%s): %.0f%% to hit land drops on curve",
+		landCount, describeLands(lands), averageLandDrop(spells, landCount)*100))
+	build.Explanation = append(build.Explanation, fmt.Sprintf("Curve %s with %d creatures",
+		describeCurve(build.Curve), build.Creatures))
+	if build.Creatures < minCreatures {
+		build.Explanation = append(build.Explanation, fmt.Sprintf("Light on creatures (%d of %d)", build.Creatures, minCreatures))
+	}
+	if hardest != nil && hardest.Castability < castabilityWarned {
+		build.Explanation = append(build.Explanation, fmt.Sprintf("Hardest to cast on curve: %s (%.0f%%)", hardest.Name, hardest.Castability*100))
+	}
+	if cut := c.bestCut(spells); cut != nil {
+		build.Explanation = append(build.Explanation, fmt.Sprintf("Best card left out: %s (%.1f%%)", cut.Name, cut.GIHWR))
+	}
+	return build
+}
+
+// bestCut returns the highest-rated eligible card that did not make the deck.
+func (c *candidate) bestCut(spells []*poolCard) *poolCard {
+	inDeck := make(map[*poolCard]bool, len(spells))
+	for _, card := range spells {
+		inDeck[card] = true
+	}
+	var best *poolCard
+	for _, card := range c.cards {
+		if !inDeck[card] && (best == nil || card.GIHWR > best.GIHWR) {
+			best = card
+		}
+	}
+	return best
+}
+
+// averageLandDrop is the spells' mean chance of having enough lands on curve.
+func averageLandDrop(spells []*poolCard, lands int) float64 {
+	if len(spells) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, card := range spells {
+		sum += landDropProbability(lands, card.CMC)
+	}
+	return sum / float64(len(spells))
+}
+
+func curveSlot(cmc int) int {
+	return clamp(cmc, 0, len(curveTargets)-1)
+}
+
+// curveTarget scales a curve slot's target to the number of spells.
+func curveTarget(slot, spells int) int {
+	return int(math.Ceil(float64(curveTargets[slot]) * float64(spells) / 23))
+}
+
+func describeLands(lands map[string]int) string {
+	var parts []string
+	for _, color := range wubrg {
+		if n := lands[color]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, basicNames[color]))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func describeCurve(curve [7]int) string {
+	parts := make([]string, 0, len(curve)-1)
+	for slot := 1; slot < len(curve); slot++ {
+		n := curve[slot]
+		if slot == 1 {
+			n += curve[0] // Free spells count with one-drops
+		}
+		parts = append(parts, strconv.Itoa(n))
+	}
+	return strings.Join(parts, "/")
+}
+
+func pipColors(pips map[string]int) string {
+	var key strings.Builder
+	for _, color := range wubrg {
+		if pips[color] > 0 {
+			key.WriteString(color)
+		}
+	}
+	return key.String()
+}
+
+func isLand(card *models.SetCard) bool {
+	return containsString(card.Types, "Land") && !containsString(card.Types, "Creature")
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,229 @@
+package optimizer
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+type fakeDraftRepo struct {
+	repository.DraftRepository
+	session *models.DraftSession
+	picks   []*models.DraftPickSession
+}
+
+func (f *fakeDraftRepo) GetSession(ctx context.Context, id string) (*models.DraftSession, error) {
+	if f.session == nil || f.session.ID != id {
+		return nil, nil
+	}
+	return f.session, nil
+}
+
+func (f *fakeDraftRepo) GetPicksBySession(ctx context.Context, sessionID string) ([]*models.DraftPickSession, error) {
+	return f.picks, nil
+}
+
+type fakeSetCardRepo struct {
+	repository.SetCardRepository
+	cards map[string]*models.SetCard
+}
+
+func (f *fakeSetCardRepo) GetCardByArenaID(ctx context.Context, arenaID string) (*models.SetCard, error) {
+	return f.cards[arenaID], nil
+}
+
+type fakeRatingsRepo struct {
+	repository.DraftRatingsRepository
+	cards []seventeenlands.CardRating
+}
+
+func (f *fakeRatingsRepo) GetCardRatings(ctx context.Context, setCode, draftFormat string) ([]seventeenlands.CardRating, time.Time, error) {
+	return f.cards, time.Now(), nil
+}
+
+// testPool is a pool deep in white and blue with a red bomb, some weaker
+// black and green cards, and a couple of lands.
+type testCard struct {
+	manaCost string
+	cmc      int
+	gihwr    float64
+	types    []string
+}
+
+func testPool() []testCard {
+	var pool []testCard
+	add := func(n int, manaCost string, cmc int, gihwr float64) {
+		for i := 0; i < n; i++ {
+			pool = append(pool, testCard{manaCost, cmc, gihwr, []string{"Creature"}})
+		}
+	}
+	add(3, "{1}{W}", 2, 57)
+	add(3, "{2}{W}", 3, 56)
+	add(2, "{3}{W}", 4, 55)
+	add(2, "{4}{W}", 5, 54)
+	add(3, "{1}{U}", 2, 56)
+	add(3, "{2}{U}", 3, 55)
+	add(2, "{3}{U}", 4, 55)
+	add(2, "{4}{U}{U}", 6, 54)
+	add(1, "{W}{U}", 2, 60)
+	add(2, "{2}", 2, 53) // Colorless
+	add(1, "{3}{R}", 4, 70)
+	add(2, "{1}{B}{B}", 3, 51)
+	add(4, "{2}{G}", 3, 50)
+	add(2, "{R}{R}", 2, 58)
+	pool = append(pool, testCard{"", 0, 55, []string{"Land"}})
+	return pool
+}
+
+func newTestOptimizer(pool []testCard) *Optimizer {
+	cards := make(map[string]*models.SetCard)
+	var ratings []seventeenlands.CardRating
+	var picks []*models.DraftPickSession
+	for i, c := range pool {
+		id := 1000 + i
+		arenaID := strconv.Itoa(id)
+		cards[arenaID] = &models.SetCard{ArenaID: arenaID, Name: "Card " + arenaID, ManaCost: c.manaCost, CMC: c.cmc, Types: c.types}
+		ratings = append(ratings, seventeenlands.CardRating{MTGAID: id, GIHWR: c.gihwr})
+		picks = append(picks, &models.DraftPickSession{CardID: arenaID, PackNumber: i / 14, PickNumber: i%14 + 1})
+	}
+	session := &models.DraftSession{ID: "draft-1", SetCode: "TST", EventName: "PremierDraft"}
+	return NewOptimizer(&fakeDraftRepo{session: session, picks: picks}, &fakeSetCardRepo{cards: cards}, &fakeRatingsRepo{cards: ratings})
+}
+
+func TestCastProbability(t *testing.T) {
+	// A colorless spell only needs lands
+	if got, want := castProbability(map[string]int{"W": 9, "U": 8}, 3, nil), landDropProbability(17, 3); math.Abs(got-want) > 1e-9 {
+		t.Errorf("colorless castability = %v, want land drop probability %v", got, want)
+	}
+
+	// Every land is a source of a mono-color deck's only color
+	mono := castProbability(map[string]int{"W": 17}, 2, map[string]int{"W": 2})
+	if math.Abs(mono-landDropProbability(17, 2)) > 1e-9 {
+		t.Errorf("mono-color castability = %v, want %v", mono, landDropProbability(17, 2))
+	}
+
+	// More sources of a color make its cards easier to cast
+	few := castProbability(map[string]int{"W": 7, "U": 10}, 3, map[string]int{"W": 2})
+	many := castProbability(map[string]int{"W": 10, "U": 7}, 3, map[string]int{"W": 2})
+	if few >= many {
+		t.Errorf("WW castability with 7 Plains %v should be below 10 Plains %v", few, many)
+	}
+
+	if got := castProbability(map[string]int{"W": 17}, 1, map[string]int{"U": 1}); got != 0 {
+		t.Errorf("off-color castability = %v, want 0", got)
+	}
+}
+
+func TestParseManaCost(t *testing.T) {
+	c := parseManaCost("{2}{W}{W}{W/U}{G/P}")
+	if c.Pips["W"] != 2 || len(c.Hybrid) != 1 || c.Pips["G"] != 0 {
+		t.Fatalf("parseManaCost = %+v", c)
+	}
+
+	pips, ok := c.requirement([]string{"U", "W"})
+	if !ok || pips["W"] != 2 || pips["U"] != 1 {
+		t.Errorf("hybrid should be paid with the first listed color, got %v", pips)
+	}
+	if _, ok := c.requirement([]string{"U", "B"}); ok {
+		t.Error("WW card should not be castable in UB")
+	}
+}
+
+func TestOptimalBuilds_SplashesBomb(t *testing.T) {
+	result, err := newTestOptimizer(testPool()).OptimalBuilds(context.Background(), "draft-1", 3)
+	if err != nil {
+		t.Fatalf("OptimalBuilds: %v", err)
+	}
+	// Only white-blue has enough playables, with and without the splash
+	if len(result.Builds) != 2 {
+		t.Fatalf("got %d builds, want 2", len(result.Builds))
+	}
+
+	best := result.Builds[0]
+	if best.Colors != "WU" || best.Splash != "R" {
+		t.Fatalf("best build is %s splash %q, want WU splash R", best.Colors, best.Splash)
+	}
+	if best.Rank != 1 || best.Score < result.Builds[1].Score {
+		t.Errorf("builds not ranked by score: %v then %v", best.Score, result.Builds[1].Score)
+	}
+
+	total := 0
+	for _, n := range best.Lands {
+		total += n
+	}
+	if total != best.LandCount || len(best.Spells)+best.LandCount != 40 {
+		t.Errorf("%d spells and %d lands (%v) do not make 40 cards", len(best.Spells), best.LandCount, best.Lands)
+	}
+	if best.Lands["R"] < minSplashLands || best.Lands["R"] > maxSplashLands {
+		t.Errorf("splash has %d Mountains, want %d-%d", best.Lands["R"], minSplashLands, maxSplashLands)
+	}
+
+	splashed := 0
+	for _, card := range best.Spells {
+		if card.Splash {
+			splashed++
+			if card.GIHWR != 70 {
+				t.Errorf("splashed %s at %.1f%%, want only the bomb", card.Name, card.GIHWR)
+			}
+		}
+		// RR cards cannot be splashed
+		if card.ManaCost == "{R}{R}" {
+			t.Errorf("double-pip splash card %s made the deck", card.Name)
+		}
+	}
+	if splashed != 1 {
+		t.Errorf("splashed %d cards, want 1", splashed)
+	}
+	if len(best.Explanation) == 0 {
+		t.Error("expected an explanation")
+	}
+}
+
+func TestOptimalBuilds_LandCountFollowsCurve(t *testing.T) {
+	var low []testCard
+	for i := 0; i < 12; i++ {
+		low = append(low, testCard{"{W}", 1, 55, []string{"Creature"}}, testCard{"{1}{U}", 2, 55, []string{"Creature"}})
+	}
+	var high []testCard
+	for i := 0; i < 12; i++ {
+		high = append(high, testCard{"{3}{W}", 4, 55, []string{"Creature"}}, testCard{"{4}{U}", 5, 55, []string{"Creature"}})
+	}
+
+	lowResult, err := newTestOptimizer(low).OptimalBuilds(context.Background(), "draft-1", 1)
+	if err != nil {
+		t.Fatalf("OptimalBuilds: %v", err)
+	}
+	highResult, err := newTestOptimizer(high).OptimalBuilds(context.Background(), "draft-1", 1)
+	if err != nil {
+		t.Fatalf("OptimalBuilds: %v", err)
+	}
+	if got := lowResult.Builds[0].LandCount; got != minLands {
+		t.Errorf("low curve plays %d lands, want %d", got, minLands)
+	}
+	if got := highResult.Builds[0].LandCount; got != maxLands {
+		t.Errorf("high curve plays %d lands, want %d", got, maxLands)
+	}
+}
+
+func TestOptimalBuilds_SmallPool(t *testing.T) {
+	result, err := newTestOptimizer(testPool()[:10]).OptimalBuilds(context.Background(), "draft-1", 3)
+	if err != nil {
+		t.Fatalf("OptimalBuilds: %v", err)
+	}
+	if len(result.Builds) != 0 {
+		t.Errorf("got %d builds from 10 cards, want none", len(result.Builds))
+	}
+}
+
+func TestOptimalBuilds_SessionNotFound(t *testing.T) {
+	if _, err := newTestOptimizer(nil).OptimalBuilds(context.Background(), "missing", 3); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("OptimalBuilds returned %v, want ErrSessionNotFound", err)
+	}
+}
@@ -11,10 +11,12 @@ import (
 
 // Alternative represents an alternative card pick with its rating.
 type Alternative struct {
-	CardID   string  `json:"card_id"`
-	CardName string  `json:"card_name"`
-	GIHWR    float64 `json:"gihwr"`
-	Rank     int     `json:"rank"`
+	CardID   string        `json:"card_id"`
+	CardName string        `json:"card_name"`
+	GIHWR    float64       `json:"gihwr"`
+	Rank     int           `json:"rank"`
+	Score    float64       `json:"score,omitempty"`   // Context-aware score (see ContextAnalyzer)
+	Factors  *ScoreFactors `json:"factors,omitempty"` // Breakdown of Score
 }
 
 // PickQuality represents the quality analysis of a draft pick.
@@ -24,6 +26,11 @@ type PickQuality struct {
 	PackBestGIHWR   float64       `json:"pack_best_gihwr"`   // Best GIHWR in pack
 	PickedCardGIHWR float64       `json:"picked_card_gihwr"` // GIHWR of picked card
 	Alternatives    []Alternative `json:"alternatives"`      // Top 5 alternatives
+
+	// Context-aware scoring, set by ContextAnalyzer
+	PickedScore   float64       `json:"picked_score,omitempty"`    // Context score of picked card
+	PackBestScore float64       `json:"pack_best_score,omitempty"` // Best context score in pack
+	PickedFactors *ScoreFactors `json:"picked_factors,omitempty"`  // Breakdown of PickedScore
 }
 
 // Analyzer analyzes draft pick quality using 17Lands data.
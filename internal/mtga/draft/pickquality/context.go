@@ -0,0 +1,507 @@
+package pickquality
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/recommendations"
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+)
+
+// Context score weights. Scores are expressed in GIHWR percentage points, so
+// each adjustment reads as "worth about N points of win rate" next to the
+// card's own rating.
+const (
+	colorFitWeight     = 6.0 // Penalty for a fully off-color card once the pool is committed
+	archetypeWeight    = 0.5 // Scale applied to a color pair's win rate edge over the format average
+	creatureNeedBonus  = 1.5 // Bonus when the pool is behind on creatures
+	removalNeedBonus   = 2.0 // Bonus when the pool is behind on removal
+	curveNeedBonus     = 1.0 // Bonus for a two-drop when the pool is short on them
+	synergyRoleBonus   = 0.5 // Bonus per synergy package the card shares with the pool
+	maxSynergyBonus    = 1.5
+	wheelPenaltyWeight = 2.0 // Penalty for a card likely to come back around the table
+)
+
+// Limited deck targets, scaled by how far through the draft the pick is.
+const (
+	targetCreatures = 15
+	targetRemoval   = 4
+	targetTwoDrops  = 5
+	podSize         = 8 // Drafters passing each pack
+	defaultPackSize = 14
+)
+
+// ScoreFactors breaks a context-aware pick score into its components.
+// The total score is the sum of all fields.
+type ScoreFactors struct {
+	BaseGIHWR float64 `json:"base_gihwr"` // 17Lands GIHWR of the card
+	ColorFit  float64 `json:"color_fit"`  // Penalty for colors outside the pool's main colors
+	Archetype float64 `json:"archetype"`  // Win rate edge of the best color pair the card fits
+	RoleNeed  float64 `json:"role_need"`  // Bonus for filling creature, removal, curve or synergy gaps
+	Position  float64 `json:"position"`   // Penalty when ALSA suggests the card will wheel
+}
+
+// Total returns the context-aware score.
+func (f ScoreFactors) Total() float64 {
+	return f.BaseGIHWR + f.ColorFit + f.Archetype + f.RoleNeed + f.Position
+}
+
+// PickContext describes where in the draft a pick was made.
+type PickContext struct {
+	Pool       []string // Arena IDs picked before this pick, in pick order
+	TotalPicks int      // Expected picks in the draft (pack size × 3); 0 assumes 14-card packs
+}
+
+// packSize returns the number of cards in a fresh pack.
+func (p PickContext) packSize() int {
+	if p.TotalPicks >= 3 {
+		return p.TotalPicks / 3
+	}
+	return defaultPackSize
+}
+
+// ContextAnalyzer scores pack cards against the pool drafted so far, using
+// color commitment, color pair win rates, role needs and pick position on top
+// of the card's GIHWR. Create one per draft; it caches set ratings and cards.
+type ContextAnalyzer struct {
+	analyzer     *Analyzer
+	ratings      map[string]seventeenlands.CardRating // arenaID -> rating
+	pairWinRates map[string]float64                   // sorted color pair -> win rate %
+	avgPairRate  float64
+	cards        map[string]*models.SetCard
+}
+
+// NewContextAnalyzer loads the ratings for a set and returns an analyzer for its drafts.
+func (a *Analyzer) NewContextAnalyzer(ctx context.Context, setCode, draftFormat string) (*ContextAnalyzer, error) {
+	cardRatings, _, err := a.ratingsRepo.GetCardRatings(ctx, setCode, draftFormat)
+	if err != nil {
+		return nil, fmt.Errorf("get card ratings: %w", err)
+	}
+	colorRatings, _, err := a.ratingsRepo.GetColorRatings(ctx, setCode, draftFormat)
+	if err != nil {
+		return nil, fmt.Errorf("get color ratings: %w", err)
+	}
+
+	c := &ContextAnalyzer{
+		analyzer:     a,
+		ratings:      make(map[string]seventeenlands.CardRating, len(cardRatings)),
+		pairWinRates: make(map[string]float64),
+		cards:        make(map[string]*models.SetCard),
+	}
+	for _, r := range cardRatings {
+		c.ratings[strconv.Itoa(r.MTGAID)] = r
+	}
+
+	pairSum := 0.0
+	for _, r := range colorRatings {
+		colors := parseColors(r.ColorName)
+		if r.IsSplash || len(colors) != 2 {
+			continue
+		}
+		winRate := r.WinRate
+		if winRate <= 1 {
+			winRate *= 100 // 17Lands reports color win rates as fractions
+		}
+		c.pairWinRates[strings.Join(colors, "")] = winRate
+		pairSum += winRate
+	}
+	if len(c.pairWinRates) > 0 {
+		c.avgPairRate = pairSum / float64(len(c.pairWinRates))
+	}
+
+	return c, nil
+}
+
+// CardScore is the context-aware score of one pack card.
+type CardScore struct {
+	CardID  string
+	Name    string
+	GIHWR   float64
+	Factors ScoreFactors
+}
+
+// ScorePack scores every card in a pack given the pool drafted so far,
+// best first.
+func (c *ContextAnalyzer) ScorePack(ctx context.Context, pick PickContext, packCardIDs []string) []CardScore {
+	pool := c.profilePool(ctx, pick)
+	pickInPack := pick.packSize() - len(packCardIDs) + 1
+	canWheel := len(packCardIDs) > podSize
+
+	scores := make([]CardScore, 0, len(packCardIDs))
+	for _, arenaID := range packCardIDs {
+		rating, hasRating := c.ratings[arenaID]
+		card := c.card(ctx, arenaID)
+
+		score := CardScore{CardID: arenaID, Name: "Unknown Card"}
+		if card != nil {
+			score.Name = card.Name
+		} else if hasRating && rating.Name != "" {
+			score.Name = rating.Name
+		}
+		if hasRating {
+			score.GIHWR = rating.GIHWR
+		}
+
+		colors := cardColors(card, rating)
+		score.Factors = ScoreFactors{
+			BaseGIHWR: score.GIHWR,
+			ColorFit:  pool.colorFit(colors),
+			Archetype: c.archetypeEdge(colors, pool.mainColors),
+			RoleNeed:  pool.roleNeed(card),
+		}
+		if hasRating && canWheel {
+			score.Factors.Position = wheelPenalty(rating, pickInPack)
+		}
+		scores = append(scores, score)
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Factors.Total() > scores[j].Factors.Total()
+	})
+	return scores
+}
+
+// AnalyzePick grades a pick by where the picked card ranks among the pack's
+// context-aware scores. Alternatives carry their own scores and breakdowns.
+func (c *ContextAnalyzer) AnalyzePick(ctx context.Context, pick PickContext, packCardIDs []string, pickedCardID string) (*PickQuality, error) {
+	if len(packCardIDs) == 0 {
+		return nil, fmt.Errorf("no cards in pack")
+	}
+
+	scores := c.ScorePack(ctx, pick, packCardIDs)
+
+	pickedRank := 0
+	var picked CardScore
+	packBestGIHWR := 0.0
+	hasRatingData := false
+	for i, s := range scores {
+		if s.CardID == pickedCardID && pickedRank == 0 {
+			pickedRank = i + 1
+			picked = s
+		}
+		if s.GIHWR > packBestGIHWR {
+			packBestGIHWR = s.GIHWR
+		}
+		if s.GIHWR > 0 {
+			hasRatingData = true
+		}
+	}
+	if pickedRank == 0 {
+		return nil, fmt.Errorf("picked card not found in pack")
+	}
+
+	grade := "N/A"
+	if hasRatingData {
+		grade = calculateGrade(pickedRank, len(scores))
+	}
+
+	alternatives := make([]Alternative, 0, 5)
+	for i, s := range scores {
+		if s.CardID == pickedCardID {
+			continue
+		}
+		if len(alternatives) >= 5 {
+			break
+		}
+		factors := s.Factors
+		alternatives = append(alternatives, Alternative{
+			CardID:   s.CardID,
+			CardName: s.Name,
+			GIHWR:    s.GIHWR,
+			Rank:     i + 1,
+			Score:    stats.Round(factors.Total(), 2),
+			Factors:  &factors,
+		})
+	}
+
+	pickedFactors := picked.Factors
+	return &PickQuality{
+		Grade:           grade,
+		Rank:            pickedRank,
+		PackBestGIHWR:   packBestGIHWR,
+		PickedCardGIHWR: picked.GIHWR,
+		Alternatives:    alternatives,
+		PickedScore:     stats.Round(pickedFactors.Total(), 2),
+		PackBestScore:   stats.Round(scores[0].Factors.Total(), 2),
+		PickedFactors:   &pickedFactors,
+	}, nil
+}
+
+// card returns cached set card data, or nil if the card is unknown.
+func (c *ContextAnalyzer) card(ctx context.Context, arenaID string) *models.SetCard {
+	if card, ok := c.cards[arenaID]; ok {
+		return card
+	}
+	card, err := c.analyzer.setCardRepo.GetCardByArenaID(ctx, arenaID)
+	if err != nil {
+		card = nil
+	}
+	c.cards[arenaID] = card
+	return card
+}
+
+// archetypeEdge returns how much better than average the best color pair the
+// card fits performs. Cards with no colors, or more than two, are neutral.
+func (c *ContextAnalyzer) archetypeEdge(colors, poolColors []string) float64 {
+	if len(colors) == 0 || len(colors) > 2 || len(c.pairWinRates) == 0 {
+		return 0
+	}
+
+	best := 0.0
+	found := false
+	for pair, winRate := range c.pairWinRates {
+		if !containsAll(pair, colors) {
+			continue
+		}
+		// Once the pool has a direction, only pairs sharing a main color count
+		if len(poolColors) > 0 && !containsAny(pair, poolColors) {
+			continue
+		}
+		if !found || winRate > best {
+			best = winRate
+			found = true
+		}
+	}
+	if !found {
+		return 0
+	}
+	return (best - c.avgPairRate) * archetypeWeight
+}
+
+// poolProfile summarizes the cards drafted so far.
+type poolProfile struct {
+	mainColors []string        // Up to two colors the pool is leaning into
+	commitment float64         // 0 (open) to 1 (locked into mainColors)
+	progress   float64         // Fraction of the draft completed
+	creatures  int             // Creature cards in the pool
+	removal    int             // Removal spells in the pool
+	twoDrops   int             // Two-mana-value cards in the pool
+	packages   map[string]bool // Synergy packages with at least one card in the pool
+}
+
+// profilePool weighs each pool card's colors by its rating above replacement
+// and counts the roles the pool already covers.
+func (c *ContextAnalyzer) profilePool(ctx context.Context, pick PickContext) *poolProfile {
+	profile := &poolProfile{packages: make(map[string]bool)}
+	totalPicks := pick.packSize() * 3
+	profile.progress = math.Min(float64(len(pick.Pool))/float64(totalPicks), 1)
+
+	colorWeight := make(map[string]float64)
+	totalWeight := 0.0
+	for _, arenaID := range pick.Pool {
+		rating, hasRating := c.ratings[arenaID]
+		card := c.card(ctx, arenaID)
+
+		// Weak cards say little about where a drafter is heading
+		weight := 1.0
+		if hasRating && rating.GIHWR > 0 {
+			weight = math.Max(rating.GIHWR-50, 0.5)
+		}
+		for _, color := range cardColors(card, rating) {
+			colorWeight[color] += weight
+			totalWeight += weight
+		}
+
+		if card == nil {
+			continue
+		}
+		switch {
+		case isCreature(card):
+			profile.creatures++
+		case cards.IsRemovalText(card.Text):
+			profile.removal++
+		}
+		if card.CMC == 2 {
+			profile.twoDrops++
+		}
+		for _, role := range recommendations.GetCardRoles(toCard(card)) {
+			profile.packages[role.PackageName] = true
+		}
+	}
+
+	if totalWeight == 0 {
+		return profile
+	}
+
+	colors := make([]string, 0, len(colorWeight))
+	for color := range colorWeight {
+		colors = append(colors, color)
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		if colorWeight[colors[i]] != colorWeight[colors[j]] {
+			return colorWeight[colors[i]] > colorWeight[colors[j]]
+		}
+		return colors[i] < colors[j]
+	})
+	if len(colors) > 2 {
+		colors = colors[:2]
+	}
+	profile.mainColors = colors
+
+	// Commitment grows through pack 1 and with how concentrated the pool is.
+	// The first few picks are always open.
+	share := 0.0
+	for _, color := range colors {
+		share += colorWeight[color]
+	}
+	share /= totalWeight
+	ramp := (float64(len(pick.Pool)) - 3) / float64(pick.packSize())
+	profile.commitment = clamp(ramp, 0, 1) * share
+
+	return profile
+}
+
+// colorFit penalizes cards whose colors fall outside the pool's main colors,
+// scaled by how committed the pool is.
+func (p *poolProfile) colorFit(colors []string) float64 {
+	if len(colors) == 0 || len(p.mainColors) == 0 || p.commitment == 0 {
+		return 0
+	}
+	onColor := 0
+	for _, color := range colors {
+		for _, main := range p.mainColors {
+			if color == main {
+				onColor++
+			}
+		}
+	}
+	offShare := 1 - float64(onColor)/float64(len(colors))
+	return -offShare * p.commitment * colorFitWeight
+}
+
+// roleNeed rewards cards that fill a gap the pool has at this point of the draft.
+func (p *poolProfile) roleNeed(card *models.SetCard) float64 {
+	if card == nil {
+		return 0
+	}
+
+	bonus := 0.0
+	switch {
+	case isCreature(card):
+		bonus += creatureNeedBonus * deficit(targetCreatures, p.creatures, p.progress)
+	case cards.IsRemovalText(card.Text):
+		bonus += removalNeedBonus * deficit(targetRemoval, p.removal, p.progress)
+	}
+	if card.CMC == 2 {
+		bonus += curveNeedBonus * deficit(targetTwoDrops, p.twoDrops, p.progress)
+	}
+
+	synergy := 0.0
+	for _, role := range recommendations.GetCardRoles(toCard(card)) {
+		if p.packages[role.PackageName] {
+			synergy += synergyRoleBonus
+		}
+	}
+	return bonus + math.Min(synergy, maxSynergyBonus)
+}
+
+// deficit returns how far behind the target count the pool is for this point
+// of the draft, from 0 (on track) to 1 (three or more short).
+func deficit(target, have int, progress float64) float64 {
+	expected := float64(target) * progress
+	return clamp((expected-float64(have))/3, 0, 1)
+}
+
+// wheelPenalty discounts a card whose ALSA (or ATA when ALSA is missing) says
+// it usually goes later than a full lap from this pick.
+func wheelPenalty(rating seventeenlands.CardRating, pickInPack int) float64 {
+	lastSeen := rating.ALSA
+	if lastSeen == 0 {
+		lastSeen = rating.ATA
+	}
+	if lastSeen == 0 {
+		return 0
+	}
+	likelihood := clamp((lastSeen-float64(pickInPack+podSize))/2+0.5, 0, 1)
+	return -likelihood * wheelPenaltyWeight
+}
+
+// cardColors returns a card's colors from set data, falling back to the 17Lands color string.
+func cardColors(card *models.SetCard, rating seventeenlands.CardRating) []string {
+	if card != nil && len(card.Colors) > 0 {
+		return card.Colors
+	}
+	return parseColors(rating.Color)
+}
+
+// parseColors extracts WUBRG letters from a color string in WUBRG order.
+func parseColors(s string) []string {
+	var colors []string
+	for _, color := range []string{"W", "U", "B", "R", "G"} {
+		if strings.Contains(strings.ToUpper(s), color) {
+			colors = append(colors, color)
+		}
+	}
+	return colors
+}
+
+func containsAll(colors string, want []string) bool {
+	for _, c := range want {
+		if !strings.Contains(colors, c) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAny(colors string, want []string) bool {
+	for _, c := range want {
+		if strings.Contains(colors, c) {
+			return true
+		}
+	}
+	return false
+}
+
+func isCreature(card *models.SetCard) bool {
+	for _, t := range card.Types {
+		if strings.Contains(strings.ToLower(t), "creature") {
+			return true
+		}
+	}
+	return false
+}
+
+// toCard adapts set card data to the fields recommendations.GetCardRoles reads.
+func toCard(card *models.SetCard) *cards.Card {
+	c := &cards.Card{
+		Name:     card.Name,
+		TypeLine: strings.Join(card.Types, " "),
+		CMC:      float64(card.CMC),
+		Colors:   card.Colors,
+	}
+	if card.Text != "" {
+		c.OracleText = &card.Text
+	}
+	return c
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
+
+// SerializeFactors converts a score breakdown to JSON for database storage.
+func SerializeFactors(factors *ScoreFactors) (string, error) {
+	data, err := json.Marshal(factors)
+	if err != nil {
+		return "", fmt.Errorf("marshal score factors: %w", err)
+	}
+	return string(data), nil
+}
+
+// DeserializeFactors converts a JSON score breakdown back to ScoreFactors.
+func DeserializeFactors(jsonStr string) (*ScoreFactors, error) {
+	var factors ScoreFactors
+	if err := json.Unmarshal([]byte(jsonStr), &factors); err != nil {
+		return nil, fmt.Errorf("unmarshal score factors: %w", err)
+	}
+	return &factors, nil
+}
@@ -0,0 +1,206 @@
+package pickquality
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+// fakeRatingsRepo serves a fixed set of card and color ratings.
+type fakeRatingsRepo struct {
+	repository.DraftRatingsRepository
+	cards  []seventeenlands.CardRating
+	colors []seventeenlands.ColorRating
+}
+
+func (f *fakeRatingsRepo) GetCardRatings(ctx context.Context, setCode, draftFormat string) ([]seventeenlands.CardRating, time.Time, error) {
+	return f.cards, time.Now(), nil
+}
+
+func (f *fakeRatingsRepo) GetColorRatings(ctx context.Context, setCode, draftFormat string) ([]seventeenlands.ColorRating, time.Time, error) {
+	return f.colors, time.Now(), nil
+}
+
+// fakeSetCardRepo serves set cards by arena ID.
+type fakeSetCardRepo struct {
+	repository.SetCardRepository
+	cards map[string]*models.SetCard
+}
+
+func (f *fakeSetCardRepo) GetCardByArenaID(ctx context.Context, arenaID string) (*models.SetCard, error) {
+	return f.cards[arenaID], nil
+}
+
+// newTestContextAnalyzer builds a small set: a white creature, a blue creature, a red
+// removal spell, a white removal spell and filler.
+func newTestContextAnalyzer(t *testing.T) *ContextAnalyzer {
+	t.Helper()
+
+	type cardDef struct {
+		id     int
+		name   string
+		color  string
+		types  []string
+		text   string
+		cmc    int
+		gihwr  float64
+		alsa   float64
+		rarity string
+	}
+	defs := []cardDef{
+		{1, "White Knight", "W", []string{"Creature", "Human", "Knight"}, "", 2, 57, 3, "common"},
+		{2, "Blue Drake", "U", []string{"Creature", "Drake"}, "Flying", 3, 58, 3, "common"},
+		{3, "Red Bolt", "R", []string{"Instant"}, "Red Bolt deals 3 damage to any target.", 1, 58.5, 3, "common"},
+		{4, "White Banish", "W", []string{"Enchantment"}, "When this enters, exile target creature an opponent controls.", 3, 57.5, 3, "uncommon"},
+		{5, "Plains Walker", "W", []string{"Creature", "Soldier"}, "", 2, 55, 12, "common"},
+		{6, "Filler", "B", []string{"Creature", "Zombie"}, "", 4, 50, 12, "common"},
+	}
+	for i := 10; i < 22; i++ {
+		defs = append(defs, cardDef{i, "White Pool Card " + strconv.Itoa(i), "W", []string{"Creature"}, "", 3, 56, 5, "common"})
+	}
+	for i := 30; i < 36; i++ {
+		defs = append(defs, cardDef{i, "Blue Pool Card " + strconv.Itoa(i), "U", []string{"Sorcery"}, "Draw a card.", 3, 55, 5, "common"})
+	}
+
+	ratings := &fakeRatingsRepo{
+		colors: []seventeenlands.ColorRating{
+			{ColorName: "WU", WinRate: 0.57},
+			{ColorName: "WR", WinRate: 0.55},
+			{ColorName: "UR", WinRate: 0.53},
+			{ColorName: "UB", WinRate: 0.54},
+		},
+	}
+	setCards := &fakeSetCardRepo{cards: map[string]*models.SetCard{}}
+	for _, d := range defs {
+		ratings.cards = append(ratings.cards, seventeenlands.CardRating{
+			MTGAID: d.id, Name: d.name, Color: d.color, Rarity: d.rarity, GIHWR: d.gihwr, ALSA: d.alsa, ATA: d.alsa,
+		})
+		id := strconv.Itoa(d.id)
+		setCards.cards[id] = &models.SetCard{
+			ArenaID: id, Name: d.name, Colors: []string{d.color}, Types: d.types, Text: d.text, CMC: d.cmc, Rarity: d.rarity,
+		}
+	}
+
+	analyzer, err := NewAnalyzer(ratings, setCards).NewContextAnalyzer(context.Background(), "TST", "PremierDraft")
+	if err != nil {
+		t.Fatalf("NewContextAnalyzer failed: %v", err)
+	}
+	return analyzer
+}
+
+func ids(from, to int) []string {
+	var out []string
+	for i := from; i < to; i++ {
+		out = append(out, strconv.Itoa(i))
+	}
+	return out
+}
+
+func TestContextAnalyzer_EmptyPoolHasNoPoolAdjustments(t *testing.T) {
+	analyzer := newTestContextAnalyzer(t)
+	pack := []string{"1", "2", "3", "4", "5", "6"}
+
+	scores := analyzer.ScorePack(context.Background(), PickContext{TotalPicks: 42}, pack)
+	for _, s := range scores {
+		if s.Factors.ColorFit != 0 || s.Factors.RoleNeed != 0 {
+			t.Errorf("card %s: pool adjustments %+v with an empty pool", s.CardID, s.Factors)
+		}
+	}
+
+	// Only the archetype edge separates Blue Drake (best pair W/U) from the
+	// slightly higher rated Red Bolt (best pair W/R)
+	if scores[0].CardID != "2" || scores[1].CardID != "3" {
+		t.Errorf("P1P1 order starts %s, %s; want 2, 3", scores[0].CardID, scores[1].CardID)
+	}
+}
+
+func TestContextAnalyzer_CommittedPoolPrefersOnColor(t *testing.T) {
+	analyzer := newTestContextAnalyzer(t)
+	ctx := context.Background()
+
+	// Twelve white creatures: solidly white, nothing else committed
+	pick := PickContext{Pool: ids(10, 22), TotalPicks: 42}
+	quality, err := analyzer.AnalyzePick(ctx, pick, []string{"3", "4", "6"}, "3")
+	if err != nil {
+		t.Fatalf("AnalyzePick failed: %v", err)
+	}
+
+	if quality.Rank == 1 {
+		t.Errorf("off-color Red Bolt should not be the best pick for a white pool (score %.2f, best %.2f)", quality.PickedScore, quality.PackBestScore)
+	}
+	if quality.PickedFactors == nil || quality.PickedFactors.ColorFit >= 0 {
+		t.Errorf("expected a color penalty for the off-color pick, got %+v", quality.PickedFactors)
+	}
+	if len(quality.Alternatives) == 0 || quality.Alternatives[0].CardID != "4" {
+		t.Fatalf("expected White Banish as the top alternative, got %+v", quality.Alternatives)
+	}
+	best := quality.Alternatives[0]
+	if best.Factors == nil || best.Factors.RoleNeed <= 0 {
+		t.Errorf("removal should fill a need in a pool without any, got %+v", best.Factors)
+	}
+	if best.Score != quality.PackBestScore {
+		t.Errorf("top alternative score %.2f != pack best score %.2f", best.Score, quality.PackBestScore)
+	}
+}
+
+func TestContextAnalyzer_ArchetypeFavorsStrongPair(t *testing.T) {
+	analyzer := newTestContextAnalyzer(t)
+
+	// W/U is the best pair, so a blue card should get a bigger archetype edge
+	// than a black one for a white pool
+	pick := PickContext{Pool: ids(10, 22), TotalPicks: 42}
+	scores := analyzer.ScorePack(context.Background(), pick, []string{"2", "6"})
+
+	byID := map[string]CardScore{}
+	for _, s := range scores {
+		byID[s.CardID] = s
+	}
+	if byID["2"].Factors.Archetype <= byID["6"].Factors.Archetype {
+		t.Errorf("blue archetype edge %.2f should beat black %.2f", byID["2"].Factors.Archetype, byID["6"].Factors.Archetype)
+	}
+}
+
+func TestContextAnalyzer_WheelPenalty(t *testing.T) {
+	analyzer := newTestContextAnalyzer(t)
+
+	// Fourteen-card pack at pick 1: Plains Walker (ALSA 12) usually wheels, White Knight (ALSA 3) never does
+	pack := append([]string{"1", "5"}, ids(10, 22)...)
+	scores := analyzer.ScorePack(context.Background(), PickContext{TotalPicks: 42}, pack)
+
+	for _, s := range scores {
+		switch s.CardID {
+		case "1":
+			if s.Factors.Position != 0 {
+				t.Errorf("White Knight position = %.2f, want 0", s.Factors.Position)
+			}
+		case "5":
+			if s.Factors.Position >= 0 {
+				t.Errorf("Plains Walker position = %.2f, want a wheel penalty", s.Factors.Position)
+			}
+		}
+	}
+}
+
+func TestScoreFactors_RoundTrip(t *testing.T) {
+	factors := &ScoreFactors{BaseGIHWR: 58, ColorFit: -3, Archetype: 1, RoleNeed: 2, Position: -1}
+
+	data, err := SerializeFactors(factors)
+	if err != nil {
+		t.Fatalf("SerializeFactors failed: %v", err)
+	}
+	got, err := DeserializeFactors(data)
+	if err != nil {
+		t.Fatalf("DeserializeFactors failed: %v", err)
+	}
+	if *got != *factors {
+		t.Errorf("round trip = %+v, want %+v", got, factors)
+	}
+	if factors.Total() != 57 {
+		t.Errorf("Total() = %.2f, want 57", factors.Total())
+	}
+}
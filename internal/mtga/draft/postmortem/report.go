@@ -0,0 +1,697 @@
+package postmortem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/colorid"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/grading"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/pickquality"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/recommendations"
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+// maxEventSessions caps how many completed drafts are read to find where a
+// draft's event window ends.
+const maxEventSessions = 1000
+
+// ErrSessionNotFound is returned when the draft session does not exist.
+var ErrSessionNotFound = errors.New("draft session not found")
+
+// Report is the post-mortem of one draft: how it was drafted, what was built,
+// and how the cards did in the event.
+type Report struct {
+	SessionID       string              `json:"session_id"`
+	SetCode         string              `json:"set_code"`
+	EventName       string              `json:"event_name"`
+	DraftFormat     string              `json:"draft_format"`
+	Status          string              `json:"status"`
+	StartTime       time.Time           `json:"start_time"`
+	GeneratedAt     time.Time           `json:"generated_at"`
+	Grade           *grading.DraftGrade `json:"grade,omitempty"`
+	Prediction      *Prediction         `json:"prediction,omitempty"`
+	Picks           []PickReview        `json:"picks"`
+	Agreement       float64             `json:"agreement"` // Share of scored picks that matched the evaluator's top choice
+	Pivot           *Pivot              `json:"pivot,omitempty"`
+	Deck            *DeckComparison     `json:"deck,omitempty"`
+	Record          Record              `json:"record"`
+	CardPerformance []CardPerformance   `json:"card_performance"`
+}
+
+// Prediction is the stored win rate prediction for the draft.
+type Prediction struct {
+	WinRate     float64    `json:"win_rate"` // 0-1
+	WinRateMin  float64    `json:"win_rate_min"`
+	WinRateMax  float64    `json:"win_rate_max"`
+	PredictedAt *time.Time `json:"predicted_at,omitempty"`
+}
+
+// PickReview compares one pick with the context-aware evaluator's choice.
+type PickReview struct {
+	PackNumber   int     `json:"pack_number"` // 0-based
+	PickNumber   int     `json:"pick_number"` // 1-based within the pack
+	CardID       string  `json:"card_id"`
+	CardName     string  `json:"card_name"`
+	Score        float64 `json:"score"`
+	Rank         int     `json:"rank"` // Evaluator rank of the picked card, 0 when the pack is unknown
+	PackSize     int     `json:"pack_size"`
+	BestCardID   string  `json:"best_card_id,omitempty"`
+	BestCardName string  `json:"best_card_name,omitempty"`
+	BestScore    float64 `json:"best_score"`
+	Agreed       bool    `json:"agreed"`
+	Grade        string  `json:"grade,omitempty"`
+	PoolColors   string  `json:"pool_colors"` // Leading colors of the pool after this pick
+}
+
+// Pivot is the pick from which the pool's leading colors never changed again.
+type Pivot struct {
+	PickIndex      int    `json:"pick_index"`  // 1-based over the whole draft
+	PackNumber     int    `json:"pack_number"` // 0-based
+	PickNumber     int    `json:"pick_number"` // 1-based within the pack
+	CardName       string `json:"card_name"`
+	Colors         string `json:"colors"`
+	PreviousColors string `json:"previous_colors,omitempty"` // Leading colors just before the pivot
+}
+
+// DeckCard is a card in a deck summary.
+type DeckCard struct {
+	CardID   int     `json:"card_id"`
+	Name     string  `json:"name"`
+	Quantity int     `json:"quantity"`
+	GIHWR    float64 `json:"gihwr,omitempty"`
+}
+
+// DeckSummary describes the nonland cards of a deck.
+type DeckSummary struct {
+	Name     string     `json:"name"`
+	Colors   string     `json:"colors"`
+	Spells   int        `json:"spells"`
+	AvgGIHWR float64    `json:"avg_gihwr"` // Mean GIHWR of rated spells
+	Cards    []DeckCard `json:"cards"`
+}
+
+// DeckComparison sets the deck that was played against the best build the
+// deck suggester finds in the pool.
+type DeckComparison struct {
+	Built       *DeckSummary `json:"built,omitempty"`
+	Optimal     *DeckSummary `json:"optimal,omitempty"`
+	Overlap     int          `json:"overlap"`      // Spells in both decks
+	OnlyBuilt   []string     `json:"only_built"`   // Spells played that the optimal build leaves out
+	OnlyOptimal []string     `json:"only_optimal"` // Spells the optimal build plays instead
+}
+
+// MatchLine is one match played in the draft's event.
+type MatchLine struct {
+	MatchID      string    `json:"match_id"`
+	Result       string    `json:"result"`
+	PlayerWins   int       `json:"player_wins"`
+	OpponentWins int       `json:"opponent_wins"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// Record is the event's match record.
+type Record struct {
+	Wins    int         `json:"wins"`
+	Losses  int         `json:"losses"`
+	Matches []MatchLine `json:"matches"`
+}
+
+// CardPerformance is how one card did when cast during the event.
+type CardPerformance struct {
+	CardID          int     `json:"card_id"`
+	Name            string  `json:"name"`
+	Casts           int     `json:"casts"`
+	MatchesCast     int     `json:"matches_cast"`
+	WinsWhenCast    int     `json:"wins_when_cast"`
+	WinRateWhenCast float64 `json:"win_rate_when_cast"` // 0-1
+	AvgTurn         float64 `json:"avg_turn"`
+	InDeck          bool    `json:"in_deck"` // Whether the card is in the built deck
+}
+
+// DeckBuilder builds decks from a draft pool. *recommendations.DeckSuggester
+// implements it; SeedDeckBuilder is not used because it builds 60-card
+// constructed decks from the whole set or collection, not from the cards drafted.
+type DeckBuilder interface {
+	SuggestDecks(ctx context.Context, draftPool []int, setCode, draftFormat string) (*recommendations.SuggestDecksResponse, error)
+}
+
+// Generator assembles post-mortem reports from stored drafts, decks and matches.
+type Generator struct {
+	draftRepo   repository.DraftRepository
+	matchRepo   repository.MatchRepository
+	deckRepo    repository.DeckRepository
+	playRepo    repository.GamePlayRepository
+	ratingsRepo repository.DraftRatingsRepository
+	setCardRepo repository.SetCardRepository
+	builder     DeckBuilder
+}
+
+// NewGenerator creates a new report generator. builder may be nil, in which
+// case reports leave out the optimal build.
+func NewGenerator(
+	draftRepo repository.DraftRepository,
+	matchRepo repository.MatchRepository,
+	deckRepo repository.DeckRepository,
+	playRepo repository.GamePlayRepository,
+	ratingsRepo repository.DraftRatingsRepository,
+	setCardRepo repository.SetCardRepository,
+	builder DeckBuilder,
+) *Generator {
+	return &Generator{
+		draftRepo:   draftRepo,
+		matchRepo:   matchRepo,
+		deckRepo:    deckRepo,
+		playRepo:    playRepo,
+		ratingsRepo: ratingsRepo,
+		setCardRepo: setCardRepo,
+		builder:     builder,
+	}
+}
+
+// Generate builds the post-mortem for a draft session. Sections whose data is
+// missing (no deck, no matches, no ratings) are left empty rather than failing
+// the report.
+func (g *Generator) Generate(ctx context.Context, sessionID string) (*Report, error) {
+	session, err := g.draftRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	picks, err := g.draftRepo.GetPicksBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get picks: %w", err)
+	}
+	packs, err := g.draftRepo.GetPacksBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get packs: %w", err)
+	}
+
+	// Ratings are cached under the event name, as for signals and prediction
+	format := session.EventName
+	report := &Report{
+		SessionID:       session.ID,
+		SetCode:         session.SetCode,
+		EventName:       session.EventName,
+		DraftFormat:     format,
+		Status:          session.Status,
+		StartTime:       session.StartTime,
+		GeneratedAt:     time.Now(),
+		Picks:           []PickReview{},
+		CardPerformance: []CardPerformance{},
+		Record:          Record{Matches: []MatchLine{}},
+	}
+	if session.PredictedWinRate != nil {
+		report.Prediction = &Prediction{WinRate: *session.PredictedWinRate, PredictedAt: session.PredictedAt}
+		if session.PredictedWinRateMin != nil {
+			report.Prediction.WinRateMin = *session.PredictedWinRateMin
+		}
+		if session.PredictedWinRateMax != nil {
+			report.Prediction.WinRateMax = *session.PredictedWinRateMax
+		}
+	}
+	if len(picks) > 0 {
+		grade, err := grading.NewCalculator(g.draftRepo, g.ratingsRepo, g.setCardRepo).CalculateGrade(ctx, sessionID)
+		if err != nil {
+			log.Printf("Warning: Failed to grade draft %s for post-mortem: %v", sessionID, err)
+		} else {
+			report.Grade = grade
+		}
+	}
+
+	names := newCardNames(ctx, g.ratingsRepo, g.setCardRepo, session.SetCode, format)
+	report.Picks, report.Agreement = g.reviewPicks(ctx, session, format, picks, packs, names)
+
+	deck := g.deckComparison(ctx, session, format, picks, names)
+	report.Deck = deck
+
+	finalColors := ""
+	if deck != nil && deck.Built != nil {
+		finalColors = deck.Built.Colors
+	}
+	report.Pivot = findPivot(report.Picks, finalColors)
+
+	matches, err := g.eventMatches(ctx, session)
+	if err != nil {
+		log.Printf("Warning: Failed to get matches for draft %s: %v", sessionID, err)
+	}
+	for _, m := range matches {
+		switch m.Result {
+		case "win":
+			report.Record.Wins++
+		case "loss":
+			report.Record.Losses++
+		}
+		report.Record.Matches = append(report.Record.Matches, MatchLine{
+			MatchID:      m.ID,
+			Result:       m.Result,
+			PlayerWins:   m.PlayerWins,
+			OpponentWins: m.OpponentWins,
+			Timestamp:    m.Timestamp,
+		})
+	}
+	report.CardPerformance = g.cardPerformance(ctx, matches, deck, names)
+
+	return report, nil
+}
+
+// reviewPicks scores every pack against the pool drafted before it and
+// records what the evaluator would have taken.
+func (g *Generator) reviewPicks(ctx context.Context, session *models.DraftSession, format string, picks []*models.DraftPickSession, packs []*models.DraftPackSession, names *cardNames) ([]PickReview, float64) {
+	sorted := append([]*models.DraftPickSession(nil), picks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].PackNumber != sorted[j].PackNumber {
+			return sorted[i].PackNumber < sorted[j].PackNumber
+		}
+		return sorted[i].PickNumber < sorted[j].PickNumber
+	})
+	packByPick := make(map[[2]int]*models.DraftPackSession, len(packs))
+	for _, pack := range packs {
+		packByPick[[2]int{pack.PackNumber, pack.PickNumber}] = pack
+	}
+
+	contextAnalyzer, err := pickquality.NewAnalyzer(g.ratingsRepo, g.setCardRepo).NewContextAnalyzer(ctx, session.SetCode, format)
+	if err != nil {
+		log.Printf("Warning: Pick evaluator unavailable for %s/%s: %v", session.SetCode, format, err)
+	}
+
+	reviews := make([]PickReview, 0, len(sorted))
+	pool := make([]string, 0, len(sorted))
+	counts := make(map[string]float64)
+	scored, agreed := 0, 0
+	for _, pick := range sorted {
+		review := PickReview{
+			PackNumber: pick.PackNumber,
+			PickNumber: pick.PickNumber,
+			CardID:     pick.CardID,
+			CardName:   names.name(ctx, pick.CardID),
+		}
+		if pick.PickQualityGrade != nil {
+			review.Grade = *pick.PickQualityGrade
+		}
+
+		if pack, ok := packByPick[[2]int{pick.PackNumber, pick.PickNumber}]; ok && contextAnalyzer != nil && len(pack.CardIDs) > 0 {
+			scores := contextAnalyzer.ScorePack(ctx, pickquality.PickContext{Pool: pool, TotalPicks: session.TotalPicks}, pack.CardIDs)
+			review.PackSize = len(scores)
+			review.BestCardID = scores[0].CardID
+			review.BestCardName = scores[0].Name
+			review.BestScore = stats.Round(scores[0].Factors.Total(), 1)
+			for i, s := range scores {
+				if s.CardID == pick.CardID {
+					review.Rank = i + 1
+					review.Score = stats.Round(s.Factors.Total(), 1)
+					break
+				}
+			}
+			if review.Rank > 0 {
+				scored++
+				// Equal scores count as agreement; the evaluator had no preference
+				review.Agreed = review.Rank == 1 || review.Score >= review.BestScore
+				if review.Agreed {
+					agreed++
+				}
+			}
+		}
+
+		pool = append(pool, pick.CardID)
+		colors := names.colors(ctx, pick.CardID)
+		for _, c := range colors {
+			counts[string(c)] += 1 / float64(len(colors))
+		}
+		review.PoolColors = leadingColors(counts)
+		reviews = append(reviews, review)
+	}
+
+	agreement := 0.0
+	if scored > 0 {
+		agreement = float64(agreed) / float64(scored)
+	}
+	return reviews, agreement
+}
+
+// findPivot returns the first pick after which the pool's leading colors
+// stayed on the final colors for the rest of the draft. finalColors falls back
+// to the pool's colors after the last pick.
+func findPivot(reviews []PickReview, finalColors string) *Pivot {
+	if len(reviews) == 0 {
+		return nil
+	}
+	final := colorid.Key(finalColors)
+	if len(final) != 2 {
+		final = reviews[len(reviews)-1].PoolColors
+	}
+	if final == "" {
+		return nil
+	}
+
+	index := -1
+	for i := len(reviews) - 1; i >= 0; i-- {
+		if reviews[i].PoolColors != final {
+			break
+		}
+		index = i
+	}
+	if index == -1 {
+		return nil
+	}
+
+	r := reviews[index]
+	pivot := &Pivot{
+		PickIndex:  index + 1,
+		PackNumber: r.PackNumber,
+		PickNumber: r.PickNumber,
+		CardName:   r.CardName,
+		Colors:     final,
+	}
+	if index > 0 {
+		pivot.PreviousColors = reviews[index-1].PoolColors
+	}
+	return pivot
+}
+
+// deckComparison summarizes the deck built from the draft and the deck
+// suggester's best build from the pool.
+func (g *Generator) deckComparison(ctx context.Context, session *models.DraftSession, format string, picks []*models.DraftPickSession, names *cardNames) *DeckComparison {
+	comparison := &DeckComparison{OnlyBuilt: []string{}, OnlyOptimal: []string{}}
+
+	deck, err := g.deckRepo.GetByDraftEvent(ctx, session.ID)
+	if err != nil {
+		log.Printf("Warning: Failed to get deck for draft %s: %v", session.ID, err)
+	}
+	if deck != nil {
+		cards, err := g.deckRepo.GetCards(ctx, deck.ID)
+		if err != nil {
+			log.Printf("Warning: Failed to get cards for deck %s: %v", deck.ID, err)
+		} else {
+			quantities := make(map[int]int)
+			for _, c := range cards {
+				if c.Board == "main" {
+					quantities[c.CardID] += c.Quantity
+				}
+			}
+			comparison.Built = names.summarize(ctx, deck.Name, quantities)
+		}
+	}
+
+	if g.builder != nil && len(picks) > 0 {
+		pool := make([]int, 0, len(picks))
+		for _, pick := range picks {
+			if id, err := strconv.Atoi(pick.CardID); err == nil {
+				pool = append(pool, id)
+			}
+		}
+		suggestions, err := g.builder.SuggestDecks(ctx, pool, session.SetCode, format)
+		if err != nil {
+			log.Printf("Warning: Failed to build decks for draft %s: %v", session.ID, err)
+		} else if suggestions != nil && len(suggestions.Suggestions) > 0 {
+			best := suggestions.Suggestions[0]
+			quantities := make(map[int]int)
+			for _, c := range best.Spells {
+				quantities[c.CardID]++
+			}
+			comparison.Optimal = names.summarize(ctx, best.ColorCombo.Name, quantities)
+		}
+	}
+
+	if comparison.Built == nil && comparison.Optimal == nil {
+		return nil
+	}
+	if comparison.Built != nil && comparison.Optimal != nil {
+		builtCounts := cardCounts(comparison.Built)
+		optimalCounts := cardCounts(comparison.Optimal)
+		for _, c := range comparison.Built.Cards {
+			shared := min(c.Quantity, optimalCounts[c.CardID])
+			comparison.Overlap += shared
+			for i := shared; i < c.Quantity; i++ {
+				comparison.OnlyBuilt = append(comparison.OnlyBuilt, c.Name)
+			}
+		}
+		for _, c := range comparison.Optimal.Cards {
+			for i := builtCounts[c.CardID]; i < c.Quantity; i++ {
+				comparison.OnlyOptimal = append(comparison.OnlyOptimal, c.Name)
+			}
+		}
+	}
+	return comparison
+}
+
+// eventMatches returns the matches played in the draft's event: same event
+// name, from the draft's start until the next draft of the same event.
+func (g *Generator) eventMatches(ctx context.Context, session *models.DraftSession) ([]*models.Match, error) {
+	eventName := session.EventName
+	startTime := session.StartTime
+	filter := models.StatsFilter{EventName: &eventName, StartDate: &startTime}
+
+	sessions, err := g.draftRepo.GetCompletedSessions(ctx, maxEventSessions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completed drafts: %w", err)
+	}
+	var end *time.Time
+	for _, other := range sessions {
+		if other.ID == session.ID || other.EventName != session.EventName || !other.StartTime.After(session.StartTime) {
+			continue
+		}
+		if end == nil || other.StartTime.Before(*end) {
+			t := other.StartTime
+			end = &t
+		}
+	}
+	filter.EndDate = end
+
+	matches, err := g.matchRepo.GetMatches(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	inEvent := make([]*models.Match, 0, len(matches))
+	for _, m := range matches {
+		if end != nil && !m.Timestamp.Before(*end) {
+			continue
+		}
+		inEvent = append(inEvent, m)
+	}
+	sort.Slice(inEvent, func(i, j int) bool { return inEvent[i].Timestamp.Before(inEvent[j].Timestamp) })
+	return inEvent, nil
+}
+
+// cardPerformance totals the user's casts per card across the event's
+// matches, best win rate first.
+func (g *Generator) cardPerformance(ctx context.Context, matches []*models.Match, deck *DeckComparison, names *cardNames) []CardPerformance {
+	inDeck := make(map[int]bool)
+	if deck != nil && deck.Built != nil {
+		for _, c := range deck.Built.Cards {
+			inDeck[c.CardID] = true
+		}
+	}
+
+	type tally struct {
+		perf     CardPerformance
+		turnSum  int
+		inMatch  map[string]bool
+		wonMatch map[string]bool
+	}
+	tallies := make(map[int]*tally)
+	for _, m := range matches {
+		plays, err := g.playRepo.GetPlaysByMatch(ctx, m.ID)
+		if err != nil {
+			log.Printf("Warning: Failed to get plays for match %s: %v", m.ID, err)
+			continue
+		}
+		for _, play := range plays {
+			if play.PlayerType != "player" || play.ActionType != "play_card" || play.CardID == nil {
+				continue
+			}
+			t := tallies[*play.CardID]
+			if t == nil {
+				name := names.name(ctx, strconv.Itoa(*play.CardID))
+				if play.CardName != nil && *play.CardName != "" {
+					name = *play.CardName
+				}
+				t = &tally{
+					perf:     CardPerformance{CardID: *play.CardID, Name: name, InDeck: inDeck[*play.CardID]},
+					inMatch:  make(map[string]bool),
+					wonMatch: make(map[string]bool),
+				}
+				tallies[*play.CardID] = t
+			}
+			t.perf.Casts++
+			t.turnSum += play.TurnNumber
+			t.inMatch[m.ID] = true
+			if m.Result == "win" {
+				t.wonMatch[m.ID] = true
+			}
+		}
+	}
+
+	performance := make([]CardPerformance, 0, len(tallies))
+	for _, t := range tallies {
+		t.perf.MatchesCast = len(t.inMatch)
+		t.perf.WinsWhenCast = len(t.wonMatch)
+		t.perf.WinRateWhenCast = float64(t.perf.WinsWhenCast) / float64(t.perf.MatchesCast)
+		t.perf.AvgTurn = stats.Round(float64(t.turnSum)/float64(t.perf.Casts), 1)
+		performance = append(performance, t.perf)
+	}
+	sort.Slice(performance, func(i, j int) bool {
+		a, b := performance[i], performance[j]
+		if a.WinRateWhenCast != b.WinRateWhenCast {
+			return a.WinRateWhenCast > b.WinRateWhenCast
+		}
+		if a.MatchesCast != b.MatchesCast {
+			return a.MatchesCast > b.MatchesCast
+		}
+		return a.Name < b.Name
+	})
+	return performance
+}
+
+// cardNames resolves names, colors and GIHWR for the draft's cards.
+type cardNames struct {
+	setCardRepo repository.SetCardRepository
+	ratings     map[string]seventeenlands.CardRating
+	cache       map[string]*models.SetCard
+}
+
+func newCardNames(ctx context.Context, ratingsRepo repository.DraftRatingsRepository, setCardRepo repository.SetCardRepository, setCode, format string) *cardNames {
+	n := &cardNames{
+		setCardRepo: setCardRepo,
+		ratings:     make(map[string]seventeenlands.CardRating),
+		cache:       make(map[string]*models.SetCard),
+	}
+	if ratings, _, err := ratingsRepo.GetCardRatings(ctx, setCode, format); err == nil {
+		for _, r := range ratings {
+			if r.MTGAID != 0 {
+				n.ratings[strconv.Itoa(r.MTGAID)] = r
+			}
+		}
+	}
+	return n
+}
+
+func (n *cardNames) card(ctx context.Context, cardID string) *models.SetCard {
+	if card, ok := n.cache[cardID]; ok {
+		return card
+	}
+	card, err := n.setCardRepo.GetCardByArenaID(ctx, cardID)
+	if err != nil {
+		card = nil
+	}
+	n.cache[cardID] = card
+	return card
+}
+
+func (n *cardNames) name(ctx context.Context, cardID string) string {
+	if card := n.card(ctx, cardID); card != nil && card.Name != "" {
+		return card.Name
+	}
+	if r, ok := n.ratings[cardID]; ok && r.Name != "" {
+		return r.Name
+	}
+	return "Card " + cardID
+}
+
+func (n *cardNames) colors(ctx context.Context, cardID string) string {
+	if card := n.card(ctx, cardID); card != nil {
+		return colorid.Key(strings.Join(card.Colors, ""))
+	}
+	return colorid.Key(n.ratings[cardID].Color)
+}
+
+func (n *cardNames) isLand(ctx context.Context, cardID string) bool {
+	card := n.card(ctx, cardID)
+	if card == nil {
+		return false
+	}
+	isLand, isCreature := false, false
+	for _, t := range card.Types {
+		switch t {
+		case "Land":
+			isLand = true
+		case "Creature":
+			isCreature = true
+		}
+	}
+	return isLand && !isCreature
+}
+
+// summarize builds a deck summary from card quantities, leaving out lands.
+func (n *cardNames) summarize(ctx context.Context, name string, quantities map[int]int) *DeckSummary {
+	summary := &DeckSummary{Name: name, Cards: []DeckCard{}}
+	counts := make(map[string]float64)
+	var gihwrSum float64
+	rated := 0
+	for id, quantity := range quantities {
+		cardID := strconv.Itoa(id)
+		if quantity <= 0 || n.isLand(ctx, cardID) {
+			continue
+		}
+		card := DeckCard{CardID: id, Name: n.name(ctx, cardID), Quantity: quantity}
+		if r, ok := n.ratings[cardID]; ok && r.GIHWR > 0 {
+			card.GIHWR = r.GIHWR
+			gihwrSum += r.GIHWR * float64(quantity)
+			rated += quantity
+		}
+		colors := n.colors(ctx, cardID)
+		for _, c := range colors {
+			counts[string(c)] += float64(quantity) / float64(len(colors))
+		}
+		summary.Spells += quantity
+		summary.Cards = append(summary.Cards, card)
+	}
+	sort.Slice(summary.Cards, func(i, j int) bool {
+		if summary.Cards[i].GIHWR != summary.Cards[j].GIHWR {
+			return summary.Cards[i].GIHWR > summary.Cards[j].GIHWR
+		}
+		return summary.Cards[i].Name < summary.Cards[j].Name
+	})
+	if rated > 0 {
+		summary.AvgGIHWR = stats.Round(gihwrSum/float64(rated), 1)
+	}
+	summary.Colors = leadingColors(counts)
+	return summary
+}
+
+func cardCounts(deck *DeckSummary) map[int]int {
+	counts := make(map[int]int, len(deck.Cards))
+	for _, c := range deck.Cards {
+		counts[c.CardID] += c.Quantity
+	}
+	return counts
+}
+
+// leadingColors returns the two colors with the most weight in WUBRG order.
+func leadingColors(counts map[string]float64) string {
+	colors := make([]string, 0, len(counts))
+	for c, w := range counts {
+		if w > 0 {
+			colors = append(colors, c)
+		}
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		if counts[colors[i]] != counts[colors[j]] {
+			return counts[colors[i]] > counts[colors[j]]
+		}
+		return colorIndex(colors[i]) < colorIndex(colors[j])
+	})
+	if len(colors) > 2 {
+		colors = colors[:2]
+	}
+	return colorid.Key(strings.Join(colors, ""))
+}
+
+func colorIndex(c string) int {
+	for i, w := range colorid.WUBRG {
+		if w == c {
+			return i
+		}
+	}
+	return len(colorid.WUBRG)
+}
@@ -0,0 +1,279 @@
+package postmortem
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/recommendations"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+var start = time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeDraftRepo struct {
+	repository.DraftRepository
+	sessions []*models.DraftSession
+	picks    []*models.DraftPickSession
+	packs    []*models.DraftPackSession
+}
+
+func (f *fakeDraftRepo) GetSession(ctx context.Context, id string) (*models.DraftSession, error) {
+	for _, s := range f.sessions {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeDraftRepo) GetPicksBySession(ctx context.Context, sessionID string) ([]*models.DraftPickSession, error) {
+	return f.picks, nil
+}
+
+func (f *fakeDraftRepo) GetPacksBySession(ctx context.Context, sessionID string) ([]*models.DraftPackSession, error) {
+	return f.packs, nil
+}
+
+func (f *fakeDraftRepo) GetCompletedSessions(ctx context.Context, limit int) ([]*models.DraftSession, error) {
+	return f.sessions, nil
+}
+
+type fakeRatingsRepo struct {
+	repository.DraftRatingsRepository
+	cards []seventeenlands.CardRating
+}
+
+func (f *fakeRatingsRepo) GetCardRatings(ctx context.Context, setCode, draftFormat string) ([]seventeenlands.CardRating, time.Time, error) {
+	return f.cards, time.Now(), nil
+}
+
+func (f *fakeRatingsRepo) GetColorRatings(ctx context.Context, setCode, draftFormat string) ([]seventeenlands.ColorRating, time.Time, error) {
+	return nil, time.Now(), nil
+}
+
+func (f *fakeRatingsRepo) GetCardRatingByArenaID(ctx context.Context, setCode, draftFormat, arenaID string) (*seventeenlands.CardRating, error) {
+	for i := range f.cards {
+		if strconv.Itoa(f.cards[i].MTGAID) == arenaID {
+			return &f.cards[i], nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeSetCardRepo struct {
+	repository.SetCardRepository
+	cards map[string]*models.SetCard
+}
+
+func (f *fakeSetCardRepo) GetCardByArenaID(ctx context.Context, arenaID string) (*models.SetCard, error) {
+	return f.cards[arenaID], nil
+}
+
+type fakeDeckRepo struct {
+	repository.DeckRepository
+	deck  *models.Deck
+	cards []*models.DeckCard
+}
+
+func (f *fakeDeckRepo) GetByDraftEvent(ctx context.Context, draftEventID string) (*models.Deck, error) {
+	return f.deck, nil
+}
+
+func (f *fakeDeckRepo) GetCards(ctx context.Context, deckID string) ([]*models.DeckCard, error) {
+	return f.cards, nil
+}
+
+// fakeMatchRepo filters by event name and start date like the real query.
+type fakeMatchRepo struct {
+	repository.MatchRepository
+	matches []*models.Match
+}
+
+func (f *fakeMatchRepo) GetMatches(ctx context.Context, filter models.StatsFilter) ([]*models.Match, error) {
+	var matches []*models.Match
+	for _, m := range f.matches {
+		if filter.EventName != nil && m.EventName != *filter.EventName {
+			continue
+		}
+		if filter.StartDate != nil && m.Timestamp.Before(*filter.StartDate) {
+			continue
+		}
+		matches = append(matches, m)
+	}
+	return matches, nil
+}
+
+type fakePlayRepo struct {
+	repository.GamePlayRepository
+	plays map[string][]*models.GamePlay
+}
+
+func (f *fakePlayRepo) GetPlaysByMatch(ctx context.Context, matchID string) ([]*models.GamePlay, error) {
+	return f.plays[matchID], nil
+}
+
+type fakeBuilder struct {
+	spells []int
+}
+
+func (f *fakeBuilder) SuggestDecks(ctx context.Context, draftPool []int, setCode, draftFormat string) (*recommendations.SuggestDecksResponse, error) {
+	deck := &recommendations.SuggestedDeck{ColorCombo: recommendations.ColorCombination{Name: "Boros"}}
+	for _, id := range f.spells {
+		deck.Spells = append(deck.Spells, &recommendations.SuggestedCard{CardID: id})
+	}
+	return &recommendations.SuggestDecksResponse{Suggestions: []*recommendations.SuggestedDeck{deck}}, nil
+}
+
+func play(playerType string, cardID, turn int) *models.GamePlay {
+	return &models.GamePlay{PlayerType: playerType, ActionType: "play_card", CardID: &cardID, TurnNumber: turn}
+}
+
+// newTestGenerator builds a six-pick draft: U, W, R, R, W, R. The pool leads
+// with WU until the second red card, then stays WR.
+func newTestGenerator() *Generator {
+	cards := map[string]*models.SetCard{}
+	var ratings []seventeenlands.CardRating
+	for _, c := range []struct {
+		id    int
+		color string
+		gihwr float64
+	}{
+		{1001, "W", 58}, {1002, "U", 62}, {1003, "R", 55}, {1004, "W", 57},
+		{1005, "R", 50}, {1006, "R", 56}, {1007, "G", 48}, {1008, "B", 66},
+	} {
+		id := strconv.Itoa(c.id)
+		cards[id] = &models.SetCard{ArenaID: id, Name: "Card " + id, Colors: []string{c.color}, Types: []string{"Creature"}, Rarity: "common"}
+		ratings = append(ratings, seventeenlands.CardRating{MTGAID: c.id, Name: "Card " + id, Color: c.color, GIHWR: c.gihwr})
+	}
+
+	session := &models.DraftSession{ID: "draft-1", EventName: "PremierDraft_TST", SetCode: "TST", DraftType: "PremierDraft", StartTime: start, Status: "completed", TotalPicks: 6}
+	next := &models.DraftSession{ID: "draft-2", EventName: "PremierDraft_TST", SetCode: "TST", DraftType: "PremierDraft", StartTime: start.Add(48 * time.Hour), Status: "completed"}
+
+	var picks []*models.DraftPickSession
+	for i, id := range []string{"1002", "1001", "1003", "1005", "1004", "1006"} {
+		picks = append(picks, &models.DraftPickSession{SessionID: "draft-1", PackNumber: i / 3, PickNumber: i%3 + 1, CardID: id})
+	}
+	packs := []*models.DraftPackSession{
+		{SessionID: "draft-1", PackNumber: 0, PickNumber: 1, CardIDs: []string{"1007", "1002"}},
+		{SessionID: "draft-1", PackNumber: 1, PickNumber: 1, CardIDs: []string{"1005", "1008"}},
+	}
+
+	deckCards := []*models.DeckCard{}
+	for _, id := range []int{1001, 1003, 1004, 1005, 1006} {
+		deckCards = append(deckCards, &models.DeckCard{CardID: id, Quantity: 1, Board: "main"})
+	}
+	deckCards = append(deckCards, &models.DeckCard{CardID: 1002, Quantity: 1, Board: "sideboard"})
+
+	matches := []*models.Match{
+		{ID: "before", EventName: "PremierDraft_TST", Timestamp: start.Add(-time.Hour), Result: "win"},
+		{ID: "m1", EventName: "PremierDraft_TST", Timestamp: start.Add(time.Hour), Result: "win"},
+		{ID: "m2", EventName: "PremierDraft_TST", Timestamp: start.Add(2 * time.Hour), Result: "loss"},
+		{ID: "other-event", EventName: "QuickDraft_TST", Timestamp: start.Add(3 * time.Hour), Result: "win"},
+		{ID: "next-draft", EventName: "PremierDraft_TST", Timestamp: start.Add(49 * time.Hour), Result: "win"},
+	}
+	plays := map[string][]*models.GamePlay{
+		"m1":         {play("player", 1005, 2), play("player", 1005, 4), play("opponent", 1001, 3)},
+		"m2":         {play("player", 1005, 3), play("player", 1006, 5)},
+		"before":     {play("player", 1001, 1)},
+		"next-draft": {play("player", 1001, 1)},
+	}
+
+	return NewGenerator(
+		&fakeDraftRepo{sessions: []*models.DraftSession{session, next}, picks: picks, packs: packs},
+		&fakeMatchRepo{matches: matches},
+		&fakeDeckRepo{deck: &models.Deck{ID: "deck-1", Name: "My Boros"}, cards: deckCards},
+		&fakePlayRepo{plays: plays},
+		&fakeRatingsRepo{cards: ratings},
+		&fakeSetCardRepo{cards: cards},
+		&fakeBuilder{spells: []int{1001, 1004, 1005, 1006, 1008}},
+	)
+}
+
+func TestGenerate_PicksAndPivot(t *testing.T) {
+	report, err := newTestGenerator().Generate(context.Background(), "draft-1")
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	if len(report.Picks) != 6 {
+		t.Fatalf("got %d pick reviews, want 6", len(report.Picks))
+	}
+	first := report.Picks[0]
+	if !first.Agreed || first.Rank != 1 || first.PackSize != 2 {
+		t.Errorf("P1p1 = %+v, want agreement at rank 1 of 2", first)
+	}
+	fourth := report.Picks[3]
+	if fourth.Agreed || fourth.BestCardID != "1008" || fourth.Rank != 2 {
+		t.Errorf("P2p1 = %+v, want the evaluator to prefer 1008", fourth)
+	}
+	if report.Picks[1].Rank != 0 {
+		t.Errorf("pick without a stored pack has rank %d, want 0", report.Picks[1].Rank)
+	}
+	if report.Agreement != 0.5 {
+		t.Errorf("Agreement = %v, want 0.5", report.Agreement)
+	}
+
+	if report.Pivot == nil {
+		t.Fatal("expected a pivot")
+	}
+	if report.Pivot.PickIndex != 4 || report.Pivot.Colors != "WR" || report.Pivot.PreviousColors != "WU" {
+		t.Errorf("Pivot = %+v, want pick 4 from WU to WR", report.Pivot)
+	}
+}
+
+func TestGenerate_DeckComparison(t *testing.T) {
+	report, err := newTestGenerator().Generate(context.Background(), "draft-1")
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	deck := report.Deck
+	if deck == nil || deck.Built == nil || deck.Optimal == nil {
+		t.Fatalf("Deck = %+v, want built and optimal decks", deck)
+	}
+	if deck.Built.Spells != 5 || deck.Built.Colors != "WR" {
+		t.Errorf("Built = %d spells %s, want 5 WR (sideboard excluded)", deck.Built.Spells, deck.Built.Colors)
+	}
+	if deck.Overlap != 4 {
+		t.Errorf("Overlap = %d, want 4", deck.Overlap)
+	}
+	if len(deck.OnlyBuilt) != 1 || deck.OnlyBuilt[0] != "Card 1003" {
+		t.Errorf("OnlyBuilt = %v, want [Card 1003]", deck.OnlyBuilt)
+	}
+	if len(deck.OnlyOptimal) != 1 || deck.OnlyOptimal[0] != "Card 1008" {
+		t.Errorf("OnlyOptimal = %v, want [Card 1008]", deck.OnlyOptimal)
+	}
+}
+
+func TestGenerate_EventMatchesAndCardPerformance(t *testing.T) {
+	report, err := newTestGenerator().Generate(context.Background(), "draft-1")
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	if report.Record.Wins != 1 || report.Record.Losses != 1 || len(report.Record.Matches) != 2 {
+		t.Fatalf("Record = %+v, want 1-1 over m1 and m2", report.Record)
+	}
+
+	if len(report.CardPerformance) != 2 {
+		t.Fatalf("got %d cards, want 2 (opponent and out-of-event plays excluded)", len(report.CardPerformance))
+	}
+	top := report.CardPerformance[0]
+	if top.CardID != 1005 || top.Casts != 3 || top.MatchesCast != 2 || top.WinsWhenCast != 1 || top.AvgTurn != 3 || !top.InDeck {
+		t.Errorf("CardPerformance[0] = %+v, want 1005 cast 3 times over 2 matches", top)
+	}
+	if report.CardPerformance[1].WinRateWhenCast != 0 {
+		t.Errorf("CardPerformance[1] = %+v, want 0 win rate", report.CardPerformance[1])
+	}
+}
+
+func TestGenerate_SessionNotFound(t *testing.T) {
+	if _, err := newTestGenerator().Generate(context.Background(), "missing"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Generate returned %v, want ErrSessionNotFound", err)
+	}
+}
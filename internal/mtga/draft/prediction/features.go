@@ -0,0 +1,300 @@
+package prediction
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/colorid"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+const (
+	deckSize           = 23   // Nonland cards in a typical limited deck
+	bombGIHWR          = 60.0 // GIHWR (percent) at which a card counts as a bomb
+	topCardCount       = 5    // Cards averaged for TopGIHWR
+	defaultPairWinRate = 50.0 // Used when 17Lands has no rating for the deck's colors
+)
+
+// DeckCard is a card with the 17Lands and set data the learned predictor reads.
+// Win rates are in 17Lands percentage units (55.2, not 0.552).
+type DeckCard struct {
+	Name       string
+	CMC        int
+	Colors     string // WUBRG letters, "" for colorless
+	GIHWR      float64
+	IWD        float64 // Improvement when drawn, in percentage points
+	Rated      bool    // Whether 17Lands has a rating for the card
+	IsCreature bool
+	IsRemoval  bool
+	IsLand     bool
+}
+
+// DeckFeatures are the inputs of the learned predictor. Every field is a
+// number so the model can standardize and weight it.
+type DeckFeatures struct {
+	AvgGIHWR         float64 `json:"avg_gihwr"`           // Mean GIHWR of rated cards
+	TopGIHWR         float64 `json:"top_gihwr"`           // Mean GIHWR of the five best cards
+	AvgIWD           float64 `json:"avg_iwd"`             // Mean improvement when drawn
+	CurveScore       float64 `json:"curve_score"`         // evaluateCurve score (0-1)
+	TwoDrops         float64 `json:"two_drops"`           // Nonland cards with CMC <= 2
+	Removal          float64 `json:"removal"`             // Removal spells
+	Creatures        float64 `json:"creatures"`           // Creatures
+	Bombs            float64 `json:"bombs"`               // Cards at or above 60% GIHWR
+	ColorPairWinRate float64 `json:"color_pair_win_rate"` // 17Lands win rate of the deck's two main colors (percent)
+	Colors           float64 `json:"colors"`              // Colors with at least one card
+}
+
+// FeatureNames lists DeckFeatures in the order the model stores its weights.
+var FeatureNames = []string{
+	"avg_gihwr", "top_gihwr", "avg_iwd", "curve_score", "two_drops",
+	"removal", "creatures", "bombs", "color_pair_win_rate", "colors",
+}
+
+// Vector returns the features in FeatureNames order.
+func (f DeckFeatures) Vector() []float64 {
+	return []float64{
+		f.AvgGIHWR, f.TopGIHWR, f.AvgIWD, f.CurveScore, f.TwoDrops,
+		f.Removal, f.Creatures, f.Bombs, f.ColorPairWinRate, f.Colors,
+	}
+}
+
+// ExtractFeatures summarizes a deck's nonland cards. pairWinRates maps a
+// WUBRG-ordered color pair ("WU") to its 17Lands win rate in percent.
+func ExtractFeatures(deck []DeckCard, pairWinRates map[string]float64) DeckFeatures {
+	var f DeckFeatures
+	var gihwrs []float64
+	var iwdSum float64
+	curve := make(map[int]int)
+	colorCounts := make(map[string]int)
+	spells := 0
+
+	for _, card := range deck {
+		if card.IsLand {
+			continue
+		}
+		spells++
+		curve[card.CMC]++
+		if card.CMC <= 2 {
+			f.TwoDrops++
+		}
+		if card.IsCreature {
+			f.Creatures++
+		}
+		if card.IsRemoval {
+			f.Removal++
+		}
+		for _, c := range card.Colors {
+			colorCounts[string(c)]++
+		}
+		if !card.Rated {
+			continue
+		}
+		gihwrs = append(gihwrs, card.GIHWR)
+		iwdSum += card.IWD
+		if card.GIHWR >= bombGIHWR {
+			f.Bombs++
+		}
+	}
+
+	if len(gihwrs) > 0 {
+		var sum float64
+		for _, g := range gihwrs {
+			sum += g
+		}
+		f.AvgGIHWR = sum / float64(len(gihwrs))
+		f.AvgIWD = iwdSum / float64(len(gihwrs))
+
+		sort.Sort(sort.Reverse(sort.Float64Slice(gihwrs)))
+		top := gihwrs
+		if len(top) > topCardCount {
+			top = top[:topCardCount]
+		}
+		sum = 0
+		for _, g := range top {
+			sum += g
+		}
+		f.TopGIHWR = sum / float64(len(top))
+	}
+	if spells > 0 {
+		f.CurveScore = evaluateCurve(curve, spells)
+	}
+
+	f.Colors = float64(len(colorCounts))
+	f.ColorPairWinRate = defaultPairWinRate
+	if wr, ok := pairWinRates[mainPair(colorCounts)]; ok && wr > 0 {
+		f.ColorPairWinRate = wr
+	}
+	return f
+}
+
+// SelectDeck approximates the deck built from a draft pool: the two colors with
+// the most playable cards, then the best on-color and colorless cards up to a
+// full deck, topped up with the best remaining cards if the colors run short.
+func SelectDeck(pool []DeckCard) []DeckCard {
+	// Score each color by how much playable quality it offers
+	strength := make(map[string]float64)
+	for _, card := range pool {
+		if card.IsLand || len(card.Colors) == 0 {
+			continue
+		}
+		weight := 1.0
+		if card.Rated {
+			weight = 1 + (card.GIHWR-defaultPairWinRate)/10
+		}
+		if weight <= 0 {
+			continue
+		}
+		for _, c := range card.Colors {
+			strength[string(c)] += weight / float64(len(card.Colors))
+		}
+	}
+	colors := make([]string, 0, len(strength))
+	for c := range strength {
+		colors = append(colors, c)
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		if strength[colors[i]] != strength[colors[j]] {
+			return strength[colors[i]] > strength[colors[j]]
+		}
+		return colors[i] < colors[j]
+	})
+	if len(colors) > 2 {
+		colors = colors[:2]
+	}
+	pair := strings.Join(colors, "")
+
+	var onColor, offColor []DeckCard
+	for _, card := range pool {
+		if card.IsLand {
+			continue
+		}
+		if containsColors(pair, card.Colors) {
+			onColor = append(onColor, card)
+		} else {
+			offColor = append(offColor, card)
+		}
+	}
+	byQuality := func(cards []DeckCard) {
+		sort.SliceStable(cards, func(i, j int) bool { return cards[i].GIHWR > cards[j].GIHWR })
+	}
+	byQuality(onColor)
+	byQuality(offColor)
+
+	deck := onColor
+	if len(deck) > deckSize {
+		deck = deck[:deckSize]
+	}
+	for _, card := range offColor {
+		if len(deck) >= deckSize {
+			break
+		}
+		deck = append(deck, card)
+	}
+	return deck
+}
+
+// loadPool builds DeckCards for a session's picks and the 17Lands pair win
+// rates for its set.
+func loadPool(ctx context.Context, ratingsRepo repository.DraftRatingsRepository, setCardRepo repository.SetCardRepository, session *models.DraftSession, picks []*models.DraftPickSession) ([]DeckCard, map[string]float64) {
+	ratings := make(map[string]seventeenlands.CardRating)
+	if cardRatings, _, err := ratingsRepo.GetCardRatings(ctx, session.SetCode, session.EventName); err == nil {
+		for _, r := range cardRatings {
+			if r.MTGAID != 0 {
+				ratings[strconv.Itoa(r.MTGAID)] = r
+			}
+		}
+	}
+	pairWinRates := make(map[string]float64)
+	if colorRatings, _, err := ratingsRepo.GetColorRatings(ctx, session.SetCode, session.EventName); err == nil {
+		pairWinRates = PairWinRates(colorRatings)
+	}
+
+	cache := make(map[string]DeckCard)
+	pool := make([]DeckCard, 0, len(picks))
+	for _, pick := range picks {
+		card, ok := cache[pick.CardID]
+		if !ok {
+			var setCard *models.SetCard
+			if c, err := setCardRepo.GetCardByArenaID(ctx, pick.CardID); err == nil {
+				setCard = c
+			}
+			rating, rated := ratings[pick.CardID]
+			card = NewDeckCard(setCard, rating, rated)
+			cache[pick.CardID] = card
+		}
+		pool = append(pool, card)
+	}
+	return pool, pairWinRates
+}
+
+// NewDeckCard combines set data and a 17Lands rating. Either may be missing.
+func NewDeckCard(setCard *models.SetCard, rating seventeenlands.CardRating, rated bool) DeckCard {
+	card := DeckCard{
+		Name:  rating.Name,
+		Rated: rated && rating.GIHWR > 0,
+		GIHWR: rating.GIHWR,
+		IWD:   rating.GDWRDelta,
+	}
+	if rated {
+		card.Colors = colorid.Key(rating.Color)
+	}
+	if setCard != nil {
+		card.Name = setCard.Name
+		card.CMC = setCard.CMC
+		card.Colors = colorid.Key(strings.Join(setCard.Colors, ""))
+		typeLine := strings.ToLower(strings.Join(setCard.Types, " "))
+		card.IsCreature = strings.Contains(typeLine, "creature")
+		card.IsLand = strings.Contains(typeLine, "land") && !card.IsCreature
+		card.IsRemoval = cards.IsRemovalText(setCard.Text)
+	}
+	if !card.Rated {
+		card.GIHWR = defaultPairWinRate
+	}
+	return card
+}
+
+// PairWinRates maps 17Lands two-color ratings to WUBRG-ordered keys in percent.
+func PairWinRates(colorRatings []seventeenlands.ColorRating) map[string]float64 {
+	rates := make(map[string]float64)
+	for _, cr := range colorRatings {
+		key := colorid.Key(cr.ColorName)
+		if len(key) != 2 || cr.IsSplash || cr.WinRate <= 0 {
+			continue
+		}
+		rates[key] = cr.WinRate * 100
+	}
+	return rates
+}
+
+// mainPair returns the two most common colors in WUBRG order.
+func mainPair(counts map[string]int) string {
+	colors := make([]string, 0, len(counts))
+	for c := range counts {
+		colors = append(colors, c)
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		if counts[colors[i]] != counts[colors[j]] {
+			return counts[colors[i]] > counts[colors[j]]
+		}
+		return colors[i] < colors[j]
+	})
+	if len(colors) > 2 {
+		colors = colors[:2]
+	}
+	return colorid.Key(strings.Join(colors, ""))
+}
+
+// containsColors reports whether every color of a card is in the deck's colors.
+func containsColors(deckColors, cardColors string) bool {
+	for _, c := range cardColors {
+		if !strings.ContainsRune(deckColors, c) {
+			return false
+		}
+	}
+	return true
+}
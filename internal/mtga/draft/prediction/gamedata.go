@@ -0,0 +1,103 @@
+package prediction
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseGameDataCSV builds training samples from a 17Lands game_data_public CSV.
+// Each row is one game with deck_<card name> counts; rows sharing a draft_id
+// are one deck, and each game counts as a match (the public data is Bo1).
+// Without a draft_id column every row is its own sample. Cards missing from
+// cards (keyed by name) are skipped, which drops basic lands.
+func ParseGameDataCSV(r io.Reader, cards map[string]DeckCard, pairWinRates map[string]float64) ([]TrainingSample, error) {
+	reader := csv.NewReader(r)
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	colWon, colDraft := -1, -1
+	deckCols := make(map[int]string)
+	for i, col := range header {
+		lower := strings.ToLower(col)
+		switch {
+		case lower == "won" || lower == "won_game":
+			colWon = i
+		case lower == "draft_id":
+			colDraft = i
+		case strings.HasPrefix(lower, "deck_"):
+			name := col[len("deck_"):]
+			if _, ok := cards[name]; ok {
+				deckCols[i] = name
+			}
+		}
+	}
+	if colWon == -1 {
+		return nil, errors.New("could not find 'won' column in CSV header")
+	}
+	if len(deckCols) == 0 {
+		return nil, errors.New("no deck_ columns match known cards")
+	}
+
+	var samples []TrainingSample
+	byDraft := make(map[string]int) // draft_id -> index in samples
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil || len(record) <= colWon {
+			continue // Skip malformed rows like the ratings parser does
+		}
+		won := record[colWon] == "1" || strings.EqualFold(record[colWon], "true")
+
+		if colDraft >= 0 && colDraft < len(record) {
+			if idx, ok := byDraft[record[colDraft]]; ok {
+				addResult(&samples[idx], won)
+				continue
+			}
+		}
+
+		var deck []DeckCard
+		for col, name := range deckCols {
+			if col >= len(record) {
+				continue
+			}
+			count, err := strconv.Atoi(record[col])
+			if err != nil {
+				continue
+			}
+			for i := 0; i < count; i++ {
+				deck = append(deck, cards[name])
+			}
+		}
+		if len(deck) == 0 {
+			continue
+		}
+
+		sample := TrainingSample{Features: ExtractFeatures(deck, pairWinRates), Source: "17lands"}
+		addResult(&sample, won)
+		samples = append(samples, sample)
+		if colDraft >= 0 && colDraft < len(record) {
+			byDraft[record[colDraft]] = len(samples) - 1
+		}
+	}
+	return samples, nil
+}
+
+func addResult(s *TrainingSample, won bool) {
+	if won {
+		s.Wins++
+	} else {
+		s.Losses++
+	}
+}
@@ -0,0 +1,448 @@
+package prediction
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+)
+
+// LearnedModelName is the ml_model_metadata name the learned predictor is stored under.
+const LearnedModelName = "draft_win_rate"
+
+const (
+	minTrainingSamples = 5  // Decks needed before fitting a model
+	minHeadlineSamples = 20 // Decks needed before the model replaces the formula's headline prediction
+	maxNewtonSteps     = 50 // Newton iterations when fitting
+	newtonTolerance    = 1e-8
+	defaultLambda      = 1.0  // L2 penalty on standardized weights
+	biasPenalty        = 1e-3 // Keeps the bias finite when every deck won (or lost) every match
+	defaultFolds       = 5
+	defaultBins        = 10
+)
+
+// TrainingSample is one deck and the match record it earned.
+type TrainingSample struct {
+	Features DeckFeatures
+	Wins     int
+	Losses   int
+	Source   string // "local" or "17lands"
+}
+
+// FitOptions controls model fitting.
+type FitOptions struct {
+	Lambda float64 // L2 regularization strength on standardized weights
+	Folds  int     // Cross-validation folds used for calibration
+	Bins   int     // Reliability bins
+}
+
+// DefaultFitOptions returns the fitting defaults.
+func DefaultFitOptions() FitOptions {
+	return FitOptions{Lambda: defaultLambda, Folds: defaultFolds, Bins: defaultBins}
+}
+
+// ReliabilityBin compares predicted and observed match win rates for decks
+// whose prediction fell in [Lower, Upper).
+type ReliabilityBin struct {
+	Lower         float64 `json:"lower"`
+	Upper         float64 `json:"upper"`
+	Matches       int     `json:"matches"`
+	MeanPredicted float64 `json:"mean_predicted"`
+	Observed      float64 `json:"observed"`
+}
+
+// Calibration holds out-of-fold accuracy metrics for a learned model.
+type Calibration struct {
+	Folds         int              `json:"folds"`          // 1 means in-sample
+	BrierScore    float64          `json:"brier_score"`    // Mean squared error per match (lower is better)
+	BaselineBrier float64          `json:"baseline_brier"` // Brier score of always predicting the overall win rate
+	LogLoss       float64          `json:"log_loss"`
+	Accuracy      float64          `json:"accuracy"` // Matches whose outcome matched the more likely prediction
+	Bins          []ReliabilityBin `json:"bins"`
+}
+
+// LearnedModel is an L2-regularized logistic regression of match win
+// probability on standardized DeckFeatures. Each deck contributes its wins and
+// losses as binomial trials; expected event wins follow from the event structure.
+type LearnedModel struct {
+	Version     string         `json:"version"`
+	Features    []string       `json:"features"`
+	Mean        []float64      `json:"mean"`
+	Scale       []float64      `json:"scale"`
+	Weights     []float64      `json:"weights"`
+	Bias        float64        `json:"bias"`
+	Lambda      float64        `json:"lambda"`
+	Samples     int            `json:"samples"`
+	Matches     int            `json:"matches"`
+	Sources     map[string]int `json:"sources"`
+	TrainedAt   time.Time      `json:"trained_at"`
+	Calibration *Calibration   `json:"calibration,omitempty"`
+}
+
+// LearnedPrediction is the learned model's output for one deck, stored in
+// PredictionFactors next to the formula's breakdown.
+type LearnedPrediction struct {
+	ModelVersion  string             `json:"model_version"`
+	Samples       int                `json:"samples"`
+	WinRate       float64            `json:"win_rate"`      // Match win probability (0-1)
+	ExpectedWins  float64            `json:"expected_wins"` // Expected wins for the session's event
+	Features      DeckFeatures       `json:"features"`
+	Contributions map[string]float64 `json:"contributions"` // Feature -> log-odds contribution
+}
+
+// Fit trains a model on the samples.
+func Fit(samples []TrainingSample, opts FitOptions) (*LearnedModel, error) {
+	var usable []TrainingSample
+	matches := 0
+	for _, s := range samples {
+		if s.Wins+s.Losses > 0 {
+			usable = append(usable, s)
+			matches += s.Wins + s.Losses
+		}
+	}
+	if len(usable) < minTrainingSamples {
+		return nil, fmt.Errorf("need at least %d decks with match results, have %d", minTrainingSamples, len(usable))
+	}
+	if opts.Lambda <= 0 {
+		opts.Lambda = defaultLambda
+	}
+	if opts.Bins <= 0 {
+		opts.Bins = defaultBins
+	}
+
+	model, err := fitLogistic(usable, opts.Lambda)
+	if err != nil {
+		return nil, err
+	}
+	model.Samples = len(usable)
+	model.Matches = matches
+	model.Sources = make(map[string]int)
+	for _, s := range usable {
+		model.Sources[s.Source]++
+	}
+	model.TrainedAt = time.Now().UTC()
+	model.Version = model.TrainedAt.Format(time.RFC3339Nano)
+
+	calibration, err := crossValidate(usable, opts)
+	if err != nil {
+		return nil, err
+	}
+	model.Calibration = calibration
+	return model, nil
+}
+
+// WinProbability predicts the match win probability of a deck.
+func (m *LearnedModel) WinProbability(f DeckFeatures) float64 {
+	return sigmoid(m.logit(m.standardize(f.Vector())))
+}
+
+// Predict scores a deck for an event.
+func (m *LearnedModel) Predict(f DeckFeatures, event EventStructure) *LearnedPrediction {
+	x := m.standardize(f.Vector())
+	contributions := make(map[string]float64, len(m.Features))
+	for i, name := range m.Features {
+		contributions[name] = m.Weights[i] * x[i]
+	}
+	p := sigmoid(m.logit(x))
+	return &LearnedPrediction{
+		ModelVersion:  m.Version,
+		Samples:       m.Samples,
+		WinRate:       p,
+		ExpectedWins:  event.ExpectedWins(p),
+		Features:      f,
+		Contributions: contributions,
+	}
+}
+
+func (m *LearnedModel) standardize(v []float64) []float64 {
+	x := make([]float64, len(v))
+	for i := range v {
+		x[i] = (v[i] - m.Mean[i]) / m.Scale[i]
+	}
+	return x
+}
+
+func (m *LearnedModel) logit(x []float64) float64 {
+	z := m.Bias
+	for i := range x {
+		z += m.Weights[i] * x[i]
+	}
+	return z
+}
+
+// ToJSON serializes the model.
+func (m *LearnedModel) ToJSON() ([]byte, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal learned model: %w", err)
+	}
+	return data, nil
+}
+
+// LearnedModelFromJSON parses a serialized model and checks it matches the current features.
+func LearnedModelFromJSON(data []byte) (*LearnedModel, error) {
+	var m LearnedModel
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal learned model: %w", err)
+	}
+	if strings.Join(m.Features, ",") != strings.Join(FeatureNames, ",") ||
+		len(m.Mean) != len(FeatureNames) || len(m.Scale) != len(FeatureNames) || len(m.Weights) != len(FeatureNames) {
+		return nil, errors.New("learned model was trained on a different feature set")
+	}
+	return &m, nil
+}
+
+// ModelStore persists learned models. *repository.MLSuggestionRepository implements it.
+type ModelStore interface {
+	SaveModelMetadata(ctx context.Context, meta *models.MLModelMetadata) error
+	GetActiveModel(ctx context.Context, modelName string) (*models.MLModelMetadata, error)
+}
+
+// SaveLearnedModel stores a model as the active draft win rate model.
+func SaveLearnedModel(ctx context.Context, store ModelStore, m *LearnedModel) error {
+	data, err := m.ToJSON()
+	if err != nil {
+		return err
+	}
+	meta := &models.MLModelMetadata{
+		ModelName:       LearnedModelName,
+		ModelVersion:    m.Version,
+		TrainingSamples: m.Samples,
+		TrainingDate:    &m.TrainedAt,
+		IsActive:        true,
+		ModelData:       data,
+	}
+	if m.Calibration != nil {
+		meta.Accuracy = &m.Calibration.Accuracy
+	}
+	if err := store.SaveModelMetadata(ctx, meta); err != nil {
+		return fmt.Errorf("failed to save learned model: %w", err)
+	}
+	return nil
+}
+
+// LoadLearnedModel returns the active model, or nil if none has been trained.
+func LoadLearnedModel(ctx context.Context, store ModelStore) (*LearnedModel, error) {
+	meta, err := store.GetActiveModel(ctx, LearnedModelName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load learned model: %w", err)
+	}
+	if meta == nil || len(meta.ModelData) == 0 {
+		return nil, nil
+	}
+	return LearnedModelFromJSON(meta.ModelData)
+}
+
+// EventStructure describes how many matches an event runs.
+type EventStructure struct {
+	MaxWins   int // Event ends at this many wins...
+	MaxLosses int // ...or this many losses
+	Matches   int // Fixed number of matches instead, when set
+}
+
+// EventStructureFor guesses the structure from an MTGA event name. Traditional
+// drafts play three matches; Premier, Quick and Sealed run to 7 wins or 3 losses.
+func EventStructureFor(eventName string) EventStructure {
+	if strings.Contains(strings.ToLower(eventName), "trad") {
+		return EventStructure{Matches: 3}
+	}
+	return EventStructure{MaxWins: 7, MaxLosses: 3}
+}
+
+// ExpectedWins is the expected number of match wins at win probability p.
+func (e EventStructure) ExpectedWins(p float64) float64 {
+	if e.Matches > 0 {
+		return p * float64(e.Matches)
+	}
+	if e.MaxWins <= 0 || e.MaxLosses <= 0 {
+		return 0
+	}
+
+	// reach[w][l] is the probability of ever standing at w wins and l losses
+	reach := make([][]float64, e.MaxWins+1)
+	for w := range reach {
+		reach[w] = make([]float64, e.MaxLosses+1)
+	}
+	reach[0][0] = 1
+	expected := 0.0
+	for w := 0; w <= e.MaxWins; w++ {
+		for l := 0; l <= e.MaxLosses; l++ {
+			if w == e.MaxWins || l == e.MaxLosses {
+				expected += reach[w][l] * float64(w)
+				continue
+			}
+			reach[w+1][l] += reach[w][l] * p
+			reach[w][l+1] += reach[w][l] * (1 - p)
+		}
+	}
+	return expected
+}
+
+// fitLogistic fits weights by Newton's method on the penalized binomial likelihood.
+func fitLogistic(samples []TrainingSample, lambda float64) (*LearnedModel, error) {
+	d := len(FeatureNames)
+	m := &LearnedModel{
+		Features: append([]string(nil), FeatureNames...),
+		Mean:     make([]float64, d),
+		Scale:    make([]float64, d),
+		Weights:  make([]float64, d),
+		Lambda:   lambda,
+	}
+
+	// Standardize so one penalty suits features on very different scales
+	for _, s := range samples {
+		for i, v := range s.Features.Vector() {
+			m.Mean[i] += v
+		}
+	}
+	for i := range m.Mean {
+		m.Mean[i] /= float64(len(samples))
+	}
+	for _, s := range samples {
+		for i, v := range s.Features.Vector() {
+			m.Scale[i] += (v - m.Mean[i]) * (v - m.Mean[i])
+		}
+	}
+	for i := range m.Scale {
+		m.Scale[i] = math.Sqrt(m.Scale[i] / float64(len(samples)))
+		if m.Scale[i] < 1e-9 {
+			m.Scale[i] = 1 // Constant feature; its weight stays at 0
+		}
+	}
+
+	xs := make([][]float64, len(samples))
+	for i, s := range samples {
+		xs[i] = m.standardize(s.Features.Vector())
+	}
+
+	// Parameter 0 is the bias, which is only lightly penalized
+	for step := 0; step < maxNewtonSteps; step++ {
+		grad := make([]float64, d+1)
+		hess := make([][]float64, d+1)
+		for i := range hess {
+			hess[i] = make([]float64, d+1)
+		}
+		for i, s := range samples {
+			n := float64(s.Wins + s.Losses)
+			p := sigmoid(m.logit(xs[i]))
+			x := append([]float64{1}, xs[i]...)
+			r := float64(s.Wins) - n*p
+			w := n * p * (1 - p)
+			for a := range x {
+				grad[a] += r * x[a]
+				for b := range x {
+					hess[a][b] += w * x[a] * x[b]
+				}
+			}
+		}
+		grad[0] -= biasPenalty * m.Bias
+		hess[0][0] += biasPenalty
+		for j := 1; j <= d; j++ {
+			grad[j] -= lambda * m.Weights[j-1]
+			hess[j][j] += lambda
+		}
+
+		delta, err := stats.SolveLinear(hess, grad)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fit learned model: %w", err)
+		}
+		m.Bias += delta[0]
+		maxDelta := math.Abs(delta[0])
+		for j := 1; j <= d; j++ {
+			m.Weights[j-1] += delta[j]
+			maxDelta = math.Max(maxDelta, math.Abs(delta[j]))
+		}
+		if maxDelta < newtonTolerance {
+			break
+		}
+	}
+	return m, nil
+}
+
+// crossValidate predicts every deck from a model that did not see it and
+// scores those predictions. With too few decks it falls back to in-sample.
+func crossValidate(samples []TrainingSample, opts FitOptions) (*Calibration, error) {
+	folds := opts.Folds
+	if folds > len(samples)/minTrainingSamples {
+		folds = len(samples) / minTrainingSamples
+	}
+	if folds < 2 {
+		folds = 1
+	}
+
+	predictions := make([]float64, len(samples))
+	for k := 0; k < folds; k++ {
+		var train []TrainingSample
+		for i, s := range samples {
+			if folds == 1 || i%folds != k {
+				train = append(train, s)
+			}
+		}
+		model, err := fitLogistic(train, opts.Lambda)
+		if err != nil {
+			return nil, err
+		}
+		for i, s := range samples {
+			if folds == 1 || i%folds == k {
+				predictions[i] = model.WinProbability(s.Features)
+			}
+		}
+	}
+
+	c := &Calibration{Folds: folds}
+	bins := make([]ReliabilityBin, opts.Bins)
+	binWins := make([]int, opts.Bins)
+	var wins, total int
+	for i, s := range samples {
+		p := predictions[i]
+		n := s.Wins + s.Losses
+		wins += s.Wins
+		total += n
+
+		c.BrierScore += float64(s.Wins)*(1-p)*(1-p) + float64(s.Losses)*p*p
+		clipped := math.Min(math.Max(p, 1e-6), 1-1e-6)
+		c.LogLoss -= float64(s.Wins)*math.Log(clipped) + float64(s.Losses)*math.Log(1-clipped)
+		if p >= 0.5 {
+			c.Accuracy += float64(s.Wins)
+		} else {
+			c.Accuracy += float64(s.Losses)
+		}
+
+		b := int(p * float64(opts.Bins))
+		if b >= opts.Bins {
+			b = opts.Bins - 1
+		}
+		bins[b].Matches += n
+		bins[b].MeanPredicted += p * float64(n)
+		binWins[b] += s.Wins
+	}
+
+	base := float64(wins) / float64(total)
+	c.BaselineBrier = base * (1 - base)
+	c.BrierScore /= float64(total)
+	c.LogLoss /= float64(total)
+	c.Accuracy /= float64(total)
+	c.Bins = []ReliabilityBin{}
+	for b := range bins {
+		if bins[b].Matches == 0 {
+			continue
+		}
+		bin := bins[b]
+		bin.Lower = float64(b) / float64(opts.Bins)
+		bin.Upper = float64(b+1) / float64(opts.Bins)
+		bin.MeanPredicted /= float64(bin.Matches)
+		bin.Observed = float64(binWins[b]) / float64(bin.Matches)
+		c.Bins = append(c.Bins, bin)
+	}
+	return c, nil
+}
+
+func sigmoid(z float64) float64 {
+	return 1 / (1 + math.Exp(-z))
+}
@@ -0,0 +1,341 @@
+package prediction
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+// syntheticSamples builds decks whose true match win probability rises with
+// average GIHWR and removal count; everything else is noise.
+func syntheticSamples(n int) []TrainingSample {
+	rng := rand.New(rand.NewSource(42))
+	samples := make([]TrainingSample, 0, n)
+	for i := 0; i < n; i++ {
+		f := DeckFeatures{
+			AvgGIHWR:         52 + rng.Float64()*8,
+			TopGIHWR:         58 + rng.Float64()*6,
+			CurveScore:       rng.Float64(),
+			TwoDrops:         float64(rng.Intn(8)),
+			Removal:          float64(rng.Intn(7)),
+			Creatures:        float64(12 + rng.Intn(6)),
+			ColorPairWinRate: 53 + rng.Float64()*4,
+			Colors:           2,
+		}
+		p := sigmoid(0.45*(f.AvgGIHWR-56) + 0.25*(f.Removal-3))
+		s := TrainingSample{Features: f, Source: "local"}
+		for m := 0; m < 6; m++ {
+			if rng.Float64() < p {
+				s.Wins++
+			} else {
+				s.Losses++
+			}
+		}
+		samples = append(samples, s)
+	}
+	return samples
+}
+
+func TestFit_LearnsSignalAndCalibrates(t *testing.T) {
+	model, err := Fit(syntheticSamples(400), DefaultFitOptions())
+	if err != nil {
+		t.Fatalf("Fit failed: %v", err)
+	}
+
+	weights := map[string]float64{}
+	for i, name := range model.Features {
+		weights[name] = model.Weights[i]
+	}
+	if weights["avg_gihwr"] <= 0.3 || weights["removal"] <= 0.1 {
+		t.Errorf("expected positive weights for avg_gihwr and removal, got %v", weights)
+	}
+	if weights["colors"] != 0 {
+		t.Errorf("constant feature should keep a zero weight, got %.3f", weights["colors"])
+	}
+
+	strong := DeckFeatures{AvgGIHWR: 59, Removal: 6, ColorPairWinRate: 55, Colors: 2}
+	weak := DeckFeatures{AvgGIHWR: 53, Removal: 0, ColorPairWinRate: 55, Colors: 2}
+	if model.WinProbability(strong) <= model.WinProbability(weak) {
+		t.Error("stronger deck should have the higher win probability")
+	}
+
+	c := model.Calibration
+	if c == nil || c.Folds != DefaultFitOptions().Folds {
+		t.Fatalf("expected %d-fold calibration, got %+v", DefaultFitOptions().Folds, c)
+	}
+	if c.BrierScore >= c.BaselineBrier {
+		t.Errorf("Brier %.4f should beat the constant baseline %.4f", c.BrierScore, c.BaselineBrier)
+	}
+	matches := 0
+	for _, bin := range c.Bins {
+		matches += bin.Matches
+		if bin.MeanPredicted < bin.Lower || bin.MeanPredicted > bin.Upper {
+			t.Errorf("bin [%.1f, %.1f) has mean prediction %.3f", bin.Lower, bin.Upper, bin.MeanPredicted)
+		}
+	}
+	if matches != model.Matches || model.Matches != 400*6 {
+		t.Errorf("bins cover %d matches, model %d, want %d", matches, model.Matches, 400*6)
+	}
+}
+
+func TestFit_RequiresEnoughDecks(t *testing.T) {
+	samples := syntheticSamples(3)
+	samples = append(samples, TrainingSample{Features: DeckFeatures{AvgGIHWR: 55}}) // No matches
+	if _, err := Fit(samples, DefaultFitOptions()); err == nil {
+		t.Error("expected an error with fewer than the minimum decks")
+	}
+}
+
+func TestFit_SmallSampleFallsBackToInSample(t *testing.T) {
+	model, err := Fit(syntheticSamples(7), DefaultFitOptions())
+	if err != nil {
+		t.Fatalf("Fit failed: %v", err)
+	}
+	if model.Calibration.Folds != 1 {
+		t.Errorf("folds = %d, want in-sample calibration for 7 decks", model.Calibration.Folds)
+	}
+	for _, w := range model.Weights {
+		if math.IsNaN(w) || math.IsInf(w, 0) {
+			t.Fatalf("weights did not converge: %v", model.Weights)
+		}
+	}
+}
+
+func TestEventStructure_ExpectedWins(t *testing.T) {
+	premier := EventStructureFor("PremierDraft_BLB_20240801")
+	if got := premier.ExpectedWins(0); got != 0 {
+		t.Errorf("p=0: %.3f wins, want 0", got)
+	}
+	if got := premier.ExpectedWins(1); math.Abs(got-7) > 1e-9 {
+		t.Errorf("p=1: %.3f wins, want 7", got)
+	}
+	// At a coin flip, wins before the third loss follow a negative binomial
+	// (mean 3) capped at 7
+	if got := premier.ExpectedWins(0.5); got < 2.8 || got > 3 {
+		t.Errorf("p=0.5: %.3f wins, want just under 3", got)
+	}
+	if premier.ExpectedWins(0.6) <= premier.ExpectedWins(0.55) {
+		t.Error("expected wins should rise with win probability")
+	}
+
+	trad := EventStructureFor("TradDraft_BLB_20240801")
+	if got := trad.ExpectedWins(0.6); math.Abs(got-1.8) > 1e-9 {
+		t.Errorf("traditional p=0.6: %.3f wins, want 1.8", got)
+	}
+}
+
+// fakeModelStore keeps saved models in memory.
+type fakeModelStore struct {
+	saved *models.MLModelMetadata
+}
+
+func (f *fakeModelStore) SaveModelMetadata(ctx context.Context, meta *models.MLModelMetadata) error {
+	f.saved = meta
+	return nil
+}
+
+func (f *fakeModelStore) GetActiveModel(ctx context.Context, modelName string) (*models.MLModelMetadata, error) {
+	if f.saved == nil || f.saved.ModelName != modelName {
+		return nil, nil
+	}
+	return f.saved, nil
+}
+
+func TestLearnedModel_SaveAndLoad(t *testing.T) {
+	ctx := context.Background()
+	store := &fakeModelStore{}
+
+	if model, err := LoadLearnedModel(ctx, store); err != nil || model != nil {
+		t.Fatalf("expected no model before training, got %v, %v", model, err)
+	}
+
+	model, err := Fit(syntheticSamples(50), DefaultFitOptions())
+	if err != nil {
+		t.Fatalf("Fit failed: %v", err)
+	}
+	if err := SaveLearnedModel(ctx, store, model); err != nil {
+		t.Fatalf("SaveLearnedModel failed: %v", err)
+	}
+	if store.saved.TrainingSamples != 50 || !store.saved.IsActive || store.saved.Accuracy == nil {
+		t.Errorf("unexpected metadata %+v", store.saved)
+	}
+
+	loaded, err := LoadLearnedModel(ctx, store)
+	if err != nil {
+		t.Fatalf("LoadLearnedModel failed: %v", err)
+	}
+	deck := DeckFeatures{AvgGIHWR: 57, Removal: 3, ColorPairWinRate: 55, Colors: 2}
+	if loaded.WinProbability(deck) != model.WinProbability(deck) {
+		t.Error("loaded model predicts differently from the trained one")
+	}
+
+	// A model trained on another feature set must not be used
+	data := strings.Replace(string(store.saved.ModelData), `"avg_iwd"`, `"old_feature"`, 1)
+	if _, err := LearnedModelFromJSON([]byte(data)); err == nil {
+		t.Error("expected an error for a model with different features")
+	}
+}
+
+func TestExtractFeatures_SelectDeck(t *testing.T) {
+	var pool []DeckCard
+	for i := 0; i < 12; i++ {
+		pool = append(pool, DeckCard{Name: "Red", Colors: "R", CMC: 2 + i%3, GIHWR: 57, Rated: true, IsCreature: true})
+		pool = append(pool, DeckCard{Name: "Green", Colors: "G", CMC: 3, GIHWR: 56, Rated: true, IsRemoval: i < 2})
+	}
+	pool = append(pool,
+		DeckCard{Name: "Blue Bomb", Colors: "U", CMC: 5, GIHWR: 64, Rated: true},
+		DeckCard{Name: "Forest", IsLand: true},
+	)
+
+	deck := SelectDeck(pool)
+	if len(deck) != deckSize {
+		t.Fatalf("deck has %d cards, want %d", len(deck), deckSize)
+	}
+	for _, card := range deck {
+		if card.Colors != "R" && card.Colors != "G" {
+			t.Errorf("off-color card %s in an R/G deck", card.Name)
+		}
+	}
+
+	f := ExtractFeatures(deck, map[string]float64{"RG": 56.5})
+	if f.ColorPairWinRate != 56.5 || f.Colors != 2 {
+		t.Errorf("pair win rate %.1f with %v colors, want 56.5 and 2", f.ColorPairWinRate, f.Colors)
+	}
+	if f.Creatures != 12 || f.Removal != 2 || f.Bombs != 0 {
+		t.Errorf("creatures %v, removal %v, bombs %v; want 12, 2, 0", f.Creatures, f.Removal, f.Bombs)
+	}
+	if f.TopGIHWR != 57 {
+		t.Errorf("top GIHWR = %.2f, want 57", f.TopGIHWR)
+	}
+}
+
+func TestParseGameDataCSV(t *testing.T) {
+	cards := map[string]DeckCard{
+		"Shock":     {Name: "Shock", Colors: "R", CMC: 1, GIHWR: 58, Rated: true, IsRemoval: true},
+		"Grizzlies": {Name: "Grizzlies", Colors: "G", CMC: 2, GIHWR: 54, Rated: true, IsCreature: true},
+	}
+	csv := strings.Join([]string{
+		"draft_id,won,deck_Shock,deck_Grizzlies,deck_Mountain",
+		"d1,True,2,10,8",
+		"d1,False,2,10,8",
+		"d1,True,2,10,8",
+		"d2,False,0,12,8",
+	}, "\n")
+
+	samples, err := ParseGameDataCSV(strings.NewReader(csv), cards, map[string]float64{"RG": 55})
+	if err != nil {
+		t.Fatalf("ParseGameDataCSV failed: %v", err)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("got %d samples, want one per draft", len(samples))
+	}
+	first := samples[0]
+	if first.Wins != 2 || first.Losses != 1 || first.Source != "17lands" {
+		t.Errorf("d1 = %d-%d from %q, want 2-1 from 17lands", first.Wins, first.Losses, first.Source)
+	}
+	if first.Features.Removal != 2 || first.Features.Creatures != 10 || first.Features.ColorPairWinRate != 55 {
+		t.Errorf("unexpected d1 features %+v", first.Features)
+	}
+	if samples[1].Losses != 1 || samples[1].Features.Colors != 1 {
+		t.Errorf("unexpected d2 sample %+v", samples[1])
+	}
+
+	if _, err := ParseGameDataCSV(strings.NewReader("deck_Shock\n1"), cards, nil); err == nil {
+		t.Error("expected an error without a won column")
+	}
+}
+
+// Fakes for Trainer: two drafts of the same event, a week apart.
+type fakeTrainerDraftRepo struct {
+	repository.DraftRepository
+	sessions []*models.DraftSession
+}
+
+func (f *fakeTrainerDraftRepo) GetCompletedSessions(ctx context.Context, limit int) ([]*models.DraftSession, error) {
+	return f.sessions, nil
+}
+
+func (f *fakeTrainerDraftRepo) GetPicksBySession(ctx context.Context, sessionID string) ([]*models.DraftPickSession, error) {
+	return []*models.DraftPickSession{{SessionID: sessionID, CardID: "1"}, {SessionID: sessionID, CardID: "2"}}, nil
+}
+
+type fakeTrainerMatchRepo struct {
+	repository.MatchRepository
+	matches []*models.Match
+}
+
+func (f *fakeTrainerMatchRepo) GetMatches(ctx context.Context, filter models.StatsFilter) ([]*models.Match, error) {
+	var out []*models.Match
+	for _, m := range f.matches {
+		if m.EventName == *filter.EventName && !m.Timestamp.Before(*filter.StartDate) {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+type fakeTrainerRatingsRepo struct {
+	repository.DraftRatingsRepository
+}
+
+func (f *fakeTrainerRatingsRepo) GetCardRatings(ctx context.Context, setCode, draftFormat string) ([]seventeenlands.CardRating, time.Time, error) {
+	return []seventeenlands.CardRating{
+		{MTGAID: 1, Name: "Shock", Color: "R", GIHWR: 58},
+		{MTGAID: 2, Name: "Grizzlies", Color: "G", GIHWR: 54},
+	}, time.Now(), nil
+}
+
+func (f *fakeTrainerRatingsRepo) GetColorRatings(ctx context.Context, setCode, draftFormat string) ([]seventeenlands.ColorRating, time.Time, error) {
+	return []seventeenlands.ColorRating{{ColorName: "RG", WinRate: 0.56}}, time.Now(), nil
+}
+
+type fakeTrainerSetCardRepo struct {
+	repository.SetCardRepository
+}
+
+func (f *fakeTrainerSetCardRepo) GetCardByArenaID(ctx context.Context, arenaID string) (*models.SetCard, error) {
+	return nil, nil
+}
+
+func TestTrainer_CollectSamplesSplitsMatchesByDraft(t *testing.T) {
+	event := "PremierDraft_TST_20250101"
+	first := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	second := first.Add(7 * 24 * time.Hour)
+	drafts := &fakeTrainerDraftRepo{sessions: []*models.DraftSession{
+		{ID: "later", EventName: event, StartTime: second},
+		{ID: "earlier", EventName: event, StartTime: first},
+		{ID: "unplayed", EventName: "QuickDraft_TST_20250101", StartTime: first},
+	}}
+	match := func(offset time.Duration, result string) *models.Match {
+		return &models.Match{EventName: event, Timestamp: first.Add(offset), Result: result}
+	}
+	matches := &fakeTrainerMatchRepo{matches: []*models.Match{
+		match(time.Hour, "win"), match(2*time.Hour, "win"), match(3*time.Hour, "loss"),
+		match(8*24*time.Hour, "loss"),
+	}}
+
+	trainer := NewTrainer(drafts, matches, &fakeTrainerRatingsRepo{}, &fakeTrainerSetCardRepo{})
+	samples, err := trainer.CollectSamples(context.Background())
+	if err != nil {
+		t.Fatalf("CollectSamples failed: %v", err)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("got %d samples, want 2 (the unplayed draft is skipped)", len(samples))
+	}
+	if samples[0].Wins != 2 || samples[0].Losses != 1 {
+		t.Errorf("earlier draft = %d-%d, want 2-1", samples[0].Wins, samples[0].Losses)
+	}
+	if samples[1].Wins != 0 || samples[1].Losses != 1 {
+		t.Errorf("later draft = %d-%d, want 0-1", samples[1].Wins, samples[1].Losses)
+	}
+	if math.Abs(samples[0].Features.ColorPairWinRate-56) > 1e-9 || samples[0].Features.AvgGIHWR != 56 {
+		t.Errorf("unexpected features %+v", samples[0].Features)
+	}
+}
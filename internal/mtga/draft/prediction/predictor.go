@@ -24,6 +24,7 @@ type PredictionFactors struct {
 	HighPerformers    []string           `json:"high_performers"`    // Top 5 cards by GIHWR
 	LowPerformers     []string           `json:"low_performers"`     // Bottom 5 cards by GIHWR
 	ConfidenceLevel   string             `json:"confidence_level"`   // "high", "medium", "low"
+	Learned           *LearnedPrediction `json:"learned,omitempty"`  // Learned model's prediction, when a model is trained
 }
 
 // DeckPrediction contains the complete win rate prediction
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
 )
 
@@ -14,6 +16,7 @@ type Service struct {
 	draftRepo   repository.DraftRepository
 	ratingsRepo repository.DraftRatingsRepository
 	setCardRepo repository.SetCardRepository
+	model       *LearnedModel
 }
 
 // NewService creates a new prediction service with the required repositories.
@@ -25,6 +28,12 @@ func NewService(draftRepo repository.DraftRepository, ratingsRepo repository.Dra
 	}
 }
 
+// SetLearnedModel adds a learned model's prediction to every new prediction.
+// Once the model has seen enough decks it also provides the headline win rate.
+func (s *Service) SetLearnedModel(model *LearnedModel) {
+	s.model = model
+}
+
 // PredictSessionWinRate calculates and stores the win rate prediction for a draft session
 func (s *Service) PredictSessionWinRate(ctx context.Context, sessionID string) (*DeckPrediction, error) {
 	// 1. Get all picks for the session
@@ -117,7 +126,12 @@ func (s *Service) PredictSessionWinRate(ctx context.Context, sessionID string) (
 		return nil, fmt.Errorf("failed to calculate prediction: %w", err)
 	}
 
-	// 5. Store prediction in database
+	// 5. Add the learned model's view of the deck
+	if s.model != nil {
+		s.applyLearnedModel(ctx, session, picks, prediction)
+	}
+
+	// 6. Store prediction in database
 	err = s.storePrediction(sessionID, prediction)
 	if err != nil {
 		return nil, fmt.Errorf("failed to store prediction: %w", err)
@@ -126,6 +140,25 @@ func (s *Service) PredictSessionWinRate(ctx context.Context, sessionID string) (
 	return prediction, nil
 }
 
+// applyLearnedModel records the learned prediction in the factors and, when
+// the model is trained on enough decks, uses it as the headline win rate.
+func (s *Service) applyLearnedModel(ctx context.Context, session *models.DraftSession, picks []*models.DraftPickSession, prediction *DeckPrediction) {
+	pool, pairWinRates := loadPool(ctx, s.ratingsRepo, s.setCardRepo, session, picks)
+	features := ExtractFeatures(SelectDeck(pool), pairWinRates)
+	learned := s.model.Predict(features, EventStructureFor(session.EventName))
+	prediction.Factors.Learned = learned
+
+	if s.model.Samples < minHeadlineSamples {
+		return
+	}
+	width := prediction.PredictedWinRateMax - prediction.PredictedWinRateMin
+	prediction.PredictedWinRate = learned.WinRate
+	prediction.PredictedWinRateMin = math.Max(0, learned.WinRate-width/2)
+	prediction.PredictedWinRateMax = math.Min(1, learned.WinRate+width/2)
+	prediction.Factors.Explanation = fmt.Sprintf("Predicted %.1f%% win rate (%.1f expected wins) from a model trained on %d of your decks.",
+		learned.WinRate*100, learned.ExpectedWins, s.model.Samples)
+}
+
 // GetSessionPrediction retrieves the stored prediction for a draft session
 func (s *Service) GetSessionPrediction(sessionID string) (*DeckPrediction, error) {
 	session, err := s.draftRepo.GetSession(context.Background(), sessionID)
@@ -0,0 +1,141 @@
+package prediction
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+// maxTrainingSessions caps how many completed drafts are read for training.
+const maxTrainingSessions = 1000
+
+// Trainer builds training samples from the user's completed drafts.
+type Trainer struct {
+	draftRepo   repository.DraftRepository
+	matchRepo   repository.MatchRepository
+	ratingsRepo repository.DraftRatingsRepository
+	setCardRepo repository.SetCardRepository
+}
+
+// NewTrainer creates a new trainer with the required repositories.
+func NewTrainer(draftRepo repository.DraftRepository, matchRepo repository.MatchRepository, ratingsRepo repository.DraftRatingsRepository, setCardRepo repository.SetCardRepository) *Trainer {
+	return &Trainer{
+		draftRepo:   draftRepo,
+		matchRepo:   matchRepo,
+		ratingsRepo: ratingsRepo,
+		setCardRepo: setCardRepo,
+	}
+}
+
+// CollectSamples pairs each completed draft's deck with the matches played in
+// its event. A match belongs to the latest draft of the same event that
+// started before it; drafts without matches are skipped.
+func (t *Trainer) CollectSamples(ctx context.Context) ([]TrainingSample, error) {
+	sessions, err := t.draftRepo.GetCompletedSessions(ctx, maxTrainingSessions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completed drafts: %w", err)
+	}
+
+	// Later drafts of the same event bound the match window of earlier ones
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].StartTime.Before(sessions[j].StartTime) })
+	nextStart := make(map[*models.DraftSession]time.Time)
+	lastByEvent := make(map[string]*models.DraftSession)
+	for _, session := range sessions {
+		if prev, ok := lastByEvent[session.EventName]; ok {
+			nextStart[prev] = session.StartTime
+		}
+		lastByEvent[session.EventName] = session
+	}
+
+	var samples []TrainingSample
+	for _, session := range sessions {
+		eventName := session.EventName
+		startTime := session.StartTime
+		filter := models.StatsFilter{EventName: &eventName, StartDate: &startTime}
+		if end, ok := nextStart[session]; ok {
+			filter.EndDate = &end
+		}
+		matches, err := t.matchRepo.GetMatches(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get matches for draft %s: %w", session.ID, err)
+		}
+
+		sample := TrainingSample{Source: "local"}
+		for _, match := range matches {
+			if end, ok := nextStart[session]; ok && !match.Timestamp.Before(end) {
+				continue
+			}
+			switch match.Result {
+			case "win":
+				sample.Wins++
+			case "loss":
+				sample.Losses++
+			}
+		}
+		if sample.Wins+sample.Losses == 0 {
+			continue
+		}
+
+		picks, err := t.draftRepo.GetPicksBySession(ctx, session.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get picks for draft %s: %w", session.ID, err)
+		}
+		if len(picks) == 0 {
+			continue
+		}
+		pool, pairWinRates := loadPool(ctx, t.ratingsRepo, t.setCardRepo, session, picks)
+		sample.Features = ExtractFeatures(SelectDeck(pool), pairWinRates)
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// Train fits a model on the user's drafts plus any extra samples (for example
+// from ParseGameDataCSV) and stores it as the active model.
+func (t *Trainer) Train(ctx context.Context, store ModelStore, extra []TrainingSample, opts FitOptions) (*LearnedModel, error) {
+	samples, err := t.CollectSamples(ctx)
+	if err != nil {
+		return nil, err
+	}
+	samples = append(samples, extra...)
+
+	model, err := Fit(samples, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := SaveLearnedModel(ctx, store, model); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// GameDataCards builds the card lookup ParseGameDataCSV needs from a set's
+// cached cards and 17Lands ratings.
+func GameDataCards(ctx context.Context, ratingsRepo repository.DraftRatingsRepository, setCardRepo repository.SetCardRepository, setCode, draftFormat string) (map[string]DeckCard, map[string]float64, error) {
+	setCards, err := setCardRepo.GetCardsBySet(ctx, setCode)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get set cards: %w", err)
+	}
+	cardRatings, _, err := ratingsRepo.GetCardRatings(ctx, setCode, draftFormat)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get card ratings: %w", err)
+	}
+	colorRatings, _, err := ratingsRepo.GetColorRatings(ctx, setCode, draftFormat)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get color ratings: %w", err)
+	}
+
+	byName := make(map[string]*models.SetCard, len(setCards))
+	for _, card := range setCards {
+		byName[card.Name] = card
+	}
+	cards := make(map[string]DeckCard, len(cardRatings))
+	for _, rating := range cardRatings {
+		cards[rating.Name] = NewDeckCard(byName[rating.Name], rating, true)
+	}
+	return cards, PairWinRates(colorRatings), nil
+}
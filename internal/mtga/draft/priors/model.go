@@ -0,0 +1,123 @@
+// Package priors estimates draft card ratings before 17Lands has much data
+// on a set. A ridge regression over card features (the characteristic
+// embedding plus mana value) is trained on the final ratings of earlier sets
+// and predicts how far above or below the set average each card will land.
+// Live data replaces the prediction as games accumulate.
+package priors
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/embeddings"
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+)
+
+const (
+	// DefaultLambda is the ridge penalty used when training.
+	DefaultLambda = 1.0
+
+	// featureCount is the embedding plus scaled mana value.
+	featureCount = models.EmbeddingDimensions + 1
+)
+
+// Model predicts a card's GIHWR relative to its set's average.
+type Model struct {
+	Format    string    `json:"format"`
+	TrainedAt time.Time `json:"trainedAt"`
+	Sets      []string  `json:"sets"`      // Sets the model was trained on
+	RatedSets []string  `json:"ratedSets"` // Every set with ratings at training time
+	Samples   int       `json:"samples"`   // Cards the model was trained on
+	Baseline  float64   `json:"baseline"`  // Average set GIHWR across the training sets
+	RMSE      float64   `json:"rmse"`      // Training error in GIHWR points
+	Bias      float64   `json:"bias"`
+	Weights   []float64 `json:"weights"`
+}
+
+// Sample is one card with its final rating relative to its set's average.
+type Sample struct {
+	Card  *models.SetCard
+	Delta float64 // Card GIHWR minus set average GIHWR
+}
+
+// Predict returns the card's expected GIHWR in a set averaging setMean.
+func (m *Model) Predict(card *models.SetCard, setMean float64) float64 {
+	x := features(card)
+	delta := m.Bias
+	for i, w := range m.Weights {
+		if i < len(x) {
+			delta += w * x[i]
+		}
+	}
+	return stats.Round(setMean+delta, 2)
+}
+
+// Fit trains a model by ridge regression on the samples. The bias is not
+// penalized.
+func Fit(samples []Sample, lambda float64) (*Model, error) {
+	if len(samples) < featureCount {
+		return nil, fmt.Errorf("need at least %d rated cards to train, have %d", featureCount, len(samples))
+	}
+
+	// Normal equations with the bias as the last column
+	n := featureCount + 1
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n)
+	}
+	b := make([]float64, n)
+	rows := make([][]float64, len(samples))
+	for s, sample := range samples {
+		x := append(features(sample.Card), 1)
+		rows[s] = x
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				a[i][j] += x[i] * x[j]
+			}
+			b[i] += x[i] * sample.Delta
+		}
+	}
+	for i := 0; i < featureCount; i++ {
+		a[i][i] += lambda
+	}
+
+	w, err := stats.SolveLinear(a, b)
+	if err != nil {
+		return nil, fmt.Errorf("training data is degenerate: %w", err)
+	}
+
+	model := &Model{Samples: len(samples), Bias: w[featureCount], Weights: w[:featureCount]}
+	var squares float64
+	for s, x := range rows {
+		predicted := 0.0
+		for i := range x {
+			predicted += w[i] * x[i]
+		}
+		squares += (predicted - samples[s].Delta) * (predicted - samples[s].Delta)
+	}
+	model.RMSE = stats.Round(math.Sqrt(squares/float64(len(samples))), 2)
+	return model, nil
+}
+
+var generator = embeddings.NewGenerator()
+
+// features is the card's characteristic embedding (colors, mana value,
+// types, rarity, power and toughness, keywords) and its mana value scaled
+// to about one.
+func features(card *models.SetCard) []float64 {
+	embedding := generator.GenerateEmbedding(&embeddings.CardData{
+		Name:       card.Name,
+		ManaCost:   card.ManaCost,
+		CMC:        float64(card.CMC),
+		TypeLine:   strings.Join(card.Types, " "),
+		Colors:     card.Colors,
+		OracleText: card.Text,
+		Power:      card.Power,
+		Toughness:  card.Toughness,
+		Rarity:     card.Rarity,
+	})
+	return append(embedding.Embedding, float64(card.CMC)/7)
+}
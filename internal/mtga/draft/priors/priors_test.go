@@ -0,0 +1,297 @@
+package priors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+var rarities = []string{"common", "uncommon", "rare", "mythic"}
+
+// rarityBonus is the synthetic truth the tests train on: rarer is better.
+var rarityBonus = map[string]float64{"common": -2, "uncommon": 0, "rare": 2, "mythic": 4}
+
+// testSet builds n cards cycling through rarities, colors and mana values.
+func testSet(setCode string, n, firstID int) []*models.SetCard {
+	colors := []string{"W", "U", "B", "R", "G"}
+	cards := make([]*models.SetCard, n)
+	for i := range cards {
+		types := []string{"Creature", "Bear"}
+		if i%3 == 0 {
+			types = []string{"Instant"}
+		}
+		cards[i] = &models.SetCard{
+			SetCode: setCode,
+			ArenaID: fmt.Sprint(firstID + i),
+			Name:    fmt.Sprintf("%s Card %d", setCode, i),
+			CMC:     1 + i%6,
+			Types:   types,
+			Colors:  []string{colors[i%5]},
+			Rarity:  rarities[(i/5)%4],
+			Power:   fmt.Sprint(1 + i%4),
+		}
+	}
+	return cards
+}
+
+func testRatings(cards []*models.SetCard, mean float64, games int) []seventeenlands.CardRating {
+	ratings := make([]seventeenlands.CardRating, len(cards))
+	for i, card := range cards {
+		var id int
+		fmt.Sscan(card.ArenaID, &id)
+		ratings[i] = seventeenlands.CardRating{
+			Name:   card.Name,
+			MTGAID: id,
+			Rarity: card.Rarity,
+			GIHWR:  mean + rarityBonus[card.Rarity],
+			GIH:    games,
+		}
+	}
+	return ratings
+}
+
+type fakeRatings struct {
+	repository.DraftRatingsRepository
+	ratings map[string][]seventeenlands.CardRating
+}
+
+func (f *fakeRatings) GetSetsWithRatings(ctx context.Context) ([]string, error) {
+	var sets []string
+	for set := range f.ratings {
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+func (f *fakeRatings) GetCardRatings(ctx context.Context, setCode, draftFormat string) ([]seventeenlands.CardRating, time.Time, error) {
+	return f.ratings[setCode], time.Time{}, nil
+}
+
+func (f *fakeRatings) GetCardRatingByArenaID(ctx context.Context, setCode, draftFormat, arenaID string) (*seventeenlands.CardRating, error) {
+	for i, r := range f.ratings[setCode] {
+		if fmt.Sprint(r.MTGAID) == arenaID {
+			return &f.ratings[setCode][i], nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeSetCards struct {
+	repository.SetCardRepository
+	cards map[string][]*models.SetCard
+}
+
+func (f *fakeSetCards) GetCardsBySet(ctx context.Context, setCode string) ([]*models.SetCard, error) {
+	return f.cards[setCode], nil
+}
+
+type fakeSettings struct {
+	repository.SettingsRepository
+	values map[string]string
+}
+
+func (f *fakeSettings) Get(ctx context.Context, key string) (string, error) {
+	value, ok := f.values[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", repository.ErrSettingNotFound, key)
+	}
+	return value, nil
+}
+
+func (f *fakeSettings) Set(ctx context.Context, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	f.values[key] = string(data)
+	return nil
+}
+
+func trainedModel(t *testing.T) *Model {
+	t.Helper()
+	var samples []Sample
+	for _, card := range testSet("OLD", 200, 1) {
+		samples = append(samples, Sample{Card: card, Delta: rarityBonus[card.Rarity]})
+	}
+	model, err := Fit(samples, DefaultLambda)
+	if err != nil {
+		t.Fatalf("Fit: %v", err)
+	}
+	model.Baseline = 55
+	return model
+}
+
+func TestFit_LearnsFeatureEffects(t *testing.T) {
+	model := trainedModel(t)
+	cards := testSet("NEW", 20, 1000)
+	byRarity := map[string]float64{}
+	for _, card := range cards {
+		byRarity[card.Rarity] = model.Predict(card, 55)
+	}
+	if !(byRarity["mythic"] > byRarity["rare"] && byRarity["rare"] > byRarity["uncommon"] && byRarity["uncommon"] > byRarity["common"]) {
+		t.Errorf("predictions should rank by rarity, got %v", byRarity)
+	}
+	if model.RMSE > 1 {
+		t.Errorf("training error %.2f on noiseless data", model.RMSE)
+	}
+
+	if _, err := Fit(nil, DefaultLambda); err == nil {
+		t.Error("Fit with no samples should fail")
+	}
+}
+
+func TestSparse(t *testing.T) {
+	cards := testSet("NEW", 40, 1000)
+	if !Sparse(nil) {
+		t.Error("no ratings should be sparse")
+	}
+	if Sparse(testRatings(cards, 55, MaxBlendGames)) {
+		t.Error("well-sampled set should not be sparse")
+	}
+	if !Sparse(testRatings(cards, 55, 50)) {
+		t.Error("set with 50 games per card should be sparse")
+	}
+}
+
+func TestBootstrap(t *testing.T) {
+	model := trainedModel(t)
+	cards := testSet("NEW", 8, 1000)
+	cards = append(cards, &models.SetCard{ArenaID: "2000", Name: "Plains", Types: []string{"Basic", "Land", "Plains"}})
+
+	base := testRatings(cards[:2], 55, 0)
+	base[0].GIH, base[0].GIHWR = PriorGames, 70 // Half live data, half prediction
+	base[1].GIH = MaxBlendGames                 // Live data alone
+
+	ratings := Bootstrap(base, cards, model)
+	if len(ratings) != 8 {
+		t.Fatalf("got %d ratings, want the 8 nonbasic cards", len(ratings))
+	}
+
+	half := ratings[0]
+	if !half.Predicted || half.PriorWeight != 0.5 || half.ObservedGIHWR != 70 {
+		t.Errorf("card with %d games = %+v, want an even blend", PriorGames, half)
+	}
+	if want := stats.Round((half.PriorGIHWR+70)/2, 2); half.GIHWR != want {
+		t.Errorf("blended GIHWR %.2f, want %.2f", half.GIHWR, want)
+	}
+	if ratings[1].Predicted || ratings[1].GIHWR != base[1].GIHWR {
+		t.Errorf("well-sampled card changed: %+v", ratings[1])
+	}
+	for _, r := range ratings[2:] {
+		if !r.Predicted || r.PriorWeight != 1 || r.GIHWR != r.PriorGIHWR || r.MTGAID == 0 {
+			t.Errorf("unrated card should be predicted alone, got %+v", r)
+		}
+	}
+	if base[0].GIHWR != 70 {
+		t.Error("Bootstrap modified its input")
+	}
+}
+
+func TestRepository_TrainsAndBootstrapsSparseSets(t *testing.T) {
+	ctx := context.Background()
+	oldA, oldB, fresh := testSet("AAA", 150, 1), testSet("BBB", 150, 500), testSet("NEW", 60, 1000)
+	base := &fakeRatings{ratings: map[string][]seventeenlands.CardRating{
+		"AAA": testRatings(oldA, 54, 5000),
+		"BBB": testRatings(oldB, 56, 5000),
+		"NEW": testRatings(fresh[:10], 55, 100),
+	}}
+	setCards := &fakeSetCards{cards: map[string][]*models.SetCard{"AAA": oldA, "BBB": oldB, "NEW": fresh}}
+	store := NewStore(&fakeSettings{values: map[string]string{}})
+	repo := NewRepository(base, setCards, store)
+
+	// Sets with plenty of data pass through
+	ratings, _, err := repo.GetCardRatings(ctx, "AAA", "PremierDraft")
+	if err != nil || len(ratings) != 150 || ratings[0].Predicted {
+		t.Fatalf("well-sampled set = %d ratings, %v", len(ratings), err)
+	}
+
+	// The first read of a sparse set starts training rather than waiting for it
+	ratings, _, err = repo.GetCardRatings(ctx, "NEW", "PremierDraft")
+	if err != nil || len(ratings) != 10 {
+		t.Fatalf("sparse set before training = %d ratings, %v; want the live ratings", len(ratings), err)
+	}
+	repo.wg.Wait()
+
+	ratings, _, err = repo.GetCardRatings(ctx, "NEW", "PremierDraft")
+	if err != nil || len(ratings) != 60 {
+		t.Fatalf("sparse set = %d ratings, %v; want every set card", len(ratings), err)
+	}
+	var bonus float64
+	for _, card := range oldA {
+		bonus += rarityBonus[card.Rarity] / float64(len(oldA))
+	}
+	model, err := store.Load(ctx, "premierdraft")
+	if err != nil || model == nil || len(model.Sets) != 2 || model.Baseline != stats.Round(55+bonus, 2) {
+		t.Fatalf("stored model = %+v, %v", model, err)
+	}
+
+	rating, err := repo.GetCardRatingByArenaID(ctx, "NEW", "PremierDraft", "1050")
+	if err != nil || rating == nil || !rating.Predicted || rating.PriorWeight != 1 {
+		t.Errorf("unrated card = %+v, %v", rating, err)
+	}
+	if want := 55 + rarityBonus[fresh[50].Rarity]; rating != nil && (rating.GIHWR < want-1 || rating.GIHWR > want+1) {
+		t.Errorf("predicted %.2f for a %s, want about %.2f", rating.GIHWR, fresh[50].Rarity, want)
+	}
+}
+
+func TestRepository_RetrainsWhenRatedSetsChange(t *testing.T) {
+	ctx := context.Background()
+	oldA, fresh := testSet("AAA", 150, 1), testSet("NEW", 60, 1000)
+	base := &fakeRatings{ratings: map[string][]seventeenlands.CardRating{
+		"AAA": testRatings(oldA, 54, 5000),
+		"NEW": testRatings(fresh[:10], 55, 100),
+	}}
+	setCards := &fakeSetCards{cards: map[string][]*models.SetCard{"AAA": oldA, "NEW": fresh}}
+	store := NewStore(&fakeSettings{values: map[string]string{}})
+	repo := NewRepository(base, setCards, store)
+
+	if _, err := repo.Train(ctx, "PremierDraft"); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := store.Load(ctx, "PremierDraft")
+	if _, _, err := repo.GetCardRatings(ctx, "NEW", "PremierDraft"); err != nil {
+		t.Fatal(err)
+	}
+	repo.wg.Wait()
+	if model, _ := store.Load(ctx, "PremierDraft"); !model.TrainedAt.Equal(first.TrainedAt) {
+		t.Error("model retrained though the rated sets are unchanged")
+	}
+
+	// A finished set arrives; the next check after cacheTTL retrains
+	oldB := testSet("BBB", 150, 500)
+	base.ratings["BBB"] = testRatings(oldB, 56, 5000)
+	setCards.cards["BBB"] = oldB
+	repo.mu.Lock()
+	repo.checked["premierdraft"] = time.Now().Add(-cacheTTL)
+	repo.mu.Unlock()
+	if _, _, err := repo.GetCardRatings(ctx, "NEW", "PremierDraft"); err != nil {
+		t.Fatal(err)
+	}
+	repo.wg.Wait()
+	model, err := store.Load(ctx, "PremierDraft")
+	if err != nil || len(model.Sets) != 2 || len(model.RatedSets) != 3 {
+		t.Errorf("model after a new set = %+v, %v; want two trained sets of three rated", model, err)
+	}
+}
+
+func TestRepository_NoTrainingDataPassesThrough(t *testing.T) {
+	ctx := context.Background()
+	fresh := testSet("NEW", 20, 1000)
+	base := &fakeRatings{ratings: map[string][]seventeenlands.CardRating{"NEW": testRatings(fresh[:5], 55, 100)}}
+	repo := NewRepository(base, &fakeSetCards{cards: map[string][]*models.SetCard{"NEW": fresh}}, NewStore(&fakeSettings{values: map[string]string{}}))
+
+	ratings, _, err := repo.GetCardRatings(ctx, "NEW", "PremierDraft")
+	if err != nil || len(ratings) != 5 || ratings[0].Predicted {
+		t.Errorf("without a model ratings should pass through, got %d, %v", len(ratings), err)
+	}
+	if _, err := repo.Train(ctx, "PremierDraft"); err == nil {
+		t.Error("training with no finished sets should fail")
+	}
+}
@@ -0,0 +1,400 @@
+package priors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+const (
+	// PriorGames is the games of live data the prediction counts as. A card
+	// with this many games in hand is rated half by each.
+	PriorGames = 400
+
+	// MaxBlendGames is the games in hand after which live data is used alone.
+	MaxBlendGames = 2000
+
+	// sparseShare is the share of cards below MaxBlendGames that makes a set's
+	// data sparse enough to bootstrap.
+	sparseShare = 0.25
+
+	// minObservedCards is the cards with MaxBlendGames games needed to take the
+	// set's average from live data instead of the model's baseline.
+	minObservedCards = 20
+
+	// SettingKey is the settings key trained models are stored under.
+	SettingKey = "draftRatingPriors"
+
+	cacheTTL   = 10 * time.Minute
+	retryAfter = time.Hour
+)
+
+// Store keeps one trained model per draft format in settings.
+type Store struct {
+	settings repository.SettingsRepository
+
+	mu sync.Mutex // Serializes saves, which rewrite every format's model
+}
+
+// NewStore creates a model store backed by the settings repository.
+func NewStore(settings repository.SettingsRepository) *Store {
+	return &Store{settings: settings}
+}
+
+// Load returns the model for a format, or nil when none has been trained.
+func (s *Store) Load(ctx context.Context, draftFormat string) (*Model, error) {
+	all, err := s.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return all[strings.ToLower(draftFormat)], nil
+}
+
+// Save stores a model, replacing the one for its format.
+func (s *Store) Save(ctx context.Context, model *Model) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all, err := s.all(ctx)
+	if err != nil {
+		return err
+	}
+	all[strings.ToLower(model.Format)] = model
+	if err := s.settings.Set(ctx, SettingKey, all); err != nil {
+		return fmt.Errorf("failed to save rating prior model: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) all(ctx context.Context) (map[string]*Model, error) {
+	all := make(map[string]*Model)
+	// A missing setting means no model has been trained yet
+	value, err := s.settings.Get(ctx, SettingKey)
+	switch {
+	case errors.Is(err, repository.ErrSettingNotFound):
+	case err != nil:
+		return nil, fmt.Errorf("failed to load rating prior models: %w", err)
+	default:
+		if err := json.Unmarshal([]byte(value), &all); err != nil {
+			return nil, fmt.Errorf("failed to load rating prior models: %w", err)
+		}
+	}
+	return all, nil
+}
+
+// Repository is a DraftRatingsRepository that fills in predicted ratings
+// while a set's 17Lands data is sparse. Cards without live data get the
+// model's prediction, cards with a little get a blend weighted by games in
+// hand, and every such rating is flagged as Predicted. Sets with plenty of
+// data pass through unchanged.
+type Repository struct {
+	repository.DraftRatingsRepository
+
+	setCardRepo repository.SetCardRepository
+	store       *Store
+	trainer     *Trainer
+
+	mu       sync.Mutex
+	cache    map[string]*bootstrapped
+	failed   map[string]time.Time // Formats whose training failed, by when
+	checked  map[string]time.Time // Formats whose model was compared to the rated sets, by when
+	training map[string]bool      // Formats being trained in the background
+	wg       sync.WaitGroup       // Background training
+}
+
+type bootstrapped struct {
+	ratedAt   time.Time
+	trainedAt time.Time
+	builtAt   time.Time
+	ratings   []seventeenlands.CardRating
+}
+
+// NewRepository wraps a ratings repository with prior predictions. Models
+// are trained in the background from the same repository when a sparse set
+// needs one, and retrained when the sets with ratings change.
+func NewRepository(base repository.DraftRatingsRepository, setCardRepo repository.SetCardRepository, store *Store) *Repository {
+	return &Repository{
+		DraftRatingsRepository: base,
+		setCardRepo:            setCardRepo,
+		store:                  store,
+		trainer:                NewTrainer(base, setCardRepo),
+		cache:                  make(map[string]*bootstrapped),
+		failed:                 make(map[string]time.Time),
+		checked:                make(map[string]time.Time),
+		training:               make(map[string]bool),
+	}
+}
+
+// GetCardRatings returns the set's ratings with predictions filled in when
+// its data is sparse.
+func (r *Repository) GetCardRatings(ctx context.Context, setCode, draftFormat string) ([]seventeenlands.CardRating, time.Time, error) {
+	base, ratedAt, err := r.DraftRatingsRepository.GetCardRatings(ctx, setCode, draftFormat)
+	if err != nil || !Sparse(base) {
+		return base, ratedAt, err
+	}
+	model, err := r.Model(ctx, draftFormat)
+	if err != nil || model == nil {
+		return base, ratedAt, nil
+	}
+
+	key := strings.ToUpper(setCode) + "|" + strings.ToLower(draftFormat)
+	r.mu.Lock()
+	cached := r.cache[key]
+	r.mu.Unlock()
+	if cached != nil && cached.ratedAt.Equal(ratedAt) && cached.trainedAt.Equal(model.TrainedAt) && time.Since(cached.builtAt) < cacheTTL {
+		return append([]seventeenlands.CardRating(nil), cached.ratings...), ratedAt, nil
+	}
+
+	cards, err := r.setCardRepo.GetCardsBySet(ctx, setCode)
+	if err != nil {
+		log.Printf("Warning: Failed to get %s cards for rating priors: %v", setCode, err)
+		return base, ratedAt, nil
+	}
+	ratings := Bootstrap(base, cards, model)
+
+	r.mu.Lock()
+	r.cache[key] = &bootstrapped{ratedAt: ratedAt, trainedAt: model.TrainedAt, builtAt: time.Now(), ratings: ratings}
+	r.mu.Unlock()
+	return append([]seventeenlands.CardRating(nil), ratings...), ratedAt, nil
+}
+
+// GetCardRatingByArenaID returns one card's rating, predicted when its
+// live data is sparse.
+func (r *Repository) GetCardRatingByArenaID(ctx context.Context, setCode, draftFormat, arenaID string) (*seventeenlands.CardRating, error) {
+	rating, err := r.DraftRatingsRepository.GetCardRatingByArenaID(ctx, setCode, draftFormat, arenaID)
+	if err != nil || (rating != nil && rating.GIH >= MaxBlendGames) {
+		return rating, err
+	}
+	ratings, _, err := r.GetCardRatings(ctx, setCode, draftFormat)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ratings {
+		if strconv.Itoa(ratings[i].MTGAID) == arenaID {
+			return &ratings[i], nil
+		}
+	}
+	return rating, nil
+}
+
+// Model returns the stored model for a format, or nil when none has been
+// trained. When there is none, or the sets with ratings have changed since it
+// was trained, a new model is trained in the background; reads keep using the
+// stored one meanwhile. A failed training is not retried for an hour.
+func (r *Repository) Model(ctx context.Context, draftFormat string) (*Model, error) {
+	model, err := r.store.Load(ctx, draftFormat)
+	if err != nil {
+		return nil, err
+	}
+	if r.needsTraining(ctx, draftFormat, model) {
+		r.trainInBackground(ctx, draftFormat)
+	}
+	return model, nil
+}
+
+// needsTraining reports whether a format's model is missing or was trained
+// before the current sets had ratings. The sets are checked at most once per
+// cacheTTL.
+func (r *Repository) needsTraining(ctx context.Context, draftFormat string, model *Model) bool {
+	format := strings.ToLower(draftFormat)
+	r.mu.Lock()
+	failedAt, failed := r.failed[format]
+	checkedAt, checked := r.checked[format]
+	switch {
+	case r.training[format], failed && time.Since(failedAt) < retryAfter:
+		r.mu.Unlock()
+		return false
+	case model == nil:
+		r.mu.Unlock()
+		return true
+	case checked && time.Since(checkedAt) < cacheTTL:
+		r.mu.Unlock()
+		return false
+	}
+	r.checked[format] = time.Now()
+	r.mu.Unlock()
+
+	sets, err := r.DraftRatingsRepository.GetSetsWithRatings(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to check sets for rating priors: %v", err)
+		return false
+	}
+	sort.Strings(sets)
+	return !slices.Equal(sets, model.RatedSets)
+}
+
+// trainInBackground trains a format's model unless it is already training.
+func (r *Repository) trainInBackground(ctx context.Context, draftFormat string) {
+	format := strings.ToLower(draftFormat)
+	r.mu.Lock()
+	if r.training[format] {
+		r.mu.Unlock()
+		return
+	}
+	r.training[format] = true
+	r.wg.Add(1)
+	r.mu.Unlock()
+
+	go func() {
+		defer r.wg.Done()
+		if _, err := r.Train(context.WithoutCancel(ctx), draftFormat); err != nil {
+			log.Printf("Warning: Failed to train rating priors for %s: %v", draftFormat, err)
+			r.mu.Lock()
+			r.failed[format] = time.Now()
+			r.mu.Unlock()
+		}
+		r.mu.Lock()
+		delete(r.training, format)
+		r.mu.Unlock()
+	}()
+}
+
+// Train fits and stores a new model for a format.
+func (r *Repository) Train(ctx context.Context, draftFormat string) (*Model, error) {
+	model, err := r.trainer.Train(ctx, draftFormat)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.store.Save(ctx, model); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	delete(r.failed, strings.ToLower(draftFormat))
+	r.mu.Unlock()
+	return model, nil
+}
+
+// Sparse reports whether a set's ratings are too thin to use alone: there
+// are none, or more than a quarter of the cards are below MaxBlendGames.
+func Sparse(ratings []seventeenlands.CardRating) bool {
+	if len(ratings) == 0 {
+		return true
+	}
+	thin := 0
+	for _, rating := range ratings {
+		if rating.GIH < MaxBlendGames {
+			thin++
+		}
+	}
+	return float64(thin) > sparseShare*float64(len(ratings))
+}
+
+// Bootstrap fills the set's ratings in with the model's predictions. Rated
+// cards below MaxBlendGames are blended with their prediction, weighted as
+// PriorGames games against their games in hand, and set cards 17Lands has
+// not rated yet are added with the prediction alone.
+func Bootstrap(base []seventeenlands.CardRating, cards []*models.SetCard, model *Model) []seventeenlands.CardRating {
+	ratings := append([]seventeenlands.CardRating(nil), base...)
+	setMean := observedMean(base, model.Baseline)
+
+	byArenaID := make(map[int]int, len(ratings))
+	byName := make(map[string]int, len(ratings))
+	for i, rating := range ratings {
+		if rating.MTGAID > 0 {
+			byArenaID[rating.MTGAID] = i
+		}
+		byName[strings.ToLower(rating.Name)] = i
+	}
+
+	blended := make(map[int]bool)
+	for _, card := range cards {
+		if isBasicLand(card) {
+			continue
+		}
+		arenaID, _ := strconv.Atoi(card.ArenaID)
+		prior := model.Predict(card, setMean)
+
+		i, ok := byArenaID[arenaID]
+		if !ok {
+			i, ok = byName[strings.ToLower(card.Name)]
+		}
+		if !ok {
+			if arenaID <= 0 {
+				continue
+			}
+			ratings = append(ratings, seventeenlands.CardRating{
+				Name:        card.Name,
+				Color:       strings.Join(card.Colors, ""),
+				Rarity:      card.Rarity,
+				MTGAID:      arenaID,
+				GIHWR:       prior,
+				Predicted:   true,
+				PriorGIHWR:  prior,
+				PriorWeight: 1,
+			})
+			byName[strings.ToLower(card.Name)] = len(ratings) - 1
+			blended[len(ratings)-1] = true
+			continue
+		}
+		if blended[i] {
+			continue // Another printing of the same card
+		}
+		blended[i] = true
+
+		rating := &ratings[i]
+		if rating.GIH >= MaxBlendGames {
+			continue
+		}
+		weight := 1.0
+		if rating.GIH > 0 && rating.GIHWR > 0 {
+			weight = float64(PriorGames) / float64(PriorGames+rating.GIH)
+			rating.ObservedGIHWR = rating.GIHWR
+		}
+		rating.GIHWR = stats.Round(weight*prior+(1-weight)*rating.ObservedGIHWR, 2)
+		rating.Predicted = true
+		rating.PriorGIHWR = prior
+		rating.PriorWeight = stats.Round(weight, 3)
+	}
+	return ratings
+}
+
+// observedMean is the average GIHWR of cards with enough live data, or the
+// fallback when too few have it.
+func observedMean(ratings []seventeenlands.CardRating, fallback float64) float64 {
+	var sum float64
+	n := 0
+	for _, rating := range ratings {
+		if rating.GIH >= MaxBlendGames && rating.GIHWR > 0 {
+			sum += rating.GIHWR
+			n++
+		}
+	}
+	if n < minObservedCards {
+		return fallback
+	}
+	return sum / float64(n)
+}
+
+// indexCards maps a set's cards by Arena ID and lowercase name.
+func indexCards(cards []*models.SetCard) (map[int]*models.SetCard, map[string]*models.SetCard) {
+	byArenaID := make(map[int]*models.SetCard, len(cards))
+	byName := make(map[string]*models.SetCard, len(cards))
+	for _, card := range cards {
+		if id, err := strconv.Atoi(card.ArenaID); err == nil {
+			byArenaID[id] = card
+		}
+		byName[strings.ToLower(card.Name)] = card
+	}
+	return byArenaID, byName
+}
+
+func isBasicLand(card *models.SetCard) bool {
+	for _, t := range card.Types {
+		if strings.EqualFold(t, "Basic") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package priors
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+const (
+	// MinTrainingGames is the games in hand a card needs for its rating to be
+	// treated as final and used for training.
+	MinTrainingGames = 500
+
+	// minTrainingCards is the cards with final ratings a set needs to be
+	// trained on.
+	minTrainingCards = 100
+)
+
+// Trainer fits prior models on the ratings of sets already in the database.
+type Trainer struct {
+	ratingsRepo repository.DraftRatingsRepository
+	setCardRepo repository.SetCardRepository
+}
+
+// NewTrainer creates a trainer.
+func NewTrainer(ratingsRepo repository.DraftRatingsRepository, setCardRepo repository.SetCardRepository) *Trainer {
+	return &Trainer{ratingsRepo: ratingsRepo, setCardRepo: setCardRepo}
+}
+
+// Train fits a model for a draft format on every set with enough final
+// ratings in that format.
+func (t *Trainer) Train(ctx context.Context, draftFormat string) (*Model, error) {
+	sets, err := t.ratingsRepo.GetSetsWithRatings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sets with ratings: %w", err)
+	}
+	sort.Strings(sets)
+
+	var samples []Sample
+	var trained []string
+	var meanSum float64
+	for _, setCode := range sets {
+		setSamples, mean, err := t.setSamples(ctx, setCode, draftFormat)
+		if err != nil {
+			return nil, err
+		}
+		if len(setSamples) < minTrainingCards {
+			continue
+		}
+		samples = append(samples, setSamples...)
+		trained = append(trained, setCode)
+		meanSum += mean
+	}
+	if len(trained) == 0 {
+		return nil, fmt.Errorf("no sets have enough %s ratings to train on", draftFormat)
+	}
+
+	model, err := Fit(samples, DefaultLambda)
+	if err != nil {
+		return nil, err
+	}
+	model.Format = draftFormat
+	model.TrainedAt = time.Now()
+	model.Sets = trained
+	model.RatedSets = sets
+	model.Baseline = stats.Round(meanSum/float64(len(trained)), 2)
+	return model, nil
+}
+
+// setSamples returns the set's cards with final ratings and the set's
+// average GIHWR over them.
+func (t *Trainer) setSamples(ctx context.Context, setCode, draftFormat string) ([]Sample, float64, error) {
+	cardRatings, _, err := t.ratingsRepo.GetCardRatings(ctx, setCode, draftFormat)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get %s ratings: %w", setCode, err)
+	}
+	cards, err := t.setCardRepo.GetCardsBySet(ctx, setCode)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get %s cards: %w", setCode, err)
+	}
+	byArenaID, byName := indexCards(cards)
+
+	type rated struct {
+		card  *models.SetCard
+		gihwr float64
+	}
+	var final []rated
+	var sum float64
+	for _, rating := range cardRatings {
+		if rating.GIH < MinTrainingGames || rating.GIHWR <= 0 {
+			continue
+		}
+		card := byArenaID[rating.MTGAID]
+		if card == nil {
+			card = byName[strings.ToLower(rating.Name)]
+		}
+		if card == nil || isBasicLand(card) {
+			continue
+		}
+		final = append(final, rated{card: card, gihwr: rating.GIHWR})
+		sum += rating.GIHWR
+	}
+	if len(final) == 0 {
+		return nil, 0, nil
+	}
+
+	mean := sum / float64(len(final))
+	samples := make([]Sample, len(final))
+	for i, r := range final {
+		samples[i] = Sample{Card: r.card, Delta: r.gihwr - mean}
+	}
+	return samples, mean, nil
+}
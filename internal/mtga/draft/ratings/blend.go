@@ -0,0 +1,233 @@
+// Package ratings composes draft card ratings from several sources into one
+// number. A blend weights 17Lands GIHWR, ChannelFireball grades and the
+// user's own win rates per set and format; the blended value replaces GIHWR
+// wherever draft features read ratings, so pick quality, grading, tier lists
+// and the live overlay all agree.
+package ratings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+// Rating sources a blend can weight.
+const (
+	SourceGIHWR    = "gihwr"    // 17Lands games-in-hand win rate
+	SourceCFB      = "cfb"      // ChannelFireball limited grade
+	SourcePersonal = "personal" // The user's win rate when the card was drawn
+)
+
+// SettingKey is the settings key the blend configuration is stored under.
+const SettingKey = "draftRatingBlends"
+
+// maxHistory is the number of superseded blends kept for reference.
+const maxHistory = 20
+
+// Component is one weighted rating source.
+type Component struct {
+	Source string  `json:"source"`
+	Weight float64 `json:"weight"`
+}
+
+// Blend is a weighted combination of rating sources for a set and format.
+// An empty SetCode or Format matches any.
+type Blend struct {
+	SetCode    string      `json:"setCode"`
+	Format     string      `json:"format"`
+	Components []Component `json:"components"`
+	Version    int         `json:"version"` // Bumped each time the blend is saved
+	UpdatedAt  time.Time   `json:"updatedAt"`
+}
+
+// Weight returns the weight of a source, or 0 when the blend does not use it.
+func (b *Blend) Weight(source string) float64 {
+	for _, c := range b.Components {
+		if c.Source == source {
+			return c.Weight
+		}
+	}
+	return 0
+}
+
+// Validate checks that the blend names known sources once each with
+// non-negative weights, and gives at least one of them weight.
+func (b *Blend) Validate() error {
+	seen := make(map[string]bool)
+	total := 0.0
+	for _, c := range b.Components {
+		switch c.Source {
+		case SourceGIHWR, SourceCFB, SourcePersonal:
+		default:
+			return fmt.Errorf("unknown rating source %q", c.Source)
+		}
+		if seen[c.Source] {
+			return fmt.Errorf("rating source %q listed twice", c.Source)
+		}
+		seen[c.Source] = true
+		if c.Weight < 0 {
+			return fmt.Errorf("rating source %q has negative weight", c.Source)
+		}
+		total += c.Weight
+	}
+	if total <= 0 {
+		return fmt.Errorf("blend needs at least one source with positive weight")
+	}
+	return nil
+}
+
+func (b *Blend) matches(setCode, format string) bool {
+	return strings.EqualFold(b.SetCode, setCode) && strings.EqualFold(b.Format, format)
+}
+
+// Config is every blend the user has defined.
+type Config struct {
+	Version int     `json:"version"` // Bumped on every change to the configuration
+	Blends  []Blend `json:"blends"`
+	History []Blend `json:"history"` // Superseded and deleted blends, newest first
+}
+
+// Find returns the blend for a set and format, falling back to a blend for
+// the set in any format, then the format in any set, then the global blend.
+// It returns nil when none applies.
+func (c *Config) Find(setCode, format string) *Blend {
+	for _, key := range [][2]string{{setCode, format}, {setCode, ""}, {"", format}, {"", ""}} {
+		for i := range c.Blends {
+			if c.Blends[i].matches(key[0], key[1]) {
+				return &c.Blends[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Config) index(setCode, format string) int {
+	for i := range c.Blends {
+		if c.Blends[i].matches(setCode, format) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *Config) retire(b Blend) {
+	c.History = append([]Blend{b}, c.History...)
+	if len(c.History) > maxHistory {
+		c.History = c.History[:maxHistory]
+	}
+}
+
+// Store loads and saves the blend configuration in settings.
+type Store struct {
+	settings repository.SettingsRepository
+
+	mu     sync.Mutex
+	config *Config
+}
+
+// NewStore creates a store backed by the settings repository.
+func NewStore(settings repository.SettingsRepository) *Store {
+	return &Store{settings: settings}
+}
+
+// Load returns the blend configuration, empty when none has been saved.
+func (s *Store) Load(ctx context.Context) (*Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	config, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	copied := *config
+	copied.Blends = append([]Blend(nil), config.Blends...)
+	copied.History = append([]Blend(nil), config.History...)
+	return &copied, nil
+}
+
+func (s *Store) load(ctx context.Context) (*Config, error) {
+	if s.config != nil {
+		return s.config, nil
+	}
+	config := &Config{}
+	// A missing setting means no blends have been saved yet; any other error
+	// is returned uncached, so a save cannot overwrite blends it failed to read
+	value, err := s.settings.Get(ctx, SettingKey)
+	switch {
+	case errors.Is(err, repository.ErrSettingNotFound):
+	case err != nil:
+		return nil, fmt.Errorf("failed to load rating blends: %w", err)
+	default:
+		if err := json.Unmarshal([]byte(value), config); err != nil {
+			return nil, fmt.Errorf("failed to load rating blends: %w", err)
+		}
+	}
+	s.config = config
+	return config, nil
+}
+
+// SaveBlend adds a blend or replaces the one for the same set and format,
+// moving the replaced blend to the history. It returns the saved blend.
+func (s *Store) SaveBlend(ctx context.Context, blend Blend) (*Blend, error) {
+	if err := blend.Validate(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	config, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *config
+	updated.Blends = append([]Blend(nil), config.Blends...)
+	updated.History = append([]Blend(nil), config.History...)
+	blend.Version = 1
+	blend.UpdatedAt = time.Now()
+	if i := updated.index(blend.SetCode, blend.Format); i >= 0 {
+		blend.Version = updated.Blends[i].Version + 1
+		updated.retire(updated.Blends[i])
+		updated.Blends[i] = blend
+	} else {
+		updated.Blends = append(updated.Blends, blend)
+	}
+	updated.Version++
+
+	if err := s.settings.Set(ctx, SettingKey, &updated); err != nil {
+		return nil, fmt.Errorf("failed to save rating blend: %w", err)
+	}
+	s.config = &updated
+	return &blend, nil
+}
+
+// DeleteBlend removes the blend for a set and format, keeping it in the
+// history. Deleting a blend that does not exist is not an error.
+func (s *Store) DeleteBlend(ctx context.Context, setCode, format string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	config, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+	i := config.index(setCode, format)
+	if i < 0 {
+		return nil
+	}
+
+	updated := *config
+	updated.History = append([]Blend(nil), config.History...)
+	updated.retire(config.Blends[i])
+	updated.Blends = append(append([]Blend(nil), config.Blends[:i]...), config.Blends[i+1:]...)
+	updated.Version++
+
+	if err := s.settings.Set(ctx, SettingKey, &updated); err != nil {
+		return fmt.Errorf("failed to delete rating blend: %w", err)
+	}
+	s.config = &updated
+	return nil
+}
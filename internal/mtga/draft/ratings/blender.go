@@ -0,0 +1,355 @@
+package ratings
+
+import (
+	"context"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+// cacheTTL bounds how long a blended set is reused, so new games show up in
+// personal win rates.
+const cacheTTL = 10 * time.Minute
+
+// CardScore is one card's blended rating and what went into it.
+type CardScore struct {
+	ArenaID int                `json:"arenaId"`
+	Name    string             `json:"name"`
+	Rating  float64            `json:"rating"`  // Blended rating on the GIHWR scale
+	GIHWR   float64            `json:"gihwr"`   // 17Lands GIHWR before blending
+	Sources map[string]float64 `json:"sources"` // Raw value of each source the card has
+	ZScores map[string]float64 `json:"zScores"` // Standard scores within the set of each source
+}
+
+// Breakdown explains the blended ratings of a set.
+type Breakdown struct {
+	SetCode       string      `json:"setCode"`
+	Format        string      `json:"format"`
+	Blend         *Blend      `json:"blend"` // Nil when no blend applies and ratings are plain GIHWR
+	ConfigVersion int         `json:"configVersion"`
+	Cards         []CardScore `json:"cards"`
+}
+
+// Repository is a DraftRatingsRepository whose card ratings carry the
+// user's blended rating in GIHWR. Everything else passes through to the
+// underlying repository.
+type Repository struct {
+	repository.DraftRatingsRepository
+
+	store    *Store
+	cfbRepo  repository.CFBRatingsRepository
+	personal PersonalRatings
+
+	mu    sync.Mutex
+	cache map[string]*blendedSet
+}
+
+type blendedSet struct {
+	configVersion int
+	ratedAt       time.Time
+	builtAt       time.Time
+	ratings       []seventeenlands.CardRating
+	byArenaID     map[string]int // Index into ratings
+	breakdown     *Breakdown
+}
+
+// NewRepository wraps a ratings repository with the blends in the store.
+// cfbRepo and personal may be nil, in which case blends skip those sources.
+func NewRepository(base repository.DraftRatingsRepository, store *Store, cfbRepo repository.CFBRatingsRepository, personal PersonalRatings) *Repository {
+	return &Repository{
+		DraftRatingsRepository: base,
+		store:                  store,
+		cfbRepo:                cfbRepo,
+		personal:               personal,
+		cache:                  make(map[string]*blendedSet),
+	}
+}
+
+// Store returns the blend configuration store.
+func (r *Repository) Store() *Store {
+	return r.store
+}
+
+// GetCardRatings returns the set's card ratings with GIHWR replaced by the
+// blended rating.
+func (r *Repository) GetCardRatings(ctx context.Context, setCode, draftFormat string) ([]seventeenlands.CardRating, time.Time, error) {
+	set, ratedAt, err := r.blended(ctx, setCode, draftFormat)
+	if err != nil || set == nil {
+		return nil, ratedAt, err
+	}
+	return append([]seventeenlands.CardRating(nil), set.ratings...), ratedAt, nil
+}
+
+// GetCardRatingByArenaID returns one card's rating with GIHWR replaced by
+// the blended rating.
+func (r *Repository) GetCardRatingByArenaID(ctx context.Context, setCode, draftFormat, arenaID string) (*seventeenlands.CardRating, error) {
+	config, err := r.store.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if config.Find(setCode, draftFormat) == nil {
+		return r.DraftRatingsRepository.GetCardRatingByArenaID(ctx, setCode, draftFormat, arenaID)
+	}
+
+	set, _, err := r.blendedWith(ctx, config, setCode, draftFormat)
+	if err != nil {
+		return nil, err
+	}
+	if set != nil {
+		if i, ok := set.byArenaID[arenaID]; ok {
+			rating := set.ratings[i]
+			return &rating, nil
+		}
+	}
+	return r.DraftRatingsRepository.GetCardRatingByArenaID(ctx, setCode, draftFormat, arenaID)
+}
+
+// SaveSetRatings stores new ratings and drops the set's blended ratings.
+func (r *Repository) SaveSetRatings(ctx context.Context, setCode, draftFormat string, cardRatings []seventeenlands.CardRating, colorRatings []seventeenlands.ColorRating, dataSource string) error {
+	r.invalidate(setCode, draftFormat)
+	return r.DraftRatingsRepository.SaveSetRatings(ctx, setCode, draftFormat, cardRatings, colorRatings, dataSource)
+}
+
+// DeleteSetRatings removes the set's ratings and its blended ratings.
+func (r *Repository) DeleteSetRatings(ctx context.Context, setCode, draftFormat string) error {
+	r.invalidate(setCode, draftFormat)
+	return r.DraftRatingsRepository.DeleteSetRatings(ctx, setCode, draftFormat)
+}
+
+// Explain returns each card's blended rating with the value and standard
+// score of every source, sorted as the underlying ratings are.
+func (r *Repository) Explain(ctx context.Context, setCode, draftFormat string) (*Breakdown, error) {
+	set, _, err := r.blended(ctx, setCode, draftFormat)
+	if err != nil {
+		return nil, err
+	}
+	if set == nil {
+		return &Breakdown{SetCode: setCode, Format: draftFormat, Cards: []CardScore{}}, nil
+	}
+	return set.breakdown, nil
+}
+
+// BlendedRatings returns the blended rating of each card by Arena ID, on the
+// 17Lands percentage scale. It is empty when no blend applies to the set.
+func (r *Repository) BlendedRatings(ctx context.Context, setCode, draftFormat string) (map[int]float64, error) {
+	breakdown, err := r.Explain(ctx, setCode, draftFormat)
+	if err != nil {
+		return nil, err
+	}
+	blended := make(map[int]float64)
+	if breakdown.Blend == nil {
+		return blended, nil
+	}
+	for _, card := range breakdown.Cards {
+		if card.ArenaID > 0 {
+			blended[card.ArenaID] = card.Rating
+		}
+	}
+	return blended, nil
+}
+
+// blended returns the set's blended ratings, building them when the cache
+// is missing, stale, or older than the configuration or the ratings.
+func (r *Repository) blended(ctx context.Context, setCode, draftFormat string) (*blendedSet, time.Time, error) {
+	config, err := r.store.Load(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return r.blendedWith(ctx, config, setCode, draftFormat)
+}
+
+// blendedWith is blended for a configuration the caller has already loaded.
+func (r *Repository) blendedWith(ctx context.Context, config *Config, setCode, draftFormat string) (*blendedSet, time.Time, error) {
+	base, ratedAt, err := r.DraftRatingsRepository.GetCardRatings(ctx, setCode, draftFormat)
+	if err != nil || len(base) == 0 {
+		return nil, ratedAt, err
+	}
+
+	key := cacheKey(setCode, draftFormat)
+	r.mu.Lock()
+	cached := r.cache[key]
+	r.mu.Unlock()
+	if cached != nil && cached.configVersion == config.Version && cached.ratedAt.Equal(ratedAt) && time.Since(cached.builtAt) < cacheTTL {
+		return cached, ratedAt, nil
+	}
+
+	set := &blendedSet{configVersion: config.Version, ratedAt: ratedAt, builtAt: time.Now()}
+	blend := config.Find(setCode, draftFormat)
+	var cfb map[string]float64
+	var personal map[int]float64
+	if blend != nil {
+		cfb = r.cfbScores(ctx, blend, setCode)
+		personal = r.personalScores(ctx, blend, setCode, draftFormat)
+	}
+	set.ratings, set.breakdown = Apply(base, blend, cfb, personal)
+	set.byArenaID = make(map[string]int, len(set.ratings))
+	for i := range set.ratings {
+		set.byArenaID[strconv.Itoa(set.ratings[i].MTGAID)] = i
+	}
+	set.breakdown.SetCode = setCode
+	set.breakdown.Format = draftFormat
+	set.breakdown.ConfigVersion = config.Version
+
+	r.mu.Lock()
+	r.cache[key] = set
+	r.mu.Unlock()
+	return set, ratedAt, nil
+}
+
+// cfbScores returns CFB limited scores by lowercase card name. A failure
+// leaves the source out rather than failing the ratings.
+func (r *Repository) cfbScores(ctx context.Context, blend *Blend, setCode string) map[string]float64 {
+	if r.cfbRepo == nil || blend.Weight(SourceCFB) <= 0 {
+		return nil
+	}
+	cfbRatings, err := r.cfbRepo.GetRatingsForSet(ctx, setCode)
+	if err != nil {
+		log.Printf("Warning: Failed to get CFB ratings for %s: %v", setCode, err)
+		return nil
+	}
+	scores := make(map[string]float64, len(cfbRatings))
+	for _, rating := range cfbRatings {
+		score := rating.LimitedScore
+		if score == 0 && rating.LimitedRating != "" {
+			score = models.LimitedGradeToScore(rating.LimitedRating)
+		}
+		if score > 0 {
+			scores[strings.ToLower(rating.CardName)] = score
+		}
+	}
+	return scores
+}
+
+// personalScores returns the user's win rates by Arena ID. A failure leaves
+// the source out rather than failing the ratings.
+func (r *Repository) personalScores(ctx context.Context, blend *Blend, setCode, draftFormat string) map[int]float64 {
+	if r.personal == nil || blend.Weight(SourcePersonal) <= 0 {
+		return nil
+	}
+	rates, err := r.personal.WinRates(ctx, setCode, draftFormat)
+	if err != nil {
+		log.Printf("Warning: Failed to get personal win rates for %s/%s: %v", setCode, draftFormat, err)
+		return nil
+	}
+	return rates
+}
+
+func (r *Repository) invalidate(setCode, draftFormat string) {
+	r.mu.Lock()
+	delete(r.cache, cacheKey(setCode, draftFormat))
+	r.mu.Unlock()
+}
+
+func cacheKey(setCode, draftFormat string) string {
+	return strings.ToUpper(setCode) + "|" + strings.ToLower(draftFormat)
+}
+
+// Apply blends the ratings of a set. Each source is turned into a standard
+// score within the set, the scores a card has are averaged by weight, and
+// the result is mapped back onto the set's GIHWR mean and spread. Cards with
+// no weighted source keep their GIHWR. cfb is keyed by lowercase card name
+// and personal by Arena ID. A nil blend returns the ratings unchanged.
+func Apply(base []seventeenlands.CardRating, blend *Blend, cfb map[string]float64, personal map[int]float64) ([]seventeenlands.CardRating, *Breakdown) {
+	ratings := append([]seventeenlands.CardRating(nil), base...)
+	breakdown := &Breakdown{Blend: blend, Cards: make([]CardScore, len(ratings))}
+
+	values := map[string][]float64{}
+	present := map[string][]bool{}
+	for _, source := range []string{SourceGIHWR, SourceCFB, SourcePersonal} {
+		values[source] = make([]float64, len(ratings))
+		present[source] = make([]bool, len(ratings))
+	}
+	for i, rating := range ratings {
+		breakdown.Cards[i] = CardScore{
+			ArenaID: rating.MTGAID,
+			Name:    rating.Name,
+			Rating:  rating.GIHWR,
+			GIHWR:   rating.GIHWR,
+			Sources: map[string]float64{},
+			ZScores: map[string]float64{},
+		}
+		if rating.GIHWR > 0 {
+			values[SourceGIHWR][i], present[SourceGIHWR][i] = rating.GIHWR, true
+		}
+		if score, ok := cfb[strings.ToLower(rating.Name)]; ok {
+			values[SourceCFB][i], present[SourceCFB][i] = score, true
+		}
+		if rate, ok := personal[rating.MTGAID]; ok && rating.MTGAID > 0 {
+			values[SourcePersonal][i], present[SourcePersonal][i] = rate, true
+		}
+		for source := range values {
+			if present[source][i] {
+				breakdown.Cards[i].Sources[source] = values[source][i]
+			}
+		}
+	}
+	if blend == nil {
+		return ratings, breakdown
+	}
+
+	mean, spread := meanAndSpread(values[SourceGIHWR], present[SourceGIHWR])
+	if spread == 0 {
+		return ratings, breakdown // No GIHWR scale to map onto
+	}
+	for _, component := range blend.Components {
+		m, s := meanAndSpread(values[component.Source], present[component.Source])
+		if s == 0 {
+			continue
+		}
+		for i := range ratings {
+			if present[component.Source][i] {
+				breakdown.Cards[i].ZScores[component.Source] = stats.Round((values[component.Source][i]-m)/s, 3)
+			}
+		}
+	}
+
+	for i := range ratings {
+		var sum, weights float64
+		for _, component := range blend.Components {
+			z, ok := breakdown.Cards[i].ZScores[component.Source]
+			if !ok || component.Weight <= 0 {
+				continue
+			}
+			sum += component.Weight * z
+			weights += component.Weight
+		}
+		if weights == 0 {
+			continue
+		}
+		ratings[i].GIHWR = stats.Round(mean+spread*sum/weights, 2)
+		breakdown.Cards[i].Rating = ratings[i].GIHWR
+	}
+	return ratings, breakdown
+}
+
+// meanAndSpread returns the mean and standard deviation of the present
+// values, with a zero spread when fewer than two are present.
+func meanAndSpread(values []float64, present []bool) (float64, float64) {
+	var sum float64
+	n := 0
+	for i, v := range values {
+		if present[i] {
+			sum += v
+			n++
+		}
+	}
+	if n < 2 {
+		return 0, 0
+	}
+	mean := sum / float64(n)
+	var squares float64
+	for i, v := range values {
+		if present[i] {
+			squares += (v - mean) * (v - mean)
+		}
+	}
+	return mean, math.Sqrt(squares / float64(n))
+}
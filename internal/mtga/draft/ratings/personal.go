@@ -0,0 +1,89 @@
+package ratings
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+const (
+	// MinPersonalGames is the games a card must have been drawn in before
+	// the user's win rate with it counts toward a blend.
+	MinPersonalGames = 5
+
+	// personalSessionLimit bounds the completed drafts searched for decks.
+	personalSessionLimit = 200
+)
+
+// PersonalRatings supplies the user's own results with each card.
+type PersonalRatings interface {
+	// WinRates returns the win rate when drawn (0.0-1.0) by Arena ID for cards
+	// drawn in at least MinPersonalGames games with decks from the set and format.
+	WinRates(ctx context.Context, setCode, format string) (map[int]float64, error)
+}
+
+// draftDeckRatings computes personal win rates from the decks built in
+// completed drafts.
+type draftDeckRatings struct {
+	draftRepo       repository.DraftRepository
+	deckRepo        repository.DeckRepository
+	performanceRepo repository.CardPerformanceRepository
+}
+
+// NewPersonalRatings creates a personal rating source from draft decks.
+func NewPersonalRatings(draftRepo repository.DraftRepository, deckRepo repository.DeckRepository, performanceRepo repository.CardPerformanceRepository) PersonalRatings {
+	return &draftDeckRatings{
+		draftRepo:       draftRepo,
+		deckRepo:        deckRepo,
+		performanceRepo: performanceRepo,
+	}
+}
+
+// WinRates sums games drawn and wins for each card across every draft deck
+// of the set and format.
+func (p *draftDeckRatings) WinRates(ctx context.Context, setCode, format string) (map[int]float64, error) {
+	sessions, err := p.draftRepo.GetCompletedSessions(ctx, personalSessionLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completed drafts: %w", err)
+	}
+
+	drawn := make(map[int]int)
+	wins := make(map[int]float64)
+	for _, session := range sessions {
+		if !strings.EqualFold(session.SetCode, setCode) || !formatMatches(session, format) {
+			continue
+		}
+		deck, err := p.deckRepo.GetByDraftEvent(ctx, session.ID)
+		if err != nil || deck == nil {
+			continue // No deck was built from this draft
+		}
+		performance, err := p.performanceRepo.GetCardPerformance(ctx, models.CardPerformanceFilter{DeckID: deck.ID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get card performance for deck %s: %w", deck.ID, err)
+		}
+		for _, card := range performance {
+			drawn[card.CardID] += card.GamesDrawn
+			wins[card.CardID] += card.WinRateWhenDrawn * float64(card.GamesDrawn)
+		}
+	}
+
+	rates := make(map[int]float64)
+	for cardID, games := range drawn {
+		if games >= MinPersonalGames {
+			rates[cardID] = wins[cardID] / float64(games)
+		}
+	}
+	return rates, nil
+}
+
+// formatMatches reports whether a draft was played in the format, which may
+// be named by its draft type or appear in its event name.
+func formatMatches(session *models.DraftSession, format string) bool {
+	if format == "" || strings.EqualFold(session.DraftType, format) {
+		return true
+	}
+	return session.DraftType == "" && strings.Contains(strings.ToLower(session.EventName), strings.ToLower(format))
+}
@@ -0,0 +1,282 @@
+package ratings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+type fakeSettings struct {
+	repository.SettingsRepository
+	values map[string]string
+	sets   int
+	getErr error
+}
+
+func (f *fakeSettings) Get(ctx context.Context, key string) (string, error) {
+	if f.getErr != nil {
+		return "", f.getErr
+	}
+	value, ok := f.values[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", repository.ErrSettingNotFound, key)
+	}
+	return value, nil
+}
+
+func (f *fakeSettings) Set(ctx context.Context, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	f.values[key] = string(data)
+	f.sets++
+	return nil
+}
+
+type fakeRatings struct {
+	repository.DraftRatingsRepository
+	ratings []seventeenlands.CardRating
+	ratedAt time.Time
+}
+
+func (f *fakeRatings) GetCardRatings(ctx context.Context, setCode, draftFormat string) ([]seventeenlands.CardRating, time.Time, error) {
+	return f.ratings, f.ratedAt, nil
+}
+
+func (f *fakeRatings) GetCardRatingByArenaID(ctx context.Context, setCode, draftFormat, arenaID string) (*seventeenlands.CardRating, error) {
+	for i := range f.ratings {
+		if fmt.Sprint(f.ratings[i].MTGAID) == arenaID {
+			return &f.ratings[i], nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeCFB struct {
+	repository.CFBRatingsRepository
+	ratings []*models.CFBRating
+}
+
+func (f *fakeCFB) GetRatingsForSet(ctx context.Context, setCode string) ([]*models.CFBRating, error) {
+	return f.ratings, nil
+}
+
+type fakePersonal map[int]float64
+
+func (f fakePersonal) WinRates(ctx context.Context, setCode, format string) (map[int]float64, error) {
+	return f, nil
+}
+
+func testRatings() []seventeenlands.CardRating {
+	return []seventeenlands.CardRating{
+		{Name: "Bomb", MTGAID: 1, GIHWR: 62},
+		{Name: "Filler", MTGAID: 2, GIHWR: 55},
+		{Name: "Chaff", MTGAID: 3, GIHWR: 48},
+	}
+}
+
+func TestConfig_FindFallsBackToBroaderBlends(t *testing.T) {
+	config := &Config{Blends: []Blend{
+		{SetCode: "", Format: "", Version: 1},
+		{SetCode: "", Format: "QuickDraft", Version: 2},
+		{SetCode: "BLB", Format: "", Version: 3},
+		{SetCode: "BLB", Format: "PremierDraft", Version: 4},
+	}}
+	tests := []struct {
+		set, format string
+		want        int
+	}{
+		{"blb", "PremierDraft", 4},
+		{"BLB", "QuickDraft", 3},
+		{"DSK", "QuickDraft", 2},
+		{"DSK", "PremierDraft", 1},
+	}
+	for _, tt := range tests {
+		if got := config.Find(tt.set, tt.format); got == nil || got.Version != tt.want {
+			t.Errorf("Find(%q, %q) = %+v, want version %d", tt.set, tt.format, got, tt.want)
+		}
+	}
+	if (&Config{}).Find("BLB", "PremierDraft") != nil {
+		t.Error("empty config should have no blend")
+	}
+}
+
+func TestBlend_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []Component
+		wantErr    bool
+	}{
+		{"valid", []Component{{SourceGIHWR, 0.6}, {SourceCFB, 0.3}, {SourcePersonal, 0.1}}, false},
+		{"unknown source", []Component{{"vibes", 1}}, true},
+		{"duplicate", []Component{{SourceGIHWR, 0.5}, {SourceGIHWR, 0.5}}, true},
+		{"negative", []Component{{SourceGIHWR, 1}, {SourceCFB, -0.1}}, true},
+		{"no weight", []Component{{SourceGIHWR, 0}}, true},
+	}
+	for _, tt := range tests {
+		b := &Blend{Components: tt.components}
+		if err := b.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestStore_SaveBlendVersionsAndHistory(t *testing.T) {
+	ctx := context.Background()
+	settings := &fakeSettings{values: map[string]string{}}
+	store := NewStore(settings)
+
+	blend := Blend{SetCode: "BLB", Format: "PremierDraft", Components: []Component{{SourceGIHWR, 1}}}
+	saved, err := store.SaveBlend(ctx, blend)
+	if err != nil || saved.Version != 1 {
+		t.Fatalf("first save = %+v, %v", saved, err)
+	}
+	blend.Components = []Component{{SourceGIHWR, 0.7}, {SourceCFB, 0.3}}
+	if saved, err = store.SaveBlend(ctx, blend); err != nil || saved.Version != 2 {
+		t.Fatalf("second save = %+v, %v", saved, err)
+	}
+	if _, err := store.SaveBlend(ctx, Blend{Components: []Component{{"vibes", 1}}}); err == nil {
+		t.Error("invalid blend should not save")
+	}
+
+	// A fresh store reads what was saved
+	config, err := NewStore(settings).Load(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Version != 2 || len(config.Blends) != 1 || len(config.History) != 1 || config.History[0].Version != 1 {
+		t.Errorf("config after two saves = %+v", config)
+	}
+
+	if err := store.DeleteBlend(ctx, "blb", "premierdraft"); err != nil {
+		t.Fatal(err)
+	}
+	config, _ = store.Load(ctx)
+	if config.Version != 3 || len(config.Blends) != 0 || len(config.History) != 2 {
+		t.Errorf("config after delete = %+v", config)
+	}
+	sets := settings.sets
+	if err := store.DeleteBlend(ctx, "BLB", "PremierDraft"); err != nil || settings.sets != sets {
+		t.Error("deleting a missing blend should be a no-op")
+	}
+}
+
+func TestStore_ReadFailureKeepsSavedBlends(t *testing.T) {
+	ctx := context.Background()
+	settings := &fakeSettings{values: map[string]string{}}
+	blend := Blend{SetCode: "BLB", Format: "PremierDraft", Components: []Component{{SourceGIHWR, 1}}}
+	if _, err := NewStore(settings).SaveBlend(ctx, blend); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewStore(settings)
+	settings.getErr = errors.New("database is locked")
+	if _, err := store.Load(ctx); err == nil {
+		t.Error("a failed read should be an error, not an empty configuration")
+	}
+	sets := settings.sets
+	if _, err := store.SaveBlend(ctx, Blend{Components: []Component{{SourceGIHWR, 1}}}); err == nil || settings.sets != sets {
+		t.Error("saving after a failed read should not overwrite the stored blends")
+	}
+
+	settings.getErr = nil
+	config, err := store.Load(ctx)
+	if err != nil || len(config.Blends) != 1 {
+		t.Errorf("config once the read succeeds = %+v, %v", config, err)
+	}
+}
+
+func TestApply_BlendsStandardScores(t *testing.T) {
+	base := testRatings()
+	// CFB disagrees completely with 17Lands
+	cfb := map[string]float64{"bomb": 0.48, "filler": 0.70, "chaff": 0.92}
+
+	ratings, breakdown := Apply(base, &Blend{Components: []Component{{SourceGIHWR, 1}}}, cfb, nil)
+	for i := range ratings {
+		if ratings[i].GIHWR != base[i].GIHWR {
+			t.Errorf("GIHWR-only blend changed %s from %.2f to %.2f", ratings[i].Name, base[i].GIHWR, ratings[i].GIHWR)
+		}
+	}
+	if breakdown.Cards[0].Sources[SourceCFB] != 0.48 {
+		t.Errorf("breakdown sources = %+v", breakdown.Cards[0].Sources)
+	}
+
+	ratings, _ = Apply(base, &Blend{Components: []Component{{SourceGIHWR, 0.5}, {SourceCFB, 0.5}}}, cfb, nil)
+	for _, r := range ratings {
+		if r.GIHWR != 55 {
+			t.Errorf("opposing sources at equal weight should cancel, %s rated %.2f", r.Name, r.GIHWR)
+		}
+	}
+
+	ratings, _ = Apply(base, &Blend{Components: []Component{{SourceCFB, 1}}}, cfb, nil)
+	if ratings[2].GIHWR != 62 || ratings[0].GIHWR != 48 {
+		t.Errorf("CFB-only blend should reorder onto the GIHWR scale, got %+v", ratings)
+	}
+	if base[0].GIHWR != 62 {
+		t.Error("Apply modified its input")
+	}
+}
+
+func TestApply_CardsWithoutSourcesKeepGIHWR(t *testing.T) {
+	base := testRatings()
+	personal := map[int]float64{1: 0.40, 3: 0.70}
+	ratings, breakdown := Apply(base, &Blend{Components: []Component{{SourcePersonal, 1}}}, nil, personal)
+	if ratings[1].GIHWR != 55 {
+		t.Errorf("card without personal games rated %.2f, want its GIHWR", ratings[1].GIHWR)
+	}
+	if ratings[0].GIHWR >= ratings[2].GIHWR {
+		t.Errorf("personal results should lift Chaff above Bomb, got %.2f and %.2f", ratings[2].GIHWR, ratings[0].GIHWR)
+	}
+	if _, ok := breakdown.Cards[1].ZScores[SourcePersonal]; ok {
+		t.Error("card without personal games has a personal score")
+	}
+}
+
+func TestRepository_AppliesConfiguredBlend(t *testing.T) {
+	ctx := context.Background()
+	base := &fakeRatings{ratings: testRatings(), ratedAt: time.Now()}
+	store := NewStore(&fakeSettings{values: map[string]string{}})
+	cfb := &fakeCFB{ratings: []*models.CFBRating{
+		{CardName: "Bomb", LimitedRating: "C"},
+		{CardName: "Filler", LimitedRating: "B"},
+		{CardName: "Chaff", LimitedScore: 0.92},
+	}}
+	repo := NewRepository(base, store, cfb, fakePersonal{})
+
+	// No blend: ratings pass through
+	rating, err := repo.GetCardRatingByArenaID(ctx, "BLB", "PremierDraft", "1")
+	if err != nil || rating.GIHWR != 62 {
+		t.Fatalf("unblended rating = %+v, %v", rating, err)
+	}
+
+	if _, err := store.SaveBlend(ctx, Blend{SetCode: "BLB", Components: []Component{{SourceCFB, 1}}}); err != nil {
+		t.Fatal(err)
+	}
+	rating, err = repo.GetCardRatingByArenaID(ctx, "BLB", "PremierDraft", "3")
+	if err != nil || rating.GIHWR != 62 {
+		t.Errorf("blended Chaff = %+v, %v; want the top rating", rating, err)
+	}
+	ratings, _, err := repo.GetCardRatings(ctx, "BLB", "PremierDraft")
+	if err != nil || ratings[0].GIHWR != 48 {
+		t.Errorf("blended Bomb = %+v, %v; want the bottom rating", ratings[0], err)
+	}
+
+	// Other sets are untouched
+	ratings, _, _ = repo.GetCardRatings(ctx, "DSK", "PremierDraft")
+	if ratings[0].GIHWR != 62 {
+		t.Errorf("DSK Bomb rated %.2f without a blend", ratings[0].GIHWR)
+	}
+
+	breakdown, err := repo.Explain(ctx, "BLB", "PremierDraft")
+	if err != nil || breakdown.Blend == nil || breakdown.ConfigVersion != 1 || len(breakdown.Cards) != 3 {
+		t.Errorf("Explain = %+v, %v", breakdown, err)
+	}
+}
@@ -0,0 +1,24 @@
+package ratings
+
+import (
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/priors"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage"
+)
+
+// NewStorageRepository builds the ratings draft features read from storage:
+// the cached 17Lands ratings, with early-set priors filled in for sparse sets
+// and the user's blends applied on top. It also returns the priors layer.
+func NewStorageRepository(service *storage.Service) (*Repository, *priors.Repository) {
+	priorsRepo := priors.NewRepository(
+		service.DraftRatingsRepo(),
+		service.SetCardRepo(),
+		priors.NewStore(service.SettingsRepo()),
+	)
+	blended := NewRepository(
+		priorsRepo,
+		NewStore(service.SettingsRepo()),
+		service.NewCFBRatingsRepo(),
+		NewPersonalRatings(service.DraftRepo(), service.DeckRepo(), service.CardPerformanceAnalysisRepo()),
+	)
+	return blended, priorsRepo
+}
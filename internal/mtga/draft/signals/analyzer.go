@@ -0,0 +1,432 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/colorid"
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+// Evidence is expressed in "late good card" units: a card 4 GIHWR points above
+// the set average seen 3 picks after its ALSA is worth 1.
+const (
+	qualityUnit     = 4.0 // GIHWR points above average per unit of quality
+	latenessUnit    = 3.0 // Picks past ALSA per unit of lateness
+	maxLateness     = 6.0 // Cap so one very late card cannot dominate
+	middlePackScale = 0.5 // Pack 2 passes the other way, so it says less about the seat we share packs 1 and 3 with
+	wheelBonus      = 1.0 // Extra evidence for a good card that came all the way around
+	cutPenalty      = 0.5 // Evidence against a color when a card expected to wheel was taken
+	opennessScale   = 3.0 // Evidence at which openness reaches ~88
+	podSize         = 8
+	defaultPackSize = 14
+	maxLateCards    = 10
+)
+
+// Colors in WUBRG order.
+var Colors = []string{"W", "U", "B", "R", "G"}
+
+// ColorSignal is the openness reading for one color or color pair.
+type ColorSignal struct {
+	Colors    string  `json:"colors"`     // "W" or "WU"
+	Score     float64 `json:"score"`      // Raw evidence, positive = open
+	Openness  float64 `json:"openness"`   // 0-100, 50 = no signal
+	LateCards int     `json:"late_cards"` // Above-average cards seen past their ALSA
+	Wheeled   int     `json:"wheeled"`    // Above-average cards that wheeled
+	Cut       int     `json:"cut"`        // Cards expected to wheel that were taken
+}
+
+// LateCard is an above-average card seen later than 17Lands drafters usually see it.
+type LateCard struct {
+	CardID       string  `json:"card_id"`
+	CardName     string  `json:"card_name"`
+	Colors       string  `json:"colors"`
+	PackNumber   int     `json:"pack_number"`   // 0-based
+	PickInPack   int     `json:"pick_in_pack"`  // 1-based
+	ExpectedPick float64 `json:"expected_pick"` // ALSA (ATA when ALSA is missing)
+	GIHWR        float64 `json:"gihwr"`
+	Wheeled      bool    `json:"wheeled"`
+	Evidence     float64 `json:"evidence"`
+}
+
+// Snapshot records color openness after one pack was seen.
+type Snapshot struct {
+	PackNumber int                `json:"pack_number"`
+	PickInPack int                `json:"pick_in_pack"`
+	Openness   map[string]float64 `json:"openness"`
+}
+
+// Signals is the signal reading for a draft session.
+type Signals struct {
+	SessionID     string        `json:"session_id"`
+	SetCode       string        `json:"set_code"`
+	PacksAnalyzed int           `json:"packs_analyzed"`
+	Colors        []ColorSignal `json:"colors"`      // WUBRG order
+	Pairs         []ColorSignal `json:"pairs"`       // Most open first
+	OpenColors    []string      `json:"open_colors"` // Colors reading as open, most open first
+	LateCards     []LateCard    `json:"late_cards"`  // Strongest signals first
+	History       []Snapshot    `json:"history"`     // One entry per pack seen, in pick order
+}
+
+// Analyzer reads color signals from the packs seen in a draft.
+type Analyzer struct {
+	draftRepo   repository.DraftRepository
+	ratingsRepo repository.DraftRatingsRepository
+}
+
+// NewAnalyzer creates a new signals analyzer.
+func NewAnalyzer(draftRepo repository.DraftRepository, ratingsRepo repository.DraftRatingsRepository) *Analyzer {
+	return &Analyzer{
+		draftRepo:   draftRepo,
+		ratingsRepo: ratingsRepo,
+	}
+}
+
+// AnalyzeSession reads signals for every pack seen so far in a session.
+// Returns nil if the session has no 17Lands ratings.
+func (a *Analyzer) AnalyzeSession(ctx context.Context, sessionID string) (*Signals, error) {
+	session, err := a.draftRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	packs, err := a.draftRepo.GetPacksBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get packs: %w", err)
+	}
+	picks, err := a.draftRepo.GetPicksBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get picks: %w", err)
+	}
+
+	ratings, _, err := a.ratingsRepo.GetCardRatings(ctx, session.SetCode, session.EventName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card ratings: %w", err)
+	}
+	if len(ratings) == 0 {
+		return nil, nil
+	}
+
+	return Analyze(session, packs, picks, ratings), nil
+}
+
+// Analyze reads signals from a session's packs and picks. Packs are replayed in
+// pick order, so History shows how the reading moved as the draft went on.
+func Analyze(session *models.DraftSession, packs []*models.DraftPackSession, picks []*models.DraftPickSession, ratings []seventeenlands.CardRating) *Signals {
+	r := newReader(session, ratings)
+
+	// Cards we took ourselves, per pack number; they never count as cut
+	for _, pick := range picks {
+		if r.ours[pick.PackNumber] == nil {
+			r.ours[pick.PackNumber] = make(map[string]bool)
+		}
+		r.ours[pick.PackNumber][pick.CardID] = true
+	}
+
+	ordered := make([]*models.DraftPackSession, len(packs))
+	copy(ordered, packs)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].PackNumber != ordered[j].PackNumber {
+			return ordered[i].PackNumber < ordered[j].PackNumber
+		}
+		if ordered[i].PickNumber != ordered[j].PickNumber {
+			return ordered[i].PickNumber < ordered[j].PickNumber
+		}
+		// Pick numbers can collide when a reconstructed first pick meets a
+		// zero-based status update; the fuller pack came first
+		return len(ordered[i].CardIDs) > len(ordered[j].CardIDs)
+	})
+
+	result := &Signals{
+		SessionID:  session.ID,
+		SetCode:    session.SetCode,
+		OpenColors: []string{},
+		History:    []Snapshot{},
+	}
+	for _, pack := range ordered {
+		if len(pack.CardIDs) == 0 {
+			continue
+		}
+		r.readPack(pack)
+		result.PacksAnalyzed++
+		result.History = append(result.History, Snapshot{
+			PackNumber: pack.PackNumber,
+			PickInPack: r.pickInPack(pack),
+			Openness:   r.colorOpenness(),
+		})
+	}
+
+	result.Colors = r.colorSignals()
+	result.Pairs = r.pairSignals()
+	for _, c := range sortedByScore(result.Colors) {
+		if c.Openness > 50 {
+			result.OpenColors = append(result.OpenColors, c.Colors)
+		}
+	}
+
+	sort.SliceStable(r.late, func(i, j int) bool { return r.late[i].Evidence > r.late[j].Evidence })
+	if len(r.late) > maxLateCards {
+		r.late = r.late[:maxLateCards]
+	}
+	result.LateCards = r.late
+	return result
+}
+
+// reader accumulates signal evidence pack by pack.
+type reader struct {
+	packSize int
+	ratings  map[string]seventeenlands.CardRating
+	average  float64
+
+	ours  map[int]map[string]bool                  // pack number -> cards we picked
+	seen  map[int]map[int]*models.DraftPackSession // pack number -> pick in pack -> pack
+	score map[string]float64                       // color or pair -> evidence
+	stats map[string]*ColorSignal                  // color or pair -> counters
+	late  []LateCard
+}
+
+func newReader(session *models.DraftSession, ratings []seventeenlands.CardRating) *reader {
+	r := &reader{
+		packSize: defaultPackSize,
+		ratings:  make(map[string]seventeenlands.CardRating, len(ratings)),
+		ours:     make(map[int]map[string]bool),
+		seen:     make(map[int]map[int]*models.DraftPackSession),
+		score:    make(map[string]float64),
+		stats:    make(map[string]*ColorSignal),
+		late:     []LateCard{},
+	}
+	if session.TotalPicks >= 3 {
+		r.packSize = session.TotalPicks / 3
+	}
+
+	var sum float64
+	var n int
+	for _, rating := range ratings {
+		if rating.MTGAID == 0 {
+			continue
+		}
+		r.ratings[strconv.Itoa(rating.MTGAID)] = rating
+		if rating.GIHWR > 0 {
+			sum += rating.GIHWR
+			n++
+		}
+	}
+	if n > 0 {
+		r.average = sum / float64(n)
+	}
+	return r
+}
+
+// pickInPack derives the 1-based pick position from how many cards are left.
+func (r *reader) pickInPack(pack *models.DraftPackSession) int {
+	pick := r.packSize - len(pack.CardIDs) + 1
+	if pick < 1 {
+		pick = 1
+	}
+	return pick
+}
+
+// readPack adds the evidence from one pack: good cards still there past their
+// ALSA, and for packs we have seen before, what wheeled and what was taken.
+func (r *reader) readPack(pack *models.DraftPackSession) {
+	pick := r.pickInPack(pack)
+	if r.seen[pack.PackNumber] == nil {
+		r.seen[pack.PackNumber] = make(map[int]*models.DraftPackSession)
+	}
+	r.seen[pack.PackNumber][pick] = pack
+
+	// We opened this pack; nobody has passed on anything yet
+	if pick <= 1 {
+		return
+	}
+
+	weight := 1.0
+	if pack.PackNumber == 1 {
+		weight = middlePackScale
+	}
+
+	first := r.seen[pack.PackNumber][pick-podSize]
+	wheeled := first != nil && isSubset(pack.CardIDs, first.CardIDs)
+	inPack := make(map[string]bool, len(pack.CardIDs))
+	for _, id := range pack.CardIDs {
+		inPack[id] = true
+	}
+
+	for _, id := range pack.CardIDs {
+		rating, ok := r.ratings[id]
+		quality := r.quality(rating)
+		expected := expectedPick(rating)
+		if !ok || quality <= 0 || expected <= 0 || float64(pick) <= expected {
+			continue
+		}
+
+		lateness := math.Min(float64(pick)-expected, maxLateness) / latenessUnit
+		evidence := quality * lateness
+		if wheeled {
+			evidence += quality * wheelBonus
+		}
+		evidence *= weight
+
+		colors := colorid.Key(rating.Color)
+		r.add(colors, evidence, func(s *ColorSignal) {
+			s.LateCards++
+			if wheeled {
+				s.Wheeled++
+			}
+		})
+		r.late = append(r.late, LateCard{
+			CardID:       id,
+			CardName:     rating.Name,
+			Colors:       colors,
+			PackNumber:   pack.PackNumber,
+			PickInPack:   pick,
+			ExpectedPick: expected,
+			GIHWR:        rating.GIHWR,
+			Wheeled:      wheeled,
+			Evidence:     stats.Round(evidence, 2),
+		})
+	}
+
+	if !wheeled {
+		return
+	}
+
+	// Playable cards that usually wheel but were taken this lap tell us
+	// someone else at the table is in those colors
+	for _, id := range first.CardIDs {
+		if inPack[id] || r.ours[pack.PackNumber][id] {
+			continue
+		}
+		rating, ok := r.ratings[id]
+		if !ok || rating.GIHWR < r.average || expectedPick(rating) < float64(pick) {
+			continue
+		}
+		r.add(colorid.Key(rating.Color), -cutPenalty*weight, func(s *ColorSignal) { s.Cut++ })
+	}
+}
+
+// add credits evidence to a card's colors. Mono-colored cards count fully for
+// their color; gold cards split it across their colors and count fully for
+// their pair.
+func (r *reader) add(colors string, evidence float64, count func(*ColorSignal)) {
+	if colors == "" {
+		return
+	}
+	if len(colors) == 1 {
+		r.score[colors] += evidence
+		count(r.stat(colors))
+		return
+	}
+	for _, c := range colors {
+		r.score[string(c)] += evidence / float64(len(colors))
+		count(r.stat(string(c)))
+	}
+	if len(colors) == 2 {
+		r.score[colors] += evidence
+	}
+}
+
+func (r *reader) stat(colors string) *ColorSignal {
+	s, ok := r.stats[colors]
+	if !ok {
+		s = &ColorSignal{Colors: colors}
+		r.stats[colors] = s
+	}
+	return s
+}
+
+// quality is how far above the set average a card plays, in quality units.
+func (r *reader) quality(rating seventeenlands.CardRating) float64 {
+	if rating.GIHWR <= 0 || r.average <= 0 {
+		return 0
+	}
+	return (rating.GIHWR - r.average) / qualityUnit
+}
+
+func (r *reader) colorOpenness() map[string]float64 {
+	openness := make(map[string]float64, len(Colors))
+	for _, c := range Colors {
+		openness[c] = opennessOf(r.score[c])
+	}
+	return openness
+}
+
+func (r *reader) colorSignals() []ColorSignal {
+	signals := make([]ColorSignal, 0, len(Colors))
+	for _, c := range Colors {
+		s := *r.stat(c)
+		s.Score = stats.Round(r.score[c], 2)
+		s.Openness = opennessOf(r.score[c])
+		signals = append(signals, s)
+	}
+	return signals
+}
+
+// pairSignals averages the two colors' evidence and adds gold cards for the pair.
+func (r *reader) pairSignals() []ColorSignal {
+	var pairs []ColorSignal
+	for i := 0; i < len(Colors); i++ {
+		for j := i + 1; j < len(Colors); j++ {
+			a, b := r.stat(Colors[i]), r.stat(Colors[j])
+			key := Colors[i] + Colors[j]
+			score := (r.score[Colors[i]]+r.score[Colors[j]])/2 + r.score[key]
+			pairs = append(pairs, ColorSignal{
+				Colors:    key,
+				Score:     stats.Round(score, 2),
+				Openness:  opennessOf(score),
+				LateCards: a.LateCards + b.LateCards,
+				Wheeled:   a.Wheeled + b.Wheeled,
+				Cut:       a.Cut + b.Cut,
+			})
+		}
+	}
+	return sortedByScore(pairs)
+}
+
+// expectedPick is when 17Lands drafters last see a card, falling back to when they take it.
+func expectedPick(rating seventeenlands.CardRating) float64 {
+	if rating.ALSA > 0 {
+		return rating.ALSA
+	}
+	return rating.ATA
+}
+
+// isSubset reports whether every card in later is also in earlier, i.e. later
+// is the same physical pack coming back around.
+func isSubset(later, earlier []string) bool {
+	if len(later) >= len(earlier) {
+		return false
+	}
+	counts := make(map[string]int, len(earlier))
+	for _, id := range earlier {
+		counts[id]++
+	}
+	for _, id := range later {
+		if counts[id] == 0 {
+			return false
+		}
+		counts[id]--
+	}
+	return true
+}
+
+func sortedByScore(signals []ColorSignal) []ColorSignal {
+	sorted := make([]ColorSignal, len(signals))
+	copy(sorted, signals)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Score > sorted[j].Score })
+	return sorted
+}
+
+// opennessOf maps evidence onto 0-100 with 50 meaning no signal either way.
+func opennessOf(score float64) float64 {
+	return stats.Round(50+50*math.Tanh(score/opennessScale), 2)
+}
@@ -0,0 +1,195 @@
+package signals
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+// fakeDraftRepo serves one session with its packs and picks.
+type fakeDraftRepo struct {
+	repository.DraftRepository
+	session *models.DraftSession
+	packs   []*models.DraftPackSession
+	picks   []*models.DraftPickSession
+}
+
+func (f *fakeDraftRepo) GetSession(ctx context.Context, id string) (*models.DraftSession, error) {
+	return f.session, nil
+}
+
+func (f *fakeDraftRepo) GetPacksBySession(ctx context.Context, sessionID string) ([]*models.DraftPackSession, error) {
+	return f.packs, nil
+}
+
+func (f *fakeDraftRepo) GetPicksBySession(ctx context.Context, sessionID string) ([]*models.DraftPickSession, error) {
+	return f.picks, nil
+}
+
+// fakeRatingsRepo serves a fixed set of card ratings.
+type fakeRatingsRepo struct {
+	repository.DraftRatingsRepository
+	cards []seventeenlands.CardRating
+}
+
+func (f *fakeRatingsRepo) GetCardRatings(ctx context.Context, setCode, draftFormat string) ([]seventeenlands.CardRating, time.Time, error) {
+	return f.cards, time.Now(), nil
+}
+
+// testRatings has filler at 55% GIHWR (the set average is ~55) plus a few
+// standouts: a red bomb usually gone by pick 2, a green common that usually
+// goes by pick 5 and a blue playable that usually wheels.
+func testRatings() []seventeenlands.CardRating {
+	ratings := []seventeenlands.CardRating{
+		{MTGAID: 1, Name: "Red Bomb", Color: "R", GIHWR: 63, ALSA: 2},
+		{MTGAID: 2, Name: "Green Beast", Color: "G", GIHWR: 60, ALSA: 5},
+		{MTGAID: 3, Name: "Blue Filler", Color: "U", GIHWR: 56, ALSA: 11},
+		{MTGAID: 4, Name: "Gold Bruiser", Color: "RG", GIHWR: 61, ALSA: 3},
+	}
+	for i := 100; i < 140; i++ {
+		ratings = append(ratings, seventeenlands.CardRating{MTGAID: i, Name: "Filler " + strconv.Itoa(i), Color: "W", GIHWR: 54, ALSA: 8})
+	}
+	return ratings
+}
+
+// testPackSize is the pack size of the 42-pick test sessions.
+const testPackSize = 14
+
+// pack builds a pack holding the given cards padded with filler to size,
+// numbered as the pick it was seen at.
+func pack(packNumber, size int, cards ...string) *models.DraftPackSession {
+	ids := append([]string{}, cards...)
+	for i := 100; len(ids) < size; i++ {
+		ids = append(ids, strconv.Itoa(i))
+	}
+	return &models.DraftPackSession{PackNumber: packNumber, PickNumber: testPackSize - size + 1, CardIDs: ids}
+}
+
+func find(signals []ColorSignal, colors string) ColorSignal {
+	for _, s := range signals {
+		if s.Colors == colors {
+			return s
+		}
+	}
+	return ColorSignal{}
+}
+
+func TestAnalyze_LateCardOpensItsColor(t *testing.T) {
+	session := &models.DraftSession{ID: "s1", SetCode: "TST", TotalPicks: 42}
+	packs := []*models.DraftPackSession{
+		pack(0, 13, "1"), // Red Bomb at pick 2 is on time
+		pack(0, 14),
+		pack(0, 10, "2"), // Green Beast at pick 5 is on time
+		pack(0, 7, "4"),  // Gold Bruiser at pick 8 is five picks late
+	}
+
+	result := Analyze(session, packs, nil, testRatings())
+
+	if result.PacksAnalyzed != 4 || len(result.History) != 4 {
+		t.Fatalf("analyzed %d packs with %d snapshots, want 4 and 4", result.PacksAnalyzed, len(result.History))
+	}
+	if result.History[0].PickInPack != 1 || result.History[3].PickInPack != 8 {
+		t.Errorf("history picks = %d..%d, want 1..8", result.History[0].PickInPack, result.History[3].PickInPack)
+	}
+
+	red, green, white := find(result.Colors, "R"), find(result.Colors, "G"), find(result.Colors, "W")
+	if red.Openness <= 50 || green.Openness <= 50 {
+		t.Errorf("R/G should read open after a late gold card, got R %.1f G %.1f", red.Openness, green.Openness)
+	}
+	if white.Openness != 50 {
+		t.Errorf("below-average filler should not move white, got %.1f", white.Openness)
+	}
+	if result.Pairs[0].Colors != "RG" {
+		t.Errorf("most open pair = %s, want RG", result.Pairs[0].Colors)
+	}
+	if len(result.LateCards) != 1 || result.LateCards[0].CardID != "4" || result.LateCards[0].PickInPack != 8 {
+		t.Errorf("late cards = %+v, want only Gold Bruiser at pick 8", result.LateCards)
+	}
+	if result.History[3].Openness["R"] <= result.History[2].Openness["R"] {
+		t.Error("history should show red opening at pick 8")
+	}
+}
+
+func TestAnalyze_WheelAndCut(t *testing.T) {
+	session := &models.DraftSession{ID: "s1", SetCode: "TST", TotalPicks: 42}
+	first := pack(0, 14, "2", "3", "1")
+	// Pick 9: Green Beast wheeled; Blue Filler, expected to wheel, was taken.
+	// Red Bomb was our first pick, so it doesn't count as cut.
+	wheel := &models.DraftPackSession{PackNumber: 0, PickNumber: 9, CardIDs: append([]string{"2"}, first.CardIDs[3:8]...)}
+	picks := []*models.DraftPickSession{{PackNumber: 0, PickNumber: 1, CardID: "1"}}
+
+	result := Analyze(session, []*models.DraftPackSession{first, wheel}, picks, testRatings())
+
+	green, blue, red := find(result.Colors, "G"), find(result.Colors, "U"), find(result.Colors, "R")
+	if green.Wheeled != 1 || green.Openness <= 50 {
+		t.Errorf("green = %+v, want one wheeled card and open", green)
+	}
+	if blue.Cut != 1 || blue.Openness >= 50 {
+		t.Errorf("blue = %+v, want one cut card and closed", blue)
+	}
+	if red.Cut != 0 {
+		t.Errorf("our own pick counted as cut: %+v", red)
+	}
+	if len(result.OpenColors) == 0 || result.OpenColors[0] != "G" {
+		t.Errorf("open colors = %v, want G first", result.OpenColors)
+	}
+}
+
+func TestAnalyze_ReplaysPacksInPickOrder(t *testing.T) {
+	session := &models.DraftSession{ID: "s1", SetCode: "TST", TotalPicks: 42}
+	// Both packs were logged with seven cards; the pick number, not the card
+	// count, says the one holding Gold Bruiser came first.
+	late := pack(0, 7, "4")
+	next := pack(0, 7)
+	next.PickNumber = late.PickNumber + 1
+
+	result := Analyze(session, []*models.DraftPackSession{next, late}, nil, testRatings())
+
+	if len(result.History) != 2 {
+		t.Fatalf("got %d snapshots, want 2", len(result.History))
+	}
+	if result.History[0].Openness["R"] <= 50 {
+		t.Errorf("first snapshot should read Gold Bruiser's pack, got R %.1f", result.History[0].Openness["R"])
+	}
+}
+
+func TestAnalyze_MiddlePackCountsLess(t *testing.T) {
+	session := &models.DraftSession{ID: "s1", SetCode: "TST", TotalPicks: 42}
+	ratings := testRatings()
+
+	first := Analyze(session, []*models.DraftPackSession{pack(0, 7, "2")}, nil, ratings)
+	middle := Analyze(session, []*models.DraftPackSession{pack(1, 7, "2")}, nil, ratings)
+
+	got, want := find(middle.Colors, "G").Score, find(first.Colors, "G").Score*middlePackScale
+	if got != want {
+		t.Errorf("pack 2 evidence = %.2f, want %.2f", got, want)
+	}
+}
+
+func TestAnalyzer_AnalyzeSessionWithoutRatings(t *testing.T) {
+	drafts := &fakeDraftRepo{
+		session: &models.DraftSession{ID: "s1", SetCode: "TST", TotalPicks: 42},
+		packs:   []*models.DraftPackSession{pack(0, 14)},
+	}
+
+	result, err := NewAnalyzer(drafts, &fakeRatingsRepo{}).AnalyzeSession(context.Background(), "s1")
+	if err != nil {
+		t.Fatalf("AnalyzeSession failed: %v", err)
+	}
+	if result != nil {
+		t.Errorf("expected nil signals without ratings, got %+v", result)
+	}
+
+	result, err = NewAnalyzer(drafts, &fakeRatingsRepo{cards: testRatings()}).AnalyzeSession(context.Background(), "s1")
+	if err != nil {
+		t.Fatalf("AnalyzeSession failed: %v", err)
+	}
+	if result == nil || result.SessionID != "s1" || result.PacksAnalyzed != 1 {
+		t.Errorf("unexpected signals %+v", result)
+	}
+}
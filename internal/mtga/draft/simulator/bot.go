@@ -0,0 +1,118 @@
+package simulator
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/colorid"
+)
+
+// Bot pick scores are in GIHWR percentage points.
+const (
+	commitPicks     = 10.0 // Picks until a bot is fully committed to its colors
+	onColorBonus    = 3.0  // Bonus for cards in the bot's colors once committed
+	offColorPenalty = 5.0  // Penalty per color outside the bot's colors once committed
+	pickNoise       = 1.0  // Spread of random noise so bots don't all draft alike
+	colorPullFloor  = 50.0 // GIHWR above which a pick pulls a bot further into its colors
+)
+
+// unratedGIHWR stands in for cards 17Lands has no rating for.
+var unratedGIHWR = map[string]float64{
+	"common":   52,
+	"uncommon": 53,
+	"rare":     55,
+	"mythic":   56,
+}
+
+// card is the simulator's view of a draftable card.
+type card struct {
+	ID     string
+	Name   string
+	Rarity string
+	Colors string // WUBRG letters, "" for colorless
+	GIHWR  float64
+	Rated  bool
+}
+
+// Bot is a computer drafter. It takes the best card by 17Lands GIHWR early
+// and increasingly favors its two strongest colors as its pool grows.
+type Bot struct {
+	Seat  int
+	Picks []string
+
+	colorWeight map[string]float64
+	rng         *rand.Rand
+}
+
+func newBot(seat int, rng *rand.Rand) *Bot {
+	return &Bot{Seat: seat, colorWeight: make(map[string]float64), rng: rng}
+}
+
+// Colors returns the bot's two strongest colors in WUBRG order.
+func (b *Bot) Colors() string {
+	colors := make([]string, 0, len(b.colorWeight))
+	for c, w := range b.colorWeight {
+		if w > 0 {
+			colors = append(colors, c)
+		}
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		if b.colorWeight[colors[i]] != b.colorWeight[colors[j]] {
+			return b.colorWeight[colors[i]] > b.colorWeight[colors[j]]
+		}
+		return colors[i] < colors[j]
+	})
+	if len(colors) > 2 {
+		colors = colors[:2]
+	}
+	return colorid.Key(strings.Join(colors, ""))
+}
+
+// Pick chooses a card from the pack, records it and returns its index.
+func (b *Bot) Pick(pack []string, cards map[string]*card) int {
+	best, bestScore := 0, math.Inf(-1)
+	for i, id := range pack {
+		if score := b.score(cards[id]); score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	b.take(cards[pack[best]])
+	return best
+}
+
+// score rates a card for this bot. Commitment ramps from 0 to 1 over the
+// first picks, so early picks follow raw card quality.
+func (b *Bot) score(c *card) float64 {
+	score := c.GIHWR + b.rng.NormFloat64()*pickNoise
+	pair := b.Colors()
+	if pair == "" || c.Colors == "" {
+		return score
+	}
+
+	commitment := math.Min(1, float64(len(b.Picks))/commitPicks)
+	off := 0
+	for _, color := range c.Colors {
+		if !strings.ContainsRune(pair, color) {
+			off++
+		}
+	}
+	if off == 0 {
+		return score + commitment*onColorBonus
+	}
+	return score - commitment*offColorPenalty*float64(off)
+}
+
+// take adds a card to the pool. Every colored pick counts a little toward its
+// colors; good ones count more.
+func (b *Bot) take(c *card) {
+	b.Picks = append(b.Picks, c.ID)
+	if c.Colors == "" {
+		return
+	}
+	weight := 1 + math.Max(0, c.GIHWR-colorPullFloor)
+	for _, color := range c.Colors {
+		b.colorWeight[string(color)] += weight / float64(len(c.Colors))
+	}
+}
@@ -0,0 +1,216 @@
+package simulator
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+)
+
+// PackSlot is a group of cards in a booster drawn from the same rarity odds.
+type PackSlot struct {
+	Count    int                `json:"count"`
+	Rarities map[string]float64 `json:"rarities"` // Rarity -> relative odds, e.g. {"rare": 7, "mythic": 1}
+}
+
+// PackConfig describes how boosters for a set are collated.
+type PackConfig struct {
+	Slots []PackSlot `json:"slots"`
+	// PrintRuns weights individual cards within their rarity, keyed by card
+	// name. A card printed twice as often as its peers gets 2. Cards not listed
+	// get 1.
+	PrintRuns map[string]float64 `json:"print_runs,omitempty"`
+}
+
+// DefaultPackConfig is a 14-card Arena booster: one rare or mythic (7:1),
+// three uncommons and ten commons. Every set is drafted with it unless the
+// caller passes its own layout in Options.PackConfig, e.g. for sets with
+// bonus sheets or special guest slots.
+var DefaultPackConfig = PackConfig{
+	Slots: []PackSlot{
+		{Count: 1, Rarities: map[string]float64{"rare": 7, "mythic": 1}},
+		{Count: 3, Rarities: map[string]float64{"uncommon": 1}},
+		{Count: 10, Rarities: map[string]float64{"common": 1}},
+	},
+}
+
+// Size returns the number of cards in a pack.
+func (c PackConfig) Size() int {
+	size := 0
+	for _, slot := range c.Slots {
+		size += slot.Count
+	}
+	return size
+}
+
+// Validate reports configurations that cannot produce packs.
+func (c PackConfig) Validate() error {
+	if len(c.Slots) == 0 {
+		return fmt.Errorf("pack config has no slots")
+	}
+	for i, slot := range c.Slots {
+		if slot.Count <= 0 {
+			return fmt.Errorf("slot %d has no cards", i)
+		}
+		total := 0.0
+		for _, weight := range slot.Rarities {
+			if weight < 0 {
+				return fmt.Errorf("slot %d has a negative rarity weight", i)
+			}
+			total += weight
+		}
+		if total == 0 {
+			return fmt.Errorf("slot %d has no rarities", i)
+		}
+	}
+	return nil
+}
+
+// sheet is the cards of one rarity with their print run weights.
+type sheet struct {
+	cardIDs []string
+	weights []float64
+}
+
+// packGenerator opens boosters from a set's cards.
+type packGenerator struct {
+	config PackConfig
+	sheets map[string]*sheet
+	rng    *rand.Rand
+}
+
+// newPackGenerator sorts draftable cards onto per-rarity sheets. Basic lands
+// and cards without an Arena ID never appear in packs.
+func newPackGenerator(cards []*models.SetCard, config PackConfig, rng *rand.Rand) (*packGenerator, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	g := &packGenerator{config: config, sheets: make(map[string]*sheet), rng: rng}
+	for _, card := range cards {
+		if card.ArenaID == "" || card.ArenaID == "0" || isBasicLand(card) {
+			continue
+		}
+		rarity := strings.ToLower(card.Rarity)
+		weight := 1.0
+		if w, ok := config.PrintRuns[card.Name]; ok {
+			weight = w
+		}
+		if weight <= 0 {
+			continue
+		}
+		s := g.sheets[rarity]
+		if s == nil {
+			s = &sheet{}
+			g.sheets[rarity] = s
+		}
+		s.cardIDs = append(s.cardIDs, card.ArenaID)
+		s.weights = append(s.weights, weight)
+	}
+
+	// Every slot needs at least one rarity with cards
+	for i, slot := range config.Slots {
+		if g.slotWeight(slot) == 0 {
+			return nil, fmt.Errorf("no cards for slot %d rarities", i)
+		}
+	}
+	return g, nil
+}
+
+// Open returns one booster. A card appears at most once per pack unless its
+// sheet runs out of other cards.
+func (g *packGenerator) Open() []string {
+	pack := make([]string, 0, g.config.Size())
+	inPack := make(map[string]bool)
+	for _, slot := range g.config.Slots {
+		for i := 0; i < slot.Count; i++ {
+			rarity := g.drawRarity(slot)
+			cardID := g.drawCard(g.sheets[rarity], inPack)
+			inPack[cardID] = true
+			pack = append(pack, cardID)
+		}
+	}
+	return pack
+}
+
+// slotWeight is the total odds of a slot's rarities that have cards.
+func (g *packGenerator) slotWeight(slot PackSlot) float64 {
+	total := 0.0
+	for rarity, weight := range slot.Rarities {
+		if s := g.sheets[rarity]; s != nil && len(s.cardIDs) > 0 {
+			total += weight
+		}
+	}
+	return total
+}
+
+// drawRarity picks a rarity for a slot, skipping rarities the set lacks.
+func (g *packGenerator) drawRarity(slot PackSlot) string {
+	// Iterate in a fixed order so a seed always opens the same packs
+	rarities := make([]string, 0, len(slot.Rarities))
+	for rarity := range slot.Rarities {
+		rarities = append(rarities, rarity)
+	}
+	sort.Strings(rarities)
+
+	roll := g.rng.Float64() * g.slotWeight(slot)
+	last := ""
+	for _, rarity := range rarities {
+		if s := g.sheets[rarity]; s == nil || len(s.cardIDs) == 0 {
+			continue
+		}
+		last = rarity
+		roll -= slot.Rarities[rarity]
+		if roll < 0 {
+			return rarity
+		}
+	}
+	return last
+}
+
+// drawCard picks a card from a sheet by print run weight, avoiding cards
+// already in the pack when possible.
+func (g *packGenerator) drawCard(s *sheet, exclude map[string]bool) string {
+	total := 0.0
+	for i, id := range s.cardIDs {
+		if !exclude[id] {
+			total += s.weights[i]
+		}
+	}
+	if total == 0 {
+		return s.cardIDs[g.rng.Intn(len(s.cardIDs))]
+	}
+
+	roll := g.rng.Float64() * total
+	for i, id := range s.cardIDs {
+		if exclude[id] {
+			continue
+		}
+		roll -= s.weights[i]
+		if roll < 0 {
+			return id
+		}
+	}
+	// Rounding can leave roll at zero after the last card
+	for i := len(s.cardIDs) - 1; i >= 0; i-- {
+		if !exclude[s.cardIDs[i]] {
+			return s.cardIDs[i]
+		}
+	}
+	return s.cardIDs[0]
+}
+
+func isBasicLand(card *models.SetCard) bool {
+	return containsString(card.Types, "Basic") && containsString(card.Types, "Land")
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
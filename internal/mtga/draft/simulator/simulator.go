@@ -0,0 +1,435 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/colorid"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+const (
+	podSize         = 8
+	packsPerDrafter = 3
+	userSeat        = 0
+
+	// draftIdleTimeout is how long a draft is kept after it was last looked at
+	// or picked from. Finished and abandoned drafts are dropped after it.
+	draftIdleTimeout = 2 * time.Hour
+)
+
+var (
+	// ErrDraftNotFound is returned for simulated drafts this process is not running.
+	ErrDraftNotFound = errors.New("simulated draft not found")
+	// ErrCardNotInPack is returned when the user picks a card they were not passed.
+	ErrCardNotInPack = errors.New("card is not in the current pack")
+	// ErrDraftComplete is returned when picking after the last pick.
+	ErrDraftComplete = errors.New("simulated draft is complete")
+)
+
+// SetSource supplies a set's cached cards. *setcache.Fetcher implements it.
+type SetSource interface {
+	GetCachedSet(ctx context.Context, setCode string) ([]*models.SetCard, error)
+}
+
+// Options configures a new simulated draft.
+type Options struct {
+	SetCode     string
+	DraftFormat string      // 17Lands format for bot ratings; defaults to PremierDraft
+	Seed        int64       // 0 picks a random seed
+	PackConfig  *PackConfig // Booster layout; nil uses DefaultPackConfig
+}
+
+// CardView is a card as shown to the user.
+type CardView struct {
+	CardID string  `json:"card_id"`
+	Name   string  `json:"name"`
+	Rarity string  `json:"rarity"`
+	Colors string  `json:"colors"`
+	GIHWR  float64 `json:"gihwr,omitempty"` // 17Lands GIHWR (percent), omitted when unrated
+}
+
+// BotView describes one bot drafter. Picks are revealed once the draft is complete.
+type BotView struct {
+	Seat   int        `json:"seat"`
+	Colors string     `json:"colors"`
+	Picks  []CardView `json:"picks,omitempty"`
+}
+
+// State is the user's view of a simulated draft.
+type State struct {
+	SessionID     string     `json:"session_id"`
+	SetCode       string     `json:"set_code"`
+	DraftFormat   string     `json:"draft_format"`
+	Seed          int64      `json:"seed"`
+	PackNumber    int        `json:"pack_number"` // 0-based
+	PickNumber    int        `json:"pick_number"` // 1-based within the pack
+	PackSize      int        `json:"pack_size"`
+	TotalPicks    int        `json:"total_picks"`
+	PicksMade     int        `json:"picks_made"`
+	PassDirection string     `json:"pass_direction"` // "left" or "right"
+	Complete      bool       `json:"complete"`
+	Pack          []CardView `json:"pack"`
+	Pool          []CardView `json:"pool"`
+	Bots          []BotView  `json:"bots"`
+}
+
+// Simulator runs practice drafts against bots. Each draft is stored as a
+// regular draft session as it goes, so grading, prediction and deck building
+// work on it like on a real draft. Drafts in progress live in memory, do not
+// survive a restart and are dropped once idle for draftIdleTimeout.
+type Simulator struct {
+	sets        SetSource
+	draftRepo   repository.DraftRepository
+	ratingsRepo repository.DraftRatingsRepository
+
+	mu     sync.Mutex
+	drafts map[string]*draft
+}
+
+// NewSimulator creates a new draft simulator.
+func NewSimulator(sets SetSource, draftRepo repository.DraftRepository, ratingsRepo repository.DraftRatingsRepository) *Simulator {
+	return &Simulator{
+		sets:        sets,
+		draftRepo:   draftRepo,
+		ratingsRepo: ratingsRepo,
+		drafts:      make(map[string]*draft),
+	}
+}
+
+// draft is one simulated pod. The user sits in seat 0.
+type draft struct {
+	mu       sync.Mutex
+	session  *models.DraftSession
+	format   string
+	seed     int64
+	cards    map[string]*card
+	packSize int
+	round    int          // 0-based pack number
+	pick     int          // 1-based pick within the pack
+	packs    [][]string   // Pack currently held by each seat
+	unopened [][][]string // [round][seat] boosters not yet opened
+	bots     []*Bot       // Seats 1-7
+	pool     []string
+	picks    int
+	complete bool
+	lastUsed time.Time // Guarded by Simulator.mu
+}
+
+// Start opens boosters for a new pod, stores the draft session and returns the
+// user's first pack.
+func (s *Simulator) Start(ctx context.Context, opts Options) (*State, error) {
+	setCode := strings.ToUpper(opts.SetCode)
+	if setCode == "" {
+		return nil, fmt.Errorf("set code is required")
+	}
+	format := opts.DraftFormat
+	if format == "" {
+		format = "PremierDraft"
+	}
+	config := DefaultPackConfig
+	if opts.PackConfig != nil {
+		config = *opts.PackConfig
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	setCards, err := s.sets.GetCachedSet(ctx, setCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cards for %s: %w", setCode, err)
+	}
+	if len(setCards) == 0 {
+		return nil, fmt.Errorf("no cached cards for %s; fetch the set first", setCode)
+	}
+	generator, err := newPackGenerator(setCards, config, rng)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pack config for %s: %w", setCode, err)
+	}
+
+	d := &draft{
+		format:   format,
+		seed:     seed,
+		cards:    s.loadCards(ctx, setCode, format, setCards),
+		packSize: config.Size(),
+		pick:     1,
+	}
+	for round := 0; round < packsPerDrafter; round++ {
+		boosters := make([][]string, podSize)
+		for seat := range boosters {
+			boosters[seat] = generator.Open()
+		}
+		d.unopened = append(d.unopened, boosters)
+	}
+	for seat := 1; seat < podSize; seat++ {
+		d.bots = append(d.bots, newBot(seat, rng))
+	}
+	d.packs = append([][]string(nil), d.unopened[0]...)
+
+	now := time.Now()
+	d.session = &models.DraftSession{
+		ID:          fmt.Sprintf("sim_%s_%d", setCode, now.UnixNano()),
+		EventName:   format,
+		SetCode:     setCode,
+		DraftType:   format,
+		StartTime:   now,
+		Status:      "in_progress",
+		TotalPicks:  d.packSize * packsPerDrafter,
+		IsSimulated: true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.draftRepo.CreateSession(ctx, d.session); err != nil {
+		return nil, fmt.Errorf("failed to create draft session: %w", err)
+	}
+	s.saveCurrentPack(ctx, d)
+
+	s.mu.Lock()
+	s.prune(now)
+	d.lastUsed = now
+	s.drafts[d.session.ID] = d
+	s.mu.Unlock()
+
+	log.Printf("[Simulator] Started %s draft %s (seed %d, %d-card packs)", setCode, d.session.ID, seed, d.packSize)
+	return d.state(), nil
+}
+
+// State returns the current state of a simulated draft.
+func (s *Simulator) State(sessionID string) (*State, error) {
+	d, err := s.get(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.state(), nil
+}
+
+// Pick takes a card from the user's pack, lets the bots pick and passes the
+// packs. The pick is stored before the pod moves on, so a failed save leaves
+// the draft where it was.
+func (s *Simulator) Pick(ctx context.Context, sessionID, cardID string) (*State, error) {
+	d, err := s.get(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.complete {
+		return nil, ErrDraftComplete
+	}
+	index := -1
+	for i, id := range d.packs[userSeat] {
+		if id == cardID {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return nil, ErrCardNotInPack
+	}
+
+	pick := &models.DraftPickSession{
+		SessionID:  d.session.ID,
+		PackNumber: d.round,
+		PickNumber: d.pick,
+		CardID:     cardID,
+		Timestamp:  time.Now(),
+	}
+	if err := s.draftRepo.SavePick(ctx, pick); err != nil {
+		return nil, fmt.Errorf("failed to save pick: %w", err)
+	}
+
+	d.packs[userSeat] = remove(d.packs[userSeat], index)
+	d.pool = append(d.pool, cardID)
+	d.picks++
+	for _, bot := range d.bots {
+		pack := d.packs[bot.Seat]
+		d.packs[bot.Seat] = remove(pack, bot.Pick(pack, d.cards))
+	}
+	d.pass()
+
+	if d.complete {
+		endTime := time.Now()
+		if err := s.draftRepo.UpdateSessionStatus(ctx, d.session.ID, "completed", &endTime); err != nil {
+			log.Printf("Warning: Failed to mark simulated draft %s as completed: %v", d.session.ID, err)
+		}
+		log.Printf("[Simulator] Draft %s complete (%d picks)", d.session.ID, d.picks)
+	} else {
+		s.saveCurrentPack(ctx, d)
+	}
+	return d.state(), nil
+}
+
+func (s *Simulator) get(sessionID string) (*draft, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.drafts[sessionID]
+	if !ok {
+		return nil, ErrDraftNotFound
+	}
+	d.lastUsed = time.Now()
+	return d, nil
+}
+
+// prune drops drafts idle for longer than draftIdleTimeout. The caller must
+// hold s.mu.
+func (s *Simulator) prune(now time.Time) {
+	for id, d := range s.drafts {
+		if now.Sub(d.lastUsed) > draftIdleTimeout {
+			delete(s.drafts, id)
+		}
+	}
+}
+
+// loadCards builds the card table, using 17Lands ratings where available.
+// Without ratings bots fall back to rarity.
+func (s *Simulator) loadCards(ctx context.Context, setCode, format string, setCards []*models.SetCard) map[string]*card {
+	byID := make(map[string]float64)
+	byName := make(map[string]float64)
+	ratings, _, err := s.ratingsRepo.GetCardRatings(ctx, setCode, format)
+	if err != nil {
+		log.Printf("Warning: No 17Lands ratings for %s/%s, bots will pick by rarity: %v", setCode, format, err)
+	}
+	for _, r := range ratings {
+		if r.GIHWR <= 0 {
+			continue
+		}
+		if r.MTGAID != 0 {
+			byID[strconv.Itoa(r.MTGAID)] = r.GIHWR
+		}
+		byName[r.Name] = r.GIHWR
+	}
+
+	cards := make(map[string]*card, len(setCards))
+	for _, sc := range setCards {
+		c := &card{
+			ID:     sc.ArenaID,
+			Name:   sc.Name,
+			Rarity: strings.ToLower(sc.Rarity),
+			Colors: colorid.Key(strings.Join(sc.Colors, "")),
+		}
+		if gihwr, ok := byID[sc.ArenaID]; ok {
+			c.GIHWR, c.Rated = gihwr, true
+		} else if gihwr, ok := byName[sc.Name]; ok {
+			c.GIHWR, c.Rated = gihwr, true
+		} else if gihwr, ok := unratedGIHWR[c.Rarity]; ok {
+			c.GIHWR = gihwr
+		} else {
+			c.GIHWR = unratedGIHWR["common"]
+		}
+		cards[sc.ArenaID] = c
+	}
+	return cards
+}
+
+// saveCurrentPack stores the pack the user is looking at, like the log
+// processor does for real drafts.
+func (s *Simulator) saveCurrentPack(ctx context.Context, d *draft) {
+	pack := &models.DraftPackSession{
+		SessionID:  d.session.ID,
+		PackNumber: d.round,
+		PickNumber: d.pick,
+		CardIDs:    append([]string(nil), d.packs[userSeat]...),
+		Timestamp:  time.Now(),
+	}
+	if err := s.draftRepo.SavePack(ctx, pack); err != nil {
+		log.Printf("Warning: Failed to save simulated pack: %v", err)
+	}
+}
+
+// pass hands every pack to the next seat, opening the next round of boosters
+// when the packs run out. Packs 1 and 3 go left, pack 2 goes right.
+func (d *draft) pass() {
+	if len(d.packs[userSeat]) == 0 {
+		d.round++
+		d.pick = 1
+		if d.round >= packsPerDrafter {
+			d.complete = true
+			return
+		}
+		d.packs = append([][]string(nil), d.unopened[d.round]...)
+		return
+	}
+
+	passed := make([][]string, podSize)
+	for seat, pack := range d.packs {
+		if d.passLeft() {
+			passed[(seat+1)%podSize] = pack
+		} else {
+			passed[(seat+podSize-1)%podSize] = pack
+		}
+	}
+	d.packs = passed
+	d.pick++
+}
+
+func (d *draft) passLeft() bool {
+	return d.round%2 == 0
+}
+
+func (d *draft) state() *State {
+	st := &State{
+		SessionID:     d.session.ID,
+		SetCode:       d.session.SetCode,
+		DraftFormat:   d.format,
+		Seed:          d.seed,
+		PackNumber:    d.round,
+		PickNumber:    d.pick,
+		PackSize:      d.packSize,
+		TotalPicks:    d.session.TotalPicks,
+		PicksMade:     d.picks,
+		PassDirection: "right",
+		Complete:      d.complete,
+		Pack:          []CardView{},
+		Pool:          d.views(d.pool),
+	}
+	if d.passLeft() {
+		st.PassDirection = "left"
+	}
+	if d.complete {
+		st.PackNumber = packsPerDrafter - 1
+		st.PickNumber = d.packSize
+	} else {
+		st.Pack = d.views(d.packs[userSeat])
+	}
+	for _, bot := range d.bots {
+		view := BotView{Seat: bot.Seat, Colors: bot.Colors()}
+		if d.complete {
+			view.Picks = d.views(bot.Picks)
+		}
+		st.Bots = append(st.Bots, view)
+	}
+	return st
+}
+
+func (d *draft) views(cardIDs []string) []CardView {
+	views := make([]CardView, 0, len(cardIDs))
+	for _, id := range cardIDs {
+		c := d.cards[id]
+		view := CardView{CardID: id, Name: c.Name, Rarity: c.Rarity, Colors: c.Colors}
+		if c.Rated {
+			view.GIHWR = c.GIHWR
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+// remove deletes the element at index, keeping order.
+func remove(pack []string, index int) []string {
+	out := make([]string, 0, len(pack)-1)
+	out = append(out, pack[:index]...)
+	return append(out, pack[index+1:]...)
+}
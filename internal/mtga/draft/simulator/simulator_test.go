@@ -0,0 +1,322 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/draft/colorid"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+)
+
+// fakeSets serves a fixed card list for any set.
+type fakeSets struct {
+	cards []*models.SetCard
+}
+
+func (f *fakeSets) GetCachedSet(ctx context.Context, setCode string) ([]*models.SetCard, error) {
+	return f.cards, nil
+}
+
+// fakeDraftRepo records what the simulator stores.
+type fakeDraftRepo struct {
+	repository.DraftRepository
+	session *models.DraftSession
+	picks   []*models.DraftPickSession
+	packs   []*models.DraftPackSession
+	status  string
+}
+
+func (f *fakeDraftRepo) CreateSession(ctx context.Context, session *models.DraftSession) error {
+	f.session = session
+	f.status = session.Status
+	return nil
+}
+
+func (f *fakeDraftRepo) SavePick(ctx context.Context, pick *models.DraftPickSession) error {
+	f.picks = append(f.picks, pick)
+	return nil
+}
+
+func (f *fakeDraftRepo) SavePack(ctx context.Context, pack *models.DraftPackSession) error {
+	f.packs = append(f.packs, pack)
+	return nil
+}
+
+func (f *fakeDraftRepo) UpdateSessionStatus(ctx context.Context, id string, status string, endTime *time.Time) error {
+	f.status = status
+	return nil
+}
+
+// fakeRatingsRepo serves a fixed set of card ratings.
+type fakeRatingsRepo struct {
+	repository.DraftRatingsRepository
+	cards []seventeenlands.CardRating
+}
+
+func (f *fakeRatingsRepo) GetCardRatings(ctx context.Context, setCode, draftFormat string) ([]seventeenlands.CardRating, time.Time, error) {
+	return f.cards, time.Now(), nil
+}
+
+// testSet builds a set with 60 commons, 30 uncommons, 15 rares, 5 mythics
+// spread over the five colors, plus basic lands. GIHWR rises with the ID.
+func testSet() ([]*models.SetCard, []seventeenlands.CardRating) {
+	var cards []*models.SetCard
+	var ratings []seventeenlands.CardRating
+	id := 1000
+	for _, group := range []struct {
+		rarity string
+		count  int
+	}{{"common", 60}, {"uncommon", 30}, {"rare", 15}, {"mythic", 5}} {
+		for i := 0; i < group.count; i++ {
+			id++
+			color := colorid.WUBRG[i%len(colorid.WUBRG)]
+			cards = append(cards, &models.SetCard{
+				ArenaID: strconv.Itoa(id),
+				Name:    "Card " + strconv.Itoa(id),
+				Rarity:  group.rarity,
+				Colors:  []string{color},
+				Types:   []string{"Creature"},
+			})
+			ratings = append(ratings, seventeenlands.CardRating{
+				MTGAID: id,
+				Name:   "Card " + strconv.Itoa(id),
+				Color:  color,
+				GIHWR:  50 + float64(id%20)/2,
+			})
+		}
+	}
+	for i, name := range []string{"Plains", "Island", "Swamp", "Mountain", "Forest"} {
+		cards = append(cards, &models.SetCard{
+			ArenaID: strconv.Itoa(9000 + i),
+			Name:    name,
+			Rarity:  "common",
+			Types:   []string{"Basic", "Land", name},
+		})
+	}
+	return cards, ratings
+}
+
+func newTestSimulator() (*Simulator, *fakeDraftRepo) {
+	cards, ratings := testSet()
+	repo := &fakeDraftRepo{}
+	return NewSimulator(&fakeSets{cards: cards}, repo, &fakeRatingsRepo{cards: ratings}), repo
+}
+
+func TestPackGenerator_FollowsSlots(t *testing.T) {
+	cards, _ := testSet()
+	rarity := make(map[string]string)
+	for _, c := range cards {
+		rarity[c.ArenaID] = c.Rarity
+	}
+
+	generator, err := newPackGenerator(cards, DefaultPackConfig, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("newPackGenerator: %v", err)
+	}
+	mythics := 0
+	for i := 0; i < 400; i++ {
+		pack := generator.Open()
+		if len(pack) != 14 {
+			t.Fatalf("pack has %d cards, want 14", len(pack))
+		}
+		counts := make(map[string]int)
+		seen := make(map[string]bool)
+		for _, id := range pack {
+			if seen[id] {
+				t.Fatalf("card %s appears twice in one pack", id)
+			}
+			seen[id] = true
+			counts[rarity[id]]++
+		}
+		if counts["rare"]+counts["mythic"] != 1 || counts["uncommon"] != 3 || counts["common"] != 10 {
+			t.Fatalf("unexpected rarity mix %v", counts)
+		}
+		mythics += counts["mythic"]
+	}
+	// 1 in 8 rare slots is a mythic
+	if mythics < 25 || mythics > 80 {
+		t.Errorf("got %d mythics in 400 packs, want about 50", mythics)
+	}
+}
+
+func TestPackGenerator_PrintRuns(t *testing.T) {
+	cards, _ := testSet()
+	config := PackConfig{
+		Slots:     []PackSlot{{Count: 1, Rarities: map[string]float64{"common": 1}}},
+		PrintRuns: map[string]float64{"Card 1001": 0, "Card 1002": 30},
+	}
+	generator, err := newPackGenerator(cards, config, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("newPackGenerator: %v", err)
+	}
+	counts := make(map[string]int)
+	for i := 0; i < 900; i++ {
+		counts[generator.Open()[0]]++
+	}
+	if counts["1001"] != 0 {
+		t.Errorf("card with no print run was opened %d times", counts["1001"])
+	}
+	// 30 of 89 weight units
+	if counts["1002"] < 200 {
+		t.Errorf("heavily printed card opened %d times, want about 300", counts["1002"])
+	}
+}
+
+func TestBot_CommitsToColors(t *testing.T) {
+	bot := newBot(1, rand.New(rand.NewSource(1)))
+	cards := map[string]*card{
+		"w1": {ID: "w1", Colors: "W", GIHWR: 58},
+		"u1": {ID: "u1", Colors: "U", GIHWR: 58},
+		"w2": {ID: "w2", Colors: "W", GIHWR: 56},
+		"r1": {ID: "r1", Colors: "R", GIHWR: 58},
+	}
+	for i := 0; i < 12; i++ {
+		bot.take(cards[[]string{"w1", "u1"}[i%2]])
+	}
+	if got := bot.Colors(); got != "WU" {
+		t.Fatalf("Colors() = %q, want WU", got)
+	}
+
+	// A slightly better off-color card loses to an on-color one once committed
+	pack := []string{"r1", "w2"}
+	if idx := bot.Pick(pack, cards); pack[idx] != "w2" {
+		t.Errorf("committed bot picked %s, want w2", pack[idx])
+	}
+}
+
+func TestSimulator_FullDraft(t *testing.T) {
+	sim, repo := newTestSimulator()
+	ctx := context.Background()
+
+	state, err := sim.Start(ctx, Options{SetCode: "tst", Seed: 42})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if state.SetCode != "TST" || state.DraftFormat != "PremierDraft" || state.TotalPicks != 42 {
+		t.Fatalf("unexpected start state %+v", state)
+	}
+	if repo.session == nil || repo.session.EventName != "PremierDraft" || repo.status != "in_progress" || !repo.session.IsSimulated {
+		t.Fatalf("session not stored as an in-progress simulated draft: %+v", repo.session)
+	}
+
+	for !state.Complete {
+		wantPack := len(state.Pack)
+		if wantPack != state.PackSize-state.PickNumber+1 {
+			t.Fatalf("P%dp%d has %d cards", state.PackNumber+1, state.PickNumber, wantPack)
+		}
+		state, err = sim.Pick(ctx, state.SessionID, state.Pack[0].CardID)
+		if err != nil {
+			t.Fatalf("Pick: %v", err)
+		}
+	}
+
+	if len(state.Pool) != 42 || len(repo.picks) != 42 || len(repo.packs) != 42 {
+		t.Fatalf("pool %d, stored picks %d, stored packs %d; want 42 each", len(state.Pool), len(repo.picks), len(repo.packs))
+	}
+	if repo.status != "completed" {
+		t.Errorf("session status = %q, want completed", repo.status)
+	}
+	last := repo.picks[len(repo.picks)-1]
+	if last.PackNumber != 2 || last.PickNumber != 14 {
+		t.Errorf("last pick stored as P%dp%d, want pack 2 pick 14", last.PackNumber, last.PickNumber)
+	}
+	for _, bot := range state.Bots {
+		if len(bot.Picks) != 42 || bot.Colors == "" {
+			t.Errorf("bot %d has %d picks and colors %q", bot.Seat, len(bot.Picks), bot.Colors)
+		}
+	}
+
+	if _, err := sim.Pick(ctx, state.SessionID, "1001"); !errors.Is(err, ErrDraftComplete) {
+		t.Errorf("pick after the end returned %v, want ErrDraftComplete", err)
+	}
+}
+
+func TestSimulator_PassesLeftThenRight(t *testing.T) {
+	sim, _ := newTestSimulator()
+	ctx := context.Background()
+
+	state, err := sim.Start(ctx, Options{SetCode: "TST", Seed: 7})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	d, _ := sim.get(state.SessionID)
+	fromSeat7 := d.unopened[0][7]
+
+	if _, err := sim.Pick(ctx, state.SessionID, "not-a-card"); !errors.Is(err, ErrCardNotInPack) {
+		t.Fatalf("bad pick returned %v, want ErrCardNotInPack", err)
+	}
+
+	state, err = sim.Pick(ctx, state.SessionID, state.Pack[0].CardID)
+	if err != nil {
+		t.Fatalf("Pick: %v", err)
+	}
+	// Pack 1 passes left, so the user now holds seat 7's pack minus its bot's pick
+	held := make(map[string]bool)
+	for _, c := range fromSeat7 {
+		held[c] = true
+	}
+	for _, c := range state.Pack {
+		if !held[c.CardID] {
+			t.Fatalf("card %s did not come from seat 7", c.CardID)
+		}
+	}
+
+	for state.PackNumber == 0 {
+		state, _ = sim.Pick(ctx, state.SessionID, state.Pack[0].CardID)
+	}
+	if state.PassDirection != "right" {
+		t.Errorf("pack 2 passes %s, want right", state.PassDirection)
+	}
+	fromSeat1 := d.unopened[1][1]
+	state, _ = sim.Pick(ctx, state.SessionID, state.Pack[0].CardID)
+	held = make(map[string]bool)
+	for _, c := range fromSeat1 {
+		held[c] = true
+	}
+	for _, c := range state.Pack {
+		if !held[c.CardID] {
+			t.Fatalf("card %s did not come from seat 1", c.CardID)
+		}
+	}
+}
+
+func TestSimulator_UnknownDraft(t *testing.T) {
+	sim, _ := newTestSimulator()
+	if _, err := sim.State("missing"); !errors.Is(err, ErrDraftNotFound) {
+		t.Errorf("State returned %v, want ErrDraftNotFound", err)
+	}
+}
+
+func TestSimulator_DropsIdleDrafts(t *testing.T) {
+	sim, _ := newTestSimulator()
+	ctx := context.Background()
+
+	idle, err := sim.Start(ctx, Options{SetCode: "tst", Seed: 1})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	active, err := sim.Start(ctx, Options{SetCode: "tst", Seed: 2})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	sim.mu.Lock()
+	sim.drafts[idle.SessionID].lastUsed = time.Now().Add(-draftIdleTimeout - time.Minute)
+	sim.mu.Unlock()
+
+	// Starting another draft drops the idle one and keeps the rest
+	if _, err := sim.Start(ctx, Options{SetCode: "tst", Seed: 3}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if _, err := sim.State(idle.SessionID); !errors.Is(err, ErrDraftNotFound) {
+		t.Errorf("idle draft State returned %v, want ErrDraftNotFound", err)
+	}
+	if _, err := sim.State(active.SessionID); err != nil {
+		t.Errorf("active draft State: %v", err)
+	}
+}
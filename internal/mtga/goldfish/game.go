@@ -0,0 +1,271 @@
+package goldfish
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/castability"
+)
+
+// card is one card in the library. Copies of a card share one entry.
+type card struct {
+	name string
+
+	land   bool
+	mask   int // Colors a land makes, as a castability color mask
+	tapped bool
+
+	cmc      int
+	pips     [5]int
+	creature bool
+	power    int
+	haste    bool
+	burn     int // Damage dealt to the opponent on cast
+}
+
+// game is the outcome of one simulated game.
+type game struct {
+	killTurn   int
+	mulligans  int
+	screwed    bool
+	flooded    bool
+	firstSpell int
+	spent      int // Mana spent over the first curveTurns turns
+	available  int // Mana available over the first curveTurns turns
+}
+
+// creature is a creature on the battlefield.
+type creature struct {
+	power int
+	sick  bool
+}
+
+// maxSpellsConsidered bounds the search for the best set of spells to cast.
+const maxSpellsConsidered = 10
+
+func playGame(library []*card, rng *rand.Rand, opts Options) game {
+	g := game{mulligans: opts.Mulligans}
+	hand, deck := mulligan(library, rng, &g)
+	play(hand, deck, opts, &g)
+	return g
+}
+
+// play takes turns from a kept hand until the opponent is dead or the turn
+// limit is reached.
+func play(hand, deck []*card, opts Options, g *game) {
+	var lands []*card
+	var battlefield []creature
+	damage := 0
+	for turn := 1; turn <= opts.MaxTurns; turn++ {
+		if turn > 1 || opts.OnDraw {
+			if len(deck) == 0 {
+				break
+			}
+			hand = append(hand, deck[0])
+			deck = deck[1:]
+		}
+
+		// Land drop
+		untapped := untappedMasks(lands)
+		if i := chooseLand(hand, untapped); i >= 0 {
+			land := hand[i]
+			hand = append(hand[:i], hand[i+1:]...)
+			lands = append(lands, land)
+			if !land.tapped {
+				untapped = append(untapped, land.mask)
+			}
+		}
+
+		// Main phase
+		cast, spent := bestSpells(hand, untapped)
+		if len(cast) > 0 && g.firstSpell == 0 {
+			g.firstSpell = turn
+		}
+		for _, spell := range cast {
+			hand = removeCard(hand, spell)
+			damage += spell.burn
+			if spell.creature {
+				battlefield = append(battlefield, creature{power: spell.power, sick: !spell.haste})
+			}
+		}
+
+		// Attack with everything that can
+		for i := range battlefield {
+			if !battlefield[i].sick {
+				damage += battlefield[i].power
+			}
+			battlefield[i].sick = false
+		}
+
+		if turn <= curveTurns {
+			g.spent += spent
+			g.available += len(untapped)
+		}
+		if turn == screwTurn && len(lands) < screwLands {
+			g.screwed = true
+		}
+		if turn == floodTurn && len(lands) >= floodLands {
+			g.flooded = true
+		}
+		if damage >= startingLife {
+			g.killTurn = turn
+			break
+		}
+	}
+}
+
+// mulligan draws opening hands under the London mulligan until one is kept
+// and returns it with the rest of the library, bottomed cards last.
+func mulligan(library []*card, rng *rand.Rand, g *game) ([]*card, []*card) {
+	deck := make([]*card, len(library))
+	for {
+		copy(deck, library)
+		rng.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+
+		keep := openingHand - g.mulligans
+		hand, bottom := bottomCards(deck[:openingHand], keep)
+		landCount := countLands(hand)
+		// Keep 2 to 5 lands in seven, 2 to 4 in six, and any five
+		if keep <= 5 || (landCount >= 2 && landCount <= keep-2) {
+			rest := append(append([]*card(nil), deck[openingHand:]...), bottom...)
+			return hand, rest
+		}
+		g.mulligans++
+	}
+}
+
+// bottomCards keeps the best keep cards of a hand: it bottoms lands while
+// more than half the hand is lands and the most expensive spells otherwise.
+func bottomCards(drawn []*card, keep int) ([]*card, []*card) {
+	hand := append([]*card(nil), drawn...)
+	var bottom []*card
+	for len(hand) > keep {
+		worst := -1
+		if countLands(hand)*2 > keep {
+			for i, c := range hand {
+				if c.land && (worst < 0 || c.tapped && !hand[worst].tapped) {
+					worst = i
+				}
+			}
+		} else {
+			for i, c := range hand {
+				if !c.land && (worst < 0 || c.cmc > hand[worst].cmc) {
+					worst = i
+				}
+			}
+		}
+		if worst < 0 {
+			worst = len(hand) - 1
+		}
+		bottom = append(bottom, hand[worst])
+		hand = append(hand[:worst], hand[worst+1:]...)
+	}
+	return hand, bottom
+}
+
+// chooseLand picks the land to play: the one that lets the most mana be spent
+// this turn, preferring a tapped land when it costs nothing, then one that
+// adds a new color. It returns -1 when the hand has no land.
+func chooseLand(hand []*card, untapped []int) int {
+	inPlay := 0
+	for _, mask := range untapped {
+		inPlay |= mask
+	}
+	best, bestSpent := -1, -1
+	for i, c := range hand {
+		if !c.land {
+			continue
+		}
+		available := untapped
+		if !c.tapped {
+			available = append(append([]int(nil), untapped...), c.mask)
+		}
+		_, spent := bestSpells(hand, available)
+		if best < 0 || spent > bestSpent || spent == bestSpent && betterLand(c, hand[best], inPlay) {
+			best, bestSpent = i, spent
+		}
+	}
+	return best
+}
+
+func betterLand(a, b *card, inPlay int) bool {
+	if a.tapped != b.tapped {
+		return a.tapped
+	}
+	return a.mask&^inPlay != 0 && b.mask&^inPlay == 0
+}
+
+// bestSpells finds the spells in hand that spend the most mana from the
+// untapped lands, breaking ties by damage.
+func bestSpells(hand []*card, untapped []int) ([]*card, int) {
+	var candidates []*card
+	for _, c := range hand {
+		if !c.land && c.cmc <= len(untapped) && castability.Payable(untapped, c.cmc, c.pips) {
+			candidates = append(candidates, c)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].cmc > candidates[j].cmc })
+	if len(candidates) > maxSpellsConsidered {
+		candidates = candidates[:maxSpellsConsidered]
+	}
+
+	var best []*card
+	bestSpent, bestDamage := 0, 0
+	var chosen []*card
+	var walk func(i, spent int, pips [5]int)
+	walk = func(i, spent int, pips [5]int) {
+		if i == len(candidates) {
+			damage := 0
+			for _, c := range chosen {
+				damage += c.burn + c.power
+			}
+			if spent > bestSpent || spent == bestSpent && damage > bestDamage {
+				best = append(best[:0], chosen...)
+				bestSpent, bestDamage = spent, damage
+			}
+			return
+		}
+		c := candidates[i]
+		if spent+c.cmc <= len(untapped) {
+			var combined [5]int
+			for bit := range combined {
+				combined[bit] = pips[bit] + c.pips[bit]
+			}
+			if castability.Payable(untapped, spent+c.cmc, combined) {
+				chosen = append(chosen, c)
+				walk(i+1, spent+c.cmc, combined)
+				chosen = chosen[:len(chosen)-1]
+			}
+		}
+		walk(i+1, spent, pips)
+	}
+	walk(0, 0, [5]int{})
+	return best, bestSpent
+}
+
+func untappedMasks(lands []*card) []int {
+	masks := make([]int, len(lands))
+	for i, land := range lands {
+		masks[i] = land.mask
+	}
+	return masks
+}
+
+func countLands(hand []*card) int {
+	n := 0
+	for _, c := range hand {
+		if c.land {
+			n++
+		}
+	}
+	return n
+}
+
+func removeCard(hand []*card, c *card) []*card {
+	for i, h := range hand {
+		if h == c {
+			return append(hand[:i], hand[i+1:]...)
+		}
+	}
+	return hand
+}
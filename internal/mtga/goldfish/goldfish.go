@@ -0,0 +1,324 @@
+// Package goldfish estimates a deck's speed by playing it alone against an
+// empty board: mulligans, land drops and the best curve of spells each turn,
+// attacking with everything until a 20-life opponent is dead.
+package goldfish
+
+import (
+	"math/rand"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/castability"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/manabase"
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
+)
+
+const (
+	// DefaultIterations is the number of games played when none is given.
+	DefaultIterations = 1000
+	// DefaultMaxTurns is the turn a game is given up on when none is given.
+	DefaultMaxTurns = 15
+
+	startingLife = 20
+	openingHand  = 7
+
+	// Lands in play that count as screw and flood, matching PlayAnalyzer:
+	// fewer than 3 lands on turn 5, and 7 or more lands on turn 7.
+	screwTurn  = 5
+	screwLands = 3
+	floodTurn  = 7
+	floodLands = 7
+
+	// curveTurns is the number of early turns mana efficiency is measured over.
+	curveTurns = 6
+)
+
+var burnPattern = regexp.MustCompile(`(?i)deals (\d+) damage to (?:any target|target player|target opponent|each opponent|each player)`)
+
+// Card is a deck entry to play.
+type Card struct {
+	Name       string
+	Quantity   int
+	ManaCost   string
+	CMC        int
+	TypeLine   string
+	OracleText string
+	Power      int
+}
+
+// FromCard converts card metadata into a deck entry.
+func FromCard(card *cards.Card, quantity int) Card {
+	c := Card{Name: card.Name, Quantity: quantity, CMC: int(card.CMC), TypeLine: card.TypeLine}
+	if card.ManaCost != nil {
+		c.ManaCost = *card.ManaCost
+	}
+	if card.OracleText != nil {
+		c.OracleText = *card.OracleText
+	}
+	if card.Power != nil {
+		c.Power, _ = strconv.Atoi(*card.Power) // "*" counts as 0
+	}
+	return c
+}
+
+// Options configures a simulation.
+type Options struct {
+	Iterations int   // Games to play; DefaultIterations when zero
+	MaxTurns   int   // Turns before a game counts as no kill; DefaultMaxTurns when zero
+	OnDraw     bool  // Play every game on the draw instead of the play
+	Mulligans  int   // Mulligans already taken, so the first hand kept is smaller; at most 7
+	Seed       int64 // Seed for shuffling; the same seed gives the same result
+}
+
+// Result summarizes the simulated games.
+type Result struct {
+	Iterations            int         `json:"iterations"`
+	OnDraw                bool        `json:"onDraw"`
+	KillTurns             map[int]int `json:"killTurns"` // Games won on each turn
+	AverageKillTurn       float64     `json:"averageKillTurn"`
+	MedianKillTurn        int         `json:"medianKillTurn"`
+	NoKillRate            float64     `json:"noKillRate"` // Games not won by the turn limit
+	MulliganRate          float64     `json:"mulliganRate"`
+	ManaScrewRate         float64     `json:"manaScrewRate"`
+	ManaFloodRate         float64     `json:"manaFloodRate"`
+	AverageFirstSpellTurn float64     `json:"averageFirstSpellTurn"`
+	CurveEfficiency       float64     `json:"curveEfficiency"` // Share of mana spent over the first six turns
+}
+
+// Comparison is two decks played with the same shuffles.
+type Comparison struct {
+	A             *Result `json:"a"`
+	B             *Result `json:"b"`
+	KillTurnDelta float64 `json:"killTurnDelta"` // B's average kill turn minus A's; negative when B is faster
+	Faster        string  `json:"faster"`        // "a", "b" or "tie"
+}
+
+// Simulate plays the deck opts.Iterations times.
+func Simulate(deck []Card, opts Options) *Result {
+	opts = withDefaults(opts)
+	library := buildLibrary(deck)
+	if len(library) < openingHand {
+		return &Result{Iterations: opts.Iterations, OnDraw: opts.OnDraw, KillTurns: make(map[int]int)}
+	}
+	return simulate(opts, func(rng *rand.Rand) game {
+		return playGame(library, rng, opts)
+	})
+}
+
+// SimulateHand plays the deck opts.Iterations times from the same opening
+// hand, given as the names of the cards drawn. With opts.Mulligans set the
+// hand is cut down the way Simulate's mulligans are before play starts. Names
+// not found in the deck are left out of the hand.
+func SimulateHand(deck []Card, hand []string, opts Options) *Result {
+	opts = withDefaults(opts)
+	library := buildLibrary(deck)
+	var drawn []*card
+	for _, name := range hand {
+		for i, c := range library {
+			if c.name == name {
+				drawn = append(drawn, c)
+				library = append(library[:i], library[i+1:]...)
+				break
+			}
+		}
+	}
+	keep := openingHand - opts.Mulligans
+	if len(drawn) == 0 || keep <= 0 {
+		return &Result{Iterations: opts.Iterations, OnDraw: opts.OnDraw, KillTurns: make(map[int]int)}
+	}
+	return simulate(opts, func(rng *rand.Rand) game {
+		rest := make([]*card, len(library))
+		copy(rest, library)
+		rng.Shuffle(len(rest), func(i, j int) { rest[i], rest[j] = rest[j], rest[i] })
+		kept, bottom := bottomCards(drawn, keep)
+		g := game{mulligans: opts.Mulligans}
+		play(kept, append(rest, bottom...), opts, &g)
+		return g
+	})
+}
+
+func withDefaults(opts Options) Options {
+	if opts.Iterations <= 0 {
+		opts.Iterations = DefaultIterations
+	}
+	if opts.MaxTurns <= 0 {
+		opts.MaxTurns = DefaultMaxTurns
+	}
+	// A hand can be mulliganed down to no cards but no further
+	opts.Mulligans = max(0, min(opts.Mulligans, openingHand))
+	return opts
+}
+
+// simulate summarizes opts.Iterations games played by playOne.
+func simulate(opts Options, playOne func(rng *rand.Rand) game) *Result {
+	result := &Result{
+		Iterations: opts.Iterations,
+		OnDraw:     opts.OnDraw,
+		KillTurns:  make(map[int]int),
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	var kills []int
+	var mulligans, screwed, flooded, firstSpells, spent, available int
+	var firstSpellTurns int
+	for i := 0; i < opts.Iterations; i++ {
+		g := playOne(rng)
+		if g.killTurn > 0 {
+			result.KillTurns[g.killTurn]++
+			kills = append(kills, g.killTurn)
+		}
+		if g.mulligans > opts.Mulligans {
+			mulligans++
+		}
+		if g.screwed {
+			screwed++
+		}
+		if g.flooded {
+			flooded++
+		}
+		if g.firstSpell > 0 {
+			firstSpells++
+			firstSpellTurns += g.firstSpell
+		}
+		spent += g.spent
+		available += g.available
+	}
+
+	n := float64(opts.Iterations)
+	if len(kills) > 0 {
+		sort.Ints(kills)
+		total := 0
+		for _, turn := range kills {
+			total += turn
+		}
+		result.AverageKillTurn = stats.Round(float64(total)/float64(len(kills)), 2)
+		result.MedianKillTurn = kills[len(kills)/2]
+	}
+	result.NoKillRate = stats.Round(float64(opts.Iterations-len(kills))/n, 3)
+	result.MulliganRate = stats.Round(float64(mulligans)/n, 3)
+	result.ManaScrewRate = stats.Round(float64(screwed)/n, 3)
+	result.ManaFloodRate = stats.Round(float64(flooded)/n, 3)
+	if firstSpells > 0 {
+		result.AverageFirstSpellTurn = stats.Round(float64(firstSpellTurns)/float64(firstSpells), 2)
+	}
+	if available > 0 {
+		result.CurveEfficiency = stats.Round(float64(spent)/float64(available), 3)
+	}
+	return result
+}
+
+// KilledBy is the share of games won by the given turn.
+func (r *Result) KilledBy(turn int) float64 {
+	if r.Iterations == 0 {
+		return 0
+	}
+	won := 0
+	for t, n := range r.KillTurns {
+		if t <= turn {
+			won += n
+		}
+	}
+	return float64(won) / float64(r.Iterations)
+}
+
+// Compare plays two decks with the same seed, so both see the same shuffles
+// and the difference between them is mostly the cards.
+func Compare(a, b []Card, opts Options) *Comparison {
+	c := &Comparison{A: Simulate(a, opts), B: Simulate(b, opts), Faster: "tie"}
+	c.KillTurnDelta = stats.Round(effectiveKillTurn(c.B, opts)-effectiveKillTurn(c.A, opts), 2)
+	switch {
+	case c.KillTurnDelta < 0:
+		c.Faster = "b"
+	case c.KillTurnDelta > 0:
+		c.Faster = "a"
+	}
+	return c
+}
+
+// effectiveKillTurn is the average kill turn with games that never won
+// counted one turn past the limit, so decks that stall are not flattered.
+func effectiveKillTurn(r *Result, opts Options) float64 {
+	maxTurns := opts.MaxTurns
+	if maxTurns <= 0 {
+		maxTurns = DefaultMaxTurns
+	}
+	if r.Iterations == 0 {
+		return 0
+	}
+	won := 1 - r.NoKillRate
+	return r.AverageKillTurn*won + float64(maxTurns+1)*r.NoKillRate
+}
+
+// buildLibrary expands the deck into one entry per card.
+func buildLibrary(deck []Card) []*card {
+	var basics []string
+	for _, c := range deck {
+		if c.Quantity > 0 && strings.Contains(strings.ToLower(c.TypeLine), "basic") {
+			basics = append(basics, manabase.ParseLand(c.Name, c.TypeLine, c.OracleText).Colors...)
+		}
+	}
+	basicMask := castability.Mask(basics)
+
+	var library []*card
+	sources := make(map[string]int)
+	var spells []Card
+	for _, c := range deck {
+		if c.Quantity <= 0 {
+			continue
+		}
+		if !castability.IsLand(c.TypeLine) {
+			spells = append(spells, c)
+			continue
+		}
+		source := manabase.ParseLand(c.Name, c.TypeLine, c.OracleText)
+		mask := castability.Mask(source.Colors)
+		if source.AnyBasic {
+			mask |= basicMask
+		}
+		for bit, color := range castability.Colors {
+			if mask&(1<<bit) != 0 {
+				sources[color] += c.Quantity
+			}
+		}
+		// Fetches like Evolving Wilds put their land onto the battlefield tapped
+		tapped := source.EntersTapped || source.Fetch && strings.Contains(strings.ToLower(c.OracleText), "battlefield tapped")
+		land := &card{name: c.Name, land: true, mask: mask, tapped: tapped}
+		for i := 0; i < c.Quantity; i++ {
+			library = append(library, land)
+		}
+	}
+
+	for _, c := range spells {
+		cost := manabase.ParseManaCost(c.ManaCost, c.CMC)
+		spell := &card{
+			name:     c.Name,
+			cmc:      c.CMC,
+			creature: strings.Contains(strings.ToLower(c.TypeLine), "creature"),
+			power:    c.Power,
+			haste:    strings.Contains(strings.ToLower(c.OracleText), "haste"),
+		}
+		for bit, color := range castability.Colors {
+			spell.pips[bit] = cost.Pips[color]
+		}
+		// Hybrid symbols are paid with the color the deck has the most sources of
+		for _, options := range cost.Hybrid {
+			best := options[0]
+			for _, color := range options[1:] {
+				if sources[color] > sources[best] {
+					best = color
+				}
+			}
+			spell.pips[castability.Bit(best)]++
+		}
+		if match := burnPattern.FindStringSubmatch(c.OracleText); match != nil {
+			spell.burn, _ = strconv.Atoi(match[1])
+		}
+		for i := 0; i < c.Quantity; i++ {
+			library = append(library, spell)
+		}
+	}
+	return library
+}
@@ -0,0 +1,176 @@
+package goldfish
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards"
+)
+
+func mountains(n int) Card {
+	return Card{Name: "Mountain", Quantity: n, TypeLine: "Basic Land — Mountain"}
+}
+
+func redDeck() []Card {
+	return []Card{
+		mountains(20),
+		{Name: "Goblin Guide", Quantity: 12, ManaCost: "{R}", CMC: 1, TypeLine: "Creature — Goblin", OracleText: "Haste", Power: 2},
+		{Name: "Bear", Quantity: 12, ManaCost: "{1}{R}", CMC: 2, TypeLine: "Creature — Bear", Power: 2},
+		{Name: "Lightning Bolt", Quantity: 16, ManaCost: "{R}", CMC: 1, TypeLine: "Instant", OracleText: "Lightning Bolt deals 3 damage to any target."},
+	}
+}
+
+func slowDeck() []Card {
+	return []Card{
+		mountains(24),
+		{Name: "Dragon", Quantity: 16, ManaCost: "{4}{R}{R}", CMC: 6, TypeLine: "Creature — Dragon", Power: 5},
+		{Name: "Ogre", Quantity: 20, ManaCost: "{3}{R}", CMC: 4, TypeLine: "Creature — Ogre", Power: 3},
+	}
+}
+
+func TestSimulate_Deterministic(t *testing.T) {
+	a := Simulate(redDeck(), Options{Iterations: 500, Seed: 3})
+	b := Simulate(redDeck(), Options{Iterations: 500, Seed: 3})
+	if !reflect.DeepEqual(a, b) {
+		t.Fatal("same seed should give the same result")
+	}
+	if a.Iterations != 500 || a.NoKillRate > 0.01 {
+		t.Errorf("aggressive deck should almost always kill, got %+v", a)
+	}
+	total := 0
+	for _, n := range a.KillTurns {
+		total += n
+	}
+	if float64(total) != float64(a.Iterations)*(1-a.NoKillRate) {
+		t.Errorf("kill turns count %d games, want %v", total, float64(a.Iterations)*(1-a.NoKillRate))
+	}
+	if a.AverageFirstSpellTurn > 1.2 {
+		t.Errorf("first spell on turn %.2f, want about turn 1", a.AverageFirstSpellTurn)
+	}
+}
+
+func TestCompare_FasterDeck(t *testing.T) {
+	c := Compare(slowDeck(), redDeck(), Options{Iterations: 300, Seed: 1})
+	if c.Faster != "b" || c.KillTurnDelta >= 0 {
+		t.Errorf("red deck should be faster, got faster %q delta %.2f", c.Faster, c.KillTurnDelta)
+	}
+	if c.A.AverageKillTurn <= c.B.AverageKillTurn {
+		t.Errorf("slow deck kills on turn %.2f, red deck on %.2f", c.A.AverageKillTurn, c.B.AverageKillTurn)
+	}
+}
+
+func TestSimulate_ScrewAndFlood(t *testing.T) {
+	spells := Card{Name: "Bear", Quantity: 1, ManaCost: "{1}{R}", CMC: 2, TypeLine: "Creature — Bear", Power: 2}
+
+	few := spells
+	few.Quantity = 54
+	screwed := Simulate([]Card{mountains(6), few}, Options{Iterations: 300, Seed: 1})
+
+	many := spells
+	many.Quantity = 24
+	flooded := Simulate([]Card{mountains(36), many}, Options{Iterations: 300, Seed: 1})
+
+	if screwed.ManaScrewRate <= flooded.ManaScrewRate {
+		t.Errorf("6-land deck screw rate %.3f should beat 36-land deck %.3f", screwed.ManaScrewRate, flooded.ManaScrewRate)
+	}
+	if flooded.ManaFloodRate <= screwed.ManaFloodRate {
+		t.Errorf("36-land deck flood rate %.3f should beat 6-land deck %.3f", flooded.ManaFloodRate, screwed.ManaFloodRate)
+	}
+	if screwed.MulliganRate <= 0.5 {
+		t.Errorf("6-land deck should mulligan most hands, got %.3f", screwed.MulliganRate)
+	}
+}
+
+func TestSimulateHand(t *testing.T) {
+	good := SimulateHand(redDeck(), []string{"Mountain", "Mountain", "Goblin Guide", "Goblin Guide", "Bear", "Lightning Bolt", "Lightning Bolt"}, Options{Iterations: 300, Seed: 2})
+	landless := SimulateHand(redDeck(), []string{"Goblin Guide", "Goblin Guide", "Bear", "Bear", "Lightning Bolt", "Lightning Bolt", "Lightning Bolt"}, Options{Iterations: 300, Seed: 2})
+	if good.KilledBy(5) <= landless.KilledBy(5) {
+		t.Errorf("two-land hand should kill by turn 5 more often: %.3f vs %.3f", good.KilledBy(5), landless.KilledBy(5))
+	}
+	if good.MulliganRate != 0 {
+		t.Errorf("a given hand is never mulliganed, got rate %.3f", good.MulliganRate)
+	}
+
+	afterMulligan := SimulateHand(redDeck(), []string{"Mountain", "Mountain", "Mountain", "Mountain", "Mountain", "Bear", "Bear"}, Options{Iterations: 10, Mulligans: 1, Seed: 2})
+	if afterMulligan.Iterations != 10 || afterMulligan.MulliganRate != 0 {
+		t.Errorf("unexpected result after a mulligan: %+v", afterMulligan)
+	}
+
+	mulliganed := Simulate(redDeck(), Options{Iterations: 300, Mulligans: 2, Seed: 2})
+	fresh := Simulate(redDeck(), Options{Iterations: 300, Seed: 2})
+	if mulliganed.KilledBy(4) >= fresh.KilledBy(4) {
+		t.Errorf("starting on five cards should kill by turn 4 less often: %.3f vs %.3f", mulliganed.KilledBy(4), fresh.KilledBy(4))
+	}
+}
+
+func TestBestSpells_UsesAllMana(t *testing.T) {
+	two := &card{name: "Two", cmc: 2, pips: [5]int{0, 0, 0, 1, 0}}
+	three := &card{name: "Three", cmc: 3, pips: [5]int{0, 0, 0, 1, 0}}
+	four := &card{name: "Four", cmc: 4, pips: [5]int{0, 0, 0, 1, 0}}
+	red := 1 << 3
+	cast, spent := bestSpells([]*card{four, two, three}, []int{red, red, red, red, red})
+	if spent != 5 || len(cast) != 2 {
+		t.Errorf("with five lands cast %d spells for %d mana, want Two and Three for 5", len(cast), spent)
+	}
+
+	// A blue pip cannot be paid from red lands
+	blue := &card{name: "Blue", cmc: 1, pips: [5]int{0, 1, 0, 0, 0}}
+	if cast, _ := bestSpells([]*card{blue}, []int{red, red}); len(cast) != 0 {
+		t.Error("blue spell cast from red lands")
+	}
+}
+
+func TestMulligan_LondonBottomsExtraLands(t *testing.T) {
+	land := &card{name: "Mountain", land: true, mask: 1 << 3}
+	tapped := &card{name: "Temple", land: true, mask: 1 << 3, tapped: true}
+	spell := &card{name: "Bear", cmc: 2}
+	dragon := &card{name: "Dragon", cmc: 6}
+
+	hand, bottom := bottomCards([]*card{land, land, tapped, land, land, spell, spell}, 6)
+	if len(hand) != 6 || len(bottom) != 1 || bottom[0] != tapped {
+		t.Errorf("flooded hand should bottom the tapped land, bottomed %v", bottom)
+	}
+	hand, bottom = bottomCards([]*card{land, land, spell, dragon, spell, spell, spell}, 5)
+	if len(hand) != 5 || bottom[0] != dragon || countLands(hand) != 2 {
+		t.Errorf("spell-heavy hand should bottom the most expensive spells, bottomed %v", bottom)
+	}
+
+	library := make([]*card, 0, 40)
+	for i := 0; i < 17; i++ {
+		library = append(library, land)
+	}
+	for i := 0; i < 23; i++ {
+		library = append(library, spell)
+	}
+	var g game
+	hand, rest := mulligan(library, rand.New(rand.NewSource(5)), &g)
+	if len(hand) != openingHand-g.mulligans {
+		t.Errorf("kept %d cards after %d mulligans", len(hand), g.mulligans)
+	}
+	if len(hand)+len(rest) != len(library) {
+		t.Errorf("hand and library hold %d cards, want %d", len(hand)+len(rest), len(library))
+	}
+}
+
+func TestFromCard(t *testing.T) {
+	manaCost, power, text := "{1}{R}", "3", "Haste"
+	c := FromCard(&cards.Card{Name: "Raider", CMC: 2, ManaCost: &manaCost, Power: &power, OracleText: &text, TypeLine: "Creature — Human"}, 4)
+	if c.Quantity != 4 || c.Power != 3 || c.ManaCost != "{1}{R}" || c.OracleText != "Haste" {
+		t.Errorf("FromCard = %+v", c)
+	}
+}
+
+func TestSimulate_MulligansClampedToHandSize(t *testing.T) {
+	hand := []string{"Mountain", "Mountain", "Goblin Guide", "Bear", "Bear", "Lightning Bolt", "Lightning Bolt"}
+	for _, mulligans := range []int{-1, 7, 9} {
+		result := Simulate(redDeck(), Options{Iterations: 10, Mulligans: mulligans, Seed: 2})
+		if result.Iterations != 10 {
+			t.Errorf("Simulate with %d mulligans played %d games, want 10", mulligans, result.Iterations)
+		}
+		handResult := SimulateHand(redDeck(), hand, Options{Iterations: 10, Mulligans: mulligans, Seed: 2})
+		if handResult.Iterations != 10 {
+			t.Errorf("SimulateHand with %d mulligans played %d games, want 10", mulligans, handResult.Iterations)
+		}
+	}
+}
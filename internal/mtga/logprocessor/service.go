@@ -2,9 +2,11 @@ package logprocessor
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/logreader"
@@ -20,6 +22,31 @@ type Service struct {
 	storage    *storage.Service
 	dryRun     bool // When true, parse entries but don't store to database (for replay testing)
 	replayMode bool // When true, keep draft sessions as "in_progress" for UI testing
+
+	mu         sync.Mutex
+	playerConn *logreader.GREConnection // Seat from the last connectResp, for later batches of the game
+	match      *matchState              // GRE state of the match being played, carried across batches
+	timing     *logreader.TimingTracker // Open turn, decision and rope state, carried across batches
+	life       *logreader.LifeTracker   // Game objects and life totals, carried across batches
+}
+
+// matchState is game state that later log batches of a match still need. The
+// poller can split a match between batches at any point.
+type matchState struct {
+	matchID string
+	zones   map[int]string // Zone names by zone ID
+}
+
+// matchState returns the state carried for matchID, starting over when a new
+// match begins. Callers must hold s.mu.
+func (s *Service) matchState(matchID string) *matchState {
+	if s.match == nil || s.match.matchID != matchID {
+		s.match = &matchState{
+			matchID: matchID,
+			zones:   make(map[int]string),
+		}
+	}
+	return s.match
 }
 
 // NewService creates a new log processor service.
@@ -54,21 +81,28 @@ func (s *Service) SetReplayMode(enabled bool) {
 
 // ProcessResult contains the results of processing log entries.
 type ProcessResult struct {
-	MatchesStored        int
-	GamesStored          int
-	DecksStored          int
-	RanksStored          int
-	QuestsStored         int
-	QuestsCompleted      int
-	QuestsRerolled       int // Quests marked as rerolled (disappeared without completion)
-	DraftsStored         int
-	DraftPicksStored     int
-	CollectionCardsAdded int // Cards added to collection
-	CollectionNewCards   int // New unique cards discovered
-	GamePlaysStored      int // Game plays stored from GRE messages
-	GameSnapshotsStored  int // Turn snapshots stored
-	OpponentCardsStored  int // Opponent cards observed
-	Errors               []error
+	MatchesStored         int
+	GamesStored           int
+	DecksStored           int
+	RanksStored           int
+	QuestsStored          int
+	QuestsCompleted       int
+	QuestsRerolled        int // Quests marked as rerolled (disappeared without completion)
+	DraftsStored          int
+	DraftPicksStored      int
+	CollectionCardsAdded  int // Cards added to collection
+	CollectionNewCards    int // New unique cards discovered
+	GamePlaysStored       int // Game plays stored from GRE messages
+	GameSnapshotsStored   int // Turn snapshots stored
+	ReplayStepsExtracted  int // Per-step game state changes sent for replay storage
+	DecisionTimingsStored int // Decision durations measured from GRE messages
+	LifeChangesStored     int // Life total changes attributed to their sources
+	OpeningHandsStored    int // Hands offered at mulligan prompts
+	OpponentCardsStored   int // Opponent cards observed
+	Errors                []error
+	GamePlayMatchID       string                  // Match the game plays and snapshots belong to
+	MatchStarts           []*logreader.MatchStart // Matches that began and have not finished
+	PendingOpeningHands   []*models.OpeningHand   // Hands still waiting on our keep or mulligan decision
 }
 
 // ProcessLogEntries processes a batch of log entries and stores all extracted data.
@@ -121,9 +155,35 @@ func (s *Service) ProcessLogEntries(ctx context.Context, entries []*logreader.Lo
 		result.Errors = append(result.Errors, err)
 	}
 
+	// Detect newly started matches so their opponent can be scouted
+	// This must run AFTER processArenaStats so finished matches are already stored
+	if err := s.processMatchStarts(ctx, entries, result); err != nil {
+		result.Errors = append(result.Errors, err)
+	}
+
 	return result, nil
 }
 
+// processMatchStarts records matches that have begun but are not yet stored.
+func (s *Service) processMatchStarts(ctx context.Context, entries []*logreader.LogEntry, result *ProcessResult) error {
+	account, err := s.storage.GetCurrentAccount(ctx)
+	if err != nil || account == nil || account.ScreenName == nil {
+		return nil // Can't tell the opponent apart without our screen name
+	}
+
+	for _, start := range logreader.ParseMatchStarts(entries, *account.ScreenName) {
+		existing, err := s.storage.MatchRepo().GetByID(ctx, start.MatchID)
+		if err != nil {
+			return fmt.Errorf("failed to check match %s: %w", start.MatchID, err)
+		}
+		if existing != nil {
+			continue // Already finished, e.g. when re-reading the whole log
+		}
+		result.MatchStarts = append(result.MatchStarts, start)
+	}
+	return nil
+}
+
 // processArenaStats parses and stores arena statistics from log entries.
 func (s *Service) processArenaStats(ctx context.Context, entries []*logreader.LogEntry, result *ProcessResult) error {
 	arenaStats, err := logreader.ParseArenaStats(entries)
@@ -1360,6 +1420,102 @@ func (s *Service) inferSetCodeFromCardID(cardID string) string {
 	return setCode
 }
 
+// boardStateJSON encodes a snapshot's permanents and the player's hand for storage.
+func boardStateJSON(snap *logreader.GameSnapshot) *string {
+	toStates := func(objects []logreader.GREGameObject) []repository.PermanentState {
+		states := make([]repository.PermanentState, 0, len(objects))
+		for _, obj := range objects {
+			counters := 0
+			for _, count := range obj.Counters {
+				counters += count
+			}
+			cardTypes := make([]string, 0, len(obj.CardTypes))
+			for _, cardType := range obj.CardTypes {
+				cardTypes = append(cardTypes, strings.TrimPrefix(cardType, "CardType_"))
+			}
+			states = append(states, repository.PermanentState{
+				InstanceID: obj.InstanceID,
+				CardID:     obj.GRPId,
+				CardTypes:  cardTypes,
+				Power:      obj.Power,
+				Toughness:  obj.Toughness,
+				IsTapped:   obj.IsTapped,
+				Counters:   counters,
+				Attacking:  obj.IsAttacking,
+				Blocking:   obj.IsBlocking,
+			})
+		}
+		return states
+	}
+
+	board := repository.BoardState{
+		PlayerPermanents:   toStates(snap.PlayerPermanents),
+		OpponentPermanents: toStates(snap.OpponentPermanents),
+	}
+	if len(snap.PlayerHand) > 0 {
+		board.PlayerHand = toStates(snap.PlayerHand)
+	}
+	data, err := json.Marshal(board)
+	if err != nil {
+		return nil
+	}
+	boardJSON := string(data)
+	return &boardJSON
+}
+
+// replayStep converts a GRE replay step, resolving seats to player and opponent
+// and naming zones from the zones the match has reported.
+func replayStep(step *logreader.ReplayStep, playerConn *logreader.GREConnection, zones map[int]string) *models.ReplayStep {
+	side := func(seatID int) string {
+		if seatID == playerConn.SeatID {
+			return models.PlayerTypePlayer
+		}
+		return models.PlayerTypeOpponent
+	}
+
+	modelStep := &models.ReplayStep{
+		MatchID:      step.MatchID,
+		GameNumber:   step.GameNumber,
+		TurnNumber:   step.TurnNumber,
+		Phase:        step.Phase,
+		Step:         step.Step,
+		ActivePlayer: step.ActivePlayer,
+		PlayerLife:   step.PlayerLife,
+		OpponentLife: step.OpponentLife,
+		Full:         step.Full,
+		Removed:      step.Removed,
+		Timestamp:    step.Timestamp,
+	}
+	for _, obj := range step.Objects {
+		zone, ok := zones[obj.ZoneID]
+		if !ok {
+			zone = obj.ZoneName
+		}
+		replayObj := models.ReplayObject{
+			InstanceID:    obj.InstanceID,
+			CardID:        obj.GRPId,
+			Controller:    side(obj.ControllerSeatID),
+			Owner:         side(obj.OwnerSeatID),
+			Zone:          zone,
+			Power:         obj.Power,
+			Toughness:     obj.Toughness,
+			IsTapped:      obj.IsTapped,
+			IsAttacking:   obj.IsAttacking,
+			IsBlocking:    obj.IsBlocking,
+			SummoningSick: obj.HasSummoningSickness,
+		}
+		for _, cardType := range obj.CardTypes {
+			replayObj.CardTypes = append(replayObj.CardTypes, strings.TrimPrefix(cardType, "CardType_"))
+		}
+		// Leave counters nil when empty so unchanged objects compare equal once stored
+		if len(obj.Counters) > 0 {
+			replayObj.Counters = obj.Counters
+		}
+		modelStep.Objects = append(modelStep.Objects, replayObj)
+	}
+	return modelStep
+}
+
 // processGamePlays parses GRE messages and stores game play data (in-game actions).
 // This includes card plays, attacks, blocks, land drops, and turn snapshots.
 func (s *Service) processGamePlays(ctx context.Context, entries []*logreader.LogEntry, result *ProcessResult) error {
@@ -1368,15 +1524,22 @@ func (s *Service) processGamePlays(ctx context.Context, entries []*logreader.Log
 		return nil
 	}
 
-	// Get player seat ID from connectResp
+	// Get player seat ID from connectResp, which only the first batch of a
+	// game carries
 	playerConn := logreader.GetPlayerSeatID(entries)
+	s.mu.Lock()
+	if playerConn != nil {
+		log.Printf("Found player connection: seat=%d", playerConn.SystemSeatID)
+		s.playerConn = playerConn
+	} else {
+		playerConn = s.playerConn
+	}
+	s.mu.Unlock()
 	if playerConn == nil {
 		// No player connection info found - no game in progress
 		return nil
 	}
 
-	log.Printf("Found player connection: seat=%d", playerConn.SystemSeatID)
-
 	// Parse game plays (zone changes, attacks, blocks, etc.)
 	gamePlays, err := logreader.ParseGamePlays(entries, playerConn)
 	if err != nil {
@@ -1445,6 +1608,7 @@ func (s *Service) processGamePlays(ctx context.Context, entries []*logreader.Log
 	} else if len(snapshots) > 0 {
 		matchID = snapshots[0].MatchID
 	}
+	result.GamePlayMatchID = matchID
 
 	// Store opponent cards
 	if len(opponentCards) > 0 && matchID != "" {
@@ -1504,6 +1668,7 @@ func (s *Service) processGamePlays(ctx context.Context, entries []*logreader.Log
 				lands := snap.OpponentLandsInPlay
 				modelSnap.OpponentLandsInPlay = &lands
 			}
+			modelSnap.BoardStateJSON = boardStateJSON(snap)
 			if err := s.storage.GamePlayRepo().CreateSnapshot(ctx, modelSnap); err != nil {
 				log.Printf("Warning: Failed to store game snapshot: %v", err)
 			} else {
@@ -1515,5 +1680,167 @@ func (s *Service) processGamePlays(ctx context.Context, entries []*logreader.Log
 		}
 	}
 
+	// Store per-step game state for replays
+	replaySteps, err := logreader.ExtractReplaySteps(entries, playerConn)
+	if err != nil {
+		log.Printf("Warning: Failed to extract replay steps: %v", err)
+	} else if len(replaySteps) > 0 {
+		modelSteps := make([]*models.ReplayStep, 0, len(replaySteps))
+		s.mu.Lock()
+		for _, step := range replaySteps {
+			if step.MatchID == "" {
+				continue
+			}
+			zones := s.matchState(step.MatchID).zones
+			for zoneID, name := range step.Zones {
+				zones[zoneID] = name
+			}
+			modelSteps = append(modelSteps, replayStep(step, playerConn, zones))
+		}
+		s.mu.Unlock()
+		if err := s.storage.GamePlayRepo().AppendReplaySteps(ctx, modelSteps); err != nil {
+			log.Printf("Warning: Failed to store replay steps: %v", err)
+		} else {
+			result.ReplayStepsExtracted = len(modelSteps)
+		}
+	}
+
+	// Store turn and decision timing
+	s.mu.Lock()
+	if s.timing == nil {
+		s.timing = logreader.NewTimingTracker()
+	}
+	timing, err := s.timing.Extract(entries, playerConn)
+	s.mu.Unlock()
+	if err != nil {
+		log.Printf("Warning: Failed to extract game timing: %v", err)
+	} else if len(timing.Turns) > 0 || len(timing.TimerEvents) > 0 {
+		turns, decisions, events := timingModels(timing)
+		if err := s.storage.NewTimingRepo().SaveTiming(ctx, turns, decisions, events); err != nil {
+			log.Printf("Warning: Failed to store game timing: %v", err)
+		} else {
+			result.DecisionTimingsStored = len(decisions)
+		}
+	}
+
+	// Store life total changes
+	s.mu.Lock()
+	if s.life == nil {
+		s.life = logreader.NewLifeTracker()
+	}
+	lifeChanges, err := s.life.Extract(entries, playerConn)
+	s.mu.Unlock()
+	if err != nil {
+		log.Printf("Warning: Failed to extract life changes: %v", err)
+	} else if len(lifeChanges) > 0 {
+		modelChanges := make([]*models.LifeChange, 0, len(lifeChanges))
+		for _, change := range lifeChanges {
+			if change.MatchID == "" {
+				continue
+			}
+			modelChanges = append(modelChanges, lifeChange(change))
+		}
+		if err := s.storage.GamePlayRepo().CreateLifeChanges(ctx, modelChanges); err != nil {
+			log.Printf("Warning: Failed to store life changes: %v", err)
+		} else {
+			result.LifeChangesStored = len(modelChanges)
+		}
+	}
+
+	// Store opening hands offered at mulligan prompts
+	openingHands, err := logreader.ExtractOpeningHands(entries, playerConn)
+	if err != nil {
+		log.Printf("Warning: Failed to extract opening hands: %v", err)
+	} else if len(openingHands) > 0 {
+		modelHands := make([]*models.OpeningHand, 0, len(openingHands))
+		for _, hand := range openingHands {
+			modelHands = append(modelHands, &models.OpeningHand{
+				MatchID:       hand.MatchID,
+				GameNumber:    hand.GameNumber,
+				MulliganCount: hand.MulliganCount,
+				CardIDs:       hand.CardIDs,
+				OnPlay:        hand.OnPlay,
+				Decision:      hand.Decision,
+				Timestamp:     hand.Timestamp,
+			})
+		}
+		if err := s.storage.GamePlayRepo().SaveOpeningHands(ctx, modelHands); err != nil {
+			log.Printf("Warning: Failed to store opening hands: %v", err)
+		} else {
+			result.OpeningHandsStored = len(modelHands)
+			for _, hand := range modelHands {
+				if hand.Decision == "" {
+					result.PendingOpeningHands = append(result.PendingOpeningHands, hand)
+				}
+			}
+		}
+	}
+
 	return nil
 }
+
+// lifeChange converts an extracted life change to its storage model.
+func lifeChange(change *logreader.LifeChange) *models.LifeChange {
+	modelChange := &models.LifeChange{
+		MatchID:          change.MatchID,
+		GameNumber:       change.GameNumber,
+		TurnNumber:       change.TurnNumber,
+		Phase:            change.Phase,
+		Step:             change.Step,
+		AffectedPlayer:   change.AffectedPlayer,
+		Amount:           change.Amount,
+		LifeAfter:        change.LifeAfter,
+		ChangeType:       change.ChangeType,
+		SourceController: change.SourceController,
+		AnnotationID:     change.AnnotationID,
+		Timestamp:        change.Timestamp,
+	}
+	if change.SourceCardID != 0 {
+		cardID := change.SourceCardID
+		modelChange.SourceCardID = &cardID
+	}
+	return modelChange
+}
+
+// timingModels converts extracted GRE timing to storage models.
+func timingModels(timing *logreader.GameTiming) ([]*models.TurnTiming, []*models.DecisionTiming, []*models.TimerEvent) {
+	turns := make([]*models.TurnTiming, 0, len(timing.Turns))
+	for _, t := range timing.Turns {
+		turns = append(turns, &models.TurnTiming{
+			MatchID:      t.MatchID,
+			GameNumber:   t.GameNumber,
+			TurnNumber:   t.TurnNumber,
+			ActivePlayer: t.ActivePlayer,
+			StartedAt:    t.StartedAt,
+			EndedAt:      t.EndedAt,
+		})
+	}
+
+	decisions := make([]*models.DecisionTiming, 0, len(timing.Decisions))
+	for _, d := range timing.Decisions {
+		decisions = append(decisions, &models.DecisionTiming{
+			MatchID:    d.MatchID,
+			GameNumber: d.GameNumber,
+			TurnNumber: d.TurnNumber,
+			PlayerType: d.PlayerType,
+			Phase:      d.Phase,
+			Step:       d.Step,
+			StartedAt:  d.StartedAt,
+			DurationMs: d.Duration.Milliseconds(),
+		})
+	}
+
+	events := make([]*models.TimerEvent, 0, len(timing.TimerEvents))
+	for _, e := range timing.TimerEvents {
+		events = append(events, &models.TimerEvent{
+			MatchID:    e.MatchID,
+			GameNumber: e.GameNumber,
+			TurnNumber: e.TurnNumber,
+			PlayerType: e.PlayerType,
+			EventType:  e.EventType,
+			ElapsedMs:  e.Elapsed.Milliseconds(),
+			Timestamp:  e.Timestamp,
+		})
+	}
+	return turns, decisions, events
+}
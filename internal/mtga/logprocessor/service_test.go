@@ -1227,6 +1227,85 @@ func TestProcessLogEntries_QuestsProgressUpdate(t *testing.T) {
 	}
 }
 
+// greStateEntry wraps a game state message in a GRE log entry.
+func greStateEntry(state map[string]interface{}) *logreader.LogEntry {
+	return &logreader.LogEntry{
+		IsJSON:    true,
+		Timestamp: time.Now().Format("2006-01-02 15:04:05"),
+		JSON: map[string]interface{}{
+			"greToClientEvent": map[string]interface{}{
+				"greToClientMessages": []interface{}{
+					map[string]interface{}{
+						"type":             "GREMessageType_GameStateMessage",
+						"gameStateMessage": state,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestProcessLogEntries_ReplayZonesCarryAcrossBatches(t *testing.T) {
+	service, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	processor := NewService(service)
+
+	land := func(zoneID int) map[string]interface{} {
+		return map[string]interface{}{
+			"instanceId": float64(10), "grpId": float64(100),
+			"ownerSeatId": float64(1), "controllerSeatId": float64(1), "zoneId": float64(zoneID),
+		}
+	}
+	gameInfo := map[string]interface{}{"matchID": "replay-zones", "gameNumber": float64(1)}
+
+	// The first batch reports the zones; the second only moves the land
+	first := []*logreader.LogEntry{
+		{IsJSON: true, JSON: map[string]interface{}{
+			"connectResp": map[string]interface{}{"systemSeatIds": []interface{}{float64(1)}},
+		}},
+		greStateEntry(map[string]interface{}{
+			"type":     "GameStateType_Full",
+			"gameInfo": gameInfo,
+			"turnInfo": map[string]interface{}{"turnNumber": float64(1), "phase": "Phase_Main1", "activePlayer": float64(1)},
+			"zones": []interface{}{
+				map[string]interface{}{"zoneId": float64(28), "type": "ZoneType_Battlefield"},
+				map[string]interface{}{"zoneId": float64(31), "type": "ZoneType_Hand", "ownerSeatId": float64(1)},
+			},
+			"gameObjects": []interface{}{land(31)},
+		}),
+	}
+	second := []*logreader.LogEntry{
+		greStateEntry(map[string]interface{}{
+			"type":        "GameStateType_Diff",
+			"gameInfo":    gameInfo,
+			"turnInfo":    map[string]interface{}{"turnNumber": float64(1), "phase": "Phase_Combat", "step": "Step_BeginCombat", "activePlayer": float64(1)},
+			"gameObjects": []interface{}{land(28)},
+		}),
+	}
+
+	for _, batch := range [][]*logreader.LogEntry{first, second} {
+		if _, err := processor.ProcessLogEntries(ctx, batch); err != nil {
+			t.Fatalf("ProcessLogEntries: %v", err)
+		}
+	}
+
+	frames, err := service.GamePlayRepo().GetReplayFrames(ctx, "replay-zones")
+	if err != nil {
+		t.Fatalf("GetReplayFrames: %v", err)
+	}
+	if len(frames) != 2 {
+		t.Fatalf("got %d frames, want 2", len(frames))
+	}
+	if zone := frames[0].Objects[0].Zone; zone != "hand" {
+		t.Errorf("first frame zone = %q, want hand", zone)
+	}
+	if zone := frames[1].Objects[0].Zone; zone != "battlefield" {
+		t.Errorf("second frame zone = %q, want battlefield from the first batch's zones", zone)
+	}
+}
+
 // Benchmark tests
 func BenchmarkProcessLogEntries(b *testing.B) {
 	service, cleanup := setupTestService(&testing.T{})
@@ -0,0 +1,164 @@
+package logprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/logreader"
+)
+
+// ErrWriteQueueClosed is returned by Submit after the queue has been closed.
+var ErrWriteQueueClosed = errors.New("write queue closed")
+
+// Source identifies the log reader a submission came from. It names the
+// reader in errors returned by Submit.
+type Source string
+
+const (
+	// SourcePoller is the live Player.log poller.
+	SourcePoller Source = "poller"
+
+	// SourceReplay is a replay of historical or recorded logs.
+	SourceReplay Source = "replay"
+
+	// SourceRecovery is startup recovery of logs written while the daemon was down.
+	SourceRecovery Source = "recovery"
+
+	// SourceLogMonitor is the UTC_Log file monitor.
+	SourceLogMonitor Source = "log_monitor"
+)
+
+// WriteQueueConfig configures a WriteQueue.
+type WriteQueueConfig struct {
+	// QueueSize is the number of submissions that can wait before Submit blocks.
+	// Default: 64
+	QueueSize int
+}
+
+// DefaultWriteQueueConfig returns a WriteQueueConfig with sensible default values.
+func DefaultWriteQueueConfig() WriteQueueConfig {
+	return WriteQueueConfig{
+		QueueSize: 64,
+	}
+}
+
+// writeRequest is a single Submit call waiting to be written.
+type writeRequest struct {
+	ctx     context.Context
+	source  Source
+	entries []*logreader.LogEntry
+	done    chan writeResult
+}
+
+type writeResult struct {
+	result *ProcessResult
+	err    error
+}
+
+// WriteQueue is a single-writer queue: submissions are processed one at a
+// time, in the order they were queued, so only one ProcessLogEntries call
+// writes to the database at a time. Each submission is processed on its own
+// and its caller receives only its own result.
+//
+// The live poller, UTC_Log monitor and replay engine can all produce entries
+// concurrently; without the queue their writes race for the SQLite write lock
+// and fail with SQLITE_BUSY.
+type WriteQueue struct {
+	processor *Service
+	requests  chan *writeRequest
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewWriteQueue creates a write queue for processor and starts its worker.
+// Call Close to stop the worker once no more entries will be submitted.
+func NewWriteQueue(processor *Service, config WriteQueueConfig) *WriteQueue {
+	if config.QueueSize <= 0 {
+		config.QueueSize = DefaultWriteQueueConfig().QueueSize
+	}
+
+	q := &WriteQueue{
+		processor: processor,
+		requests:  make(chan *writeRequest, config.QueueSize),
+	}
+
+	q.wg.Add(1)
+	go q.run()
+
+	return q
+}
+
+// Submit queues entries read by source for processing and waits for them to be
+// written. The result covers only these entries.
+func (q *WriteQueue) Submit(ctx context.Context, source Source, entries []*logreader.LogEntry) (*ProcessResult, error) {
+	req := &writeRequest{
+		ctx:     ctx,
+		source:  source,
+		entries: entries,
+		done:    make(chan writeResult, 1),
+	}
+
+	q.mu.RLock()
+	if q.closed {
+		q.mu.RUnlock()
+		return nil, ErrWriteQueueClosed
+	}
+	select {
+	case q.requests <- req:
+		q.mu.RUnlock()
+	case <-ctx.Done():
+		q.mu.RUnlock()
+		return nil, ctx.Err()
+	}
+
+	// Wait for the worker even if ctx is cancelled: the entries may already be
+	// writing and the caller should see the outcome.
+	res := <-req.done
+	return res.result, res.err
+}
+
+// Close stops accepting submissions, writes everything already queued and
+// waits for the worker to exit.
+func (q *WriteQueue) Close() {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return
+	}
+	q.closed = true
+	close(q.requests)
+	q.mu.Unlock()
+
+	q.wg.Wait()
+}
+
+// run is the single writer loop.
+func (q *WriteQueue) run() {
+	defer q.wg.Done()
+
+	for req := range q.requests {
+		q.write(req)
+	}
+}
+
+// write processes a single submission and notifies its submitter.
+func (q *WriteQueue) write(req *writeRequest) {
+	// Skip submissions whose callers have already given up
+	if err := req.ctx.Err(); err != nil {
+		req.done <- writeResult{err: err}
+		return
+	}
+
+	// Once started, the write finishes even if the caller cancels, so the
+	// entries are not left half processed.
+	ctx := context.WithoutCancel(req.ctx)
+	result, err := q.processor.ProcessLogEntries(ctx, req.entries)
+	if err != nil {
+		err = fmt.Errorf("failed to process %s entries: %w", req.source, err)
+	}
+	req.done <- writeResult{result: result, err: err}
+}
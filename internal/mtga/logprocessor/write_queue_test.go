@@ -0,0 +1,251 @@
+package logprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/logreader"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+)
+
+// replayMatchEntries builds a log excerpt with n completed matches, as a replayed
+// Player.log would deliver them.
+func replayMatchEntries(prefix string, n int) []*logreader.LogEntry {
+	base := time.Date(2025, 11, 15, 10, 0, 0, 0, time.UTC)
+	entries := make([]*logreader.LogEntry, 0, n)
+	for i := 0; i < n; i++ {
+		matchID := fmt.Sprintf("%s-match-%d", prefix, i)
+		winningTeam := float64(1)
+		if i%3 == 0 {
+			winningTeam = 2
+		}
+		entries = append(entries, &logreader.LogEntry{
+			IsJSON:    true,
+			Timestamp: base.Add(time.Duration(i) * time.Minute).Format("2006-01-02 15:04:05"),
+			JSON: map[string]interface{}{
+				"matchGameRoomStateChangedEvent": map[string]interface{}{
+					"gameRoomInfo": map[string]interface{}{
+						"gameRoomConfig": map[string]interface{}{
+							"matchId": matchID,
+							"reservedPlayers": []interface{}{
+								map[string]interface{}{
+									"playerName": "Tester",
+									"teamId":     float64(1),
+									"eventId":    "Ladder",
+								},
+							},
+						},
+						"finalMatchResult": map[string]interface{}{
+							"matchId": matchID,
+							"resultList": []interface{}{
+								map[string]interface{}{
+									"scope":         "MatchScope_Game",
+									"winningTeamId": winningTeam,
+								},
+								map[string]interface{}{
+									"scope":         "MatchScope_Match",
+									"winningTeamId": winningTeam,
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	return entries
+}
+
+func TestWriteQueue_SubmitWritesEntries(t *testing.T) {
+	service, cleanup := setupTestService(t)
+	defer cleanup()
+
+	queue := NewWriteQueue(NewService(service), DefaultWriteQueueConfig())
+	defer queue.Close()
+
+	result, err := queue.Submit(context.Background(), SourcePoller, replayMatchEntries("submit", 3))
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if result.MatchesStored != 3 {
+		t.Errorf("MatchesStored = %d, want 3", result.MatchesStored)
+	}
+}
+
+func TestWriteQueue_SubmitAfterClose(t *testing.T) {
+	service, cleanup := setupTestService(t)
+	defer cleanup()
+
+	queue := NewWriteQueue(NewService(service), DefaultWriteQueueConfig())
+	queue.Close()
+	queue.Close() // idempotent
+
+	_, err := queue.Submit(context.Background(), SourcePoller, replayMatchEntries("closed", 1))
+	if !errors.Is(err, ErrWriteQueueClosed) {
+		t.Errorf("Submit after Close error = %v, want ErrWriteQueueClosed", err)
+	}
+}
+
+func TestWriteQueue_ConcurrentSubmissions(t *testing.T) {
+	service, cleanup := setupTestService(t)
+	defer cleanup()
+
+	queue := NewWriteQueue(NewService(service), WriteQueueConfig{QueueSize: 16})
+	defer queue.Close()
+
+	const submitters = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, submitters)
+	for i := 0; i < submitters; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := queue.Submit(context.Background(), SourceReplay, replayMatchEntries(fmt.Sprintf("concurrent-%d", i), 2)); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Submit failed: %v", err)
+	}
+
+	stats, err := service.GetStats(context.Background(), models.StatsFilter{})
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if stats.TotalMatches != submitters*2 {
+		t.Errorf("TotalMatches = %d, want %d", stats.TotalMatches, submitters*2)
+	}
+}
+
+func TestWriteQueue_EachSubmitterGetsOwnResult(t *testing.T) {
+	service, cleanup := setupTestService(t)
+	defer cleanup()
+
+	queue := NewWriteQueue(NewService(service), WriteQueueConfig{QueueSize: 16})
+	defer queue.Close()
+
+	// Two sources submit batches of different sizes at once, so batches from
+	// both wait in the queue while earlier ones are being written.
+	const rounds = 4
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*rounds)
+	submit := func(source Source, prefix string, matches int) {
+		defer wg.Done()
+		result, err := queue.Submit(context.Background(), source, replayMatchEntries(prefix, matches))
+		if err != nil {
+			errs <- err
+			return
+		}
+		if result.MatchesStored != matches {
+			errs <- fmt.Errorf("%s: MatchesStored = %d, want %d", prefix, result.MatchesStored, matches)
+		}
+	}
+	for i := 0; i < rounds; i++ {
+		wg.Add(2)
+		go submit(SourcePoller, fmt.Sprintf("poller-%d", i), 2)
+		go submit(SourceRecovery, fmt.Sprintf("recovery-%d", i), 5)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// TestWriteQueue_AnalyticsDuringReplayIngest runs the heavy analytics queries on
+// the read pool while a replayed log is ingested through the write queue from
+// several sources at once. Neither side may fail with SQLITE_BUSY.
+func TestWriteQueue_AnalyticsDuringReplayIngest(t *testing.T) {
+	service, cleanup := setupTestService(t)
+	defer cleanup()
+
+	queue := NewWriteQueue(NewService(service), DefaultWriteQueueConfig())
+	defer queue.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const (
+		sources         = 3
+		chunksPerSource = 10
+		matchesPerChunk = 5
+	)
+
+	var errMu sync.Mutex
+	var failures []error
+	fail := func(err error) {
+		errMu.Lock()
+		failures = append(failures, err)
+		errMu.Unlock()
+	}
+
+	// Analytics readers run until ingestion finishes
+	var readers sync.WaitGroup
+	var queries int64
+	var queriesMu sync.Mutex
+	for i := 0; i < 4; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for ctx.Err() == nil {
+				if _, err := service.GetStats(ctx, models.StatsFilter{}); err != nil && ctx.Err() == nil {
+					fail(fmt.Errorf("GetStats: %w", err))
+				}
+				if _, err := service.GetPerformanceMetrics(ctx, models.StatsFilter{}); err != nil && ctx.Err() == nil {
+					fail(fmt.Errorf("GetPerformanceMetrics: %w", err))
+				}
+				queriesMu.Lock()
+				queries++
+				queriesMu.Unlock()
+			}
+		}()
+	}
+
+	// Several log sources (poller, UTC_Log monitor, replay) submit concurrently
+	sourceNames := []Source{SourcePoller, SourceLogMonitor, SourceReplay}
+	var writers sync.WaitGroup
+	for src := 0; src < sources; src++ {
+		writers.Add(1)
+		go func(src int) {
+			defer writers.Done()
+			for chunk := 0; chunk < chunksPerSource; chunk++ {
+				entries := replayMatchEntries(fmt.Sprintf("src%d-chunk%d", src, chunk), matchesPerChunk)
+				result, err := queue.Submit(context.Background(), sourceNames[src], entries)
+				if err != nil {
+					fail(fmt.Errorf("Submit: %w", err))
+					continue
+				}
+				for _, procErr := range result.Errors {
+					fail(fmt.Errorf("ProcessLogEntries: %w", procErr))
+				}
+			}
+		}(src)
+	}
+
+	writers.Wait()
+	cancel()
+	readers.Wait()
+
+	for _, err := range failures {
+		t.Error(err)
+	}
+	if queries == 0 {
+		t.Error("analytics readers never completed a query")
+	}
+
+	stats, err := service.GetStats(context.Background(), models.StatsFilter{})
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	want := sources * chunksPerSource * matchesPerChunk
+	if stats.TotalMatches != want {
+		t.Errorf("TotalMatches = %d, want %d", stats.TotalMatches, want)
+	}
+}
@@ -0,0 +1,223 @@
+package logreader
+
+import "time"
+
+// Life change types.
+const (
+	LifeChangeCombat   = "combat"    // Combat damage to a player
+	LifeChangeBurn     = "burn"      // Noncombat damage to a player
+	LifeChangeDrain    = "drain"     // Life loss that is not damage
+	LifeChangeLifelink = "lifelink"  // Life gained from damage the source dealt
+	LifeChangeGain     = "life_gain" // Any other life gain
+	LifeChangeOther    = "other"     // Life total changed without an annotation explaining it
+)
+
+// LifeChange is one change to a player's life total and what caused it.
+type LifeChange struct {
+	MatchID          string
+	GameNumber       int
+	TurnNumber       int
+	Phase            string
+	Step             string
+	AffectedPlayer   string // "player" or "opponent"
+	Amount           int    // Negative for damage and life loss
+	LifeAfter        *int   // Nil when the total is not known yet
+	ChangeType       string // LifeChangeCombat, LifeChangeBurn, etc.
+	SourceInstanceID int    // Zero when the source is unknown or a player
+	SourceCardID     int    // Arena card ID of the source
+	SourceController string // "player" or "opponent", empty when unknown
+	AnnotationID     int    // Zero for unexplained changes
+	Timestamp        time.Time
+}
+
+// ExtractLifeChanges attributes every life total change to its source using
+// the ModifiedLife and DamageDealt annotations of GRE game state messages.
+// Damage dealt during a combat damage step is combat damage and any other
+// damage is burn; a loss without damage is a drain, and a gain from a source
+// that dealt damage in the same message is lifelink. Changes to a life total
+// that no annotation explains are recorded as LifeChangeOther. Use a
+// LifeTracker for games that span several batches of entries.
+func ExtractLifeChanges(entries []*LogEntry, playerConn *GREConnection) ([]*LifeChange, error) {
+	return NewLifeTracker().Extract(entries, playerConn)
+}
+
+// LifeTracker attributes life changes across batches of log entries. The
+// game's objects and life totals carry from one batch to the next, so a
+// source that entered in an earlier batch is still named and a reported total
+// is compared with the one before it.
+type LifeTracker struct {
+	matchID, phase, step   string
+	gameNumber, turnNumber int
+	objects                map[int]GREGameObject // Last known state of every instance in the game
+	life                   map[int]int           // Seat ID -> life total
+}
+
+// NewLifeTracker creates a tracker with no game in progress.
+func NewLifeTracker() *LifeTracker {
+	return &LifeTracker{
+		objects: make(map[int]GREGameObject),
+		life:    make(map[int]int),
+	}
+}
+
+// Extract attributes the life changes in one batch of entries, continuing the
+// game in progress from earlier batches.
+func (t *LifeTracker) Extract(entries []*LogEntry, playerConn *GREConnection) ([]*LifeChange, error) {
+	messages, err := ParseGREMessages(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	playerType := func(seatID int) string {
+		if playerConn != nil && seatID == playerConn.SeatID {
+			return "player"
+		}
+		return "opponent"
+	}
+
+	var changes []*LifeChange
+	for _, msg := range messages {
+		if (msg.MatchID != "" && msg.MatchID != t.matchID) || (msg.GameNumber != 0 && msg.GameNumber != t.gameNumber) {
+			t.objects = make(map[int]GREGameObject)
+			t.life = make(map[int]int)
+			t.turnNumber = 0
+		}
+		if msg.MatchID != "" {
+			t.matchID = msg.MatchID
+		}
+		if msg.GameNumber != 0 {
+			t.gameNumber = msg.GameNumber
+		}
+		if msg.TurnInfo != nil {
+			if msg.TurnInfo.TurnNumber > 0 {
+				t.turnNumber = msg.TurnInfo.TurnNumber
+			}
+			if msg.TurnInfo.Phase != "" {
+				t.phase = normalizePhase(msg.TurnInfo.Phase)
+				t.step = normalizeStep(msg.TurnInfo.Step)
+			}
+		}
+
+		// Objects that leave the game keep their last state so spells that
+		// resolved in this message can still be named as the source
+		for _, obj := range msg.GameObjects {
+			t.objects[obj.InstanceID] = obj
+		}
+
+		// Sources that dealt damage to each seat, and sources that dealt any damage
+		damagedSeat := make(map[[2]int]bool)
+		dealtDamage := make(map[int]bool)
+		for _, annotation := range msg.Annotations {
+			if !annotation.HasType("AnnotationType_DamageDealt") {
+				continue
+			}
+			dealtDamage[annotation.AffectorID] = true
+			for _, id := range annotation.AffectedIDs {
+				damagedSeat[[2]int{annotation.AffectorID, id}] = true
+			}
+		}
+
+		combatStep := t.step == "CombatDamage" || t.step == "FirstStrikeDamage"
+		seatChanges := make(map[int][]*LifeChange) // Seat ID -> changes in this message
+		for _, annotation := range msg.Annotations {
+			if !annotation.HasType("AnnotationType_ModifiedLife") || len(annotation.AffectedIDs) == 0 {
+				continue
+			}
+			amount := annotation.Detail("life")
+			if amount == 0 {
+				continue
+			}
+			seatID := annotation.AffectedIDs[0]
+
+			change := &LifeChange{
+				MatchID:        t.matchID,
+				GameNumber:     t.gameNumber,
+				TurnNumber:     t.turnNumber,
+				Phase:          t.phase,
+				Step:           t.step,
+				AffectedPlayer: playerType(seatID),
+				Amount:         amount,
+				AnnotationID:   annotation.ID,
+				Timestamp:      msg.Timestamp,
+			}
+			if source, ok := t.objects[annotation.AffectorID]; ok {
+				change.SourceInstanceID = source.InstanceID
+				change.SourceCardID = source.GRPId
+				change.SourceController = playerType(source.ControllerSeatID)
+			} else if _, isSeat := t.life[annotation.AffectorID]; isSeat {
+				change.SourceController = playerType(annotation.AffectorID)
+			}
+
+			damaged := damagedSeat[[2]int{annotation.AffectorID, seatID}]
+			switch {
+			case amount < 0 && damaged && combatStep:
+				change.ChangeType = LifeChangeCombat
+			case amount < 0 && damaged:
+				change.ChangeType = LifeChangeBurn
+			case amount < 0:
+				change.ChangeType = LifeChangeDrain
+			case dealtDamage[annotation.AffectorID]:
+				change.ChangeType = LifeChangeLifelink
+			default:
+				change.ChangeType = LifeChangeGain
+			}
+
+			seatChanges[seatID] = append(seatChanges[seatID], change)
+			changes = append(changes, change)
+		}
+
+		// Reported life totals are authoritative: anything the annotations do
+		// not account for is an unexplained change, and each change's resulting
+		// total is worked out backwards from the reported one
+		reported := make(map[int]bool)
+		for _, player := range msg.Players {
+			reported[player.SeatID] = true
+			seatID := player.SeatID
+			if previous, known := t.life[seatID]; known {
+				expected := previous
+				for _, change := range seatChanges[seatID] {
+					expected += change.Amount
+				}
+				if diff := player.LifeTotal - expected; diff != 0 {
+					other := &LifeChange{
+						MatchID:        t.matchID,
+						GameNumber:     t.gameNumber,
+						TurnNumber:     t.turnNumber,
+						Phase:          t.phase,
+						Step:           t.step,
+						AffectedPlayer: playerType(seatID),
+						Amount:         diff,
+						ChangeType:     LifeChangeOther,
+						Timestamp:      msg.Timestamp,
+					}
+					seatChanges[seatID] = append(seatChanges[seatID], other)
+					changes = append(changes, other)
+				}
+			}
+
+			after := player.LifeTotal
+			for i := len(seatChanges[seatID]) - 1; i >= 0; i-- {
+				lifeAfter := after
+				seatChanges[seatID][i].LifeAfter = &lifeAfter
+				after -= seatChanges[seatID][i].Amount
+			}
+			t.life[seatID] = player.LifeTotal
+		}
+
+		// Seats without a reported total carry their last known one forward
+		for seatID, seatList := range seatChanges {
+			current, known := t.life[seatID]
+			if reported[seatID] || !known {
+				continue
+			}
+			for _, change := range seatList {
+				current += change.Amount
+				lifeAfter := current
+				change.LifeAfter = &lifeAfter
+			}
+			t.life[seatID] = current
+		}
+	}
+
+	return changes, nil
+}
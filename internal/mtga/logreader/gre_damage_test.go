@@ -0,0 +1,183 @@
+package logreader
+
+import "testing"
+
+func annotation(id, affector int, affected []int, annotationType string, details map[string]int) map[string]interface{} {
+	affectedIDs := make([]interface{}, len(affected))
+	for i, a := range affected {
+		affectedIDs[i] = float64(a)
+	}
+	var detailList []interface{}
+	for key, value := range details {
+		detailList = append(detailList, map[string]interface{}{
+			"key":        key,
+			"type":       "KeyValuePairValueType_int32",
+			"valueInt32": []interface{}{float64(value)},
+		})
+	}
+	return map[string]interface{}{
+		"id":          float64(id),
+		"affectorId":  float64(affector),
+		"affectedIds": affectedIDs,
+		"type":        []interface{}{annotationType},
+		"details":     detailList,
+	}
+}
+
+func lifeTotals(player, opponent int) []interface{} {
+	return []interface{}{
+		map[string]interface{}{"seatId": float64(1), "lifeTotal": float64(player)},
+		map[string]interface{}{"seatId": float64(2), "lifeTotal": float64(opponent)},
+	}
+}
+
+func TestExtractLifeChanges(t *testing.T) {
+	entries := []*LogEntry{
+		gameStateEntry(map[string]interface{}{
+			"type":     "GameStateType_Full",
+			"gameInfo": map[string]interface{}{"matchID": "match-1", "gameNumber": float64(1)},
+			"turnInfo": turnInfo(3, "Phase_Combat", "Step_CombatDamage", 1),
+			"players":  lifeTotals(20, 20),
+			"gameObjects": []interface{}{
+				gameObject(300, 1001, 1, 28, false), // Our lifelinking creature
+				gameObject(301, 1002, 2, 28, false), // Their creature
+			},
+		}),
+		// Our creature hits them for 3 and we gain 3
+		gameStateEntry(map[string]interface{}{
+			"type":     "GameStateType_Diff",
+			"turnInfo": turnInfo(3, "Phase_Combat", "Step_CombatDamage", 1),
+			"players":  lifeTotals(23, 17),
+			"annotations": []interface{}{
+				annotation(50, 300, []int{2}, "AnnotationType_DamageDealt", map[string]int{"damage": 3}),
+				annotation(51, 300, []int{2}, "AnnotationType_ModifiedLife", map[string]int{"life": -3}),
+				annotation(52, 300, []int{1}, "AnnotationType_ModifiedLife", map[string]int{"life": 3}),
+			},
+		}),
+		// Their burn spell hits us for 4 in their main phase, and a drain takes 2 more
+		gameStateEntry(map[string]interface{}{
+			"type":        "GameStateType_Diff",
+			"turnInfo":    turnInfo(4, "Phase_Main1", "", 2),
+			"players":     lifeTotals(17, 17),
+			"gameObjects": []interface{}{gameObject(310, 2001, 2, 27, false), gameObject(311, 2002, 2, 28, false)},
+			"annotations": []interface{}{
+				annotation(60, 310, []int{1}, "AnnotationType_DamageDealt", map[string]int{"damage": 4}),
+				annotation(61, 310, []int{1}, "AnnotationType_ModifiedLife", map[string]int{"life": -4}),
+				annotation(62, 311, []int{1}, "AnnotationType_ModifiedLife", map[string]int{"life": -2}),
+			},
+		}),
+		// A life change without an annotation
+		gameStateEntry(map[string]interface{}{
+			"type":     "GameStateType_Diff",
+			"turnInfo": turnInfo(4, "Phase_Main2", "", 2),
+			"players":  lifeTotals(16, 17),
+		}),
+	}
+
+	changes, err := ExtractLifeChanges(entries, &GREConnection{SeatID: 1})
+	if err != nil {
+		t.Fatalf("ExtractLifeChanges: %v", err)
+	}
+
+	want := []struct {
+		affected   string
+		amount     int
+		lifeAfter  int
+		changeType string
+		cardID     int
+		controller string
+	}{
+		{"opponent", -3, 17, LifeChangeCombat, 1001, "player"},
+		{"player", 3, 23, LifeChangeLifelink, 1001, "player"},
+		{"player", -4, 19, LifeChangeBurn, 2001, "opponent"},
+		{"player", -2, 17, LifeChangeDrain, 2002, "opponent"},
+		{"player", -1, 16, LifeChangeOther, 0, ""},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d life changes, want %d", len(changes), len(want))
+	}
+	for i, w := range want {
+		c := changes[i]
+		if c.LifeAfter == nil || *c.LifeAfter != w.lifeAfter {
+			t.Errorf("change %d life after = %v, want %d", i, c.LifeAfter, w.lifeAfter)
+			continue
+		}
+		if c.AffectedPlayer != w.affected || c.Amount != w.amount ||
+			c.ChangeType != w.changeType || c.SourceCardID != w.cardID || c.SourceController != w.controller {
+			t.Errorf("change %d = %+v, want %+v", i, c, w)
+		}
+		if c.MatchID != "match-1" || c.GameNumber != 1 {
+			t.Errorf("change %d not attributed to the game: %s/%d", i, c.MatchID, c.GameNumber)
+		}
+	}
+	if changes[0].TurnNumber != 3 || changes[2].TurnNumber != 4 || changes[2].Phase != "Main1" {
+		t.Errorf("unexpected turn attribution: %+v, %+v", changes[0], changes[2])
+	}
+}
+
+func TestLifeTracker_GameAcrossBatches(t *testing.T) {
+	tracker := NewLifeTracker()
+	conn := &GREConnection{SeatID: 1}
+
+	// The first batch sets up the board and life totals
+	first, err := tracker.Extract([]*LogEntry{
+		gameStateEntry(map[string]interface{}{
+			"type":        "GameStateType_Full",
+			"gameInfo":    map[string]interface{}{"matchID": "match-1", "gameNumber": float64(1)},
+			"turnInfo":    turnInfo(3, "Phase_Main1", "", 2),
+			"players":     lifeTotals(20, 20),
+			"gameObjects": []interface{}{gameObject(301, 1002, 2, 28, false)},
+		}),
+	}, conn)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if len(first) != 0 {
+		t.Fatalf("first batch: got %d life changes, want none", len(first))
+	}
+
+	// The second only carries the combat damage and the new totals
+	second, err := tracker.Extract([]*LogEntry{
+		gameStateEntry(map[string]interface{}{
+			"type":     "GameStateType_Diff",
+			"turnInfo": turnInfo(3, "Phase_Combat", "Step_CombatDamage", 2),
+			"players":  []interface{}{map[string]interface{}{"seatId": float64(1), "lifeTotal": float64(17)}},
+			"annotations": []interface{}{
+				annotation(70, 301, []int{1}, "AnnotationType_DamageDealt", map[string]int{"damage": 3}),
+				annotation(71, 301, []int{1}, "AnnotationType_ModifiedLife", map[string]int{"life": -3}),
+			},
+		}),
+	}, conn)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+
+	// The source from the first batch is named and the earlier total explains
+	// the new one, so nothing is left unexplained
+	if len(second) != 1 {
+		t.Fatalf("second batch: got %d life changes, want 1: %+v", len(second), second)
+	}
+	c := second[0]
+	if c.ChangeType != LifeChangeCombat || c.SourceCardID != 1002 || c.SourceController != "opponent" {
+		t.Errorf("change = %+v, want combat damage from card 1002", c)
+	}
+	if c.MatchID != "match-1" || c.GameNumber != 1 || c.TurnNumber != 3 {
+		t.Errorf("change not attributed to the game: %+v", c)
+	}
+	if c.LifeAfter == nil || *c.LifeAfter != 17 {
+		t.Errorf("life after = %v, want 17", c.LifeAfter)
+	}
+}
+
+func TestParseAnnotation(t *testing.T) {
+	a := parseAnnotation(annotation(7, 300, []int{2, 3}, "AnnotationType_DamageDealt", map[string]int{"damage": 5}))
+	if a.ID != 7 || a.AffectorID != 300 || len(a.AffectedIDs) != 2 {
+		t.Errorf("unexpected annotation %+v", a)
+	}
+	if !a.HasType("AnnotationType_DamageDealt") || a.HasType("AnnotationType_ModifiedLife") {
+		t.Errorf("unexpected types %v", a.Types)
+	}
+	if a.Detail("damage") != 5 || a.Detail("missing") != 0 {
+		t.Errorf("unexpected details %v", a.Details)
+	}
+}
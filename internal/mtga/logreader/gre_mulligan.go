@@ -0,0 +1,195 @@
+package logreader
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Mulligan decisions.
+const (
+	MulliganDecisionKeep     = "keep"
+	MulliganDecisionMulligan = "mulligan"
+)
+
+// OpeningHand is a hand we were asked to keep or mulligan.
+type OpeningHand struct {
+	MatchID       string
+	GameNumber    int
+	MulliganCount int   // Mulligans already taken; a kept hand bottoms this many cards
+	CardIDs       []int // Arena card IDs of the cards drawn
+	OnPlay        *bool // Nil until the starting player is known
+	Decision      string
+	Timestamp     time.Time
+}
+
+// ExtractOpeningHands finds every mulligan prompt sent to the player and the
+// hand it was for. The decision comes from our mulligan response when the log
+// has it; otherwise a further prompt in the same game means the hand was
+// mulliganed, and the first turn starting means it was kept. A hand still
+// waiting on its decision has an empty Decision.
+func ExtractOpeningHands(entries []*LogEntry, playerConn *GREConnection) ([]*OpeningHand, error) {
+	if playerConn == nil {
+		return nil, nil
+	}
+
+	var hands []*OpeningHand
+	var matchID string
+	var gameNumber, startingSeat int
+	var pending *OpeningHand               // Hand waiting on a decision
+	objects := make(map[int]GREGameObject) // Current state of every instance in the game
+	seen := make(map[string]bool)          // Prompts already recorded, by game and mulligan count
+
+	decide := func(decision string) {
+		if pending != nil {
+			pending.Decision = decision
+			pending = nil
+		}
+	}
+	setStartingSeat := func(seatID int) {
+		startingSeat = seatID
+		onPlay := seatID == playerConn.SeatID
+		for _, hand := range hands {
+			if hand.MatchID == matchID && hand.GameNumber == gameNumber {
+				hand.OnPlay = &onPlay
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		if !entry.IsJSON {
+			continue
+		}
+		at, ok := greEntryTime(entry)
+		if !ok {
+			at = time.Now()
+		}
+
+		// Our answer to a prompt, sent from the client to the GRE
+		if payload, ok := entry.JSON["payload"].(map[string]interface{}); ok {
+			if msgType, _ := payload["type"].(string); msgType == "ClientMessageType_MulliganResp" {
+				resp, _ := payload["mulliganResp"].(map[string]interface{})
+				switch decision, _ := resp["decision"].(string); decision {
+				case "MulliganOption_AcceptHand":
+					decide(MulliganDecisionKeep)
+				case "MulliganOption_Mulligan":
+					decide(MulliganDecisionMulligan)
+				}
+			}
+			continue
+		}
+
+		greEvent, ok := entry.JSON["greToClientEvent"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		greToClientMsgs, ok := greEvent["greToClientMessages"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, msgData := range greToClientMsgs {
+			msgMap, ok := msgData.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			switch msgType, _ := msgMap["type"].(string); msgType {
+			case "GREMessageType_GameStateMessage":
+				msg := parseGameStateMessage(msgMap, at)
+				if msg == nil {
+					continue
+				}
+				if (msg.MatchID != "" && msg.MatchID != matchID) || (msg.GameNumber != 0 && msg.GameNumber != gameNumber) {
+					pending = nil
+					startingSeat = 0
+					objects = make(map[int]GREGameObject)
+					if msg.MatchID != "" {
+						matchID = msg.MatchID
+					}
+					if msg.GameNumber != 0 {
+						gameNumber = msg.GameNumber
+					}
+				}
+				if msg.StateType == "GameStateType_Full" {
+					objects = make(map[int]GREGameObject)
+				}
+				for _, id := range msg.DeletedInstanceIDs {
+					delete(objects, id)
+				}
+				for _, obj := range msg.GameObjects {
+					objects[obj.InstanceID] = obj
+				}
+
+				if msg.TurnInfo == nil {
+					continue
+				}
+				if msg.TurnInfo.TurnNumber > 0 {
+					// Play has begun, so the last hand offered was kept
+					decide(MulliganDecisionKeep)
+					if msg.TurnInfo.TurnNumber == 1 && msg.TurnInfo.ActivePlayer != 0 && msg.TurnInfo.ActivePlayer != startingSeat {
+						setStartingSeat(msg.TurnInfo.ActivePlayer)
+					}
+				} else if msg.TurnInfo.ActivePlayer != 0 && startingSeat == 0 {
+					setStartingSeat(msg.TurnInfo.ActivePlayer)
+				}
+
+			case "GREMessageType_MulliganReq":
+				if matchID == "" || !promptedSeat(msgMap, playerConn.SeatID) {
+					continue
+				}
+				count := 0
+				if req, ok := msgMap["mulliganReq"].(map[string]interface{}); ok {
+					count = intField(req, "mulliganCount")
+				}
+				key := matchID + "|" + strconv.Itoa(gameNumber) + "|" + strconv.Itoa(count)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				// Another prompt means the previous hand went back
+				decide(MulliganDecisionMulligan)
+
+				hand := &OpeningHand{
+					MatchID:       matchID,
+					GameNumber:    gameNumber,
+					MulliganCount: count,
+					Timestamp:     at,
+				}
+				var inHand []GREGameObject
+				for _, obj := range objects {
+					if obj.ZoneName == "hand" && obj.ControllerSeatID == playerConn.SeatID {
+						inHand = append(inHand, obj)
+					}
+				}
+				sort.Slice(inHand, func(i, j int) bool { return inHand[i].InstanceID < inHand[j].InstanceID })
+				for _, obj := range inHand {
+					hand.CardIDs = append(hand.CardIDs, obj.GRPId)
+				}
+				if startingSeat != 0 {
+					onPlay := startingSeat == playerConn.SeatID
+					hand.OnPlay = &onPlay
+				}
+				hands = append(hands, hand)
+				pending = hand
+			}
+		}
+	}
+
+	return hands, nil
+}
+
+// promptedSeat reports whether a GRE request is addressed to the seat.
+func promptedSeat(msgMap map[string]interface{}, seatID int) bool {
+	seats, ok := msgMap["systemSeatIds"].([]interface{})
+	if !ok {
+		return false
+	}
+	for _, seat := range seats {
+		if id, ok := seat.(float64); ok && int(id) == seatID {
+			return true
+		}
+	}
+	return false
+}
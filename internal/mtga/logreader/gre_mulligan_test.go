@@ -0,0 +1,97 @@
+package logreader
+
+import "testing"
+
+func mulliganReq(seat, count int) map[string]interface{} {
+	return map[string]interface{}{
+		"type":          "GREMessageType_MulliganReq",
+		"systemSeatIds": []interface{}{float64(seat)},
+		"mulliganReq":   map[string]interface{}{"mulliganCount": float64(count), "mulliganType": "MulliganType_London"},
+	}
+}
+
+func handState(gameNumber, active int, objects ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "GREMessageType_GameStateMessage",
+		"gameStateMessage": map[string]interface{}{
+			"type":        "GameStateType_Full",
+			"gameInfo":    map[string]interface{}{"matchID": "match-1", "gameNumber": float64(gameNumber)},
+			"turnInfo":    map[string]interface{}{"activePlayer": float64(active), "decisionPlayer": float64(1)},
+			"gameObjects": objects,
+		},
+	}
+}
+
+func TestExtractOpeningHands(t *testing.T) {
+	entries := []*LogEntry{
+		// Game 1: we are on the draw, mulligan a seven and keep the six
+		timedEntry(0, handState(1, 2,
+			gameObject(10, 500, 1, 31, false),
+			gameObject(11, 501, 1, 31, false),
+			gameObject(12, 600, 2, 31, false), // Opponent's card
+		), mulliganReq(1, 0)),
+		timedEntry(1000, mulliganReq(2, 0)), // The opponent's prompt
+		timedEntry(2000, handState(1, 2,
+			gameObject(13, 502, 1, 31, false),
+			gameObject(14, 503, 1, 31, false),
+		), mulliganReq(1, 1)),
+		timedEntry(2500, mulliganReq(1, 1)), // Repeated prompt
+		timedEntry(3000, map[string]interface{}{
+			"type": "GREMessageType_GameStateMessage",
+			"gameStateMessage": map[string]interface{}{
+				"type":     "GameStateType_Diff",
+				"turnInfo": turnInfo(1, "Phase_Beginning", "Step_Upkeep", 2),
+			},
+		}),
+		// Game 2: we play first and the hand is still waiting on us
+		timedEntry(60000, handState(2, 1, gameObject(20, 700, 1, 31, false)), mulliganReq(1, 0)),
+	}
+
+	hands, err := ExtractOpeningHands(entries, &GREConnection{SeatID: 1})
+	if err != nil {
+		t.Fatalf("ExtractOpeningHands: %v", err)
+	}
+	if len(hands) != 3 {
+		t.Fatalf("got %d hands, want 3", len(hands))
+	}
+
+	first, second, third := hands[0], hands[1], hands[2]
+	if first.Decision != MulliganDecisionMulligan || first.MulliganCount != 0 || len(first.CardIDs) != 2 || first.CardIDs[0] != 500 {
+		t.Errorf("unexpected first hand %+v", first)
+	}
+	// The full state for the new hand replaces the first one
+	if second.Decision != MulliganDecisionKeep || second.MulliganCount != 1 || len(second.CardIDs) != 2 || second.CardIDs[0] != 502 {
+		t.Errorf("unexpected second hand %+v", second)
+	}
+	if first.OnPlay == nil || *first.OnPlay || second.OnPlay == nil || *second.OnPlay {
+		t.Errorf("game 1 hands should be on the draw: %v, %v", first.OnPlay, second.OnPlay)
+	}
+	if third.GameNumber != 2 || third.Decision != "" || third.OnPlay == nil || !*third.OnPlay {
+		t.Errorf("unexpected third hand %+v", third)
+	}
+}
+
+func TestExtractOpeningHands_MulliganResponse(t *testing.T) {
+	response := &LogEntry{
+		IsJSON: true,
+		JSON: map[string]interface{}{
+			"clientToMatchServiceMessageType": "ClientToMatchServiceMessageType_ClientToGREMessage",
+			"payload": map[string]interface{}{
+				"type":         "ClientMessageType_MulliganResp",
+				"mulliganResp": map[string]interface{}{"decision": "MulliganOption_AcceptHand"},
+			},
+		},
+	}
+	entries := []*LogEntry{
+		timedEntry(0, handState(1, 1, gameObject(10, 500, 1, 31, false)), mulliganReq(1, 0)),
+		response,
+	}
+
+	hands, err := ExtractOpeningHands(entries, &GREConnection{SeatID: 1})
+	if err != nil {
+		t.Fatalf("ExtractOpeningHands: %v", err)
+	}
+	if len(hands) != 1 || hands[0].Decision != MulliganDecisionKeep {
+		t.Errorf("hand should be kept from our response: %+v", hands)
+	}
+}
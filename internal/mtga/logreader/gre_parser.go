@@ -2,18 +2,23 @@ package logreader
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
 // GREGameStateMessage represents a parsed game state message from the GRE.
 type GREGameStateMessage struct {
-	MatchID       string
-	GameNumber    int
-	TurnInfo      *GRETurnInfo
-	Players       []GREPlayerState
-	GameObjects   []GREGameObject
-	PrevGameState *GREGameStateMessage // For comparing state changes
-	Timestamp     time.Time
+	MatchID            string
+	GameNumber         int
+	StateType          string // "GameStateType_Full" or "GameStateType_Diff"
+	DeletedInstanceIDs []int  // Objects removed from the game state by a diff
+	TurnInfo           *GRETurnInfo
+	Players            []GREPlayerState
+	GameObjects        []GREGameObject
+	Zones              []GREZone // Zones reported by this message; diffs only carry changed zones
+	Annotations        []GREAnnotation
+	PrevGameState      *GREGameStateMessage // For comparing state changes
+	Timestamp          time.Time
 }
 
 // GRETurnInfo contains information about the current turn.
@@ -64,6 +69,42 @@ type GREGameObject struct {
 	Abilities            []int
 }
 
+// GREZone is one zone of the game. Zone IDs are assigned per game, so an
+// object's zone can only be named from the zones the game reported.
+type GREZone struct {
+	ZoneID      int
+	Name        string // "battlefield", "hand", "library", etc.
+	OwnerSeatID int    // Zero for shared zones such as the battlefield
+}
+
+// GREAnnotation describes an event the GRE reports alongside a state change,
+// such as damage being dealt or a life total changing.
+type GREAnnotation struct {
+	ID          int
+	AffectorID  int      // Instance or seat ID that caused the event
+	AffectedIDs []int    // Instance or seat IDs the event happened to
+	Types       []string // "AnnotationType_DamageDealt", "AnnotationType_ModifiedLife", etc.
+	Details     map[string][]int
+}
+
+// HasType reports whether the annotation is of the given type.
+func (a GREAnnotation) HasType(annotationType string) bool {
+	for _, t := range a.Types {
+		if t == annotationType {
+			return true
+		}
+	}
+	return false
+}
+
+// Detail returns the first integer value of a detail, or zero.
+func (a GREAnnotation) Detail(key string) int {
+	if values := a.Details[key]; len(values) > 0 {
+		return values[0]
+	}
+	return 0
+}
+
 // GamePlayEvent represents a detected game play/action.
 type GamePlayEvent struct {
 	MatchID        string
@@ -173,8 +214,11 @@ func GetPlayerSeatID(entries []*LogEntry) *GREConnection {
 }
 
 // ParseGREMessages extracts game state messages from log entries.
+// Objects are named from the zones reported by earlier messages, falling back
+// to a guess from the zone ID for zones not seen yet.
 func ParseGREMessages(entries []*LogEntry) ([]*GREGameStateMessage, error) {
 	var messages []*GREGameStateMessage
+	zoneNames := make(map[int]string)
 
 	for _, entry := range entries {
 		if !entry.IsJSON {
@@ -215,6 +259,14 @@ func ParseGREMessages(entries []*LogEntry) ([]*GREGameStateMessage, error) {
 
 				msg := parseGameStateMessage(msgMap, entryTime)
 				if msg != nil {
+					for _, zone := range msg.Zones {
+						zoneNames[zone.ZoneID] = zone.Name
+					}
+					for i, obj := range msg.GameObjects {
+						if name, ok := zoneNames[obj.ZoneID]; ok {
+							msg.GameObjects[i].ZoneName = name
+						}
+					}
 					messages = append(messages, msg)
 				}
 			}
@@ -236,6 +288,17 @@ func parseGameStateMessage(msgMap map[string]interface{}, timestamp time.Time) *
 		return nil
 	}
 
+	if stateType, ok := gameStateMsg["type"].(string); ok {
+		msg.StateType = stateType
+	}
+	if deleted, ok := gameStateMsg["diffDeletedInstanceIds"].([]interface{}); ok {
+		for _, id := range deleted {
+			if instanceID, ok := id.(float64); ok {
+				msg.DeletedInstanceIDs = append(msg.DeletedInstanceIDs, int(instanceID))
+			}
+		}
+	}
+
 	// Parse turn info
 	if turnInfo, ok := gameStateMsg["turnInfo"].(map[string]interface{}); ok {
 		msg.TurnInfo = parseTurnInfo(turnInfo)
@@ -265,6 +328,30 @@ func parseGameStateMessage(msgMap map[string]interface{}, timestamp time.Time) *
 		}
 	}
 
+	// Parse zones
+	if zones, ok := gameStateMsg["zones"].([]interface{}); ok {
+		for _, zoneData := range zones {
+			zoneMap, ok := zoneData.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if zone, ok := parseZone(zoneMap); ok {
+				msg.Zones = append(msg.Zones, zone)
+			}
+		}
+	}
+
+	// Parse annotations
+	if annotations, ok := gameStateMsg["annotations"].([]interface{}); ok {
+		for _, annotationData := range annotations {
+			annotationMap, ok := annotationData.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			msg.Annotations = append(msg.Annotations, parseAnnotation(annotationMap))
+		}
+	}
+
 	// Get game info if available
 	if gameInfo, ok := gameStateMsg["gameInfo"].(map[string]interface{}); ok {
 		if matchID, ok := gameInfo["matchID"].(string); ok {
@@ -278,6 +365,56 @@ func parseGameStateMessage(msgMap map[string]interface{}, timestamp time.Time) *
 	return msg
 }
 
+// parseAnnotation parses an annotation from the game state.
+func parseAnnotation(annotationMap map[string]interface{}) GREAnnotation {
+	annotation := GREAnnotation{
+		Details: make(map[string][]int),
+	}
+
+	if id, ok := annotationMap["id"].(float64); ok {
+		annotation.ID = int(id)
+	}
+	if affectorID, ok := annotationMap["affectorId"].(float64); ok {
+		annotation.AffectorID = int(affectorID)
+	}
+	if affected, ok := annotationMap["affectedIds"].([]interface{}); ok {
+		for _, id := range affected {
+			if affectedID, ok := id.(float64); ok {
+				annotation.AffectedIDs = append(annotation.AffectedIDs, int(affectedID))
+			}
+		}
+	}
+	if types, ok := annotationMap["type"].([]interface{}); ok {
+		for _, t := range types {
+			if typeStr, ok := t.(string); ok {
+				annotation.Types = append(annotation.Types, typeStr)
+			}
+		}
+	}
+
+	// Details are key/value pairs; only integer values are kept
+	if details, ok := annotationMap["details"].([]interface{}); ok {
+		for _, detailData := range details {
+			detailMap, ok := detailData.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			key, _ := detailMap["key"].(string)
+			values, ok := detailMap["valueInt32"].([]interface{})
+			if key == "" || !ok {
+				continue
+			}
+			for _, v := range values {
+				if value, ok := v.(float64); ok {
+					annotation.Details[key] = append(annotation.Details[key], int(value))
+				}
+			}
+		}
+	}
+
+	return annotation
+}
+
 // parseTurnInfo parses turn information from the game state.
 func parseTurnInfo(turnInfo map[string]interface{}) *GRETurnInfo {
 	ti := &GRETurnInfo{}
@@ -410,7 +547,28 @@ func parseGameObject(objMap map[string]interface{}) GREGameObject {
 	return obj
 }
 
-// zoneIDToName maps zone IDs to readable zone names.
+// parseZone parses a zone from the game state, reporting false when it has
+// no ID or type.
+func parseZone(zoneMap map[string]interface{}) (GREZone, bool) {
+	zoneID, ok := zoneMap["zoneId"].(float64)
+	if !ok {
+		return GREZone{}, false
+	}
+	zoneType, _ := zoneMap["type"].(string)
+	name := strings.ToLower(strings.TrimPrefix(zoneType, "ZoneType_"))
+	if name == "" {
+		return GREZone{}, false
+	}
+
+	zone := GREZone{ZoneID: int(zoneID), Name: name}
+	if ownerSeatID, ok := zoneMap["ownerSeatId"].(float64); ok {
+		zone.OwnerSeatID = int(ownerSeatID)
+	}
+	return zone, true
+}
+
+// zoneIDToName guesses a zone name from its ID for zones the game has not
+// reported yet.
 // Zone IDs in MTGA are player-specific (different IDs for each player's zones).
 func zoneIDToName(zoneID int) string {
 	// These mappings are based on typical MTGA zone IDs
@@ -816,11 +974,20 @@ func ExtractGameSnapshots(entries []*LogEntry, playerConn *GREConnection) ([]*Ga
 			if obj.ZoneName == "hand" {
 				if isPlayer {
 					playerCardsInHand++
+					snapshot.PlayerHand = append(snapshot.PlayerHand, obj)
 				} else {
 					opponentCardsInHand++
 				}
 			}
 
+			if obj.ZoneName == "battlefield" {
+				if isPlayer {
+					snapshot.PlayerPermanents = append(snapshot.PlayerPermanents, obj)
+				} else {
+					snapshot.OpponentPermanents = append(snapshot.OpponentPermanents, obj)
+				}
+			}
+
 			if obj.ZoneName == "battlefield" {
 				for _, cardType := range obj.CardTypes {
 					if cardType == "CardType_Land" {
@@ -859,6 +1026,9 @@ type GameSnapshot struct {
 	PlayerLandsInPlay   int
 	OpponentLandsInPlay int
 	BoardStateJSON      string
+	PlayerPermanents    []GREGameObject // Battlefield objects the player controls
+	OpponentPermanents  []GREGameObject
+	PlayerHand          []GREGameObject // The opponent's hand is hidden
 	Timestamp           time.Time
 }
 
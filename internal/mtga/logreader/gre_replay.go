@@ -0,0 +1,133 @@
+package logreader
+
+import "time"
+
+// ReplayStep is the change to a game's state over one phase or step. Steps
+// applied in order rebuild the complete object list at every point in the
+// game, which is what a replay viewer needs.
+type ReplayStep struct {
+	MatchID      string
+	GameNumber   int
+	TurnNumber   int
+	Phase        string
+	Step         string
+	ActivePlayer string // "player" or "opponent"
+	PlayerLife   *int   // Nil when unchanged during the step
+	OpponentLife *int
+	Full         bool            // Objects is the whole game state, not changes to it
+	Objects      []GREGameObject // Objects added or changed during the step
+	Removed      []int           // Instance IDs that left the game state
+	Zones        map[int]string  // Names of zones reported during the step, by zone ID
+	Timestamp    time.Time
+}
+
+// ExtractReplaySteps groups game state messages into one step per phase or
+// step of each game. A full game state message starts a new step that
+// replaces everything before it.
+func ExtractReplaySteps(entries []*LogEntry, playerConn *GREConnection) ([]*ReplayStep, error) {
+	messages, err := ParseGREMessages(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []*ReplayStep
+	var current *ReplayStep
+	var objectIndex map[int]int // Instance ID to index in current.Objects
+
+	var matchID, phase, step, activePlayer string
+	var gameNumber, turnNumber int
+	for _, msg := range messages {
+		// Diffs only carry what changed, so match, game and turn info carry forward
+		if msg.MatchID != "" {
+			matchID = msg.MatchID
+		}
+		if msg.GameNumber != 0 {
+			gameNumber = msg.GameNumber
+		}
+		if msg.TurnInfo != nil {
+			if msg.TurnInfo.TurnNumber > 0 {
+				turnNumber = msg.TurnInfo.TurnNumber
+			}
+			if msg.TurnInfo.Phase != "" {
+				phase = normalizePhase(msg.TurnInfo.Phase)
+				step = normalizeStep(msg.TurnInfo.Step)
+			}
+			if msg.TurnInfo.ActivePlayer != 0 {
+				activePlayer = "opponent"
+				if playerConn != nil && msg.TurnInfo.ActivePlayer == playerConn.SeatID {
+					activePlayer = "player"
+				}
+			}
+		}
+
+		full := msg.StateType == "GameStateType_Full"
+		if current == nil || full || current.MatchID != matchID || current.GameNumber != gameNumber ||
+			current.TurnNumber != turnNumber || current.Phase != phase || current.Step != step {
+			current = &ReplayStep{
+				MatchID:      matchID,
+				GameNumber:   gameNumber,
+				TurnNumber:   turnNumber,
+				Phase:        phase,
+				Step:         step,
+				ActivePlayer: activePlayer,
+				Full:         full,
+			}
+			objectIndex = make(map[int]int)
+			steps = append(steps, current)
+		}
+		current.Timestamp = msg.Timestamp
+
+		for _, player := range msg.Players {
+			life := player.LifeTotal
+			if playerConn != nil && player.SeatID == playerConn.SeatID {
+				current.PlayerLife = &life
+			} else {
+				current.OpponentLife = &life
+			}
+		}
+
+		for _, zone := range msg.Zones {
+			if current.Zones == nil {
+				current.Zones = make(map[int]string)
+			}
+			current.Zones[zone.ZoneID] = zone.Name
+		}
+
+		for _, obj := range msg.GameObjects {
+			if i, ok := objectIndex[obj.InstanceID]; ok {
+				current.Objects[i] = obj
+				continue
+			}
+			objectIndex[obj.InstanceID] = len(current.Objects)
+			current.Objects = append(current.Objects, obj)
+			current.Removed = removeInstance(current.Removed, obj.InstanceID)
+		}
+
+		for _, instanceID := range msg.DeletedInstanceIDs {
+			if i, ok := objectIndex[instanceID]; ok {
+				current.Objects = append(current.Objects[:i], current.Objects[i+1:]...)
+				delete(objectIndex, instanceID)
+				for id, j := range objectIndex {
+					if j > i {
+						objectIndex[id] = j - 1
+					}
+				}
+			}
+			if !current.Full {
+				current.Removed = append(removeInstance(current.Removed, instanceID), instanceID)
+			}
+		}
+	}
+
+	return steps, nil
+}
+
+// removeInstance returns ids without instanceID.
+func removeInstance(ids []int, instanceID int) []int {
+	for i, id := range ids {
+		if id == instanceID {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,112 @@
+package logreader
+
+import "testing"
+
+// gameStateEntry wraps a game state message in a GRE log entry.
+func gameStateEntry(state map[string]interface{}) *LogEntry {
+	return &LogEntry{
+		IsJSON: true,
+		JSON: map[string]interface{}{
+			"greToClientEvent": map[string]interface{}{
+				"greToClientMessages": []interface{}{
+					map[string]interface{}{
+						"type":             "GREMessageType_GameStateMessage",
+						"gameStateMessage": state,
+					},
+				},
+			},
+		},
+	}
+}
+
+func turnInfo(turn int, phase, step string, active int) map[string]interface{} {
+	info := map[string]interface{}{
+		"turnNumber":   float64(turn),
+		"phase":        phase,
+		"activePlayer": float64(active),
+	}
+	if step != "" {
+		info["step"] = step
+	}
+	return info
+}
+
+func gameObject(instanceID, grpID, controller, zoneID int, tapped bool) map[string]interface{} {
+	return map[string]interface{}{
+		"instanceId":       float64(instanceID),
+		"grpId":            float64(grpID),
+		"ownerSeatId":      float64(controller),
+		"controllerSeatId": float64(controller),
+		"zoneId":           float64(zoneID),
+		"isTapped":         tapped,
+	}
+}
+
+func TestExtractReplaySteps(t *testing.T) {
+	entries := []*LogEntry{
+		gameStateEntry(map[string]interface{}{
+			"type":     "GameStateType_Full",
+			"gameInfo": map[string]interface{}{"matchID": "match-1", "gameNumber": float64(1)},
+			"turnInfo": turnInfo(1, "Phase_Main1", "", 1),
+			"players": []interface{}{
+				map[string]interface{}{"seatId": float64(1), "lifeTotal": float64(20)},
+				map[string]interface{}{"seatId": float64(2), "lifeTotal": float64(20)},
+			},
+			"zones": []interface{}{
+				map[string]interface{}{"zoneId": float64(28), "type": "ZoneType_Battlefield"},
+				map[string]interface{}{"zoneId": float64(31), "type": "ZoneType_Hand", "ownerSeatId": float64(1)},
+			},
+			"gameObjects": []interface{}{gameObject(10, 100, 1, 31, false), gameObject(11, 101, 1, 31, false)},
+		}),
+		// Same step: the land moves to the battlefield and a card is drawn
+		gameStateEntry(map[string]interface{}{
+			"type":        "GameStateType_Diff",
+			"turnInfo":    turnInfo(1, "Phase_Main1", "", 1),
+			"gameObjects": []interface{}{gameObject(10, 100, 1, 28, false), gameObject(12, 102, 1, 31, false)},
+		}),
+		// Combat: the land taps and the drawn card is deleted
+		gameStateEntry(map[string]interface{}{
+			"type":                   "GameStateType_Diff",
+			"turnInfo":               turnInfo(1, "Phase_Combat", "Step_DeclareAttack", 1),
+			"gameObjects":            []interface{}{gameObject(10, 100, 1, 28, true)},
+			"diffDeletedInstanceIds": []interface{}{float64(12)},
+			"players":                []interface{}{map[string]interface{}{"seatId": float64(2), "lifeTotal": float64(18)}},
+		}),
+	}
+
+	steps, err := ExtractReplaySteps(entries, &GREConnection{SeatID: 1})
+	if err != nil {
+		t.Fatalf("ExtractReplaySteps: %v", err)
+	}
+	if len(steps) != 2 {
+		t.Fatalf("got %d steps, want 2", len(steps))
+	}
+
+	main := steps[0]
+	if !main.Full || main.MatchID != "match-1" || main.GameNumber != 1 || main.Phase != "Main1" || main.ActivePlayer != "player" {
+		t.Errorf("first step = %+v", main)
+	}
+	if len(main.Objects) != 3 || main.Objects[0].ZoneName != "battlefield" {
+		t.Errorf("first step should merge its diff into the full state, got %+v", main.Objects)
+	}
+	if main.Objects[1].ZoneName != "hand" || main.Zones[28] != "battlefield" || main.Zones[31] != "hand" {
+		t.Errorf("zones should be named from the zone list, got objects %+v and zones %v", main.Objects, main.Zones)
+	}
+	if main.PlayerLife == nil || *main.PlayerLife != 20 {
+		t.Errorf("player life = %v", main.PlayerLife)
+	}
+
+	combat := steps[1]
+	if combat.Full || combat.MatchID != "match-1" || combat.Phase != "Combat" || combat.Step != "DeclareAttackers" {
+		t.Errorf("second step = %+v", combat)
+	}
+	if len(combat.Objects) != 1 || !combat.Objects[0].IsTapped || combat.Objects[0].ZoneName != "battlefield" {
+		t.Errorf("combat objects = %+v", combat.Objects)
+	}
+	if len(combat.Removed) != 1 || combat.Removed[0] != 12 {
+		t.Errorf("combat removed = %v", combat.Removed)
+	}
+	if combat.PlayerLife != nil || combat.OpponentLife == nil || *combat.OpponentLife != 18 {
+		t.Errorf("combat life = %v / %v", combat.PlayerLife, combat.OpponentLife)
+	}
+}
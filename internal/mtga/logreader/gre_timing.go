@@ -0,0 +1,415 @@
+package logreader
+
+import (
+	"strconv"
+	"time"
+)
+
+// dotNetEpochTicks is 1970-01-01 in .NET ticks (100ns since 0001-01-01).
+const dotNetEpochTicks = 621355968000000000
+
+// GRETimer is one timer from a GRE timer state message.
+type GRETimer struct {
+	TimerID             int
+	Type                string // "TimerType_ActivePlayer", "TimerType_Inactivity", "TimerType_MatchClock", etc.
+	DurationSec         int
+	ElapsedSec          int
+	ElapsedMs           int
+	WarningThresholdSec int // Seconds left when the rope appears
+	Running             bool
+	Behavior            string
+}
+
+// ropeAfter returns how long the timer runs before the rope appears, or zero
+// if the timer has no warning.
+func (t GRETimer) ropeAfter() time.Duration {
+	if t.DurationSec <= 0 || t.WarningThresholdSec <= 0 || t.WarningThresholdSec >= t.DurationSec {
+		return 0
+	}
+	return time.Duration(t.DurationSec-t.WarningThresholdSec) * time.Second
+}
+
+// elapsed returns the time the timer has run.
+func (t GRETimer) elapsed() time.Duration {
+	if t.ElapsedMs > 0 {
+		return time.Duration(t.ElapsedMs) * time.Millisecond
+	}
+	return time.Duration(t.ElapsedSec) * time.Second
+}
+
+// TurnTiming is when one turn of a game started and the last activity seen in it.
+type TurnTiming struct {
+	MatchID      string
+	GameNumber   int
+	TurnNumber   int
+	ActivePlayer string // "player" or "opponent"
+	StartedAt    time.Time
+	EndedAt      time.Time
+}
+
+// DecisionTiming is how long a player held a decision or priority.
+type DecisionTiming struct {
+	MatchID    string
+	GameNumber int
+	TurnNumber int
+	PlayerType string // "player" or "opponent"
+	Phase      string
+	Step       string
+	StartedAt  time.Time
+	Duration   time.Duration
+}
+
+// Timer event types.
+const (
+	TimerEventRope    = "rope"    // The rope appeared for a player
+	TimerEventTimeout = "timeout" // A player ran out of time
+)
+
+// TimerEvent is a rope or timeout for one player.
+type TimerEvent struct {
+	MatchID    string
+	GameNumber int
+	TurnNumber int
+	PlayerType string
+	EventType  string
+	Elapsed    time.Duration // Time on the decision when the event happened
+	Timestamp  time.Time
+}
+
+// GameTiming holds the turn, decision and timer data extracted from GRE messages.
+type GameTiming struct {
+	Turns       []*TurnTiming
+	Decisions   []*DecisionTiming
+	TimerEvents []*TimerEvent
+}
+
+// ExtractGameTiming measures turn and decision durations from GRE messages.
+// A decision lasts from when a player becomes the decision or priority player
+// until someone else does. The rope is recorded when a decision outlasts the
+// warning threshold of that player's running timer, or when a timer state
+// shows it already has; timeouts come from GRE timeout messages. Entries
+// without a usable timestamp are skipped. Use a TimingTracker to measure
+// games that span several batches of entries.
+func ExtractGameTiming(entries []*LogEntry, playerConn *GREConnection) (*GameTiming, error) {
+	return NewTimingTracker().Extract(entries, playerConn)
+}
+
+// TimingTracker measures turn and decision timing across batches of log
+// entries. The turn, decision and timer state of the game in progress carry
+// from one batch to the next, so a decision split between batches is measured
+// from when it started.
+type TimingTracker struct {
+	matchID, phase, step   string
+	gameNumber, turnNumber int
+	turn                   *TurnTiming
+	decision               *DecisionTiming
+	decisionSeat           int
+	ropeAfter              map[int]time.Duration // Seat ID -> time before the rope
+	roped                  map[string]bool       // Rope or timeout already recorded for a turn
+
+	// Per Extract call
+	timing     *GameTiming
+	playerConn *GREConnection
+	turnSeen   bool // The open turn is already in timing.Turns
+}
+
+// NewTimingTracker creates a tracker with no game in progress.
+func NewTimingTracker() *TimingTracker {
+	return &TimingTracker{
+		ropeAfter: make(map[int]time.Duration),
+		roped:     make(map[string]bool),
+	}
+}
+
+// Extract measures the timing in one batch of entries, continuing the game in
+// progress from earlier batches. Turns are reported with their activity so
+// far, and a decision still open when the batch ends is reported with its
+// duration so far and kept open; a later batch reports it again with the
+// longer duration.
+func (t *TimingTracker) Extract(entries []*LogEntry, playerConn *GREConnection) (*GameTiming, error) {
+	t.timing = &GameTiming{}
+	t.playerConn = playerConn
+	t.turnSeen = false
+	defer func() {
+		t.timing = nil
+		t.playerConn = nil
+	}()
+
+	for _, entry := range entries {
+		if !entry.IsJSON {
+			continue
+		}
+		greEvent, ok := entry.JSON["greToClientEvent"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		greToClientMsgs, ok := greEvent["greToClientMessages"].([]interface{})
+		if !ok {
+			continue
+		}
+		at, ok := greEntryTime(entry)
+		if !ok {
+			continue
+		}
+
+		for _, msgData := range greToClientMsgs {
+			msgMap, ok := msgData.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			switch msgType, _ := msgMap["type"].(string); msgType {
+			case "GREMessageType_TimerStateMessage":
+				state, ok := msgMap["timerStateMessage"].(map[string]interface{})
+				if !ok {
+					continue
+				}
+				seatID := intField(state, "seatId")
+				for _, timer := range parseTimers(state) {
+					if timer.Type != "TimerType_ActivePlayer" && timer.Type != "TimerType_Inactivity" {
+						continue
+					}
+					if limit := timer.ropeAfter(); limit > 0 {
+						t.ropeAfter[seatID] = limit
+						if timer.Running && timer.elapsed() >= limit {
+							t.recordEvent(seatID, TimerEventRope, timer.elapsed(), at)
+						}
+					}
+				}
+
+			case "GREMessageType_TimeoutMessage":
+				seatID := 0
+				if timeout, ok := msgMap["timeoutMessage"].(map[string]interface{}); ok {
+					seatID = intField(timeout, "seatId")
+				}
+				if seatID == 0 {
+					if seats, ok := msgMap["systemSeatIds"].([]interface{}); ok && len(seats) > 0 {
+						if seat, ok := seats[0].(float64); ok {
+							seatID = int(seat)
+						}
+					}
+				}
+				var elapsed time.Duration
+				if t.decision != nil && t.decisionSeat == seatID {
+					elapsed = at.Sub(t.decision.StartedAt)
+				}
+				t.recordEvent(seatID, TimerEventTimeout, elapsed, at)
+
+			case "GREMessageType_GameStateMessage":
+				if msg := parseGameStateMessage(msgMap, at); msg != nil {
+					t.gameState(msg, at)
+				}
+			}
+		}
+	}
+
+	// A decision still open when the entries run out is reported up to the
+	// last activity
+	if t.decision != nil && t.turn != nil {
+		if duration := t.turn.EndedAt.Sub(t.decision.StartedAt); duration > 0 {
+			decision := *t.decision
+			decision.Duration = duration
+			t.timing.Decisions = append(t.timing.Decisions, &decision)
+			t.checkRope(&decision)
+		}
+	}
+
+	// Report copies so later batches do not change what was returned
+	for i, turn := range t.timing.Turns {
+		reported := *turn
+		t.timing.Turns[i] = &reported
+	}
+	return t.timing, nil
+}
+
+// gameState advances the turn and decision from a game state message.
+func (t *TimingTracker) gameState(msg *GREGameStateMessage, at time.Time) {
+	// A new game or match ends whatever was in progress at its last activity
+	if (msg.MatchID != "" && msg.MatchID != t.matchID) || (msg.GameNumber != 0 && msg.GameNumber != t.gameNumber) {
+		if t.turn != nil {
+			t.closeDecision(t.turn.EndedAt)
+		}
+		t.decision = nil
+		t.decisionSeat = 0
+		t.turn = nil
+		t.turnNumber = 0
+		if msg.MatchID != "" && msg.MatchID != t.matchID {
+			t.roped = make(map[string]bool)
+			t.matchID = msg.MatchID
+		}
+		if msg.GameNumber != 0 {
+			t.gameNumber = msg.GameNumber
+		}
+	}
+	if t.matchID == "" || msg.TurnInfo == nil {
+		t.touchTurn(at)
+		return
+	}
+
+	info := msg.TurnInfo
+	if info.Phase != "" {
+		t.phase = normalizePhase(info.Phase)
+		t.step = normalizeStep(info.Step)
+	}
+	if info.TurnNumber > 0 && info.TurnNumber != t.turnNumber {
+		t.closeDecision(at)
+		t.turnNumber = info.TurnNumber
+		t.turn = &TurnTiming{
+			MatchID:    t.matchID,
+			GameNumber: t.gameNumber,
+			TurnNumber: t.turnNumber,
+			StartedAt:  at,
+		}
+		t.turnSeen = false
+		if info.ActivePlayer != 0 {
+			t.turn.ActivePlayer = t.playerType(info.ActivePlayer)
+		}
+	}
+	if t.turn == nil {
+		return
+	}
+	t.touchTurn(at)
+	if t.turn.ActivePlayer == "" && info.ActivePlayer != 0 {
+		t.turn.ActivePlayer = t.playerType(info.ActivePlayer)
+	}
+
+	seatID := info.DecisionPlayer
+	if seatID == 0 {
+		seatID = info.PriorityPlayer
+	}
+	if seatID != 0 && seatID != t.decisionSeat {
+		t.closeDecision(at)
+		t.decisionSeat = seatID
+		t.decision = &DecisionTiming{
+			MatchID:    t.matchID,
+			GameNumber: t.gameNumber,
+			TurnNumber: t.turnNumber,
+			PlayerType: t.playerType(seatID),
+			Phase:      t.phase,
+			Step:       t.step,
+			StartedAt:  at,
+		}
+	}
+}
+
+// touchTurn records activity in the open turn, reporting the turn once per batch.
+func (t *TimingTracker) touchTurn(at time.Time) {
+	if t.turn == nil {
+		return
+	}
+	t.turn.EndedAt = at
+	if !t.turnSeen {
+		t.timing.Turns = append(t.timing.Turns, t.turn)
+		t.turnSeen = true
+	}
+}
+
+// closeDecision ends the open decision at the given time.
+func (t *TimingTracker) closeDecision(at time.Time) {
+	if t.decision == nil {
+		return
+	}
+	t.decision.Duration = at.Sub(t.decision.StartedAt)
+	if t.decision.Duration > 0 {
+		t.timing.Decisions = append(t.timing.Decisions, t.decision)
+		t.checkRope(t.decision)
+	}
+	t.decision = nil
+	t.decisionSeat = 0
+}
+
+// checkRope records the rope for a decision that outlasted its player's
+// warning threshold.
+func (t *TimingTracker) checkRope(decision *DecisionTiming) {
+	if limit := t.ropeAfter[t.decisionSeat]; limit > 0 && decision.Duration >= limit {
+		t.recordEvent(t.decisionSeat, TimerEventRope, decision.Duration, decision.StartedAt.Add(limit))
+	}
+}
+
+// recordEvent records a rope or timeout once per player, turn and type.
+func (t *TimingTracker) recordEvent(seatID int, eventType string, elapsed time.Duration, at time.Time) {
+	key := strconv.Itoa(t.gameNumber) + "|" + strconv.Itoa(t.turnNumber) + "|" + strconv.Itoa(seatID) + "|" + eventType
+	if t.matchID == "" || t.roped[key] {
+		return
+	}
+	t.roped[key] = true
+	t.timing.TimerEvents = append(t.timing.TimerEvents, &TimerEvent{
+		MatchID:    t.matchID,
+		GameNumber: t.gameNumber,
+		TurnNumber: t.turnNumber,
+		PlayerType: t.playerType(seatID),
+		EventType:  eventType,
+		Elapsed:    elapsed,
+		Timestamp:  at,
+	})
+}
+
+// playerType resolves a seat to "player" or "opponent".
+func (t *TimingTracker) playerType(seatID int) string {
+	if t.playerConn != nil && seatID == t.playerConn.SeatID {
+		return "player"
+	}
+	return "opponent"
+}
+
+// parseTimers parses the timers of a timer state message.
+func parseTimers(state map[string]interface{}) []GRETimer {
+	timersData, ok := state["timers"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	timers := make([]GRETimer, 0, len(timersData))
+	for _, timerData := range timersData {
+		timerMap, ok := timerData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		timer := GRETimer{
+			TimerID:             intField(timerMap, "timerId"),
+			DurationSec:         intField(timerMap, "durationSec"),
+			ElapsedSec:          intField(timerMap, "elapsedSec"),
+			ElapsedMs:           intField(timerMap, "elapsedMs"),
+			WarningThresholdSec: intField(timerMap, "warningThresholdSec"),
+		}
+		timer.Type, _ = timerMap["type"].(string)
+		timer.Behavior, _ = timerMap["behavior"].(string)
+		timer.Running, _ = timerMap["running"].(bool)
+		timers = append(timers, timer)
+	}
+	return timers
+}
+
+// intField reads a JSON number field as an int.
+func intField(m map[string]interface{}, key string) int {
+	if v, ok := m[key].(float64); ok {
+		return int(v)
+	}
+	return 0
+}
+
+// greEntryTime returns when a GRE event was sent. The event's own timestamp
+// is milliseconds since the Unix epoch, or .NET ticks in older clients, and
+// is more precise than the log line's, which is used as a fallback.
+func greEntryTime(entry *LogEntry) (time.Time, bool) {
+	var raw int64
+	switch v := entry.JSON["timestamp"].(type) {
+	case string:
+		raw, _ = strconv.ParseInt(v, 10, 64)
+	case float64:
+		raw = int64(v)
+	}
+	if raw > dotNetEpochTicks {
+		return time.UnixMilli((raw - dotNetEpochTicks) / 10000).UTC(), true
+	}
+	if raw > 0 {
+		return time.UnixMilli(raw).UTC(), true
+	}
+
+	if entry.Timestamp != "" {
+		if t, err := parseLogTimestamp(entry.Timestamp); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
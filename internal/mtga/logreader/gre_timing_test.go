@@ -0,0 +1,180 @@
+package logreader
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// timedEntry wraps GRE messages in a log entry sent at ms after the epoch base.
+func timedEntry(ms int64, messages ...map[string]interface{}) *LogEntry {
+	msgs := make([]interface{}, len(messages))
+	for i, m := range messages {
+		msgs[i] = m
+	}
+	return &LogEntry{
+		IsJSON: true,
+		JSON: map[string]interface{}{
+			"timestamp":        strconv.FormatInt(1700000000000+ms, 10),
+			"greToClientEvent": map[string]interface{}{"greToClientMessages": msgs},
+		},
+	}
+}
+
+func timingState(turn, active, decision int, gameInfo bool) map[string]interface{} {
+	info := turnInfo(turn, "Phase_Main1", "", active)
+	info["decisionPlayer"] = float64(decision)
+	state := map[string]interface{}{"type": "GameStateType_Diff", "turnInfo": info}
+	if gameInfo {
+		state["gameInfo"] = map[string]interface{}{"matchID": "match-1", "gameNumber": float64(1)}
+	}
+	return map[string]interface{}{"type": "GREMessageType_GameStateMessage", "gameStateMessage": state}
+}
+
+func TestExtractGameTiming(t *testing.T) {
+	timerState := map[string]interface{}{
+		"type": "GREMessageType_TimerStateMessage",
+		"timerStateMessage": map[string]interface{}{
+			"seatId": float64(2),
+			"timers": []interface{}{
+				map[string]interface{}{
+					"timerId": float64(5), "type": "TimerType_ActivePlayer",
+					"durationSec": float64(60), "warningThresholdSec": float64(20), "running": true,
+				},
+			},
+		},
+	}
+	timeout := map[string]interface{}{
+		"type":          "GREMessageType_TimeoutMessage",
+		"systemSeatIds": []interface{}{float64(2)},
+	}
+
+	entries := []*LogEntry{
+		timedEntry(0, timingState(1, 1, 1, true)),
+		timedEntry(12000, timingState(1, 1, 2, false)), // We decided for 12s
+		timedEntry(15000, timingState(2, 2, 2, false)), // Opponent held 3s, then their turn starts
+		timedEntry(16000, timerState),
+		timedEntry(65000, timingState(2, 2, 1, false)), // Opponent held 50s, past the 40s rope
+		timedEntry(70000, timeout),
+		{IsJSON: true, JSON: map[string]interface{}{"greToClientEvent": map[string]interface{}{}}},
+	}
+
+	timing, err := ExtractGameTiming(entries, &GREConnection{SeatID: 1})
+	if err != nil {
+		t.Fatalf("ExtractGameTiming: %v", err)
+	}
+
+	if len(timing.Turns) != 2 {
+		t.Fatalf("got %d turns, want 2", len(timing.Turns))
+	}
+	first, second := timing.Turns[0], timing.Turns[1]
+	if first.ActivePlayer != "player" || second.ActivePlayer != "opponent" {
+		t.Errorf("active players = %s, %s", first.ActivePlayer, second.ActivePlayer)
+	}
+	if got := first.EndedAt.Sub(first.StartedAt); got != 12*time.Second {
+		t.Errorf("turn 1 activity lasted %v, want 12s", got)
+	}
+	if got := second.StartedAt.Sub(first.StartedAt); got != 15*time.Second {
+		t.Errorf("turn 2 started %v after turn 1, want 15s", got)
+	}
+
+	want := []struct {
+		turn       int
+		playerType string
+		duration   time.Duration
+	}{
+		{1, "player", 12 * time.Second},
+		{1, "opponent", 3 * time.Second},
+		{2, "opponent", 50 * time.Second},
+	}
+	if len(timing.Decisions) != len(want) {
+		t.Fatalf("got %d decisions, want %d", len(timing.Decisions), len(want))
+	}
+	for i, w := range want {
+		d := timing.Decisions[i]
+		if d.TurnNumber != w.turn || d.PlayerType != w.playerType || d.Duration != w.duration {
+			t.Errorf("decision %d = turn %d %s %v, want turn %d %s %v", i, d.TurnNumber, d.PlayerType, d.Duration, w.turn, w.playerType, w.duration)
+		}
+	}
+
+	if len(timing.TimerEvents) != 2 {
+		t.Fatalf("got %d timer events, want 2", len(timing.TimerEvents))
+	}
+	rope, out := timing.TimerEvents[0], timing.TimerEvents[1]
+	if rope.EventType != TimerEventRope || rope.PlayerType != "opponent" || rope.TurnNumber != 2 {
+		t.Errorf("unexpected rope event %+v", rope)
+	}
+	if rope.Timestamp.Sub(second.StartedAt) != 40*time.Second {
+		t.Errorf("rope appeared %v into the decision, want 40s", rope.Timestamp.Sub(second.StartedAt))
+	}
+	if out.EventType != TimerEventTimeout || out.PlayerType != "opponent" {
+		t.Errorf("unexpected timeout event %+v", out)
+	}
+}
+
+func TestTimingTracker_DecisionAcrossBatches(t *testing.T) {
+	tracker := NewTimingTracker()
+	conn := &GREConnection{SeatID: 1}
+
+	// Our decision starts in the first batch and ends in the second
+	first, err := tracker.Extract([]*LogEntry{
+		timedEntry(0, timingState(1, 1, 2, true)),
+		timedEntry(4000, timingState(1, 1, 1, false)),
+		timedEntry(9000, timingState(1, 1, 1, false)),
+	}, conn)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	second, err := tracker.Extract([]*LogEntry{
+		timedEntry(30000, timingState(1, 1, 2, false)),
+	}, conn)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+
+	// The first batch reports the open decision as it stood
+	if len(first.Decisions) != 2 {
+		t.Fatalf("first batch: got %d decisions, want 2", len(first.Decisions))
+	}
+	if d := first.Decisions[1]; d.PlayerType != "player" || d.Duration != 5*time.Second {
+		t.Errorf("first batch open decision = %s %v, want player 5s", d.PlayerType, d.Duration)
+	}
+
+	// The second measures it from when it started, not from the batch boundary
+	if len(second.Decisions) != 1 {
+		t.Fatalf("second batch: got %d decisions, want 1", len(second.Decisions))
+	}
+	d := second.Decisions[0]
+	if d.PlayerType != "player" || d.Duration != 26*time.Second || !d.StartedAt.Equal(first.Decisions[1].StartedAt) {
+		t.Errorf("second batch decision = %s %v from %v, want player 26s from %v", d.PlayerType, d.Duration, d.StartedAt, first.Decisions[1].StartedAt)
+	}
+	if d.MatchID != "match-1" || d.TurnNumber != 1 {
+		t.Errorf("decision should keep the match and turn, got %+v", d)
+	}
+
+	// The turn carries over and is reported with its later activity
+	if len(second.Turns) != 1 || second.Turns[0].TurnNumber != 1 || second.Turns[0].EndedAt.Sub(second.Turns[0].StartedAt) != 30*time.Second {
+		t.Errorf("second batch turns = %+v", second.Turns)
+	}
+	if got := first.Turns[0].EndedAt.Sub(first.Turns[0].StartedAt); got != 9*time.Second {
+		t.Errorf("first batch turn should not change after it was returned, lasted %v", got)
+	}
+}
+
+func TestGREEntryTime(t *testing.T) {
+	ms := &LogEntry{IsJSON: true, JSON: map[string]interface{}{"timestamp": "1700000000123"}}
+	if got, ok := greEntryTime(ms); !ok || got.UnixMilli() != 1700000000123 {
+		t.Errorf("millisecond timestamp = %v, %v", got, ok)
+	}
+
+	ticks := &LogEntry{IsJSON: true, JSON: map[string]interface{}{
+		"timestamp": strconv.FormatInt(dotNetEpochTicks+1700000000123*10000, 10),
+	}}
+	if got, ok := greEntryTime(ticks); !ok || got.UnixMilli() != 1700000000123 {
+		t.Errorf(".NET ticks timestamp = %v, %v", got, ok)
+	}
+
+	if _, ok := greEntryTime(&LogEntry{IsJSON: true, JSON: map[string]interface{}{}}); ok {
+		t.Error("expected no time without a timestamp")
+	}
+}
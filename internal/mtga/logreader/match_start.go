@@ -0,0 +1,103 @@
+package logreader
+
+import (
+	"time"
+)
+
+// MatchStart describes a match that has just begun, before any result is known.
+type MatchStart struct {
+	MatchID      string
+	EventID      string
+	OpponentID   string // Opponent's Arena user ID
+	OpponentName string
+	Timestamp    time.Time
+}
+
+// ParseMatchStarts finds matches entering the playing state and identifies the
+// opponent among the reserved players by excluding the player's own screen name.
+// Matches that also finish within entries are skipped. Without a screen name the
+// opponent cannot be told apart, so nothing is returned.
+func ParseMatchStarts(entries []*LogEntry, playerScreenName string) []*MatchStart {
+	if playerScreenName == "" {
+		return nil
+	}
+
+	var starts []*MatchStart
+	seen := make(map[string]bool)
+	finished := make(map[string]bool)
+
+	for _, entry := range entries {
+		if !entry.IsJSON {
+			continue
+		}
+
+		eventMap, ok := entry.JSON["matchGameRoomStateChangedEvent"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		gameRoomInfo, ok := eventMap["gameRoomInfo"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if finalMatchResult, ok := gameRoomInfo["finalMatchResult"].(map[string]interface{}); ok {
+			if matchID, _ := finalMatchResult["matchId"].(string); matchID != "" {
+				finished[matchID] = true
+			}
+			continue
+		}
+		if stateType, _ := gameRoomInfo["stateType"].(string); stateType != "MatchGameRoomStateType_Playing" {
+			continue
+		}
+		gameRoomConfig, ok := gameRoomInfo["gameRoomConfig"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		matchID, _ := gameRoomConfig["matchId"].(string)
+		if matchID == "" || seen[matchID] {
+			continue
+		}
+		reservedPlayers, ok := gameRoomConfig["reservedPlayers"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		start := &MatchStart{MatchID: matchID, Timestamp: time.Now()}
+		if entry.Timestamp != "" {
+			if t, err := parseLogTimestamp(entry.Timestamp); err == nil {
+				start.Timestamp = t
+			}
+		}
+
+		for _, playerData := range reservedPlayers {
+			player, ok := playerData.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if eventID, ok := player["eventId"].(string); ok && eventID != "" {
+				start.EventID = eventID
+			}
+
+			name, _ := player["playerName"].(string)
+			if name == "" || name == playerScreenName {
+				continue
+			}
+			start.OpponentName = name
+			start.OpponentID, _ = player["userId"].(string)
+		}
+
+		if start.OpponentID == "" {
+			continue
+		}
+		seen[matchID] = true
+		starts = append(starts, start)
+	}
+
+	inProgress := starts[:0]
+	for _, start := range starts {
+		if !finished[start.MatchID] {
+			inProgress = append(inProgress, start)
+		}
+	}
+	return inProgress
+}
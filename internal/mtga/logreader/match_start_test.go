@@ -0,0 +1,55 @@
+package logreader
+
+import "testing"
+
+func matchRoomEntry(matchID, stateType string, players ...map[string]interface{}) *LogEntry {
+	reserved := make([]interface{}, len(players))
+	for i, p := range players {
+		reserved[i] = p
+	}
+	return &LogEntry{
+		IsJSON: true,
+		JSON: map[string]interface{}{
+			"matchGameRoomStateChangedEvent": map[string]interface{}{
+				"gameRoomInfo": map[string]interface{}{
+					"stateType": stateType,
+					"gameRoomConfig": map[string]interface{}{
+						"matchId":         matchID,
+						"reservedPlayers": reserved,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestParseMatchStarts(t *testing.T) {
+	me := map[string]interface{}{"playerName": "Me#1234", "userId": "ME", "eventId": "Traditional_Ladder"}
+	them := map[string]interface{}{"playerName": "Rival#5678", "userId": "RIVAL", "eventId": "Traditional_Ladder"}
+
+	finished := matchRoomEntry("match-2", "MatchGameRoomStateType_MatchCompleted", me, them)
+	finished.JSON["matchGameRoomStateChangedEvent"].(map[string]interface{})["gameRoomInfo"].(map[string]interface{})["finalMatchResult"] = map[string]interface{}{
+		"matchId": "match-2",
+	}
+
+	entries := []*LogEntry{
+		matchRoomEntry("match-1", "MatchGameRoomStateType_Playing", me, them),
+		matchRoomEntry("match-1", "MatchGameRoomStateType_Playing", me, them), // Repeated state change
+		matchRoomEntry("match-2", "MatchGameRoomStateType_Playing", me, them),
+		finished,
+		matchRoomEntry("match-3", "MatchGameRoomStateType_Playing", me), // Opponent not known yet
+	}
+
+	starts := ParseMatchStarts(entries, "Me#1234")
+	if len(starts) != 1 {
+		t.Fatalf("got %d starts, want 1", len(starts))
+	}
+	start := starts[0]
+	if start.MatchID != "match-1" || start.OpponentID != "RIVAL" || start.OpponentName != "Rival#5678" || start.EventID != "Traditional_Ladder" {
+		t.Errorf("unexpected match start: %+v", start)
+	}
+
+	if starts := ParseMatchStarts(entries, ""); starts != nil {
+		t.Errorf("without a screen name got %+v", starts)
+	}
+}
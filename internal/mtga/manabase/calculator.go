@@ -0,0 +1,221 @@
+// Package manabase checks whether a deck's lands support its spells, in the
+// style of Frank Karsten's mana base tables: for each spell, the chance of
+// having its colored mana by the turn it should be cast.
+package manabase
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/castability"
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
+)
+
+// Karsten's consistency target: cast on curve 90% of the time, on the play,
+// in games where the land drops were hit.
+const DefaultTarget = 0.90
+
+const openingHand = 7
+
+// Card is a deck entry to analyze.
+type Card struct {
+	Name       string
+	Quantity   int
+	ManaCost   string
+	CMC        int
+	TypeLine   string
+	OracleText string
+}
+
+// Options configures an analysis.
+type Options struct {
+	Target      float64 // Consistency a spell needs to count as supported; DefaultTarget when zero
+	Simulations int     // Opening hands to simulate; none when zero
+	Seed        int64   // Seed for the opening hand simulator
+}
+
+// Castability is how reliably the lands cast one spell.
+type Castability struct {
+	Name            string         `json:"name"`
+	Quantity        int            `json:"quantity"`
+	ManaCost        string         `json:"manaCost"`
+	CMC             int            `json:"cmc"`
+	Pips            map[string]int `json:"pips"`
+	OnPlay          float64        `json:"onPlay"`          // Chance to have the mana by turn CMC on the play
+	OnDraw          float64        `json:"onDraw"`          // Chance to have the mana by turn CMC on the draw
+	Consistency     float64        `json:"consistency"`     // On-play chance to have the colors when the land drops were hit
+	RequiredSources map[string]int `json:"requiredSources"` // Sources of each color needed to reach the target
+	Supported       bool           `json:"supported"`
+}
+
+// Report is a mana base analysis.
+type Report struct {
+	DeckSize           int            `json:"deckSize"`
+	LandCount          int            `json:"landCount"`
+	RecommendedLands   int            `json:"recommendedLands"`
+	AverageCMC         float64        `json:"averageCMC"` // Of nonland cards
+	Sources            map[string]int `json:"sources"`    // Lands that can produce or fetch each color
+	RecommendedSources map[string]int `json:"recommendedSources"`
+	EntersTapped       int            `json:"entersTapped"`
+	Target             float64        `json:"target"`
+	Cards              []Castability  `json:"cards"`
+	UnderSupported     []string       `json:"underSupported"`
+	OpeningHands       *HandStats     `json:"openingHands,omitempty"`
+}
+
+// Analyze computes castability for every spell in the deck and how many
+// sources of each color the spells call for.
+func Analyze(cards []Card, opts Options) *Report {
+	if opts.Target <= 0 {
+		opts.Target = DefaultTarget
+	}
+	report := &Report{
+		Sources:            make(map[string]int),
+		RecommendedSources: make(map[string]int),
+		Target:             opts.Target,
+		Cards:              []Castability{},
+		UnderSupported:     []string{},
+	}
+
+	// Lands first, so fetches for "a basic land" know which basics there are
+	var sources []LandSource
+	var quantities []int
+	basics := 0
+	var spells []Card
+	totalCMC := 0
+	for _, card := range cards {
+		if card.Quantity <= 0 {
+			continue
+		}
+		report.DeckSize += card.Quantity
+		if !castability.IsLand(card.TypeLine) {
+			spells = append(spells, card)
+			totalCMC += card.CMC * card.Quantity
+			continue
+		}
+		source := ParseLand(card.Name, card.TypeLine, card.OracleText)
+		sources = append(sources, source)
+		quantities = append(quantities, card.Quantity)
+		report.LandCount += card.Quantity
+		if source.EntersTapped {
+			report.EntersTapped += card.Quantity
+		}
+		if strings.Contains(strings.ToLower(card.TypeLine), "basic") {
+			basics |= castability.Mask(source.Colors)
+		}
+	}
+
+	masks := make(map[int]int)
+	for i, source := range sources {
+		mask := castability.Mask(source.Colors)
+		if source.AnyBasic {
+			mask |= basics
+		}
+		masks[mask] += quantities[i]
+		for bit, color := range Colors {
+			if mask&(1<<bit) != 0 {
+				report.Sources[color] += quantities[i]
+			}
+		}
+	}
+	lands := make([]castability.Group, 0, len(masks))
+	for mask, count := range masks {
+		lands = append(lands, castability.Group{Mask: mask, Count: count})
+	}
+	sort.Slice(lands, func(i, j int) bool { return lands[i].Mask < lands[j].Mask })
+
+	nonlands := report.DeckSize - report.LandCount
+	if nonlands > 0 {
+		report.AverageCMC = stats.Round(float64(totalCMC)/float64(nonlands), 2)
+	}
+	report.RecommendedLands = RecommendedLands(report.DeckSize, report.AverageCMC)
+
+	for _, card := range spells {
+		if card.CMC <= 0 && card.ManaCost == "" {
+			continue
+		}
+		cost := ParseManaCost(card.ManaCost, card.CMC)
+		pips := cost.resolve(report.Sources)
+		c := Castability{
+			Name:            card.Name,
+			Quantity:        card.Quantity,
+			ManaCost:        card.ManaCost,
+			CMC:             card.CMC,
+			Pips:            pips,
+			RequiredSources: make(map[string]int),
+		}
+		turn := max(card.CMC, 1)
+		c.OnPlay = stats.Round(castability.Probability(lands, report.DeckSize, turn, card.CMC, pips, false), 3)
+		c.OnDraw = stats.Round(castability.Probability(lands, report.DeckSize, turn, card.CMC, pips, true), 3)
+		if enough := castability.LandProbability(report.LandCount, report.DeckSize, turn, card.CMC, false); enough > 0 {
+			c.Consistency = stats.Round(math.Min(1, castability.Probability(lands, report.DeckSize, turn, card.CMC, pips, false)/enough), 3)
+		}
+		for color, n := range pips {
+			required := RequiredSources(report.DeckSize, report.LandCount, turn, card.CMC, n, opts.Target)
+			c.RequiredSources[color] = required
+			if required > report.RecommendedSources[color] {
+				report.RecommendedSources[color] = required
+			}
+		}
+		c.Supported = c.Consistency >= opts.Target
+		if !c.Supported {
+			report.UnderSupported = append(report.UnderSupported, c.Name)
+		}
+		report.Cards = append(report.Cards, c)
+	}
+	sort.SliceStable(report.Cards, func(i, j int) bool {
+		if report.Cards[i].Consistency != report.Cards[j].Consistency {
+			return report.Cards[i].Consistency < report.Cards[j].Consistency
+		}
+		return report.Cards[i].Name < report.Cards[j].Name
+	})
+
+	if opts.Simulations > 0 && report.DeckSize >= openingHand {
+		report.OpeningHands = simulateOpeningHands(lands, report.DeckSize, spellColors(report.Cards), opts.Simulations, opts.Seed)
+	}
+	return report
+}
+
+// RecommendedLands is Karsten's land count for a deck's average mana value:
+// 19.59 + 1.90 x average for 60 cards, scaled to the deck size, and
+// 31.42 + 3.13 x average for 100-card singleton decks.
+func RecommendedLands(deckSize int, averageCMC float64) int {
+	if deckSize <= 0 {
+		return 0
+	}
+	if deckSize >= 99 {
+		return int(math.Round(31.42 + 3.13*averageCMC))
+	}
+	return int(math.Round((19.59 + 1.90*averageCMC) * float64(deckSize) / 60))
+}
+
+// RequiredSources is the fewest sources of one color that give a spell
+// needing pips of it the target chance to have them by turn, on the play,
+// in games where the land drops were hit.
+func RequiredSources(deckSize, lands, turn, cmc, pips int, target float64) int {
+	enough := castability.LandProbability(lands, deckSize, turn, cmc, false)
+	if enough == 0 {
+		return lands
+	}
+	for sources := pips; sources <= lands; sources++ {
+		group := []castability.Group{{Mask: 1, Count: sources}, {Mask: 0, Count: lands - sources}}
+		if castability.Probability(group, deckSize, turn, cmc, map[string]int{Colors[0]: pips}, false)/enough >= target {
+			return sources
+		}
+	}
+	return lands
+}
+
+// spellColors returns the colors the spells need, as a bit mask.
+func spellColors(cards []Castability) int {
+	mask := 0
+	for _, c := range cards {
+		for bit, color := range Colors {
+			if c.Pips[color] > 0 {
+				mask |= 1 << bit
+			}
+		}
+	}
+	return mask
+}
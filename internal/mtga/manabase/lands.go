@@ -0,0 +1,151 @@
+package manabase
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/castability"
+)
+
+// Colors is the canonical WUBRG order.
+var Colors = castability.Colors
+
+var basicTypes = map[string]string{
+	"plains":   "W",
+	"island":   "U",
+	"swamp":    "B",
+	"mountain": "R",
+	"forest":   "G",
+}
+
+var (
+	manaSymbol   = regexp.MustCompile(`\{([WUBRG])\}`)
+	addSentence  = regexp.MustCompile(`(?i)\badd [^.]*`)
+	fetchPattern = regexp.MustCompile(`(?i)search your library for ([^.]*?) card`)
+)
+
+// LandSource is what a land can tap for.
+type LandSource struct {
+	Colors       []string `json:"colors"`                 // Colors the land can produce or fetch
+	Fetch        bool     `json:"fetch,omitempty"`        // Sacrifices to find another land
+	AnyBasic     bool     `json:"anyBasic,omitempty"`     // Fetches any basic land, resolved against the deck's basics
+	EntersTapped bool     `json:"entersTapped,omitempty"` // Enters the battlefield tapped
+}
+
+// ParseLand reads a land's mana abilities from its name, type line and
+// oracle text. Basic land types count as mana abilities, so basics and typed
+// duals need no text.
+func ParseLand(name, typeLine, oracleText string) LandSource {
+	var source LandSource
+	colors := make(map[string]bool)
+
+	lowerType := strings.ToLower(typeLine)
+	for landType, color := range basicTypes {
+		if strings.Contains(lowerType, landType) {
+			colors[color] = true
+		}
+	}
+	// Basics listed without a full type line
+	if color, ok := basicTypes[strings.ToLower(strings.TrimSpace(name))]; ok {
+		colors[color] = true
+	}
+
+	text := strings.ReplaceAll(oracleText, "\n", ". ")
+	lowerText := strings.ToLower(text)
+	for _, sentence := range addSentence.FindAllString(text, -1) {
+		lower := strings.ToLower(sentence)
+		if strings.Contains(lower, "any color") || strings.Contains(lower, "any type") {
+			for _, color := range Colors {
+				colors[color] = true
+			}
+		}
+		for _, match := range manaSymbol.FindAllStringSubmatch(sentence, -1) {
+			colors[match[1]] = true
+		}
+	}
+
+	if match := fetchPattern.FindStringSubmatch(text); match != nil {
+		target := strings.ToLower(match[1])
+		found := false
+		for landType, color := range basicTypes {
+			if strings.Contains(target, landType) {
+				colors[color] = true
+				found = true
+			}
+		}
+		if !found && strings.Contains(target, "basic land") {
+			source.AnyBasic = true
+		}
+		source.Fetch = found || source.AnyBasic
+	}
+
+	source.EntersTapped = strings.Contains(lowerText, "enters the battlefield tapped") ||
+		strings.Contains(lowerText, "enters tapped")
+
+	for _, color := range Colors {
+		if colors[color] {
+			source.Colors = append(source.Colors, color)
+		}
+	}
+	return source
+}
+
+// Cost is a spell's mana requirement.
+type Cost struct {
+	CMC    int
+	Pips   map[string]int // Colored pips by color
+	Hybrid [][]string     // Hybrid symbols, each payable by any of its colors
+}
+
+// ParseManaCost reads a mana cost such as "{2}{W}{W}" or "{1}{W/U}".
+// Phyrexian symbols are left out since they can be paid with life.
+func ParseManaCost(manaCost string, cmc int) Cost {
+	c := Cost{CMC: cmc, Pips: make(map[string]int)}
+	// Split cards list both halves; the first half is the one usually cast
+	if i := strings.Index(manaCost, " // "); i >= 0 {
+		manaCost = manaCost[:i]
+	}
+	for _, part := range strings.Split(manaCost, "{") {
+		symbol := strings.TrimSuffix(strings.TrimSpace(part), "}")
+		switch {
+		case symbol == "", strings.Contains(symbol, "/P"):
+			continue
+		case strings.Contains(symbol, "/"):
+			var options []string
+			for _, s := range strings.Split(symbol, "/") {
+				if isColor(s) {
+					options = append(options, s)
+				}
+			}
+			if len(options) > 0 {
+				c.Hybrid = append(c.Hybrid, options)
+			}
+		case isColor(symbol):
+			c.Pips[symbol]++
+		}
+	}
+	return c
+}
+
+func isColor(s string) bool {
+	return len(s) == 1 && strings.Contains("WUBRG", s)
+}
+
+// resolve pays each hybrid symbol with the color the deck has the most sources
+// of and returns the colored pips.
+func (c Cost) resolve(sources map[string]int) map[string]int {
+	pips := make(map[string]int, len(c.Pips)+len(c.Hybrid))
+	for color, n := range c.Pips {
+		pips[color] = n
+	}
+	for _, options := range c.Hybrid {
+		best := options[0]
+		for _, color := range options[1:] {
+			if sources[color] > sources[best] {
+				best = color
+			}
+		}
+		pips[best]++
+	}
+	return pips
+}
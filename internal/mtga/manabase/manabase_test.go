@@ -0,0 +1,174 @@
+package manabase
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/castability"
+)
+
+func TestParseLand(t *testing.T) {
+	tests := []struct {
+		name, typeLine, text string
+		want                 LandSource
+	}{
+		{"Plains", "Basic Land — Plains", "", LandSource{Colors: []string{"W"}}},
+		{"Island", "", "", LandSource{Colors: []string{"U"}}},
+		{"Hallowed Fountain", "Land — Plains Island", "As Hallowed Fountain enters the battlefield, you may pay 2 life.", LandSource{Colors: []string{"W", "U"}}},
+		{"Temple of Mystery", "Land", "Temple of Mystery enters the battlefield tapped.\nWhen Temple of Mystery enters the battlefield, scry 1.\n{T}: Add {G} or {U}.", LandSource{Colors: []string{"U", "G"}, EntersTapped: true}},
+		{"Command Tower", "Land", "{T}: Add one mana of any color in your commander's color identity.", LandSource{Colors: Colors}},
+		{"Evolving Wilds", "Land", "{T}, Sacrifice Evolving Wilds: Search your library for a basic land card, put it onto the battlefield tapped, then shuffle.", LandSource{Fetch: true, AnyBasic: true}},
+		{"Flooded Strand", "Land", "{T}, Pay 1 life, Sacrifice Flooded Strand: Search your library for a Plains or Island card, put it onto the battlefield, then shuffle.", LandSource{Colors: []string{"W", "U"}, Fetch: true}},
+		{"Mutavault", "Land", "{T}: Add {C}.", LandSource{}},
+	}
+	for _, tt := range tests {
+		if got := ParseLand(tt.name, tt.typeLine, tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseLand(%s) = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseManaCost(t *testing.T) {
+	c := ParseManaCost("{2}{W}{W}{W/U}{G/P} // {R}", 5)
+	if c.Pips["W"] != 2 || len(c.Hybrid) != 1 || c.Pips["G"] != 0 || c.Pips["R"] != 0 {
+		t.Fatalf("ParseManaCost = %+v", c)
+	}
+	if pips := c.resolve(map[string]int{"W": 8, "U": 12}); pips["W"] != 2 || pips["U"] != 1 {
+		t.Errorf("hybrid should be paid with the color with more sources, got %v", pips)
+	}
+}
+
+// deck builds a 60-card deck from lands and spells, filling out with
+// colorless filler spells.
+func deck(lands map[string]int, spells ...Card) []Card {
+	cards := append([]Card(nil), spells...)
+	total := 0
+	for _, c := range spells {
+		total += c.Quantity
+	}
+	for name, n := range lands {
+		cards = append(cards, Card{Name: name, Quantity: n, TypeLine: "Basic Land — " + name})
+		total += n
+	}
+	return append(cards, Card{Name: "Filler", Quantity: 60 - total, ManaCost: "{2}", CMC: 2, TypeLine: "Artifact"})
+}
+
+func castabilityOf(report *Report, name string) Castability {
+	for _, c := range report.Cards {
+		if c.Name == name {
+			return c
+		}
+	}
+	return Castability{}
+}
+
+func TestAnalyze_MonoColorAlwaysConsistent(t *testing.T) {
+	report := Analyze(deck(map[string]int{"Plains": 24}, Card{Name: "Wrath", Quantity: 4, ManaCost: "{2}{W}{W}", CMC: 4, TypeLine: "Sorcery"}), Options{})
+	wrath := castabilityOf(report, "Wrath")
+	if wrath.Consistency != 1 || !wrath.Supported {
+		t.Errorf("mono-white Wrath consistency = %v, want 1", wrath.Consistency)
+	}
+	if math.Abs(wrath.OnPlay-castability.LandProbability(24, 60, 4, 4, false)) > 1e-3 {
+		t.Errorf("on-play castability %v should equal the land drop chance", wrath.OnPlay)
+	}
+	if wrath.OnDraw <= wrath.OnPlay {
+		t.Errorf("on the draw %v should beat on the play %v", wrath.OnDraw, wrath.OnPlay)
+	}
+	if report.LandCount != 24 || report.DeckSize != 60 || report.Sources["W"] != 24 {
+		t.Errorf("report counts = %d lands of %d, %v", report.LandCount, report.DeckSize, report.Sources)
+	}
+}
+
+func TestAnalyze_DualsAddSources(t *testing.T) {
+	spells := []Card{
+		{Name: "Counterspell", Quantity: 4, ManaCost: "{U}{U}", CMC: 2, TypeLine: "Instant"},
+		{Name: "Swords", Quantity: 4, ManaCost: "{W}", CMC: 1, TypeLine: "Instant"},
+	}
+	basics := Analyze(deck(map[string]int{"Plains": 12, "Island": 12}, spells...), Options{})
+
+	withDuals := deck(map[string]int{"Plains": 8, "Island": 8}, spells...)
+	withDuals = append(withDuals, Card{Name: "Hallowed Fountain", Quantity: 8, TypeLine: "Land — Plains Island"})
+	duals := Analyze(withDuals, Options{})
+
+	if duals.Sources["U"] != 16 || duals.Sources["W"] != 16 {
+		t.Errorf("sources = %v, want 16 of each", duals.Sources)
+	}
+	before, after := castabilityOf(basics, "Counterspell"), castabilityOf(duals, "Counterspell")
+	if after.Consistency <= before.Consistency {
+		t.Errorf("duals should make Counterspell more consistent: %v then %v", before.Consistency, after.Consistency)
+	}
+}
+
+func TestAnalyze_FlagsUnderSupportedSplash(t *testing.T) {
+	cards := deck(map[string]int{"Forest": 20, "Mountain": 4},
+		Card{Name: "Llanowar Elves", Quantity: 4, ManaCost: "{G}", CMC: 1, TypeLine: "Creature — Elf Druid"},
+		Card{Name: "Glorybringer", Quantity: 2, ManaCost: "{3}{R}{R}", CMC: 5, TypeLine: "Creature — Dragon"},
+	)
+	report := Analyze(cards, Options{})
+	if len(report.UnderSupported) != 1 || report.UnderSupported[0] != "Glorybringer" {
+		t.Errorf("under-supported = %v, want [Glorybringer]", report.UnderSupported)
+	}
+	if report.Cards[0].Name != "Glorybringer" {
+		t.Errorf("least consistent card first, got %s", report.Cards[0].Name)
+	}
+	if report.RecommendedSources["R"] <= 4 || report.RecommendedSources["R"] > 24 {
+		t.Errorf("recommended red sources = %d", report.RecommendedSources["R"])
+	}
+}
+
+func TestAnalyze_AnyBasicFetchUsesDeckBasics(t *testing.T) {
+	cards := deck(map[string]int{"Plains": 10, "Island": 10}, Card{Name: "Opt", Quantity: 4, ManaCost: "{U}", CMC: 1, TypeLine: "Instant"})
+	cards = append(cards, Card{Name: "Evolving Wilds", Quantity: 4, TypeLine: "Land", OracleText: "{T}, Sacrifice Evolving Wilds: Search your library for a basic land card, put it onto the battlefield tapped, then shuffle."})
+	report := Analyze(cards, Options{})
+	if report.Sources["W"] != 14 || report.Sources["U"] != 14 || report.Sources["G"] != 0 {
+		t.Errorf("sources = %v, want 14 white and blue", report.Sources)
+	}
+	if report.EntersTapped != 0 {
+		// Wilds fetches a tapped land but enters untapped itself
+		t.Errorf("enters tapped = %d, want 0", report.EntersTapped)
+	}
+}
+
+func TestRecommendedLands(t *testing.T) {
+	if got := RecommendedLands(60, 3); got != 25 {
+		t.Errorf("60 cards averaging 3 = %d, want 25", got)
+	}
+	if got := RecommendedLands(40, 3); got != 17 {
+		t.Errorf("40 cards averaging 3 = %d, want 17", got)
+	}
+	if got := RecommendedLands(100, 3); got != 41 {
+		t.Errorf("100 cards averaging 3 = %d, want 41", got)
+	}
+}
+
+func TestSimulateOpeningHands(t *testing.T) {
+	cards := deck(map[string]int{"Plains": 12, "Island": 12}, Card{Name: "Azorius Charm", Quantity: 4, ManaCost: "{W}{U}", CMC: 2, TypeLine: "Instant"})
+	a := Analyze(cards, Options{Simulations: 20000, Seed: 7})
+	b := Analyze(cards, Options{Simulations: 20000, Seed: 7})
+	if !reflect.DeepEqual(a.OpeningHands, b.OpeningHands) {
+		t.Fatal("same seed should give the same simulation")
+	}
+
+	hands := a.OpeningHands
+	var total float64
+	for _, share := range hands.LandCounts {
+		total += share
+	}
+	if math.Abs(total-1) > 0.01 {
+		t.Errorf("land count shares sum to %v", total)
+	}
+	// 24 of 60 cards are lands: 2.8 per hand
+	if math.Abs(hands.AverageLands-2.8) > 0.05 {
+		t.Errorf("average lands = %v, want about 2.8", hands.AverageLands)
+	}
+	if math.Abs(hands.LandDrops[1]-castability.LandProbability(24, 60, 2, 2, false)) > 0.02 {
+		t.Errorf("turn 2 land drop = %v, want about %v", hands.LandDrops[1], castability.LandProbability(24, 60, 2, 2, false))
+	}
+	if hands.KeepableRate < 0.7 || hands.AllColorsRate <= 0 || hands.AllColorsRate > 1 {
+		t.Errorf("keepable %v, all colors %v", hands.KeepableRate, hands.AllColorsRate)
+	}
+	if Analyze(cards, Options{}).OpeningHands != nil {
+		t.Error("no simulation requested, want nil opening hands")
+	}
+}
@@ -0,0 +1,100 @@
+package manabase
+
+import (
+	"math/rand"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/castability"
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
+)
+
+// HandStats summarizes simulated opening hands.
+type HandStats struct {
+	Simulations   int       `json:"simulations"`
+	LandCounts    []float64 `json:"landCounts"` // Share of hands with 0 through 7 lands
+	AverageLands  float64   `json:"averageLands"`
+	KeepableRate  float64   `json:"keepableRate"`  // Hands with 2 to 5 lands
+	AllColorsRate float64   `json:"allColorsRate"` // Keepable hands with a source of every color the spells need
+	LandDrops     []float64 `json:"landDrops"`     // Chance to make the land drop on turns 1 through 4 on the play
+}
+
+// Hands with fewer or more lands than this are usually mulliganed.
+const (
+	minKeepLands  = 2
+	maxKeepLands  = 5
+	landDropTurns = 4
+)
+
+// simulateOpeningHands shuffles the deck n times and deals a seven-card hand
+// and the first draws of each game. colors is the mask of colors the spells
+// need. The same seed always gives the same result.
+func simulateOpeningHands(lands []castability.Group, deckSize, colors, n int, seed int64) *HandStats {
+	hands := &HandStats{
+		Simulations: n,
+		LandCounts:  make([]float64, openingHand+1),
+		LandDrops:   make([]float64, landDropTurns),
+	}
+	if n <= 0 || deckSize < openingHand {
+		return hands
+	}
+
+	// A deck of land masks, -1 for spells
+	deck := make([]int, 0, deckSize)
+	for _, l := range lands {
+		for i := 0; i < l.Count; i++ {
+			deck = append(deck, l.Mask)
+		}
+	}
+	for len(deck) < deckSize {
+		deck = append(deck, -1)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	seen := min(openingHand+landDropTurns-1, deckSize)
+	var totalLands, keepable, allColors int
+	for sim := 0; sim < n; sim++ {
+		// Only the cards that can be seen need shuffling into place
+		for i := 0; i < seen; i++ {
+			j := i + rng.Intn(len(deck)-i)
+			deck[i], deck[j] = deck[j], deck[i]
+		}
+
+		count, mask := 0, 0
+		for _, card := range deck[:openingHand] {
+			if card >= 0 {
+				count++
+				mask |= card
+			}
+		}
+		hands.LandCounts[count]++
+		totalLands += count
+		if count >= minKeepLands && count <= maxKeepLands {
+			keepable++
+			if mask&colors == colors {
+				allColors++
+			}
+		}
+
+		drawn := count
+		for turn := 1; turn <= landDropTurns; turn++ {
+			if turn > 1 && openingHand+turn-2 < seen && deck[openingHand+turn-2] >= 0 {
+				drawn++
+			}
+			if drawn >= turn {
+				hands.LandDrops[turn-1]++
+			}
+		}
+	}
+
+	for i := range hands.LandCounts {
+		hands.LandCounts[i] = stats.Round(hands.LandCounts[i]/float64(n), 3)
+	}
+	for i := range hands.LandDrops {
+		hands.LandDrops[i] = stats.Round(hands.LandDrops[i]/float64(n), 3)
+	}
+	hands.AverageLands = stats.Round(float64(totalLands)/float64(n), 2)
+	hands.KeepableRate = stats.Round(float64(keepable)/float64(n), 3)
+	if keepable > 0 {
+		hands.AllColorsRate = stats.Round(float64(allColors)/float64(keepable), 3)
+	}
+	return hands
+}
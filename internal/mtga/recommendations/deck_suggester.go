@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards"
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/manabase"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
 )
 
@@ -60,6 +61,8 @@ type DeckSuggestionAnalysis struct {
 	TopCards          []string       `json:"topCards"`  // Names of best cards
 	Synergies         []string       `json:"synergies"` // Detected synergies
 	PlayableCount     int            `json:"playableCount"`
+	Castability       float64        `json:"castability"`              // Average on-curve consistency of the spells
+	UnderSupported    []string       `json:"underSupported,omitempty"` // Spells the lands cast on curve less than 90% of the time
 }
 
 // SuggestDecksResponse contains all viable deck suggestions.
@@ -251,6 +254,7 @@ func (s *DeckSuggester) evaluateColorCombination(
 
 	// Calculate deck analysis
 	analysis := s.analyzeDeckSuggestion(selectedCards, candidates)
+	s.validateManaBase(selectedCards, lands, analysis)
 
 	// Calculate overall deck score
 	deckScore := s.calculateDeckScore(selectedCards, analysis, combo)
@@ -819,7 +823,7 @@ func (s *DeckSuggester) calculateDeckScore(selectedCards []*scoredCard, analysis
 
 // determineViability returns the viability status based on score and analysis.
 func (s *DeckSuggester) determineViability(score float64, analysis *DeckSuggestionAnalysis) string {
-	if score >= 0.7 && analysis.CreatureCount >= 10 && analysis.PlayableCount >= 20 {
+	if score >= 0.7 && analysis.CreatureCount >= 10 && analysis.PlayableCount >= 20 && len(analysis.UnderSupported) == 0 {
 		return "strong"
 	} else if score >= 0.5 && analysis.CreatureCount >= 6 {
 		return "viable"
@@ -827,6 +831,45 @@ func (s *DeckSuggester) determineViability(score float64, analysis *DeckSuggesti
 	return "weak"
 }
 
+// validateManaBase checks the spells against the suggested basics and records
+// how reliably they can be cast on curve.
+func (s *DeckSuggester) validateManaBase(selectedCards []*scoredCard, lands []*SuggestedLand, analysis *DeckSuggestionAnalysis) {
+	deck := make([]manabase.Card, 0, len(selectedCards)+len(lands))
+	index := make(map[string]int, len(selectedCards))
+	for _, sc := range selectedCards {
+		// Draft pools hold duplicates as separate cards
+		if i, ok := index[sc.card.Name]; ok {
+			deck[i].Quantity++
+			continue
+		}
+		index[sc.card.Name] = len(deck)
+		card := manabase.Card{Name: sc.card.Name, Quantity: 1, CMC: int(sc.card.CMC), TypeLine: sc.card.TypeLine}
+		if sc.card.ManaCost != nil {
+			card.ManaCost = *sc.card.ManaCost
+		}
+		if sc.card.OracleText != nil {
+			card.OracleText = *sc.card.OracleText
+		}
+		deck = append(deck, card)
+	}
+	for _, land := range lands {
+		deck = append(deck, manabase.Card{Name: land.Name, Quantity: land.Quantity, TypeLine: "Basic Land — " + land.Name})
+	}
+
+	report := manabase.Analyze(deck, manabase.Options{})
+	if len(report.Cards) == 0 {
+		return
+	}
+	var total float64
+	for _, c := range report.Cards {
+		total += c.Consistency
+	}
+	analysis.Castability = total / float64(len(report.Cards))
+	if len(report.UnderSupported) > 0 {
+		analysis.UnderSupported = report.UnderSupported
+	}
+}
+
 // countLands counts total lands from the land distribution.
 func (s *DeckSuggester) countLands(lands []*SuggestedLand) int {
 	total := 0
@@ -1025,13 +1068,14 @@ func (s *DeckSuggester) buildArchetypeDeck(
 
 	// Build analysis
 	analysis := s.analyzeDeckSuggestion(selectedCards, candidates)
+	s.validateManaBase(selectedCards, lands, analysis)
 
 	// Calculate archetype-specific score
 	deckScore := s.calculateArchetypeScore(selectedCards, analysis, combo, target)
 
 	// Determine viability
 	viability := "weak"
-	if deckScore >= 0.7 {
+	if deckScore >= 0.7 && len(analysis.UnderSupported) == 0 {
 		viability = "strong"
 	} else if deckScore >= 0.5 {
 		viability = "viable"
@@ -355,6 +355,16 @@ func TestDetermineViability(t *testing.T) {
 			},
 			expected: "weak",
 		},
+		{
+			name:  "Strong deck with under-supported spells",
+			score: 0.75,
+			analysis: &DeckSuggestionAnalysis{
+				CreatureCount:  15,
+				PlayableCount:  30,
+				UnderSupported: []string{"Double Pip Bomb"},
+			},
+			expected: "viable",
+		},
 	}
 
 	for _, tt := range tests {
@@ -367,6 +377,33 @@ func TestDetermineViability(t *testing.T) {
 	}
 }
 
+func TestValidateManaBase(t *testing.T) {
+	suggester := &DeckSuggester{}
+
+	manaCost := func(s string) *string { return &s }
+	var selected []*scoredCard
+	for i := 0; i < 20; i++ {
+		selected = append(selected, &scoredCard{card: &cards.Card{Name: "Knight", ManaCost: manaCost("{1}{W}"), CMC: 2, TypeLine: "Creature"}})
+	}
+	for i := 0; i < 3; i++ {
+		selected = append(selected, &scoredCard{card: &cards.Card{Name: "Sphinx", ManaCost: manaCost("{3}{U}{U}"), CMC: 5, TypeLine: "Creature"}})
+	}
+	lands := []*SuggestedLand{
+		{Name: "Plains", Quantity: 14, Color: "W"},
+		{Name: "Island", Quantity: 3, Color: "U"},
+	}
+
+	analysis := &DeckSuggestionAnalysis{}
+	suggester.validateManaBase(selected, lands, analysis)
+
+	if len(analysis.UnderSupported) != 1 || analysis.UnderSupported[0] != "Sphinx" {
+		t.Errorf("expected Sphinx to be under-supported by 3 Islands, got %v", analysis.UnderSupported)
+	}
+	if analysis.Castability <= 0 || analysis.Castability >= 1 {
+		t.Errorf("expected average castability between 0 and 1, got %.3f", analysis.Castability)
+	}
+}
+
 func TestColorCombinations(t *testing.T) {
 	// Verify we have all 25 color combinations (5 mono + 10 two-color + 10 three-color)
 	if len(allColorCombinations) != 25 {
@@ -1970,24 +1970,7 @@ func (s *SeedDeckBuilder) isRemovalSpell(card *cards.Card) bool {
 	if card.OracleText == nil {
 		return false
 	}
-	text := strings.ToLower(*card.OracleText)
-	removalKeywords := []string{
-		"destroy target",
-		"exile target",
-		"deals damage",
-		"deal", // Matches "deal 3 damage", "deal damage", etc.
-		"-x/-x",
-		"return target",
-		"sacrifice a creature",
-		"destroy all",
-		"exile all",
-	}
-	for _, kw := range removalKeywords {
-		if strings.Contains(text, kw) {
-			return true
-		}
-	}
-	return false
+	return cards.IsRemovalText(*card.OracleText)
 }
 
 // isCardAdvantageSpell checks if a card provides card advantage.
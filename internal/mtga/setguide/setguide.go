@@ -23,6 +23,14 @@ type SetGuide struct {
 	cacheDir   string
 	setFiles   map[string]*seventeenlands.SetFile // Cached set files by set code
 	archetypes map[string][]Archetype             // Archetypes by set code
+	blender    RatingBlender                      // Optional user rating blends
+}
+
+// RatingBlender supplies the user's blended card ratings.
+type RatingBlender interface {
+	// BlendedRatings returns blended ratings by Arena ID on the 17Lands
+	// percentage scale, empty when no blend applies.
+	BlendedRatings(ctx context.Context, setCode, draftFormat string) (map[int]float64, error)
 }
 
 // Archetype represents a draft archetype (e.g., "WU Fliers", "BR Aggro").
@@ -47,8 +55,9 @@ type CardTier struct {
 	ALSA     float64
 	ATA      float64
 	GIH      int
-	Tier     string // "S", "A", "B", "C", "D", "F"
-	Category string // "Bomb", "Removal", "Fixing", etc.
+	Rating   float64 // Rating the tier list is ranked by: the user's blend, or GIHWR without one
+	Tier     string  // "S", "A", "B", "C", "D", "F"
+	Category string  // "Bomb", "Removal", "Fixing", etc.
 }
 
 // TypeStats represents statistics for a card type within a set.
@@ -87,6 +96,12 @@ func NewSetGuide(client *seventeenlands.Client, cacheDir string) *SetGuide {
 	}
 }
 
+// SetRatingBlender ranks tier lists by the user's blended ratings instead of
+// raw GIHWR.
+func (sg *SetGuide) SetRatingBlender(blender RatingBlender) {
+	sg.blender = blender
+}
+
 // LoadSet loads or fetches a set file.
 func (sg *SetGuide) LoadSet(ctx context.Context, setCode, format string) error {
 	// Check if already loaded
@@ -181,6 +196,15 @@ func (sg *SetGuide) GetTierList(setCode string, opts TierListOptions) ([]CardTie
 		return nil, fmt.Errorf("set %s not loaded", setCode)
 	}
 
+	var blended map[int]float64
+	if sg.blender != nil {
+		var err error
+		blended, err = sg.blender.BlendedRatings(context.Background(), setCode, setFile.Meta.DraftFormat)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blended ratings: %w", err)
+		}
+	}
+
 	var tiers []CardTier
 
 	for _, cardData := range setFile.CardRatings {
@@ -206,6 +230,12 @@ func (sg *SetGuide) GetTierList(setCode string, opts TierListOptions) ([]CardTie
 			continue
 		}
 
+		// Set files hold GIHWR as a decimal, blends as a percentage
+		value := rating.GIHWR
+		if b, ok := blended[cardData.ArenaID]; ok {
+			value = b / 100
+		}
+
 		tier := CardTier{
 			Name:     cardData.Name,
 			Color:    colorString(cardData.Colors),
@@ -215,16 +245,17 @@ func (sg *SetGuide) GetTierList(setCode string, opts TierListOptions) ([]CardTie
 			ALSA:     rating.ALSA,
 			ATA:      rating.ATA,
 			GIH:      rating.GIH,
-			Tier:     calculateTier(rating.GIHWR),
-			Category: categorizeCard(cardData.Name, cardType, rating.GIHWR),
+			Rating:   value,
+			Tier:     calculateTier(value),
+			Category: categorizeCard(cardData.Name, cardType, value),
 		}
 
 		tiers = append(tiers, tier)
 	}
 
-	// Sort by GIHWR descending
+	// Sort by rating descending
 	sort.Slice(tiers, func(i, j int) bool {
-		return tiers[i].GIHWR > tiers[j].GIHWR
+		return tiers[i].Rating > tiers[j].Rating
 	})
 
 	// Apply limit
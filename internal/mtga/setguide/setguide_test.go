@@ -1,6 +1,7 @@
 package setguide
 
 import (
+	"context"
 	"testing"
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
@@ -506,6 +507,51 @@ func TestGetTierListWithCardTypeFilter(t *testing.T) {
 	}
 }
 
+type fakeBlender map[int]float64
+
+func (f fakeBlender) BlendedRatings(ctx context.Context, setCode, draftFormat string) (map[int]float64, error) {
+	return f, nil
+}
+
+func TestGetTierListWithRatingBlender(t *testing.T) {
+	sg := NewSetGuide(nil, "")
+	sg.setFiles["TEST"] = &seventeenlands.SetFile{
+		Meta: seventeenlands.SetMeta{SetCode: "TEST", DraftFormat: "PremierDraft"},
+		CardRatings: map[string]*seventeenlands.CardRatingData{
+			"1": {
+				Name:       "Overrated",
+				ArenaID:    1,
+				DeckColors: map[string]*seventeenlands.DeckColorRatings{"ALL": {GIHWR: 0.61}},
+			},
+			"2": {
+				Name:       "Sleeper",
+				ArenaID:    2,
+				DeckColors: map[string]*seventeenlands.DeckColorRatings{"ALL": {GIHWR: 0.53}},
+			},
+			"3": {
+				Name:       "Unblended",
+				ArenaID:    3,
+				DeckColors: map[string]*seventeenlands.DeckColorRatings{"ALL": {GIHWR: 0.55}},
+			},
+		},
+	}
+	sg.SetRatingBlender(fakeBlender{1: 52, 2: 60.5})
+
+	tiers, err := sg.GetTierList("TEST", TierListOptions{})
+	if err != nil {
+		t.Fatalf("GetTierList returned error: %v", err)
+	}
+	if len(tiers) != 3 || tiers[0].Name != "Sleeper" || tiers[1].Name != "Unblended" || tiers[2].Name != "Overrated" {
+		t.Fatalf("Expected tier list ranked by blended rating, got %+v", tiers)
+	}
+	if tiers[0].Tier != "S" || tiers[0].GIHWR != 0.53 || tiers[0].Rating != 0.605 {
+		t.Errorf("Expected Sleeper in S tier with raw GIHWR kept, got %+v", tiers[0])
+	}
+	if tiers[1].Rating != 0.55 {
+		t.Errorf("Expected card without a blended rating to use GIHWR, got %v", tiers[1].Rating)
+	}
+}
+
 func TestCalculateTypeStats(t *testing.T) {
 	sg := NewSetGuide(nil, "")
 
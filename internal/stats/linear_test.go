@@ -0,0 +1,28 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSolveLinear(t *testing.T) {
+	// y + z = 3, 2x + y = 5, x + 3y + z = 10; the first row needs a pivot
+	a := [][]float64{{0, 1, 1}, {2, 1, 0}, {1, 3, 1}}
+	b := []float64{3, 5, 10}
+	x, err := SolveLinear(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []float64{1, 3, 0}
+	for i := range want {
+		if math.Abs(x[i]-want[i]) > 1e-9 {
+			t.Errorf("x = %v, want %v", x, want)
+			break
+		}
+	}
+
+	if _, err := SolveLinear([][]float64{{1, 2}, {2, 4}}, []float64{1, 2}); !errors.Is(err, ErrSingular) {
+		t.Errorf("singular system error = %v, want ErrSingular", err)
+	}
+}
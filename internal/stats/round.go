@@ -0,0 +1,9 @@
+package stats
+
+import "math"
+
+// Round rounds v to the given number of decimal places for reporting.
+func Round(v float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(v*scale) / scale
+}
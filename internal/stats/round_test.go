@@ -0,0 +1,21 @@
+package stats
+
+import "testing"
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		v      float64
+		places int
+		want   float64
+	}{
+		{0.12345, 3, 0.123},
+		{0.6666, 2, 0.67},
+		{2.45, 1, 2.5},
+		{-1.005, 0, -1},
+	}
+	for _, tt := range tests {
+		if got := Round(tt.v, tt.places); got != tt.want {
+			t.Errorf("Round(%v, %d) = %v, want %v", tt.v, tt.places, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// DefaultConfidence is the confidence level used for reported intervals.
+	DefaultConfidence = 0.95
+
+	// SignificanceLevel is the p-value below which a difference is significant.
+	SignificanceLevel = 0.05
+
+	// MinSampleSize is the fewest trials per group before a win rate is
+	// considered reliable enough to call a difference significant.
+	MinSampleSize = 30
+)
+
+// Interval is a confidence or credible interval on a proportion.
+type Interval struct {
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+}
+
+// Scale multiplies both bounds, e.g. by 100 for percentages.
+func (i Interval) Scale(factor float64) Interval {
+	return Interval{Low: i.Low * factor, High: i.High * factor}
+}
+
+// Proportion summarizes a win rate with its uncertainty.
+type Proportion struct {
+	Successes int      `json:"successes"`
+	Trials    int      `json:"trials"`
+	Rate      float64  `json:"rate"`
+	Wilson    Interval `json:"wilson"`
+	Jeffreys  Interval `json:"jeffreys"`
+	Warning   string   `json:"warning,omitempty"` // Set when the sample is too small
+}
+
+// Comparison is the outcome of testing whether two win rates differ.
+type Comparison struct {
+	Difference        float64 `json:"difference"`         // Rate 1 minus rate 2
+	ZScore            float64 `json:"z_score"`            // Two-proportion z statistic
+	PValue            float64 `json:"p_value"`            // Two-sided
+	ProbabilityBetter float64 `json:"probability_better"` // Posterior P(rate 1 > rate 2) with uniform priors
+	Significant       bool    `json:"significant"`        // p < SignificanceLevel and both samples large enough
+	Warning           string  `json:"warning,omitempty"`
+}
+
+// NewProportion builds a proportion with 95% Wilson and Jeffreys intervals.
+func NewProportion(successes, trials int) Proportion {
+	p := Proportion{
+		Successes: successes,
+		Trials:    trials,
+		Wilson:    WilsonInterval(successes, trials, DefaultConfidence),
+		Jeffreys:  JeffreysInterval(successes, trials, DefaultConfidence),
+		Warning:   SampleWarning(trials),
+	}
+	if trials > 0 {
+		p.Rate = float64(successes) / float64(trials)
+	}
+	return p
+}
+
+// Compare tests whether the first win rate differs from the second.
+func Compare(successes1, trials1, successes2, trials2 int) Comparison {
+	z, pValue := TwoProportionZTest(successes1, trials1, successes2, trials2)
+	c := Comparison{
+		ZScore:            z,
+		PValue:            pValue,
+		ProbabilityBetter: ProbabilityBetter(successes1, trials1, successes2, trials2),
+	}
+	if trials1 > 0 && trials2 > 0 {
+		c.Difference = float64(successes1)/float64(trials1) - float64(successes2)/float64(trials2)
+	}
+
+	if trials1 < trials2 {
+		c.Warning = SampleWarning(trials1)
+	} else {
+		c.Warning = SampleWarning(trials2)
+	}
+	c.Significant = c.Warning == "" && pValue < SignificanceLevel
+	return c
+}
+
+// SampleWarning returns a warning when trials is below MinSampleSize.
+func SampleWarning(trials int) string {
+	if trials >= MinSampleSize {
+		return ""
+	}
+	return fmt.Sprintf("only %d matches; at least %d needed for a reliable win rate", trials, MinSampleSize)
+}
+
+// zScore returns the two-sided normal critical value for a confidence level.
+func zScore(confidence float64) float64 {
+	return math.Sqrt2 * math.Erfinv(confidence)
+}
+
+// WilsonInterval returns the Wilson score interval for a proportion.
+func WilsonInterval(successes, trials int, confidence float64) Interval {
+	if trials <= 0 {
+		return Interval{High: 1}
+	}
+
+	z := zScore(confidence)
+	n := float64(trials)
+	p := float64(successes) / n
+	denom := 1 + z*z/n
+	center := (p + z*z/(2*n)) / denom
+	margin := z * math.Sqrt(p*(1-p)/n+z*z/(4*n*n)) / denom
+	return Interval{Low: math.Max(0, center-margin), High: math.Min(1, center+margin)}
+}
+
+// JeffreysInterval returns the equal-tailed Jeffreys interval, the quantiles of
+// a Beta(successes+0.5, failures+0.5) posterior.
+func JeffreysInterval(successes, trials int, confidence float64) Interval {
+	if trials <= 0 {
+		return Interval{High: 1}
+	}
+
+	a := float64(successes) + 0.5
+	b := float64(trials-successes) + 0.5
+	tail := (1 - confidence) / 2
+	interval := Interval{Low: 0, High: 1}
+	if successes > 0 {
+		interval.Low = betaQuantile(tail, a, b)
+	}
+	if successes < trials {
+		interval.High = betaQuantile(1-tail, a, b)
+	}
+	return interval
+}
+
+// TwoProportionZTest runs a pooled two-proportion z-test and returns the z
+// statistic and two-sided p-value.
+func TwoProportionZTest(successes1, trials1, successes2, trials2 int) (float64, float64) {
+	if trials1 <= 0 || trials2 <= 0 {
+		return 0, 1
+	}
+
+	n1, n2 := float64(trials1), float64(trials2)
+	p1, p2 := float64(successes1)/n1, float64(successes2)/n2
+	pooled := float64(successes1+successes2) / (n1 + n2)
+	se := math.Sqrt(pooled * (1 - pooled) * (1/n1 + 1/n2))
+	if se == 0 {
+		return 0, 1
+	}
+
+	z := (p1 - p2) / se
+	return z, math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// ProbabilityBetter returns the posterior probability that the first win rate
+// exceeds the second, with uniform Beta(1, 1) priors on both.
+func ProbabilityBetter(successes1, trials1, successes2, trials2 int) float64 {
+	// Closed form for P(X > Y) with X ~ Beta(a1, b1), Y ~ Beta(a2, b2) and integer a1
+	a1, b1 := float64(successes1+1), float64(trials1-successes1+1)
+	a2, b2 := float64(successes2+1), float64(trials2-successes2+1)
+
+	total := 0.0
+	for i := 0.0; i < a1; i++ {
+		total += math.Exp(logBeta(a2+i, b1+b2) - math.Log(b1+i) - logBeta(1+i, b1) - logBeta(a2, b2))
+	}
+	return math.Max(0, math.Min(1, total))
+}
+
+func logBeta(a, b float64) float64 {
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	return la + lb - lab
+}
+
+// betaQuantile inverts the regularized incomplete beta function by bisection.
+func betaQuantile(p, a, b float64) float64 {
+	lo, hi := 0.0, 1.0
+	for range 100 {
+		mid := (lo + hi) / 2
+		if regularizedBeta(mid, a, b) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// regularizedBeta returns I_x(a, b) using the continued fraction expansion.
+func regularizedBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	front := math.Exp(a*math.Log(x) + b*math.Log(1-x) - logBeta(a, b))
+	// The continued fraction converges quickly only below the mean
+	if x > (a+1)/(a+b+2) {
+		return 1 - front*betaContinuedFraction(1-x, b, a)/b
+	}
+	return front * betaContinuedFraction(x, a, b) / a
+}
+
+// betaContinuedFraction evaluates the incomplete beta continued fraction with
+// the modified Lentz method.
+func betaContinuedFraction(x, a, b float64) float64 {
+	const tiny = 1e-300
+	const epsilon = 1e-14
+
+	c := 1.0
+	d := 1 - (a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	result := d
+
+	for m := 1.0; m <= 300; m++ {
+		// Even step
+		num := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 + num*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + num/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		result *= d * c
+
+		// Odd step
+		num = -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 + num*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + num/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		result *= delta
+
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return result
+}
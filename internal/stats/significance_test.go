@@ -0,0 +1,104 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWilsonInterval(t *testing.T) {
+	tests := []struct {
+		name      string
+		successes int
+		trials    int
+		wantLow   float64
+		wantHigh  float64
+	}{
+		{name: "Half of 100", successes: 50, trials: 100, wantLow: 0.4038, wantHigh: 0.5962},
+		{name: "All of 10", successes: 10, trials: 10, wantLow: 0.7225, wantHigh: 1},
+		{name: "None of 10", successes: 0, trials: 10, wantLow: 0, wantHigh: 0.2775},
+		{name: "No trials", successes: 0, trials: 0, wantLow: 0, wantHigh: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WilsonInterval(tt.successes, tt.trials, DefaultConfidence)
+			if math.Abs(got.Low-tt.wantLow) > 0.001 || math.Abs(got.High-tt.wantHigh) > 0.001 {
+				t.Errorf("WilsonInterval(%d, %d) = %+v, want [%v, %v]", tt.successes, tt.trials, got, tt.wantLow, tt.wantHigh)
+			}
+		})
+	}
+}
+
+func TestJeffreysInterval(t *testing.T) {
+	tests := []struct {
+		name      string
+		successes int
+		trials    int
+		wantLow   float64
+		wantHigh  float64
+	}{
+		{name: "Half of 100", successes: 50, trials: 100, wantLow: 0.4036, wantHigh: 0.5964},
+		{name: "7 of 10", successes: 7, trials: 10, wantLow: 0.3942, wantHigh: 0.9073},
+		{name: "None of 10", successes: 0, trials: 10, wantLow: 0, wantHigh: 0.2172},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := JeffreysInterval(tt.successes, tt.trials, DefaultConfidence)
+			if math.Abs(got.Low-tt.wantLow) > 0.001 || math.Abs(got.High-tt.wantHigh) > 0.001 {
+				t.Errorf("JeffreysInterval(%d, %d) = %+v, want [%v, %v]", tt.successes, tt.trials, got, tt.wantLow, tt.wantHigh)
+			}
+		})
+	}
+}
+
+func TestTwoProportionZTest(t *testing.T) {
+	z, p := TwoProportionZTest(60, 100, 45, 100)
+	if math.Abs(z-2.1240) > 0.001 || math.Abs(p-0.0337) > 0.001 {
+		t.Errorf("TwoProportionZTest = (%v, %v), want (2.1240, 0.0337)", z, p)
+	}
+
+	if z, p := TwoProportionZTest(5, 10, 0, 0); z != 0 || p != 1 {
+		t.Errorf("empty group should not be significant, got (%v, %v)", z, p)
+	}
+}
+
+func TestProbabilityBetter(t *testing.T) {
+	if p := ProbabilityBetter(5, 10, 5, 10); math.Abs(p-0.5) > 1e-9 {
+		t.Errorf("identical records = %v, want 0.5", p)
+	}
+
+	// P(X > Y) for X ~ Beta(2, 1) and Y ~ Beta(1, 1) is 2/3
+	if p := ProbabilityBetter(1, 1, 0, 0); math.Abs(p-2.0/3) > 1e-9 {
+		t.Errorf("one win vs no data = %v, want 2/3", p)
+	}
+
+	better := ProbabilityBetter(60, 100, 45, 100)
+	worse := ProbabilityBetter(45, 100, 60, 100)
+	if better < 0.95 || math.Abs(better+worse-1) > 1e-6 {
+		t.Errorf("ProbabilityBetter = %v / %v", better, worse)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	c := Compare(60, 100, 45, 100)
+	if !c.Significant || c.Warning != "" || math.Abs(c.Difference-0.15) > 1e-9 {
+		t.Errorf("large samples: %+v", c)
+	}
+
+	// A large gap on small samples is flagged rather than called significant
+	c = Compare(9, 10, 1, 10)
+	if c.Significant || c.Warning == "" || c.PValue >= SignificanceLevel {
+		t.Errorf("small samples: %+v", c)
+	}
+}
+
+func TestNewProportion(t *testing.T) {
+	p := NewProportion(40, 50)
+	if p.Rate != 0.8 || p.Warning != "" || p.Wilson.Low >= 0.8 || p.Jeffreys.High <= 0.8 {
+		t.Errorf("NewProportion(40, 50) = %+v", p)
+	}
+	if p := NewProportion(3, 5); p.Warning == "" {
+		t.Error("expected small sample warning")
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 // BackupType represents the type of backup (full or incremental).
@@ -144,7 +146,7 @@ func (bm *BackupManager) Backup(config *BackupConfig) (string, error) {
 	backupPath := filepath.Join(backupDir, backupName+".db")
 
 	// Open source database
-	sourceDB, err := sql.Open("sqlite", bm.dbPath)
+	sourceDB, err := sql.Open(sqlitedriver.Name, bm.dbPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to open source database: %w", err)
 	}
@@ -401,7 +403,7 @@ func (bm *BackupManager) Restore(backupPath string, encryptionPassword ...string
 // VerifyBackup verifies that a backup file is a valid SQLite database.
 func (bm *BackupManager) VerifyBackup(backupPath string) error {
 	// Try to open the backup as a SQLite database
-	db, err := sql.Open("sqlite", backupPath)
+	db, err := sql.Open(sqlitedriver.Name, backupPath)
 	if err != nil {
 		return fmt.Errorf("failed to open backup as database: %w", err)
 	}
@@ -678,7 +680,7 @@ func (bm *BackupManager) CleanupBackups(backupDir string, retentionDays int, max
 // generateMetadata creates metadata for the current database state.
 func (bm *BackupManager) generateMetadata(backupType BackupType, backupPath, baseBackup string) (*BackupMetadata, error) {
 	// Open database
-	db, err := sql.Open("sqlite", bm.dbPath)
+	db, err := sql.Open(sqlitedriver.Name, bm.dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -1007,7 +1009,7 @@ func (bm *BackupManager) findChangedTables(oldMetadata, newMetadata *BackupMetad
 // exportTablesToSQL exports specified tables to a SQL file.
 func (bm *BackupManager) exportTablesToSQL(tables []string, outputPath string) error {
 	// Open database
-	db, err := sql.Open("sqlite", bm.dbPath)
+	db, err := sql.Open(sqlitedriver.Name, bm.dbPath)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -1200,7 +1202,7 @@ func (bm *BackupManager) applySQLFile(sqlPath string) error {
 	}
 
 	// Open database
-	db, err := sql.Open("sqlite", bm.dbPath)
+	db, err := sql.Open(sqlitedriver.Name, bm.dbPath)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
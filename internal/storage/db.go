@@ -9,12 +9,13 @@ import (
 	"strings"
 	"time"
 
-	_ "modernc.org/sqlite" // SQLite driver
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 // DB wraps the database connection and provides access to repositories.
 type DB struct {
-	conn *sql.DB
+	conn   *sql.DB // writer; a single connection when the read pool is enabled
+	reader *sql.DB // read-only pool, nil when reads share conn
 }
 
 // Config holds database configuration settings.
@@ -49,6 +50,20 @@ type Config struct {
 	// Default: NORMAL for good balance of safety and performance
 	Synchronous string
 
+	// ReadPoolSize is the number of read-only connections opened alongside the writer.
+	// When greater than zero and the database is a WAL-mode file, the writer is
+	// limited to a single connection (so writes queue instead of failing with
+	// SQLITE_BUSY) and analytics reads run concurrently on the read pool.
+	// Zero keeps a single shared pool sized by MaxOpenConns.
+	// Default: 4
+	ReadPoolSize int
+
+	// ForeignKeys enables SQLite foreign key enforcement on every connection.
+	// Default: false. Earlier builds requested foreign keys with a DSN parameter the
+	// pure-Go driver ignored, so existing databases may hold rows that would now
+	// violate constraints.
+	ForeignKeys bool
+
 	// AutoMigrate automatically runs pending database migrations on Open.
 	// Default: false (migrations must be run manually)
 	AutoMigrate bool
@@ -64,9 +79,25 @@ func DefaultConfig(path string) *Config {
 		BusyTimeout:     5 * time.Second,
 		JournalMode:     "WAL",
 		Synchronous:     "NORMAL",
+		ReadPoolSize:    4,
 	}
 }
 
+// splitReadWrite reports whether Open should create a separate read pool.
+// WAL is required for readers to proceed while the writer holds its lock, and
+// each in-memory connection is its own database, so neither can be split.
+func (c *Config) splitReadWrite() bool {
+	return c.ReadPoolSize > 0 && c.Path != ":memory:" && strings.EqualFold(c.JournalMode, "WAL")
+}
+
+// writerPoolLimits returns the open/idle connection limits for the writer pool.
+func (c *Config) writerPoolLimits() (maxOpen, maxIdle int) {
+	if c.splitReadWrite() {
+		return 1, 1
+	}
+	return c.MaxOpenConns, c.MaxIdleConns
+}
+
 // Open creates a new database connection with the given configuration.
 // It configures connection pooling and SQLite-specific settings.
 func Open(config *Config) (*DB, error) {
@@ -82,23 +113,24 @@ func Open(config *Config) (*DB, error) {
 		}
 	}
 
-	// Build DSN with pragma parameters
-	dsn := fmt.Sprintf("%s?_busy_timeout=%d&_journal_mode=%s&_synchronous=%s&_foreign_keys=on",
-		config.Path,
-		config.BusyTimeout.Milliseconds(),
-		config.JournalMode,
-		config.Synchronous,
-	)
+	// Build DSN with pragma parameters in the compiled-in driver's syntax
+	dsn := sqlitedriver.DSN(config.Path, sqlitedriver.Pragmas{
+		BusyTimeout: config.BusyTimeout,
+		JournalMode: config.JournalMode,
+		Synchronous: config.Synchronous,
+		ForeignKeys: config.ForeignKeys,
+	})
 
 	// Open database connection
-	conn, err := sql.Open("sqlite", dsn)
+	conn, err := sql.Open(sqlitedriver.Name, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
 	// Configure connection pool
-	conn.SetMaxOpenConns(config.MaxOpenConns)
-	conn.SetMaxIdleConns(config.MaxIdleConns)
+	maxOpen, maxIdle := config.writerPoolLimits()
+	conn.SetMaxOpenConns(maxOpen)
+	conn.SetMaxIdleConns(maxIdle)
 	conn.SetConnMaxLifetime(config.ConnMaxLifetime)
 
 	// Verify connection
@@ -134,14 +166,14 @@ func Open(config *Config) (*DB, error) {
 		}
 
 		// Reopen the connection
-		conn, err = sql.Open("sqlite", dsn)
+		conn, err = sql.Open(sqlitedriver.Name, dsn)
 		if err != nil {
 			return nil, fmt.Errorf("failed to reopen database after migrations: %w", err)
 		}
 
 		// Reconfigure connection pool
-		conn.SetMaxOpenConns(config.MaxOpenConns)
-		conn.SetMaxIdleConns(config.MaxIdleConns)
+		conn.SetMaxOpenConns(maxOpen)
+		conn.SetMaxIdleConns(maxIdle)
 		conn.SetConnMaxLifetime(config.ConnMaxLifetime)
 
 		// Verify connection again
@@ -153,39 +185,107 @@ func Open(config *Config) (*DB, error) {
 		}
 	}
 
-	return &DB{conn: conn}, nil
+	db := &DB{conn: conn}
+
+	// Open the read-only pool after the writer so WAL mode is already set on the file
+	if config.splitReadWrite() {
+		reader, err := openReadPool(config)
+		if err != nil {
+			if closeErr := conn.Close(); closeErr != nil {
+				return nil, fmt.Errorf("failed to close database after read pool error: %w (original error: %v)", closeErr, err)
+			}
+			return nil, err
+		}
+		db.reader = reader
+	}
+
+	return db, nil
+}
+
+// openReadPool opens the query-only connection pool used for analytics reads.
+func openReadPool(config *Config) (*sql.DB, error) {
+	dsn := sqlitedriver.DSN(config.Path, sqlitedriver.Pragmas{
+		BusyTimeout: config.BusyTimeout,
+		ForeignKeys: config.ForeignKeys,
+		QueryOnly:   true,
+	})
+
+	reader, err := sql.Open(sqlitedriver.Name, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open read pool: %w", err)
+	}
+
+	reader.SetMaxOpenConns(config.ReadPoolSize)
+	reader.SetMaxIdleConns(config.ReadPoolSize)
+	reader.SetConnMaxLifetime(config.ConnMaxLifetime)
+
+	if err := reader.Ping(); err != nil {
+		_ = reader.Close()
+		return nil, fmt.Errorf("failed to ping read pool: %w", err)
+	}
+
+	return reader, nil
 }
 
-// Close closes the database connection.
+// Close closes the writer connection and the read pool.
 func (db *DB) Close() error {
 	if db.conn == nil {
 		return nil
 	}
+	if db.reader != nil {
+		if err := db.reader.Close(); err != nil {
+			_ = db.conn.Close()
+			return fmt.Errorf("failed to close read pool: %w", err)
+		}
+	}
 	return db.conn.Close()
 }
 
 // Conn returns the underlying sql.DB connection.
 // This is useful for raw SQL queries or custom operations.
+// It is the writer connection; use Reader for long-running read-only queries.
 func (db *DB) Conn() *sql.DB {
 	return db.conn
 }
 
+// Reader returns the read-only connection pool for analytics queries.
+// When the read pool is disabled it returns the writer connection, so callers
+// never need to check the configuration.
+func (db *DB) Reader() *sql.DB {
+	if db.reader != nil {
+		return db.reader
+	}
+	return db.conn
+}
+
+// HasReadPool reports whether reads run on a pool separate from the writer.
+func (db *DB) HasReadPool() bool {
+	return db.reader != nil
+}
+
 // Ping verifies the database connection is alive.
 func (db *DB) Ping() error {
-	return db.conn.Ping()
+	if err := db.conn.Ping(); err != nil {
+		return err
+	}
+	if db.reader != nil {
+		return db.reader.Ping()
+	}
+	return nil
 }
 
 // IsSQLiteBusy checks if an error is a SQLite SQLITE_BUSY error.
+// Classification is identical for both SQLite drivers (see sqlitedriver).
 func IsSQLiteBusy(err error) bool {
-	if err == nil {
-		return false
-	}
-	// Check for "database is locked" or "SQLITE_BUSY" in error message
-	errMsg := err.Error()
-	return strings.Contains(errMsg, "database is locked") || strings.Contains(errMsg, "SQLITE_BUSY")
+	return sqlitedriver.IsBusy(err)
+}
+
+// IsSQLiteLocked checks if an error is a SQLite SQLITE_LOCKED error.
+func IsSQLiteLocked(err error) bool {
+	return sqlitedriver.IsLocked(err)
 }
 
-// RetryOnBusy retries a function if it returns a SQLITE_BUSY error.
+// RetryOnBusy retries a function if it returns a SQLITE_BUSY or SQLITE_LOCKED error.
 // It uses exponential backoff with a maximum of 5 retries.
 func RetryOnBusy(fn func() error) error {
 	const maxRetries = 5
@@ -198,8 +298,8 @@ func RetryOnBusy(fn func() error) error {
 			return nil
 		}
 
-		// If it's not a SQLITE_BUSY error, return immediately
-		if !IsSQLiteBusy(lastErr) {
+		// If it's not a contention error, return immediately
+		if !sqlitedriver.IsContention(lastErr) {
 			return lastErr
 		}
 
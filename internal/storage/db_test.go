@@ -1,9 +1,13 @@
 package storage
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 )
 
 func TestOpen_CreatesDirectory(t *testing.T) {
@@ -39,3 +43,147 @@ func TestOpen_CreatesDirectory(t *testing.T) {
 
 	t.Log("✅ Directory creation successful!")
 }
+
+func TestOpen_SplitsReadPool(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "split.db")
+
+	db, err := Open(DefaultConfig(dbPath))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	if !db.HasReadPool() {
+		t.Fatal("expected a separate read pool for a WAL file database")
+	}
+	if db.Reader() == db.Conn() {
+		t.Fatal("Reader() should not return the writer connection")
+	}
+	if got := db.Conn().Stats().MaxOpenConnections; got != 1 {
+		t.Errorf("writer MaxOpenConnections = %d, want 1", got)
+	}
+
+	if _, err := db.Conn().Exec("CREATE TABLE t (id INTEGER)"); err != nil {
+		t.Fatalf("writer should accept writes: %v", err)
+	}
+	if _, err := db.Reader().Exec("INSERT INTO t (id) VALUES (1)"); err == nil {
+		t.Error("read pool should reject writes")
+	}
+
+	var count int
+	if err := db.Reader().QueryRow("SELECT COUNT(*) FROM t").Scan(&count); err != nil {
+		t.Fatalf("read pool should see committed schema: %v", err)
+	}
+}
+
+func TestOpen_NoReadPoolWithoutWAL(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *Config
+	}{
+		{"in-memory", DefaultConfig(":memory:")},
+		{"delete journal", func() *Config {
+			c := DefaultConfig(filepath.Join(t.TempDir(), "delete.db"))
+			c.JournalMode = "DELETE"
+			return c
+		}()},
+		{"disabled", func() *Config {
+			c := DefaultConfig(filepath.Join(t.TempDir(), "disabled.db"))
+			c.ReadPoolSize = 0
+			return c
+		}()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := Open(tt.config)
+			if err != nil {
+				t.Fatalf("Failed to open database: %v", err)
+			}
+			defer db.Close()
+
+			if db.HasReadPool() {
+				t.Error("did not expect a separate read pool")
+			}
+			if db.Reader() != db.Conn() {
+				t.Error("Reader() should fall back to the writer connection")
+			}
+		})
+	}
+}
+
+func TestSplitPool_MultiStepWritesDoNotDeadlock(t *testing.T) {
+	service := setupTestService(t)
+	if !service.db.HasReadPool() {
+		t.Fatal("expected setupTestService to open a split read/write database")
+	}
+
+	// With a single writer connection, any helper that holds a transaction
+	// while writing through the pool blocks until the context expires.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	match := &Match{
+		ID:           "split-pool-match",
+		AccountID:    1,
+		EventID:      "Ladder",
+		EventName:    "Ladder",
+		Timestamp:    time.Now(),
+		PlayerWins:   2,
+		PlayerTeamID: 1,
+		Format:       "Ladder",
+		Result:       "win",
+	}
+	games := []*Game{{MatchID: match.ID, GameNumber: 1, Result: "win", CreatedAt: time.Now()}}
+	if err := service.StoreMatch(ctx, match, games); err != nil {
+		t.Fatalf("StoreMatch failed: %v", err)
+	}
+
+	deck := &models.Deck{
+		ID:         "split-pool-deck",
+		AccountID:  1,
+		Name:       "Split Pool",
+		Format:     "Standard",
+		Source:     "constructed",
+		CreatedAt:  time.Now(),
+		ModifiedAt: time.Now(),
+	}
+	if err := service.StoreDeck(ctx, deck, []*models.DeckCard{{DeckID: deck.ID, CardID: 12345, Quantity: 4, Board: "main"}}); err != nil {
+		t.Fatalf("StoreDeck failed: %v", err)
+	}
+}
+
+func TestStoreDeck_RollsBackOnFailure(t *testing.T) {
+	service := setupTestService(t)
+	ctx := context.Background()
+
+	deck := &models.Deck{
+		ID:         "atomic-deck",
+		AccountID:  1,
+		Name:       "Atomic",
+		Format:     "Standard",
+		Source:     "constructed",
+		CreatedAt:  time.Now(),
+		ModifiedAt: time.Now(),
+	}
+	if err := service.StoreDeck(ctx, deck, []*models.DeckCard{{DeckID: deck.ID, CardID: 12345, Quantity: 4, Board: "main"}}); err != nil {
+		t.Fatalf("StoreDeck failed: %v", err)
+	}
+
+	// The second card violates the board CHECK constraint after the old cards are cleared
+	err := service.StoreDeck(ctx, deck, []*models.DeckCard{
+		{DeckID: deck.ID, CardID: 67890, Quantity: 4, Board: "main"},
+		{DeckID: deck.ID, CardID: 11111, Quantity: 1, Board: "maybeboard"},
+	})
+	if err == nil {
+		t.Fatal("expected StoreDeck to fail on an invalid board")
+	}
+
+	cards, err := service.DeckRepo().GetCards(ctx, deck.ID)
+	if err != nil {
+		t.Fatalf("GetCards failed: %v", err)
+	}
+	if len(cards) != 1 || cards[0].CardID != 12345 {
+		t.Errorf("expected the original card to survive the failed store, got %+v", cards)
+	}
+}
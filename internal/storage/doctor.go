@@ -0,0 +1,277 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+)
+
+// Doctor finding severities.
+const (
+	DoctorSeverityError   = "error"   // Data is wrong or the file is damaged
+	DoctorSeverityWarning = "warning" // Leftover or duplicated rows that skew results
+	DoctorSeverityInfo    = "info"    // Gaps that reduce accuracy but are expected over time
+)
+
+// doctorSampleLimit caps how many identifying keys a finding lists.
+const doctorSampleLimit = 10
+
+// DoctorOptions controls a database health check.
+type DoctorOptions struct {
+	// Fix applies the safe repairs for any findings that have one.
+	Fix bool
+
+	// FutureTolerance is how far past now a match timestamp may be before it is
+	// reported, allowing for clock skew between MTGA and this machine.
+	FutureTolerance time.Duration
+
+	// MetadataStaleAge is the age after which cached card metadata is reported as stale.
+	MetadataStaleAge time.Duration
+}
+
+// DefaultDoctorOptions returns report-only options with the default thresholds.
+func DefaultDoctorOptions() DoctorOptions {
+	return DoctorOptions{
+		FutureTolerance:  24 * time.Hour,
+		MetadataStaleAge: 7 * 24 * time.Hour, // Same threshold as the card metadata refresher
+	}
+}
+
+// DoctorFinding is the outcome of a single health check.
+type DoctorFinding struct {
+	Check       string   `json:"check"`
+	Severity    string   `json:"severity"`
+	Description string   `json:"description"`
+	Count       int      `json:"count"`
+	Samples     []string `json:"samples,omitempty"`
+	Fixable     bool     `json:"fixable"`
+	Fixed       int      `json:"fixed"`
+	FixError    string   `json:"fix_error,omitempty"`
+	Hint        string   `json:"hint,omitempty"` // Manual remedy when there is no safe automatic fix
+}
+
+// OK reports whether the check found nothing.
+func (f *DoctorFinding) OK() bool {
+	return f.Count == 0
+}
+
+// DoctorReport is the result of a database health check.
+type DoctorReport struct {
+	CheckedAt    time.Time        `json:"checked_at"`
+	FixRequested bool             `json:"fix_requested"`
+	FixSkipped   string           `json:"fix_skipped,omitempty"` // Why requested fixes were not applied
+	Findings     []*DoctorFinding `json:"findings"`
+}
+
+// Healthy reports whether no error or warning findings remain after any fixes.
+func (r *DoctorReport) Healthy() bool {
+	for _, f := range r.Findings {
+		if f.Severity != DoctorSeverityInfo && f.Count > f.Fixed {
+			return false
+		}
+	}
+	return true
+}
+
+// doctorCheck pairs a finder with its optional safe repair.
+type doctorCheck struct {
+	name        string
+	severity    string
+	description string
+	hint        string
+	find        func(ctx context.Context) (*models.IntegrityIssue, error)
+	fix         func(ctx context.Context) (int, error)
+}
+
+// RunDoctor checks the database for structural damage and inconsistent rows.
+// With opts.Fix set it also applies the safe repairs, unless SQLite's own
+// integrity check fails, in which case the file should be restored from a
+// backup before anything writes to it.
+func (s *Service) RunDoctor(ctx context.Context, opts DoctorOptions) (*DoctorReport, error) {
+	defaults := DefaultDoctorOptions()
+	if opts.FutureTolerance <= 0 {
+		opts.FutureTolerance = defaults.FutureTolerance
+	}
+	if opts.MetadataStaleAge <= 0 {
+		opts.MetadataStaleAge = defaults.MetadataStaleAge
+	}
+
+	report := &DoctorReport{
+		CheckedAt:    time.Now(),
+		FixRequested: opts.Fix,
+	}
+
+	problems, err := s.integrity.IntegrityCheck(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.Findings = append(report.Findings, &DoctorFinding{
+		Check:       "integrity_check",
+		Severity:    DoctorSeverityError,
+		Description: "SQLite integrity check",
+		Count:       len(problems),
+		Samples:     problems,
+		Hint:        "Restore from a backup with 'mtga-companion backup restore'",
+	})
+
+	applyFixes := opts.Fix
+	if opts.Fix && len(problems) > 0 {
+		applyFixes = false
+		report.FixSkipped = "database file failed the integrity check"
+	}
+
+	for _, check := range s.doctorChecks(report.CheckedAt, opts) {
+		issue, err := check.find(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to run %s check: %w", check.name, err)
+		}
+
+		finding := &DoctorFinding{
+			Check:       check.name,
+			Severity:    check.severity,
+			Description: check.description,
+			Count:       issue.Count,
+			Samples:     issue.Samples,
+			Fixable:     check.fix != nil,
+		}
+		if check.fix == nil {
+			finding.Hint = check.hint
+		}
+
+		if applyFixes && finding.Fixable && finding.Count > 0 {
+			fixed, err := check.fix(ctx)
+			if err != nil {
+				finding.FixError = err.Error()
+			}
+			finding.Fixed = fixed
+		}
+
+		report.Findings = append(report.Findings, finding)
+	}
+
+	return report, nil
+}
+
+// doctorChecks lists the row-level checks in the order they run. Duplicate
+// matches come before orphan games because removing a duplicate can orphan
+// its play-by-play rows.
+func (s *Service) doctorChecks(now time.Time, opts DoctorOptions) []doctorCheck {
+	return []doctorCheck{
+		{
+			name:        "duplicate_matches",
+			severity:    DoctorSeverityWarning,
+			description: "Matches recorded more than once under different IDs",
+			find:        s.integrity.FindDuplicateMatches,
+			fix:         s.integrity.DeleteDuplicateMatches,
+		},
+		{
+			name:        "orphan_games",
+			severity:    DoctorSeverityWarning,
+			description: "Games and play-by-play rows whose match or game no longer exists",
+			find:        s.integrity.FindOrphanGames,
+			fix:         s.integrity.DeleteOrphanGames,
+		},
+		{
+			name:        "orphan_draft_picks",
+			severity:    DoctorSeverityWarning,
+			description: "Draft picks and packs whose draft session no longer exists",
+			find:        s.integrity.FindOrphanDraftPicks,
+			fix:         s.integrity.DeleteOrphanDraftPicks,
+		},
+		{
+			name:        "orphan_deck_cards",
+			severity:    DoctorSeverityWarning,
+			description: "Deck cards whose deck no longer exists",
+			find:        s.integrity.FindOrphanDeckCards,
+			fix:         s.integrity.DeleteOrphanDeckCards,
+		},
+		{
+			name:        "unknown_deck_cards",
+			severity:    DoctorSeverityInfo,
+			description: "Deck cards with arena IDs missing from the card cache",
+			hint:        "Refresh card metadata for the sets these decks use",
+			find:        s.integrity.FindUnknownDeckCards,
+		},
+		{
+			name:        "negative_wins",
+			severity:    DoctorSeverityError,
+			description: "Matches with a negative win count",
+			hint:        "Delete the affected matches and replay their logs",
+			find:        s.integrity.FindNegativeWins,
+		},
+		{
+			name:        "future_matches",
+			severity:    DoctorSeverityError,
+			description: "Matches timestamped in the future",
+			hint:        "Check the system clock, then delete the affected matches and replay their logs",
+			find: func(ctx context.Context) (*models.IntegrityIssue, error) {
+				return s.integrity.FindFutureMatches(ctx, now.Add(opts.FutureTolerance))
+			},
+		},
+		{
+			name:        "matches_without_deck",
+			severity:    DoctorSeverityInfo,
+			description: "Matches not linked to a deck",
+			find:        s.findMatchesWithoutDeck,
+			fix:         s.InferDeckIDsForMatches,
+		},
+		{
+			name:        "stale_card_metadata",
+			severity:    DoctorSeverityInfo,
+			description: fmt.Sprintf("Cached card metadata older than %s", formatDoctorAge(opts.MetadataStaleAge)),
+			hint:        "Refresh card metadata for the affected sets",
+			find: func(ctx context.Context) (*models.IntegrityIssue, error) {
+				return s.findStaleCardMetadata(ctx, opts.MetadataStaleAge)
+			},
+		},
+	}
+}
+
+// findMatchesWithoutDeck adapts the match repository's deck-link query to a doctor finding.
+func (s *Service) findMatchesWithoutDeck(ctx context.Context) (*models.IntegrityIssue, error) {
+	matches, err := s.matches.GetMatchesWithoutDeckID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches without deck IDs: %w", err)
+	}
+
+	issue := &models.IntegrityIssue{Count: len(matches)}
+	for i := 0; i < len(matches) && i < doctorSampleLimit; i++ {
+		issue.Samples = append(issue.Samples, matches[i].ID)
+	}
+	return issue, nil
+}
+
+// findStaleCardMetadata adapts the set card repository's staleness queries to a doctor finding.
+func (s *Service) findStaleCardMetadata(ctx context.Context, staleAge time.Duration) (*models.IntegrityIssue, error) {
+	seconds := int(staleAge.Seconds())
+
+	staleness, err := s.setCard.GetMetadataStaleness(ctx, seconds, seconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata staleness: %w", err)
+	}
+
+	issue := &models.IntegrityIssue{Count: staleness.Total - staleness.Fresh}
+	if issue.Count == 0 {
+		return issue, nil
+	}
+
+	staleCards, err := s.setCard.GetStaleCards(ctx, seconds, doctorSampleLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stale cards: %w", err)
+	}
+	for _, card := range staleCards {
+		issue.Samples = append(issue.Samples, fmt.Sprintf("%s (%s, fetched %s)", card.ArenaID, card.SetCode, card.LastUpdated))
+	}
+	return issue, nil
+}
+
+// formatDoctorAge formats whole-day durations as days and anything else with time.Duration.
+func formatDoctorAge(d time.Duration) string {
+	const day = 24 * time.Hour
+	if d >= day && d%day == 0 {
+		return fmt.Sprintf("%d days", d/day)
+	}
+	return d.String()
+}
@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+)
+
+// seedDoctorFixtures inserts one healthy match plus one row for each problem the doctor detects.
+// Matches go through the match repository so timestamps are stored as the log processor stores them.
+func seedDoctorFixtures(t *testing.T, service *Service) {
+	t.Helper()
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	ts := now.Add(-time.Hour)
+	stale := now.Add(-30 * 24 * time.Hour).Format("2006-01-02 15:04:05")
+	opponent := "opp-1"
+
+	matches := []*models.Match{
+		// Healthy match, and an exact duplicate under another ID
+		{ID: "m-ok", AccountID: 1, EventID: "Ladder", EventName: "Ladder", Timestamp: ts, PlayerWins: 2, OpponentWins: 1, PlayerTeamID: 1, Format: "Standard", Result: "win", OpponentID: &opponent, CreatedAt: now},
+		{ID: "m-dup", AccountID: 1, EventID: "Ladder", EventName: "Ladder", Timestamp: ts, PlayerWins: 2, OpponentWins: 1, PlayerTeamID: 1, Format: "Standard", Result: "win", OpponentID: &opponent, CreatedAt: now},
+
+		// Impossible values
+		{ID: "m-neg", AccountID: 1, EventID: "Ladder", EventName: "Ladder", Timestamp: ts, PlayerWins: -1, OpponentWins: 0, PlayerTeamID: 1, Format: "Standard", Result: "loss", CreatedAt: now},
+		{ID: "m-future", AccountID: 1, EventID: "Play", EventName: "Play", Timestamp: now.Add(72 * time.Hour), PlayerWins: 1, OpponentWins: 0, PlayerTeamID: 1, Format: "Standard", Result: "win", CreatedAt: now},
+	}
+	for _, match := range matches {
+		if err := service.matches.Create(ctx, match); err != nil {
+			t.Fatalf("failed to seed match %s: %v", match.ID, err)
+		}
+	}
+
+	stmts := []struct {
+		query string
+		args  []interface{}
+	}{
+		{`INSERT INTO games (match_id, game_number, result) VALUES ('m-ok', 1, 'win')`, nil},
+		{`INSERT INTO games (match_id, game_number, result) VALUES ('m-dup', 1, 'win')`, nil},
+
+		// Orphans
+		{`INSERT INTO games (match_id, game_number, result) VALUES ('m-gone', 1, 'loss')`, nil},
+		{`INSERT INTO game_plays (game_id, match_id, turn_number, player_type, action_type, timestamp, sequence_number)
+			VALUES (9999, 'm-gone', 1, 'player', 'land_drop', ?, 1)`, []interface{}{ts}},
+		{`INSERT INTO draft_picks (session_id, pack_number, pick_number, card_id, timestamp)
+			VALUES ('draft-gone', 0, 1, '12345', ?)`, []interface{}{ts}},
+		{`INSERT INTO deck_cards (deck_id, card_id, quantity, board) VALUES ('deck-gone', 12345, 4, 'main')`, nil},
+
+		// Cached card metadata, one row refreshed long ago
+		{`INSERT INTO set_cards (set_code, arena_id, scryfall_id, name, fetched_at) VALUES ('TST', '12345', 'sf-1', 'Test Card', ?)`, []interface{}{stale}},
+	}
+
+	conn := service.GetDB()
+	for _, stmt := range stmts {
+		if _, err := conn.Exec(stmt.query, stmt.args...); err != nil {
+			t.Fatalf("failed to seed fixture %q: %v", stmt.query, err)
+		}
+	}
+}
+
+func findingByCheck(t *testing.T, report *DoctorReport, check string) *DoctorFinding {
+	t.Helper()
+	for _, f := range report.Findings {
+		if f.Check == check {
+			return f
+		}
+	}
+	t.Fatalf("report has no %s finding", check)
+	return nil
+}
+
+func TestRunDoctor_CleanDatabase(t *testing.T) {
+	service := setupTestService(t)
+
+	report, err := service.RunDoctor(context.Background(), DefaultDoctorOptions())
+	if err != nil {
+		t.Fatalf("RunDoctor failed: %v", err)
+	}
+
+	if !report.Healthy() {
+		t.Errorf("expected a fresh database to be healthy, got %+v", report.Findings)
+	}
+	for _, f := range report.Findings {
+		if !f.OK() {
+			t.Errorf("%s: expected no findings, got %d (%v)", f.Check, f.Count, f.Samples)
+		}
+	}
+}
+
+func TestRunDoctor_ReportOnly(t *testing.T) {
+	service := setupTestService(t)
+	seedDoctorFixtures(t, service)
+	ctx := context.Background()
+
+	report, err := service.RunDoctor(ctx, DefaultDoctorOptions())
+	if err != nil {
+		t.Fatalf("RunDoctor failed: %v", err)
+	}
+
+	if report.Healthy() {
+		t.Error("expected seeded database to be unhealthy")
+	}
+
+	want := map[string]int{
+		"integrity_check":     0,
+		"duplicate_matches":   1,
+		"orphan_games":        2, // one game, one play
+		"orphan_draft_picks":  1,
+		"orphan_deck_cards":   1,
+		"unknown_deck_cards":  0,
+		"negative_wins":       1,
+		"future_matches":      1,
+		"stale_card_metadata": 1,
+	}
+	for check, count := range want {
+		f := findingByCheck(t, report, check)
+		if f.Count != count {
+			t.Errorf("%s: count = %d, want %d (samples %v)", check, f.Count, count, f.Samples)
+		}
+		if f.Fixed != 0 {
+			t.Errorf("%s: report-only run fixed %d rows", check, f.Fixed)
+		}
+	}
+
+	if got := findingByCheck(t, report, "duplicate_matches").Samples; len(got) != 1 || got[0] != "m-dup" {
+		t.Errorf("duplicate_matches samples = %v, want [m-dup]", got)
+	}
+
+	var matches int
+	if err := service.GetDB().QueryRow("SELECT COUNT(*) FROM matches").Scan(&matches); err != nil {
+		t.Fatalf("failed to count matches: %v", err)
+	}
+	if matches != 4 {
+		t.Errorf("report-only run changed matches: have %d, want 4", matches)
+	}
+}
+
+func TestRunDoctor_Fix(t *testing.T) {
+	service := setupTestService(t)
+	seedDoctorFixtures(t, service)
+	ctx := context.Background()
+
+	opts := DefaultDoctorOptions()
+	opts.Fix = true
+	report, err := service.RunDoctor(ctx, opts)
+	if err != nil {
+		t.Fatalf("RunDoctor failed: %v", err)
+	}
+
+	for _, check := range []string{"duplicate_matches", "orphan_draft_picks", "orphan_deck_cards"} {
+		f := findingByCheck(t, report, check)
+		if f.Fixed != f.Count || f.FixError != "" {
+			t.Errorf("%s: fixed %d of %d (error %q)", check, f.Fixed, f.Count, f.FixError)
+		}
+	}
+	// The duplicate's game is removed with it, so orphan cleanup only sees the original two rows
+	if f := findingByCheck(t, report, "orphan_games"); f.Fixed != 2 {
+		t.Errorf("orphan_games: fixed %d, want 2", f.Fixed)
+	}
+
+	// Findings without a safe fix are left for the user
+	for _, check := range []string{"negative_wins", "future_matches"} {
+		f := findingByCheck(t, report, check)
+		if f.Fixable || f.Fixed != 0 || f.Hint == "" {
+			t.Errorf("%s: expected an unfixed finding with a hint, got %+v", check, f)
+		}
+	}
+
+	// A second pass finds nothing left to repair
+	again, err := service.RunDoctor(ctx, DefaultDoctorOptions())
+	if err != nil {
+		t.Fatalf("second RunDoctor failed: %v", err)
+	}
+	for _, check := range []string{"duplicate_matches", "orphan_games", "orphan_draft_picks", "orphan_deck_cards"} {
+		if f := findingByCheck(t, again, check); !f.OK() {
+			t.Errorf("%s: %d rows remain after fix", check, f.Count)
+		}
+	}
+
+	if match, err := service.GetMatchByID(ctx, "m-ok"); err != nil || match == nil {
+		t.Errorf("original match should survive duplicate cleanup (err %v)", err)
+	}
+}
@@ -202,7 +202,7 @@ func (s *Service) GetCardRatingsForSet(ctx context.Context, expansion, format, c
 		ORDER BY gihwr DESC
 	`
 
-	rows, err := s.db.Conn().QueryContext(ctx, query, expansion, format, colors)
+	rows, err := s.db.Reader().QueryContext(ctx, query, expansion, format, colors)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query card ratings: %w", err)
 	}
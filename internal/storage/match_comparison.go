@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 )
 
@@ -14,6 +15,7 @@ type ComparisonGroup struct {
 	Filter     models.StatsFilter // Filter defining this group
 	Statistics *models.Statistics // Aggregated statistics for this group
 	MatchCount int                // Number of matches in this group
+	WinRate    stats.Proportion   // Match win rate with confidence intervals
 }
 
 // ComparisonResult represents the result of comparing two or more groups.
@@ -22,6 +24,7 @@ type ComparisonResult struct {
 	BestGroup      *ComparisonGroup // Group with highest win rate
 	WorstGroup     *ComparisonGroup // Group with lowest win rate
 	WinRateDiff    float64          // Difference between best and worst
+	Significance   stats.Comparison // Whether best and worst genuinely differ
 	TotalMatches   int              // Total matches across all groups
 	ComparisonDate time.Time        // When comparison was performed
 }
@@ -34,7 +37,8 @@ type ComparisonDiff struct {
 	GameWinRateDiff float64
 	MatchCountDiff  int
 	GamesPlayedDiff int
-	Trend           string // "improving", "declining", "stable"
+	Trend           string           // "improving", "declining", "stable"
+	Significance    stats.Comparison // Group1 vs Group2 match win rate
 }
 
 // TimePeriod represents a labeled time period for comparison.
@@ -61,15 +65,16 @@ func (s *Service) CompareMatches(ctx context.Context, groups []ComparisonGroup)
 		group := &groups[i]
 
 		// Get statistics for this group
-		stats, err := s.GetStats(ctx, group.Filter)
+		groupStats, err := s.GetStats(ctx, group.Filter)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get stats for group %s: %w", group.Label, err)
 		}
 
-		group.Statistics = stats
-		group.MatchCount = stats.TotalMatches
+		group.Statistics = groupStats
+		group.MatchCount = groupStats.TotalMatches
+		group.WinRate = stats.NewProportion(groupStats.MatchesWon, groupStats.TotalMatches)
 		result.Groups = append(result.Groups, group)
-		result.TotalMatches += stats.TotalMatches
+		result.TotalMatches += groupStats.TotalMatches
 	}
 
 	// Find best and worst performing groups
@@ -87,6 +92,10 @@ func (s *Service) CompareMatches(ctx context.Context, groups []ComparisonGroup)
 		}
 
 		result.WinRateDiff = result.BestGroup.Statistics.WinRate - result.WorstGroup.Statistics.WinRate
+		result.Significance = stats.Compare(
+			result.BestGroup.Statistics.MatchesWon, result.BestGroup.Statistics.TotalMatches,
+			result.WorstGroup.Statistics.MatchesWon, result.WorstGroup.Statistics.TotalMatches,
+		)
 	}
 
 	return result, nil
@@ -112,6 +121,7 @@ func (s *Service) CompareTwoGroups(ctx context.Context, group1, group2 Compariso
 		GameWinRateDiff: stats1.GameWinRate - stats2.GameWinRate,
 		MatchCountDiff:  stats1.TotalMatches - stats2.TotalMatches,
 		GamesPlayedDiff: stats1.TotalGames - stats2.TotalGames,
+		Significance:    stats.Compare(stats1.MatchesWon, stats1.TotalMatches, stats2.MatchesWon, stats2.TotalMatches),
 	}
 
 	// Determine trend
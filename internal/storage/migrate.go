@@ -8,8 +8,9 @@ import (
 	"path/filepath"
 
 	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 //go:embed migrations/*.sql
@@ -41,7 +42,7 @@ func NewMigrationManager(dbPath string) (*MigrationManager, error) {
 	if filepath.IsAbs(dbPath) && normalizedPath[0] != '/' {
 		normalizedPath = "/" + normalizedPath
 	}
-	databaseURL := fmt.Sprintf("sqlite://%s", normalizedPath)
+	databaseURL := fmt.Sprintf("%s://%s", sqlitedriver.MigrateScheme, normalizedPath)
 
 	// Create migrate instance
 	m, err := migrate.NewWithSourceInstance("iofs", sourceDriver, databaseURL)
@@ -0,0 +1,9 @@
+-- Remove context-aware pick scores from draft_picks table
+
+-- Note: SQLite doesn't support DROP COLUMN directly
+-- For now, just set columns to NULL in down migration
+
+UPDATE draft_picks SET
+    pick_score = NULL,
+    pack_best_score = NULL,
+    score_breakdown_json = NULL;
@@ -0,0 +1,5 @@
+-- Add context-aware pick scores to draft_picks table
+-- Scores account for the pool drafted so far (colors, archetype, role needs, pick position)
+ALTER TABLE draft_picks ADD COLUMN pick_score REAL;
+ALTER TABLE draft_picks ADD COLUMN pack_best_score REAL;
+ALTER TABLE draft_picks ADD COLUMN score_breakdown_json TEXT;
@@ -0,0 +1,3 @@
+-- Rollback: Remove per-step game state for replays
+DROP INDEX IF EXISTS idx_game_replay_frames_match_id;
+DROP TABLE IF EXISTS game_replay_frames;
@@ -0,0 +1,22 @@
+-- Migration: Add per-step game state for replays
+-- Each row is one phase or step of a game. Keyframes hold the full object list;
+-- other rows hold only the objects that changed and the instance IDs removed
+-- since the previous frame.
+CREATE TABLE IF NOT EXISTS game_replay_frames (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_id TEXT NOT NULL,
+    game_number INTEGER NOT NULL,
+    frame_index INTEGER NOT NULL,           -- Order within the game
+    turn_number INTEGER NOT NULL,
+    phase TEXT,                             -- Main1, Combat, Main2, etc.
+    step TEXT,                              -- BeginCombat, DeclareAttackers, etc.
+    active_player TEXT,                     -- 'player' or 'opponent'
+    player_life INTEGER,
+    opponent_life INTEGER,
+    is_keyframe BOOLEAN NOT NULL DEFAULT 0, -- objects_json holds the full state
+    objects_json TEXT NOT NULL,             -- Full state or changes since the previous frame
+    timestamp TIMESTAMP NOT NULL,
+    UNIQUE(match_id, game_number, frame_index)
+);
+
+CREATE INDEX idx_game_replay_frames_match_id ON game_replay_frames(match_id);
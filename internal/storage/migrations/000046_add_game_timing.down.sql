@@ -0,0 +1,7 @@
+-- Rollback: Remove turn, decision and timer data
+DROP INDEX IF EXISTS idx_timer_events_match_id;
+DROP INDEX IF EXISTS idx_decision_timings_match_id;
+DROP INDEX IF EXISTS idx_turn_timings_match_id;
+DROP TABLE IF EXISTS timer_events;
+DROP TABLE IF EXISTS decision_timings;
+DROP TABLE IF EXISTS turn_timings;
@@ -0,0 +1,44 @@
+-- Migration: Add turn, decision and timer data from GRE messages
+-- Rows are written while a game is in progress, before the match itself is
+-- stored, so they are keyed by match ID without a foreign key.
+CREATE TABLE IF NOT EXISTS turn_timings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_id TEXT NOT NULL,
+    game_number INTEGER NOT NULL,
+    turn_number INTEGER NOT NULL,
+    active_player TEXT,                     -- 'player' or 'opponent'
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP NOT NULL,            -- Last activity seen in the turn
+    UNIQUE(match_id, game_number, turn_number)
+);
+
+-- How long each player held a decision or priority
+CREATE TABLE IF NOT EXISTS decision_timings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_id TEXT NOT NULL,
+    game_number INTEGER NOT NULL,
+    turn_number INTEGER NOT NULL,
+    player_type TEXT NOT NULL,              -- 'player' or 'opponent'
+    phase TEXT,
+    step TEXT,
+    started_at TIMESTAMP NOT NULL,
+    duration_ms INTEGER NOT NULL,
+    UNIQUE(match_id, game_number, player_type, started_at)
+);
+
+-- Rope warnings and timeouts
+CREATE TABLE IF NOT EXISTS timer_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_id TEXT NOT NULL,
+    game_number INTEGER NOT NULL,
+    turn_number INTEGER NOT NULL,
+    player_type TEXT NOT NULL,
+    event_type TEXT NOT NULL CHECK(event_type IN ('rope', 'timeout')),
+    elapsed_ms INTEGER NOT NULL DEFAULT 0,  -- Time on the decision when it happened
+    timestamp TIMESTAMP NOT NULL,
+    UNIQUE(match_id, game_number, turn_number, player_type, event_type)
+);
+
+CREATE INDEX idx_turn_timings_match_id ON turn_timings(match_id);
+CREATE INDEX idx_decision_timings_match_id ON decision_timings(match_id);
+CREATE INDEX idx_timer_events_match_id ON timer_events(match_id);
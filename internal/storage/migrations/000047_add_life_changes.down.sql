@@ -0,0 +1,3 @@
+-- Rollback: Remove life total changes
+DROP INDEX IF EXISTS idx_life_changes_match_id;
+DROP TABLE IF EXISTS life_changes;
@@ -0,0 +1,22 @@
+-- Migration: Add life total changes attributed to their sources
+-- Rows come from GRE annotations while a game is in progress, before the match
+-- itself is stored, so they are keyed by match ID without a foreign key.
+CREATE TABLE IF NOT EXISTS life_changes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_id TEXT NOT NULL,
+    game_number INTEGER NOT NULL,
+    turn_number INTEGER NOT NULL,
+    phase TEXT,
+    step TEXT,
+    affected_player TEXT NOT NULL,          -- 'player' or 'opponent'
+    amount INTEGER NOT NULL,                -- Negative for damage and life loss
+    life_after INTEGER,
+    change_type TEXT NOT NULL CHECK(change_type IN ('combat', 'burn', 'drain', 'lifelink', 'life_gain', 'other')),
+    source_card_id INTEGER,                 -- Arena card ID of the source
+    source_controller TEXT,                 -- 'player' or 'opponent'
+    annotation_id INTEGER NOT NULL DEFAULT 0, -- 0 when no annotation explained the change
+    timestamp TIMESTAMP NOT NULL,
+    UNIQUE(match_id, game_number, annotation_id, affected_player, timestamp)
+);
+
+CREATE INDEX idx_life_changes_match_id ON life_changes(match_id);
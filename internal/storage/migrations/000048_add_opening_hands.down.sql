@@ -0,0 +1,3 @@
+-- Rollback: Remove opening hands
+DROP INDEX IF EXISTS idx_opening_hands_match_id;
+DROP TABLE IF EXISTS opening_hands;
@@ -0,0 +1,16 @@
+-- Migration: Add opening hands offered at each mulligan prompt
+-- Hands are captured from the GRE stream while a game is in progress, before
+-- the match itself is stored, so they are keyed by match ID without a foreign key.
+CREATE TABLE IF NOT EXISTS opening_hands (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_id TEXT NOT NULL,
+    game_number INTEGER NOT NULL,
+    mulligan_count INTEGER NOT NULL DEFAULT 0, -- Mulligans taken before this hand was drawn
+    card_ids TEXT NOT NULL,                    -- JSON array of Arena card IDs drawn
+    on_play BOOLEAN,                           -- NULL when the starting player was not seen
+    decision TEXT CHECK(decision IN ('keep', 'mulligan')), -- NULL until the decision is seen
+    timestamp TIMESTAMP NOT NULL,
+    UNIQUE(match_id, game_number, mulligan_count)
+);
+
+CREATE INDEX idx_opening_hands_match_id ON opening_hands(match_id);
@@ -0,0 +1,8 @@
+-- Remove the simulated draft flag
+
+-- Note: SQLite doesn't support DROP COLUMN directly
+-- For now, drop the index and clear the flag in down migration
+
+DROP INDEX IF EXISTS idx_draft_sessions_simulated_status;
+
+UPDATE draft_sessions SET is_simulated = 0;
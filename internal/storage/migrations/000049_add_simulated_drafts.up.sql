@@ -0,0 +1,8 @@
+-- Flag practice drafts played against bots
+-- Stats, exports, model training and the live draft views skip simulated sessions
+ALTER TABLE draft_sessions ADD COLUMN is_simulated INTEGER NOT NULL DEFAULT 0;
+
+-- Practice drafts were previously told apart only by their "sim_" ID prefix
+UPDATE draft_sessions SET is_simulated = 1 WHERE id LIKE 'sim\_%' ESCAPE '\';
+
+CREATE INDEX IF NOT EXISTS idx_draft_sessions_simulated_status ON draft_sessions(is_simulated, status);
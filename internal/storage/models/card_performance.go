@@ -66,6 +66,47 @@ type DeckPerformanceAnalysis struct {
 	AnalysisDate    string   `json:"analysis_date"`
 }
 
+// WinRateMetric is a win rate over the games matching one 17Lands-style condition.
+type WinRateMetric struct {
+	Games    int     `json:"games"`
+	Wins     int     `json:"wins"`
+	WinRate  float64 `json:"win_rate"` // Raw wins / games
+	Adjusted float64 `json:"adjusted"` // Shrunk toward 50% with Bayesian averaging
+	CILow    float64 `json:"ci_low"`   // 95% Wilson interval on the raw rate
+	CIHigh   float64 `json:"ci_high"`
+}
+
+// CardGameMetrics contains 17Lands-style in-game metrics for one card in a deck.
+type CardGameMetrics struct {
+	CardID   int    `json:"card_id"`
+	CardName string `json:"card_name"`
+
+	GamesInHand WinRateMetric `json:"gih"` // Opening hand or drawn
+	OpeningHand WinRateMetric `json:"oh"`
+	GameDrawn   WinRateMetric `json:"gd"`  // Drawn after the opening hand
+	NotSeen     WinRateMetric `json:"gns"` // Games the card never reached hand
+	IWD         float64       `json:"iwd"` // Improvement when drawn: adjusted GIH minus adjusted GNS
+
+	AvgTurnPlayed  float64     `json:"avg_turn_played"`
+	TurnPlayedDist map[int]int `json:"turn_played_dist"`
+}
+
+// DeckCardGameMetrics contains in-game card metrics for a deck or one of its versions.
+type DeckCardGameMetrics struct {
+	DeckID        string             `json:"deck_id"`
+	PermutationID *int               `json:"permutation_id,omitempty"`
+	TotalGames    int                `json:"total_games"` // Games with recorded plays
+	GamesWon      int                `json:"games_won"`
+	Cards         []*CardGameMetrics `json:"cards"`
+}
+
+// CardGameMetricsFilter selects the games in-game card metrics are computed over.
+type CardGameMetricsFilter struct {
+	DeckID        string `json:"deck_id"`
+	PermutationID *int   `json:"permutation_id,omitempty"` // Only games played with this deck version
+	IncludeLands  bool   `json:"include_lands"`
+}
+
 // CardPerformanceFilter provides filtering options for performance queries.
 type CardPerformanceFilter struct {
 	DeckID       string `json:"deck_id"`
@@ -39,6 +39,56 @@ type GameStateSnapshot struct {
 	Timestamp           time.Time `json:"timestamp" db:"timestamp"`
 }
 
+// ReplayObject is a game object in a reconstructed game: a card or token in
+// any zone, with its combat and counter state.
+type ReplayObject struct {
+	InstanceID    int            `json:"instance_id"`
+	CardID        int            `json:"card_id"`    // Arena card ID (0 for hidden cards)
+	Controller    string         `json:"controller"` // "player" or "opponent"
+	Owner         string         `json:"owner"`
+	Zone          string         `json:"zone"` // hand, library, battlefield, graveyard, exile, stack, etc.
+	CardTypes     []string       `json:"card_types,omitempty"`
+	Power         int            `json:"power,omitempty"`
+	Toughness     int            `json:"toughness,omitempty"`
+	IsTapped      bool           `json:"is_tapped,omitempty"`
+	IsAttacking   bool           `json:"is_attacking,omitempty"`
+	IsBlocking    bool           `json:"is_blocking,omitempty"`
+	SummoningSick bool           `json:"summoning_sick,omitempty"`
+	Counters      map[string]int `json:"counters,omitempty"` // Counter type to count
+}
+
+// ReplayStep is the change to a game's state over one phase or step, as
+// recorded from the GRE.
+type ReplayStep struct {
+	MatchID      string         `json:"match_id"`
+	GameNumber   int            `json:"game_number"`
+	TurnNumber   int            `json:"turn_number"`
+	Phase        string         `json:"phase"`
+	Step         string         `json:"step,omitempty"`
+	ActivePlayer string         `json:"active_player"`
+	PlayerLife   *int           `json:"player_life,omitempty"` // Nil when unchanged
+	OpponentLife *int           `json:"opponent_life,omitempty"`
+	Full         bool           `json:"full,omitempty"`    // Objects replaces the whole game state
+	Objects      []ReplayObject `json:"objects,omitempty"` // Objects added or changed
+	Removed      []int          `json:"removed,omitempty"` // Instance IDs that left the game state
+	Timestamp    time.Time      `json:"timestamp"`
+}
+
+// ReplayFrame is a game's complete state at the end of one phase or step.
+type ReplayFrame struct {
+	MatchID      string         `json:"match_id"`
+	GameNumber   int            `json:"game_number"`
+	FrameIndex   int            `json:"frame_index"` // Order within the game
+	TurnNumber   int            `json:"turn_number"`
+	Phase        string         `json:"phase"`
+	Step         string         `json:"step,omitempty"`
+	ActivePlayer string         `json:"active_player"`
+	PlayerLife   *int           `json:"player_life,omitempty"`
+	OpponentLife *int           `json:"opponent_life,omitempty"`
+	Objects      []ReplayObject `json:"objects"`
+	Timestamp    time.Time      `json:"timestamp"`
+}
+
 // OpponentCardObserved tracks cards revealed by the opponent during a game.
 type OpponentCardObserved struct {
 	ID            int     `json:"id" db:"id"`
@@ -80,6 +130,76 @@ type GamePlaySummary struct {
 	LandDrops         int    `json:"land_drops"`
 	TotalTurns        int    `json:"total_turns"`
 	OpponentCardsSeen int    `json:"opponent_cards_seen"`
+
+	// Life flow, from GRE annotations
+	DamageDealt        int              `json:"damage_dealt"` // Damage and life loss dealt to the opponent
+	DamageTaken        int              `json:"damage_taken"`
+	LifeGained         int              `json:"life_gained"`
+	OpponentLifeGained int              `json:"opponent_life_gained"`
+	DamageBySource     []*DamageSource  `json:"damage_by_source,omitempty"` // Most damage first
+	KilledBy           []*LethalSummary `json:"killed_by,omitempty"`        // One per game we died in
+}
+
+// LifeChange is one change to a player's life total, attributed to its source.
+type LifeChange struct {
+	ID               int       `json:"id"`
+	MatchID          string    `json:"match_id"`
+	GameNumber       int       `json:"game_number"`
+	TurnNumber       int       `json:"turn_number"`
+	Phase            string    `json:"phase,omitempty"`
+	Step             string    `json:"step,omitempty"`
+	AffectedPlayer   string    `json:"affected_player"` // "player" or "opponent"
+	Amount           int       `json:"amount"`          // Negative for damage and life loss
+	LifeAfter        *int      `json:"life_after,omitempty"`
+	ChangeType       string    `json:"change_type"`              // "combat", "burn", "drain", "lifelink", "life_gain", "other"
+	SourceCardID     *int      `json:"source_card_id,omitempty"` // Arena card ID of the source
+	SourceController string    `json:"source_controller,omitempty"`
+	AnnotationID     int       `json:"annotation_id"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+// DamageSource totals the life one card took or gave during a match.
+type DamageSource struct {
+	CardID       *int   `json:"card_id,omitempty"` // Nil for damage with no known source
+	Controller   string `json:"controller,omitempty"`
+	Target       string `json:"target"` // Player whose life changed
+	Damage       int    `json:"damage"` // Damage and life loss dealt
+	LifeGained   int    `json:"life_gained"`
+	Hits         int    `json:"hits"`
+	CombatDamage int    `json:"combat_damage"`
+}
+
+// LethalSummary is the damage we took on the turn we died in one game.
+type LethalSummary struct {
+	GameNumber int             `json:"game_number"`
+	TurnNumber int             `json:"turn_number"`
+	Damage     int             `json:"damage"`     // Damage and life loss taken that turn
+	Sources    []*DamageSource `json:"sources"`    // Most damage first
+	FinalBlow  *LifeChange     `json:"final_blow"` // The change that took us to zero
+}
+
+// TurnDamageStats is the average damage dealt and taken on one turn number
+// across the games played with a deck.
+type TurnDamageStats struct {
+	TurnNumber     int     `json:"turn_number"`
+	Games          int     `json:"games"` // Games that lasted to this turn
+	AvgDamageDealt float64 `json:"avg_damage_dealt"`
+	AvgDamageTaken float64 `json:"avg_damage_taken"`
+	AvgLifeGained  float64 `json:"avg_life_gained"`
+}
+
+// OpeningHand is a hand we were offered at a mulligan prompt.
+type OpeningHand struct {
+	ID            int       `json:"id"`
+	MatchID       string    `json:"match_id"`
+	GameNumber    int       `json:"game_number"`
+	MulliganCount int       `json:"mulligan_count"` // Mulligans taken before this hand was drawn
+	CardIDs       []int     `json:"card_ids"`       // Arena card IDs drawn
+	OnPlay        *bool     `json:"on_play,omitempty"`
+	Decision      string    `json:"decision,omitempty"` // "keep" or "mulligan", empty while undecided
+	DeckID        string    `json:"deck_id,omitempty"`  // Deck of the stored match
+	Result        string    `json:"result,omitempty"`   // "win" or "loss" once the game is stored
+	Timestamp     time.Time `json:"timestamp"`
 }
 
 // Constants for player types.
@@ -97,6 +217,22 @@ const (
 	ActionTypeMulligan = "mulligan"
 )
 
+// Constants for life change types.
+const (
+	LifeChangeCombat   = "combat"
+	LifeChangeBurn     = "burn"
+	LifeChangeDrain    = "drain"
+	LifeChangeLifelink = "lifelink"
+	LifeChangeGain     = "life_gain"
+	LifeChangeOther    = "other"
+)
+
+// Constants for mulligan decisions.
+const (
+	MulliganDecisionKeep     = "keep"
+	MulliganDecisionMulligan = "mulligan"
+)
+
 // Constants for game phases.
 const (
 	PhaseBeginning = "Beginning"
@@ -0,0 +1,130 @@
+package models
+
+import "time"
+
+// Timer event types.
+const (
+	TimerEventRope    = "rope"
+	TimerEventTimeout = "timeout"
+)
+
+// TurnTiming is one turn's duration and the decision time each player spent in it.
+type TurnTiming struct {
+	MatchID            string    `json:"match_id"`
+	GameNumber         int       `json:"game_number"`
+	TurnNumber         int       `json:"turn_number"`
+	ActivePlayer       string    `json:"active_player"` // "player" or "opponent"
+	StartedAt          time.Time `json:"started_at"`
+	EndedAt            time.Time `json:"ended_at"`    // Last activity seen in the turn
+	DurationMs         int64     `json:"duration_ms"` // Until the next turn started, or the last activity for a game's final turn
+	PlayerDecisionMs   int64     `json:"player_decision_ms"`
+	OpponentDecisionMs int64     `json:"opponent_decision_ms"`
+	PlayerDecisions    int       `json:"player_decisions"`
+	OpponentDecisions  int       `json:"opponent_decisions"`
+}
+
+// DecisionTiming is how long a player held a decision or priority.
+type DecisionTiming struct {
+	ID         int       `json:"id"`
+	MatchID    string    `json:"match_id"`
+	GameNumber int       `json:"game_number"`
+	TurnNumber int       `json:"turn_number"`
+	PlayerType string    `json:"player_type"` // "player" or "opponent"
+	Phase      string    `json:"phase,omitempty"`
+	Step       string    `json:"step,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// TimerEvent is a rope warning or timeout for one player.
+type TimerEvent struct {
+	ID         int       `json:"id"`
+	MatchID    string    `json:"match_id"`
+	GameNumber int       `json:"game_number"`
+	TurnNumber int       `json:"turn_number"`
+	PlayerType string    `json:"player_type"`
+	EventType  string    `json:"event_type"` // TimerEventRope or TimerEventTimeout
+	ElapsedMs  int64     `json:"elapsed_ms"` // Time on the decision when it happened
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// GameClockUsage is the decision time each player used in one game, the
+// equivalent of a chess clock.
+type GameClockUsage struct {
+	GameNumber                int   `json:"game_number"`
+	Turns                     int   `json:"turns"`
+	PlayerMs                  int64 `json:"player_ms"`
+	OpponentMs                int64 `json:"opponent_ms"`
+	PlayerLongestDecisionMs   int64 `json:"player_longest_decision_ms"`
+	OpponentLongestDecisionMs int64 `json:"opponent_longest_decision_ms"`
+}
+
+// MatchTiming is the turn, decision and clock data for one match.
+type MatchTiming struct {
+	MatchID          string            `json:"match_id"`
+	Turns            []*TurnTiming     `json:"turns"`
+	Decisions        []*DecisionTiming `json:"decisions"`
+	TimerEvents      []*TimerEvent     `json:"timer_events"`
+	Games            []*GameClockUsage `json:"games"`
+	PlayerTotalMs    int64             `json:"player_total_ms"` // Clock used across every game of the match
+	OpponentTotalMs  int64             `json:"opponent_total_ms"`
+	PlayerRopes      int               `json:"player_ropes"`
+	OpponentRopes    int               `json:"opponent_ropes"`
+	PlayerTimeouts   int               `json:"player_timeouts"`
+	OpponentTimeouts int               `json:"opponent_timeouts"`
+}
+
+// DeckTimingStats is how long we take per turn with one deck.
+type DeckTimingStats struct {
+	DeckID                 string  `json:"deck_id"`
+	DeckName               string  `json:"deck_name"`
+	Matches                int     `json:"matches"`
+	WinRate                float64 `json:"win_rate"`
+	AvgTurnSeconds         float64 `json:"avg_turn_seconds"` // Our decision time on our own turns
+	AvgMatchClockSeconds   float64 `json:"avg_match_clock_seconds"`
+	LongestTurnSeconds     float64 `json:"longest_turn_seconds"`
+	Ropes                  int     `json:"ropes"`
+	Timeouts               int     `json:"timeouts"`
+	AvgOpponentTurnSeconds float64 `json:"avg_opponent_turn_seconds"`
+}
+
+// MatchupTimingStats is how long both players take per turn against one
+// opponent archetype.
+type MatchupTimingStats struct {
+	OpponentArchetype      string  `json:"opponent_archetype"`
+	Matches                int     `json:"matches"`
+	WinRate                float64 `json:"win_rate"`
+	AvgTurnSeconds         float64 `json:"avg_turn_seconds"`
+	AvgOpponentTurnSeconds float64 `json:"avg_opponent_turn_seconds"`
+	AvgMatchClockSeconds   float64 `json:"avg_match_clock_seconds"`
+	Ropes                  int     `json:"ropes"`
+}
+
+// TurnTimeOutcome relates how long we take per turn to whether we lose.
+type TurnTimeOutcome struct {
+	Matches                int     `json:"matches"`
+	Wins                   int     `json:"wins"`
+	Losses                 int     `json:"losses"`
+	AvgTurnSecondsInWins   float64 `json:"avg_turn_seconds_in_wins"`
+	AvgTurnSecondsInLosses float64 `json:"avg_turn_seconds_in_losses"`
+	Correlation            float64 `json:"correlation"` // Point-biserial correlation of average turn time with losing, -1 to 1
+	LongTurnSeconds        float64 `json:"long_turn_seconds"`
+	MatchesWithLongTurns   int     `json:"matches_with_long_turns"`
+	LossRateWithLongTurns  float64 `json:"loss_rate_with_long_turns"`
+	LossRateOtherwise      float64 `json:"loss_rate_otherwise"`
+}
+
+// SlowRollSuspect is an opponent decision on the final turn of a game we lost
+// that took far longer than their usual decisions.
+type SlowRollSuspect struct {
+	MatchID          string    `json:"match_id"`
+	GameNumber       int       `json:"game_number"`
+	TurnNumber       int       `json:"turn_number"`
+	OpponentName     string    `json:"opponent_name,omitempty"`
+	Phase            string    `json:"phase,omitempty"`
+	Step             string    `json:"step,omitempty"`
+	DecisionMs       int64     `json:"decision_ms"`
+	MedianDecisionMs int64     `json:"median_decision_ms"` // Opponent's median in the same game
+	Ratio            float64   `json:"ratio"`
+	Timestamp        time.Time `json:"timestamp"`
+}
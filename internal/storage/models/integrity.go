@@ -0,0 +1,7 @@
+package models
+
+// IntegrityIssue summarizes the rows that failed a single database integrity check.
+type IntegrityIssue struct {
+	Count   int      // Total number of offending rows
+	Samples []string // Identifying keys for the first few offending rows
+}
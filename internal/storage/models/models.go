@@ -311,6 +311,7 @@ type DraftSession struct {
 	EndTime              *time.Time
 	Status               string // "in_progress", "completed", "abandoned"
 	TotalPicks           int
+	IsSimulated          bool     // Practice draft against bots; left out of stats, exports and training
 	OverallGrade         *string  // A+, A, A-, B+, etc.
 	OverallScore         *int     // 0-100
 	PickQualityScore     *float64 // Component score (0-40)
@@ -339,6 +340,10 @@ type DraftPickSession struct {
 	PackBestGIHWR    *float64 // Best GIHWR in pack
 	PickedCardGIHWR  *float64 // GIHWR of picked card
 	AlternativesJSON *string  // JSON array of alternative picks
+	// Context-aware scoring (pool, colors, role needs and pick position)
+	PickScore          *float64 // Context score of picked card
+	PackBestScore      *float64 // Best context score in pack
+	ScoreBreakdownJSON *string  // JSON per-factor breakdown of the picked card's score
 }
 
 // DraftPackSession represents the cards available in a pack during a draft.
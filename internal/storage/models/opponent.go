@@ -128,6 +128,71 @@ type ColorIdentityStatsEntry struct {
 	WinRate       float64 `json:"winRate"`
 }
 
+// OpponentScoutingReport aggregates everything known about one opponent across matches.
+type OpponentScoutingReport struct {
+	OpponentID    string                `json:"opponentId"`
+	OpponentName  string                `json:"opponentName"` // Most recent display name
+	MatchesPlayed int                   `json:"matchesPlayed"`
+	MatchesWon    int                   `json:"matchesWon"` // Our wins against them
+	MatchesLost   int                   `json:"matchesLost"`
+	GamesWon      int                   `json:"gamesWon"`
+	GamesLost     int                   `json:"gamesLost"`
+	WinRate       float64               `json:"winRate"`
+	FirstSeen     *time.Time            `json:"firstSeen,omitempty"`
+	LastSeen      *time.Time            `json:"lastSeen,omitempty"`
+	Decks         []ScoutedDeck         `json:"decks"`
+	CardsSeen     []ScoutedCard         `json:"cardsSeen"`
+	PlayPatterns  OpponentPlayPatterns  `json:"playPatterns"`
+	RecentMatches []ScoutedMatchSummary `json:"recentMatches"`
+}
+
+// ScoutedDeck is an archetype and color identity an opponent has played against us.
+type ScoutedDeck struct {
+	Archetype     string    `json:"archetype"`
+	ColorIdentity string    `json:"colorIdentity"`
+	Format        string    `json:"format"`
+	Matches       int       `json:"matches"`
+	Wins          int       `json:"wins"` // Our wins against this deck
+	LastSeen      time.Time `json:"lastSeen"`
+}
+
+// ScoutedCard is a card an opponent has revealed against us.
+type ScoutedCard struct {
+	CardID    int    `json:"cardId"`
+	CardName  string `json:"cardName"`
+	Matches   int    `json:"matches"` // Matches the card was seen in
+	TimesSeen int    `json:"timesSeen"`
+}
+
+// OpponentPlayPatterns describes how an opponent tends to play.
+type OpponentPlayPatterns struct {
+	Concedes       int      `json:"concedes"`
+	ConcedeRate    float64  `json:"concedeRate"`              // Share of our wins that were concessions
+	AvgConcedeTurn *float64 `json:"avgConcedeTurn,omitempty"` // Last recorded turn of matches they conceded
+	AvgTurnSeconds *float64 `json:"avgTurnSeconds,omitempty"` // Time taken on their own turns
+	TurnsTimed     int      `json:"turnsTimed"`
+	Timeouts       int      `json:"timeouts"`
+}
+
+// ScoutedMatchSummary is one past match against a scouted opponent.
+type ScoutedMatchSummary struct {
+	MatchID      string    `json:"matchId"`
+	Timestamp    time.Time `json:"timestamp"`
+	Format       string    `json:"format"`
+	Result       string    `json:"result"`
+	ResultReason *string   `json:"resultReason,omitempty"`
+	Archetype    *string   `json:"archetype,omitempty"`
+}
+
+// ScoutedOpponent is a list entry for an opponent we have played more than once.
+type ScoutedOpponent struct {
+	OpponentID    string    `json:"opponentId"`
+	OpponentName  string    `json:"opponentName"`
+	MatchesPlayed int       `json:"matchesPlayed"`
+	MatchesWon    int       `json:"matchesWon"`
+	LastSeen      time.Time `json:"lastSeen"`
+}
+
 // Constants for deck styles.
 const (
 	DeckStyleAggro    = "aggro"
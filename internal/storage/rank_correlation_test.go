@@ -6,13 +6,13 @@ import (
 	"testing"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/logreader"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 // setupRankCorrelationTestDB creates an in-memory database for rank correlation tests.
 func setupRankCorrelationTestDB(t *testing.T) *DB {
-	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	sqlDB, err := sql.Open(sqlitedriver.Name, ":memory:")
 	if err != nil {
 		t.Fatalf("failed to open test database: %v", err)
 	}
@@ -7,8 +7,12 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 // Sentinel errors for card performance repository.
@@ -36,6 +40,10 @@ type CardPerformanceRepository interface {
 
 	// GetOverperformingCards identifies cards with high win impact.
 	GetOverperformingCards(ctx context.Context, deckID string, threshold float64) ([]*models.CardPerformance, error)
+
+	// GetCardGameMetrics computes 17Lands-style in-hand, opening hand, drawn and
+	// not-seen win rates for every card seen in a deck's games.
+	GetCardGameMetrics(ctx context.Context, filter models.CardGameMetricsFilter, config seventeenlands.BayesianConfig) (*models.DeckCardGameMetrics, error)
 }
 
 // cardPerformanceRepository is the concrete implementation.
@@ -557,33 +565,45 @@ func (r *cardPerformanceRepository) GetCardRecommendations(ctx context.Context,
 		CurrentWinRate: analysis.OverallWinRate,
 	}
 
-	// Generate remove recommendations from underperforming cards
-	underperformers, err := r.GetUnderperformingCards(ctx, req.DeckID, 0.05)
-	if err != nil {
-		return nil, err
-	}
-
 	maxResults := req.MaxResults
 	if maxResults <= 0 {
 		maxResults = 5
 	}
 
-	for i, card := range underperformers {
-		if i >= maxResults {
-			break
+	// Prefer in-game metrics: comparing games the card was drawn against games
+	// it was not seen isolates the card from the deck's overall results
+	removes, err := r.getMetricRemoveRecommendations(ctx, req.DeckID, maxResults)
+	if err != nil {
+		removes = nil
+	}
+	if removes == nil {
+		// Generate remove recommendations from underperforming cards
+		underperformers, err := r.GetUnderperformingCards(ctx, req.DeckID, 0.05)
+		if err != nil {
+			return nil, err
 		}
 
-		rec := &models.CardRecommendation{
-			Type:           "remove",
-			CardID:         card.CardID,
-			CardName:       card.CardName,
-			Reason:         generateRemoveReason(card),
-			ImpactEstimate: -card.WinContribution, // Removing bad card improves win rate
-			Confidence:     card.ConfidenceLevel,
-			Priority:       i + 1,
-			BasedOnGames:   card.SampleSize,
+		removes = []*models.CardRecommendation{}
+		for i, card := range underperformers {
+			if i >= maxResults {
+				break
+			}
+
+			rec := &models.CardRecommendation{
+				Type:           "remove",
+				CardID:         card.CardID,
+				CardName:       card.CardName,
+				Reason:         generateRemoveReason(card),
+				ImpactEstimate: -card.WinContribution, // Removing bad card improves win rate
+				Confidence:     card.ConfidenceLevel,
+				Priority:       i + 1,
+				BasedOnGames:   card.SampleSize,
+			}
+			removes = append(removes, rec)
 		}
-		response.RemoveRecommendations = append(response.RemoveRecommendations, rec)
+	}
+	if len(removes) > 0 {
+		response.RemoveRecommendations = removes
 	}
 
 	// Generate add recommendations from similar successful decks
@@ -595,8 +615,8 @@ func (r *cardPerformanceRepository) GetCardRecommendations(ctx context.Context,
 	response.AddRecommendations = addRecs
 
 	// Generate swap recommendations if requested
-	if req.IncludeSwaps && len(underperformers) > 0 && len(addRecs) > 0 {
-		response.SwapRecommendations = generateSwapRecommendations(underperformers, addRecs, maxResults)
+	if req.IncludeSwaps && len(removes) > 0 && len(addRecs) > 0 {
+		response.SwapRecommendations = generateSwapRecommendations(removes, addRecs, maxResults)
 	}
 
 	// Calculate projected win rate
@@ -746,7 +766,7 @@ func generateRemoveReason(card *models.CardPerformance) string {
 }
 
 // generateSwapRecommendations creates swap recommendations from remove and add lists.
-func generateSwapRecommendations(removes []*models.CardPerformance, adds []*models.CardRecommendation, maxResults int) []*models.CardRecommendation {
+func generateSwapRecommendations(removes, adds []*models.CardRecommendation, maxResults int) []*models.CardRecommendation {
 	var swaps []*models.CardRecommendation
 
 	for i := 0; i < len(removes) && i < len(adds) && i < maxResults; i++ {
@@ -760,7 +780,7 @@ func generateSwapRecommendations(removes []*models.CardPerformance, adds []*mode
 			SwapForCardID:   &add.CardID,
 			SwapForCardName: &add.CardName,
 			Reason:          fmt.Sprintf("Replace underperformer with %s", add.CardName),
-			ImpactEstimate:  add.ImpactEstimate - remove.WinContribution,
+			ImpactEstimate:  add.ImpactEstimate + remove.ImpactEstimate,
 			Confidence:      add.Confidence,
 			Priority:        i + 1,
 			BasedOnGames:    add.BasedOnGames,
@@ -800,3 +820,384 @@ func calculateProjectedWinRate(currentWinRate float64, response *models.Recommen
 
 	return projected
 }
+
+// minIWDForRemoval is how far a card's improvement when drawn must fall below
+// zero before it is recommended as a cut.
+const minIWDForRemoval = -0.05
+
+// getMetricRemoveRecommendations recommends cutting cards whose games go worse
+// when they are drawn than when they are not seen. Returns nil when the deck
+// has too few recorded games to judge.
+func (r *cardPerformanceRepository) getMetricRemoveRecommendations(ctx context.Context, deckID string, maxResults int) ([]*models.CardRecommendation, error) {
+	metrics, err := r.GetCardGameMetrics(ctx, models.CardGameMetricsFilter{DeckID: deckID}, seventeenlands.DefaultBayesianConfig())
+	if err != nil {
+		return nil, err
+	}
+	if metrics.TotalGames < models.MinGamesForAnalysis {
+		return nil, nil
+	}
+
+	var candidates []*models.CardGameMetrics
+	for _, card := range metrics.Cards {
+		if card.GamesInHand.Games >= models.MinGamesForAnalysis &&
+			card.NotSeen.Games >= models.MinGamesForAnalysis &&
+			card.IWD < minIWDForRemoval {
+			candidates = append(candidates, card)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].IWD < candidates[j].IWD
+	})
+
+	recs := make([]*models.CardRecommendation, 0, len(candidates))
+	for i, card := range candidates {
+		if i >= maxResults {
+			break
+		}
+		recs = append(recs, &models.CardRecommendation{
+			Type:     "remove",
+			CardID:   card.CardID,
+			CardName: card.CardName,
+			Reason: fmt.Sprintf("wins %.1f%% of games when drawn vs %.1f%% when not seen (%.1f points improvement when drawn)",
+				card.GamesInHand.Adjusted*100, card.NotSeen.Adjusted*100, card.IWD*100),
+			ImpactEstimate: -card.IWD,
+			Confidence:     getConfidenceLevel(card.GamesInHand.Games),
+			Priority:       i + 1,
+			BasedOnGames:   card.GamesInHand.Games,
+		})
+	}
+	return recs, nil
+}
+
+// cardGame tracks which cards reached the player's hand in one game.
+type cardGame struct {
+	won     bool
+	seen    map[int]bool
+	opening map[int]bool
+}
+
+// GetCardGameMetrics computes 17Lands-style in-hand, opening hand, drawn and
+// not-seen win rates for every card seen in a deck's games.
+//
+// A card counts as seen when it was in the player's hand in a turn snapshot,
+// moved to hand, or was cast or played from hand. It counts as in the opening
+// hand when it was in hand at the first snapshot of turn 1 or played on turn 1,
+// before any draw. Games are those with recorded plays or snapshots.
+func (r *cardPerformanceRepository) GetCardGameMetrics(ctx context.Context, filter models.CardGameMetricsFilter, config seventeenlands.BayesianConfig) (*models.DeckCardGameMetrics, error) {
+	if filter.DeckID == "" {
+		return nil, fmt.Errorf("deck_id is required")
+	}
+
+	matchFilter, args, err := r.cardMetricsMatchFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	games := make(map[string]*cardGame)
+	gameFor := func(matchID string, gameID int, result string) *cardGame {
+		key := fmt.Sprintf("%s/%d", matchID, gameID)
+		game := games[key]
+		if game == nil {
+			game = &cardGame{won: result == "win", seen: make(map[int]bool), opening: make(map[int]bool)}
+			games[key] = game
+		}
+		return game
+	}
+	names := make(map[int]string)
+	turns := make(map[int]map[int]int)
+
+	playRows, err := r.db.QueryContext(ctx, `
+		SELECT gp.match_id, gp.game_id, COALESCE(g.result, m.result), gp.card_id, gp.card_name,
+			gp.turn_number, gp.action_type, COALESCE(gp.zone_from, ''), COALESCE(gp.zone_to, '')
+		FROM game_plays gp
+		INNER JOIN matches m ON gp.match_id = m.id
+		LEFT JOIN games g ON g.id = gp.game_id AND g.match_id = gp.match_id
+		WHERE `+matchFilter+`
+		AND gp.player_type = 'player'
+		AND gp.card_id IS NOT NULL
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query card plays: %w", err)
+	}
+	defer func() {
+		_ = playRows.Close()
+	}()
+
+	for playRows.Next() {
+		var matchID, result, actionType, zoneFrom, zoneTo string
+		var gameID, cardID, turn int
+		var cardName sql.NullString
+		if err := playRows.Scan(&matchID, &gameID, &result, &cardID, &cardName, &turn, &actionType, &zoneFrom, &zoneTo); err != nil {
+			return nil, fmt.Errorf("failed to scan card play: %w", err)
+		}
+		if cardName.Valid && cardName.String != "" {
+			names[cardID] = cardName.String
+		}
+
+		game := gameFor(matchID, gameID, result)
+		if zoneTo == "hand" {
+			game.seen[cardID] = true
+		}
+		if zoneFrom != "hand" {
+			continue
+		}
+		game.seen[cardID] = true
+		if turn <= 1 {
+			game.opening[cardID] = true // Nobody draws on the first turn of the game
+		}
+		if actionType == models.ActionTypePlayCard || actionType == models.ActionTypeLandDrop {
+			if turns[cardID] == nil {
+				turns[cardID] = make(map[int]int)
+			}
+			turns[cardID][turn]++
+		}
+	}
+	if err := playRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating card plays: %w", err)
+	}
+
+	snapshotRows, err := r.db.QueryContext(ctx, `
+		SELECT s.match_id, s.game_id, COALESCE(g.result, m.result), s.turn_number, s.board_state_json
+		FROM game_state_snapshots s
+		INNER JOIN matches m ON s.match_id = m.id
+		LEFT JOIN games g ON g.id = s.game_id AND g.match_id = s.match_id
+		WHERE `+matchFilter+`
+		ORDER BY s.match_id, s.game_id, s.turn_number
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query game snapshots: %w", err)
+	}
+	defer func() {
+		_ = snapshotRows.Close()
+	}()
+
+	firstSnapshot := make(map[*cardGame]bool)
+	for snapshotRows.Next() {
+		var matchID, result string
+		var gameID, turn int
+		var boardJSON sql.NullString
+		if err := snapshotRows.Scan(&matchID, &gameID, &result, &turn, &boardJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan game snapshot: %w", err)
+		}
+
+		game := gameFor(matchID, gameID, result)
+		opening := turn <= 1 && !firstSnapshot[game]
+		firstSnapshot[game] = true
+		if !boardJSON.Valid {
+			continue
+		}
+		board, err := ParseBoardState(&boardJSON.String)
+		if err != nil || board == nil {
+			continue
+		}
+		for _, card := range board.PlayerHand {
+			if card.CardID == 0 {
+				continue
+			}
+			if card.CardName != "" && names[card.CardID] == "" {
+				names[card.CardID] = card.CardName
+			}
+			game.seen[card.CardID] = true
+			if opening {
+				game.opening[card.CardID] = true
+			}
+		}
+	}
+	if err := snapshotRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating game snapshots: %w", err)
+	}
+
+	result := &models.DeckCardGameMetrics{
+		DeckID:        filter.DeckID,
+		PermutationID: filter.PermutationID,
+		TotalGames:    len(games),
+		Cards:         []*models.CardGameMetrics{},
+	}
+	cardIDs := make(map[int]bool)
+	for _, game := range games {
+		if game.won {
+			result.GamesWon++
+		}
+		for cardID := range game.seen {
+			cardIDs[cardID] = true
+		}
+	}
+
+	if err := r.fillCardNames(ctx, cardIDs, names); err != nil {
+		return nil, err
+	}
+
+	for cardID := range cardIDs {
+		if !filter.IncludeLands && isBasicLand(names[cardID]) {
+			continue
+		}
+
+		var inHand, opening, drawn, notSeen [2]int // Games, wins
+		for _, game := range games {
+			win := 0
+			if game.won {
+				win = 1
+			}
+			switch {
+			case game.opening[cardID]:
+				opening[0]++
+				opening[1] += win
+			case game.seen[cardID]:
+				drawn[0]++
+				drawn[1] += win
+			default:
+				notSeen[0]++
+				notSeen[1] += win
+			}
+		}
+		inHand[0] = opening[0] + drawn[0]
+		inHand[1] = opening[1] + drawn[1]
+
+		metrics := &models.CardGameMetrics{
+			CardID:         cardID,
+			CardName:       names[cardID],
+			GamesInHand:    winRateMetric(inHand[1], inHand[0], config),
+			OpeningHand:    winRateMetric(opening[1], opening[0], config),
+			GameDrawn:      winRateMetric(drawn[1], drawn[0], config),
+			NotSeen:        winRateMetric(notSeen[1], notSeen[0], config),
+			TurnPlayedDist: turns[cardID],
+		}
+		if metrics.GamesInHand.Games > 0 && metrics.NotSeen.Games > 0 {
+			metrics.IWD = metrics.GamesInHand.Adjusted - metrics.NotSeen.Adjusted
+		}
+
+		var played, turnTotal int
+		for turn, count := range turns[cardID] {
+			played += count
+			turnTotal += turn * count
+		}
+		if played > 0 {
+			metrics.AvgTurnPlayed = float64(turnTotal) / float64(played)
+		}
+
+		result.Cards = append(result.Cards, metrics)
+	}
+
+	sort.Slice(result.Cards, func(i, j int) bool {
+		if result.Cards[i].GamesInHand.Games != result.Cards[j].GamesInHand.Games {
+			return result.Cards[i].GamesInHand.Games > result.Cards[j].GamesInHand.Games
+		}
+		return result.Cards[i].CardID < result.Cards[j].CardID
+	})
+
+	return result, nil
+}
+
+// cardMetricsMatchFilter builds the WHERE clause selecting a deck's matches.
+// A deck version covers the matches played from its creation until the next
+// version was created; the first version also covers earlier matches.
+// Match timestamps are stored as Go time text, so the version bounds are bound
+// as time.Time values and compared as text.
+func (r *cardPerformanceRepository) cardMetricsMatchFilter(ctx context.Context, filter models.CardGameMetricsFilter) (string, []interface{}, error) {
+	clause := "m.deck_id = ?"
+	args := []interface{}{filter.DeckID}
+	if filter.PermutationID == nil {
+		return clause, args, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, created_at
+		FROM deck_permutations
+		WHERE deck_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, filter.DeckID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to query deck permutations: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var ids []int
+	var created []time.Time
+	for rows.Next() {
+		var id int
+		var createdAt string
+		if err := rows.Scan(&id, &createdAt); err != nil {
+			return "", nil, fmt.Errorf("failed to scan deck permutation: %w", err)
+		}
+		at, err := sqlitedriver.ParseTime(createdAt)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to parse deck permutation created_at: %w", err)
+		}
+		ids = append(ids, id)
+		created = append(created, at.UTC())
+	}
+	if err := rows.Err(); err != nil {
+		return "", nil, fmt.Errorf("error iterating deck permutations: %w", err)
+	}
+
+	for i, id := range ids {
+		if id != *filter.PermutationID {
+			continue
+		}
+		if i > 0 {
+			clause += " AND m.timestamp >= ?"
+			args = append(args, created[i])
+		}
+		if i+1 < len(ids) {
+			clause += " AND m.timestamp < ?"
+			args = append(args, created[i+1])
+		}
+		return clause, args, nil
+	}
+	return "", nil, fmt.Errorf("permutation %d does not belong to deck %s", *filter.PermutationID, filter.DeckID)
+}
+
+// fillCardNames looks up names for cards the game plays did not name.
+func (r *cardPerformanceRepository) fillCardNames(ctx context.Context, cardIDs map[int]bool, names map[int]string) error {
+	var missing []interface{}
+	for cardID := range cardIDs {
+		if names[cardID] == "" {
+			missing = append(missing, fmt.Sprintf("%d", cardID))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	query := `SELECT arena_id, name FROM set_cards WHERE arena_id IN (?` + strings.Repeat(", ?", len(missing)-1) + `)`
+	rows, err := r.db.QueryContext(ctx, query, missing...)
+	if err != nil {
+		return fmt.Errorf("failed to query card names: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var arenaID, name string
+		if err := rows.Scan(&arenaID, &name); err != nil {
+			return fmt.Errorf("failed to scan card name: %w", err)
+		}
+		var cardID int
+		if _, err := fmt.Sscanf(arenaID, "%d", &cardID); err == nil {
+			names[cardID] = name
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating card names: %w", err)
+	}
+	return nil
+}
+
+// winRateMetric builds a win rate with Bayesian shrinkage and a 95% Wilson interval.
+func winRateMetric(wins, games int, config seventeenlands.BayesianConfig) models.WinRateMetric {
+	metric := models.WinRateMetric{Games: games, Wins: wins}
+	if games == 0 {
+		return metric
+	}
+
+	metric.WinRate = float64(wins) / float64(games)
+	metric.Adjusted = seventeenlands.CalculateWinRate(metric.WinRate*100, games, config) / 100
+
+	interval := stats.WilsonInterval(wins, games, stats.DefaultConfidence)
+	metric.CILow = interval.Low
+	metric.CIHigh = interval.High
+	return metric
+}
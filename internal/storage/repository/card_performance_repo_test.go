@@ -3,19 +3,21 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"testing"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards/seventeenlands"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 // setupCardPerfTestDB creates an in-memory SQLite database for card performance testing.
 func setupCardPerfTestDB(t *testing.T) *sql.DB {
-	db, err := sql.Open("sqlite3", ":memory:")
+	db, err := sql.Open(sqlitedriver.Name, ":memory:")
 	require.NoError(t, err)
 
 	// Create required tables
@@ -34,9 +36,22 @@ func setupCardPerfTestDB(t *testing.T) *sql.DB {
 		CREATE TABLE IF NOT EXISTS matches (
 			id TEXT PRIMARY KEY,
 			account_id INTEGER,
+			event_id TEXT,
+			event_name TEXT,
+			timestamp DATETIME,
+			duration_seconds INTEGER,
+			player_wins INTEGER,
+			opponent_wins INTEGER,
+			player_team_id INTEGER,
 			deck_id TEXT,
+			rank_before TEXT,
+			rank_after TEXT,
+			format TEXT,
 			result TEXT,
-			timestamp DATETIME,
+			result_reason TEXT,
+			opponent_name TEXT,
+			opponent_id TEXT,
+			created_at DATETIME,
 			FOREIGN KEY (deck_id) REFERENCES decks(id)
 		);
 
@@ -353,3 +368,141 @@ func TestGetCardPerformance_RequiresDeckID(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "deck_id is required")
 }
+
+// setupCardMetricsTestDB adds the snapshot, deck version and card tables used by in-game metrics.
+func setupCardMetricsTestDB(t *testing.T) *sql.DB {
+	db := setupCardPerfTestDB(t)
+	_, err := db.Exec(`
+		CREATE TABLE game_state_snapshots (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			match_id TEXT NOT NULL,
+			turn_number INTEGER NOT NULL,
+			board_state_json TEXT
+		);
+
+		CREATE TABLE deck_permutations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			deck_id TEXT NOT NULL,
+			created_at DATETIME NOT NULL
+		);
+
+		CREATE TABLE set_cards (
+			arena_id TEXT PRIMARY KEY,
+			name TEXT NOT NULL
+		);
+
+		INSERT INTO decks (id, name, format) VALUES ('deck-1', 'Mono Green', 'Standard');
+		INSERT INTO deck_permutations (id, deck_id, created_at) VALUES
+			(1, 'deck-1', '2024-01-01 00:00:00'),
+			(2, 'deck-1', '2024-01-15 00:00:00');
+		INSERT INTO set_cards (arena_id, name) VALUES ('103', 'Forest');
+		INSERT INTO game_plays (game_id, match_id, turn_number, player_type, action_type, card_id, card_name, zone_from, zone_to, sequence_number) VALUES
+			(0, 'm1', 1, 'player', 'play_card', 100, 'Llanowar Elves', 'hand', 'battlefield', 1),
+			(0, 'm2', 3, 'player', 'play_card', 102, 'Ram Through', 'hand', 'graveyard', 1),
+			(0, 'm2', 3, 'opponent', 'play_card', 200, 'Shock', 'hand', 'graveyard', 2);
+	`)
+	require.NoError(t, err)
+
+	// Matches go through the match repository so timestamps are stored as the log processor stores them
+	deckID := "deck-1"
+	matchRepo := NewMatchRepository(db)
+	matches := []*models.Match{
+		{ID: "m1", Result: "win", Timestamp: time.Date(2023, 12, 20, 10, 0, 0, 0, time.UTC)},
+		{ID: "m2", Result: "loss", Timestamp: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)},
+		{ID: "m3", Result: "win", Timestamp: time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)},
+		{ID: "m4", Result: "loss", Timestamp: time.Date(2024, 2, 2, 10, 0, 0, 0, time.UTC)},
+	}
+	for _, match := range matches {
+		match.DeckID = &deckID
+		match.CreatedAt = match.Timestamp
+		require.NoError(t, matchRepo.Create(context.Background(), match))
+	}
+
+	hands := []struct {
+		matchID string
+		turn    int
+		hand    []int
+	}{
+		{"m1", 1, []int{100, 101}},
+		{"m2", 1, []int{101}},
+		{"m2", 3, []int{101, 102}},
+		{"m3", 1, []int{100}},
+		{"m4", 1, []int{101, 103}},
+	}
+	for _, h := range hands {
+		board := BoardState{}
+		for _, cardID := range h.hand {
+			board.PlayerHand = append(board.PlayerHand, PermanentState{CardID: cardID})
+		}
+		boardJSON, err := json.Marshal(board)
+		require.NoError(t, err)
+		_, err = db.Exec(`INSERT INTO game_state_snapshots (game_id, match_id, turn_number, board_state_json) VALUES (0, ?, ?, ?)`,
+			h.matchID, h.turn, string(boardJSON))
+		require.NoError(t, err)
+	}
+	return db
+}
+
+func TestGetCardGameMetrics(t *testing.T) {
+	db := setupCardMetricsTestDB(t)
+	defer db.Close()
+
+	repo := NewCardPerformanceRepository(db)
+	ctx := context.Background()
+	config := seventeenlands.DefaultBayesianConfig()
+
+	metrics, err := repo.GetCardGameMetrics(ctx, models.CardGameMetricsFilter{DeckID: "deck-1"}, config)
+	require.NoError(t, err)
+	assert.Equal(t, 4, metrics.TotalGames)
+	assert.Equal(t, 2, metrics.GamesWon)
+
+	cards := make(map[int]*models.CardGameMetrics)
+	for _, card := range metrics.Cards {
+		cards[card.CardID] = card
+	}
+	require.Len(t, cards, 3, "basic lands and opponent cards are excluded")
+
+	elves := cards[100]
+	assert.Equal(t, "Llanowar Elves", elves.CardName)
+	assert.Equal(t, 2, elves.OpeningHand.Games)
+	assert.Equal(t, 2, elves.OpeningHand.Wins)
+	assert.Equal(t, 0, elves.GameDrawn.Games)
+	assert.Equal(t, 2, elves.NotSeen.Games)
+	assert.Equal(t, 0, elves.NotSeen.Wins)
+	assert.InDelta(t, 12.0/22, elves.GamesInHand.Adjusted, 0.0001, "shrunk toward 50%")
+	assert.InDelta(t, 10.0/22, elves.NotSeen.Adjusted, 0.0001)
+	assert.InDelta(t, 2.0/22, elves.IWD, 0.0001)
+	assert.Less(t, elves.GamesInHand.CILow, 1.0)
+	assert.Equal(t, 1.0, elves.GamesInHand.CIHigh)
+	assert.Equal(t, map[int]int{1: 1}, elves.TurnPlayedDist)
+
+	ramThrough := cards[102]
+	assert.Equal(t, 1, ramThrough.GameDrawn.Games, "drawn after the opening hand")
+	assert.Equal(t, 0, ramThrough.OpeningHand.Games)
+	assert.Equal(t, 3.0, ramThrough.AvgTurnPlayed)
+
+	assert.Equal(t, 3, cards[101].OpeningHand.Games)
+	assert.Equal(t, 1, cards[101].OpeningHand.Wins)
+
+	withLands, err := repo.GetCardGameMetrics(ctx, models.CardGameMetricsFilter{DeckID: "deck-1", IncludeLands: true}, config)
+	require.NoError(t, err)
+	require.Len(t, withLands.Cards, 4)
+
+	// The second version only covers matches played after it was created
+	permID := 2
+	version, err := repo.GetCardGameMetrics(ctx, models.CardGameMetricsFilter{DeckID: "deck-1", PermutationID: &permID}, config)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version.TotalGames)
+
+	// The first version also covers matches from before versions were tracked
+	permID = 1
+	version, err = repo.GetCardGameMetrics(ctx, models.CardGameMetricsFilter{DeckID: "deck-1", PermutationID: &permID}, config)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version.TotalGames)
+	assert.Equal(t, 1, version.GamesWon)
+
+	permID = 99
+	_, err = repo.GetCardGameMetrics(ctx, models.CardGameMetricsFilter{DeckID: "deck-1", PermutationID: &permID}, config)
+	assert.Error(t, err)
+}
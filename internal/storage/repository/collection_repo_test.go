@@ -5,11 +5,13 @@ import (
 	"database/sql"
 	"testing"
 	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 // setupCollectionTestDB creates an in-memory database with collection tables.
 func setupCollectionTestDB(t *testing.T) *sql.DB {
-	db, err := sql.Open("sqlite", ":memory:")
+	db, err := sql.Open(sqlitedriver.Name, ":memory:")
 	if err != nil {
 		t.Fatalf("failed to open test database: %v", err)
 	}
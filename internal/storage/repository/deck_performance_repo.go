@@ -61,12 +61,19 @@ type DeckPerformanceRepository interface {
 }
 
 type deckPerformanceRepository struct {
-	db *sql.DB
+	db     *sql.DB // writer: inserts, updates and deletes
+	reader *sql.DB // read-only pool for analytics queries; same as db when not split
 }
 
 // NewDeckPerformanceRepository creates a new deck performance repository.
 func NewDeckPerformanceRepository(db *sql.DB) DeckPerformanceRepository {
-	return &deckPerformanceRepository{db: db}
+	return &deckPerformanceRepository{db: db, reader: db}
+}
+
+// NewDeckPerformanceRepositoryWithReader creates a deck performance repository whose read methods run on
+// reader (typically storage.DB.Reader) while writes stay on db.
+func NewDeckPerformanceRepositoryWithReader(db, reader *sql.DB) DeckPerformanceRepository {
+	return &deckPerformanceRepository{db: db, reader: reader}
 }
 
 // CreateHistory records a new deck performance history entry.
@@ -115,7 +122,7 @@ func (r *deckPerformanceRepository) GetHistoryByDeck(ctx context.Context, deckID
 		ORDER BY match_timestamp DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, deckID)
+	rows, err := r.reader.QueryContext(ctx, query, deckID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query deck performance history: %w", err)
 	}
@@ -138,7 +145,7 @@ func (r *deckPerformanceRepository) GetHistoryByArchetype(ctx context.Context, a
 		ORDER BY match_timestamp DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, archetype, format)
+	rows, err := r.reader.QueryContext(ctx, query, archetype, format)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query archetype performance history: %w", err)
 	}
@@ -162,7 +169,7 @@ func (r *deckPerformanceRepository) GetHistoryByAccount(ctx context.Context, acc
 		LIMIT ?
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, accountID, limit)
+	rows, err := r.reader.QueryContext(ctx, query, accountID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query account performance history: %w", err)
 	}
@@ -187,7 +194,7 @@ func (r *deckPerformanceRepository) GetArchetypePerformance(ctx context.Context,
 	var totalMatches, totalWins int
 	var avgDuration *float64
 
-	err := r.db.QueryRowContext(ctx, query, archetype, format).Scan(&totalMatches, &totalWins, &avgDuration)
+	err := r.reader.QueryRowContext(ctx, query, archetype, format).Scan(&totalMatches, &totalWins, &avgDuration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get archetype performance: %w", err)
 	}
@@ -222,7 +229,7 @@ func (r *deckPerformanceRepository) GetPerformanceByDateRange(ctx context.Contex
 	startStr := start.UTC().Format("2006-01-02 15:04:05.999999")
 	endStr := end.UTC().Format("2006-01-02 15:04:05.999999")
 
-	rows, err := r.db.QueryContext(ctx, query, accountID, startStr, endStr)
+	rows, err := r.reader.QueryContext(ctx, query, accountID, startStr, endStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query performance by date range: %w", err)
 	}
@@ -309,7 +316,7 @@ func (r *deckPerformanceRepository) GetArchetypeByID(ctx context.Context, id int
 	archetype := &models.DeckArchetype{}
 	var createdAt, updatedAt string
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.reader.QueryRowContext(ctx, query, id).Scan(
 		&archetype.ID, &archetype.Name, &archetype.SetCode, &archetype.Format,
 		&archetype.ColorIdentity, &archetype.SignatureCards, &archetype.SynergyPatterns,
 		&archetype.TotalMatches, &archetype.TotalWins, &archetype.AvgWinRate,
@@ -356,7 +363,7 @@ func (r *deckPerformanceRepository) GetArchetypeByName(ctx context.Context, name
 	archetype := &models.DeckArchetype{}
 	var createdAt, updatedAt string
 
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+	err := r.reader.QueryRowContext(ctx, query, args...).Scan(
 		&archetype.ID, &archetype.Name, &archetype.SetCode, &archetype.Format,
 		&archetype.ColorIdentity, &archetype.SignatureCards, &archetype.SynergyPatterns,
 		&archetype.TotalMatches, &archetype.TotalWins, &archetype.AvgWinRate,
@@ -399,7 +406,7 @@ func (r *deckPerformanceRepository) ListArchetypes(ctx context.Context, setCode
 
 	query += " ORDER BY name"
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.reader.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list archetypes: %w", err)
 	}
@@ -501,7 +508,7 @@ func (r *deckPerformanceRepository) GetCardWeights(ctx context.Context, archetyp
 		ORDER BY weight DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, archetypeID)
+	rows, err := r.reader.QueryContext(ctx, query, archetypeID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get card weights: %w", err)
 	}
@@ -521,7 +528,7 @@ func (r *deckPerformanceRepository) GetCardWeightsByCard(ctx context.Context, ca
 		ORDER BY weight DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, cardID)
+	rows, err := r.reader.QueryContext(ctx, query, cardID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get card weights by card: %w", err)
 	}
@@ -99,11 +99,14 @@ type DeckRepository interface {
 	// GetCardCountsByAccount returns aggregated card counts across all decks for an account.
 	// Returns a map of card ID to total quantity across all decks.
 	GetCardCountsByAccount(ctx context.Context, accountID int) (map[int]int, error)
+
+	// WithTx returns a repository that runs every query inside tx.
+	WithTx(tx *sql.Tx) DeckRepository
 }
 
 // deckRepository is the concrete implementation of DeckRepository.
 type deckRepository struct {
-	db *sql.DB
+	db Querier
 }
 
 // NewDeckRepository creates a new deck repository.
@@ -111,6 +114,11 @@ func NewDeckRepository(db *sql.DB) DeckRepository {
 	return &deckRepository{db: db}
 }
 
+// WithTx returns a repository that runs every query inside tx.
+func (r *deckRepository) WithTx(tx *sql.Tx) DeckRepository {
+	return &deckRepository{db: tx}
+}
+
 // Create inserts a new deck into the database.
 func (r *deckRepository) Create(ctx context.Context, deck *models.Deck) error {
 	query := `
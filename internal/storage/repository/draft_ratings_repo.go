@@ -124,12 +124,20 @@ type PeriodAverage struct {
 }
 
 type draftRatingsRepository struct {
-	db *sql.DB
+	db     *sql.DB
+	reader *sql.DB // trend reads over the rating history
 }
 
 // NewDraftRatingsRepository creates a new draft ratings repository.
 func NewDraftRatingsRepository(db *sql.DB) DraftRatingsRepository {
-	return &draftRatingsRepository{db: db}
+	return &draftRatingsRepository{db: db, reader: db}
+}
+
+// NewDraftRatingsRepositoryWithReader creates a draft ratings repository whose
+// trend reads run on reader (typically storage.DB.Reader) while everything
+// else stays on db.
+func NewDraftRatingsRepositoryWithReader(db, reader *sql.DB) DraftRatingsRepository {
+	return &draftRatingsRepository{db: db, reader: reader}
 }
 
 // SaveSetRatings saves card and color ratings for a set.
@@ -537,7 +545,7 @@ func (r *draftRatingsRepository) GetCardWinRateTrend(ctx context.Context, arenaI
 		ORDER BY cached_at ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, arenaID, expansion, days)
+	rows, err := r.reader.QueryContext(ctx, query, arenaID, expansion, days)
 	if err != nil {
 		return nil, err
 	}
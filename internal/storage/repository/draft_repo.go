@@ -28,6 +28,7 @@ type DraftRepository interface {
 	GetPicksBySession(ctx context.Context, sessionID string) ([]*models.DraftPickSession, error)
 	GetPickByNumber(ctx context.Context, sessionID string, packNum, pickNum int) (*models.DraftPickSession, error)
 	UpdatePickQuality(ctx context.Context, pickID int, grade string, rank int, packBestGIHWR, pickedCardGIHWR float64, alternativesJSON string) error
+	UpdatePickScore(ctx context.Context, pickID int, pickScore, packBestScore float64, breakdownJSON string) error
 
 	// Packs
 	SavePack(ctx context.Context, pack *models.DraftPackSession) error
@@ -42,6 +43,7 @@ type DraftRepository interface {
 
 	// Cleanup
 	ClearAllSessions(ctx context.Context) (sessionsDeleted, picksDeleted, packsDeleted int64, err error)
+	DeleteUnfinishedSimulations(ctx context.Context) (int64, error)
 	GetSessionCount(ctx context.Context) (int, error)
 	GetPickCount(ctx context.Context) (int, error)
 	GetPackCount(ctx context.Context) (int, error)
@@ -63,8 +65,8 @@ func NewDraftRepository(db *sql.DB) DraftRepository {
 // Uses INSERT OR REPLACE to handle replays where the same draft session may be processed multiple times.
 func (r *draftRepository) CreateSession(ctx context.Context, session *models.DraftSession) error {
 	query := `
-		INSERT OR REPLACE INTO draft_sessions (id, event_name, set_code, draft_type, start_time, status, total_picks, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT OR REPLACE INTO draft_sessions (id, event_name, set_code, draft_type, start_time, status, total_picks, is_simulated, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		session.ID,
@@ -74,6 +76,7 @@ func (r *draftRepository) CreateSession(ctx context.Context, session *models.Dra
 		session.StartTime,
 		session.Status,
 		session.TotalPicks,
+		session.IsSimulated,
 		session.CreatedAt,
 		session.UpdatedAt,
 	)
@@ -83,7 +86,7 @@ func (r *draftRepository) CreateSession(ctx context.Context, session *models.Dra
 // GetSession retrieves a draft session by ID.
 func (r *draftRepository) GetSession(ctx context.Context, id string) (*models.DraftSession, error) {
 	query := `
-		SELECT id, event_name, set_code, draft_type, start_time, end_time, status, total_picks,
+		SELECT id, event_name, set_code, draft_type, start_time, end_time, status, total_picks, is_simulated,
 			overall_grade, overall_score, pick_quality_score, color_discipline_score,
 			deck_composition_score, strategic_score,
 			predicted_win_rate, predicted_win_rate_min, predicted_win_rate_max,
@@ -117,6 +120,7 @@ func (r *draftRepository) GetSession(ctx context.Context, id string) (*models.Dr
 		&endTime,
 		&session.Status,
 		&session.TotalPicks,
+		&session.IsSimulated,
 		&overallGrade,
 		&overallScore,
 		&pickQuality,
@@ -179,12 +183,12 @@ func (r *draftRepository) GetSession(ctx context.Context, id string) (*models.Dr
 	return session, nil
 }
 
-// GetActiveSessions retrieves all active draft sessions.
+// GetActiveSessions retrieves all active draft sessions. Practice drafts are excluded.
 func (r *draftRepository) GetActiveSessions(ctx context.Context) ([]*models.DraftSession, error) {
 	query := `
 		SELECT id, event_name, set_code, draft_type, start_time, end_time, status, total_picks, created_at, updated_at
 		FROM draft_sessions
-		WHERE status = 'in_progress'
+		WHERE status = 'in_progress' AND is_simulated = 0
 		ORDER BY start_time DESC
 	`
 	rows, err := r.db.QueryContext(ctx, query)
@@ -228,12 +232,12 @@ func (r *draftRepository) GetActiveSessions(ctx context.Context) ([]*models.Draf
 
 // GetActiveSessionByIDPrefix finds an active (in_progress) session whose ID starts with the given prefix.
 // This is used to find existing sessions created with timestamp suffixes (e.g., "QuickDraft_TLA_20251127_*").
-// Returns the most recently created session if multiple exist.
+// Returns the most recently created session if multiple exist. Practice drafts are excluded.
 func (r *draftRepository) GetActiveSessionByIDPrefix(ctx context.Context, prefix string) (*models.DraftSession, error) {
 	query := `
 		SELECT id, event_name, set_code, draft_type, start_time, end_time, status, total_picks, created_at, updated_at
 		FROM draft_sessions
-		WHERE status = 'in_progress' AND id LIKE ? || '%'
+		WHERE status = 'in_progress' AND is_simulated = 0 AND id LIKE ? || '%'
 		ORDER BY created_at DESC
 		LIMIT 1
 	`
@@ -269,11 +273,12 @@ func (r *draftRepository) GetActiveSessionByIDPrefix(ctx context.Context, prefix
 }
 
 // GetCompletedSessions retrieves completed draft sessions ordered by completion date.
+// Practice drafts are excluded.
 func (r *draftRepository) GetCompletedSessions(ctx context.Context, limit int) ([]*models.DraftSession, error) {
 	query := `
 		SELECT id, event_name, set_code, draft_type, start_time, end_time, status, total_picks, created_at, updated_at
 		FROM draft_sessions
-		WHERE status = 'completed'
+		WHERE status = 'completed' AND is_simulated = 0
 		ORDER BY start_time DESC
 		LIMIT ?
 	`
@@ -380,7 +385,8 @@ func (r *draftRepository) SavePick(ctx context.Context, pick *models.DraftPickSe
 func (r *draftRepository) GetPicksBySession(ctx context.Context, sessionID string) ([]*models.DraftPickSession, error) {
 	query := `
 		SELECT id, session_id, pack_number, pick_number, card_id, timestamp,
-			pick_quality_grade, pick_quality_rank, pack_best_gihwr, picked_card_gihwr, alternatives_json
+			pick_quality_grade, pick_quality_rank, pack_best_gihwr, picked_card_gihwr, alternatives_json,
+			pick_score, pack_best_score, score_breakdown_json
 		FROM draft_picks
 		WHERE session_id = ?
 		ORDER BY pack_number, pick_number
@@ -401,6 +407,9 @@ func (r *draftRepository) GetPicksBySession(ctx context.Context, sessionID strin
 		var packBestGIHWR sql.NullFloat64
 		var pickedCardGIHWR sql.NullFloat64
 		var alternativesJSON sql.NullString
+		var pickScore sql.NullFloat64
+		var packBestScore sql.NullFloat64
+		var breakdownJSON sql.NullString
 
 		err := rows.Scan(
 			&pick.ID,
@@ -414,6 +423,9 @@ func (r *draftRepository) GetPicksBySession(ctx context.Context, sessionID strin
 			&packBestGIHWR,
 			&pickedCardGIHWR,
 			&alternativesJSON,
+			&pickScore,
+			&packBestScore,
+			&breakdownJSON,
 		)
 		if err != nil {
 			return nil, err
@@ -435,6 +447,15 @@ func (r *draftRepository) GetPicksBySession(ctx context.Context, sessionID strin
 		if alternativesJSON.Valid {
 			pick.AlternativesJSON = &alternativesJSON.String
 		}
+		if pickScore.Valid {
+			pick.PickScore = &pickScore.Float64
+		}
+		if packBestScore.Valid {
+			pick.PackBestScore = &packBestScore.Float64
+		}
+		if breakdownJSON.Valid {
+			pick.ScoreBreakdownJSON = &breakdownJSON.String
+		}
 
 		picks = append(picks, pick)
 	}
@@ -445,13 +466,17 @@ func (r *draftRepository) GetPicksBySession(ctx context.Context, sessionID strin
 // GetPickByNumber retrieves a specific pick by pack and pick number.
 func (r *draftRepository) GetPickByNumber(ctx context.Context, sessionID string, packNum, pickNum int) (*models.DraftPickSession, error) {
 	query := `
-		SELECT id, session_id, pack_number, pick_number, card_id, timestamp
+		SELECT id, session_id, pack_number, pick_number, card_id, timestamp,
+			pick_score, pack_best_score, score_breakdown_json
 		FROM draft_picks
 		WHERE session_id = ? AND pack_number = ? AND pick_number = ?
 	`
 	row := r.db.QueryRowContext(ctx, query, sessionID, packNum, pickNum)
 
 	pick := &models.DraftPickSession{}
+	var pickScore sql.NullFloat64
+	var packBestScore sql.NullFloat64
+	var breakdownJSON sql.NullString
 	err := row.Scan(
 		&pick.ID,
 		&pick.SessionID,
@@ -459,6 +484,9 @@ func (r *draftRepository) GetPickByNumber(ctx context.Context, sessionID string,
 		&pick.PickNumber,
 		&pick.CardID,
 		&pick.Timestamp,
+		&pickScore,
+		&packBestScore,
+		&breakdownJSON,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -467,6 +495,16 @@ func (r *draftRepository) GetPickByNumber(ctx context.Context, sessionID string,
 		return nil, err
 	}
 
+	if pickScore.Valid {
+		pick.PickScore = &pickScore.Float64
+	}
+	if packBestScore.Valid {
+		pick.PackBestScore = &packBestScore.Float64
+	}
+	if breakdownJSON.Valid {
+		pick.ScoreBreakdownJSON = &breakdownJSON.String
+	}
+
 	return pick, nil
 }
 
@@ -608,6 +646,19 @@ func (r *draftRepository) UpdatePickQuality(ctx context.Context, pickID int, gra
 	return err
 }
 
+// UpdatePickScore updates the context-aware score fields for a pick.
+func (r *draftRepository) UpdatePickScore(ctx context.Context, pickID int, pickScore, packBestScore float64, breakdownJSON string) error {
+	query := `
+		UPDATE draft_picks
+		SET pick_score = ?,
+			pack_best_score = ?,
+			score_breakdown_json = ?
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, pickScore, packBestScore, breakdownJSON, pickID)
+	return err
+}
+
 // UpdateSessionGrade updates the grade fields for a draft session.
 func (r *draftRepository) UpdateSessionGrade(ctx context.Context, sessionID string, overallGrade string, overallScore int, pickQuality, colorDiscipline, deckComposition, strategic float64) error {
 	query := `
@@ -668,6 +719,36 @@ func (r *draftRepository) ClearAllSessions(ctx context.Context) (sessionsDeleted
 	return sessionsDeleted, picksDeleted, packsDeleted, nil
 }
 
+// DeleteUnfinishedSimulations deletes practice drafts that were never finished,
+// with their picks and packs. Simulated drafts only live in memory, so any
+// left in progress when the app starts can never be resumed.
+// Returns the number of sessions deleted.
+func (r *draftRepository) DeleteUnfinishedSimulations(ctx context.Context) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	const unfinished = `SELECT id FROM draft_sessions WHERE is_simulated = 1 AND status = 'in_progress'`
+	if _, err := tx.ExecContext(ctx, `DELETE FROM draft_picks WHERE session_id IN (`+unfinished+`)`); err != nil {
+		return 0, fmt.Errorf("failed to delete simulated picks: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM draft_packs WHERE session_id IN (`+unfinished+`)`); err != nil {
+		return 0, fmt.Errorf("failed to delete simulated packs: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM draft_sessions WHERE is_simulated = 1 AND status = 'in_progress'`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete simulated sessions: %w", err)
+	}
+	deleted, _ := result.RowsAffected()
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return deleted, nil
+}
+
 // GetSessionCount returns the total number of draft sessions.
 func (r *draftRepository) GetSessionCount(ctx context.Context) (int, error) {
 	var count int
@@ -690,12 +771,14 @@ func (r *draftRepository) GetPackCount(ctx context.Context) (int, error) {
 }
 
 // GetAllPickCardCounts returns aggregated card counts across all draft picks.
-// Returns a map of card ID (as int) to pick count.
+// Returns a map of card ID (as int) to pick count. Practice draft picks are not
+// cards the player owns, so they are left out.
 func (r *draftRepository) GetAllPickCardCounts(ctx context.Context) (map[int]int, error) {
 	query := `
 		SELECT dp.card_id, COUNT(*) as pick_count
 		FROM draft_picks dp
 		JOIN draft_sessions ds ON dp.session_id = ds.id
+		WHERE ds.is_simulated = 0
 		GROUP BY dp.card_id
 	`
 
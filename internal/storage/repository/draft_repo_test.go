@@ -6,13 +6,13 @@ import (
 	"testing"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 // setupDraftTestDB creates an in-memory database with all draft-related tables.
 func setupDraftTestDB(t *testing.T) *sql.DB {
-	db, err := sql.Open("sqlite3", ":memory:")
+	db, err := sql.Open(sqlitedriver.Name, ":memory:")
 	if err != nil {
 		t.Fatalf("failed to open test database: %v", err)
 	}
@@ -38,6 +38,7 @@ func setupDraftTestDB(t *testing.T) *sql.DB {
 			predicted_win_rate_max REAL,
 			prediction_factors TEXT,
 			predicted_at TIMESTAMP,
+			is_simulated INTEGER NOT NULL DEFAULT 0,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		);
@@ -54,6 +55,9 @@ func setupDraftTestDB(t *testing.T) *sql.DB {
 			pack_best_gihwr REAL,
 			picked_card_gihwr REAL,
 			alternatives_json TEXT,
+			pick_score REAL,
+			pack_best_score REAL,
+			score_breakdown_json TEXT,
 			FOREIGN KEY (session_id) REFERENCES draft_sessions(id) ON DELETE CASCADE,
 			UNIQUE(session_id, pack_number, pick_number)
 		);
@@ -502,6 +506,73 @@ func TestDraftRepository_SavePick(t *testing.T) {
 	}
 }
 
+func TestDraftRepository_UpdatePickScore(t *testing.T) {
+	db := setupDraftTestDB(t)
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("Error closing database: %v", err)
+		}
+	}()
+
+	repo := NewDraftRepository(db)
+	ctx := context.Background()
+	now := time.Now()
+
+	if err := repo.CreateSession(ctx, &models.DraftSession{
+		ID: "session-1", EventName: "Quick Draft FDN", SetCode: "FDN", StartTime: now, Status: "in_progress",
+	}); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	pick := &models.DraftPickSession{SessionID: "session-1", PackNumber: 0, PickNumber: 1, CardID: "12345", Timestamp: now}
+	if err := repo.SavePick(ctx, pick); err != nil {
+		t.Fatalf("failed to save pick: %v", err)
+	}
+
+	// Unscored picks read back with nil scores
+	picks, err := repo.GetPicksBySession(ctx, "session-1")
+	if err != nil {
+		t.Fatalf("failed to get picks: %v", err)
+	}
+	if picks[0].PickScore != nil || picks[0].ScoreBreakdownJSON != nil {
+		t.Error("expected no score before UpdatePickScore")
+	}
+
+	breakdown := `{"base_gihwr":57.5,"color_fit":-1.5,"archetype":0,"role_need":0,"position":0}`
+	if err := repo.UpdatePickScore(ctx, pick.ID, 56, 58.25, breakdown); err != nil {
+		t.Fatalf("failed to update pick score: %v", err)
+	}
+
+	picks, err = repo.GetPicksBySession(ctx, "session-1")
+	if err != nil {
+		t.Fatalf("failed to get picks: %v", err)
+	}
+	got := picks[0]
+	if got.PickScore == nil || *got.PickScore != 56 {
+		t.Errorf("expected pick score 56, got %v", got.PickScore)
+	}
+	if got.PackBestScore == nil || *got.PackBestScore != 58.25 {
+		t.Errorf("expected pack best score 58.25, got %v", got.PackBestScore)
+	}
+	if got.ScoreBreakdownJSON == nil || *got.ScoreBreakdownJSON != breakdown {
+		t.Errorf("expected breakdown %s, got %v", breakdown, got.ScoreBreakdownJSON)
+	}
+
+	// A single pick reads back with its score too
+	byNumber, err := repo.GetPickByNumber(ctx, "session-1", 0, 1)
+	if err != nil {
+		t.Fatalf("failed to get pick by number: %v", err)
+	}
+	if byNumber.PickScore == nil || *byNumber.PickScore != 56 {
+		t.Errorf("GetPickByNumber: expected pick score 56, got %v", byNumber.PickScore)
+	}
+	if byNumber.PackBestScore == nil || *byNumber.PackBestScore != 58.25 {
+		t.Errorf("GetPickByNumber: expected pack best score 58.25, got %v", byNumber.PackBestScore)
+	}
+	if byNumber.ScoreBreakdownJSON == nil || *byNumber.ScoreBreakdownJSON != breakdown {
+		t.Errorf("GetPickByNumber: expected breakdown %s, got %v", breakdown, byNumber.ScoreBreakdownJSON)
+	}
+}
+
 func TestDraftRepository_SavePack(t *testing.T) {
 	db := setupDraftTestDB(t)
 	defer func() {
@@ -853,6 +924,93 @@ func TestDraftRepository_GetCompletedSessions(t *testing.T) {
 	}
 }
 
+func TestDraftRepository_SimulatedSessions(t *testing.T) {
+	db := setupDraftTestDB(t)
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("Error closing database: %v", err)
+		}
+	}()
+
+	repo := NewDraftRepository(db)
+	ctx := context.Background()
+	now := time.Now()
+
+	sessions := []*models.DraftSession{
+		{ID: "real-active", EventName: "QuickDraft_FDN", SetCode: "FDN", StartTime: now, Status: "in_progress"},
+		{ID: "real-done", EventName: "QuickDraft_FDN", SetCode: "FDN", StartTime: now, Status: "completed"},
+		{ID: "sim_FDN_1", EventName: "PremierDraft", SetCode: "FDN", StartTime: now, Status: "in_progress", IsSimulated: true},
+		{ID: "sim_FDN_2", EventName: "PremierDraft", SetCode: "FDN", StartTime: now, Status: "completed", IsSimulated: true},
+	}
+	for _, session := range sessions {
+		if err := repo.CreateSession(ctx, session); err != nil {
+			t.Fatalf("failed to create session %s: %v", session.ID, err)
+		}
+		pick := &models.DraftPickSession{SessionID: session.ID, PackNumber: 0, PickNumber: 1, CardID: "12345", Timestamp: now}
+		if err := repo.SavePick(ctx, pick); err != nil {
+			t.Fatalf("failed to save pick: %v", err)
+		}
+		pack := &models.DraftPackSession{SessionID: session.ID, PackNumber: 0, PickNumber: 1, CardIDs: []string{"12345"}, Timestamp: now}
+		if err := repo.SavePack(ctx, pack); err != nil {
+			t.Fatalf("failed to save pack: %v", err)
+		}
+	}
+
+	// Practice drafts stay reachable by ID but out of the draft lists
+	sim, err := repo.GetSession(ctx, "sim_FDN_1")
+	if err != nil || sim == nil || !sim.IsSimulated {
+		t.Fatalf("expected simulated session by ID, got %+v (err %v)", sim, err)
+	}
+	active, err := repo.GetActiveSessions(ctx)
+	if err != nil {
+		t.Fatalf("failed to get active sessions: %v", err)
+	}
+	if len(active) != 1 || active[0].ID != "real-active" {
+		t.Errorf("active sessions = %v, want only real-active", active)
+	}
+	completed, err := repo.GetCompletedSessions(ctx, 10)
+	if err != nil {
+		t.Fatalf("failed to get completed sessions: %v", err)
+	}
+	if len(completed) != 1 || completed[0].ID != "real-done" {
+		t.Errorf("completed sessions = %v, want only real-done", completed)
+	}
+	if prefixed, err := repo.GetActiveSessionByIDPrefix(ctx, "sim_"); err != nil || prefixed != nil {
+		t.Errorf("GetActiveSessionByIDPrefix returned practice draft %+v (err %v)", prefixed, err)
+	}
+	counts, err := repo.GetAllPickCardCounts(ctx)
+	if err != nil {
+		t.Fatalf("failed to get pick card counts: %v", err)
+	}
+	if counts[12345] != 2 {
+		t.Errorf("pick count for 12345 = %d, want 2 (real drafts only)", counts[12345])
+	}
+
+	// Only the unfinished practice draft is cleaned up
+	deleted, err := repo.DeleteUnfinishedSimulations(ctx)
+	if err != nil {
+		t.Fatalf("DeleteUnfinishedSimulations failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted %d sessions, want 1", deleted)
+	}
+	if gone, _ := repo.GetSession(ctx, "sim_FDN_1"); gone != nil {
+		t.Error("unfinished practice draft should be deleted")
+	}
+	if picks, _ := repo.GetPicksBySession(ctx, "sim_FDN_1"); len(picks) != 0 {
+		t.Errorf("unfinished practice draft left %d picks", len(picks))
+	}
+	if packs, _ := repo.GetPacksBySession(ctx, "sim_FDN_1"); len(packs) != 0 {
+		t.Errorf("unfinished practice draft left %d packs", len(packs))
+	}
+	if kept, _ := repo.GetSession(ctx, "sim_FDN_2"); kept == nil {
+		t.Error("finished practice draft should be kept")
+	}
+	if count, _ := repo.GetSessionCount(ctx); count != 3 {
+		t.Errorf("session count = %d, want 3", count)
+	}
+}
+
 func TestDraftRepository_UpdateSessionPrediction(t *testing.T) {
 	db := setupDraftTestDB(t)
 	defer func() {
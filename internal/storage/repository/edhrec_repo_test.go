@@ -7,8 +7,8 @@ import (
 	"testing"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 func setupEDHRECTestDB(t *testing.T) (*sql.DB, func()) {
@@ -21,7 +21,7 @@ func setupEDHRECTestDB(t *testing.T) (*sql.DB, func()) {
 	}
 	tmpFile.Close()
 
-	db, err := sql.Open("sqlite3", tmpFile.Name())
+	db, err := sql.Open(sqlitedriver.Name, tmpFile.Name())
 	if err != nil {
 		os.Remove(tmpFile.Name())
 		t.Fatalf("Failed to open database: %v", err)
@@ -5,6 +5,9 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
+	"time"
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 )
@@ -55,6 +58,34 @@ type GamePlayRepository interface {
 
 	// DeletePlaysByGame removes all plays for a specific game.
 	DeletePlaysByGame(ctx context.Context, gameID int) error
+
+	// AppendReplaySteps stores per-step game state changes after the frames
+	// already stored for each game.
+	AppendReplaySteps(ctx context.Context, steps []*models.ReplayStep) error
+
+	// GetReplayFrames rebuilds the complete game state at every stored step of a match.
+	GetReplayFrames(ctx context.Context, matchID string) ([]*models.ReplayFrame, error)
+
+	// CreateLifeChanges stores life total changes, skipping ones already stored.
+	CreateLifeChanges(ctx context.Context, changes []*models.LifeChange) error
+
+	// GetLifeChangesByMatch retrieves every life total change of a match in order.
+	GetLifeChangesByMatch(ctx context.Context, matchID string) ([]*models.LifeChange, error)
+
+	// GetDamageByTurn returns the average damage dealt and taken on each turn
+	// of the games played with a deck.
+	GetDamageByTurn(ctx context.Context, deckID string) ([]*models.TurnDamageStats, error)
+
+	// SaveOpeningHands stores hands offered at mulligan prompts, filling in
+	// decisions and the starting player on hands already stored.
+	SaveOpeningHands(ctx context.Context, hands []*models.OpeningHand) error
+
+	// GetOpeningHandsByMatch retrieves the hands offered in a match with each game's result.
+	GetOpeningHandsByMatch(ctx context.Context, matchID string) ([]*models.OpeningHand, error)
+
+	// GetOpeningHandsByDeck retrieves the hands offered in every stored match
+	// played with a deck, with each game's result.
+	GetOpeningHandsByDeck(ctx context.Context, deckID string) ([]*models.OpeningHand, error)
 }
 
 // gamePlayRepository is the concrete implementation.
@@ -571,6 +602,12 @@ func (r *gamePlayRepository) GetPlaySummary(ctx context.Context, matchID string)
 		return nil, fmt.Errorf("failed to get opponent cards count: %w", err)
 	}
 
+	changes, err := r.GetLifeChangesByMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	summarizeLifeChanges(summary, changes)
+
 	return summary, nil
 }
 
@@ -602,6 +639,24 @@ func (r *gamePlayRepository) DeletePlaysByMatch(ctx context.Context, matchID str
 		return fmt.Errorf("failed to delete opponent cards: %w", err)
 	}
 
+	// Delete replay frames
+	_, err = tx.ExecContext(ctx, `DELETE FROM game_replay_frames WHERE match_id = ?`, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to delete replay frames: %w", err)
+	}
+
+	// Delete life changes
+	_, err = tx.ExecContext(ctx, `DELETE FROM life_changes WHERE match_id = ?`, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to delete life changes: %w", err)
+	}
+
+	// Delete opening hands
+	_, err = tx.ExecContext(ctx, `DELETE FROM opening_hands WHERE match_id = ?`, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to delete opening hands: %w", err)
+	}
+
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -637,6 +692,380 @@ func (r *gamePlayRepository) DeletePlaysByGame(ctx context.Context, gameID int)
 		return fmt.Errorf("failed to delete opponent cards: %w", err)
 	}
 
+	// Delete replay frames, which are keyed by match and game number
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM game_replay_frames
+		WHERE EXISTS (
+			SELECT 1 FROM games g
+			WHERE g.id = ? AND g.match_id = game_replay_frames.match_id AND g.game_number = game_replay_frames.game_number
+		)
+	`, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to delete replay frames: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// replayKeyframeInterval is the most frames stored between full states, which
+// bounds how far back appending has to read to rebuild the current state.
+const replayKeyframeInterval = 25
+
+// replayObjects is the stored form of a replay frame's objects: the full
+// state for a keyframe, otherwise only what changed since the previous frame.
+type replayObjects struct {
+	Objects []models.ReplayObject `json:"objects,omitempty"`
+	Removed []int                 `json:"removed,omitempty"`
+}
+
+// replayGameState is a game's state as of its last stored frame.
+type replayGameState struct {
+	objects      map[int]models.ReplayObject
+	frames       int // Frames stored so far
+	keyframe     int // Index of the latest keyframe
+	turnNumber   int
+	phase        string
+	step         string
+	playerLife   *int
+	opponentLife *int
+	timestamp    time.Time
+}
+
+// apply updates the state with a stored frame's objects.
+func (g *replayGameState) apply(isKeyframe bool, objectsJSON string) error {
+	var stored replayObjects
+	if err := json.Unmarshal([]byte(objectsJSON), &stored); err != nil {
+		return fmt.Errorf("failed to decode replay frame: %w", err)
+	}
+	if isKeyframe {
+		g.objects = make(map[int]models.ReplayObject, len(stored.Objects))
+	}
+	for _, obj := range stored.Objects {
+		g.objects[obj.InstanceID] = obj
+	}
+	for _, instanceID := range stored.Removed {
+		delete(g.objects, instanceID)
+	}
+	return nil
+}
+
+// sortedObjects returns the state's objects ordered by instance ID.
+func (g *replayGameState) sortedObjects() []models.ReplayObject {
+	objects := make([]models.ReplayObject, 0, len(g.objects))
+	for _, obj := range g.objects {
+		objects = append(objects, obj)
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].InstanceID < objects[j].InstanceID
+	})
+	return objects
+}
+
+// AppendReplaySteps stores per-step game state changes. Each step is diffed
+// against the game's current state so only real changes are written, with a
+// keyframe holding the full state at the start of a game, on every full
+// state from the GRE, and every replayKeyframeInterval frames. Steps older
+// than a game's last stored frame, or that change nothing, are skipped so
+// reprocessing a log does not duplicate frames.
+func (r *gamePlayRepository) AppendReplaySteps(ctx context.Context, steps []*models.ReplayStep) error {
+	if len(steps) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO game_replay_frames (
+			match_id, game_number, frame_index, turn_number, phase, step, active_player,
+			player_life, opponent_life, is_keyframe, objects_json, timestamp
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	type gameKey struct {
+		MatchID    string
+		GameNumber int
+	}
+	games := make(map[gameKey]*replayGameState)
+
+	for _, step := range steps {
+		key := gameKey{MatchID: step.MatchID, GameNumber: step.GameNumber}
+		game, ok := games[key]
+		if !ok {
+			game, err = r.loadReplayState(ctx, tx, step.MatchID, step.GameNumber)
+			if err != nil {
+				return err
+			}
+			games[key] = game
+		}
+		if game.frames > 0 && step.Timestamp.Before(game.timestamp) {
+			continue
+		}
+
+		var changes replayObjects
+		if step.Full {
+			incoming := make(map[int]bool, len(step.Objects))
+			for _, obj := range step.Objects {
+				incoming[obj.InstanceID] = true
+			}
+			for instanceID := range game.objects {
+				if !incoming[instanceID] {
+					changes.Removed = append(changes.Removed, instanceID)
+				}
+			}
+			sort.Ints(changes.Removed)
+		} else {
+			for _, instanceID := range step.Removed {
+				if _, exists := game.objects[instanceID]; exists {
+					changes.Removed = append(changes.Removed, instanceID)
+				}
+			}
+		}
+		for _, obj := range step.Objects {
+			if existing, exists := game.objects[obj.InstanceID]; !exists || !reflect.DeepEqual(existing, obj) {
+				changes.Objects = append(changes.Objects, obj)
+			}
+		}
+
+		playerLife, opponentLife := game.playerLife, game.opponentLife
+		if step.PlayerLife != nil {
+			playerLife = step.PlayerLife
+		}
+		if step.OpponentLife != nil {
+			opponentLife = step.OpponentLife
+		}
+		unchanged := len(changes.Objects) == 0 && len(changes.Removed) == 0 &&
+			intPtrEqual(playerLife, game.playerLife) && intPtrEqual(opponentLife, game.opponentLife)
+		sameStep := step.TurnNumber == game.turnNumber && step.Phase == game.phase && step.Step == game.step
+		if game.frames > 0 && unchanged && sameStep {
+			continue
+		}
+
+		for _, obj := range changes.Objects {
+			game.objects[obj.InstanceID] = obj
+		}
+		for _, instanceID := range changes.Removed {
+			delete(game.objects, instanceID)
+		}
+
+		index := game.frames
+		isKeyframe := index == 0 || step.Full || index-game.keyframe >= replayKeyframeInterval
+		stored := changes
+		if isKeyframe {
+			stored = replayObjects{Objects: game.sortedObjects()}
+			game.keyframe = index
+		}
+		data, err := json.Marshal(stored)
+		if err != nil {
+			return fmt.Errorf("failed to encode replay frame: %w", err)
+		}
+
+		_, err = stmt.ExecContext(ctx,
+			step.MatchID,
+			step.GameNumber,
+			index,
+			step.TurnNumber,
+			step.Phase,
+			step.Step,
+			step.ActivePlayer,
+			playerLife,
+			opponentLife,
+			isKeyframe,
+			string(data),
+			step.Timestamp.UTC().Format("2006-01-02 15:04:05.999999"),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert replay frame: %w", err)
+		}
+
+		game.frames++
+		game.turnNumber, game.phase, game.step = step.TurnNumber, step.Phase, step.Step
+		game.playerLife, game.opponentLife = playerLife, opponentLife
+		game.timestamp = step.Timestamp
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// loadReplayState rebuilds a game's current state from its latest keyframe
+// and the frames after it.
+func (r *gamePlayRepository) loadReplayState(ctx context.Context, tx *sql.Tx, matchID string, gameNumber int) (*replayGameState, error) {
+	query := `
+		SELECT frame_index, turn_number, phase, step, player_life, opponent_life,
+		       is_keyframe, objects_json, timestamp
+		FROM game_replay_frames
+		WHERE match_id = ? AND game_number = ? AND frame_index >= (
+			SELECT COALESCE(MAX(frame_index), 0) FROM game_replay_frames
+			WHERE match_id = ? AND game_number = ? AND is_keyframe = 1
+		)
+		ORDER BY frame_index ASC
+	`
+
+	rows, err := tx.QueryContext(ctx, query, matchID, gameNumber, matchID, gameNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replay state: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	game := &replayGameState{objects: make(map[int]models.ReplayObject)}
+	for rows.Next() {
+		var index int
+		var phase, step sql.NullString
+		var isKeyframe bool
+		var objectsJSON string
+		if err := rows.Scan(&index, &game.turnNumber, &phase, &step, &game.playerLife, &game.opponentLife,
+			&isKeyframe, &objectsJSON, &game.timestamp); err != nil {
+			return nil, fmt.Errorf("failed to scan replay frame: %w", err)
+		}
+		if err := game.apply(isKeyframe, objectsJSON); err != nil {
+			return nil, err
+		}
+		if isKeyframe {
+			game.keyframe = index
+		}
+		game.frames = index + 1
+		game.phase, game.step = phase.String, step.String
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating replay frames: %w", err)
+	}
+
+	return game, nil
+}
+
+// GetReplayFrames rebuilds the complete game state at every stored step of a
+// match, ordered by game and frame.
+func (r *gamePlayRepository) GetReplayFrames(ctx context.Context, matchID string) ([]*models.ReplayFrame, error) {
+	query := `
+		SELECT game_number, frame_index, turn_number, phase, step, active_player,
+		       player_life, opponent_life, is_keyframe, objects_json, timestamp
+		FROM game_replay_frames
+		WHERE match_id = ?
+		ORDER BY game_number ASC, frame_index ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replay frames: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var frames []*models.ReplayFrame
+	var game *replayGameState
+	gameNumber := -1
+	for rows.Next() {
+		frame := &models.ReplayFrame{MatchID: matchID}
+		var phase, step, activePlayer sql.NullString
+		var isKeyframe bool
+		var objectsJSON string
+		err := rows.Scan(
+			&frame.GameNumber,
+			&frame.FrameIndex,
+			&frame.TurnNumber,
+			&phase,
+			&step,
+			&activePlayer,
+			&frame.PlayerLife,
+			&frame.OpponentLife,
+			&isKeyframe,
+			&objectsJSON,
+			&frame.Timestamp,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan replay frame: %w", err)
+		}
+		frame.Phase, frame.Step, frame.ActivePlayer = phase.String, step.String, activePlayer.String
+
+		if frame.GameNumber != gameNumber {
+			gameNumber = frame.GameNumber
+			game = &replayGameState{objects: make(map[int]models.ReplayObject)}
+		}
+		if err := game.apply(isKeyframe, objectsJSON); err != nil {
+			return nil, err
+		}
+		frame.Objects = game.sortedObjects()
+		frames = append(frames, frame)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating replay frames: %w", err)
+	}
+
+	return frames, nil
+}
+
+// CreateLifeChanges stores life total changes. A change is identified by its
+// game, annotation, affected player and time, so reprocessing a log does not
+// store it twice.
+func (r *gamePlayRepository) CreateLifeChanges(ctx context.Context, changes []*models.LifeChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT OR IGNORE INTO life_changes (
+			match_id, game_number, turn_number, phase, step, affected_player, amount,
+			life_after, change_type, source_card_id, source_controller, annotation_id, timestamp
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	for _, change := range changes {
+		_, err := stmt.ExecContext(ctx,
+			change.MatchID,
+			change.GameNumber,
+			change.TurnNumber,
+			change.Phase,
+			change.Step,
+			change.AffectedPlayer,
+			change.Amount,
+			change.LifeAfter,
+			change.ChangeType,
+			change.SourceCardID,
+			change.SourceController,
+			change.AnnotationID,
+			change.Timestamp.UTC().Format("2006-01-02 15:04:05.999999"),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert life change: %w", err)
+		}
+	}
+
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -644,6 +1073,228 @@ func (r *gamePlayRepository) DeletePlaysByGame(ctx context.Context, gameID int)
 	return nil
 }
 
+// GetLifeChangesByMatch retrieves every life total change of a match in order.
+func (r *gamePlayRepository) GetLifeChangesByMatch(ctx context.Context, matchID string) ([]*models.LifeChange, error) {
+	query := `
+		SELECT id, match_id, game_number, turn_number, phase, step, affected_player, amount,
+		       life_after, change_type, source_card_id, source_controller, annotation_id, timestamp
+		FROM life_changes
+		WHERE match_id = ?
+		ORDER BY game_number ASC, timestamp ASC, id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get life changes: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var changes []*models.LifeChange
+	for rows.Next() {
+		change := &models.LifeChange{}
+		var phase, step, sourceController sql.NullString
+		err := rows.Scan(
+			&change.ID,
+			&change.MatchID,
+			&change.GameNumber,
+			&change.TurnNumber,
+			&phase,
+			&step,
+			&change.AffectedPlayer,
+			&change.Amount,
+			&change.LifeAfter,
+			&change.ChangeType,
+			&change.SourceCardID,
+			&sourceController,
+			&change.AnnotationID,
+			&change.Timestamp,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan life change: %w", err)
+		}
+		change.Phase, change.Step, change.SourceController = phase.String, step.String, sourceController.String
+		changes = append(changes, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating life changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// summarizeLifeChanges adds life totals, damage by source and what killed us
+// in each game to a play summary.
+func summarizeLifeChanges(summary *models.GamePlaySummary, changes []*models.LifeChange) {
+	summary.DamageBySource = damageBySource(changes)
+
+	dead := make(map[int]bool) // Games we already found our death in
+	for i, change := range changes {
+		switch {
+		case change.Amount < 0 && change.AffectedPlayer == models.PlayerTypeOpponent:
+			summary.DamageDealt -= change.Amount
+		case change.Amount < 0:
+			summary.DamageTaken -= change.Amount
+		case change.AffectedPlayer == models.PlayerTypePlayer:
+			summary.LifeGained += change.Amount
+		default:
+			summary.OpponentLifeGained += change.Amount
+		}
+
+		if change.AffectedPlayer != models.PlayerTypePlayer || change.LifeAfter == nil || *change.LifeAfter > 0 || dead[change.GameNumber] {
+			continue
+		}
+		dead[change.GameNumber] = true
+
+		// Everything that hit us on the turn we died
+		var lethalTurn []*models.LifeChange
+		for _, c := range changes[:i+1] {
+			if c.GameNumber == change.GameNumber && c.TurnNumber == change.TurnNumber &&
+				c.AffectedPlayer == models.PlayerTypePlayer && c.Amount < 0 {
+				lethalTurn = append(lethalTurn, c)
+			}
+		}
+		lethal := &models.LethalSummary{
+			GameNumber: change.GameNumber,
+			TurnNumber: change.TurnNumber,
+			Sources:    damageBySource(lethalTurn),
+			FinalBlow:  change,
+		}
+		for _, c := range lethalTurn {
+			lethal.Damage -= c.Amount
+		}
+		summary.KilledBy = append(summary.KilledBy, lethal)
+	}
+}
+
+// damageBySource groups life changes by source card, controller and the
+// player affected, most damage first.
+func damageBySource(changes []*models.LifeChange) []*models.DamageSource {
+	type sourceKey struct {
+		cardID     int
+		controller string
+		target     string
+	}
+	var sources []*models.DamageSource
+	index := make(map[sourceKey]*models.DamageSource)
+	for _, change := range changes {
+		key := sourceKey{controller: change.SourceController, target: change.AffectedPlayer}
+		if change.SourceCardID != nil {
+			key.cardID = *change.SourceCardID
+		}
+		source, ok := index[key]
+		if !ok {
+			source = &models.DamageSource{
+				CardID:     change.SourceCardID,
+				Controller: change.SourceController,
+				Target:     change.AffectedPlayer,
+			}
+			index[key] = source
+			sources = append(sources, source)
+		}
+		if change.Amount < 0 {
+			source.Damage -= change.Amount
+			source.Hits++
+			if change.ChangeType == models.LifeChangeCombat {
+				source.CombatDamage -= change.Amount
+			}
+		} else {
+			source.LifeGained += change.Amount
+		}
+	}
+
+	sort.SliceStable(sources, func(i, j int) bool {
+		return sources[i].Damage > sources[j].Damage
+	})
+	return sources
+}
+
+// GetDamageByTurn averages the damage dealt and taken on each turn number over
+// the games played with a deck that lasted to that turn. A game's length is
+// the last turn with a life change.
+func (r *gamePlayRepository) GetDamageByTurn(ctx context.Context, deckID string) ([]*models.TurnDamageStats, error) {
+	query := `
+		SELECT lc.match_id, lc.game_number, lc.turn_number, lc.affected_player, lc.amount
+		FROM life_changes lc
+		JOIN matches m ON m.id = lc.match_id
+		WHERE m.deck_id = ?
+		ORDER BY lc.match_id, lc.game_number, lc.turn_number
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, deckID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get damage by turn: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	type gameKey struct {
+		matchID    string
+		gameNumber int
+	}
+	lastTurn := make(map[gameKey]int)
+	totals := make(map[int]*models.TurnDamageStats)
+	maxTurn := 0
+	for rows.Next() {
+		var key gameKey
+		var turn, amount int
+		var affected string
+		if err := rows.Scan(&key.matchID, &key.gameNumber, &turn, &affected, &amount); err != nil {
+			return nil, fmt.Errorf("failed to scan life change: %w", err)
+		}
+		lastTurn[key] = max(lastTurn[key], turn)
+		maxTurn = max(maxTurn, turn)
+
+		stats, ok := totals[turn]
+		if !ok {
+			stats = &models.TurnDamageStats{TurnNumber: turn}
+			totals[turn] = stats
+		}
+		switch {
+		case amount < 0 && affected == models.PlayerTypeOpponent:
+			stats.AvgDamageDealt -= float64(amount)
+		case amount < 0:
+			stats.AvgDamageTaken -= float64(amount)
+		case affected == models.PlayerTypePlayer:
+			stats.AvgLifeGained += float64(amount)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating life changes: %w", err)
+	}
+
+	result := []*models.TurnDamageStats{}
+	for turn := 1; turn <= maxTurn; turn++ {
+		stats, ok := totals[turn]
+		if !ok {
+			stats = &models.TurnDamageStats{TurnNumber: turn}
+		}
+		for _, last := range lastTurn {
+			if last >= turn {
+				stats.Games++
+			}
+		}
+		if stats.Games == 0 {
+			continue
+		}
+		stats.AvgDamageDealt /= float64(stats.Games)
+		stats.AvgDamageTaken /= float64(stats.Games)
+		stats.AvgLifeGained /= float64(stats.Games)
+		result = append(result, stats)
+	}
+
+	return result, nil
+}
+
+func intPtrEqual(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 // scanPlays is a helper function to scan game plays from rows.
 func (r *gamePlayRepository) scanPlays(rows *sql.Rows) ([]*models.GamePlay, error) {
 	var plays []*models.GamePlay
@@ -744,16 +1395,31 @@ func (r *gamePlayRepository) scanOpponentCards(rows *sql.Rows) ([]*models.Oppone
 type BoardState struct {
 	PlayerPermanents   []PermanentState `json:"player_permanents"`
 	OpponentPermanents []PermanentState `json:"opponent_permanents"`
+	PlayerHand         []PermanentState `json:"player_hand,omitempty"` // Cards in the player's hand; the opponent's is hidden
 }
 
 // PermanentState represents a permanent on the battlefield.
 type PermanentState struct {
-	CardID    int    `json:"card_id"`
-	CardName  string `json:"card_name"`
-	IsTapped  bool   `json:"is_tapped"`
-	Counters  int    `json:"counters,omitempty"`
-	Attacking bool   `json:"attacking,omitempty"`
-	Blocking  bool   `json:"blocking,omitempty"`
+	InstanceID int      `json:"instance_id,omitempty"`
+	CardID     int      `json:"card_id"`
+	CardName   string   `json:"card_name"`
+	CardTypes  []string `json:"card_types,omitempty"` // Land, Creature, etc.
+	Power      int      `json:"power,omitempty"`
+	Toughness  int      `json:"toughness,omitempty"`
+	IsTapped   bool     `json:"is_tapped"`
+	Counters   int      `json:"counters,omitempty"`
+	Attacking  bool     `json:"attacking,omitempty"`
+	Blocking   bool     `json:"blocking,omitempty"`
+}
+
+// HasType reports whether the permanent has a card type such as "Land" or "Creature".
+func (p *PermanentState) HasType(cardType string) bool {
+	for _, t := range p.CardTypes {
+		if t == cardType {
+			return true
+		}
+	}
+	return false
 }
 
 // ParseBoardState parses the board state JSON from a snapshot.
@@ -769,3 +1435,163 @@ func ParseBoardState(jsonStr *string) (*BoardState, error) {
 
 	return &state, nil
 }
+
+// SaveOpeningHands stores hands offered at mulligan prompts. A hand is
+// identified by its game and mulligan count; saving it again keeps the cards
+// already stored when the new copy has none, and fills in a decision or
+// starting player the earlier copy was missing.
+func (r *gamePlayRepository) SaveOpeningHands(ctx context.Context, hands []*models.OpeningHand) error {
+	if len(hands) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO opening_hands (match_id, game_number, mulligan_count, card_ids, on_play, decision, timestamp)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(match_id, game_number, mulligan_count) DO UPDATE SET
+			card_ids = CASE WHEN excluded.card_ids = '[]' THEN opening_hands.card_ids ELSE excluded.card_ids END,
+			on_play = COALESCE(excluded.on_play, opening_hands.on_play),
+			decision = COALESCE(excluded.decision, opening_hands.decision)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	for _, hand := range hands {
+		cardIDs := hand.CardIDs
+		if cardIDs == nil {
+			cardIDs = []int{}
+		}
+		cardsJSON, err := json.Marshal(cardIDs)
+		if err != nil {
+			return fmt.Errorf("failed to marshal opening hand cards: %w", err)
+		}
+		var decision *string
+		if hand.Decision != "" {
+			decision = &hand.Decision
+		}
+		_, err = stmt.ExecContext(ctx,
+			hand.MatchID,
+			hand.GameNumber,
+			hand.MulliganCount,
+			string(cardsJSON),
+			hand.OnPlay,
+			decision,
+			hand.Timestamp.UTC().Format("2006-01-02 15:04:05.999999"),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save opening hand: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetOpeningHandsByMatch retrieves the hands offered in a match in order.
+func (r *gamePlayRepository) GetOpeningHandsByMatch(ctx context.Context, matchID string) ([]*models.OpeningHand, error) {
+	return r.getOpeningHands(ctx, "oh.match_id = ?", matchID)
+}
+
+// GetOpeningHandsByDeck retrieves the hands offered in every stored match
+// played with a deck.
+func (r *gamePlayRepository) GetOpeningHandsByDeck(ctx context.Context, deckID string) ([]*models.OpeningHand, error) {
+	return r.getOpeningHands(ctx, "m.deck_id = ?", deckID)
+}
+
+// getOpeningHands reads opening hands with their match's deck and game's
+// result. Hands stored without a decision get one from what followed them:
+// a later hand in the same game means this one was mulliganed, and a game
+// that finished with no later hand means it was kept.
+func (r *gamePlayRepository) getOpeningHands(ctx context.Context, where string, args ...interface{}) ([]*models.OpeningHand, error) {
+	query := `
+		SELECT oh.id, oh.match_id, oh.game_number, oh.mulligan_count, oh.card_ids, oh.on_play,
+		       oh.decision, m.deck_id, g.result, oh.timestamp
+		FROM opening_hands oh
+		LEFT JOIN matches m ON m.id = oh.match_id
+		LEFT JOIN games g ON g.match_id = oh.match_id AND g.game_number = oh.game_number
+		WHERE ` + where + `
+		ORDER BY oh.timestamp ASC, oh.game_number ASC, oh.mulligan_count ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get opening hands: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var hands []*models.OpeningHand
+	for rows.Next() {
+		hand := &models.OpeningHand{}
+		var cardsJSON string
+		var onPlay sql.NullBool
+		var decision, deckID, result sql.NullString
+		err := rows.Scan(
+			&hand.ID,
+			&hand.MatchID,
+			&hand.GameNumber,
+			&hand.MulliganCount,
+			&cardsJSON,
+			&onPlay,
+			&decision,
+			&deckID,
+			&result,
+			&hand.Timestamp,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan opening hand: %w", err)
+		}
+		if err := json.Unmarshal([]byte(cardsJSON), &hand.CardIDs); err != nil {
+			return nil, fmt.Errorf("failed to parse opening hand cards: %w", err)
+		}
+		if onPlay.Valid {
+			hand.OnPlay = &onPlay.Bool
+		}
+		hand.Decision, hand.DeckID, hand.Result = decision.String, deckID.String, result.String
+		hands = append(hands, hand)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating opening hands: %w", err)
+	}
+
+	type gameKey struct {
+		matchID    string
+		gameNumber int
+	}
+	lastHand := make(map[gameKey]int) // Highest mulligan count seen in each game
+	for _, hand := range hands {
+		key := gameKey{hand.MatchID, hand.GameNumber}
+		if count, ok := lastHand[key]; !ok || hand.MulliganCount > count {
+			lastHand[key] = hand.MulliganCount
+		}
+	}
+	for _, hand := range hands {
+		if hand.Decision != "" {
+			continue
+		}
+		switch {
+		case hand.MulliganCount < lastHand[gameKey{hand.MatchID, hand.GameNumber}]:
+			hand.Decision = models.MulliganDecisionMulligan
+		case hand.Result != "":
+			hand.Decision = models.MulliganDecisionKeep
+		}
+	}
+
+	return hands, nil
+}
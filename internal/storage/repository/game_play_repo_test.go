@@ -6,13 +6,13 @@ import (
 	"testing"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 // setupGamePlayTestDB creates an in-memory database with game play related tables.
 func setupGamePlayTestDB(t *testing.T) *sql.DB {
-	db, err := sql.Open("sqlite3", ":memory:")
+	db, err := sql.Open(sqlitedriver.Name, ":memory:")
 	if err != nil {
 		t.Fatalf("failed to open test database: %v", err)
 	}
@@ -101,6 +101,53 @@ func setupGamePlayTestDB(t *testing.T) *sql.DB {
 			UNIQUE(game_id, card_id)
 		);
 
+		CREATE TABLE game_replay_frames (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			match_id TEXT NOT NULL,
+			game_number INTEGER NOT NULL,
+			frame_index INTEGER NOT NULL,
+			turn_number INTEGER NOT NULL,
+			phase TEXT,
+			step TEXT,
+			active_player TEXT,
+			player_life INTEGER,
+			opponent_life INTEGER,
+			is_keyframe BOOLEAN NOT NULL DEFAULT 0,
+			objects_json TEXT NOT NULL,
+			timestamp TIMESTAMP NOT NULL,
+			UNIQUE(match_id, game_number, frame_index)
+		);
+
+		CREATE TABLE life_changes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			match_id TEXT NOT NULL,
+			game_number INTEGER NOT NULL,
+			turn_number INTEGER NOT NULL,
+			phase TEXT,
+			step TEXT,
+			affected_player TEXT NOT NULL,
+			amount INTEGER NOT NULL,
+			life_after INTEGER,
+			change_type TEXT NOT NULL,
+			source_card_id INTEGER,
+			source_controller TEXT,
+			annotation_id INTEGER NOT NULL DEFAULT 0,
+			timestamp TIMESTAMP NOT NULL,
+			UNIQUE(match_id, game_number, annotation_id, affected_player, timestamp)
+		);
+
+		CREATE TABLE opening_hands (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			match_id TEXT NOT NULL,
+			game_number INTEGER NOT NULL,
+			mulligan_count INTEGER NOT NULL DEFAULT 0,
+			card_ids TEXT NOT NULL,
+			on_play BOOLEAN,
+			decision TEXT,
+			timestamp TIMESTAMP NOT NULL,
+			UNIQUE(match_id, game_number, mulligan_count)
+		);
+
 		CREATE INDEX idx_game_plays_game_id ON game_plays(game_id);
 		CREATE INDEX idx_game_plays_match_id ON game_plays(match_id);
 		CREATE INDEX idx_game_plays_turn ON game_plays(game_id, turn_number);
@@ -622,6 +669,94 @@ func TestGamePlayRepository_GetPlaySummary(t *testing.T) {
 	}
 }
 
+func TestGamePlayRepository_LifeChanges(t *testing.T) {
+	db := setupGamePlayTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	gameID := createTestMatch(t, db, "match-011")
+	if _, err := db.Exec(`UPDATE matches SET deck_id = 'deck-1' WHERE id = 'match-011'`); err != nil {
+		t.Fatalf("failed to set deck: %v", err)
+	}
+	repo := NewGamePlayRepository(db)
+	ctx := context.Background()
+
+	if err := repo.CreatePlays(ctx, []*models.GamePlay{
+		{GameID: gameID, MatchID: "match-011", TurnNumber: 1, Phase: "Main1", PlayerType: "player", ActionType: "land_drop", Timestamp: time.Now(), SequenceNumber: 1},
+	}); err != nil {
+		t.Fatalf("CreatePlays failed: %v", err)
+	}
+
+	base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	change := func(annotation, turn int, affected string, amount, lifeAfter int, changeType string, cardID int, controller string) *models.LifeChange {
+		return &models.LifeChange{
+			MatchID: "match-011", GameNumber: 1, TurnNumber: turn, AffectedPlayer: affected,
+			Amount: amount, LifeAfter: &lifeAfter, ChangeType: changeType,
+			SourceCardID: &cardID, SourceController: controller, AnnotationID: annotation,
+			Timestamp: base.Add(time.Duration(annotation) * time.Second),
+		}
+	}
+	changes := []*models.LifeChange{
+		change(1, 2, "opponent", -3, 17, models.LifeChangeCombat, 500, "player"),
+		change(2, 3, "player", -4, 16, models.LifeChangeBurn, 600, "opponent"),
+		change(3, 4, "player", 2, 18, models.LifeChangeLifelink, 500, "player"),
+		change(4, 5, "player", -10, 8, models.LifeChangeCombat, 601, "opponent"),
+		change(5, 5, "player", -8, 0, models.LifeChangeBurn, 600, "opponent"),
+	}
+
+	// Storing the same changes again must not duplicate them
+	for i := 0; i < 2; i++ {
+		if err := repo.CreateLifeChanges(ctx, changes); err != nil {
+			t.Fatalf("CreateLifeChanges failed: %v", err)
+		}
+	}
+
+	stored, err := repo.GetLifeChangesByMatch(ctx, "match-011")
+	if err != nil {
+		t.Fatalf("GetLifeChangesByMatch failed: %v", err)
+	}
+	if len(stored) != 5 {
+		t.Fatalf("Expected 5 life changes, got %d", len(stored))
+	}
+	if stored[1].ChangeType != models.LifeChangeBurn || *stored[1].SourceCardID != 600 || *stored[1].LifeAfter != 16 {
+		t.Errorf("Unexpected life change: %+v", stored[1])
+	}
+
+	summary, err := repo.GetPlaySummary(ctx, "match-011")
+	if err != nil {
+		t.Fatalf("GetPlaySummary failed: %v", err)
+	}
+	if summary.DamageDealt != 3 || summary.DamageTaken != 22 || summary.LifeGained != 2 {
+		t.Errorf("Expected 3 dealt, 22 taken, 2 gained, got %d, %d, %d", summary.DamageDealt, summary.DamageTaken, summary.LifeGained)
+	}
+	if top := summary.DamageBySource[0]; *top.CardID != 600 || top.Damage != 12 || top.Hits != 2 || top.Target != "player" {
+		t.Errorf("Expected card 600 to deal the most damage, got %+v", top)
+	}
+	if len(summary.KilledBy) != 1 {
+		t.Fatalf("Expected one lethal summary, got %d", len(summary.KilledBy))
+	}
+	lethal := summary.KilledBy[0]
+	if lethal.TurnNumber != 5 || lethal.Damage != 18 || len(lethal.Sources) != 2 {
+		t.Errorf("Unexpected lethal summary: %+v", lethal)
+	}
+	if *lethal.Sources[0].CardID != 601 || lethal.Sources[0].CombatDamage != 10 {
+		t.Errorf("Expected the 10 combat damage first, got %+v", lethal.Sources[0])
+	}
+	if lethal.FinalBlow.AnnotationID != 5 {
+		t.Errorf("Expected the burn spell as the final blow, got %+v", lethal.FinalBlow)
+	}
+
+	byTurn, err := repo.GetDamageByTurn(ctx, "deck-1")
+	if err != nil {
+		t.Fatalf("GetDamageByTurn failed: %v", err)
+	}
+	if len(byTurn) != 5 {
+		t.Fatalf("Expected 5 turns, got %d", len(byTurn))
+	}
+	if byTurn[1].AvgDamageDealt != 3 || byTurn[4].AvgDamageTaken != 18 || byTurn[4].Games != 1 {
+		t.Errorf("Unexpected damage by turn: %+v, %+v", byTurn[1], byTurn[4])
+	}
+}
+
 func TestGamePlayRepository_DeletePlaysByMatch(t *testing.T) {
 	db := setupGamePlayTestDB(t)
 	defer func() { _ = db.Close() }()
@@ -691,6 +826,134 @@ func TestGamePlayRepository_DeletePlaysByMatch(t *testing.T) {
 	}
 }
 
+func TestReplayFrames_DeltaRoundTrip(t *testing.T) {
+	db := setupGamePlayTestDB(t)
+	defer func() { _ = db.Close() }()
+	repo := NewGamePlayRepository(db)
+	ctx := context.Background()
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	life := func(v int) *int { return &v }
+	land := models.ReplayObject{InstanceID: 1, CardID: 100, Controller: "player", Owner: "player", Zone: "battlefield", CardTypes: []string{"Land"}}
+	bear := models.ReplayObject{InstanceID: 2, CardID: 200, Controller: "player", Owner: "player", Zone: "hand"}
+	tappedLand := land
+	tappedLand.IsTapped = true
+	castBear := bear
+	castBear.Zone = "battlefield"
+	castBear.Counters = map[string]int{"P1P1": 1}
+
+	steps := []*models.ReplayStep{
+		{MatchID: "match-1", GameNumber: 1, TurnNumber: 1, Phase: "Main1", ActivePlayer: "player", PlayerLife: life(20), OpponentLife: life(20), Full: true, Objects: []models.ReplayObject{land, bear}, Timestamp: start},
+		{MatchID: "match-1", GameNumber: 1, TurnNumber: 1, Phase: "Main1", ActivePlayer: "player", Objects: []models.ReplayObject{land}, Timestamp: start.Add(time.Second)}, // No change: skipped
+		{MatchID: "match-1", GameNumber: 1, TurnNumber: 1, Phase: "Main2", ActivePlayer: "player", Objects: []models.ReplayObject{tappedLand, castBear}, Timestamp: start.Add(2 * time.Second)},
+		{MatchID: "match-1", GameNumber: 1, TurnNumber: 2, Phase: "Combat", Step: "CombatDamage", ActivePlayer: "opponent", PlayerLife: life(17), Removed: []int{2}, Timestamp: start.Add(3 * time.Second)},
+		{MatchID: "match-1", GameNumber: 2, TurnNumber: 1, Phase: "Main1", ActivePlayer: "opponent", Full: true, Objects: []models.ReplayObject{bear}, Timestamp: start.Add(4 * time.Second)},
+	}
+	if err := repo.AppendReplaySteps(ctx, steps); err != nil {
+		t.Fatalf("AppendReplaySteps: %v", err)
+	}
+
+	// Only the first frame of each game is a keyframe; the rest are stored as changes
+	var keyframes, deltas int
+	if err := db.QueryRow(`SELECT SUM(is_keyframe), SUM(1 - is_keyframe) FROM game_replay_frames`).Scan(&keyframes, &deltas); err != nil {
+		t.Fatalf("count frames: %v", err)
+	}
+	if keyframes != 2 || deltas != 2 {
+		t.Errorf("stored %d keyframes and %d deltas, want 2 and 2", keyframes, deltas)
+	}
+
+	frames, err := repo.GetReplayFrames(ctx, "match-1")
+	if err != nil {
+		t.Fatalf("GetReplayFrames: %v", err)
+	}
+	if len(frames) != 4 {
+		t.Fatalf("got %d frames, want 4", len(frames))
+	}
+
+	main2 := frames[1]
+	if main2.Phase != "Main2" || len(main2.Objects) != 2 || !main2.Objects[0].IsTapped || main2.Objects[1].Zone != "battlefield" || main2.Objects[1].Counters["P1P1"] != 1 {
+		t.Errorf("Main2 frame = %+v", main2)
+	}
+	if main2.PlayerLife == nil || *main2.PlayerLife != 20 {
+		t.Error("life should carry forward between frames")
+	}
+	damage := frames[2]
+	if len(damage.Objects) != 1 || damage.Objects[0].InstanceID != 1 || *damage.PlayerLife != 17 || damage.Step != "CombatDamage" {
+		t.Errorf("combat damage frame = %+v", damage)
+	}
+	if game2 := frames[3]; game2.GameNumber != 2 || game2.FrameIndex != 0 || len(game2.Objects) != 1 || game2.Objects[0].Zone != "hand" {
+		t.Errorf("game 2 frame = %+v", game2)
+	}
+
+	// Reprocessing the same steps adds nothing; later steps continue from the stored state
+	more := append(steps[:4:4], &models.ReplayStep{MatchID: "match-1", GameNumber: 1, TurnNumber: 3, Phase: "Main1", ActivePlayer: "player", Objects: []models.ReplayObject{land}, Timestamp: start.Add(5 * time.Second)})
+	if err := repo.AppendReplaySteps(ctx, more); err != nil {
+		t.Fatalf("AppendReplaySteps again: %v", err)
+	}
+	frames, err = repo.GetReplayFrames(ctx, "match-1")
+	if err != nil {
+		t.Fatalf("GetReplayFrames: %v", err)
+	}
+	if len(frames) != 5 {
+		t.Fatalf("got %d frames after reprocessing, want 5", len(frames))
+	}
+	if untapped := frames[3]; untapped.TurnNumber != 3 || untapped.FrameIndex != 3 || len(untapped.Objects) != 1 || untapped.Objects[0].IsTapped {
+		t.Errorf("appended frame = %+v", untapped)
+	}
+
+	if err := repo.DeletePlaysByMatch(ctx, "match-1"); err != nil {
+		t.Fatalf("DeletePlaysByMatch: %v", err)
+	}
+	if frames, _ := repo.GetReplayFrames(ctx, "match-1"); len(frames) != 0 {
+		t.Errorf("expected replay frames to be deleted with the match, got %d", len(frames))
+	}
+}
+
+func TestReplayFrames_PeriodicKeyframes(t *testing.T) {
+	db := setupGamePlayTestDB(t)
+	defer func() { _ = db.Close() }()
+	repo := NewGamePlayRepository(db)
+	ctx := context.Background()
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var steps []*models.ReplayStep
+	for i := 0; i < replayKeyframeInterval*2+1; i++ {
+		steps = append(steps, &models.ReplayStep{
+			MatchID:    "match-2",
+			GameNumber: 1,
+			TurnNumber: i + 1,
+			Phase:      "Main1",
+			Objects:    []models.ReplayObject{{InstanceID: i + 1, CardID: 100, Zone: "battlefield"}},
+			Timestamp:  start.Add(time.Duration(i) * time.Second),
+		})
+	}
+	// Split across two calls so the second rebuilds its state from the latest keyframe
+	if err := repo.AppendReplaySteps(ctx, steps[:replayKeyframeInterval+3]); err != nil {
+		t.Fatalf("AppendReplaySteps: %v", err)
+	}
+	if err := repo.AppendReplaySteps(ctx, steps[replayKeyframeInterval+3:]); err != nil {
+		t.Fatalf("AppendReplaySteps: %v", err)
+	}
+
+	var keyframes int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM game_replay_frames WHERE is_keyframe = 1`).Scan(&keyframes); err != nil {
+		t.Fatalf("count keyframes: %v", err)
+	}
+	if keyframes != 3 {
+		t.Errorf("got %d keyframes, want 3", keyframes)
+	}
+
+	frames, err := repo.GetReplayFrames(ctx, "match-2")
+	if err != nil {
+		t.Fatalf("GetReplayFrames: %v", err)
+	}
+	for i, frame := range frames {
+		if len(frame.Objects) != i+1 {
+			t.Fatalf("frame %d has %d objects, want %d", i, len(frame.Objects), i+1)
+		}
+	}
+}
+
 func TestParseBoardState(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -750,6 +1013,69 @@ func TestParseBoardState(t *testing.T) {
 	}
 }
 
+func TestGamePlayRepository_OpeningHands(t *testing.T) {
+	db := setupGamePlayTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	createTestMatch(t, db, "match-012")
+	if _, err := db.Exec(`UPDATE matches SET deck_id = 'deck-1' WHERE id = 'match-012'`); err != nil {
+		t.Fatalf("failed to set deck: %v", err)
+	}
+	repo := NewGamePlayRepository(db)
+	ctx := context.Background()
+
+	base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	onDraw := false
+	// Seen live: the second hand has no decision yet and the starting player is unknown
+	if err := repo.SaveOpeningHands(ctx, []*models.OpeningHand{
+		{MatchID: "match-012", GameNumber: 1, MulliganCount: 0, CardIDs: []int{1, 2, 3, 4, 5, 6, 7}, Timestamp: base},
+		{MatchID: "match-012", GameNumber: 1, MulliganCount: 1, CardIDs: []int{8, 9, 10, 11, 12, 13, 14}, Timestamp: base.Add(time.Second)},
+	}); err != nil {
+		t.Fatalf("SaveOpeningHands failed: %v", err)
+	}
+	// A later read without the cards fills in what was missing
+	if err := repo.SaveOpeningHands(ctx, []*models.OpeningHand{
+		{MatchID: "match-012", GameNumber: 1, MulliganCount: 1, OnPlay: &onDraw, Timestamp: base.Add(time.Second)},
+	}); err != nil {
+		t.Fatalf("SaveOpeningHands failed: %v", err)
+	}
+
+	hands, err := repo.GetOpeningHandsByMatch(ctx, "match-012")
+	if err != nil {
+		t.Fatalf("GetOpeningHandsByMatch failed: %v", err)
+	}
+	if len(hands) != 2 {
+		t.Fatalf("Expected 2 opening hands, got %d", len(hands))
+	}
+	if hands[0].Decision != models.MulliganDecisionMulligan || hands[1].Decision != models.MulliganDecisionKeep {
+		t.Errorf("Expected decisions mulligan then keep, got %q and %q", hands[0].Decision, hands[1].Decision)
+	}
+	if len(hands[1].CardIDs) != 7 || hands[1].CardIDs[0] != 8 {
+		t.Errorf("Expected stored cards to be kept, got %v", hands[1].CardIDs)
+	}
+	if hands[1].OnPlay == nil || *hands[1].OnPlay || hands[0].OnPlay != nil {
+		t.Errorf("Unexpected starting player: %v, %v", hands[0].OnPlay, hands[1].OnPlay)
+	}
+	if hands[1].Result != "win" || hands[1].DeckID != "deck-1" {
+		t.Errorf("Expected the game result and deck, got %q and %q", hands[1].Result, hands[1].DeckID)
+	}
+
+	byDeck, err := repo.GetOpeningHandsByDeck(ctx, "deck-1")
+	if err != nil {
+		t.Fatalf("GetOpeningHandsByDeck failed: %v", err)
+	}
+	if len(byDeck) != 2 {
+		t.Errorf("Expected 2 hands for the deck, got %d", len(byDeck))
+	}
+
+	if err := repo.DeletePlaysByMatch(ctx, "match-012"); err != nil {
+		t.Fatalf("DeletePlaysByMatch failed: %v", err)
+	}
+	if hands, _ := repo.GetOpeningHandsByMatch(ctx, "match-012"); len(hands) != 0 {
+		t.Errorf("Expected opening hands to be deleted, got %d", len(hands))
+	}
+}
+
 // strPtr is a helper to get a pointer to a string.
 func strPtr(s string) *string {
 	return &s
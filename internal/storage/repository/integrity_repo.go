@@ -0,0 +1,346 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+)
+
+// integritySampleLimit caps how many identifying keys are returned per issue.
+const integritySampleLimit = 10
+
+// IntegrityRepository runs cross-table consistency checks and the safe repairs
+// that go with them. Checks only read; each Delete method removes exactly the
+// rows its matching Find method reports.
+type IntegrityRepository interface {
+	// IntegrityCheck runs SQLite's PRAGMA integrity_check and returns any problems.
+	// An empty slice means the database file is structurally sound.
+	IntegrityCheck(ctx context.Context) ([]string, error)
+
+	// FindOrphanGames finds games whose match no longer exists, plus play-by-play
+	// rows whose game no longer exists.
+	FindOrphanGames(ctx context.Context) (*models.IntegrityIssue, error)
+
+	// DeleteOrphanGames removes the rows reported by FindOrphanGames.
+	DeleteOrphanGames(ctx context.Context) (int, error)
+
+	// FindOrphanDraftPicks finds draft picks and packs whose session no longer exists.
+	FindOrphanDraftPicks(ctx context.Context) (*models.IntegrityIssue, error)
+
+	// DeleteOrphanDraftPicks removes the rows reported by FindOrphanDraftPicks.
+	DeleteOrphanDraftPicks(ctx context.Context) (int, error)
+
+	// FindOrphanDeckCards finds deck cards whose deck no longer exists.
+	FindOrphanDeckCards(ctx context.Context) (*models.IntegrityIssue, error)
+
+	// DeleteOrphanDeckCards removes the rows reported by FindOrphanDeckCards.
+	DeleteOrphanDeckCards(ctx context.Context) (int, error)
+
+	// FindUnknownDeckCards finds deck cards whose arena ID has no cached card metadata.
+	// Samples are distinct arena IDs.
+	FindUnknownDeckCards(ctx context.Context) (*models.IntegrityIssue, error)
+
+	// FindDuplicateMatches finds matches that repeat another match's account, event,
+	// timestamp, score and opponent under a different ID. The earliest inserted
+	// copy is treated as the original and is not counted.
+	FindDuplicateMatches(ctx context.Context) (*models.IntegrityIssue, error)
+
+	// DeleteDuplicateMatches removes the rows reported by FindDuplicateMatches,
+	// along with their games.
+	DeleteDuplicateMatches(ctx context.Context) (int, error)
+
+	// FindNegativeWins finds matches with a negative player or opponent win count.
+	FindNegativeWins(ctx context.Context) (*models.IntegrityIssue, error)
+
+	// FindFutureMatches finds matches timestamped after the given time.
+	FindFutureMatches(ctx context.Context, after time.Time) (*models.IntegrityIssue, error)
+}
+
+// integrityRepository is the concrete implementation.
+type integrityRepository struct {
+	db *sql.DB
+}
+
+// NewIntegrityRepository creates a new integrity repository.
+func NewIntegrityRepository(db *sql.DB) IntegrityRepository {
+	return &integrityRepository{db: db}
+}
+
+const (
+	orphanGamesWhere      = `match_id NOT IN (SELECT id FROM matches)`
+	orphanGameChildWhere  = `game_id NOT IN (SELECT id FROM games)`
+	orphanDraftWhere      = `session_id NOT IN (SELECT id FROM draft_sessions)`
+	orphanDeckCardsWhere  = `deck_id NOT IN (SELECT id FROM decks)`
+	unknownDeckCardsWhere = `CAST(card_id AS TEXT) NOT IN (SELECT arena_id FROM set_cards)`
+
+	// duplicateMatchesWhere selects every match that has an earlier-inserted twin.
+	duplicateMatchesWhere = `rowid NOT IN (
+		SELECT MIN(rowid) FROM matches
+		GROUP BY COALESCE(account_id, 0), event_id, timestamp, player_wins, opponent_wins, COALESCE(opponent_id, '')
+	)`
+)
+
+// gameChildTables hold per-game rows keyed by game_id.
+var gameChildTables = []string{"game_plays", "game_state_snapshots", "opponent_cards_observed"}
+
+// IntegrityCheck runs SQLite's PRAGMA integrity_check and returns any problems.
+func (r *integrityRepository) IntegrityCheck(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, "PRAGMA integrity_check")
+	if err != nil {
+		return nil, fmt.Errorf("failed to run integrity check: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	problems := []string{}
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, fmt.Errorf("failed to scan integrity check result: %w", err)
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating integrity check results: %w", err)
+	}
+
+	return problems, nil
+}
+
+// FindOrphanGames finds games whose match no longer exists, plus play-by-play
+// rows whose game no longer exists.
+func (r *integrityRepository) FindOrphanGames(ctx context.Context) (*models.IntegrityIssue, error) {
+	issue, err := r.findIssue(ctx, `SELECT match_id || '#' || game_number FROM games WHERE `+orphanGamesWhere)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orphan games: %w", err)
+	}
+
+	for _, table := range gameChildTables {
+		count, err := r.count(ctx, table, orphanGameChildWhere)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count orphan %s: %w", table, err)
+		}
+		issue.Count += count
+	}
+
+	return issue, nil
+}
+
+// DeleteOrphanGames removes the rows reported by FindOrphanGames.
+func (r *integrityRepository) DeleteOrphanGames(ctx context.Context) (int, error) {
+	stmts := []string{`DELETE FROM games WHERE ` + orphanGamesWhere}
+	for _, table := range gameChildTables {
+		// Runs after the games delete so children of just-removed games go too
+		stmts = append(stmts, `DELETE FROM `+table+` WHERE `+orphanGameChildWhere)
+	}
+
+	deleted, err := r.execInTx(ctx, stmts...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete orphan games: %w", err)
+	}
+	return deleted, nil
+}
+
+// FindOrphanDraftPicks finds draft picks and packs whose session no longer exists.
+func (r *integrityRepository) FindOrphanDraftPicks(ctx context.Context) (*models.IntegrityIssue, error) {
+	issue, err := r.findIssue(ctx, `SELECT session_id || ' P' || pack_number || 'p' || pick_number FROM draft_picks WHERE `+orphanDraftWhere)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orphan draft picks: %w", err)
+	}
+
+	packs, err := r.count(ctx, "draft_packs", orphanDraftWhere)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count orphan draft packs: %w", err)
+	}
+	issue.Count += packs
+
+	return issue, nil
+}
+
+// DeleteOrphanDraftPicks removes the rows reported by FindOrphanDraftPicks.
+func (r *integrityRepository) DeleteOrphanDraftPicks(ctx context.Context) (int, error) {
+	deleted, err := r.execInTx(ctx,
+		`DELETE FROM draft_picks WHERE `+orphanDraftWhere,
+		`DELETE FROM draft_packs WHERE `+orphanDraftWhere,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete orphan draft picks: %w", err)
+	}
+	return deleted, nil
+}
+
+// FindOrphanDeckCards finds deck cards whose deck no longer exists.
+func (r *integrityRepository) FindOrphanDeckCards(ctx context.Context) (*models.IntegrityIssue, error) {
+	issue, err := r.findIssue(ctx, `SELECT deck_id || ':' || card_id FROM deck_cards WHERE `+orphanDeckCardsWhere)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orphan deck cards: %w", err)
+	}
+	return issue, nil
+}
+
+// DeleteOrphanDeckCards removes the rows reported by FindOrphanDeckCards.
+func (r *integrityRepository) DeleteOrphanDeckCards(ctx context.Context) (int, error) {
+	deleted, err := r.execInTx(ctx, `DELETE FROM deck_cards WHERE `+orphanDeckCardsWhere)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete orphan deck cards: %w", err)
+	}
+	return deleted, nil
+}
+
+// FindUnknownDeckCards finds deck cards whose arena ID has no cached card metadata.
+func (r *integrityRepository) FindUnknownDeckCards(ctx context.Context) (*models.IntegrityIssue, error) {
+	count, err := r.count(ctx, "deck_cards", unknownDeckCardsWhere)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unknown deck cards: %w", err)
+	}
+
+	samples, err := r.samples(ctx,
+		`SELECT DISTINCT CAST(card_id AS TEXT) FROM deck_cards WHERE `+unknownDeckCardsWhere+` ORDER BY card_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sample unknown deck cards: %w", err)
+	}
+
+	return &models.IntegrityIssue{Count: count, Samples: samples}, nil
+}
+
+// FindDuplicateMatches finds matches that repeat another match under a different ID.
+func (r *integrityRepository) FindDuplicateMatches(ctx context.Context) (*models.IntegrityIssue, error) {
+	issue, err := r.findIssue(ctx, `SELECT id FROM matches WHERE `+duplicateMatchesWhere)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate matches: %w", err)
+	}
+	return issue, nil
+}
+
+// DeleteDuplicateMatches removes the rows reported by FindDuplicateMatches, along with their games.
+func (r *integrityRepository) DeleteDuplicateMatches(ctx context.Context) (int, error) {
+	// Games are removed first while the duplicate rows can still be selected;
+	// their play-by-play rows are left for the orphan games check.
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM games WHERE match_id IN (SELECT id FROM matches WHERE `+duplicateMatchesWhere+`)`); err != nil {
+		return 0, fmt.Errorf("failed to delete duplicate match games: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM matches WHERE `+duplicateMatchesWhere)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete duplicate matches: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return int(deleted), nil
+}
+
+// FindNegativeWins finds matches with a negative player or opponent win count.
+func (r *integrityRepository) FindNegativeWins(ctx context.Context) (*models.IntegrityIssue, error) {
+	issue, err := r.findIssue(ctx, `SELECT id FROM matches WHERE player_wins < 0 OR opponent_wins < 0`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find negative win counts: %w", err)
+	}
+	return issue, nil
+}
+
+// FindFutureMatches finds matches timestamped after the given time.
+// Timestamps are stored as Go time text, so the cutoff is bound as a time.Time
+// and compared as text, as in the match repository's date filters.
+func (r *integrityRepository) FindFutureMatches(ctx context.Context, after time.Time) (*models.IntegrityIssue, error) {
+	issue, err := r.findIssue(ctx, `SELECT id FROM matches WHERE timestamp > ?`, after.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find future matches: %w", err)
+	}
+	return issue, nil
+}
+
+// findIssue counts the rows returned by sampleQuery and keeps the first few as samples.
+func (r *integrityRepository) findIssue(ctx context.Context, sampleQuery string, args ...interface{}) (*models.IntegrityIssue, error) {
+	var count int
+	countQuery := `SELECT COUNT(*) FROM (` + sampleQuery + `)`
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&count); err != nil {
+		return nil, err
+	}
+
+	issue := &models.IntegrityIssue{Count: count}
+	if count == 0 {
+		return issue, nil
+	}
+
+	samples, err := r.samples(ctx, sampleQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	issue.Samples = samples
+
+	return issue, nil
+}
+
+// samples returns up to integritySampleLimit values from a single-column query.
+func (r *integrityRepository) samples(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf("%s LIMIT %d", query, integritySampleLimit), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var samples []string
+	for rows.Next() {
+		var s sql.NullString
+		if err := rows.Scan(&s); err != nil {
+			return nil, err
+		}
+		samples = append(samples, s.String)
+	}
+
+	return samples, rows.Err()
+}
+
+// count returns the number of rows in table matching where.
+func (r *integrityRepository) count(ctx context.Context, table, where string) (int, error) {
+	var n int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+table+` WHERE `+where).Scan(&n)
+	return n, err
+}
+
+// execInTx runs stmts in one transaction and returns the total rows affected.
+func (r *integrityRepository) execInTx(ctx context.Context, stmts ...string) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var total int64
+	for _, stmt := range stmts {
+		result, err := tx.ExecContext(ctx, stmt)
+		if err != nil {
+			return 0, err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		total += n
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return int(total), nil
+}
@@ -81,16 +81,32 @@ type MatchRepository interface {
 
 	// MarkMatchesAsProcessedForML marks the given match IDs as processed by the ML engine.
 	MarkMatchesAsProcessedForML(ctx context.Context, matchIDs []string) error
+
+	// WithTx returns a repository that runs every query inside tx.
+	WithTx(tx *sql.Tx) MatchRepository
 }
 
 // matchRepository is the concrete implementation of MatchRepository.
 type matchRepository struct {
-	db *sql.DB
+	db     Querier // writer: inserts, updates and deletes
+	reader Querier // read-only pool for analytics queries; same as db when not split
+	pool   *sql.DB // writer pool for repository-owned transactions; nil inside WithTx
 }
 
 // NewMatchRepository creates a new match repository.
 func NewMatchRepository(db *sql.DB) MatchRepository {
-	return &matchRepository{db: db}
+	return &matchRepository{db: db, reader: db, pool: db}
+}
+
+// NewMatchRepositoryWithReader creates a match repository whose read methods run on
+// reader (typically storage.DB.Reader) while writes stay on db.
+func NewMatchRepositoryWithReader(db, reader *sql.DB) MatchRepository {
+	return &matchRepository{db: db, reader: reader, pool: db}
+}
+
+// WithTx returns a repository that runs every query, reads included, inside tx.
+func (r *matchRepository) WithTx(tx *sql.Tx) MatchRepository {
+	return &matchRepository{db: tx, reader: tx}
 }
 
 // buildFilterWhereClause constructs a WHERE clause and args from a StatsFilter.
@@ -287,7 +303,7 @@ func (r *matchRepository) GetByID(ctx context.Context, id string) (*models.Match
 	`
 
 	match := &models.Match{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.reader.QueryRowContext(ctx, query, id).Scan(
 		&match.ID,
 		&match.AccountID,
 		&match.EventID,
@@ -339,7 +355,7 @@ func (r *matchRepository) GetByDateRange(ctx context.Context, start, end time.Ti
 
 	query += " ORDER BY timestamp DESC"
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.reader.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get matches by date range: %w", err)
 	}
@@ -405,7 +421,7 @@ func (r *matchRepository) GetByFormat(ctx context.Context, format string, accoun
 
 	query += " ORDER BY timestamp DESC"
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.reader.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get matches by format: %w", err)
 	}
@@ -470,7 +486,7 @@ func (r *matchRepository) GetMatches(ctx context.Context, filter models.StatsFil
 		ORDER BY m.timestamp DESC
 	`, fromClause, where)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.reader.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get filtered matches: %w", err)
 	}
@@ -557,7 +573,7 @@ func (r *matchRepository) GetStats(ctx context.Context, filter models.StatsFilte
 	`, col("result"), col("result"), matchFrom, where)
 
 	stats := &models.Statistics{}
-	err := r.db.QueryRowContext(ctx, matchQuery, args...).Scan(
+	err := r.reader.QueryRowContext(ctx, matchQuery, args...).Scan(
 		&stats.TotalMatches,
 		&stats.MatchesWon,
 		&stats.MatchesLost,
@@ -581,7 +597,7 @@ func (r *matchRepository) GetStats(ctx context.Context, filter models.StatsFilte
 		%s
 	`, gameFrom, where)
 
-	err = r.db.QueryRowContext(ctx, gameQuery, args...).Scan(
+	err = r.reader.QueryRowContext(ctx, gameQuery, args...).Scan(
 		&stats.TotalGames,
 		&stats.GamesWon,
 		&stats.GamesLost,
@@ -620,7 +636,7 @@ func (r *matchRepository) GetRecentMatches(ctx context.Context, limit int, accou
 	query += " ORDER BY timestamp DESC LIMIT ?"
 	args = append(args, limit)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.reader.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get recent matches: %w", err)
 	}
@@ -686,7 +702,7 @@ func (r *matchRepository) GetLatestMatch(ctx context.Context, accountID int) (*m
 	query += " ORDER BY timestamp DESC LIMIT 1"
 
 	match := &models.Match{}
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+	err := r.reader.QueryRowContext(ctx, query, args...).Scan(
 		&match.ID,
 		&match.AccountID,
 		&match.EventID,
@@ -752,7 +768,7 @@ func (r *matchRepository) GetStatsByFormat(ctx context.Context, filter models.St
 		ORDER BY format ASC
 	`, where)
 
-	rows, err := r.db.QueryContext(ctx, matchQuery, args...)
+	rows, err := r.reader.QueryContext(ctx, matchQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get stats by format: %w", err)
 	}
@@ -801,7 +817,7 @@ func (r *matchRepository) GetStatsByFormat(ctx context.Context, filter models.St
 		`, where)
 
 		gameArgs := append(args, format)
-		err = r.db.QueryRowContext(ctx, gameQuery, gameArgs...).Scan(
+		err = r.reader.QueryRowContext(ctx, gameQuery, gameArgs...).Scan(
 			&stats.TotalGames,
 			&stats.GamesWon,
 			&stats.GamesLost,
@@ -869,7 +885,7 @@ func (r *matchRepository) GetStatsByDeck(ctx context.Context, filter models.Stat
 		ORDER BY total DESC
 	`, where)
 
-	rows, err := r.db.QueryContext(ctx, matchQuery, args...)
+	rows, err := r.reader.QueryContext(ctx, matchQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get stats by deck: %w", err)
 	}
@@ -919,7 +935,7 @@ func (r *matchRepository) GetStatsByDeck(ctx context.Context, filter models.Stat
 		GROUP BY deck_name
 	`, where)
 
-	gameRows, err := r.db.QueryContext(ctx, gameQuery, args...)
+	gameRows, err := r.reader.QueryContext(ctx, gameQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get game stats by deck: %w", err)
 	}
@@ -976,7 +992,7 @@ func (r *matchRepository) GetGamesForMatch(ctx context.Context, matchID string)
 		ORDER BY game_number ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, matchID)
+	rows, err := r.reader.QueryContext(ctx, query, matchID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get games for match: %w", err)
 	}
@@ -1050,7 +1066,7 @@ func (r *matchRepository) GetPerformanceMetrics(ctx context.Context, filter mode
 	metrics := &models.PerformanceMetrics{}
 	var avgMatch, minMatch, maxMatch sql.NullFloat64
 
-	err := r.db.QueryRowContext(ctx, matchQuery, args...).Scan(
+	err := r.reader.QueryRowContext(ctx, matchQuery, args...).Scan(
 		&avgMatch,
 		&minMatch,
 		&maxMatch,
@@ -1086,7 +1102,7 @@ func (r *matchRepository) GetPerformanceMetrics(ctx context.Context, filter mode
 
 	var avgGame, minGame, maxGame sql.NullFloat64
 
-	err = r.db.QueryRowContext(ctx, gameQuery, args...).Scan(
+	err = r.reader.QueryRowContext(ctx, gameQuery, args...).Scan(
 		&avgGame,
 		&minGame,
 		&maxGame,
@@ -1122,7 +1138,7 @@ func (r *matchRepository) GetMatchesWithoutDeckID(ctx context.Context) ([]*model
 		ORDER BY timestamp DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.reader.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query matches: %w", err)
 	}
@@ -1194,8 +1210,13 @@ func (r *matchRepository) UpdateDeckID(ctx context.Context, matchID, deckID stri
 // If accountID is > 0, only deletes matches for that account.
 // If accountID is 0, deletes all matches for all accounts.
 func (r *matchRepository) DeleteAll(ctx context.Context, accountID int) error {
+	// Inside WithTx the caller's transaction already makes this atomic
+	if r.pool == nil {
+		return r.deleteAll(ctx, r.db, accountID)
+	}
+
 	// Start a transaction to ensure atomicity
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := r.pool.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -1206,6 +1227,21 @@ func (r *matchRepository) DeleteAll(ctx context.Context, accountID int) error {
 		}
 	}()
 
+	if err := r.deleteAll(ctx, tx, accountID); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// deleteAll runs the deletes for DeleteAll on q.
+func (r *matchRepository) deleteAll(ctx context.Context, q Querier, accountID int) error {
 	// Delete games first (foreign key constraint)
 	var gamesQuery string
 	var gamesArgs []interface{}
@@ -1217,8 +1253,7 @@ func (r *matchRepository) DeleteAll(ctx context.Context, accountID int) error {
 		gamesArgs = []interface{}{}
 	}
 
-	if _, err := tx.ExecContext(ctx, gamesQuery, gamesArgs...); err != nil {
-		_ = tx.Rollback()
+	if _, err := q.ExecContext(ctx, gamesQuery, gamesArgs...); err != nil {
 		return fmt.Errorf("failed to delete games: %w", err)
 	}
 
@@ -1233,17 +1268,11 @@ func (r *matchRepository) DeleteAll(ctx context.Context, accountID int) error {
 		matchesArgs = []interface{}{}
 	}
 
-	result, err := tx.ExecContext(ctx, matchesQuery, matchesArgs...)
+	result, err := q.ExecContext(ctx, matchesQuery, matchesArgs...)
 	if err != nil {
-		_ = tx.Rollback()
 		return fmt.Errorf("failed to delete matches: %w", err)
 	}
 
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
 	rowsAffected, _ := result.RowsAffected()
 	log.Printf("Deleted %d matches and associated games", rowsAffected)
 
@@ -1288,7 +1317,7 @@ func (r *matchRepository) GetDailyWins(ctx context.Context, accountID int) (int,
 	}
 
 	var count int
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	err := r.reader.QueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get daily wins: %w", err)
 	}
@@ -1336,7 +1365,7 @@ func (r *matchRepository) GetWeeklyWins(ctx context.Context, accountID int) (int
 	}
 
 	var count int
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	err := r.reader.QueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get weekly wins: %w", err)
 	}
@@ -1373,7 +1402,7 @@ func (r *matchRepository) GetMatchesForMLProcessing(ctx context.Context, filter
 		ORDER BY m.timestamp DESC
 	`, fromClause, where)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.reader.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get matches for ML processing: %w", err)
 	}
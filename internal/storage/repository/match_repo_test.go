@@ -8,12 +8,12 @@ import (
 	"time"
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
-	_ "modernc.org/sqlite"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 // setupTestDB creates an in-memory database with the necessary schema for testing.
 func setupTestDB(t *testing.T) *sql.DB {
-	db, err := sql.Open("sqlite", ":memory:")
+	db, err := sql.Open(sqlitedriver.Name, ":memory:")
 	if err != nil {
 		t.Fatalf("failed to open test database: %v", err)
 	}
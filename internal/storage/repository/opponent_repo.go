@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 // OpponentRepository handles database operations for opponent deck analysis.
@@ -27,10 +29,15 @@ type OpponentRepository interface {
 	// Expected cards
 	UpsertExpectedCard(ctx context.Context, card *models.ArchetypeExpectedCard) error
 	GetExpectedCards(ctx context.Context, archetypeName, format string) ([]*models.ArchetypeExpectedCard, error)
+	ListExpectedCards(ctx context.Context, format *string) ([]*models.ArchetypeExpectedCard, error)
 	DeleteExpectedCards(ctx context.Context, archetypeName, format string) error
 
 	// Opponent history summary
 	GetOpponentHistorySummary(ctx context.Context, accountID int, format *string) (*models.OpponentHistorySummary, error)
+
+	// Scouting by opponent identity
+	GetScoutingReport(ctx context.Context, accountID int, opponentID string) (*models.OpponentScoutingReport, error)
+	ListScoutedOpponents(ctx context.Context, accountID int, minMatches, limit int) ([]*models.ScoutedOpponent, error)
 }
 
 // OpponentProfileFilter provides filtering options for opponent profiles.
@@ -408,6 +415,35 @@ func (r *opponentRepository) GetExpectedCards(ctx context.Context, archetypeName
 	}
 	defer func() { _ = rows.Close() }()
 
+	return r.scanExpectedCards(rows)
+}
+
+// ListExpectedCards retrieves expected cards for every archetype, optionally
+// limited to one format.
+func (r *opponentRepository) ListExpectedCards(ctx context.Context, format *string) ([]*models.ArchetypeExpectedCard, error) {
+	query := `
+		SELECT id, archetype_name, format, card_id, card_name, inclusion_rate,
+			avg_copies, is_signature, category, created_at
+		FROM archetype_expected_cards
+	`
+	args := make([]interface{}, 0)
+	if format != nil {
+		query += " WHERE format = ?"
+		args = append(args, *format)
+	}
+	query += " ORDER BY archetype_name, inclusion_rate DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expected cards: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return r.scanExpectedCards(rows)
+}
+
+// scanExpectedCards scans expected card rows.
+func (r *opponentRepository) scanExpectedCards(rows *sql.Rows) ([]*models.ArchetypeExpectedCard, error) {
 	var cards []*models.ArchetypeExpectedCard
 	for rows.Next() {
 		var c models.ArchetypeExpectedCard
@@ -421,7 +457,7 @@ func (r *opponentRepository) GetExpectedCards(ctx context.Context, archetypeName
 		}
 		cards = append(cards, &c)
 	}
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("failed to iterate expected cards: %w", err)
 	}
 
@@ -551,6 +587,272 @@ func (r *opponentRepository) GetOpponentHistorySummary(ctx context.Context, acco
 	return summary, nil
 }
 
+// Scouting report limits.
+const (
+	scoutingRecentMatches = 10
+	scoutingMaxCards      = 40
+	maxTimedTurnSeconds   = 30 * 60 // Longer gaps are disconnects or idle clients, not turns
+)
+
+// GetScoutingReport aggregates our history against one opponent: the decks and
+// cards they've shown, our record, and how they play. Returns nil if we have
+// never played them.
+func (r *opponentRepository) GetScoutingReport(ctx context.Context, accountID int, opponentID string) (*models.OpponentScoutingReport, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT m.id, m.timestamp, m.format, m.result, m.result_reason,
+			m.player_wins, m.opponent_wins, COALESCE(m.opponent_name, ''),
+			odp.detected_archetype, COALESCE(odp.color_identity, '')
+		FROM matches m
+		LEFT JOIN opponent_deck_profiles odp ON odp.match_id = m.id
+		WHERE m.account_id = ? AND m.opponent_id = ?
+		ORDER BY m.timestamp DESC
+	`, accountID, opponentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches against opponent: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	report := &models.OpponentScoutingReport{
+		OpponentID:    opponentID,
+		Decks:         []models.ScoutedDeck{},
+		CardsSeen:     []models.ScoutedCard{},
+		RecentMatches: []models.ScoutedMatchSummary{},
+	}
+	deckIndex := make(map[string]int)
+	for rows.Next() {
+		var summary models.ScoutedMatchSummary
+		var playerWins, opponentWins int
+		var name, colors string
+		if err := rows.Scan(&summary.MatchID, &summary.Timestamp, &summary.Format, &summary.Result, &summary.ResultReason,
+			&playerWins, &opponentWins, &name, &summary.Archetype, &colors); err != nil {
+			return nil, fmt.Errorf("failed to scan match against opponent: %w", err)
+		}
+
+		if report.OpponentName == "" {
+			report.OpponentName = name
+		}
+		if report.LastSeen == nil {
+			lastSeen := summary.Timestamp
+			report.LastSeen = &lastSeen
+		}
+		firstSeen := summary.Timestamp
+		report.FirstSeen = &firstSeen
+
+		report.MatchesPlayed++
+		report.GamesWon += playerWins
+		report.GamesLost += opponentWins
+		won := summary.Result == "win"
+		if won {
+			report.MatchesWon++
+		} else {
+			report.MatchesLost++
+		}
+		if summary.ResultReason != nil {
+			switch *summary.ResultReason {
+			case "opponent_concede":
+				report.PlayPatterns.Concedes++
+			case "opponent_timeout":
+				report.PlayPatterns.Timeouts++
+			}
+		}
+
+		archetype := "Unknown"
+		if summary.Archetype != nil && *summary.Archetype != "" {
+			archetype = *summary.Archetype
+		}
+		key := archetype + "|" + colors + "|" + summary.Format
+		i, ok := deckIndex[key]
+		if !ok {
+			i = len(report.Decks)
+			deckIndex[key] = i
+			report.Decks = append(report.Decks, models.ScoutedDeck{
+				Archetype:     archetype,
+				ColorIdentity: colors,
+				Format:        summary.Format,
+				LastSeen:      summary.Timestamp,
+			})
+		}
+		report.Decks[i].Matches++
+		if won {
+			report.Decks[i].Wins++
+		}
+
+		if len(report.RecentMatches) < scoutingRecentMatches {
+			report.RecentMatches = append(report.RecentMatches, summary)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate matches against opponent: %w", err)
+	}
+
+	if report.MatchesPlayed == 0 {
+		return nil, nil
+	}
+	report.WinRate = float64(report.MatchesWon) / float64(report.MatchesPlayed)
+	if report.MatchesWon > 0 {
+		report.PlayPatterns.ConcedeRate = float64(report.PlayPatterns.Concedes) / float64(report.MatchesWon)
+	}
+	sort.SliceStable(report.Decks, func(i, j int) bool {
+		return report.Decks[i].Matches > report.Decks[j].Matches
+	})
+
+	if report.CardsSeen, err = r.getScoutedCards(ctx, accountID, opponentID); err != nil {
+		return nil, err
+	}
+	if err := r.fillScoutedTurnPatterns(ctx, accountID, opponentID, &report.PlayPatterns); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// getScoutedCards returns the cards an opponent has revealed, most often seen first.
+func (r *opponentRepository) getScoutedCards(ctx context.Context, accountID int, opponentID string) ([]models.ScoutedCard, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT oco.card_id, COALESCE(MAX(oco.card_name), ''),
+			COUNT(DISTINCT oco.match_id) as matches, SUM(oco.times_seen) as times_seen
+		FROM opponent_cards_observed oco
+		JOIN matches m ON m.id = oco.match_id
+		WHERE m.account_id = ? AND m.opponent_id = ?
+		GROUP BY oco.card_id
+		ORDER BY matches DESC, times_seen DESC
+		LIMIT ?
+	`, accountID, opponentID, scoutingMaxCards)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get opponent cards seen: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	cards := []models.ScoutedCard{}
+	for rows.Next() {
+		var card models.ScoutedCard
+		if err := rows.Scan(&card.CardID, &card.CardName, &card.Matches, &card.TimesSeen); err != nil {
+			return nil, fmt.Errorf("failed to scan opponent card: %w", err)
+		}
+		cards = append(cards, card)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate opponent cards: %w", err)
+	}
+	return cards, nil
+}
+
+// fillScoutedTurnPatterns derives concede timing and turn length from turn snapshots.
+// A turn lasts from its snapshot until the next turn's snapshot.
+func (r *opponentRepository) fillScoutedTurnPatterns(ctx context.Context, accountID int, opponentID string, patterns *models.OpponentPlayPatterns) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT s.match_id, s.game_id, s.turn_number, s.active_player,
+			s.timestamp, COALESCE(m.result_reason, '')
+		FROM game_state_snapshots s
+		JOIN matches m ON m.id = s.match_id
+		WHERE m.account_id = ? AND m.opponent_id = ?
+		ORDER BY s.match_id, s.game_id, s.turn_number
+	`, accountID, opponentID)
+	if err != nil {
+		return fmt.Errorf("failed to get opponent turn snapshots: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	type turn struct {
+		matchID      string
+		gameID       int
+		number       int
+		activePlayer string
+		at           time.Time
+	}
+	var prev *turn
+	var turnSeconds float64
+	concedeTurns := make(map[string]int)
+	for rows.Next() {
+		var t turn
+		var reason string
+		if err := rows.Scan(&t.matchID, &t.gameID, &t.number, &t.activePlayer, &t.at, &reason); err != nil {
+			return fmt.Errorf("failed to scan opponent turn snapshot: %w", err)
+		}
+		if reason == "opponent_concede" && t.number > concedeTurns[t.matchID] {
+			concedeTurns[t.matchID] = t.number
+		}
+
+		if prev != nil && prev.matchID == t.matchID && prev.gameID == t.gameID &&
+			prev.number+1 == t.number && prev.activePlayer == "opponent" {
+			seconds := t.at.Sub(prev.at).Seconds()
+			if seconds > 0 && seconds <= maxTimedTurnSeconds {
+				turnSeconds += seconds
+				patterns.TurnsTimed++
+			}
+		}
+		prev = &t
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate opponent turn snapshots: %w", err)
+	}
+
+	if patterns.TurnsTimed > 0 {
+		avg := turnSeconds / float64(patterns.TurnsTimed)
+		patterns.AvgTurnSeconds = &avg
+	}
+	if len(concedeTurns) > 0 {
+		total := 0
+		for _, turn := range concedeTurns {
+			total += turn
+		}
+		avg := float64(total) / float64(len(concedeTurns))
+		patterns.AvgConcedeTurn = &avg
+	}
+	return nil
+}
+
+// ListScoutedOpponents returns opponents we have played at least minMatches
+// times, most recently seen first.
+func (r *opponentRepository) ListScoutedOpponents(ctx context.Context, accountID int, minMatches, limit int) ([]*models.ScoutedOpponent, error) {
+	if minMatches < 1 {
+		minMatches = 1
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT m.opponent_id,
+			COALESCE((SELECT x.opponent_name FROM matches x
+				WHERE x.account_id = m.account_id AND x.opponent_id = m.opponent_id
+				ORDER BY x.timestamp DESC LIMIT 1), '') as opponent_name,
+			COUNT(*) as matches,
+			SUM(CASE WHEN m.result = 'win' THEN 1 ELSE 0 END) as wins,
+			MAX(m.timestamp) as last_seen
+		FROM matches m
+		WHERE m.account_id = ? AND m.opponent_id IS NOT NULL AND m.opponent_id != ''
+		GROUP BY m.opponent_id
+		HAVING COUNT(*) >= ?
+		ORDER BY last_seen DESC
+		LIMIT ?
+	`, accountID, minMatches, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scouted opponents: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var opponents []*models.ScoutedOpponent
+	for rows.Next() {
+		opponent := &models.ScoutedOpponent{}
+		var lastSeen string
+		if err := rows.Scan(&opponent.OpponentID, &opponent.OpponentName, &opponent.MatchesPlayed, &opponent.MatchesWon, &lastSeen); err != nil {
+			return nil, fmt.Errorf("failed to scan scouted opponent: %w", err)
+		}
+		// MAX() drops the column type, so the driver returns the stored text
+		seen, err := sqlitedriver.ParseTime(lastSeen)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse scouted opponent last seen: %w", err)
+		}
+		opponent.LastSeen = seen
+		opponents = append(opponents, opponent)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate scouted opponents: %w", err)
+	}
+	return opponents, nil
+}
+
 // Helper function to convert card IDs slice to JSON string.
 func CardIDsToJSON(cardIDs []int) string {
 	data, _ := json.Marshal(cardIDs)
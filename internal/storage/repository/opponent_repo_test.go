@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
+)
+
+// setupScoutingTestDB creates an in-memory SQLite database with two opponents.
+// Matches and turn snapshots bind time.Time values so timestamps are stored as
+// the log processor stores them.
+func setupScoutingTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open(sqlitedriver.Name, ":memory:")
+	require.NoError(t, err)
+
+	_, err = db.Exec(`
+		CREATE TABLE matches (
+			id TEXT PRIMARY KEY,
+			account_id INTEGER,
+			event_id TEXT,
+			event_name TEXT,
+			timestamp DATETIME,
+			duration_seconds INTEGER,
+			player_wins INTEGER,
+			opponent_wins INTEGER,
+			player_team_id INTEGER,
+			deck_id TEXT,
+			rank_before TEXT,
+			rank_after TEXT,
+			format TEXT,
+			result TEXT,
+			result_reason TEXT,
+			opponent_name TEXT,
+			opponent_id TEXT,
+			created_at DATETIME
+		);
+
+		CREATE TABLE opponent_deck_profiles (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			match_id TEXT UNIQUE,
+			detected_archetype TEXT,
+			color_identity TEXT
+		);
+
+		CREATE TABLE opponent_cards_observed (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER,
+			match_id TEXT,
+			card_id INTEGER,
+			card_name TEXT,
+			times_seen INTEGER
+		);
+
+		CREATE TABLE game_state_snapshots (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			match_id TEXT,
+			game_id INTEGER,
+			turn_number INTEGER,
+			active_player TEXT,
+			timestamp DATETIME
+		);
+
+		INSERT INTO opponent_deck_profiles (match_id, detected_archetype, color_identity) VALUES
+			('m1', 'Mono Red Aggro', 'R'),
+			('m2', 'Mono Red Aggro', 'R'),
+			('m3', 'Azorius Control', 'WU');
+
+		INSERT INTO opponent_cards_observed (game_id, match_id, card_id, card_name, times_seen) VALUES
+			(1, 'm1', 100, 'Lightning Strike', 2),
+			(2, 'm2', 100, 'Lightning Strike', 1),
+			(2, 'm2', 200, 'Mountain', 5),
+			(4, 'm5', 300, 'Hidden Card', 1);
+	`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 10, 0, 0, 0, time.UTC) }
+	str := func(s string) *string { return &s }
+
+	matchRepo := NewMatchRepository(db)
+	matches := []*models.Match{
+		{ID: "m1", AccountID: 1, Timestamp: day(1), Format: "Standard", Result: "win", ResultReason: str("opponent_concede"), PlayerWins: 2, OpponentWins: 0, OpponentName: str("OldName"), OpponentID: str("opp-1")},
+		{ID: "m2", AccountID: 1, Timestamp: day(5), Format: "Standard", Result: "loss", PlayerWins: 1, OpponentWins: 2, OpponentName: str("Rival"), OpponentID: str("opp-1")},
+		{ID: "m3", AccountID: 1, Timestamp: day(9), Format: "Standard", Result: "win", PlayerWins: 2, OpponentWins: 1, OpponentName: str("Rival"), OpponentID: str("opp-1")},
+		{ID: "m4", AccountID: 1, Timestamp: day(10), Format: "Standard", Result: "win", PlayerWins: 2, OpponentWins: 0, OpponentName: str("Stranger"), OpponentID: str("opp-2")},
+		{ID: "m5", AccountID: 2, Timestamp: day(10), Format: "Standard", Result: "loss", PlayerWins: 0, OpponentWins: 2, OpponentName: str("Rival"), OpponentID: str("opp-1")},
+	}
+	for _, match := range matches {
+		match.CreatedAt = match.Timestamp
+		require.NoError(t, matchRepo.Create(ctx, match))
+	}
+
+	// Opponent turns take 90s, 30s and 60 minutes
+	turns := []time.Duration{0, time.Minute, 150 * time.Second, 3 * time.Minute, 210 * time.Second, 4 * time.Minute, 64 * time.Minute}
+	for i, offset := range turns {
+		activePlayer := "player"
+		if i%2 == 1 {
+			activePlayer = "opponent"
+		}
+		_, err = db.Exec(`INSERT INTO game_state_snapshots (match_id, game_id, turn_number, active_player, timestamp) VALUES ('m1', 1, ?, ?, ?)`,
+			i+1, activePlayer, day(1).Add(offset))
+		require.NoError(t, err)
+	}
+
+	return db
+}
+
+func TestGetScoutingReport(t *testing.T) {
+	db := setupScoutingTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := NewOpponentRepository(db)
+	ctx := context.Background()
+
+	report, err := repo.GetScoutingReport(ctx, 1, "opp-1")
+	require.NoError(t, err)
+	require.NotNil(t, report)
+
+	assert.Equal(t, "Rival", report.OpponentName)
+	assert.Equal(t, 3, report.MatchesPlayed)
+	assert.Equal(t, 2, report.MatchesWon)
+	assert.Equal(t, 1, report.MatchesLost)
+	assert.Equal(t, 5, report.GamesWon)
+	assert.Equal(t, 3, report.GamesLost)
+	assert.InDelta(t, 2.0/3, report.WinRate, 0.001)
+	require.NotNil(t, report.FirstSeen)
+	require.NotNil(t, report.LastSeen)
+	assert.True(t, report.FirstSeen.Before(*report.LastSeen))
+
+	// Mono Red was seen twice, so it sorts first
+	require.Len(t, report.Decks, 2)
+	assert.Equal(t, "Mono Red Aggro", report.Decks[0].Archetype)
+	assert.Equal(t, 2, report.Decks[0].Matches)
+	assert.Equal(t, 1, report.Decks[0].Wins)
+	assert.Equal(t, "Azorius Control", report.Decks[1].Archetype)
+
+	// Cards from another account's match are excluded
+	require.Len(t, report.CardsSeen, 2)
+	assert.Equal(t, 100, report.CardsSeen[0].CardID)
+	assert.Equal(t, 2, report.CardsSeen[0].Matches)
+	assert.Equal(t, 3, report.CardsSeen[0].TimesSeen)
+
+	patterns := report.PlayPatterns
+	assert.Equal(t, 1, patterns.Concedes)
+	assert.InDelta(t, 0.5, patterns.ConcedeRate, 0.001)
+	require.NotNil(t, patterns.AvgConcedeTurn)
+	assert.InDelta(t, 7, *patterns.AvgConcedeTurn, 0.001)
+
+	// Opponent turns took 90s, 30s and 60 minutes; the last is treated as idle
+	assert.Equal(t, 2, patterns.TurnsTimed)
+	require.NotNil(t, patterns.AvgTurnSeconds)
+	assert.InDelta(t, 60, *patterns.AvgTurnSeconds, 0.5)
+
+	require.Len(t, report.RecentMatches, 3)
+	assert.Equal(t, "m3", report.RecentMatches[0].MatchID)
+}
+
+func TestGetScoutingReport_UnknownOpponent(t *testing.T) {
+	db := setupScoutingTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := NewOpponentRepository(db)
+
+	report, err := repo.GetScoutingReport(context.Background(), 1, "opp-unknown")
+	require.NoError(t, err)
+	assert.Nil(t, report)
+}
+
+func TestListScoutedOpponents(t *testing.T) {
+	db := setupScoutingTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := NewOpponentRepository(db)
+	ctx := context.Background()
+
+	opponents, err := repo.ListScoutedOpponents(ctx, 1, 2, 0)
+	require.NoError(t, err)
+	require.Len(t, opponents, 1)
+	assert.Equal(t, "opp-1", opponents[0].OpponentID)
+	assert.Equal(t, "Rival", opponents[0].OpponentName)
+	assert.Equal(t, 3, opponents[0].MatchesPlayed)
+	assert.Equal(t, 2, opponents[0].MatchesWon)
+	assert.True(t, opponents[0].LastSeen.Equal(time.Date(2026, 1, 9, 10, 0, 0, 0, time.UTC)))
+
+	opponents, err = repo.ListScoutedOpponents(ctx, 1, 1, 0)
+	require.NoError(t, err)
+	require.Len(t, opponents, 2)
+	assert.Equal(t, "opp-2", opponents[0].OpponentID)
+}
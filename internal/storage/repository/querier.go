@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Querier is the part of *sql.DB and *sql.Tx that repositories run queries
+// through, so the same repository code can run on a pool or inside a caller's
+// transaction.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
@@ -30,12 +30,20 @@ type RankHistoryRepository interface {
 }
 
 type rankHistoryRepository struct {
-	db *sql.DB
+	db     *sql.DB
+	reader *sql.DB // history reads for timelines and exports
 }
 
 // NewRankHistoryRepository creates a new rank history repository.
 func NewRankHistoryRepository(db *sql.DB) RankHistoryRepository {
-	return &rankHistoryRepository{db: db}
+	return &rankHistoryRepository{db: db, reader: db}
+}
+
+// NewRankHistoryRepositoryWithReader creates a rank history repository whose
+// history reads run on reader (typically storage.DB.Reader) while writes and
+// the latest-rank lookup used during log processing stay on db.
+func NewRankHistoryRepositoryWithReader(db, reader *sql.DB) RankHistoryRepository {
+	return &rankHistoryRepository{db: db, reader: reader}
 }
 
 // Create stores a new rank snapshot in the database.
@@ -83,7 +91,7 @@ func (r *rankHistoryRepository) GetByFormat(ctx context.Context, accountID int,
 		ORDER BY timestamp DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, accountID, format)
+	rows, err := r.reader.QueryContext(ctx, query, accountID, format)
 	if err != nil {
 		return nil, err
 	}
@@ -124,7 +132,7 @@ func (r *rankHistoryRepository) GetBySeason(ctx context.Context, accountID int,
 		ORDER BY timestamp DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, accountID, seasonOrdinal)
+	rows, err := r.reader.QueryContext(ctx, query, accountID, seasonOrdinal)
 	if err != nil {
 		return nil, err
 	}
@@ -165,7 +173,7 @@ func (r *rankHistoryRepository) GetByDateRange(ctx context.Context, accountID in
 		ORDER BY timestamp DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, accountID, startDate, endDate)
+	rows, err := r.reader.QueryContext(ctx, query, accountID, startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
@@ -242,7 +250,7 @@ func (r *rankHistoryRepository) GetAll(ctx context.Context, accountID int) ([]*m
 		ORDER BY timestamp DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, accountID)
+	rows, err := r.reader.QueryContext(ctx, query, accountID)
 	if err != nil {
 		return nil, err
 	}
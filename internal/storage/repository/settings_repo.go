@@ -4,14 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 )
 
+// ErrSettingNotFound is returned by Get when no value is stored for a key.
+var ErrSettingNotFound = errors.New("setting not found")
+
 // SettingsRepository provides access to user settings.
 type SettingsRepository interface {
 	// Get retrieves a setting value by key.
-	// Returns the JSON-encoded value or error if not found.
+	// Returns the JSON-encoded value, or an error wrapping ErrSettingNotFound.
 	Get(ctx context.Context, key string) (string, error)
 
 	// GetTyped retrieves a setting and unmarshals it to the target type.
@@ -47,7 +51,7 @@ func (r *settingsRepository) Get(ctx context.Context, key string) (string, error
 	err := r.db.QueryRowContext(ctx, "SELECT value FROM settings WHERE key = ?", key).Scan(&value)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return "", fmt.Errorf("setting not found: %s", key)
+			return "", fmt.Errorf("%w: %s", ErrSettingNotFound, key)
 		}
 		return "", fmt.Errorf("failed to get setting %s: %w", key, err)
 	}
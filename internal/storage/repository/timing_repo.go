@@ -0,0 +1,704 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+)
+
+const (
+	// longTurnSeconds is how long our decisions on one of our turns can take
+	// before the turn counts as long.
+	longTurnSeconds = 90
+
+	// Opponent decisions on the last turn of a game we lost are suspect when
+	// they take slowRollRatio times their median and at least slowRollMinMs.
+	slowRollRatio = 3.0
+	slowRollMinMs = 20000
+
+	timingTimestampFormat = "2006-01-02 15:04:05.999999"
+)
+
+// TimingRepository stores and analyzes turn, decision and timer data.
+type TimingRepository interface {
+	// SaveTiming stores turn, decision and timer data, merging it with what
+	// earlier log batches stored for the same turns and decisions.
+	SaveTiming(ctx context.Context, turns []*models.TurnTiming, decisions []*models.DecisionTiming, events []*models.TimerEvent) error
+
+	// GetMatchTiming returns every turn, decision and timer event of a match
+	// with per-game clock usage. Returns nil if no timing was recorded.
+	GetMatchTiming(ctx context.Context, matchID string) (*models.MatchTiming, error)
+
+	// GetDeckTimingStats returns how long we take per turn with each deck.
+	GetDeckTimingStats(ctx context.Context, accountID int) ([]*models.DeckTimingStats, error)
+
+	// GetMatchupTimingStats returns turn times against each opponent archetype.
+	GetMatchupTimingStats(ctx context.Context, accountID int) ([]*models.MatchupTimingStats, error)
+
+	// GetTurnTimeOutcome relates our turn times to match results.
+	GetTurnTimeOutcome(ctx context.Context, accountID int) (*models.TurnTimeOutcome, error)
+
+	// GetSlowRollSuspects returns unusually long opponent decisions on the
+	// final turn of games we lost, most recent first.
+	GetSlowRollSuspects(ctx context.Context, accountID int, limit int) ([]*models.SlowRollSuspect, error)
+}
+
+// timingRepository is the concrete implementation.
+type timingRepository struct {
+	db *sql.DB
+}
+
+// NewTimingRepository creates a new timing repository.
+func NewTimingRepository(db *sql.DB) TimingRepository {
+	return &timingRepository{db: db}
+}
+
+// SaveTiming stores turn, decision and timer data in one transaction. A turn
+// seen across batches keeps its earliest start and latest activity, and a
+// decision keeps its longest measured duration.
+func (r *timingRepository) SaveTiming(ctx context.Context, turns []*models.TurnTiming, decisions []*models.DecisionTiming, events []*models.TimerEvent) error {
+	if len(turns) == 0 && len(decisions) == 0 && len(events) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, turn := range turns {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO turn_timings (match_id, game_number, turn_number, active_player, started_at, ended_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT(match_id, game_number, turn_number) DO UPDATE SET
+				active_player = COALESCE(turn_timings.active_player, excluded.active_player),
+				started_at = MIN(turn_timings.started_at, excluded.started_at),
+				ended_at = MAX(turn_timings.ended_at, excluded.ended_at)
+		`, turn.MatchID, turn.GameNumber, turn.TurnNumber, nullString(turn.ActivePlayer),
+			turn.StartedAt.UTC().Format(timingTimestampFormat), turn.EndedAt.UTC().Format(timingTimestampFormat)); err != nil {
+			return fmt.Errorf("failed to save turn timing: %w", err)
+		}
+	}
+
+	for _, decision := range decisions {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO decision_timings (match_id, game_number, turn_number, player_type, phase, step, started_at, duration_ms)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(match_id, game_number, player_type, started_at) DO UPDATE SET
+				duration_ms = MAX(decision_timings.duration_ms, excluded.duration_ms)
+		`, decision.MatchID, decision.GameNumber, decision.TurnNumber, decision.PlayerType,
+			nullString(decision.Phase), nullString(decision.Step),
+			decision.StartedAt.UTC().Format(timingTimestampFormat), decision.DurationMs); err != nil {
+			return fmt.Errorf("failed to save decision timing: %w", err)
+		}
+	}
+
+	for _, event := range events {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO timer_events (match_id, game_number, turn_number, player_type, event_type, elapsed_ms, timestamp)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(match_id, game_number, turn_number, player_type, event_type) DO NOTHING
+		`, event.MatchID, event.GameNumber, event.TurnNumber, event.PlayerType, event.EventType,
+			event.ElapsedMs, event.Timestamp.UTC().Format(timingTimestampFormat)); err != nil {
+			return fmt.Errorf("failed to save timer event: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit timing: %w", err)
+	}
+	return nil
+}
+
+// nullString stores empty strings as NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// GetMatchTiming returns the timing of one match.
+func (r *timingRepository) GetMatchTiming(ctx context.Context, matchID string) (*models.MatchTiming, error) {
+	turns, err := r.getTurns(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if len(turns) == 0 {
+		return nil, nil
+	}
+
+	decisions, err := r.getDecisions(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := r.getTimerEvents(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	timing := &models.MatchTiming{
+		MatchID:     matchID,
+		Turns:       turns,
+		Decisions:   decisions,
+		TimerEvents: events,
+		Games:       []*models.GameClockUsage{},
+	}
+
+	type turnKey struct{ game, turn int }
+	turnIndex := make(map[turnKey]*models.TurnTiming, len(turns))
+	games := make(map[int]*models.GameClockUsage)
+	for _, turn := range turns {
+		turnIndex[turnKey{turn.GameNumber, turn.TurnNumber}] = turn
+		game, ok := games[turn.GameNumber]
+		if !ok {
+			game = &models.GameClockUsage{GameNumber: turn.GameNumber}
+			games[turn.GameNumber] = game
+			timing.Games = append(timing.Games, game)
+		}
+		game.Turns++
+	}
+
+	for _, d := range decisions {
+		turn := turnIndex[turnKey{d.GameNumber, d.TurnNumber}]
+		game := games[d.GameNumber]
+		if d.PlayerType == "player" {
+			timing.PlayerTotalMs += d.DurationMs
+			if turn != nil {
+				turn.PlayerDecisionMs += d.DurationMs
+				turn.PlayerDecisions++
+			}
+			if game != nil {
+				game.PlayerMs += d.DurationMs
+				game.PlayerLongestDecisionMs = max(game.PlayerLongestDecisionMs, d.DurationMs)
+			}
+		} else {
+			timing.OpponentTotalMs += d.DurationMs
+			if turn != nil {
+				turn.OpponentDecisionMs += d.DurationMs
+				turn.OpponentDecisions++
+			}
+			if game != nil {
+				game.OpponentMs += d.DurationMs
+				game.OpponentLongestDecisionMs = max(game.OpponentLongestDecisionMs, d.DurationMs)
+			}
+		}
+	}
+
+	for _, e := range events {
+		switch {
+		case e.PlayerType == "player" && e.EventType == models.TimerEventRope:
+			timing.PlayerRopes++
+		case e.PlayerType == "player" && e.EventType == models.TimerEventTimeout:
+			timing.PlayerTimeouts++
+		case e.EventType == models.TimerEventRope:
+			timing.OpponentRopes++
+		case e.EventType == models.TimerEventTimeout:
+			timing.OpponentTimeouts++
+		}
+	}
+
+	return timing, nil
+}
+
+// getTurns returns a match's turns in order, with each turn lasting until the
+// next one in the same game started.
+func (r *timingRepository) getTurns(ctx context.Context, matchID string) ([]*models.TurnTiming, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT match_id, game_number, turn_number, COALESCE(active_player, ''), started_at, ended_at
+		FROM turn_timings
+		WHERE match_id = ?
+		ORDER BY game_number, turn_number
+	`, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get turn timings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var turns []*models.TurnTiming
+	for rows.Next() {
+		turn := &models.TurnTiming{}
+		if err := rows.Scan(&turn.MatchID, &turn.GameNumber, &turn.TurnNumber, &turn.ActivePlayer, &turn.StartedAt, &turn.EndedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan turn timing: %w", err)
+		}
+		turns = append(turns, turn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate turn timings: %w", err)
+	}
+
+	for i, turn := range turns {
+		end := turn.EndedAt
+		if i+1 < len(turns) && turns[i+1].GameNumber == turn.GameNumber {
+			end = turns[i+1].StartedAt
+		}
+		turn.DurationMs = end.Sub(turn.StartedAt).Milliseconds()
+	}
+	return turns, nil
+}
+
+// getDecisions returns a match's decisions in order.
+func (r *timingRepository) getDecisions(ctx context.Context, matchID string) ([]*models.DecisionTiming, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, match_id, game_number, turn_number, player_type,
+		       COALESCE(phase, ''), COALESCE(step, ''), started_at, duration_ms
+		FROM decision_timings
+		WHERE match_id = ?
+		ORDER BY game_number, started_at
+	`, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get decision timings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	decisions := []*models.DecisionTiming{}
+	for rows.Next() {
+		d := &models.DecisionTiming{}
+		if err := rows.Scan(&d.ID, &d.MatchID, &d.GameNumber, &d.TurnNumber, &d.PlayerType,
+			&d.Phase, &d.Step, &d.StartedAt, &d.DurationMs); err != nil {
+			return nil, fmt.Errorf("failed to scan decision timing: %w", err)
+		}
+		decisions = append(decisions, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate decision timings: %w", err)
+	}
+	return decisions, nil
+}
+
+// getTimerEvents returns a match's rope and timeout events in order.
+func (r *timingRepository) getTimerEvents(ctx context.Context, matchID string) ([]*models.TimerEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, match_id, game_number, turn_number, player_type, event_type, elapsed_ms, timestamp
+		FROM timer_events
+		WHERE match_id = ?
+		ORDER BY game_number, timestamp
+	`, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timer events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	events := []*models.TimerEvent{}
+	for rows.Next() {
+		e := &models.TimerEvent{}
+		if err := rows.Scan(&e.ID, &e.MatchID, &e.GameNumber, &e.TurnNumber, &e.PlayerType,
+			&e.EventType, &e.ElapsedMs, &e.Timestamp); err != nil {
+			return nil, fmt.Errorf("failed to scan timer event: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate timer events: %w", err)
+	}
+	return events, nil
+}
+
+// matchTimingSummary is the per-match input to the timing analytics.
+type matchTimingSummary struct {
+	matchID           string
+	won               bool
+	deckID            string
+	deckName          string
+	opponentArchetype string
+	playerTurnMs      []int64 // Our decision time on each of our turns
+	opponentTurnMs    []int64 // Their decision time on each of their turns
+	playerClockMs     int64   // Our decision time across the match
+	ropes             int
+	timeouts          int
+}
+
+func (s *matchTimingSummary) avgTurnSeconds() float64 {
+	return averageMs(s.playerTurnMs) / 1000
+}
+
+func (s *matchTimingSummary) longestTurnSeconds() float64 {
+	longest := int64(0)
+	for _, ms := range s.playerTurnMs {
+		longest = max(longest, ms)
+	}
+	return float64(longest) / 1000
+}
+
+func averageMs(values []int64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	total := int64(0)
+	for _, v := range values {
+		total += v
+	}
+	return float64(total) / float64(len(values))
+}
+
+// getMatchTimingSummaries sums decision time per turn for every completed
+// match of an account that has timing data.
+func (r *timingRepository) getMatchTimingSummaries(ctx context.Context, accountID int) ([]*matchTimingSummary, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT m.id, m.result, COALESCE(m.deck_id, ''), COALESCE(d.name, ''),
+		       COALESCE(odp.detected_archetype, ''), COALESCE(t.active_player, ''),
+		       COALESCE(SUM(CASE WHEN dt.player_type = 'player' THEN dt.duration_ms END), 0),
+		       COALESCE(SUM(CASE WHEN dt.player_type = 'opponent' THEN dt.duration_ms END), 0)
+		FROM turn_timings t
+		JOIN matches m ON m.id = t.match_id
+		LEFT JOIN decks d ON d.id = m.deck_id
+		LEFT JOIN opponent_deck_profiles odp ON odp.match_id = m.id
+		LEFT JOIN decision_timings dt ON dt.match_id = t.match_id
+			AND dt.game_number = t.game_number AND dt.turn_number = t.turn_number
+		WHERE m.account_id = ?
+		GROUP BY t.id
+		ORDER BY m.id, t.game_number, t.turn_number
+	`, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match timing: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var summaries []*matchTimingSummary
+	byMatch := make(map[string]*matchTimingSummary)
+	for rows.Next() {
+		var matchID, result, deckID, deckName, archetype, activePlayer string
+		var playerMs, opponentMs int64
+		if err := rows.Scan(&matchID, &result, &deckID, &deckName, &archetype, &activePlayer, &playerMs, &opponentMs); err != nil {
+			return nil, fmt.Errorf("failed to scan match timing: %w", err)
+		}
+
+		summary, ok := byMatch[matchID]
+		if !ok {
+			summary = &matchTimingSummary{
+				matchID:           matchID,
+				won:               result == "win",
+				deckID:            deckID,
+				deckName:          deckName,
+				opponentArchetype: archetype,
+			}
+			byMatch[matchID] = summary
+			summaries = append(summaries, summary)
+		}
+		summary.playerClockMs += playerMs
+		switch activePlayer {
+		case "player":
+			summary.playerTurnMs = append(summary.playerTurnMs, playerMs)
+		case "opponent":
+			summary.opponentTurnMs = append(summary.opponentTurnMs, opponentMs)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate match timing: %w", err)
+	}
+
+	eventRows, err := r.db.QueryContext(ctx, `
+		SELECT te.match_id, te.event_type, COUNT(*)
+		FROM timer_events te
+		JOIN matches m ON m.id = te.match_id
+		WHERE m.account_id = ? AND te.player_type = 'player'
+		GROUP BY te.match_id, te.event_type
+	`, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timer events: %w", err)
+	}
+	defer func() { _ = eventRows.Close() }()
+
+	for eventRows.Next() {
+		var matchID, eventType string
+		var count int
+		if err := eventRows.Scan(&matchID, &eventType, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan timer event count: %w", err)
+		}
+		if summary, ok := byMatch[matchID]; ok {
+			if eventType == models.TimerEventRope {
+				summary.ropes += count
+			} else {
+				summary.timeouts += count
+			}
+		}
+	}
+	if err := eventRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate timer event counts: %w", err)
+	}
+
+	return summaries, nil
+}
+
+// GetDeckTimingStats groups match timing by deck, slowest first.
+func (r *timingRepository) GetDeckTimingStats(ctx context.Context, accountID int) ([]*models.DeckTimingStats, error) {
+	summaries, err := r.getMatchTimingSummaries(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := []*models.DeckTimingStats{}
+	byDeck := make(map[string]*models.DeckTimingStats)
+	turns := make(map[string][]int64)
+	opponentTurns := make(map[string][]int64)
+	clocks := make(map[string][]int64)
+	wins := make(map[string]int)
+	for _, s := range summaries {
+		if s.deckID == "" {
+			continue
+		}
+		deck, ok := byDeck[s.deckID]
+		if !ok {
+			deck = &models.DeckTimingStats{DeckID: s.deckID, DeckName: s.deckName}
+			byDeck[s.deckID] = deck
+			stats = append(stats, deck)
+		}
+		deck.Matches++
+		deck.Ropes += s.ropes
+		deck.Timeouts += s.timeouts
+		deck.LongestTurnSeconds = math.Max(deck.LongestTurnSeconds, s.longestTurnSeconds())
+		if s.won {
+			wins[s.deckID]++
+		}
+		turns[s.deckID] = append(turns[s.deckID], s.playerTurnMs...)
+		opponentTurns[s.deckID] = append(opponentTurns[s.deckID], s.opponentTurnMs...)
+		clocks[s.deckID] = append(clocks[s.deckID], s.playerClockMs)
+	}
+
+	for _, deck := range stats {
+		deck.WinRate = float64(wins[deck.DeckID]) / float64(deck.Matches)
+		deck.AvgTurnSeconds = averageMs(turns[deck.DeckID]) / 1000
+		deck.AvgOpponentTurnSeconds = averageMs(opponentTurns[deck.DeckID]) / 1000
+		deck.AvgMatchClockSeconds = averageMs(clocks[deck.DeckID]) / 1000
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].AvgTurnSeconds > stats[j].AvgTurnSeconds
+	})
+	return stats, nil
+}
+
+// GetMatchupTimingStats groups match timing by opponent archetype, slowest first.
+func (r *timingRepository) GetMatchupTimingStats(ctx context.Context, accountID int) ([]*models.MatchupTimingStats, error) {
+	summaries, err := r.getMatchTimingSummaries(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := []*models.MatchupTimingStats{}
+	byArchetype := make(map[string]*models.MatchupTimingStats)
+	turns := make(map[string][]int64)
+	opponentTurns := make(map[string][]int64)
+	clocks := make(map[string][]int64)
+	wins := make(map[string]int)
+	for _, s := range summaries {
+		archetype := s.opponentArchetype
+		if archetype == "" {
+			archetype = "Unknown"
+		}
+		matchup, ok := byArchetype[archetype]
+		if !ok {
+			matchup = &models.MatchupTimingStats{OpponentArchetype: archetype}
+			byArchetype[archetype] = matchup
+			stats = append(stats, matchup)
+		}
+		matchup.Matches++
+		matchup.Ropes += s.ropes
+		if s.won {
+			wins[archetype]++
+		}
+		turns[archetype] = append(turns[archetype], s.playerTurnMs...)
+		opponentTurns[archetype] = append(opponentTurns[archetype], s.opponentTurnMs...)
+		clocks[archetype] = append(clocks[archetype], s.playerClockMs)
+	}
+
+	for _, matchup := range stats {
+		archetype := matchup.OpponentArchetype
+		matchup.WinRate = float64(wins[archetype]) / float64(matchup.Matches)
+		matchup.AvgTurnSeconds = averageMs(turns[archetype]) / 1000
+		matchup.AvgOpponentTurnSeconds = averageMs(opponentTurns[archetype]) / 1000
+		matchup.AvgMatchClockSeconds = averageMs(clocks[archetype]) / 1000
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].AvgTurnSeconds > stats[j].AvgTurnSeconds
+	})
+	return stats, nil
+}
+
+// GetTurnTimeOutcome relates average turn time and long turns to losses.
+func (r *timingRepository) GetTurnTimeOutcome(ctx context.Context, accountID int) (*models.TurnTimeOutcome, error) {
+	summaries, err := r.getMatchTimingSummaries(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	outcome := &models.TurnTimeOutcome{LongTurnSeconds: longTurnSeconds}
+	var winTurns, lossTurns []float64
+	var longLosses, otherLosses int
+	for _, s := range summaries {
+		if len(s.playerTurnMs) == 0 {
+			continue
+		}
+		outcome.Matches++
+		avg := s.avgTurnSeconds()
+		if s.won {
+			outcome.Wins++
+			winTurns = append(winTurns, avg)
+		} else {
+			outcome.Losses++
+			lossTurns = append(lossTurns, avg)
+		}
+
+		if s.longestTurnSeconds() >= longTurnSeconds {
+			outcome.MatchesWithLongTurns++
+			if !s.won {
+				longLosses++
+			}
+		} else if !s.won {
+			otherLosses++
+		}
+	}
+	if outcome.Matches == 0 {
+		return outcome, nil
+	}
+
+	outcome.AvgTurnSecondsInWins = mean(winTurns)
+	outcome.AvgTurnSecondsInLosses = mean(lossTurns)
+	if outcome.MatchesWithLongTurns > 0 {
+		outcome.LossRateWithLongTurns = float64(longLosses) / float64(outcome.MatchesWithLongTurns)
+	}
+	if others := outcome.Matches - outcome.MatchesWithLongTurns; others > 0 {
+		outcome.LossRateOtherwise = float64(otherLosses) / float64(others)
+	}
+
+	// Point-biserial correlation between average turn time and losing
+	all := append(append([]float64{}, winTurns...), lossTurns...)
+	if sd := stddev(all); sd > 0 && len(winTurns) > 0 && len(lossTurns) > 0 {
+		n := float64(len(all))
+		p := float64(len(lossTurns)) / n
+		outcome.Correlation = (outcome.AvgTurnSecondsInLosses - outcome.AvgTurnSecondsInWins) / sd * math.Sqrt(p*(1-p))
+	}
+	return outcome, nil
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total / float64(len(values))
+}
+
+// stddev returns the population standard deviation.
+func stddev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	m := mean(values)
+	sum := 0.0
+	for _, v := range values {
+		sum += (v - m) * (v - m)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+// GetSlowRollSuspects compares each opponent's decisions on the final turn of
+// a game we lost against their median decision in that game.
+func (r *timingRepository) GetSlowRollSuspects(ctx context.Context, accountID int, limit int) ([]*models.SlowRollSuspect, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT dt.match_id, dt.game_number, dt.turn_number, COALESCE(m.opponent_name, ''),
+		       COALESCE(dt.phase, ''), COALESCE(dt.step, ''), dt.duration_ms, dt.started_at,
+		       (SELECT MAX(t.turn_number) FROM turn_timings t
+		        WHERE t.match_id = dt.match_id AND t.game_number = dt.game_number) as last_turn
+		FROM decision_timings dt
+		JOIN matches m ON m.id = dt.match_id
+		JOIN games g ON g.match_id = dt.match_id AND g.game_number = dt.game_number
+		WHERE m.account_id = ? AND g.result = 'loss' AND dt.player_type = 'opponent'
+		ORDER BY dt.match_id, dt.game_number, dt.started_at
+	`, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get opponent decisions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	type gameDecisions struct {
+		durations []int64
+		final     []*models.SlowRollSuspect
+	}
+	var order []string
+	games := make(map[string]*gameDecisions)
+	for rows.Next() {
+		s := &models.SlowRollSuspect{}
+		var lastTurn int
+		var startedAt time.Time
+		if err := rows.Scan(&s.MatchID, &s.GameNumber, &s.TurnNumber, &s.OpponentName,
+			&s.Phase, &s.Step, &s.DecisionMs, &startedAt, &lastTurn); err != nil {
+			return nil, fmt.Errorf("failed to scan opponent decision: %w", err)
+		}
+		s.Timestamp = startedAt
+
+		key := fmt.Sprintf("%s|%d", s.MatchID, s.GameNumber)
+		game, ok := games[key]
+		if !ok {
+			game = &gameDecisions{}
+			games[key] = game
+			order = append(order, key)
+		}
+		game.durations = append(game.durations, s.DecisionMs)
+		if s.TurnNumber == lastTurn {
+			game.final = append(game.final, s)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate opponent decisions: %w", err)
+	}
+
+	suspects := []*models.SlowRollSuspect{}
+	for _, key := range order {
+		game := games[key]
+		median := medianMs(game.durations)
+		if median <= 0 {
+			continue
+		}
+
+		// The longest final-turn decision is the one that matters
+		var slowest *models.SlowRollSuspect
+		for _, s := range game.final {
+			if slowest == nil || s.DecisionMs > slowest.DecisionMs {
+				slowest = s
+			}
+		}
+		if slowest == nil || slowest.DecisionMs < slowRollMinMs {
+			continue
+		}
+		ratio := float64(slowest.DecisionMs) / float64(median)
+		if ratio < slowRollRatio {
+			continue
+		}
+		slowest.MedianDecisionMs = median
+		slowest.Ratio = ratio
+		suspects = append(suspects, slowest)
+	}
+
+	sort.SliceStable(suspects, func(i, j int) bool {
+		return suspects[i].Timestamp.After(suspects[j].Timestamp)
+	})
+	if len(suspects) > limit {
+		suspects = suspects[:limit]
+	}
+	return suspects, nil
+}
+
+func medianMs(values []int64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int64{}, values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
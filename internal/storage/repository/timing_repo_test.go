@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
+)
+
+var timingBase = time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func timingAt(seconds int) time.Time {
+	return timingBase.Add(time.Duration(seconds) * time.Second)
+}
+
+// setupTimingTestDB creates an in-memory SQLite database with a won and a lost
+// match played with the same deck.
+func setupTimingTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open(sqlitedriver.Name, ":memory:")
+	require.NoError(t, err)
+
+	_, err = db.Exec(`
+		CREATE TABLE matches (
+			id TEXT PRIMARY KEY,
+			account_id INTEGER,
+			deck_id TEXT,
+			result TEXT,
+			opponent_name TEXT
+		);
+
+		CREATE TABLE games (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			match_id TEXT,
+			game_number INTEGER,
+			result TEXT
+		);
+
+		CREATE TABLE decks (
+			id TEXT PRIMARY KEY,
+			name TEXT
+		);
+
+		CREATE TABLE opponent_deck_profiles (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			match_id TEXT UNIQUE,
+			detected_archetype TEXT
+		);
+
+		CREATE TABLE turn_timings (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			match_id TEXT NOT NULL,
+			game_number INTEGER NOT NULL,
+			turn_number INTEGER NOT NULL,
+			active_player TEXT,
+			started_at TIMESTAMP NOT NULL,
+			ended_at TIMESTAMP NOT NULL,
+			UNIQUE(match_id, game_number, turn_number)
+		);
+
+		CREATE TABLE decision_timings (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			match_id TEXT NOT NULL,
+			game_number INTEGER NOT NULL,
+			turn_number INTEGER NOT NULL,
+			player_type TEXT NOT NULL,
+			phase TEXT,
+			step TEXT,
+			started_at TIMESTAMP NOT NULL,
+			duration_ms INTEGER NOT NULL,
+			UNIQUE(match_id, game_number, player_type, started_at)
+		);
+
+		CREATE TABLE timer_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			match_id TEXT NOT NULL,
+			game_number INTEGER NOT NULL,
+			turn_number INTEGER NOT NULL,
+			player_type TEXT NOT NULL,
+			event_type TEXT NOT NULL,
+			elapsed_ms INTEGER NOT NULL DEFAULT 0,
+			timestamp TIMESTAMP NOT NULL,
+			UNIQUE(match_id, game_number, turn_number, player_type, event_type)
+		);
+
+		INSERT INTO matches (id, account_id, deck_id, result, opponent_name) VALUES
+			('m1', 1, 'd1', 'win', 'Fast'),
+			('m2', 1, 'd1', 'loss', 'Slow');
+		INSERT INTO games (match_id, game_number, result) VALUES ('m1', 1, 'win'), ('m2', 1, 'loss');
+		INSERT INTO decks (id, name) VALUES ('d1', 'Mono-Red');
+		INSERT INTO opponent_deck_profiles (match_id, detected_archetype) VALUES ('m1', 'Control'), ('m2', 'Control');
+	`)
+	require.NoError(t, err)
+
+	repo := NewTimingRepository(db)
+	ctx := context.Background()
+
+	// m1: we take 20s on our turn, the opponent 15s on theirs
+	require.NoError(t, repo.SaveTiming(ctx,
+		[]*models.TurnTiming{
+			{MatchID: "m1", GameNumber: 1, TurnNumber: 1, ActivePlayer: "player", StartedAt: timingAt(0), EndedAt: timingAt(25)},
+			{MatchID: "m1", GameNumber: 1, TurnNumber: 2, ActivePlayer: "opponent", StartedAt: timingAt(30), EndedAt: timingAt(50)},
+		},
+		[]*models.DecisionTiming{
+			{MatchID: "m1", GameNumber: 1, TurnNumber: 1, PlayerType: "player", StartedAt: timingAt(0), DurationMs: 20000},
+			{MatchID: "m1", GameNumber: 1, TurnNumber: 1, PlayerType: "opponent", StartedAt: timingAt(20), DurationMs: 5000},
+			{MatchID: "m1", GameNumber: 1, TurnNumber: 2, PlayerType: "opponent", StartedAt: timingAt(30), DurationMs: 15000},
+			{MatchID: "m1", GameNumber: 1, TurnNumber: 2, PlayerType: "player", StartedAt: timingAt(45), DurationMs: 2000},
+		}, nil))
+
+	// m2: we take 95s on our turn, then the opponent sits on the final turn
+	require.NoError(t, repo.SaveTiming(ctx,
+		[]*models.TurnTiming{
+			{MatchID: "m2", GameNumber: 1, TurnNumber: 1, ActivePlayer: "player", StartedAt: timingAt(0), EndedAt: timingAt(97)},
+			{MatchID: "m2", GameNumber: 1, TurnNumber: 2, ActivePlayer: "opponent", StartedAt: timingAt(100), EndedAt: timingAt(170)},
+		},
+		[]*models.DecisionTiming{
+			{MatchID: "m2", GameNumber: 1, TurnNumber: 1, PlayerType: "player", StartedAt: timingAt(0), DurationMs: 95000},
+			{MatchID: "m2", GameNumber: 1, TurnNumber: 1, PlayerType: "opponent", StartedAt: timingAt(95), DurationMs: 2000},
+			{MatchID: "m2", GameNumber: 1, TurnNumber: 2, PlayerType: "opponent", StartedAt: timingAt(100), DurationMs: 5000},
+			{MatchID: "m2", GameNumber: 1, TurnNumber: 2, PlayerType: "opponent", StartedAt: timingAt(110), DurationMs: 60000},
+		},
+		[]*models.TimerEvent{
+			{MatchID: "m2", GameNumber: 1, TurnNumber: 1, PlayerType: "player", EventType: models.TimerEventRope, ElapsedMs: 40000, Timestamp: timingAt(40)},
+			{MatchID: "m2", GameNumber: 1, TurnNumber: 2, PlayerType: "opponent", EventType: models.TimerEventRope, ElapsedMs: 40000, Timestamp: timingAt(150)},
+		}))
+
+	return db
+}
+
+func TestSaveTiming_MergesBatches(t *testing.T) {
+	db := setupTimingTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := NewTimingRepository(db)
+	ctx := context.Background()
+
+	// A later batch sees more of turn 2 and the rest of a decision it already saw
+	require.NoError(t, repo.SaveTiming(ctx,
+		[]*models.TurnTiming{
+			{MatchID: "m1", GameNumber: 1, TurnNumber: 2, ActivePlayer: "opponent", StartedAt: timingAt(40), EndedAt: timingAt(60)},
+		},
+		[]*models.DecisionTiming{
+			{MatchID: "m1", GameNumber: 1, TurnNumber: 2, PlayerType: "player", StartedAt: timingAt(45), DurationMs: 8000},
+		},
+		[]*models.TimerEvent{
+			{MatchID: "m2", GameNumber: 1, TurnNumber: 1, PlayerType: "player", EventType: models.TimerEventRope, ElapsedMs: 41000, Timestamp: timingAt(41)},
+		}))
+
+	timing, err := repo.GetMatchTiming(ctx, "m1")
+	require.NoError(t, err)
+	require.Len(t, timing.Turns, 2)
+	assert.True(t, timing.Turns[1].StartedAt.Equal(timingAt(30)), "earliest start should be kept")
+	assert.True(t, timing.Turns[1].EndedAt.Equal(timingAt(60)), "latest activity should be kept")
+	assert.Len(t, timing.Decisions, 4)
+	assert.Equal(t, int64(20000), timing.Games[0].PlayerLongestDecisionMs)
+	assert.Equal(t, int64(28000), timing.PlayerTotalMs)
+
+	m2, err := repo.GetMatchTiming(ctx, "m2")
+	require.NoError(t, err)
+	assert.Equal(t, 1, m2.PlayerRopes, "a rope is only recorded once per turn")
+}
+
+func TestGetMatchTiming(t *testing.T) {
+	db := setupTimingTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := NewTimingRepository(db)
+	timing, err := repo.GetMatchTiming(context.Background(), "m2")
+	require.NoError(t, err)
+	require.NotNil(t, timing)
+
+	require.Len(t, timing.Turns, 2)
+	assert.Equal(t, int64(100000), timing.Turns[0].DurationMs, "lasts until the next turn starts")
+	assert.Equal(t, int64(70000), timing.Turns[1].DurationMs, "final turn lasts until its last activity")
+	assert.Equal(t, int64(95000), timing.Turns[0].PlayerDecisionMs)
+	assert.Equal(t, 2, timing.Turns[1].OpponentDecisions)
+
+	require.Len(t, timing.Games, 1)
+	assert.Equal(t, int64(95000), timing.Games[0].PlayerMs)
+	assert.Equal(t, int64(67000), timing.Games[0].OpponentMs)
+	assert.Equal(t, int64(60000), timing.Games[0].OpponentLongestDecisionMs)
+	assert.Equal(t, 1, timing.PlayerRopes)
+	assert.Equal(t, 1, timing.OpponentRopes)
+
+	missing, err := repo.GetMatchTiming(context.Background(), "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestGetDeckAndMatchupTimingStats(t *testing.T) {
+	db := setupTimingTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := NewTimingRepository(db)
+	ctx := context.Background()
+
+	decks, err := repo.GetDeckTimingStats(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, decks, 1)
+	assert.Equal(t, "Mono-Red", decks[0].DeckName)
+	assert.Equal(t, 2, decks[0].Matches)
+	assert.InDelta(t, 0.5, decks[0].WinRate, 1e-9)
+	assert.InDelta(t, 57.5, decks[0].AvgTurnSeconds, 1e-9)
+	assert.InDelta(t, 95, decks[0].LongestTurnSeconds, 1e-9)
+	assert.Equal(t, 1, decks[0].Ropes)
+
+	matchups, err := repo.GetMatchupTimingStats(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, matchups, 1)
+	assert.Equal(t, "Control", matchups[0].OpponentArchetype)
+	assert.InDelta(t, 40, matchups[0].AvgOpponentTurnSeconds, 1e-9)
+
+	other, err := repo.GetDeckTimingStats(ctx, 2)
+	require.NoError(t, err)
+	assert.Empty(t, other)
+}
+
+func TestGetTurnTimeOutcome(t *testing.T) {
+	db := setupTimingTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	outcome, err := NewTimingRepository(db).GetTurnTimeOutcome(context.Background(), 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, outcome.Matches)
+	assert.InDelta(t, 20, outcome.AvgTurnSecondsInWins, 1e-9)
+	assert.InDelta(t, 95, outcome.AvgTurnSecondsInLosses, 1e-9)
+	assert.Equal(t, 1, outcome.MatchesWithLongTurns)
+	assert.InDelta(t, 1, outcome.LossRateWithLongTurns, 1e-9)
+	assert.InDelta(t, 0, outcome.LossRateOtherwise, 1e-9)
+	assert.InDelta(t, 1, outcome.Correlation, 1e-9)
+}
+
+func TestGetSlowRollSuspects(t *testing.T) {
+	db := setupTimingTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	suspects, err := NewTimingRepository(db).GetSlowRollSuspects(context.Background(), 1, 10)
+	require.NoError(t, err)
+	require.Len(t, suspects, 1, "only the lost game is considered")
+
+	s := suspects[0]
+	assert.Equal(t, "m2", s.MatchID)
+	assert.Equal(t, "Slow", s.OpponentName)
+	assert.Equal(t, 2, s.TurnNumber)
+	assert.Equal(t, int64(60000), s.DecisionMs)
+	assert.Equal(t, int64(5000), s.MedianDecisionMs)
+	assert.InDelta(t, 12, s.Ratio, 1e-9)
+}
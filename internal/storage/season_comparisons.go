@@ -5,42 +5,47 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ramonehamilton/MTGA-Companion/internal/stats"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 )
 
 // SeasonStats represents statistics for a specific season/time period.
 type SeasonStats struct {
-	SeasonName   string    `json:"season_name"`
-	StartDate    time.Time `json:"start_date"`
-	EndDate      time.Time `json:"end_date"`
-	TotalMatches int       `json:"total_matches"`
-	MatchesWon   int       `json:"matches_won"`
-	MatchesLost  int       `json:"matches_lost"`
-	TotalGames   int       `json:"total_games"`
-	GamesWon     int       `json:"games_won"`
-	GamesLost    int       `json:"games_lost"`
-	WinRate      float64   `json:"win_rate"`      // Match win rate percentage
-	GameWinRate  float64   `json:"game_win_rate"` // Game win rate percentage
+	SeasonName   string         `json:"season_name"`
+	StartDate    time.Time      `json:"start_date"`
+	EndDate      time.Time      `json:"end_date"`
+	TotalMatches int            `json:"total_matches"`
+	MatchesWon   int            `json:"matches_won"`
+	MatchesLost  int            `json:"matches_lost"`
+	TotalGames   int            `json:"total_games"`
+	GamesWon     int            `json:"games_won"`
+	GamesLost    int            `json:"games_lost"`
+	WinRate      float64        `json:"win_rate"`      // Match win rate percentage
+	GameWinRate  float64        `json:"game_win_rate"` // Game win rate percentage
+	WinRateCI    stats.Interval `json:"win_rate_ci"`   // 95% Wilson interval, in percent
+	Warning      string         `json:"warning,omitempty"`
 }
 
 // SeasonComparison compares two seasons/time periods.
 type SeasonComparison struct {
-	Season1              *SeasonStats `json:"season1"`
-	Season2              *SeasonStats `json:"season2"`
-	WinRateChange        float64      `json:"win_rate_change"`         // Percentage point change
-	GameWinRateChange    float64      `json:"game_win_rate_change"`    // Percentage point change
-	MatchCountChange     int          `json:"match_count_change"`      // Absolute change in matches played
-	MatchCountChangePerc float64      `json:"match_count_change_perc"` // Percentage change in matches played
-	Trend                string       `json:"trend"`                   // "improving", "declining", "stable"
+	Season1              *SeasonStats     `json:"season1"`
+	Season2              *SeasonStats     `json:"season2"`
+	WinRateChange        float64          `json:"win_rate_change"`         // Percentage point change
+	GameWinRateChange    float64          `json:"game_win_rate_change"`    // Percentage point change
+	MatchCountChange     int              `json:"match_count_change"`      // Absolute change in matches played
+	MatchCountChangePerc float64          `json:"match_count_change_perc"` // Percentage change in matches played
+	Trend                string           `json:"trend"`                   // "improving", "declining", "stable"
+	Significance         stats.Comparison `json:"significance"`            // Season 2 vs season 1 match win rate
 }
 
 // MultiSeasonComparison compares multiple seasons/time periods.
 type MultiSeasonComparison struct {
-	Seasons      []SeasonStats `json:"seasons"`
-	BestSeason   string        `json:"best_season"`   // Season name with highest win rate
-	WorstSeason  string        `json:"worst_season"`  // Season name with lowest win rate
-	MostActive   string        `json:"most_active"`   // Season with most matches
-	OverallTrend string        `json:"overall_trend"` // "improving", "declining", "stable", "variable"
+	Seasons      []SeasonStats     `json:"seasons"`
+	BestSeason   string            `json:"best_season"`            // Season name with highest win rate
+	WorstSeason  string            `json:"worst_season"`           // Season name with lowest win rate
+	MostActive   string            `json:"most_active"`            // Season with most matches
+	OverallTrend string            `json:"overall_trend"`          // "improving", "declining", "stable", "variable"
+	Significance *stats.Comparison `json:"significance,omitempty"` // Last vs first season match win rate
 }
 
 // GetSeasonStats calculates statistics for a specific season/time period.
@@ -58,7 +63,7 @@ func (s *Service) GetSeasonStats(ctx context.Context, seasonName string, startDa
 		return nil, fmt.Errorf("failed to get matches for season %s: %w", seasonName, err)
 	}
 
-	stats := &SeasonStats{
+	seasonStats := &SeasonStats{
 		SeasonName: seasonName,
 		StartDate:  startDate,
 		EndDate:    endDate,
@@ -66,27 +71,29 @@ func (s *Service) GetSeasonStats(ctx context.Context, seasonName string, startDa
 
 	// Calculate statistics
 	for _, match := range matches {
-		stats.TotalMatches++
-		stats.TotalGames += match.PlayerWins + match.OpponentWins
-		stats.GamesWon += match.PlayerWins
-		stats.GamesLost += match.OpponentWins
+		seasonStats.TotalMatches++
+		seasonStats.TotalGames += match.PlayerWins + match.OpponentWins
+		seasonStats.GamesWon += match.PlayerWins
+		seasonStats.GamesLost += match.OpponentWins
 
 		if match.Result == "win" {
-			stats.MatchesWon++
+			seasonStats.MatchesWon++
 		} else {
-			stats.MatchesLost++
+			seasonStats.MatchesLost++
 		}
 	}
 
 	// Calculate win rates
-	if stats.TotalMatches > 0 {
-		stats.WinRate = float64(stats.MatchesWon) / float64(stats.TotalMatches) * 100
+	if seasonStats.TotalMatches > 0 {
+		seasonStats.WinRate = float64(seasonStats.MatchesWon) / float64(seasonStats.TotalMatches) * 100
 	}
-	if stats.TotalGames > 0 {
-		stats.GameWinRate = float64(stats.GamesWon) / float64(stats.TotalGames) * 100
+	if seasonStats.TotalGames > 0 {
+		seasonStats.GameWinRate = float64(seasonStats.GamesWon) / float64(seasonStats.TotalGames) * 100
 	}
+	seasonStats.WinRateCI = stats.WilsonInterval(seasonStats.MatchesWon, seasonStats.TotalMatches, stats.DefaultConfidence).Scale(100)
+	seasonStats.Warning = stats.SampleWarning(seasonStats.TotalMatches)
 
-	return stats, nil
+	return seasonStats, nil
 }
 
 // CompareSeasons compares two seasons/time periods.
@@ -107,6 +114,7 @@ func (s *Service) CompareSeasons(ctx context.Context, season1Name string, season
 		WinRateChange:     season2.WinRate - season1.WinRate,
 		GameWinRateChange: season2.GameWinRate - season1.GameWinRate,
 		MatchCountChange:  season2.TotalMatches - season1.TotalMatches,
+		Significance:      stats.Compare(season2.MatchesWon, season2.TotalMatches, season1.MatchesWon, season1.TotalMatches),
 	}
 
 	// Calculate percentage change in match count
@@ -182,6 +190,8 @@ func (s *Service) CompareMultipleSeasons(ctx context.Context, seasons []struct {
 
 		if first.TotalMatches > 0 && last.TotalMatches > 0 {
 			winRateChange := last.WinRate - first.WinRate
+			significance := stats.Compare(last.MatchesWon, last.TotalMatches, first.MatchesWon, first.TotalMatches)
+			comparison.Significance = &significance
 
 			switch {
 			case winRateChange > 5.0:
@@ -35,6 +35,7 @@ type Service struct {
 	standard                repository.StandardRepository
 	gamePlay                repository.GamePlayRepository
 	cardPerformanceAnalysis repository.CardPerformanceRepository
+	integrity               repository.IntegrityRepository
 	currentAccountID        int // Current active account ID
 }
 
@@ -58,6 +59,7 @@ type ServiceConfig struct {
 	Standard                repository.StandardRepository
 	GamePlay                repository.GamePlayRepository
 	CardPerformanceAnalysis repository.CardPerformanceRepository
+	Integrity               repository.IntegrityRepository
 }
 
 // NewService creates a new storage service with default repository implementations.
@@ -73,30 +75,39 @@ func NewServiceWithConfig(db *DB, cfg *ServiceConfig) *Service {
 	}
 
 	conn := db.Conn()
+	reader := db.Reader()
 
 	svc := &Service{
-		db:              db,
-		matches:         orDefault(cfg.Matches, func() repository.MatchRepository { return repository.NewMatchRepository(conn) }),
-		stats:           orDefault(cfg.Stats, func() repository.StatsRepository { return repository.NewStatsRepository(conn) }),
-		decks:           orDefault(cfg.Decks, func() repository.DeckRepository { return repository.NewDeckRepository(conn) }),
-		collection:      orDefault(cfg.Collection, func() repository.CollectionRepository { return repository.NewCollectionRepository(conn) }),
-		accounts:        orDefault(cfg.Accounts, func() repository.AccountRepository { return repository.NewAccountRepository(conn) }),
-		rankHistory:     orDefault(cfg.RankHistory, func() repository.RankHistoryRepository { return repository.NewRankHistoryRepository(conn) }),
-		quests:          orDefaultQuest(cfg.Quests, func() *QuestRepository { return NewQuestRepository(conn) }),
-		draft:           orDefault(cfg.Draft, func() repository.DraftRepository { return repository.NewDraftRepository(conn) }),
-		setCard:         orDefault(cfg.SetCard, func() repository.SetCardRepository { return repository.NewSetCardRepository(conn) }),
-		draftRatings:    orDefault(cfg.DraftRatings, func() repository.DraftRatingsRepository { return repository.NewDraftRatingsRepository(conn) }),
-		inventory:       orDefault(cfg.Inventory, func() repository.InventoryRepository { return repository.NewInventoryRepository(conn) }),
-		settings:        orDefault(cfg.Settings, func() repository.SettingsRepository { return repository.NewSettingsRepository(conn) }),
-		deckPerformance: orDefault(cfg.DeckPerformance, func() repository.DeckPerformanceRepository { return repository.NewDeckPerformanceRepository(conn) }),
+		db:         db,
+		matches:    orDefault(cfg.Matches, func() repository.MatchRepository { return repository.NewMatchRepositoryWithReader(conn, reader) }),
+		stats:      orDefault(cfg.Stats, func() repository.StatsRepository { return repository.NewStatsRepository(conn) }),
+		decks:      orDefault(cfg.Decks, func() repository.DeckRepository { return repository.NewDeckRepository(conn) }),
+		collection: orDefault(cfg.Collection, func() repository.CollectionRepository { return repository.NewCollectionRepository(conn) }),
+		accounts:   orDefault(cfg.Accounts, func() repository.AccountRepository { return repository.NewAccountRepository(conn) }),
+		rankHistory: orDefault(cfg.RankHistory, func() repository.RankHistoryRepository {
+			return repository.NewRankHistoryRepositoryWithReader(conn, reader)
+		}),
+		quests:  orDefaultQuest(cfg.Quests, func() *QuestRepository { return NewQuestRepository(conn) }),
+		draft:   orDefault(cfg.Draft, func() repository.DraftRepository { return repository.NewDraftRepository(conn) }),
+		setCard: orDefault(cfg.SetCard, func() repository.SetCardRepository { return repository.NewSetCardRepository(conn) }),
+		draftRatings: orDefault(cfg.DraftRatings, func() repository.DraftRatingsRepository {
+			return repository.NewDraftRatingsRepositoryWithReader(conn, reader)
+		}),
+		inventory: orDefault(cfg.Inventory, func() repository.InventoryRepository { return repository.NewInventoryRepository(conn) }),
+		settings:  orDefault(cfg.Settings, func() repository.SettingsRepository { return repository.NewSettingsRepository(conn) }),
+		deckPerformance: orDefault(cfg.DeckPerformance, func() repository.DeckPerformanceRepository {
+			return repository.NewDeckPerformanceRepositoryWithReader(conn, reader)
+		}),
 		recommendationFeedback: orDefault(cfg.RecommendationFeedback, func() repository.RecommendationFeedbackRepository {
 			return repository.NewRecommendationFeedbackRepository(conn)
 		}),
 		standard: orDefault(cfg.Standard, func() repository.StandardRepository { return repository.NewStandardRepository(conn) }),
 		gamePlay: orDefault(cfg.GamePlay, func() repository.GamePlayRepository { return repository.NewGamePlayRepository(conn) }),
+		// Card performance analysis is read-only, so it runs entirely on the read pool
 		cardPerformanceAnalysis: orDefault(cfg.CardPerformanceAnalysis, func() repository.CardPerformanceRepository {
-			return repository.NewCardPerformanceRepository(conn)
+			return repository.NewCardPerformanceRepository(reader)
 		}),
+		integrity: orDefault(cfg.Integrity, func() repository.IntegrityRepository { return repository.NewIntegrityRepository(conn) }),
 	}
 
 	// Initialize default account if it doesn't exist
@@ -739,17 +750,23 @@ func parseLogTimestamp(timestampStr string) (time.Time, error) {
 
 // StoreMatch stores a single match and its games.
 // This is useful when processing match completion events from the log.
+//
+// The writes go through a repository scoped to the transaction: on a split
+// read/write database the writer has a single connection, so writing through
+// the pool while the transaction holds it would deadlock.
 func (s *Service) StoreMatch(ctx context.Context, match *Match, games []*Game) error {
 	// Use a transaction to ensure atomicity
 	return s.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		matches := s.matches.WithTx(tx)
+
 		// Create the match
-		if err := s.matches.Create(ctx, match); err != nil {
+		if err := matches.Create(ctx, match); err != nil {
 			return fmt.Errorf("failed to create match: %w", err)
 		}
 
 		// Create each game
 		for _, game := range games {
-			if err := s.matches.CreateGame(ctx, game); err != nil {
+			if err := matches.CreateGame(ctx, game); err != nil {
 				return fmt.Errorf("failed to create game: %w", err)
 			}
 		}
@@ -1029,31 +1046,35 @@ func (s *Service) StoreDeckFromParser(ctx context.Context, deckID, name, format,
 }
 
 // StoreDeck stores a complete deck with its cards.
+// Like StoreMatch, it writes through a repository scoped to the transaction,
+// so a failure part-way leaves the deck's previous cards in place.
 func (s *Service) StoreDeck(ctx context.Context, deck *models.Deck, cards []*models.DeckCard) error {
 	return s.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		decks := s.decks.WithTx(tx)
+
 		// Create or update the deck
-		existing, err := s.decks.GetByID(ctx, deck.ID)
+		existing, err := decks.GetByID(ctx, deck.ID)
 		if err != nil {
 			return fmt.Errorf("failed to check existing deck: %w", err)
 		}
 
 		if existing == nil {
-			if err := s.decks.Create(ctx, deck); err != nil {
+			if err := decks.Create(ctx, deck); err != nil {
 				return fmt.Errorf("failed to create deck: %w", err)
 			}
 		} else {
-			if err := s.decks.Update(ctx, deck); err != nil {
+			if err := decks.Update(ctx, deck); err != nil {
 				return fmt.Errorf("failed to update deck: %w", err)
 			}
 		}
 
 		// Clear existing cards and add new ones
-		if err := s.decks.ClearCards(ctx, deck.ID); err != nil {
+		if err := decks.ClearCards(ctx, deck.ID); err != nil {
 			return fmt.Errorf("failed to clear deck cards: %w", err)
 		}
 
 		for _, card := range cards {
-			if err := s.decks.AddCard(ctx, card); err != nil {
+			if err := decks.AddCard(ctx, card); err != nil {
 				return fmt.Errorf("failed to add card to deck: %w", err)
 			}
 		}
@@ -2226,6 +2247,11 @@ func (s *Service) NewDeckRepo() repository.DeckRepository {
 	return repository.NewDeckRepository(s.db.Conn())
 }
 
+// NewDeckPermutationRepo creates a new deck permutation repository using the service's database connection.
+func (s *Service) NewDeckPermutationRepo() repository.DeckPermutationRepository {
+	return repository.NewDeckPermutationRepository(s.db.Conn())
+}
+
 // NewSetCardRepo creates a new set card repository using the service's database connection.
 func (s *Service) NewSetCardRepo() repository.SetCardRepository {
 	return repository.NewSetCardRepository(s.db.Conn())
@@ -2236,11 +2262,39 @@ func (s *Service) NewOpponentRepo() repository.OpponentRepository {
 	return repository.NewOpponentRepository(s.db.Conn())
 }
 
+// NewMTGZoneRepo creates a new MTGZone repository using the service's database connection.
+func (s *Service) NewMTGZoneRepo() repository.MTGZoneRepository {
+	return repository.NewMTGZoneRepository(s.db.Conn())
+}
+
+// NewTimingRepo creates a new timing repository using the service's database connection.
+func (s *Service) NewTimingRepo() repository.TimingRepository {
+	return repository.NewTimingRepository(s.db.Conn())
+}
+
 // NewCFBRatingsRepo creates a new CFB ratings repository using the service's database connection.
 func (s *Service) NewCFBRatingsRepo() repository.CFBRatingsRepository {
 	return repository.NewCFBRatingsRepository(s.db.Conn())
 }
 
+// NewReadOnlyDraftRepo creates a draft repository on the read pool for exports
+// and analytics that only read. Its write methods fail.
+func (s *Service) NewReadOnlyDraftRepo() repository.DraftRepository {
+	return repository.NewDraftRepository(s.db.Reader())
+}
+
+// NewReadOnlyGamePlayRepo creates a game play repository on the read pool for
+// exports and analytics that only read. Its write methods fail.
+func (s *Service) NewReadOnlyGamePlayRepo() repository.GamePlayRepository {
+	return repository.NewGamePlayRepository(s.db.Reader())
+}
+
+// NewReadOnlySetCardRepo creates a set card repository on the read pool for
+// exports and analytics that only read. Its write methods fail.
+func (s *Service) NewReadOnlySetCardRepo() repository.SetCardRepository {
+	return repository.NewSetCardRepository(s.db.Reader())
+}
+
 // Close closes the database connection.
 func (s *Service) Close() error {
 	return s.db.Close()
@@ -6,9 +6,9 @@ import (
 	"testing"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/models"
 	"github.com/ramonehamilton/MTGA-Companion/internal/storage/repository"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 // mockMatchRepository is a mock implementation of MatchRepository for testing DI.
@@ -28,6 +28,10 @@ func (m *mockMatchRepository) GetByID(_ context.Context, _ string) (*models.Matc
 	return nil, nil
 }
 
+func (m *mockMatchRepository) WithTx(_ *sql.Tx) repository.MatchRepository {
+	return m
+}
+
 // mockDeckRepository is a mock implementation of DeckRepository for testing DI.
 type mockDeckRepository struct {
 	repository.DeckRepository
@@ -47,9 +51,13 @@ func (m *mockDeckRepository) Create(_ context.Context, _ *models.Deck) error {
 	return nil
 }
 
+func (m *mockDeckRepository) WithTx(_ *sql.Tx) repository.DeckRepository {
+	return m
+}
+
 // setupDITestDB creates an in-memory database for DI tests.
 func setupDITestDB(t *testing.T) *DB {
-	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	sqlDB, err := sql.Open(sqlitedriver.Name, ":memory:")
 	if err != nil {
 		t.Fatalf("failed to open test database: %v", err)
 	}
@@ -355,7 +363,7 @@ func TestNewServiceWithConfig_InitializesCurrentAccount(t *testing.T) {
 
 func TestNewServiceWithConfig_CreatesDefaultAccountIfMissing(t *testing.T) {
 	// Create empty database (no default account)
-	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	sqlDB, err := sql.Open(sqlitedriver.Name, ":memory:")
 	if err != nil {
 		t.Fatalf("failed to open test database: %v", err)
 	}
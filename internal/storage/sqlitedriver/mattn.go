@@ -0,0 +1,121 @@
+//go:build sqlite_cgo && cgo
+
+package sqlitedriver
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite3" // migrate driver for mattn/go-sqlite3
+	"github.com/mattn/go-sqlite3"
+)
+
+const (
+	// Name is the database/sql driver name to pass to sql.Open.
+	// It is registered below with the SQL math functions modernc provides built in.
+	Name = "sqlite3_mtga"
+
+	// Implementation identifies the compiled-in driver package.
+	Implementation = "github.com/mattn/go-sqlite3"
+
+	// CGO reports whether the compiled-in driver requires cgo.
+	CGO = true
+
+	// MigrateScheme is the golang-migrate URL scheme for the compiled-in driver.
+	MigrateScheme = "sqlite3"
+)
+
+func init() {
+	sql.Register(Name, &sqlite3.SQLiteDriver{ConnectHook: registerMathFunctions})
+}
+
+// registerMathFunctions adds the SQLite math functions that mattn/go-sqlite3 only
+// compiles in with the sqlite_math_functions tag, so queries behave the same
+// under both drivers.
+func registerMathFunctions(conn *sqlite3.SQLiteConn) error {
+	funcs := map[string]any{
+		"sqrt":    unary(math.Sqrt),
+		"exp":     unary(math.Exp),
+		"ln":      unary(math.Log),
+		"log":     unary(math.Log10),
+		"log2":    unary(math.Log2),
+		"log10":   unary(math.Log10),
+		"pow":     binary(math.Pow),
+		"power":   binary(math.Pow),
+		"floor":   unary(math.Floor),
+		"ceil":    unary(math.Ceil),
+		"ceiling": unary(math.Ceil),
+	}
+	for name, fn := range funcs {
+		if err := conn.RegisterFunc(name, fn, true); err != nil {
+			return fmt.Errorf("failed to register SQL function %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// DSN builds a mattn/go-sqlite3 data source name for path with the given pragmas.
+func DSN(path string, p Pragmas) string {
+	var params []string
+	if p.BusyTimeout > 0 {
+		params = append(params, fmt.Sprintf("_busy_timeout=%d", p.BusyTimeout.Milliseconds()))
+	}
+	if p.JournalMode != "" {
+		params = append(params, "_journal_mode="+p.JournalMode)
+	}
+	if p.Synchronous != "" {
+		params = append(params, "_synchronous="+p.Synchronous)
+	}
+	if p.ForeignKeys {
+		params = append(params, "_foreign_keys=on")
+	}
+	if p.QueryOnly {
+		params = append(params, "_query_only=on")
+	}
+	if len(params) == 0 {
+		return path
+	}
+	return path + "?" + strings.Join(params, "&")
+}
+
+// primaryCode extracts the SQLite result code from a mattn driver error.
+func primaryCode(err error) (int, bool) {
+	return asCode(err, func(e sqlite3.Error) int { return int(e.Code) })
+}
+
+// unary adapts a float function to SQLite's loose typing: integer arguments are
+// widened and NULL propagates, matching the built-in math functions.
+func unary(fn func(float64) float64) func(any) any {
+	return func(x any) any {
+		v, ok := toFloat(x)
+		if !ok {
+			return nil
+		}
+		return fn(v)
+	}
+}
+
+// binary is the two-argument form of unary.
+func binary(fn func(float64, float64) float64) func(any, any) any {
+	return func(x, y any) any {
+		a, okA := toFloat(x)
+		b, okB := toFloat(y)
+		if !okA || !okB {
+			return nil
+		}
+		return fn(a, b)
+	}
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,55 @@
+//go:build !(sqlite_cgo && cgo)
+
+package sqlitedriver
+
+import (
+	"fmt"
+	"net/url"
+
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite" // migrate driver for modernc.org/sqlite
+	"modernc.org/sqlite"
+)
+
+const (
+	// Name is the database/sql driver name to pass to sql.Open.
+	Name = "sqlite"
+
+	// Implementation identifies the compiled-in driver package.
+	Implementation = "modernc.org/sqlite"
+
+	// CGO reports whether the compiled-in driver requires cgo.
+	CGO = false
+
+	// MigrateScheme is the golang-migrate URL scheme for the compiled-in driver.
+	MigrateScheme = "sqlite"
+)
+
+// DSN builds a modernc.org/sqlite data source name for path with the given pragmas.
+// modernc applies each _pragma parameter to every new connection in the pool.
+func DSN(path string, p Pragmas) string {
+	q := url.Values{}
+	if p.BusyTimeout > 0 {
+		q.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", p.BusyTimeout.Milliseconds()))
+	}
+	if p.JournalMode != "" {
+		q.Add("_pragma", fmt.Sprintf("journal_mode(%s)", p.JournalMode))
+	}
+	if p.Synchronous != "" {
+		q.Add("_pragma", fmt.Sprintf("synchronous(%s)", p.Synchronous))
+	}
+	if p.ForeignKeys {
+		q.Add("_pragma", "foreign_keys(1)")
+	}
+	if p.QueryOnly {
+		q.Add("_pragma", "query_only(1)")
+	}
+	if len(q) == 0 {
+		return path
+	}
+	return path + "?" + q.Encode()
+}
+
+// primaryCode extracts the SQLite result code from a modernc driver error.
+func primaryCode(err error) (int, bool) {
+	return asCode(err, func(e *sqlite.Error) int { return e.Code() })
+}
@@ -0,0 +1,155 @@
+// Package sqlitedriver selects the SQLite database/sql driver at build time.
+//
+// The default build uses the pure-Go modernc.org/sqlite driver, so the whole
+// repository can be built and tested with CGO_ENABLED=0. Building with the
+// sqlite_cgo tag (and cgo enabled) switches to github.com/mattn/go-sqlite3:
+//
+//	go build -tags sqlite_cgo ./...
+//
+// Everything that opens a SQLite connection (storage.Open, the backup manager,
+// migrations and tests) goes through this package so the driver name, DSN
+// syntax and error classification always agree with the compiled-in driver.
+package sqlitedriver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Primary SQLite result codes used for error classification.
+// Extended result codes carry the primary code in their low byte.
+const (
+	codeBusy   = 5 // SQLITE_BUSY: the database file is locked
+	codeLocked = 6 // SQLITE_LOCKED: a table in the database is locked
+)
+
+// ErrorClass categorizes SQLite errors that callers may want to retry.
+type ErrorClass int
+
+const (
+	// ClassNone means the error is nil or not a contention error.
+	ClassNone ErrorClass = iota
+	// ClassBusy means another connection holds a conflicting lock on the database file.
+	ClassBusy
+	// ClassLocked means a table is locked by another statement on a shared connection.
+	ClassLocked
+)
+
+// String returns the SQLite name of the class.
+func (c ErrorClass) String() string {
+	switch c {
+	case ClassBusy:
+		return "SQLITE_BUSY"
+	case ClassLocked:
+		return "SQLITE_LOCKED"
+	default:
+		return "none"
+	}
+}
+
+// Pragmas holds the per-connection settings applied by every DSN built by this package.
+type Pragmas struct {
+	// BusyTimeout is how long a connection waits on a locked database before failing.
+	BusyTimeout time.Duration
+
+	// JournalMode is the SQLite journal mode (e.g. WAL, DELETE). Empty leaves the default.
+	JournalMode string
+
+	// Synchronous is the SQLite synchronous mode (e.g. NORMAL, FULL). Empty leaves the default.
+	Synchronous string
+
+	// ForeignKeys enables foreign key enforcement.
+	ForeignKeys bool
+
+	// QueryOnly opens the connection in read-only query mode.
+	QueryOnly bool
+}
+
+// Classify returns the contention class of err for the compiled-in driver.
+// Typed driver errors are inspected first; wrapped or stringified errors fall
+// back to matching the messages SQLite uses for these codes, so both drivers
+// classify identically.
+func Classify(err error) ErrorClass {
+	if err == nil {
+		return ClassNone
+	}
+
+	if code, ok := primaryCode(err); ok {
+		switch code & 0xff {
+		case codeBusy:
+			return ClassBusy
+		case codeLocked:
+			return ClassLocked
+		}
+		return ClassNone
+	}
+
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "SQLITE_BUSY"), strings.Contains(msg, "database is locked"):
+		return ClassBusy
+	case strings.Contains(msg, "SQLITE_LOCKED"), strings.Contains(msg, "database table is locked"):
+		return ClassLocked
+	}
+	return ClassNone
+}
+
+// IsBusy reports whether err is a SQLITE_BUSY error.
+func IsBusy(err error) bool {
+	return Classify(err) == ClassBusy
+}
+
+// IsLocked reports whether err is a SQLITE_LOCKED error.
+func IsLocked(err error) bool {
+	return Classify(err) == ClassLocked
+}
+
+// IsContention reports whether err is a SQLITE_BUSY or SQLITE_LOCKED error,
+// i.e. whether retrying the operation later may succeed.
+func IsContention(err error) bool {
+	return Classify(err) != ClassNone
+}
+
+// timeLayouts are the layouts either driver writes time.Time values in:
+// modernc stores time.Time.String(), mattn the first SQLite timestamp format.
+// The remaining layouts cover timestamps written as plain SQLite text.
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// ParseTime parses a stored time.Time value that was read back as text.
+// Columns are only converted to time.Time by the driver when they are selected
+// directly; aggregates such as MAX(timestamp) lose the declared type and come
+// back as the stored string.
+func ParseTime(s string) (time.Time, error) {
+	// Drop the monotonic clock reading time.Time.String() appends
+	if i := strings.Index(s, " m="); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.TrimSuffix(strings.TrimSpace(s), "Z")
+
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time format %q", s)
+}
+
+// asCode unwraps err looking for a driver error exposing a numeric code.
+func asCode[T error](err error, code func(T) int) (int, bool) {
+	var target T
+	if errors.As(err, &target) {
+		return code(target), true
+	}
+	return 0, false
+}
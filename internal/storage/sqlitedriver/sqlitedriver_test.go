@@ -0,0 +1,188 @@
+package sqlitedriver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestClassify_Messages(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorClass
+	}{
+		{"nil", nil, ClassNone},
+		{"unrelated", errors.New("no such table: matches"), ClassNone},
+		{"busy message", errors.New("database is locked (5) (SQLITE_BUSY)"), ClassBusy},
+		{"wrapped busy", fmt.Errorf("insert failed: %w", errors.New("database is locked")), ClassBusy},
+		{"locked message", errors.New("database table is locked"), ClassLocked},
+		{"locked code name", errors.New("SQLITE_LOCKED: shared cache"), ClassLocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.err); got != tt.want {
+				t.Errorf("Classify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassify_DriverBusyError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "busy.db")
+	dsn := DSN(path, Pragmas{JournalMode: "DELETE"})
+
+	holder, err := sql.Open(Name, dsn)
+	if err != nil {
+		t.Fatalf("open holder: %v", err)
+	}
+	defer func() { _ = holder.Close() }()
+
+	if _, err := holder.Exec("CREATE TABLE t (id INTEGER)"); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	ctx := context.Background()
+	lockConn, err := holder.Conn(ctx)
+	if err != nil {
+		t.Fatalf("conn: %v", err)
+	}
+	defer func() { _ = lockConn.Close() }()
+	if _, err := lockConn.ExecContext(ctx, "BEGIN EXCLUSIVE"); err != nil {
+		t.Fatalf("begin exclusive: %v", err)
+	}
+	defer func() { _, _ = lockConn.ExecContext(ctx, "ROLLBACK") }()
+
+	other, err := sql.Open(Name, DSN(path, Pragmas{BusyTimeout: time.Millisecond}))
+	if err != nil {
+		t.Fatalf("open other: %v", err)
+	}
+	defer func() { _ = other.Close() }()
+
+	_, err = other.Exec("INSERT INTO t (id) VALUES (1)")
+	if err == nil {
+		t.Fatal("expected busy error while exclusive lock is held")
+	}
+	if !IsBusy(err) {
+		t.Errorf("IsBusy(%v) = false, want true", err)
+	}
+	if !IsContention(fmt.Errorf("wrapped: %w", err)) {
+		t.Errorf("IsContention should see through wrapping")
+	}
+}
+
+func TestDSN_AppliesPragmas(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pragmas.db")
+	db, err := sql.Open(Name, DSN(path, Pragmas{
+		BusyTimeout: 2 * time.Second,
+		JournalMode: "WAL",
+		ForeignKeys: true,
+	}))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	var journal string
+	if err := db.QueryRow("PRAGMA journal_mode").Scan(&journal); err != nil {
+		t.Fatalf("journal_mode: %v", err)
+	}
+	if journal != "wal" {
+		t.Errorf("journal_mode = %q, want wal", journal)
+	}
+
+	var fk int
+	if err := db.QueryRow("PRAGMA foreign_keys").Scan(&fk); err != nil {
+		t.Fatalf("foreign_keys: %v", err)
+	}
+	if fk != 1 {
+		t.Errorf("foreign_keys = %d, want 1", fk)
+	}
+
+	var timeout int
+	if err := db.QueryRow("PRAGMA busy_timeout").Scan(&timeout); err != nil {
+		t.Fatalf("busy_timeout: %v", err)
+	}
+	if timeout != 2000 {
+		t.Errorf("busy_timeout = %d, want 2000", timeout)
+	}
+}
+
+func TestMathFunctions(t *testing.T) {
+	db, err := sql.Open(Name, ":memory:")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	var root, ratio float64
+	if err := db.QueryRow("SELECT SQRT(16), log(8) / log(2)").Scan(&root, &ratio); err != nil {
+		t.Fatalf("math query: %v", err)
+	}
+	if root != 4 {
+		t.Errorf("SQRT(16) = %v, want 4", root)
+	}
+	if ratio < 2.999 || ratio > 3.001 {
+		t.Errorf("log(8)/log(2) = %v, want 3", ratio)
+	}
+}
+
+func TestParseTime_AggregateOfStoredTime(t *testing.T) {
+	db, err := sql.Open(Name, ":memory:")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	if _, err := db.Exec("CREATE TABLE events (at DATETIME)"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	first := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	last := time.Date(2026, 1, 9, 10, 30, 0, 0, time.UTC)
+	for _, at := range []time.Time{first, last} {
+		if _, err := db.Exec("INSERT INTO events (at) VALUES (?)", at); err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+
+	var text string
+	if err := db.QueryRow("SELECT MAX(at) FROM events").Scan(&text); err != nil {
+		t.Fatalf("max query: %v", err)
+	}
+	got, err := ParseTime(text)
+	if err != nil {
+		t.Fatalf("ParseTime(%q): %v", text, err)
+	}
+	if !got.Equal(last) {
+		t.Errorf("ParseTime(%q) = %v, want %v", text, got, last)
+	}
+}
+
+func TestParseTime_Formats(t *testing.T) {
+	want := time.Date(2026, 1, 9, 10, 30, 0, 0, time.UTC)
+	for _, s := range []string{
+		"2026-01-09 10:30:00 +0000 UTC",
+		"2026-01-09 10:30:00 +0000 UTC m=+0.000123",
+		"2026-01-09 10:30:00+00:00",
+		"2026-01-09T10:30:00Z",
+		"2026-01-09 10:30:00",
+	} {
+		got, err := ParseTime(s)
+		if err != nil {
+			t.Errorf("ParseTime(%q): %v", s, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseTime(%q) = %v, want %v", s, got, want)
+		}
+	}
+
+	if _, err := ParseTime("yesterday"); err == nil {
+		t.Error("ParseTime(\"yesterday\") should fail")
+	}
+}
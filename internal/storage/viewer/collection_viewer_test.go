@@ -7,13 +7,13 @@ import (
 	"time"
 
 	"github.com/ramonehamilton/MTGA-Companion/internal/mtga/cards"
-	_ "modernc.org/sqlite"
+	"github.com/ramonehamilton/MTGA-Companion/internal/storage/sqlitedriver"
 )
 
 func setupTestDBForViewer(t *testing.T) (*sql.DB, *cards.Service) {
 	t.Helper()
 
-	db, err := sql.Open("sqlite", ":memory:")
+	db, err := sql.Open(sqlitedriver.Name, ":memory:")
 	if err != nil {
 		t.Fatalf("Failed to create test database: %v", err)
 	}